@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+)
+
+// minAdminPasswordLength matches the length ADMIN_PASSWORD is checked for on startup
+const minAdminPasswordLength = 8
+
+// createAdmin creates an administrator account. The password is read from ADMIN_PASSWORD unless given as a flag, so
+// it needn't end up in the shell history.
+func createAdmin(args []string) error {
+	fs, flags := newFlagSet("create-admin")
+	username := fs.String("username", "", "username of the account (required)")
+	email := fs.String("email", "", "email address of the account (required)")
+	password := fs.String("password", "", "password of the account (default $ADMIN_PASSWORD)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := flags.Load()
+	if err != nil {
+		return err
+	}
+
+	if *password == "" {
+		*password = os.Getenv("ADMIN_PASSWORD")
+	}
+	switch {
+	case *username == "" || *email == "":
+		return errors.New("-username and -email are required")
+	case len(*password) < minAdminPasswordLength:
+		return fmt.Errorf("the password must be at least %d characters", minAdminPasswordLength)
+	}
+	if err := connect(cfg); err != nil {
+		return err
+	}
+
+	authService := services.NewAuthService(repositories.NewUserRepository())
+	user, err := authService.CreateAdmin(context.Background(), *username, *email, *password)
+	if err != nil {
+		return fmt.Errorf("error creating admin user: %w", err)
+	}
+	fmt.Printf("Created admin %s (%s)\n", user.Username, user.ID)
+	return nil
+}
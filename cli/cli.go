@@ -0,0 +1,106 @@
+// Package cli runs the service's commands: serving the API and the operational tasks that need its database and
+// configuration without starting the server.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"leaderboard-service/config"
+	"leaderboard-service/db"
+	"leaderboard-service/startup"
+)
+
+// command is a subcommand, run with the arguments that follow its name
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+func commands() []command {
+	return []command{
+		{"serve", "Migrate the database and serve the API (the default)", serve},
+		{"migrate", "Apply migrations (up), print what applying them would do (plan) or roll back (down N)", migrate},
+		{"create-admin", "Create an administrator account", createAdmin},
+		{"token", "Issue a service token (issue)", token},
+		{"seed", "Load demo leaderboards, metrics, participants and metric values", seedData},
+	}
+}
+
+// Run runs the command named by the first argument. Without one, or when the arguments start with a flag, the API
+// is served, so the binary still starts the way it did before it had commands.
+func Run(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return ignoreHelp(serve(args))
+	}
+
+	for _, cmd := range commands() {
+		if cmd.name == args[0] {
+			return ignoreHelp(cmd.run(args[1:]))
+		}
+	}
+	if args[0] == "help" {
+		usage()
+		return nil
+	}
+	usage()
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: leaderboard-service <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, cmd := range commands() {
+		fmt.Fprintf(os.Stderr, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun leaderboard-service <command> -h for the flags of a command.")
+}
+
+// ignoreHelp treats -h as success, the flag set having printed its usage already
+func ignoreHelp(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return err
+}
+
+// newFlagSet returns the flags of a command, with the configuration flags every command takes
+func newFlagSet(name string) (*flag.FlagSet, *config.Flags) {
+	fs := flag.NewFlagSet("leaderboard-service "+name, flag.ContinueOnError)
+	return fs, config.RegisterFlags(fs)
+}
+
+// connect verifies the settings and connects to the database, reporting every problem at once
+func connect(cfg *config.Config) error {
+	report := &startup.Report{}
+	report.Run(startup.ConfigChecks()...)
+	report.Run(startup.PoolCheck(func() error {
+		if _, err := db.PoolConfigFromEnv(); err != nil {
+			return err
+		}
+		_, err := db.DriverConfigFromEnv()
+		return err
+	}))
+	if cfg.Database.URL != "" {
+		report.Run(startup.DatabaseCheck(func() error {
+			return db.InitDB(cfg.Database.URL)
+		}))
+	}
+	return report.Err()
+}
+
+// subcommand returns the command's subcommand and the arguments after it, or an error listing the valid ones
+func subcommand(name string, args []string, valid ...string) (string, []string, error) {
+	if len(args) > 0 {
+		for _, sub := range valid {
+			if args[0] == sub {
+				return sub, args[1:], nil
+			}
+		}
+	}
+	return "", nil, fmt.Errorf("usage: leaderboard-service %s %s [flags]", name, strings.Join(valid, "|"))
+}
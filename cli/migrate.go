@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"leaderboard-service/db"
+	"leaderboard-service/db/migrations"
+)
+
+// migrate applies the pending migrations (up), prints what applying them would do (plan), or rolls back the latest
+// N applied migrations, one unless N is given (down N)
+func migrate(args []string) error {
+	sub, args, err := subcommand("migrate", args, "up", "plan", "down")
+	if err != nil {
+		return err
+	}
+	steps := 1
+	if sub == "down" && len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		if steps, err = strconv.Atoi(args[0]); err != nil || steps <= 0 {
+			return errors.New("usage: leaderboard-service migrate down [N], N being a positive number of migrations")
+		}
+		args = args[1:]
+	}
+	fs, flags := newFlagSet("migrate " + sub)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := flags.Load()
+	if err != nil {
+		return err
+	}
+	if err := connect(cfg); err != nil {
+		return err
+	}
+
+	switch sub {
+	case "plan":
+		return printPlan()
+	case "down":
+		if err := migrations.Rollback(db.DB, steps); err != nil {
+			return fmt.Errorf("error rolling back migrations: %w", err)
+		}
+		return nil
+	}
+	migrations.DeleteOrphanedRows = cfg.Features.MigrateDeleteOrphans
+	if err := migrations.Migrate(db.DB, schemaModels...); err != nil {
+		return fmt.Errorf("error migrating database: %w", err)
+	}
+	return nil
+}
+
+// printPlan prints what migrating would do, failing when migrating would
+func printPlan() error {
+	plan, err := migrations.PlanMigrations(db.DB, schemaModels...)
+	if err != nil {
+		return fmt.Errorf("error planning migrations: %w", err)
+	}
+	plan.Print(os.Stdout)
+	if len(plan.Changed) > 0 {
+		return errors.New("applied migrations were changed")
+	}
+	return nil
+}
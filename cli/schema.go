@@ -0,0 +1,36 @@
+package cli
+
+import "leaderboard-service/models"
+
+// schemaModels lists every model stored in a table. A database created by auto-migration before versioned migrations
+// is brought up to them before it is adopted.
+var schemaModels = []interface{}{
+	&models.Leaderboard{},
+	&models.LeaderboardMetric{},
+	&models.LeaderboardEntry{},
+	&models.Participant{},
+	&models.Metric{},
+	&models.MetricValue{},
+	&models.WebhookSubscription{},
+	&models.Event{},
+	&models.DeviceToken{},
+	&models.User{},
+	&models.APIKey{},
+	&models.LeaderboardMember{},
+	&models.LeaderboardParticipantAccess{},
+	&models.LoginAttempt{},
+	&models.PasswordResetToken{},
+	&models.ServiceToken{},
+	&models.TeamMember{},
+	&models.ParticipantNameChange{},
+	&models.ParticipantAnonymization{},
+	&models.ParticipantTag{},
+	&models.LeaderboardSnapshot{},
+	&models.LeaderboardSnapshotEntry{},
+	&models.StandingSummary{},
+	&models.StandingAggregate{},
+	&models.MetricValueRollup{},
+	&models.AppliedScoreIncrement{},
+	&models.AuditLog{},
+	&models.FeatureFlag{},
+}
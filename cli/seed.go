@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"leaderboard-service/repositories"
+	"leaderboard-service/seed"
+	"leaderboard-service/services"
+)
+
+// seedData loads a fixture of demo data, the one built in unless -file names a YAML or JSON one
+func seedData(args []string) error {
+	fs, flags := newFlagSet("seed")
+	file := fs.String("file", "", "YAML or JSON fixture to load (default the built-in demo fixture)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := flags.Load()
+	if err != nil {
+		return err
+	}
+
+	fixture, err := seed.Demo()
+	if *file != "" {
+		fixture, err = seed.LoadFile(*file)
+	}
+	if err != nil {
+		return err
+	}
+	if err := connect(cfg); err != nil {
+		return err
+	}
+
+	leaderboardRepo := repositories.NewLeaderboardRepository()
+	entryRepo := repositories.NewLeaderboardEntryRepository()
+	metricRepo := repositories.NewMetricRepository()
+	participantRepo := repositories.NewParticipantRepository()
+	valueRepo := repositories.NewMetricValueRepository()
+	unitOfWork := repositories.NewUnitOfWork()
+	seeder := seed.NewSeeder(
+		services.NewMetricService(metricRepo),
+		services.NewParticipantService(participantRepo, repositories.NewParticipantNameChangeRepository(), entryRepo),
+		services.NewLeaderboardService(leaderboardRepo, participantRepo, unitOfWork),
+		services.NewMetricValueService(valueRepo, metricRepo, participantRepo),
+		services.NewLeaderboardEntryService(entryRepo, leaderboardRepo, participantRepo,
+			repositories.NewScoreIncrementRepository(), unitOfWork),
+		services.NewStandingsService(leaderboardRepo, entryRepo, metricRepo, valueRepo, repositories.NewEventRepository()),
+		entryRepo,
+	)
+
+	result, err := seeder.Load(context.Background(), fixture, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("error seeding database: %w", err)
+	}
+	fmt.Printf("Seeded %d metrics, %d participants, %d metric values and %d leaderboards with %d entries\n",
+		result.Metrics, result.Participants, result.MetricValues, result.Leaderboards, result.Entries)
+	return nil
+}
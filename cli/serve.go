@@ -0,0 +1,301 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+
+	"leaderboard-service/db"
+	"leaderboard-service/db/migrations"
+	"leaderboard-service/enums"
+	"leaderboard-service/grpcserver"
+	"leaderboard-service/handlers"
+	"leaderboard-service/httpserver"
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/routes"
+	"leaderboard-service/services"
+	"leaderboard-service/startup"
+)
+
+// serve migrates the database, starts the background jobs and serves the API until the server fails
+func serve(args []string) error {
+	fs, flags := newFlagSet("serve")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := flags.Load()
+	if err != nil {
+		return err
+	}
+	if err := connect(cfg); err != nil {
+		return err
+	}
+
+	// With MIGRATE_DRY_RUN=true, print what migrating would do and exit without changing the database
+	if cfg.Features.MigrateDryRun {
+		return printPlan()
+	}
+
+	// Apply the versioned migrations the database hasn't had yet; when a database made by auto-migration is adopted,
+	// rows left without their leaderboard, participant or metric are only deleted on request
+	migrations.DeleteOrphanedRows = cfg.Features.MigrateDeleteOrphans
+	if err := migrations.Migrate(db.DB, schemaModels...); err != nil {
+		return fmt.Errorf("error migrating database: %w", err)
+	}
+
+	// Make sure every table the handlers rely on is actually present
+	report := &startup.Report{}
+	report.Run(startup.SchemaCheck(db.DB, schemaModels...))
+	if err := report.Err(); err != nil {
+		return err
+	}
+
+	// Export the connection pool's statistics on /metrics
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return fmt.Errorf("error getting database connection pool: %w", err)
+	}
+	services.RegisterDBStatsCollector(sqlDB)
+
+	// Keep leaderboards, metrics and participants found by ID in memory, or not at all with LOOKUP_CACHE_TTL=0
+	repositories.ConfigureLookupCache(cfg.Cache.LookupTTL)
+
+	// Reject JSON request bodies larger than MAX_REQUEST_BODY_BYTES
+	handlers.MaxBodyBytes = cfg.Server.MaxBodyBytes
+
+	// Issue tokens valid for JWT_EXPIRATION_HOURS, and let shared caches keep public leaderboards for HTTP_CACHE_MAX_AGE
+	middleware.TokenExpiration = cfg.JWT.Expiration
+	middleware.CacheMaxAge = cfg.Cache.HTTPMaxAge
+
+	// Create the administrator account on first start
+	if adminUsername := os.Getenv("ADMIN_USERNAME"); adminUsername != "" {
+		authService := services.NewAuthService(repositories.NewUserRepository())
+		_, err := authService.EnsureAdmin(context.Background(), adminUsername, os.Getenv("ADMIN_EMAIL"), os.Getenv("ADMIN_PASSWORD"))
+		if err != nil {
+			return fmt.Errorf("error creating admin user: %w", err)
+		}
+	}
+
+	// Start feature flags with their FEATURE_<NAME> values, overridden by those admins set at runtime, and pick up
+	// changes made through other instances every FEATURE_FLAG_REFRESH_INTERVAL
+	featureFlags := services.NewFeatureFlags(repositories.NewFeatureFlagRepository(), cfg.Features.Flags)
+	if err := featureFlags.Load(context.Background()); err != nil {
+		return fmt.Errorf("error loading feature flags: %w", err)
+	}
+	services.EnableFeatureFlags(featureFlags)
+	go featureFlags.Run(cfg.Features.FlagRefreshInterval)
+
+	// Record changes made through the services, with the caller who made them, in the audit log
+	services.EnableAuditLog(repositories.NewAuditLogRepository(), middleware.GetAuditActor)
+
+	// Persist domain events to the durable log and deliver them to webhook subscribers
+	services.RegisterEventPublisher(services.NewEventLogService(repositories.NewEventRepository()))
+	services.RegisterEventPublisher(services.NewWebhookDispatcher(repositories.NewWebhookSubscriptionRepository()))
+
+	// Roll member metric values up into team leaderboard scores
+	services.RegisterEventPublisher(services.NewTeamScoreService(
+		repositories.NewLeaderboardRepository(),
+		repositories.NewMetricRepository(),
+		repositories.NewMetricValueRepository(),
+		repositories.NewTeamMemberRepository(),
+		repositories.NewLeaderboardEntryRepository(),
+		repositories.NewParticipantRepository(),
+	))
+
+	// Optionally publish domain events to NATS JetStream
+	if natsURL := os.Getenv("NATS_URL"); natsURL != "" {
+		natsPublisher, err := services.NewNATSPublisher(services.NATSPublisherConfig{
+			URL:           natsURL,
+			SubjectPrefix: os.Getenv("NATS_SUBJECT_PREFIX"),
+			Stream:        os.Getenv("NATS_STREAM"),
+		})
+		if err != nil {
+			return fmt.Errorf("error connecting to NATS: %w", err)
+		}
+		services.RegisterEventPublisher(natsPublisher)
+		fmt.Println("Publishing events to NATS at", natsURL)
+	}
+
+	// Optionally cache the standings of public leaderboards in Redis, dropped whenever their entries change
+	if cfg.Cache.RedisURL != "" {
+		standingsCache, err := services.NewStandingsCache(services.StandingsCacheConfig{
+			URL:       cfg.Cache.RedisURL,
+			KeyPrefix: cfg.Cache.StandingsPrefix,
+			TTL:       cfg.Cache.StandingsTTL,
+		})
+		if err != nil {
+			return fmt.Errorf("error connecting to Redis: %w", err)
+		}
+		services.EnableStandingsCache(standingsCache)
+		services.RegisterEventPublisher(standingsCache)
+		fmt.Println("Caching standings in Redis")
+	}
+
+	// Recompute leaderboards in the background jobs on a bounded pool of workers
+	services.ConfigureRecomputeWorkers(cfg.Features.RecomputeWorkers)
+
+	// Optionally read standings from a summary of the aggregated metric values, rebuilt in the background as they change
+	if cfg.Features.StandingsSummary {
+		interval := cfg.Features.StandingsSummaryInterval
+		standingsSummary := services.NewStandingsSummary(
+			repositories.NewStandingSummaryRepository(),
+			repositories.NewLeaderboardRepository(),
+		)
+		services.EnableStandingsSummary(standingsSummary)
+		services.RegisterEventPublisher(standingsSummary)
+		go standingsSummary.Run(interval)
+		fmt.Println("Summarizing standings every", interval)
+	}
+
+	// Optionally buffer score increments in memory and apply them together on an interval
+	if cfg.Features.ScoreBuffer {
+		interval := cfg.Features.ScoreBufferInterval
+		scoreBuffer := services.NewScoreBuffer(
+			repositories.NewScoreIncrementRepository(),
+			repositories.NewLeaderboardEntryRepository(),
+			repositories.NewLeaderboardRepository(),
+		)
+		services.EnableScoreBuffer(scoreBuffer)
+		go scoreBuffer.Run(interval)
+		fmt.Println("Applying buffered score increments every", interval)
+	}
+
+	// Optionally compact raw metric values older than a number of days into daily rollups
+	if age := cfg.Features.MetricValueRollupAge; age > 0 {
+		interval := cfg.Features.MetricValueRollupEvery
+		compactor := services.NewMetricValueCompactor(repositories.NewMetricValueRollupRepository(), age)
+		go compactor.Run(interval)
+		fmt.Printf("Rolling up metric values older than %d days every %s\n", int(age.Hours()/24), interval)
+	}
+
+	// Send push notifications on rank changes for every configured provider
+	pushNotifiers := map[enums.PushProvider]services.PushNotifier{}
+	if projectID := os.Getenv("FCM_PROJECT_ID"); projectID != "" {
+		pushNotifiers[enums.FCM] = services.NewFCMNotifier(services.FCMConfig{
+			ProjectID:       projectID,
+			CredentialsFile: os.Getenv("FCM_CREDENTIALS_FILE"),
+		})
+	}
+	if topic := os.Getenv("APNS_TOPIC"); topic != "" {
+		pushNotifiers[enums.APNs] = services.NewAPNsNotifier(services.APNsConfig{
+			KeyID:      os.Getenv("APNS_KEY_ID"),
+			TeamID:     os.Getenv("APNS_TEAM_ID"),
+			Topic:      topic,
+			KeyFile:    os.Getenv("APNS_KEY_FILE"),
+			Production: os.Getenv("APNS_PRODUCTION") == "true",
+		})
+	}
+	if len(pushNotifiers) > 0 {
+		services.RegisterEventPublisher(services.NewRankNotifier(
+			repositories.NewDeviceTokenRepository(),
+			repositories.NewLeaderboardEntryRepository(),
+			pushNotifiers,
+		))
+	}
+
+	// Deliver password reset emails over SMTP. Without a relay emails are only logged, without their body, which
+	// development setups have to ask for with EMAIL_LOG_ONLY=true
+	resetConfig := services.PasswordResetConfig{ResetURL: os.Getenv("PASSWORD_RESET_URL")}
+	smtpHost := os.Getenv("SMTP_HOST")
+	if smtpHost == "" && os.Getenv("EMAIL_LOG_ONLY") != "true" {
+		return errors.New("SMTP_HOST is not set; set it to send password reset emails, or EMAIL_LOG_ONLY=true in development")
+	}
+	if smtpHost != "" {
+		resetConfig.Sender = services.NewSMTPEmailSender(services.SMTPConfig{
+			Host:     smtpHost,
+			Port:     os.Getenv("SMTP_PORT"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		})
+	}
+	services.ConfigurePasswordReset(resetConfig)
+
+	// Load the keys the service signs its own tokens with
+	if err := middleware.LoadSigningKeys(); err != nil {
+		return fmt.Errorf("error loading JWT signing keys: %w", err)
+	}
+
+	// Accept service tokens until they are revoked or expire
+	middleware.EnableServiceTokens(services.NewServiceTokenService(repositories.NewServiceTokenRepository(),
+		middleware.GenerateServiceToken))
+
+	// Optionally accept tokens issued by an external OIDC identity provider
+	if issuerURL := os.Getenv("OIDC_ISSUER_URL"); issuerURL != "" {
+		roleMapping, err := middleware.ParseRoleMapping(os.Getenv("OIDC_ROLE_MAPPING"))
+		if err != nil {
+			return fmt.Errorf("error parsing OIDC_ROLE_MAPPING: %w", err)
+		}
+		provider, err := middleware.NewOIDCProvider(middleware.OIDCConfig{
+			IssuerURL:   issuerURL,
+			Audience:    os.Getenv("OIDC_AUDIENCE"),
+			RolesClaim:  os.Getenv("OIDC_ROLES_CLAIM"),
+			RoleMapping: roleMapping,
+			DefaultRole: middleware.Role(os.Getenv("OIDC_DEFAULT_ROLE")),
+		})
+		if err != nil {
+			return fmt.Errorf("error configuring OIDC: %w", err)
+		}
+		middleware.EnableOIDC(provider)
+		fmt.Println("Accepting tokens issued by", issuerURL)
+	}
+
+	// Only believe the client addresses forwarded by these proxies when throttling logins
+	middleware.TrustedProxies = cfg.Server.TrustedProxies
+
+	// Optionally log the full request and response of a sample of requests, to debug integrators' payloads
+	if cfg.Features.DebugLogSampleRate > 0 {
+		middleware.EnableDebugLog(middleware.DebugLogConfig{
+			SampleRate:   cfg.Features.DebugLogSampleRate,
+			MaxBodyBytes: cfg.Features.DebugLogMaxBodyBytes,
+		})
+		fmt.Printf("Logging %g%% of requests and responses in full\n", cfg.Features.DebugLogSampleRate*100)
+	}
+
+	// Optionally serve CPU, heap and goroutine profiles to admins
+	if cfg.Features.Pprof {
+		router.EnableProfiling()
+		fmt.Println("Serving pprof profiles at /api/v1/admin/debug/pprof/")
+	}
+
+	// Cache the standings of the most-read leaderboards before serving, so a deploy does not send every first read to Postgres
+	if cfg.Cache.RedisURL != "" {
+		leaderboardRepo := repositories.NewLeaderboardRepository()
+		services.WarmStandingsCache(services.NewStandingsService(
+			leaderboardRepo,
+			repositories.NewLeaderboardEntryRepository(),
+			repositories.NewMetricRepository(),
+			repositories.NewMetricValueRepository(),
+			repositories.NewEventRepository(),
+		), leaderboardRepo, cfg.Cache.StandingsWarm)
+	}
+
+	r := router.Router()
+
+	// Serve the gRPC API for internal services alongside HTTP when a port is configured
+	if cfg.Server.GRPCPort != 0 {
+		listener, err := net.Listen("tcp", cfg.Server.GRPCAddr())
+		if err != nil {
+			return fmt.Errorf("error starting gRPC server: %w", err)
+		}
+		go func() {
+			log.Fatal(grpcserver.NewServer().Serve(listener))
+		}()
+		fmt.Println("gRPC server is running on", cfg.Server.GRPCAddr())
+	}
+
+	scheme := "http"
+	if cfg.Server.TLS.Enabled() {
+		scheme = "https"
+	}
+	fmt.Printf("Server is running on %s://%s\n", scheme, cfg.Server.Addr())
+	if cfg.Server.TLS.RedirectPort != 0 {
+		fmt.Println("Redirecting HTTP to HTTPS on port", cfg.Server.TLS.RedirectPort)
+	}
+	fmt.Printf("Swagger UI is available at %s://%s/swagger/index.html\n", scheme, cfg.Server.Addr())
+	return httpserver.ListenAndServe(cfg.Server, r)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+)
+
+// defaultServiceTokenLifetime is how long an issued service token is valid unless -expires-in is given
+const defaultServiceTokenLifetime = 90 * 24 * time.Hour
+
+// token issues service tokens (issue), recorded in the inventory like those minted through the API so they can be
+// listed and revoked there. The signed token is printed once, on stdout.
+func token(args []string) error {
+	sub, args, err := subcommand("token", args, "issue")
+	if err != nil {
+		return err
+	}
+	fs, flags := newFlagSet("token " + sub)
+	name := fs.String("name", "", "name of the calling service (required)")
+	scopes := fs.String("scopes", "", "comma separated scopes to grant (required)")
+	audience := fs.String("audience", middleware.TokenIssuer, "comma separated services the token may call")
+	expiresIn := fs.Duration("expires-in", defaultServiceTokenLifetime, "how long the token is valid, at most two years")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := flags.Load()
+	if err != nil {
+		return err
+	}
+
+	if *name == "" {
+		return errors.New("-name is required")
+	}
+	granted := splitList(*scopes)
+	if len(granted) == 0 {
+		return errors.New("-scopes is required")
+	}
+	for _, scope := range granted {
+		if !slices.Contains(middleware.GetValidScopes(), scope) {
+			return fmt.Errorf("unknown scope %s", scope)
+		}
+	}
+
+	if err := connect(cfg); err != nil {
+		return err
+	}
+	if err := middleware.LoadSigningKeys(); err != nil {
+		return fmt.Errorf("error loading JWT signing keys: %w", err)
+	}
+	service := services.NewServiceTokenService(repositories.NewServiceTokenRepository(), middleware.GenerateServiceToken)
+	serviceToken, signed, err := service.CreateServiceToken(context.Background(), *name, splitList(*audience), granted,
+		time.Now().Add(*expiresIn), nil)
+	if err != nil {
+		return fmt.Errorf("error issuing service token: %w", err)
+	}
+
+	fmt.Fprintf(fs.Output(), "Issued service token %s for %s, expiring %s\n", serviceToken.ID, serviceToken.Name,
+		serviceToken.ExpiresAt.Format(time.RFC3339))
+	fmt.Println(signed)
+	return nil
+}
+
+func splitList(value string) []string {
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
@@ -0,0 +1,413 @@
+// Package config loads the service's settings from flags, the environment and a config file, with defaults for
+// those not set.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"leaderboard-service/enums"
+
+	"github.com/joho/godotenv"
+)
+
+// Server defaults, used unless HOST and PORT are set
+const (
+	DefaultHost = "localhost"
+	DefaultPort = 8080
+)
+
+// Defaults of the other settings, the same as those of the packages main passes them to
+const (
+	DefaultMaxBodyBytes               = 1 << 20
+	DefaultTokenExpiration            = 24 * time.Hour
+	DefaultLookupCacheTTL             = 30 * time.Second
+	DefaultStandingsCacheTTL          = 5 * time.Minute
+	DefaultStandingsCacheWarm         = 50
+	DefaultStandingsSummaryInterval   = 10 * time.Second
+	DefaultScoreBufferInterval        = 5 * time.Second
+	DefaultMetricValueRollupInterval  = time.Hour
+	DefaultRecomputeWorkers           = 4
+	DefaultDebugLogMaxBodyBytes       = 64 << 10
+	DefaultFeatureFlagRefreshInterval = 10 * time.Second
+)
+
+// DefaultAutocertCacheDir keeps the certificates obtained from Let's Encrypt unless TLS_AUTOCERT_CACHE_DIR is set
+const DefaultAutocertCacheDir = "autocert-cache"
+
+// DefaultConfigFile is read when it exists and no other config file is named
+const DefaultConfigFile = ".env"
+
+// Config holds the settings the service starts with
+type Config struct {
+	Server   ServerConfig
+	Database DatabaseConfig
+	JWT      JWTConfig
+	Cache    CacheConfig
+	Features FeatureConfig
+}
+
+// ServerConfig sets where the HTTP and gRPC APIs listen
+type ServerConfig struct {
+	Host         string // Interface both APIs listen on, e.g. 0.0.0.0 in a container
+	Port         int
+	GRPCPort     int   // 0 serves only the HTTP API
+	MaxBodyBytes int64 // Largest JSON request body accepted
+	// TrustedProxies are the proxies whose X-Forwarded-For and X-Real-IP headers are believed, none by default
+	TrustedProxies []*net.IPNet
+	TLS            TLSConfig
+}
+
+// TLSConfig sets how the HTTP API terminates TLS itself, for deployments without a proxy in front. It is served over
+// plain HTTP when neither a certificate nor autocert domains are set.
+type TLSConfig struct {
+	CertFile string // PEM certificate chain, with KeyFile
+	KeyFile  string
+	// AutocertDomains get certificates from Let's Encrypt instead, obtained on their first request and renewed
+	// before they expire. Requests for other hosts are refused.
+	AutocertDomains  []string
+	AutocertCacheDir string // Where obtained certificates are kept across restarts
+	AutocertEmail    string // Let's Encrypt contact for expiry and problem notices
+	// RedirectPort serves plain HTTP that redirects to HTTPS, and answers Let's Encrypt's HTTP challenges; 0 disables
+	RedirectPort int
+}
+
+// Enabled reports whether the HTTP API is served over TLS
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || len(c.AutocertDomains) > 0
+}
+
+// Addr is the HTTP API's listen address
+func (c ServerConfig) Addr() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+// GRPCAddr is the gRPC API's listen address
+func (c ServerConfig) GRPCAddr() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.GRPCPort))
+}
+
+// DatabaseConfig sets which Postgres database to connect to. The pool and driver settings are read from
+// DB_MAX_OPEN_CONNS and the other DB_ variables when connecting, and checked on startup.
+type DatabaseConfig struct {
+	URL string
+}
+
+// JWTConfig sets the tokens the service issues. The signing keys are read from JWT_SECRET, JWT_PRIVATE_KEY_FILE and
+// the other JWT_ variables when they are first needed, and checked on startup.
+type JWTConfig struct {
+	Expiration time.Duration // How long issued tokens are valid
+}
+
+// CacheConfig sets the in-process and Redis caches
+type CacheConfig struct {
+	LookupTTL       time.Duration // How long rows found by ID are kept in memory, 0 disables
+	HTTPMaxAge      time.Duration // How long shared caches may serve public leaderboards before revalidating
+	RedisURL        string        // Standings are cached in Redis when set
+	StandingsTTL    time.Duration
+	StandingsPrefix string
+	StandingsWarm   int // Most-read leaderboards cached on startup, 0 disables
+}
+
+// FeatureConfig turns the optional behaviours of the service on and off
+type FeatureConfig struct {
+	StandingsSummary         bool
+	StandingsSummaryInterval time.Duration
+	ScoreBuffer              bool
+	ScoreBufferInterval      time.Duration
+	MetricValueRollupAge     time.Duration // Raw metric values older than this are rolled up, 0 disables
+	MetricValueRollupEvery   time.Duration
+	RecomputeWorkers         int
+	Pprof                    bool
+	DebugLogSampleRate       float64 // Share of requests logged in full, 0 disables
+	DebugLogMaxBodyBytes     int
+	MigrateDryRun            bool // Print the migration plan and exit instead of serving
+	MigrateDeleteOrphans     bool // Delete rows left without their parent when adopting an auto-migrated database
+	// Flags holds the values feature flags start with, set by FEATURE_<NAME>, until an admin changes them
+	Flags               map[enums.FeatureFlag]bool
+	FlagRefreshInterval time.Duration
+}
+
+// flags maps each command line flag to the environment variable it overrides
+var flags = []struct {
+	name  string
+	env   string
+	usage string
+}{
+	{"host", "HOST", "interface to listen on (default " + DefaultHost + ")"},
+	{"port", "PORT", "HTTP port (default " + strconv.Itoa(DefaultPort) + ")"},
+	{"grpc-port", "GRPC_PORT", "gRPC port, unset to serve only HTTP"},
+	{"database-url", "DATABASE_URL", "Postgres connection URL"},
+}
+
+// Flags are the command line flags that override the environment
+type Flags struct {
+	fs         *flag.FlagSet
+	configFile *string
+	values     map[string]*string
+}
+
+// RegisterFlags adds -config and the flags of the settings to fs, for Load once fs is parsed. Commands add their
+// own flags to the same set.
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{
+		fs:         fs,
+		configFile: fs.String("config", "", "config file of KEY=value lines (default "+DefaultConfigFile+" when it exists)"),
+		values:     make(map[string]*string, len(flags)),
+	}
+	for _, setting := range flags {
+		f.values[setting.env] = fs.String(setting.name, "", setting.usage)
+	}
+	return f
+}
+
+// Load reads the settings. Flags override the environment, which overrides the config file: the file named by
+// -config or CONFIG_FILE, or .env when it exists. The file uses the KEY=value lines of a .env file and is optional,
+// so containers can be configured from the environment alone. Flags and file values are put into the environment,
+// so the packages that read their own variables see the same settings. Every invalid value is reported at once.
+func (f *Flags) Load() (*Config, error) {
+	if err := loadFile(*f.configFile); err != nil {
+		return nil, err
+	}
+	f.fs.Visit(func(set *flag.Flag) {
+		for _, setting := range flags {
+			if setting.name == set.Name {
+				os.Setenv(setting.env, *f.values[setting.env])
+			}
+		}
+	})
+
+	return fromEnv()
+}
+
+// loadFile sets the variables of the config file that aren't already set. Only a missing default file is ignored.
+func loadFile(path string) error {
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path == "" {
+		if _, err := os.Stat(DefaultConfigFile); errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		path = DefaultConfigFile
+	}
+	if err := godotenv.Load(path); err != nil {
+		return fmt.Errorf("error loading config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func fromEnv() (*Config, error) {
+	p := &parser{}
+	config := &Config{
+		Server: ServerConfig{
+			Host:           p.str("HOST", DefaultHost),
+			Port:           p.port("PORT", DefaultPort),
+			GRPCPort:       p.port("GRPC_PORT", 0),
+			MaxBodyBytes:   int64(p.integer("MAX_REQUEST_BODY_BYTES", DefaultMaxBodyBytes, 1)),
+			TrustedProxies: p.networks("TRUSTED_PROXIES"),
+			TLS: TLSConfig{
+				CertFile:         os.Getenv("TLS_CERT_FILE"),
+				KeyFile:          os.Getenv("TLS_KEY_FILE"),
+				AutocertDomains:  p.list("TLS_AUTOCERT_DOMAINS"),
+				AutocertCacheDir: p.str("TLS_AUTOCERT_CACHE_DIR", DefaultAutocertCacheDir),
+				AutocertEmail:    os.Getenv("TLS_AUTOCERT_EMAIL"),
+				RedirectPort:     p.port("TLS_REDIRECT_PORT", 0),
+			},
+		},
+		Database: DatabaseConfig{
+			URL: os.Getenv("DATABASE_URL"),
+		},
+		JWT: JWTConfig{
+			Expiration: p.hours("JWT_EXPIRATION_HOURS", DefaultTokenExpiration),
+		},
+		Cache: CacheConfig{
+			LookupTTL:       p.seconds("LOOKUP_CACHE_TTL", DefaultLookupCacheTTL, 0),
+			HTTPMaxAge:      p.seconds("HTTP_CACHE_MAX_AGE", 0, 0),
+			RedisURL:        os.Getenv("REDIS_URL"),
+			StandingsTTL:    p.seconds("STANDINGS_CACHE_TTL", DefaultStandingsCacheTTL, 1),
+			StandingsPrefix: os.Getenv("STANDINGS_CACHE_PREFIX"),
+			StandingsWarm:   p.integer("STANDINGS_CACHE_WARM", DefaultStandingsCacheWarm, 0),
+		},
+		Features: FeatureConfig{
+			StandingsSummary:         p.boolean("STANDINGS_SUMMARY"),
+			StandingsSummaryInterval: p.seconds("STANDINGS_SUMMARY_INTERVAL", DefaultStandingsSummaryInterval, 1),
+			ScoreBuffer:              p.boolean("SCORE_BUFFER"),
+			ScoreBufferInterval:      p.seconds("SCORE_BUFFER_INTERVAL", DefaultScoreBufferInterval, 1),
+			MetricValueRollupAge:     time.Duration(p.integer("METRIC_VALUE_ROLLUP_AGE", 0, 1)) * 24 * time.Hour,
+			MetricValueRollupEvery:   p.seconds("METRIC_VALUE_ROLLUP_INTERVAL", DefaultMetricValueRollupInterval, 1),
+			RecomputeWorkers:         p.integer("RECOMPUTE_WORKERS", DefaultRecomputeWorkers, 1),
+			Pprof:                    p.boolean("PPROF_ENABLED"),
+			DebugLogSampleRate:       p.fraction("DEBUG_LOG_SAMPLE_RATE"),
+			DebugLogMaxBodyBytes:     p.integer("DEBUG_LOG_MAX_BODY_BYTES", DefaultDebugLogMaxBodyBytes, 1),
+			MigrateDryRun:            p.boolean("MIGRATE_DRY_RUN"),
+			MigrateDeleteOrphans:     p.boolean("MIGRATE_DELETE_ORPHANS"),
+			Flags:                    p.featureFlags(),
+			FlagRefreshInterval:      p.seconds("FEATURE_FLAG_REFRESH_INTERVAL", DefaultFeatureFlagRefreshInterval, 1),
+		},
+	}
+
+	p.verifyTLS(config.Server)
+
+	if len(p.errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration:\n%w", errors.Join(p.errs...))
+	}
+	return config, nil
+}
+
+// parser reads typed variables, collecting an error for each invalid one instead of stopping at the first
+type parser struct {
+	errs []error
+}
+
+func (p *parser) str(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// integer reads a whole number of at least min
+func (p *parser) integer(name string, fallback, min int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < min {
+		p.errs = append(p.errs, fmt.Errorf("%s: %q is not a whole number of at least %d", name, value, min))
+		return fallback
+	}
+	return parsed
+}
+
+// hours reads a positive whole number of hours
+func (p *parser) hours(name string, fallback time.Duration) time.Duration {
+	return time.Duration(p.integer(name, int(fallback/time.Hour), 1)) * time.Hour
+}
+
+// seconds reads a whole number of seconds, at least min
+func (p *parser) seconds(name string, fallback time.Duration, min int) time.Duration {
+	fallbackSeconds := int(fallback / time.Second)
+	return time.Duration(p.integer(name, fallbackSeconds, min)) * time.Second
+}
+
+// fraction reads a number from 0 to 1, 0 when unset
+func (p *parser) fraction(name string) float64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed < 0 || parsed > 1 {
+		p.errs = append(p.errs, fmt.Errorf("%s: %q is not a number from 0 to 1", name, value))
+		return 0
+	}
+	return parsed
+}
+
+func (p *parser) port(name string, fallback int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		p.errs = append(p.errs, fmt.Errorf("%s: %q is not a valid port", name, value))
+		return fallback
+	}
+	return port
+}
+
+// list reads comma separated values, leaving out empty ones
+func (p *parser) list(name string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// verifyTLS checks that TLS is configured one way, with both halves of a key pair, and that the ports don't clash
+func (p *parser) verifyTLS(server ServerConfig) {
+	tls := server.TLS
+	if (tls.CertFile == "") != (tls.KeyFile == "") {
+		p.errs = append(p.errs, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together"))
+	}
+	if tls.CertFile != "" && len(tls.AutocertDomains) > 0 {
+		p.errs = append(p.errs, errors.New("TLS_AUTOCERT_DOMAINS can't be set with TLS_CERT_FILE, choose one"))
+	}
+	if tls.RedirectPort != 0 && !tls.Enabled() {
+		p.errs = append(p.errs, errors.New("TLS_REDIRECT_PORT needs TLS_CERT_FILE or TLS_AUTOCERT_DOMAINS"))
+	}
+	if tls.RedirectPort != 0 && (tls.RedirectPort == server.Port || tls.RedirectPort == server.GRPCPort) {
+		p.errs = append(p.errs, fmt.Errorf("TLS_REDIRECT_PORT: %d is already used by the API", tls.RedirectPort))
+	}
+}
+
+// featureFlags reads the FEATURE_<NAME> variable of each feature flag that has one set
+func (p *parser) featureFlags() map[enums.FeatureFlag]bool {
+	flags := map[enums.FeatureFlag]bool{}
+	for _, name := range enums.GetValidFeatureFlags() {
+		variable := "FEATURE_" + strings.ToUpper(name)
+		if os.Getenv(variable) != "" {
+			flags[enums.FeatureFlag(name)] = p.boolean(variable)
+		}
+	}
+	// MAINTENANCE_MODE is the documented name of FEATURE_MAINTENANCE_MODE
+	if os.Getenv("MAINTENANCE_MODE") != "" {
+		flags[enums.FeatureMaintenanceMode] = p.boolean("MAINTENANCE_MODE")
+	}
+	return flags
+}
+
+// boolean reads true or false (or 1 and 0), false when unset
+func (p *parser) boolean(name string) bool {
+	value := os.Getenv(name)
+	if value == "" {
+		return false
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%s: %q is not true or false", name, value))
+	}
+	return parsed
+}
+
+// networks reads a comma-separated list of IP addresses and CIDR ranges such as "10.0.0.0/8,127.0.0.1"
+func (p *parser) networks(name string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, item := range strings.Split(os.Getenv(name), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if strings.Contains(item, "/") {
+			_, network, err := net.ParseCIDR(item)
+			if err != nil {
+				p.errs = append(p.errs, fmt.Errorf("%s: %q is not a valid CIDR range", name, item))
+				continue
+			}
+			networks = append(networks, network)
+			continue
+		}
+		ip := net.ParseIP(item)
+		if ip == nil {
+			p.errs = append(p.errs, fmt.Errorf("%s: %q is not a valid IP address", name, item))
+			continue
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(8*len(ip), 8*len(ip))})
+	}
+	return networks
+}
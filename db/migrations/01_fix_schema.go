@@ -443,11 +443,3 @@ func fixBaseModelColumns(db *gorm.DB) error {
 
 	return nil
 }
-
-// Registers all migration functions
-func RegisterMigrations(db *gorm.DB) error {
-	if err := Migration01FixSchema(db); err != nil {
-		return err
-	}
-	return nil
-}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// queryIndex is a composite index backing one of the hot list queries
+type queryIndex struct {
+	name    string
+	table   string
+	columns string
+}
+
+// queryIndexes are also declared on the models, so auto-migration creates them on new databases. Creating them here
+// first lets existing tables be indexed without blocking writes.
+var queryIndexes = []queryIndex{
+	// Entries of a leaderboard by rank, as listed for entries, standings and rank lookups
+	{"idx_leaderboard_entries_leaderboard_rank", "leaderboard_entries", "leaderboard_id, rank"},
+	// A participant's entry on a leaderboard, looked up when entries are created and scores recomputed
+	{"idx_leaderboard_entries_leaderboard_participant", "leaderboard_entries", "leaderboard_id, participant_id"},
+	// A participant's values for a metric over time, read for statistics, aggregates and standings
+	{"idx_metric_values_metric_participant_timestamp", "metric_values", "metric_id, participant_id, timestamp"},
+	// A participant's values across metrics by time, paged through by the participant's metric value list
+	{"idx_metric_values_participant_timestamp", "metric_values", "participant_id, timestamp"},
+	// Values by age alone, so rollups find the raw values old enough to compact without scanning the table
+	{"idx_metric_values_timestamp", "metric_values", "timestamp"},
+}
+
+// Migration02AddQueryIndexes adds the composite indexes the entry and metric value list queries need to avoid
+// sequential scans on large tables. Indexes are built concurrently, and one left invalid by an interrupted build is
+// dropped and built again.
+func Migration02AddQueryIndexes(db *gorm.DB) error {
+	fmt.Println("Running Migration02AddQueryIndexes...")
+
+	for _, index := range queryIndexes {
+		if !tableExists(db, index.table) {
+			fmt.Printf("Table '%s' doesn't exist yet, skipping index '%s'\n", index.table, index.name)
+			continue
+		}
+
+		var invalid bool
+		db.Raw(`
+			SELECT EXISTS (
+				SELECT FROM pg_index
+				JOIN pg_class ON pg_class.oid = pg_index.indexrelid
+				WHERE pg_class.relname = ? AND NOT pg_index.indisvalid
+			);
+		`, index.name).Scan(&invalid)
+		if invalid {
+			fmt.Printf("Dropping invalid index '%s'...\n", index.name)
+			if err := db.Exec(fmt.Sprintf(`DROP INDEX CONCURRENTLY IF EXISTS %s`, index.name)).Error; err != nil {
+				return fmt.Errorf("error dropping invalid index %s: %w", index.name, err)
+			}
+		}
+
+		fmt.Printf("Creating index '%s' on %s(%s)...\n", index.name, index.table, index.columns)
+		if err := db.Exec(fmt.Sprintf(`CREATE INDEX CONCURRENTLY IF NOT EXISTS %s ON %s (%s)`,
+			index.name, index.table, index.columns)).Error; err != nil {
+			return fmt.Errorf("error creating index %s: %w", index.name, err)
+		}
+	}
+
+	fmt.Println("Migration02AddQueryIndexes completed successfully")
+	return nil
+}
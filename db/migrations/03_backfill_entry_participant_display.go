@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Migration03BackfillEntryParticipantDisplay adds the participant_name and avatar_url columns to leaderboard entries
+// ahead of auto-migration, and copies each participant's shown name and avatar onto the entries that don't have them
+// yet. Only entries that differ are written, so it does nothing once the participant service keeps them in sync.
+func Migration03BackfillEntryParticipantDisplay(db *gorm.DB) error {
+	fmt.Println("Running Migration03BackfillEntryParticipantDisplay...")
+
+	if !tableExists(db, "leaderboard_entries") || !tableExists(db, "participants") {
+		fmt.Println("Entries or participants don't exist yet, skipping the backfill")
+		return nil
+	}
+
+	for _, column := range []string{"participant_name", "avatar_url"} {
+		if err := db.Exec(fmt.Sprintf(`ALTER TABLE leaderboard_entries ADD COLUMN IF NOT EXISTS %s text NOT NULL DEFAULT ''`,
+			column)).Error; err != nil {
+			return fmt.Errorf("error adding leaderboard_entries.%s: %w", column, err)
+		}
+	}
+
+	// Participants of databases older than their profile fields get them from auto-migration, and their entries are
+	// backfilled on the next start
+	if !db.Migrator().HasColumn("participants", "display_name") || !db.Migrator().HasColumn("participants", "avatar_url") {
+		fmt.Println("Participants have no profile fields yet, skipping the backfill")
+		return nil
+	}
+
+	result := db.Exec(`
+		UPDATE leaderboard_entries
+		SET participant_name = COALESCE(NULLIF(participants.display_name, ''), participants.name, ''),
+			avatar_url = COALESCE(participants.avatar_url, '')
+		FROM participants
+		WHERE participants.id = leaderboard_entries.participant_id
+			AND (leaderboard_entries.participant_name IS DISTINCT FROM COALESCE(NULLIF(participants.display_name, ''), participants.name, '')
+				OR leaderboard_entries.avatar_url IS DISTINCT FROM COALESCE(participants.avatar_url, ''))
+	`)
+	if result.Error != nil {
+		return fmt.Errorf("error backfilling entry participant fields: %w", result.Error)
+	}
+
+	fmt.Printf("Migration03BackfillEntryParticipantDisplay completed successfully, %d entries updated\n", result.RowsAffected)
+	return nil
+}
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// uniqueEntryIndex lets a participant have at most one live entry on a leaderboard. It is also declared on the
+// model, so auto-migration creates it on new databases.
+const uniqueEntryIndex = "idx_leaderboard_entries_leaderboard_participant_unique"
+
+// Migration04UniqueEntryPerParticipant soft-deletes all but one live entry of every participant that has several on
+// the same leaderboard, keeping the most recently updated, and then builds the unique index on (leaderboard_id,
+// participant_id) concurrently. The removed entries can still be found with include_deleted, and an invalid index
+// left by an interrupted build is dropped and built again.
+func Migration04UniqueEntryPerParticipant(db *gorm.DB) error {
+	fmt.Println("Running Migration04UniqueEntryPerParticipant...")
+
+	if !tableExists(db, "leaderboard_entries") {
+		fmt.Println("Table 'leaderboard_entries' doesn't exist yet, skipping")
+		return nil
+	}
+
+	result := db.Exec(`
+		UPDATE leaderboard_entries
+		SET deleted_at = NOW()
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT id, ROW_NUMBER() OVER (
+					PARTITION BY leaderboard_id, participant_id
+					ORDER BY last_updated DESC, updated_at DESC, id
+				) AS position
+				FROM leaderboard_entries
+				WHERE deleted_at IS NULL
+			) AS ranked
+			WHERE position > 1
+		)
+	`)
+	if result.Error != nil {
+		return fmt.Errorf("error removing duplicate leaderboard entries: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		fmt.Printf("Soft-deleted %d duplicate leaderboard entries\n", result.RowsAffected)
+	}
+
+	var invalid bool
+	db.Raw(`
+		SELECT EXISTS (
+			SELECT FROM pg_index
+			JOIN pg_class ON pg_class.oid = pg_index.indexrelid
+			WHERE pg_class.relname = ? AND NOT pg_index.indisvalid
+		);
+	`, uniqueEntryIndex).Scan(&invalid)
+	if invalid {
+		fmt.Printf("Dropping invalid index '%s'...\n", uniqueEntryIndex)
+		if err := db.Exec(fmt.Sprintf(`DROP INDEX CONCURRENTLY IF EXISTS %s`, uniqueEntryIndex)).Error; err != nil {
+			return fmt.Errorf("error dropping invalid index %s: %w", uniqueEntryIndex, err)
+		}
+	}
+
+	if err := db.Exec(fmt.Sprintf(`CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS %s
+		ON leaderboard_entries (leaderboard_id, participant_id) WHERE deleted_at IS NULL`, uniqueEntryIndex)).Error; err != nil {
+		return fmt.Errorf("error creating index %s: %w", uniqueEntryIndex, err)
+	}
+
+	fmt.Println("Migration04UniqueEntryPerParticipant completed successfully")
+	return nil
+}
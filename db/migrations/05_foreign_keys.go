@@ -0,0 +1,156 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// foreignKey is a constraint from a child table to its parent. The names are the ones auto-migration gives the
+// associations declaring them on the models, so it finds them and leaves them alone.
+type foreignKey struct {
+	name     string
+	table    string
+	column   string
+	parent   string
+	onDelete string
+}
+
+// entityForeignKeys are the foreign keys of entries, metric values and leaderboard metrics. Deleting a leaderboard
+// or participant from the database removes what belongs to it, while a metric can only be removed once it has no
+// recorded values.
+var entityForeignKeys = []foreignKey{
+	{"fk_leaderboards_entries", "leaderboard_entries", "leaderboard_id", "leaderboards", "CASCADE"},
+	{"fk_leaderboard_entries_participant", "leaderboard_entries", "participant_id", "participants", "CASCADE"},
+	{"fk_leaderboards_metrics", "leaderboard_metrics", "leaderboard_id", "leaderboards", "CASCADE"},
+	{"fk_metrics_leaderboard_metrics", "leaderboard_metrics", "metric_id", "metrics", "CASCADE"},
+	{"fk_participants_metric_values", "metric_values", "participant_id", "participants", "CASCADE"},
+	{"fk_metrics_values", "metric_values", "metric_id", "metrics", "RESTRICT"},
+}
+
+// DeleteOrphanedRows lets Migration05ForeignKeys delete the rows whose parent no longer exists, where the constraint
+// would have deleted them too. Without it the migration stops and reports them, so nothing is lost unnoticed.
+var DeleteOrphanedRows bool
+
+// pg_constraint.confdeltype of each ON DELETE action
+var onDeleteTypes = map[string]string{
+	"RESTRICT": "r",
+	"CASCADE":  "c",
+}
+
+// Migration05ForeignKeys gives the foreign keys of entries, metric values and leaderboard metrics their ON DELETE
+// behaviour. Auto-migration created most of them without one, and never created the one from leaderboard metrics to
+// metrics. Rows whose parent no longer exists must be dealt with first: ON DELETE CASCADE keys delete them when
+// DeleteOrphanedRows is set, as the constraint would have done, and otherwise fail like ON DELETE RESTRICT keys,
+// whose orphans are never deleted. Each constraint is added without checking the existing rows and validated
+// afterwards, so writes to the table aren't blocked while it is scanned.
+func Migration05ForeignKeys(db *gorm.DB) error {
+	fmt.Println("Running Migration05ForeignKeys...")
+
+	for _, fk := range entityForeignKeys {
+		if !tableExists(db, fk.table) || !tableExists(db, fk.parent) {
+			fmt.Printf("Table '%s' or '%s' doesn't exist yet, skipping %s\n", fk.table, fk.parent, fk.name)
+			continue
+		}
+		if err := ensureForeignKey(db, fk); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("Migration05ForeignKeys completed successfully")
+	return nil
+}
+
+// ensureForeignKey adds the foreign key, replacing any other constraint from its column to the parent table. It does
+// nothing if the constraint is already in place and validated.
+func ensureForeignKey(db *gorm.DB, fk foreignKey) error {
+	var current struct {
+		DeleteType string
+		Validated  bool
+	}
+	err := db.Raw(`
+		SELECT confdeltype AS delete_type, convalidated AS validated
+		FROM pg_constraint
+		WHERE conname = ? AND conrelid = ?::regclass
+	`, fk.name, fk.table).Scan(&current).Error
+	if err != nil {
+		return fmt.Errorf("error checking foreign key %s: %w", fk.name, err)
+	}
+	if current.DeleteType == onDeleteTypes[fk.onDelete] && current.Validated {
+		return nil
+	}
+
+	// Also repeated when a previous run added the constraint but failed to validate it
+	if err := handleOrphanedRows(db, fk); err != nil {
+		return err
+	}
+
+	if current.DeleteType != onDeleteTypes[fk.onDelete] {
+		var replaced []string
+		err := db.Raw(`
+			SELECT pg_constraint.conname
+			FROM pg_constraint
+			JOIN pg_attribute ON pg_attribute.attrelid = pg_constraint.conrelid
+				AND pg_attribute.attnum = ANY (pg_constraint.conkey)
+			WHERE pg_constraint.contype = 'f'
+				AND pg_constraint.conrelid = ?::regclass
+				AND pg_constraint.confrelid = ?::regclass
+				AND pg_attribute.attname = ?
+		`, fk.table, fk.parent, fk.column).Scan(&replaced).Error
+		if err != nil {
+			return fmt.Errorf("error finding the foreign keys on %s.%s: %w", fk.table, fk.column, err)
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for _, name := range replaced {
+				fmt.Printf("Dropping foreign key '%s' on %s.%s...\n", name, fk.table, fk.column)
+				if err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s`, fk.table, name)).Error; err != nil {
+					return fmt.Errorf("error dropping foreign key %s: %w", name, err)
+				}
+			}
+			return tx.Exec(fmt.Sprintf(`ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (id)
+				ON DELETE %s NOT VALID`, fk.table, fk.name, fk.column, fk.parent, fk.onDelete)).Error
+		})
+		if err != nil {
+			return fmt.Errorf("error adding foreign key %s: %w", fk.name, err)
+		}
+	}
+
+	if err := db.Exec(fmt.Sprintf(`ALTER TABLE %s VALIDATE CONSTRAINT %s`, fk.table, fk.name)).Error; err != nil {
+		return fmt.Errorf("error validating foreign key %s: %w", fk.name, err)
+	}
+	fmt.Printf("Foreign key '%s' on %s.%s is in place with ON DELETE %s\n", fk.name, fk.table, fk.column, fk.onDelete)
+	return nil
+}
+
+// handleOrphanedRows deletes the rows of the child table whose parent no longer exists if DeleteOrphanedRows allows it
+// for the foreign key, and otherwise returns an error saying how many there are
+func handleOrphanedRows(db *gorm.DB, fk foreignKey) error {
+	orphaned := fmt.Sprintf(`%[1]s.%[2]s IS NOT NULL
+		AND NOT EXISTS (SELECT 1 FROM %[3]s WHERE %[3]s.id = %[1]s.%[2]s)`, fk.table, fk.column, fk.parent)
+
+	var count int64
+	if err := db.Table(fk.table).Where(orphaned).Count(&count).Error; err != nil {
+		return fmt.Errorf("error counting orphaned %s: %w", fk.table, err)
+	}
+	if count == 0 {
+		return nil
+	}
+
+	if fk.onDelete != "CASCADE" {
+		return fmt.Errorf("can't add foreign key %s: %d %s rows have a %s without a matching %s row, "+
+			"fix or delete them and restart", fk.name, count, fk.table, fk.column, fk.parent)
+	}
+	if !DeleteOrphanedRows {
+		return fmt.Errorf("can't add foreign key %s: %d %s rows have a %s without a matching %s row, "+
+			"fix them or set MIGRATE_DELETE_ORPHANS=true to delete them and restart",
+			fk.name, count, fk.table, fk.column, fk.parent)
+	}
+
+	result := db.Exec(fmt.Sprintf(`DELETE FROM %s WHERE %s`, fk.table, orphaned))
+	if result.Error != nil {
+		return fmt.Errorf("error deleting orphaned %s: %w", fk.table, result.Error)
+	}
+	fmt.Printf("Deleted %d %s rows without a matching %s row\n", result.RowsAffected, fk.table, fk.parent)
+	return nil
+}
@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// uniqueLeaderboardMetricIndex lets a metric be attached to a leaderboard at most once. It is also declared on the
+// model, so auto-migration creates it on new databases.
+const uniqueLeaderboardMetricIndex = "idx_leaderboard_metrics_leaderboard_metric_unique"
+
+// Migration06UniqueLeaderboardMetric soft-deletes all but one live association of every metric attached to the same
+// leaderboard several times, keeping the most recently updated, and then builds the unique index on
+// (leaderboard_id, metric_id) concurrently. An invalid index left by an interrupted build is dropped and built again.
+func Migration06UniqueLeaderboardMetric(db *gorm.DB) error {
+	fmt.Println("Running Migration06UniqueLeaderboardMetric...")
+
+	if !tableExists(db, "leaderboard_metrics") {
+		fmt.Println("Table 'leaderboard_metrics' doesn't exist yet, skipping")
+		return nil
+	}
+
+	result := db.Exec(`
+		UPDATE leaderboard_metrics
+		SET deleted_at = NOW()
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT id, ROW_NUMBER() OVER (
+					PARTITION BY leaderboard_id, metric_id
+					ORDER BY updated_at DESC, id
+				) AS position
+				FROM leaderboard_metrics
+				WHERE deleted_at IS NULL
+			) AS ranked
+			WHERE position > 1
+		)
+	`)
+	if result.Error != nil {
+		return fmt.Errorf("error removing duplicate leaderboard metrics: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		fmt.Printf("Soft-deleted %d duplicate leaderboard metrics\n", result.RowsAffected)
+	}
+
+	var invalid bool
+	db.Raw(`
+		SELECT EXISTS (
+			SELECT FROM pg_index
+			JOIN pg_class ON pg_class.oid = pg_index.indexrelid
+			WHERE pg_class.relname = ? AND NOT pg_index.indisvalid
+		);
+	`, uniqueLeaderboardMetricIndex).Scan(&invalid)
+	if invalid {
+		fmt.Printf("Dropping invalid index '%s'...\n", uniqueLeaderboardMetricIndex)
+		if err := db.Exec(fmt.Sprintf(`DROP INDEX CONCURRENTLY IF EXISTS %s`, uniqueLeaderboardMetricIndex)).Error; err != nil {
+			return fmt.Errorf("error dropping invalid index %s: %w", uniqueLeaderboardMetricIndex, err)
+		}
+	}
+
+	if err := db.Exec(fmt.Sprintf(`CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS %s
+		ON leaderboard_metrics (leaderboard_id, metric_id) WHERE deleted_at IS NULL`, uniqueLeaderboardMetricIndex)).Error; err != nil {
+		return fmt.Errorf("error creating index %s: %w", uniqueLeaderboardMetricIndex, err)
+	}
+
+	fmt.Println("Migration06UniqueLeaderboardMetric completed successfully")
+	return nil
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Migration07CascadeLeaderboardSoftDelete soft-deletes the live entries and leaderboard metrics of leaderboards that
+// were soft-deleted before deleting a leaderboard took them with it. They get the leaderboard's deleted_at, so
+// restoring the leaderboard brings them back as it does for leaderboards deleted since.
+func Migration07CascadeLeaderboardSoftDelete(db *gorm.DB) error {
+	fmt.Println("Running Migration07CascadeLeaderboardSoftDelete...")
+
+	if !tableExists(db, "leaderboards") {
+		fmt.Println("Table 'leaderboards' doesn't exist yet, skipping")
+		return nil
+	}
+
+	for _, table := range []string{"leaderboard_entries", "leaderboard_metrics"} {
+		if !tableExists(db, table) {
+			fmt.Printf("Table '%s' doesn't exist yet, skipping\n", table)
+			continue
+		}
+
+		result := db.Exec(fmt.Sprintf(`
+			UPDATE %[1]s
+			SET deleted_at = leaderboards.deleted_at
+			FROM leaderboards
+			WHERE leaderboards.id = %[1]s.leaderboard_id
+				AND leaderboards.deleted_at IS NOT NULL AND %[1]s.deleted_at IS NULL
+		`, table))
+		if result.Error != nil {
+			return fmt.Errorf("error deleting %s of deleted leaderboards: %w", table, result.Error)
+		}
+		if result.RowsAffected > 0 {
+			fmt.Printf("Soft-deleted %d %s rows of deleted leaderboards\n", result.RowsAffected, table)
+		}
+	}
+
+	fmt.Println("Migration07CascadeLeaderboardSoftDelete completed successfully")
+	return nil
+}
@@ -0,0 +1,327 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// sqlFiles holds the versioned migrations, named <version>_<name>.sql, and the down migrations undoing them, named
+// <version>_<name>.down.sql
+//
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// migrationLockID is the advisory lock held while migrating, so replicas starting together take turns
+const migrationLockID = 7243019551
+
+// baselineVersion is the migration that creates the schema auto-migration used to manage. A database auto-migration
+// created is adopted by recording it as applied instead of running it.
+const baselineVersion = 1
+
+// downSuffix ends the names of down migrations
+const downSuffix = ".down.sql"
+
+// Migration is one versioned change to the schema
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+	Down    string // SQL undoing the migration, empty when it can't be undone
+}
+
+// Reversible reports whether the migration can be rolled back
+func (m Migration) Reversible() bool {
+	return m.Down != ""
+}
+
+// Checksum identifies the migration's SQL, so a migration edited after it was applied is noticed. The down migration
+// isn't part of it, so one can be added to a migration that was applied already.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.SQL))
+	return hex.EncodeToString(sum[:])
+}
+
+// schemaMigration is a row of schema_migrations, recording a migration applied to the database
+type schemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrations returns the embedded migrations in version order
+func Migrations() ([]Migration, error) {
+	return readMigrations(sqlFiles)
+}
+
+// readMigrations reads the migrations in the sql directory of files
+func readMigrations(files fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	versions := make(map[int]string)
+	downs := make(map[string]string)
+	for _, file := range entries {
+		sql, err := fs.ReadFile(files, path.Join("sql", file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if base, ok := strings.CutSuffix(file.Name(), downSuffix); ok {
+			downs[base] = string(sql)
+			continue
+		}
+
+		prefix, name, ok := strings.Cut(strings.TrimSuffix(file.Name(), ".sql"), "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s is not named <version>_<name>.sql", file.Name())
+		}
+		if other, ok := versions[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", other, file.Name())
+		}
+		versions[version] = file.Name()
+		migrations = append(migrations, Migration{Version: version, Name: name, SQL: string(sql)})
+	}
+
+	for i := range migrations {
+		base := strings.TrimSuffix(versions[migrations[i].Version], ".sql")
+		if down, ok := downs[base]; ok {
+			migrations[i].Down = down
+			delete(downs, base)
+		}
+	}
+	for base := range downs {
+		return nil, fmt.Errorf("down migration %s%s has no migration to undo", base, downSuffix)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Migrate applies the migrations the database hasn't had yet, oldest first, each in its own transaction together
+// with its schema_migrations row. It fails without applying anything when an applied migration's SQL has changed
+// since. A database created by auto-migration before versioned migrations existed is first brought up to date the
+// way it used to be on every start, with the fix-up migrations and auto-migration of models, and then adopted at the
+// baseline.
+func Migrate(db *gorm.DB, models ...interface{}) error {
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+
+	// The lock belongs to the session, so everything runs on the one connection that holds it
+	return db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec(`SELECT pg_advisory_lock(?)`, migrationLockID).Error; err != nil {
+			return fmt.Errorf("error locking migrations: %w", err)
+		}
+		defer conn.Exec(`SELECT pg_advisory_unlock(?)`, migrationLockID)
+
+		return migrate(conn, migrations, models)
+	})
+}
+
+func migrate(db *gorm.DB, migrations []Migration, models []interface{}) error {
+	if err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL
+		)
+	`).Error; err != nil {
+		return fmt.Errorf("error creating schema_migrations: %w", err)
+	}
+
+	var applied []schemaMigration
+	if err := db.Order("version").Find(&applied).Error; err != nil {
+		return fmt.Errorf("error reading schema_migrations: %w", err)
+	}
+	if err := verifyApplied(applied, migrations); err != nil {
+		return err
+	}
+
+	if len(applied) == 0 && tableExists(db, "leaderboards") {
+		baseline, err := adoptLegacyDatabase(db, migrations, models)
+		if err != nil {
+			return err
+		}
+		applied = append(applied, baseline)
+	}
+
+	done := make(map[int]bool, len(applied))
+	for _, migration := range applied {
+		done[migration.Version] = true
+	}
+	for _, migration := range migrations {
+		if done[migration.Version] {
+			continue
+		}
+		fmt.Printf("Applying migration %04d_%s...\n", migration.Version, migration.Name)
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.SQL).Error; err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Checksum:  migration.Checksum(),
+				AppliedAt: time.Now().UTC(),
+			}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("error applying migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	fmt.Printf("Database schema is at version %d\n", latestVersion(migrations))
+	return nil
+}
+
+// Pending returns the migrations the database hasn't had yet, oldest first. Unlike PlanMigrations it only reads
+// schema_migrations, so it is cheap enough to call on every readiness probe. A database without the table has every
+// migration pending.
+func Pending(db *gorm.DB) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	// Unlike tableExists this fails when the database can't be reached, so nothing is reported pending then
+	var exists bool
+	if err := db.Raw(`SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists).Error; err != nil {
+		return nil, fmt.Errorf("error reading schema_migrations: %w", err)
+	}
+	var versions []int
+	if exists {
+		if err := db.Model(&schemaMigration{}).Pluck("version", &versions).Error; err != nil {
+			return nil, fmt.Errorf("error reading schema_migrations: %w", err)
+		}
+	}
+	done := make(map[int]bool, len(versions))
+	for _, version := range versions {
+		done[version] = true
+	}
+
+	var pending []Migration
+	for _, migration := range migrations {
+		if !done[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// verifyApplied returns an error when a migration was changed after it was applied. Migrations the build doesn't
+// know, applied by a newer build, are reported but left alone, so an older build can still be rolled back to.
+func verifyApplied(applied []schemaMigration, migrations []Migration) error {
+	changed, unknown := compareApplied(applied, migrations)
+	for _, row := range unknown {
+		fmt.Printf("Warning: migration %04d_%s was applied by a newer build\n", row.Version, row.Name)
+	}
+	if len(changed) > 0 {
+		return fmt.Errorf("migration %04d_%s was changed after it was applied; add a new migration instead",
+			changed[0].Version, changed[0].Name)
+	}
+	return nil
+}
+
+// compareApplied returns the applied migrations whose SQL has changed since, and the applied migrations the build
+// doesn't know
+func compareApplied(applied []schemaMigration, migrations []Migration) ([]Migration, []schemaMigration) {
+	known := make(map[int]Migration, len(migrations))
+	for _, migration := range migrations {
+		known[migration.Version] = migration
+	}
+
+	var changed []Migration
+	var unknown []schemaMigration
+	for _, row := range applied {
+		migration, ok := known[row.Version]
+		if !ok {
+			unknown = append(unknown, row)
+			continue
+		}
+		if row.Checksum != migration.Checksum() {
+			changed = append(changed, migration)
+		}
+	}
+	return changed, unknown
+}
+
+// adoptLegacyDatabase runs the fix-up migrations and auto-migration on a database that is managed by neither, as
+// every start did before versioned migrations, and records the baseline as applied. The schema is then the one the
+// baseline creates.
+func adoptLegacyDatabase(db *gorm.DB, migrations []Migration, models []interface{}) (schemaMigration, error) {
+	var baseline *Migration
+	for i := range migrations {
+		if migrations[i].Version == baselineVersion {
+			baseline = &migrations[i]
+		}
+	}
+	if baseline == nil {
+		return schemaMigration{}, fmt.Errorf("baseline migration %d is missing", baselineVersion)
+	}
+
+	fmt.Println("Adopting a database created by auto-migration...")
+	if err := runLegacyMigrations(db); err != nil {
+		return schemaMigration{}, err
+	}
+	if err := db.AutoMigrate(models...); err != nil {
+		return schemaMigration{}, fmt.Errorf("error migrating models: %w", err)
+	}
+
+	row := schemaMigration{
+		Version:   baseline.Version,
+		Name:      baseline.Name,
+		Checksum:  baseline.Checksum(),
+		AppliedAt: time.Now().UTC(),
+	}
+	if err := db.Create(&row).Error; err != nil {
+		return schemaMigration{}, fmt.Errorf("error recording baseline migration: %w", err)
+	}
+	return row, nil
+}
+
+// runLegacyMigrations runs the fix-ups that prepared databases for auto-migration, in the order they were added
+func runLegacyMigrations(db *gorm.DB) error {
+	for _, migrate := range []func(*gorm.DB) error{
+		Migration01FixSchema,
+		Migration02AddQueryIndexes,
+		Migration03BackfillEntryParticipantDisplay,
+		Migration04UniqueEntryPerParticipant,
+		Migration05ForeignKeys,
+		Migration06UniqueLeaderboardMetric,
+		Migration07CascadeLeaderboardSoftDelete,
+	} {
+		if err := migrate(db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func latestVersion(migrations []Migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
@@ -0,0 +1,201 @@
+package migrations
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestReadMigrations(t *testing.T) {
+	testCases := []struct {
+		name             string
+		files            fstest.MapFS
+		expectedVersions []int
+		expectedNames    []string
+		expectedSQL      []string
+		expectedDown     []string
+		expectedValid    bool
+	}{
+		{
+			name: "Migrations sorted by version",
+			files: fstest.MapFS{
+				"sql/0010_scores.sql":  {Data: []byte("ALTER TABLE scores;")},
+				"sql/0002_users.sql":   {Data: []byte("CREATE TABLE users;")},
+				"sql/0001_schema.sql":  {Data: []byte("CREATE TABLE schema;")},
+				"sql/0003_add_idx.sql": {Data: []byte("CREATE INDEX idx;")},
+			},
+			expectedVersions: []int{1, 2, 3, 10},
+			expectedNames:    []string{"schema", "users", "add_idx", "scores"},
+			expectedSQL: []string{
+				"CREATE TABLE schema;", "CREATE TABLE users;", "CREATE INDEX idx;", "ALTER TABLE scores;",
+			},
+			expectedDown:  []string{"", "", "", ""},
+			expectedValid: true,
+		},
+		{
+			name: "Down migrations attached to the migrations they undo",
+			files: fstest.MapFS{
+				"sql/0001_schema.sql":       {Data: []byte("CREATE TABLE schema;")},
+				"sql/0002_users.sql":        {Data: []byte("CREATE TABLE users;")},
+				"sql/0002_users.down.sql":   {Data: []byte("DROP TABLE users;")},
+				"sql/0003_add_idx.sql":      {Data: []byte("CREATE INDEX idx;")},
+				"sql/0003_add_idx.down.sql": {Data: []byte("DROP INDEX idx;")},
+			},
+			expectedVersions: []int{1, 2, 3},
+			expectedNames:    []string{"schema", "users", "add_idx"},
+			expectedSQL:      []string{"CREATE TABLE schema;", "CREATE TABLE users;", "CREATE INDEX idx;"},
+			expectedDown:     []string{"", "DROP TABLE users;", "DROP INDEX idx;"},
+			expectedValid:    true,
+		},
+		{
+			name: "Down migration without its migration",
+			files: fstest.MapFS{
+				"sql/0001_schema.sql":     {Data: []byte("CREATE TABLE schema;")},
+				"sql/0002_users.down.sql": {Data: []byte("DROP TABLE users;")},
+			},
+			expectedValid: false,
+		},
+		{
+			name:          "Empty directory",
+			files:         fstest.MapFS{"sql": {Mode: fs.ModeDir}},
+			expectedValid: true,
+		},
+		{
+			name:          "Missing name",
+			files:         fstest.MapFS{"sql/0001.sql": {Data: []byte("SELECT 1;")}},
+			expectedValid: false,
+		},
+		{
+			name:          "Version that isn't a number",
+			files:         fstest.MapFS{"sql/first_schema.sql": {Data: []byte("SELECT 1;")}},
+			expectedValid: false,
+		},
+		{
+			name:          "Version zero",
+			files:         fstest.MapFS{"sql/0000_schema.sql": {Data: []byte("SELECT 1;")}},
+			expectedValid: false,
+		},
+		{
+			name: "Duplicate version",
+			files: fstest.MapFS{
+				"sql/0001_schema.sql": {Data: []byte("SELECT 1;")},
+				"sql/001_other.sql":   {Data: []byte("SELECT 2;")},
+			},
+			expectedValid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			migrations, err := readMigrations(tc.files)
+			isValid := err == nil
+
+			if isValid != tc.expectedValid {
+				if tc.expectedValid {
+					t.Fatalf("Expected the migrations to be read but got error: %v", err)
+				}
+				t.Fatalf("Expected an error but got %+v", migrations)
+			}
+			if !isValid {
+				return
+			}
+			if len(migrations) != len(tc.expectedVersions) {
+				t.Fatalf("Expected %d migrations but got %d: %+v", len(tc.expectedVersions), len(migrations), migrations)
+			}
+			for i, migration := range migrations {
+				if migration.Version != tc.expectedVersions[i] || migration.Name != tc.expectedNames[i] {
+					t.Errorf("Expected migration %d to be %d_%s but got %d_%s",
+						i, tc.expectedVersions[i], tc.expectedNames[i], migration.Version, migration.Name)
+				}
+				if migration.SQL != tc.expectedSQL[i] {
+					t.Errorf("Expected migration %d_%s to hold its file's SQL but got %q",
+						migration.Version, migration.Name, migration.SQL)
+				}
+				if migration.Down != tc.expectedDown[i] {
+					t.Errorf("Expected migration %d_%s to be undone by %q but got %q",
+						migration.Version, migration.Name, tc.expectedDown[i], migration.Down)
+				}
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("Expected the embedded migrations to be read but got error: %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != baselineVersion {
+		t.Fatalf("Expected the first migration to be the baseline but got %+v", migrations)
+	}
+}
+
+func TestVerifyApplied(t *testing.T) {
+	schema := Migration{Version: 1, Name: "schema", SQL: "CREATE TABLE leaderboards ();"}
+	scores := Migration{Version: 2, Name: "scores", SQL: "CREATE TABLE scores ();"}
+	migrations := []Migration{schema, scores}
+
+	testCases := []struct {
+		name          string
+		applied       []schemaMigration
+		expectedValid bool
+	}{
+		{
+			name:          "Nothing applied",
+			expectedValid: true,
+		},
+		{
+			name: "Unchanged migrations",
+			applied: []schemaMigration{
+				{Version: 1, Name: "schema", Checksum: schema.Checksum()},
+				{Version: 2, Name: "scores", Checksum: scores.Checksum()},
+			},
+			expectedValid: true,
+		},
+		{
+			name: "Migration from a newer build",
+			applied: []schemaMigration{
+				{Version: 1, Name: "schema", Checksum: schema.Checksum()},
+				{Version: 3, Name: "newer", Checksum: "unknown"},
+			},
+			expectedValid: true,
+		},
+		{
+			name: "Migration edited after it was applied",
+			applied: []schemaMigration{
+				{Version: 1, Name: "schema", Checksum: Migration{SQL: "CREATE TABLE old ();"}.Checksum()},
+			},
+			expectedValid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyApplied(tc.applied, migrations)
+			isValid := err == nil
+
+			if isValid != tc.expectedValid {
+				if tc.expectedValid {
+					t.Errorf("Expected the applied migrations to pass but got error: %v", err)
+				} else {
+					t.Errorf("Expected an error for the applied migrations")
+				}
+			}
+		})
+	}
+}
+
+func TestMigrationChecksum(t *testing.T) {
+	migration := Migration{Version: 1, Name: "schema", SQL: "CREATE TABLE leaderboards ();"}
+
+	// The SHA-256 of the SQL in hex, as schema_migrations has always recorded it
+	if empty := (Migration{}).Checksum(); empty != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("Expected the checksum of no SQL to be its SHA-256 but got %q", empty)
+	}
+	if renamed := (Migration{Version: 2, Name: "renamed", SQL: migration.SQL}); renamed.Checksum() != migration.Checksum() {
+		t.Errorf("Expected the checksum to depend only on the SQL")
+	}
+	if edited := (Migration{Version: 1, Name: "schema", SQL: migration.SQL + " "}); edited.Checksum() == migration.Checksum() {
+		t.Errorf("Expected editing the SQL to change the checksum")
+	}
+}
@@ -0,0 +1,162 @@
+package migrations
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// Plan is what Migrate would do to a database, worked out without changing it
+type Plan struct {
+	Version int         // Latest migration applied to the database, 0 when none is
+	Adopt   bool        // The database was created by auto-migration and would be adopted first
+	Pending []Migration // Migrations that would be applied, oldest first
+	Changed []Migration // Applied migrations whose SQL has changed since; Migrate refuses to run while there are any
+	Unknown []string    // Applied migrations the build doesn't know
+	Drift   []string    // Differences between the models and the tables as they are now
+}
+
+// PlanMigrations works out what Migrate would do to the database. Drift is only looked for in databases that
+// already have a schema, since a new one gets every table from the baseline.
+func PlanMigrations(db *gorm.DB, models ...interface{}) (*Plan, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []schemaMigration
+	if tableExists(db, "schema_migrations") {
+		if err := db.Order("version").Find(&applied).Error; err != nil {
+			return nil, fmt.Errorf("error reading schema_migrations: %w", err)
+		}
+	}
+
+	plan := &Plan{}
+	changed, unknown := compareApplied(applied, migrations)
+	plan.Changed = changed
+	for _, row := range unknown {
+		plan.Unknown = append(plan.Unknown, fmt.Sprintf("%04d_%s", row.Version, row.Name))
+	}
+	plan.Adopt = len(applied) == 0 && tableExists(db, "leaderboards")
+
+	done := make(map[int]bool, len(applied))
+	for _, row := range applied {
+		done[row.Version] = true
+		plan.Version = max(plan.Version, row.Version)
+	}
+	if plan.Adopt {
+		done[baselineVersion] = true
+	}
+	for _, migration := range migrations {
+		if !done[migration.Version] {
+			plan.Pending = append(plan.Pending, migration)
+		}
+	}
+
+	if len(applied) > 0 || plan.Adopt {
+		plan.Drift, err = detectDrift(db, models)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// detectDrift compares the tables of the models with the database, listing the tables, columns and indexes the
+// models have but the database doesn't, and the columns the database has that no model does
+func detectDrift(db *gorm.DB, models []interface{}) ([]string, error) {
+	var drift []string
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		table := stmt.Schema.Table
+
+		if !db.Migrator().HasTable(table) {
+			drift = append(drift, fmt.Sprintf("table %s is missing", table))
+			continue
+		}
+
+		columnTypes, err := db.Migrator().ColumnTypes(table)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the columns of %s: %w", table, err)
+		}
+		extra := make(map[string]bool, len(columnTypes))
+		for _, column := range columnTypes {
+			extra[column.Name()] = true
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || field.IgnoreMigration {
+				continue
+			}
+			if !extra[field.DBName] {
+				drift = append(drift, fmt.Sprintf("column %s.%s is missing", table, field.DBName))
+			}
+			delete(extra, field.DBName)
+		}
+		extraColumns := make([]string, 0, len(extra))
+		for column := range extra {
+			extraColumns = append(extraColumns, column)
+		}
+		sort.Strings(extraColumns)
+		for _, column := range extraColumns {
+			drift = append(drift, fmt.Sprintf("column %s.%s is not on any model", table, column))
+		}
+
+		var indexNames []string
+		for name := range stmt.Schema.ParseIndexes() {
+			indexNames = append(indexNames, name)
+		}
+		sort.Strings(indexNames)
+		for _, name := range indexNames {
+			if !db.Migrator().HasIndex(table, name) {
+				drift = append(drift, fmt.Sprintf("index %s on %s is missing", name, table))
+			}
+		}
+	}
+	return drift, nil
+}
+
+// Print writes the plan for review: the SQL of each pending migration, and what else Migrate would find
+func (p *Plan) Print(w io.Writer) {
+	fmt.Fprintln(w, "Migration plan (dry run, the database was not changed)")
+	if p.Version == 0 && !p.Adopt {
+		fmt.Fprintln(w, "The database has no schema yet")
+	} else if p.Version > 0 {
+		fmt.Fprintf(w, "The database is at version %d\n", p.Version)
+	}
+
+	for _, migration := range p.Changed {
+		fmt.Fprintf(w, "\nError: migration %04d_%s was changed after it was applied, so migrating would fail\n",
+			migration.Version, migration.Name)
+	}
+	for _, name := range p.Unknown {
+		fmt.Fprintf(w, "\nWarning: migration %s was applied by a newer build and is left alone\n", name)
+	}
+
+	if p.Adopt {
+		fmt.Fprintf(w, "\nThe database was created by auto-migration and would be adopted: the startup fix-ups and "+
+			"auto-migration run once more, adding what the drift below lists as missing, and %04d is recorded as "+
+			"applied without running it\n", baselineVersion)
+	}
+
+	if len(p.Pending) == 0 {
+		fmt.Fprintln(w, "\nNo migrations to apply")
+	}
+	for _, migration := range p.Pending {
+		fmt.Fprintf(w, "\n-- Would apply %04d_%s (checksum %s)\n%s", migration.Version, migration.Name,
+			migration.Checksum(), migration.SQL)
+	}
+
+	if len(p.Drift) > 0 {
+		fmt.Fprintln(w, "\nSchema drift between the models and the database, before the migrations above:")
+		for _, difference := range p.Drift {
+			fmt.Fprintf(w, "  - %s\n", difference)
+		}
+	} else if p.Version > 0 || p.Adopt {
+		fmt.Fprintln(w, "\nNo schema drift between the models and the database")
+	}
+}
@@ -0,0 +1,95 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Rollback undoes the latest n applied migrations, newest first, each in its own transaction together with removing
+// its schema_migrations row. Nothing is undone unless every one of them can be: it needs a down migration, must be
+// known to the build and unchanged since it was applied, and can't be the baseline, which would drop every table.
+func Rollback(db *gorm.DB, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("the number of migrations to roll back must be positive, not %d", n)
+	}
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+
+	return db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec(`SELECT pg_advisory_lock(?)`, migrationLockID).Error; err != nil {
+			return fmt.Errorf("error locking migrations: %w", err)
+		}
+		defer conn.Exec(`SELECT pg_advisory_unlock(?)`, migrationLockID)
+
+		return rollback(conn, migrations, n)
+	})
+}
+
+func rollback(db *gorm.DB, migrations []Migration, n int) error {
+	if !tableExists(db, "schema_migrations") {
+		return errors.New("the database has no migrations to roll back")
+	}
+	var applied []schemaMigration
+	if err := db.Order("version DESC").Limit(n).Find(&applied).Error; err != nil {
+		return fmt.Errorf("error reading schema_migrations: %w", err)
+	}
+	if len(applied) < n {
+		return fmt.Errorf("only %d migrations are applied, so %d can't be rolled back", len(applied), n)
+	}
+
+	undo, err := migrationsToUndo(applied, migrations)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range undo {
+		fmt.Printf("Rolling back migration %04d_%s...\n", migration.Version, migration.Name)
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return fmt.Errorf("error rolling back migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	var version int
+	if err := db.Model(&schemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+		return fmt.Errorf("error reading schema_migrations: %w", err)
+	}
+	fmt.Printf("Database schema is at version %d\n", version)
+	return nil
+}
+
+// migrationsToUndo returns the migrations the applied rows record, in their order, or an error naming the first one
+// that can't be rolled back
+func migrationsToUndo(applied []schemaMigration, migrations []Migration) ([]Migration, error) {
+	known := make(map[int]Migration, len(migrations))
+	for _, migration := range migrations {
+		known[migration.Version] = migration
+	}
+	undo := make([]Migration, 0, len(applied))
+	for _, row := range applied {
+		migration, ok := known[row.Version]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("migration %04d_%s was applied by a newer build, which has to roll it back",
+				row.Version, row.Name)
+		case row.Checksum != migration.Checksum():
+			return nil, fmt.Errorf("migration %04d_%s was changed after it was applied, so its down migration may not undo it",
+				row.Version, row.Name)
+		case migration.Version == baselineVersion:
+			return nil, fmt.Errorf("migration %04d_%s is the baseline and can't be rolled back", row.Version, row.Name)
+		case !migration.Reversible():
+			return nil, fmt.Errorf("migration %04d_%s has no down migration", row.Version, row.Name)
+		}
+		undo = append(undo, migration)
+	}
+	return undo, nil
+}
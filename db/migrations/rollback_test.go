@@ -0,0 +1,79 @@
+package migrations
+
+import "testing"
+
+func TestMigrationsToUndo(t *testing.T) {
+	schema := Migration{Version: 1, Name: "schema", SQL: "CREATE TABLE leaderboards ();", Down: "DROP TABLE leaderboards;"}
+	users := Migration{Version: 2, Name: "users", SQL: "CREATE TABLE users ();", Down: "DROP TABLE users;"}
+	scores := Migration{Version: 3, Name: "scores", SQL: "CREATE TABLE scores ();"}
+	migrations := []Migration{schema, users, scores}
+
+	applied := func(migration Migration) schemaMigration {
+		return schemaMigration{Version: migration.Version, Name: migration.Name, Checksum: migration.Checksum()}
+	}
+
+	testCases := []struct {
+		name             string
+		applied          []schemaMigration
+		expectedVersions []int
+		expectedValid    bool
+	}{
+		{
+			name:             "Migration with a down migration",
+			applied:          []schemaMigration{applied(users)},
+			expectedVersions: []int{2},
+			expectedValid:    true,
+		},
+		{
+			name:          "Migration without a down migration",
+			applied:       []schemaMigration{applied(scores)},
+			expectedValid: false,
+		},
+		{
+			name:          "Nothing undone when an older migration has no down migration",
+			applied:       []schemaMigration{applied(users), applied(scores)},
+			expectedValid: false,
+		},
+		{
+			name:          "Baseline",
+			applied:       []schemaMigration{applied(users), applied(schema)},
+			expectedValid: false,
+		},
+		{
+			name:          "Migration from a newer build",
+			applied:       []schemaMigration{{Version: 4, Name: "newer", Checksum: "unknown"}},
+			expectedValid: false,
+		},
+		{
+			name:          "Migration edited after it was applied",
+			applied:       []schemaMigration{{Version: 2, Name: "users", Checksum: scores.Checksum()}},
+			expectedValid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			undo, err := migrationsToUndo(tc.applied, migrations)
+			isValid := err == nil
+
+			if isValid != tc.expectedValid {
+				if tc.expectedValid {
+					t.Fatalf("Expected the migrations to be rolled back but got error: %v", err)
+				}
+				t.Fatalf("Expected an error but got %+v", undo)
+			}
+			if !isValid {
+				return
+			}
+			if len(undo) != len(tc.expectedVersions) {
+				t.Fatalf("Expected %d migrations to be undone but got %+v", len(tc.expectedVersions), undo)
+			}
+			for i, migration := range undo {
+				if migration.Version != tc.expectedVersions[i] {
+					t.Errorf("Expected migration %d to be undone in place %d but got %d",
+						tc.expectedVersions[i], i, migration.Version)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,434 @@
+-- The schema of a new database. Databases created by auto-migration before versioned migrations are adopted
+-- instead, so this never runs on them.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE "leaderboards" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "name" text NOT NULL,
+    "description" text,
+    "category" text NOT NULL,
+    "type" text NOT NULL,
+    "time_frame" text NOT NULL,
+    "start_date" timestamptz,
+    "end_date" timestamptz,
+    "sort_order" text NOT NULL,
+    "visibility_scope" text NOT NULL,
+    "max_entries" bigint,
+    "is_active" boolean,
+    "owner_id" uuid,
+    "group_id" uuid,
+    "max_entries_policy" text NOT NULL DEFAULT 'reject',
+    "team_score_aggregation" text NOT NULL DEFAULT 'sum',
+    "team_score_top_k" bigint,
+    "version" bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_leaderboards_deleted_at" ON "leaderboards" ("deleted_at");
+CREATE INDEX "idx_leaderboards_group_id" ON "leaderboards" ("group_id");
+CREATE INDEX "idx_leaderboards_owner_id" ON "leaderboards" ("owner_id");
+
+CREATE TABLE "metrics" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "name" text NOT NULL,
+    "description" text,
+    "data_type" text NOT NULL,
+    "unit" text,
+    "aggregation_type" text NOT NULL,
+    "reset_period" text NOT NULL,
+    "is_higher_better" boolean NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_metrics_deleted_at" ON "metrics" ("deleted_at");
+
+CREATE TABLE "leaderboard_metrics" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "leaderboard_id" uuid NOT NULL,
+    "metric_id" uuid NOT NULL,
+    "weight" decimal NOT NULL DEFAULT 1,
+    "display_priority" bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_leaderboards_metrics" FOREIGN KEY ("leaderboard_id") REFERENCES "leaderboards"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_metrics_leaderboard_metrics" FOREIGN KEY ("metric_id") REFERENCES "metrics"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "idx_leaderboard_metrics_leaderboard_metric_unique" ON "leaderboard_metrics" ("leaderboard_id","metric_id") WHERE deleted_at IS NULL;
+CREATE INDEX "idx_leaderboard_metrics_deleted_at" ON "leaderboard_metrics" ("deleted_at");
+
+CREATE TABLE "participants" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "external_id" text,
+    "name" text NOT NULL,
+    "type" text NOT NULL,
+    "metadata" jsonb,
+    "user_id" uuid,
+    "parent_id" uuid,
+    "is_active" boolean NOT NULL DEFAULT true,
+    "anonymized_at" timestamptz,
+    "display_name" text,
+    "avatar_url" text,
+    "country" varchar(2),
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_participants_parent_id" ON "participants" ("parent_id");
+CREATE UNIQUE INDEX "idx_participants_user_id" ON "participants" ("user_id");
+CREATE UNIQUE INDEX "idx_participants_external_id_unique" ON "participants" ("external_id") WHERE external_id <> '' AND deleted_at IS NULL;
+CREATE INDEX "idx_participants_deleted_at" ON "participants" ("deleted_at");
+
+CREATE TABLE "leaderboard_entries" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "leaderboard_id" uuid NOT NULL,
+    "participant_id" uuid NOT NULL,
+    "rank" bigint NOT NULL,
+    "score" decimal NOT NULL,
+    "last_updated" timestamptz NOT NULL,
+    "version" bigint NOT NULL DEFAULT 1,
+    "participant_name" text NOT NULL DEFAULT '',
+    "avatar_url" text NOT NULL DEFAULT '',
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_leaderboard_entries_participant" FOREIGN KEY ("participant_id") REFERENCES "participants"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_leaderboards_entries" FOREIGN KEY ("leaderboard_id") REFERENCES "leaderboards"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "idx_leaderboard_entries_leaderboard_participant_unique" ON "leaderboard_entries" ("leaderboard_id","participant_id") WHERE deleted_at IS NULL;
+CREATE INDEX "idx_leaderboard_entries_leaderboard_participant" ON "leaderboard_entries" ("leaderboard_id","participant_id");
+CREATE INDEX "idx_leaderboard_entries_leaderboard_rank" ON "leaderboard_entries" ("leaderboard_id","rank");
+CREATE INDEX "idx_leaderboard_entries_deleted_at" ON "leaderboard_entries" ("deleted_at");
+
+CREATE TABLE "metric_values" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "metric_id" uuid NOT NULL,
+    "participant_id" uuid NOT NULL,
+    "value" decimal NOT NULL,
+    "bool_value" boolean,
+    "string_value" text,
+    "timestamp" timestamptz NOT NULL,
+    "source" text,
+    "context" jsonb,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_participants_metric_values" FOREIGN KEY ("participant_id") REFERENCES "participants"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_metrics_values" FOREIGN KEY ("metric_id") REFERENCES "metrics"("id") ON DELETE RESTRICT
+);
+CREATE INDEX "idx_metric_values_participant_timestamp" ON "metric_values" ("participant_id","timestamp");
+CREATE INDEX "idx_metric_values_metric_participant_timestamp" ON "metric_values" ("metric_id","participant_id","timestamp");
+CREATE INDEX "idx_metric_values_deleted_at" ON "metric_values" ("deleted_at");
+CREATE INDEX "idx_metric_values_timestamp" ON "metric_values" ("timestamp");
+
+CREATE TABLE "webhook_subscriptions" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "target_url" text NOT NULL,
+    "secret" text NOT NULL,
+    "event_types" jsonb NOT NULL,
+    "leaderboard_id" uuid,
+    "is_active" boolean NOT NULL,
+    "last_delivery_at" timestamptz,
+    "last_delivery_status" bigint,
+    "last_delivery_error" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_webhook_subscriptions_deleted_at" ON "webhook_subscriptions" ("deleted_at");
+CREATE INDEX "idx_webhook_subscriptions_leaderboard_id" ON "webhook_subscriptions" ("leaderboard_id");
+
+CREATE TABLE "events" (
+    "id" bigserial,
+    "type" text NOT NULL,
+    "leaderboard_id" uuid,
+    "data" jsonb,
+    "occurred_at" timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_events_leaderboard_id" ON "events" ("leaderboard_id");
+CREATE INDEX "idx_events_type" ON "events" ("type");
+
+CREATE TABLE "device_tokens" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "participant_id" uuid NOT NULL,
+    "provider" varchar(20) NOT NULL,
+    "token" text NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_device_tokens_token" ON "device_tokens" ("token");
+CREATE INDEX "idx_device_tokens_participant_id" ON "device_tokens" ("participant_id");
+CREATE INDEX "idx_device_tokens_deleted_at" ON "device_tokens" ("deleted_at");
+
+CREATE TABLE "users" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "username" text NOT NULL,
+    "email" text NOT NULL,
+    "password_hash" text NOT NULL,
+    "role" text NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_users_email" ON "users" ("email");
+CREATE UNIQUE INDEX "idx_users_username" ON "users" ("username");
+CREATE INDEX "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE "api_keys" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "name" text NOT NULL,
+    "prefix" text NOT NULL,
+    "key_hash" text NOT NULL,
+    "role" text NOT NULL,
+    "scopes" jsonb NOT NULL,
+    "expires_at" timestamptz,
+    "last_used_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_api_keys_key_hash" ON "api_keys" ("key_hash");
+CREATE INDEX "idx_api_keys_deleted_at" ON "api_keys" ("deleted_at");
+
+CREATE TABLE "leaderboard_members" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "leaderboard_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "role" varchar(20) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_leaderboard_members_user_id" ON "leaderboard_members" ("user_id");
+CREATE UNIQUE INDEX "idx_leaderboard_member" ON "leaderboard_members" ("leaderboard_id","user_id");
+CREATE INDEX "idx_leaderboard_members_deleted_at" ON "leaderboard_members" ("deleted_at");
+
+CREATE TABLE "leaderboard_participant_accesses" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "leaderboard_id" uuid NOT NULL,
+    "participant_id" uuid NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_leaderboard_participant_accesses_participant_id" ON "leaderboard_participant_accesses" ("participant_id");
+CREATE UNIQUE INDEX "idx_leaderboard_participant_access" ON "leaderboard_participant_accesses" ("leaderboard_id","participant_id");
+CREATE INDEX "idx_leaderboard_participant_accesses_deleted_at" ON "leaderboard_participant_accesses" ("deleted_at");
+
+CREATE TABLE "login_attempts" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "username" text NOT NULL,
+    "ip_address" text NOT NULL,
+    "succeeded" boolean NOT NULL,
+    "failure_reason" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_login_attempts_ip_address" ON "login_attempts" ("ip_address");
+CREATE INDEX "idx_login_attempts_username" ON "login_attempts" ("username");
+CREATE INDEX "idx_login_attempts_deleted_at" ON "login_attempts" ("deleted_at");
+
+CREATE TABLE "password_reset_tokens" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "user_id" uuid NOT NULL,
+    "token_hash" text NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "used_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_password_reset_tokens_deleted_at" ON "password_reset_tokens" ("deleted_at");
+CREATE UNIQUE INDEX "idx_password_reset_tokens_token_hash" ON "password_reset_tokens" ("token_hash");
+CREATE INDEX "idx_password_reset_tokens_user_id" ON "password_reset_tokens" ("user_id");
+
+CREATE TABLE "service_tokens" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "name" text NOT NULL,
+    "audience" jsonb NOT NULL,
+    "scopes" jsonb NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "revoked_at" timestamptz,
+    "last_used_at" timestamptz,
+    "created_by" uuid,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_service_tokens_revoked_at" ON "service_tokens" ("revoked_at");
+CREATE INDEX "idx_service_tokens_deleted_at" ON "service_tokens" ("deleted_at");
+
+CREATE TABLE "team_members" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "team_id" uuid NOT NULL,
+    "member_id" uuid NOT NULL,
+    "joined_at" timestamptz NOT NULL,
+    "left_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_team_members_member" FOREIGN KEY ("member_id") REFERENCES "participants"("id")
+);
+CREATE INDEX "idx_team_members_member_id" ON "team_members" ("member_id");
+CREATE INDEX "idx_team_members_team_id" ON "team_members" ("team_id");
+CREATE INDEX "idx_team_members_deleted_at" ON "team_members" ("deleted_at");
+
+CREATE TABLE "participant_name_changes" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "participant_id" uuid NOT NULL,
+    "name" text NOT NULL,
+    "display_name" text,
+    "effective_from" timestamptz NOT NULL,
+    "effective_to" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_participant_name_changes_participant_id" ON "participant_name_changes" ("participant_id");
+CREATE INDEX "idx_participant_name_changes_deleted_at" ON "participant_name_changes" ("deleted_at");
+
+CREATE TABLE "participant_anonymizations" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "participant_id" uuid NOT NULL,
+    "pseudonym" text NOT NULL,
+    "requested_by" text NOT NULL,
+    "reason" text,
+    "metric_values_deleted" bigint,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_participant_anonymizations_participant_id" ON "participant_anonymizations" ("participant_id");
+CREATE INDEX "idx_participant_anonymizations_deleted_at" ON "participant_anonymizations" ("deleted_at");
+
+CREATE TABLE "participant_tags" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "participant_id" uuid NOT NULL,
+    "tag" varchar(50) NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_participants_tags" FOREIGN KEY ("participant_id") REFERENCES "participants"("id")
+);
+CREATE UNIQUE INDEX "idx_participant_tags_participant_tag" ON "participant_tags" ("participant_id","tag");
+CREATE INDEX "idx_participant_tags_deleted_at" ON "participant_tags" ("deleted_at");
+CREATE INDEX "idx_participant_tags_tag" ON "participant_tags" ("tag");
+
+CREATE TABLE "leaderboard_snapshots" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "leaderboard_id" uuid NOT NULL,
+    "taken_at" timestamptz NOT NULL,
+    "entry_count" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_leaderboard_snapshots_leaderboard_id" ON "leaderboard_snapshots" ("leaderboard_id");
+CREATE INDEX "idx_leaderboard_snapshots_deleted_at" ON "leaderboard_snapshots" ("deleted_at");
+
+CREATE TABLE "leaderboard_snapshot_entries" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "snapshot_id" uuid NOT NULL,
+    "participant_id" uuid NOT NULL,
+    "rank" bigint NOT NULL,
+    "score" decimal NOT NULL,
+    "metrics" jsonb,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_leaderboard_snapshot_entries_participant" FOREIGN KEY ("participant_id") REFERENCES "participants"("id"),
+    CONSTRAINT "fk_leaderboard_snapshots_entries" FOREIGN KEY ("snapshot_id") REFERENCES "leaderboard_snapshots"("id")
+);
+CREATE INDEX "idx_leaderboard_snapshot_entries_deleted_at" ON "leaderboard_snapshot_entries" ("deleted_at");
+CREATE INDEX "idx_leaderboard_snapshot_entries_snapshot_id" ON "leaderboard_snapshot_entries" ("snapshot_id");
+
+CREATE TABLE "standing_summaries" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "leaderboard_id" uuid NOT NULL,
+    "version" timestamptz NOT NULL,
+    "refreshed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_standing_summaries_leaderboard_id" ON "standing_summaries" ("leaderboard_id");
+CREATE INDEX "idx_standing_summaries_deleted_at" ON "standing_summaries" ("deleted_at");
+
+CREATE TABLE "standing_aggregates" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "leaderboard_id" uuid NOT NULL,
+    "participant_id" uuid NOT NULL,
+    "metric_id" uuid NOT NULL,
+    "count" bigint NOT NULL,
+    "sum" decimal NOT NULL,
+    "min" decimal NOT NULL,
+    "max" decimal NOT NULL,
+    "average" decimal NOT NULL,
+    "last_value" decimal NOT NULL,
+    "last_bool_value" boolean,
+    "last_string_value" text,
+    "last_recorded_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_standing_aggregates_deleted_at" ON "standing_aggregates" ("deleted_at");
+CREATE UNIQUE INDEX "idx_standing_aggregates_leaderboard_participant_metric" ON "standing_aggregates" ("leaderboard_id","participant_id","metric_id");
+
+CREATE TABLE "metric_value_rollups" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz,
+    "metric_id" uuid NOT NULL,
+    "participant_id" uuid NOT NULL,
+    "day" timestamptz NOT NULL,
+    "count" bigint NOT NULL,
+    "sum" decimal NOT NULL,
+    "min" decimal NOT NULL,
+    "max" decimal NOT NULL,
+    "last_value" decimal NOT NULL,
+    "last_bool_value" boolean,
+    "last_string_value" text,
+    "first_recorded_at" timestamptz NOT NULL,
+    "last_recorded_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_metric_value_rollups_metric_participant_day" ON "metric_value_rollups" ("metric_id","participant_id","day");
+CREATE INDEX "idx_metric_value_rollups_deleted_at" ON "metric_value_rollups" ("deleted_at");
+
+CREATE TABLE "applied_score_increments" (
+    "event_id" bigint,
+    "applied_at" timestamptz NOT NULL,
+    PRIMARY KEY ("event_id")
+);
@@ -0,0 +1,19 @@
+-- Audit logs of changes to leaderboards, entries, metrics and participants. Adopting a database created by
+-- auto-migration creates the table from the model first, so it may exist already.
+
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+    "id" bigserial,
+    "entity_type" text NOT NULL,
+    "entity_id" uuid NOT NULL,
+    "action" text NOT NULL,
+    "actor_type" text NOT NULL,
+    "actor_id" text NOT NULL DEFAULT '',
+    "before" jsonb,
+    "after" jsonb,
+    "changes" jsonb,
+    "occurred_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_entity" ON "audit_logs" ("entity_type","entity_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_actor_id" ON "audit_logs" ("actor_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_occurred_at" ON "audit_logs" ("occurred_at");
@@ -0,0 +1,10 @@
+-- Feature flags set by admins at runtime. Adopting a database created by auto-migration creates the table from the
+-- model first, so it may exist already.
+
+CREATE TABLE IF NOT EXISTS "feature_flags" (
+    "name" text,
+    "enabled" boolean NOT NULL,
+    "updated_by" text NOT NULL DEFAULT '',
+    "updated_at" timestamptz NOT NULL,
+    PRIMARY KEY ("name")
+);
@@ -1,27 +1,172 @@
 package db
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+// Connection pool defaults, used unless DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS, DB_CONN_MAX_LIFETIME or
+// DB_STATEMENT_TIMEOUT are set
+const (
+	DefaultMaxOpenConns     = 25
+	DefaultMaxIdleConns     = 10
+	DefaultConnMaxLifetime  = 30 * time.Minute
+	DefaultStatementTimeout = 30 * time.Second
+)
+
 var DB *gorm.DB
 
-func InitDB() {
-	connStr := os.Getenv("DATABASE_URL")
+// StatementTimeout is how long the queries made for one request may run before they are cancelled; 0 means no limit
+var StatementTimeout = DefaultStatementTimeout
+
+// PoolConfig sizes the database connection pool and bounds how long request queries may run
+type PoolConfig struct {
+	MaxOpenConns     int           // Connections open at once, in use or idle; 0 means unlimited
+	MaxIdleConns     int           // Idle connections kept for reuse
+	ConnMaxLifetime  time.Duration // How long a connection is reused before it is closed; 0 means forever
+	StatementTimeout time.Duration // How long a request's queries may run; 0 means no limit
+}
+
+// PoolConfigFromEnv reads the pool settings from the environment, falling back to the defaults for those not set
+func PoolConfigFromEnv() (PoolConfig, error) {
+	config := PoolConfig{
+		MaxOpenConns:     DefaultMaxOpenConns,
+		MaxIdleConns:     DefaultMaxIdleConns,
+		ConnMaxLifetime:  DefaultConnMaxLifetime,
+		StatementTimeout: DefaultStatementTimeout,
+	}
+
+	for _, setting := range []struct {
+		name   string
+		target *int
+	}{{"DB_MAX_OPEN_CONNS", &config.MaxOpenConns}, {"DB_MAX_IDLE_CONNS", &config.MaxIdleConns}} {
+		value := os.Getenv(setting.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return config, fmt.Errorf("%s: %q is not a non-negative integer", setting.name, value)
+		}
+		*setting.target = parsed
+	}
+
+	if value := os.Getenv("DB_CONN_MAX_LIFETIME"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return config, fmt.Errorf("DB_CONN_MAX_LIFETIME: %q is not a non-negative number of seconds", value)
+		}
+		config.ConnMaxLifetime = time.Duration(seconds) * time.Second
+	}
+
+	if value := os.Getenv("DB_STATEMENT_TIMEOUT"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return config, fmt.Errorf("DB_STATEMENT_TIMEOUT: %q is not a non-negative number of seconds", value)
+		}
+		config.StatementTimeout = time.Duration(seconds) * time.Second
+	}
+
+	// Idle connections beyond the open limit would be closed straight away
+	if config.MaxOpenConns > 0 && config.MaxIdleConns > config.MaxOpenConns {
+		config.MaxIdleConns = config.MaxOpenConns
+	}
+	return config, nil
+}
+
+// Driver defaults, used unless DB_QUERY_EXEC_MODE or DB_STATEMENT_CACHE_CAPACITY are set
+const (
+	DefaultQueryExecMode          = pgx.QueryExecModeCacheStatement
+	DefaultStatementCacheCapacity = 512
+)
+
+// queryExecModes are the pgx query execution modes DB_QUERY_EXEC_MODE can select
+var queryExecModes = map[string]pgx.QueryExecMode{
+	"cache_statement": pgx.QueryExecModeCacheStatement,
+	"cache_describe":  pgx.QueryExecModeCacheDescribe,
+	"describe_exec":   pgx.QueryExecModeDescribeExec,
+	"exec":            pgx.QueryExecModeExec,
+	"simple_protocol": pgx.QueryExecModeSimpleProtocol,
+}
+
+// DriverConfig sets how the pgx driver sends queries to Postgres
+type DriverConfig struct {
+	// QueryExecMode is cache_statement by default: each connection prepares a statement the first time it runs a
+	// query and reuses it afterwards, so repeated queries skip parsing and planning. Behind a transaction-pooling
+	// proxy such as PgBouncer, which can't keep prepared statements, use exec or simple_protocol instead.
+	QueryExecMode          pgx.QueryExecMode
+	StatementCacheCapacity int // Prepared statements (or descriptions, for cache_describe) kept per connection
+}
+
+// DriverConfigFromEnv reads the driver settings from the environment, falling back to the defaults for those not set
+func DriverConfigFromEnv() (DriverConfig, error) {
+	config := DriverConfig{
+		QueryExecMode:          DefaultQueryExecMode,
+		StatementCacheCapacity: DefaultStatementCacheCapacity,
+	}
+
+	if value := os.Getenv("DB_QUERY_EXEC_MODE"); value != "" {
+		mode, ok := queryExecModes[value]
+		if !ok {
+			return config, fmt.Errorf("DB_QUERY_EXEC_MODE: %q is not one of cache_statement, cache_describe, describe_exec, exec or simple_protocol", value)
+		}
+		config.QueryExecMode = mode
+	}
+
+	if value := os.Getenv("DB_STATEMENT_CACHE_CAPACITY"); value != "" {
+		capacity, err := strconv.Atoi(value)
+		if err != nil || capacity < 1 {
+			return config, fmt.Errorf("DB_STATEMENT_CACHE_CAPACITY: %q is not a positive integer", value)
+		}
+		config.StatementCacheCapacity = capacity
+	}
+	return config, nil
+}
+
+// InitDB opens the connection pool to the database at connStr and verifies the database is reachable
+func InitDB(connStr string) error {
 	if connStr == "" {
-		log.Fatal("DATABASE_URL is not set")
+		return errors.New("DATABASE_URL is not set")
+	}
+	poolConfig, err := PoolConfigFromEnv()
+	if err != nil {
+		return err
+	}
+	driverConfig, err := DriverConfigFromEnv()
+	if err != nil {
+		return err
+	}
+
+	connConfig, err := pgx.ParseConfig(connStr)
+	if err != nil {
+		return fmt.Errorf("invalid DATABASE_URL: %w", err)
+	}
+	connConfig.DefaultQueryExecMode = driverConfig.QueryExecMode
+	connConfig.StatementCacheCapacity = driverConfig.StatementCacheCapacity
+	connConfig.DescriptionCacheCapacity = driverConfig.StatementCacheCapacity
+
+	DB, err = gorm.Open(postgres.New(postgres.Config{Conn: stdlib.OpenDB(*connConfig)}), &gorm.Config{})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	var err error
-	DB, err = gorm.Open(postgres.Open(connStr), &gorm.Config{})
+	sqlDB, err := DB.DB()
 	if err != nil {
-		log.Fatal("Failed to connect to database: ", err)
+		return fmt.Errorf("failed to configure connection pool: %w", err)
 	}
+	sqlDB.SetMaxOpenConns(poolConfig.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(poolConfig.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(poolConfig.ConnMaxLifetime)
+	StatementTimeout = poolConfig.StatementTimeout
 
 	fmt.Println("Connected to postgres")
+	return nil
 }
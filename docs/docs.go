@@ -24,87 +24,21 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate a user and generate a JWT token",
-                "consumes": [
-                    "application/json"
-                ],
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Get the public keys other services use to verify tokens issued by this service. Tokens name their key in the kid header. HMAC secrets are never published, so the set is empty unless RS256 or ES256 signing is configured.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "auth"
                 ],
-                "summary": "Log in a user",
-                "parameters": [
-                    {
-                        "description": "Login credentials",
-                        "name": "loginRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LoginRequest"
-                        }
-                    }
-                ],
+                "summary": "Get the JSON Web Key Set",
                 "responses": {
                     "200": {
-                        "description": "Login successful",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LoginResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Server error",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/register": {
-            "post": {
-                "description": "Register a new user account",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Register a new user",
-                "parameters": [
-                    {
-                        "description": "Registration data",
-                        "name": "registerRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.RegisterRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Registration successful",
+                        "description": "Key set",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LoginResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
+                            "$ref": "#/definitions/middleware.JSONWebKeySet"
                         }
                     },
                     "500": {
@@ -116,14 +50,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/leaderboard-entries": {
+        "/admin/audit-logs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all entries/rankings for a specific leaderboard",
+                "description": "Get the recorded changes to leaderboards, entries, metrics and participants with an ID greater than after_id, oldest first, with who made each change and the entity before and after it. Pass the ID of the last log received as after_id to get the next page.",
                 "consumes": [
                     "application/json"
                 ],
@@ -131,24 +65,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard-entries"
+                    "admin"
                 ],
-                "summary": "List all entries for a leaderboard",
+                "summary": "List the audit log",
                 "parameters": [
                     {
+                        "enum": [
+                            "leaderboard",
+                            "leaderboard_entry",
+                            "metric",
+                            "participant"
+                        ],
                         "type": "string",
-                        "description": "Filter by participant ID",
-                        "name": "participant_id",
+                        "description": "Type of entity changed",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the entity changed",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "restore"
+                        ],
+                        "type": "string",
+                        "description": "Kind of change",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID, service token subject or API key ID of the actor",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only changes made at or after this time (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only changes made before this time (RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Return logs with an ID greater than this value",
+                        "name": "after_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of logs to return (max 1000)",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of leaderboard entries",
+                        "description": "List of audit logs",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
+                                "$ref": "#/definitions/handlers.AuditLogResponse"
                             }
                         }
                     },
@@ -158,63 +148,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new entry/ranking in a leaderboard",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "leaderboard-entries"
-                ],
-                "summary": "Create a new leaderboard entry",
-                "parameters": [
-                    {
-                        "description": "Leaderboard entry data",
-                        "name": "entry",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.CreateLeaderboardEntryRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created leaderboard entry",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Leaderboard or participant not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -228,14 +169,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/leaderboard-entries/{id}": {
+        "/admin/feature-flags": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a leaderboard entry by its unique ID",
+                "description": "Get every feature flag with its value, the value instances were started with (FEATURE_\u003cNAME\u003e or built in) and, when an admin has set it, who set it and when.",
                 "consumes": [
                     "application/json"
                 ],
@@ -243,52 +184,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard-entries"
-                ],
-                "summary": "Get a leaderboard entry by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Leaderboard Entry ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "List feature flags",
                 "responses": {
                     "200": {
-                        "description": "Leaderboard entry details",
+                        "description": "List of feature flags",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.FeatureFlagResponse"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Invalid ID",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "500": {
+                        "description": "Server error",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/feature-flags/{name}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing leaderboard entry with the provided details",
+                "description": "Turn a feature flag on or off on every instance until it is reset. The instance answering applies it right away, the others within FEATURE_FLAG_REFRESH_INTERVAL seconds.",
                 "consumes": [
                     "application/json"
                 ],
@@ -296,32 +233,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard-entries"
+                    "admin"
                 ],
-                "summary": "Update a leaderboard entry",
+                "summary": "Set a feature flag",
                 "parameters": [
                     {
+                        "enum": [
+                            "standings_cache",
+                            "standings_summary",
+                            "score_buffer",
+                            "maintenance_mode"
+                        ],
                         "type": "string",
-                        "description": "Leaderboard Entry ID",
-                        "name": "id",
+                        "description": "Feature flag name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated leaderboard entry data",
-                        "name": "entry",
+                        "description": "Value of the flag",
+                        "name": "flag",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateLeaderboardEntryRequest"
+                            "$ref": "#/definitions/handlers.SetFeatureFlagRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated leaderboard entry",
+                        "description": "Feature flag",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
+                            "$ref": "#/definitions/handlers.FeatureFlagResponse"
                         }
                     },
                     "400": {
@@ -336,8 +279,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Not found",
+                        "description": "Feature flag not found",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -356,7 +305,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a leaderboard entry by its ID",
+                "description": "Drop the value an admin set for a feature flag, so every instance goes back to the value it was started with (FEATURE_\u003cNAME\u003e or built in).",
                 "consumes": [
                     "application/json"
                 ],
@@ -364,26 +313,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard-entries"
+                    "admin"
                 ],
-                "summary": "Delete a leaderboard entry",
+                "summary": "Reset a feature flag",
                 "parameters": [
                     {
+                        "enum": [
+                            "standings_cache",
+                            "standings_summary",
+                            "score_buffer",
+                            "maintenance_mode"
+                        ],
                         "type": "string",
-                        "description": "Leaderboard Entry ID",
-                        "name": "id",
+                        "description": "Feature flag name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No content"
-                    },
-                    "400": {
-                        "description": "Invalid ID",
+                    "200": {
+                        "description": "Feature flag",
                         "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
+                            "$ref": "#/definitions/handlers.FeatureFlagResponse"
                         }
                     },
                     "401": {
@@ -392,8 +344,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Not found",
+                        "description": "Feature flag not found",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -407,14 +365,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/leaderboard-metrics": {
+        "/admin/maintenance": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all metrics associated with a specific leaderboard",
+                "description": "Get whether the service is in maintenance mode, rejecting requests that change data with 503 while reads stay available, and who switched it and when.",
                 "consumes": [
                     "application/json"
                 ],
@@ -422,40 +380,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard-metrics"
+                    "admin"
                 ],
-                "summary": "List all metrics for a leaderboard",
+                "summary": "Get maintenance mode",
                 "responses": {
                     "200": {
-                        "description": "List of leaderboard metrics",
+                        "description": "Maintenance mode",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
-                            }
+                            "$ref": "#/definitions/handlers.FeatureFlagResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid query parameters",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new metric for a leaderboard",
+                "description": "Switch maintenance mode on or off on every instance. While it is on, POST, PUT, PATCH and DELETE requests and gRPC calls other than Get and List are rejected with 503, except logging in and the admin maintenance and feature flag endpoints. The instance answering applies it right away, the others within FEATURE_FLAG_REFRESH_INTERVAL seconds.",
                 "consumes": [
                     "application/json"
                 ],
@@ -463,25 +424,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard-metrics"
+                    "admin"
                 ],
-                "summary": "Create a new leaderboard metric",
+                "summary": "Set maintenance mode",
                 "parameters": [
                     {
-                        "description": "Leaderboard metric data",
-                        "name": "metric",
+                        "description": "Whether maintenance mode is on",
+                        "name": "maintenance",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateLeaderboardMetricRequest"
+                            "$ref": "#/definitions/handlers.SetFeatureFlagRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created leaderboard metric",
+                    "200": {
+                        "description": "Maintenance mode",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
+                            "$ref": "#/definitions/handlers.FeatureFlagResponse"
                         }
                     },
                     "400": {
@@ -496,8 +457,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Leaderboard or metric not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -511,14 +472,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/leaderboard-metrics/{id}": {
+        "/admin/overview": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a leaderboard metric by its unique ID",
+                "description": "Get counts of live leaderboards, participants and entries, the number of metric values ingested on each UTC day (including days with none), the latest server errors and the state of the database connection pool. Errors and pool statistics belong to each instance, so they cover only the instance that answers, and errors are lost on restart.",
                 "consumes": [
                     "application/json"
                 ],
@@ -526,27 +487,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard-metrics"
+                    "admin"
                 ],
-                "summary": "Get a leaderboard metric by ID",
+                "summary": "Get the admin overview",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Leaderboard Metric ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of days of metric value counts, today included (max 365)",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Leaderboard metric details",
+                        "description": "Overview",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
+                            "$ref": "#/definitions/handlers.AdminOverviewResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID",
+                        "description": "Invalid days",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -557,21 +518,76 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all API keys that have not been revoked. Key values are never returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "List API keys",
+                "responses": {
+                    "200": {
+                        "description": "List of API keys",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.APIKeyResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing leaderboard metric with the provided details",
+                "description": "Create an API key for service-to-service calls. The key is returned only in this response; send it in the X-API-Key header.",
                 "consumes": [
                     "application/json"
                 ],
@@ -579,32 +595,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard-metrics"
+                    "api-keys"
                 ],
-                "summary": "Update a leaderboard metric",
+                "summary": "Create an API key",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Leaderboard Metric ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated leaderboard metric data",
-                        "name": "metric",
+                        "description": "API key data",
+                        "name": "apiKey",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateLeaderboardMetricRequest"
+                            "$ref": "#/definitions/handlers.CreateAPIKeyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Updated leaderboard metric",
+                    "201": {
+                        "description": "Created API key",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
+                            "$ref": "#/definitions/handlers.CreatedAPIKeyResponse"
                         }
                     },
                     "400": {
@@ -619,8 +628,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -632,14 +641,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api-keys/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a leaderboard metric by its ID",
+                "description": "Revoke an API key so it can no longer authenticate",
                 "consumes": [
                     "application/json"
                 ],
@@ -647,13 +658,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard-metrics"
+                    "api-keys"
                 ],
-                "summary": "Delete a leaderboard metric",
+                "summary": "Revoke an API key",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Leaderboard Metric ID",
+                        "description": "API key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -675,6 +686,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not found",
                         "schema": {
@@ -690,14 +707,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/leaderboards": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a list of all leaderboards",
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate a user and generate a JWT token. Pass scopes to get a token limited to a subset of the account's permissions.\nRepeated failures temporarily lock out the username and the client IP address.",
                 "consumes": [
                     "application/json"
                 ],
@@ -705,34 +717,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboards"
+                    "auth"
+                ],
+                "summary": "Log in a user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "loginRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginRequest"
+                        }
+                    }
                 ],
-                "summary": "List all leaderboards",
                 "responses": {
                     "200": {
-                        "description": "List of leaderboards",
+                        "description": "Login successful",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.LeaderboardResponse"
-                            }
+                            "$ref": "#/definitions/handlers.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid username or password",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Requested scopes not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts, see the Retry-After header",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/password-reset/confirm": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new leaderboard with the provided details",
+                "description": "Set a new password with the token from the reset email. Tokens expire after an hour and can only be used once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -740,35 +781,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboards"
+                    "auth"
                 ],
-                "summary": "Create a new leaderboard",
+                "summary": "Confirm a password reset",
                 "parameters": [
                     {
-                        "description": "Leaderboard data",
-                        "name": "leaderboard",
+                        "description": "Reset token and new password",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateLeaderboardRequest"
+                            "$ref": "#/definitions/handlers.PasswordResetConfirmRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created leaderboard",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LeaderboardResponse"
-                        }
+                    "204": {
+                        "description": "Password changed"
                     },
                     "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid request or invalid or expired token",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -782,14 +814,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/leaderboards/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a leaderboard by its unique ID",
+        "/auth/password-reset/request": {
+            "post": {
+                "description": "Email a single-use password reset token to the account with this address. The response is the same whether or not an account exists.",
                 "consumes": [
                     "application/json"
                 ],
@@ -797,52 +824,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboards"
+                    "auth"
                 ],
-                "summary": "Get a leaderboard by ID",
+                "summary": "Request a password reset",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Leaderboard ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Account email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PasswordResetRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Leaderboard details",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LeaderboardResponse"
-                        }
+                    "202": {
+                        "description": "Reset email sent if the account exists"
                     },
                     "400": {
-                        "description": "Invalid ID",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "500": {
+                        "description": "Server error",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update an existing leaderboard with the provided details",
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user account and log it in",
                 "consumes": [
                     "application/json"
                 ],
@@ -850,32 +867,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboards"
+                    "auth"
                 ],
-                "summary": "Update a leaderboard",
+                "summary": "Register a new user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Leaderboard ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated leaderboard data",
-                        "name": "leaderboard",
+                        "description": "Registration data",
+                        "name": "registerRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateLeaderboardRequest"
+                            "$ref": "#/definitions/handlers.RegisterRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Updated leaderboard",
+                    "201": {
+                        "description": "Registration successful",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LeaderboardResponse"
+                            "$ref": "#/definitions/handlers.LoginResponse"
                         }
                     },
                     "400": {
@@ -884,14 +894,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not found",
+                    "409": {
+                        "description": "Username or email already in use",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -903,14 +907,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/events": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a leaderboard by its ID",
+                "description": "Get events with an ID greater than after_id, oldest first. Store the ID of the last event received and pass it as after_id on the next poll; events may be delivered more than once. Tokens without the leaderboards:admin scope only get the events of leaderboards they can read.",
                 "consumes": [
                     "application/json"
                 ],
@@ -918,24 +924,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboards"
+                    "events"
                 ],
-                "summary": "Delete a leaderboard",
+                "summary": "Poll the event log",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Leaderboard ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Return events with an ID greater than this value",
+                        "name": "after_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of event types to include",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of events to return (max 1000)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No content"
+                    "200": {
+                        "description": "List of events",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.EventResponse"
+                            }
+                        }
                     },
                     "400": {
-                        "description": "Invalid ID",
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -945,23 +970,25 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not found",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Server error",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/leaderboards/{leaderboard_id}/entries": {
+        "/healthz": {
+            "get": {
+                "description": "Returns 200 while the process can answer requests. It checks no dependency, so an outage of the database doesn't get every instance restarted; use /readyz to take an instance out of rotation.",
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "Alive"
+                    }
+                }
+            }
+        },
+        "/leaderboard-entries": {
             "get": {
                 "security": [
                     {
@@ -982,15 +1009,52 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by leaderboard ID",
-                        "name": "leaderboard_id",
-                        "in": "path"
+                        "description": "Filter by participant ID",
+                        "name": "participant_id",
+                        "in": "query"
                     },
                     {
+                        "enum": [
+                            "rank",
+                            "score",
+                            "last_updated",
+                            "created_at"
+                        ],
                         "type": "string",
-                        "description": "Filter by participant ID",
-                        "name": "participant_id",
+                        "description": "Field to sort by, defaults to rank",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction, defaults to asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "participant"
+                        ],
+                        "type": "string",
+                        "description": "Related resources to load; entries always include their participant",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list soft-deleted entries (admin only)",
+                        "name": "include_deleted",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from an earlier response; on /leaderboards/{leaderboard_id}/entries the entries are only sent again if they changed",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1003,6 +1067,9 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "304": {
+                        "description": "Not modified since the ETag in If-None-Match"
+                    },
                     "400": {
                         "description": "Invalid query parameters",
                         "schema": {
@@ -1014,6 +1081,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "include_deleted without the admin role",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -1035,12 +1108,6 @@ const docTemplate = `{
                 ],
                 "summary": "Create a new leaderboard entry",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Leaderboard ID",
-                        "name": "leaderboard_id",
-                        "in": "path"
-                    },
                     {
                         "description": "Leaderboard entry data",
                         "name": "entry",
@@ -1076,6 +1143,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Participant already has an entry on the leaderboard, returned in existing, or the leaderboard is full or has ended",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Server error",
                         "schema": {
@@ -1085,14 +1158,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/leaderboards/{leaderboard_id}/metrics": {
+        "/leaderboard-entries/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all metrics associated with a specific leaderboard",
+                "description": "Retrieve a leaderboard entry by its unique ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1100,29 +1173,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard-metrics"
+                    "leaderboard-entries"
                 ],
-                "summary": "List all metrics for a leaderboard",
+                "summary": "Get a leaderboard entry by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by leaderboard ID",
-                        "name": "leaderboard_id",
-                        "in": "path"
+                        "description": "Leaderboard Entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of leaderboard metrics",
+                        "description": "Leaderboard entry details",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
-                            }
+                            "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid ID",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -1132,16 +1203,28 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new metric for a leaderboard",
+                "description": "Update an existing leaderboard entry with the provided details. Send the version the changes are based on to have them rejected if the entry was updated or re-ranked since.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1149,31 +1232,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard-metrics"
+                    "leaderboard-entries"
                 ],
-                "summary": "Create a new leaderboard metric",
+                "summary": "Update a leaderboard entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Leaderboard ID",
-                        "name": "leaderboard_id",
-                        "in": "path"
+                        "description": "Leaderboard Entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "Leaderboard metric data",
-                        "name": "metric",
+                        "description": "Updated leaderboard entry data",
+                        "name": "entry",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateLeaderboardMetricRequest"
+                            "$ref": "#/definitions/handlers.UpdateLeaderboardEntryRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created leaderboard metric",
+                    "200": {
+                        "description": "Updated leaderboard entry",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
+                            "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
                         }
                     },
                     "400": {
@@ -1189,7 +1273,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Leaderboard or metric not found",
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Leaderboard entry was updated by another request, or the leaderboard has ended",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -1201,16 +1291,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/metric-values": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of metric values with optional filtering by metric ID and/or participant ID",
+                "description": "Delete a leaderboard entry by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1218,37 +1306,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "metric-values"
+                    "leaderboard-entries"
                 ],
-                "summary": "List metric values",
+                "summary": "Delete a leaderboard entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "date-time",
-                        "description": "Filter by timestamp (greater than or equal)",
-                        "name": "from_time",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "format": "date-time",
-                        "description": "Filter by timestamp (less than or equal)",
-                        "name": "to_time",
-                        "in": "query"
+                        "description": "Leaderboard Entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of metric values",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.MetricValueResponse"
-                            }
-                        }
+                    "204": {
+                        "description": "No content"
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid ID",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -1258,16 +1333,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/leaderboard-entries/{id}/increment": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new metric value record for a participant",
+                "description": "Add a delta to the entry's score and re-rank its leaderboard. While the score buffer is enabled the\nincrement is accepted and applied with the next flush instead.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1275,29 +1364,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "metric-values"
+                    "leaderboard-entries"
                 ],
-                "summary": "Create a new metric value",
+                "summary": "Increment a leaderboard entry's score",
                 "parameters": [
                     {
-                        "description": "Metric value data",
-                        "name": "metric_value",
+                        "type": "string",
+                        "description": "Leaderboard Entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Score increment",
+                        "name": "increment",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateMetricValueRequest"
+                            "$ref": "#/definitions/handlers.IncrementScoreRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created metric value",
+                    "200": {
+                        "description": "Incremented leaderboard entry",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MetricValueResponse"
+                            "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Increment buffered",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ScoreIncrementAccepted"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request or team leaderboard",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -1309,7 +1411,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Metric or participant not found",
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Leaderboard has ended",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -1323,14 +1431,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/metric-values/{id}": {
-            "get": {
+        "/leaderboard-entries/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a metric value by its unique ID",
+                "description": "Bring back a soft-deleted leaderboard entry. Deleted entries can be found by listing with include_deleted=true. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1338,13 +1446,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "metric-values"
+                    "leaderboard-entries"
                 ],
-                "summary": "Get a metric value by ID",
+                "summary": "Restore a deleted leaderboard entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Metric Value ID",
+                        "description": "Leaderboard entry ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1352,9 +1460,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Metric value details",
+                        "description": "Restored leaderboard entry",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MetricValueResponse"
+                            "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
                         }
                     },
                     "400": {
@@ -1369,21 +1477,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Not deleted or conflicts with live data",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/leaderboard-metrics": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing metric value with the provided details",
+                "description": "Get a list of all metrics associated with a specific leaderboard. Without a leaderboard, metrics of private leaderboards the caller cannot read are left out.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1391,92 +1519,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "metric-values"
-                ],
-                "summary": "Update a metric value",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Metric Value ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated metric value data",
-                        "name": "metric_value",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdateMetricValueRequest"
-                        }
-                    }
+                    "leaderboard-metrics"
                 ],
+                "summary": "List all metrics for a leaderboard",
                 "responses": {
                     "200": {
-                        "description": "Updated metric value",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.MetricValueResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not found",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Server error",
+                        "description": "List of leaderboard metrics",
                         "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
+                            }
                         }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a metric value by its ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "metric-values"
-                ],
-                "summary": "Delete a metric value",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Metric Value ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No content"
                     },
                     "400": {
-                        "description": "Invalid ID",
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -1487,12 +1544,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Server error",
                         "schema": {
@@ -1500,43 +1551,6 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/metrics": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a list of all metrics",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "metrics"
-                ],
-                "summary": "List all metrics",
-                "responses": {
-                    "200": {
-                        "description": "List of metrics",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.MetricResponse"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/middleware.ErrorResponse"
-                        }
-                    }
-                }
             },
             "post": {
                 "security": [
@@ -1544,7 +1558,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new metric with the provided details",
+                "description": "Create a new metric for a leaderboard",
                 "consumes": [
                     "application/json"
                 ],
@@ -1552,25 +1566,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "metrics"
+                    "leaderboard-metrics"
                 ],
-                "summary": "Create a new metric",
+                "summary": "Create a new leaderboard metric",
                 "parameters": [
                     {
-                        "description": "Metric data",
+                        "description": "Leaderboard metric data",
                         "name": "metric",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateMetricRequest"
+                            "$ref": "#/definitions/handlers.CreateLeaderboardMetricRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created metric",
+                        "description": "Created leaderboard metric",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MetricResponse"
+                            "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
                         }
                     },
                     "400": {
@@ -1585,6 +1599,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Leaderboard or metric not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Metric is already attached to the leaderboard, returned in existing",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Server error",
                         "schema": {
@@ -1594,14 +1620,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics/{id}": {
+        "/leaderboard-metrics/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a metric by its unique ID",
+                "description": "Retrieve a leaderboard metric by its unique ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1609,13 +1635,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "metrics"
+                    "leaderboard-metrics"
                 ],
-                "summary": "Get a metric by ID",
+                "summary": "Get a leaderboard metric by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Metric ID",
+                        "description": "Leaderboard Metric ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1623,9 +1649,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Metric details",
+                        "description": "Leaderboard metric details",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MetricResponse"
+                            "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
                         }
                     },
                     "400": {
@@ -1645,6 +1671,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -1654,7 +1686,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing metric with the provided details",
+                "description": "Update an existing leaderboard metric with the provided details",
                 "consumes": [
                     "application/json"
                 ],
@@ -1662,32 +1694,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "metrics"
+                    "leaderboard-metrics"
                 ],
-                "summary": "Update a metric",
+                "summary": "Update a leaderboard metric",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Metric ID",
+                        "description": "Leaderboard Metric ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated metric data",
+                        "description": "Updated leaderboard metric data",
                         "name": "metric",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateMetricRequest"
+                            "$ref": "#/definitions/handlers.UpdateLeaderboardMetricRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated metric",
+                        "description": "Updated leaderboard metric",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MetricResponse"
+                            "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
                         }
                     },
                     "400": {
@@ -1722,7 +1754,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a metric by its ID",
+                "description": "Delete a leaderboard metric by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1730,13 +1762,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "metrics"
+                    "leaderboard-metrics"
                 ],
-                "summary": "Delete a metric",
+                "summary": "Delete a leaderboard metric",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Metric ID",
+                        "description": "Leaderboard Metric ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1773,14 +1805,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics/{metric_id}/values": {
+        "/leaderboards": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of metric values with optional filtering by metric ID and/or participant ID",
+                "description": "Get a list of all leaderboards, optionally only those matching every given filter, oldest first unless a sort is given. Private leaderboards are only included for users with access. Pass ids to fetch several leaderboards by ID in one request; IDs that don't exist or can't be read are left out.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1788,38 +1820,109 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "metric-values"
+                    "leaderboards"
                 ],
-                "summary": "List metric values",
+                "summary": "List all leaderboards",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by metric ID",
-                        "name": "metric_id",
-                        "in": "path"
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
                     },
                     {
+                        "enum": [
+                            "individual",
+                            "team"
+                        ],
                         "type": "string",
-                        "format": "date-time",
-                        "description": "Filter by timestamp (greater than or equal)",
-                        "name": "from_time",
+                        "description": "Filter by leaderboard type",
+                        "name": "type",
                         "in": "query"
                     },
                     {
+                        "enum": [
+                            "daily",
+                            "weekly",
+                            "monthly",
+                            "yearly",
+                            "all-time"
+                        ],
                         "type": "string",
-                        "format": "date-time",
-                        "description": "Filter by timestamp (less than or equal)",
-                        "name": "to_time",
+                        "description": "Filter by time frame",
+                        "name": "time_frame",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by active state",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "public",
+                            "private"
+                        ],
+                        "type": "string",
+                        "description": "Filter by visibility scope",
+                        "name": "visibility_scope",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "category",
+                            "start_date",
+                            "end_date",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction, defaults to asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related resources to load: metrics, entries",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list soft-deleted leaderboards (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only the leaderboards with these IDs, comma-separated or repeated (max 100)",
+                        "name": "ids",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of metric values",
+                        "description": "List of leaderboards",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handlers.MetricValueResponse"
+                                "$ref": "#/definitions/handlers.LeaderboardResponse"
                             }
                         }
                     },
@@ -1834,6 +1937,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "include_deleted without the admin role",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -1843,7 +1952,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new metric value record for a participant",
+                "description": "Create a new leaderboard with the provided details. The authenticated user becomes its owner. Metrics in the request are attached in the same transaction, so the leaderboard is only created if all of them exist.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1851,35 +1960,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "metric-values"
+                    "leaderboards"
                 ],
-                "summary": "Create a new metric value",
+                "summary": "Create a new leaderboard",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Metric ID",
-                        "name": "metric_id",
-                        "in": "path"
-                    },
-                    {
-                        "description": "Metric value data",
-                        "name": "metric_value",
+                        "description": "Leaderboard data",
+                        "name": "leaderboard",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateMetricValueRequest"
+                            "$ref": "#/definitions/handlers.CreateLeaderboardRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created metric value",
+                        "description": "Created leaderboard",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MetricValueResponse"
+                            "$ref": "#/definitions/handlers.LeaderboardResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request, or a date range that is inverted, empty or already over",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -1891,7 +1994,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Metric or participant not found",
+                        "description": "Group or metric not found",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -1905,14 +2008,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/participants": {
+        "/leaderboards/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all participants",
+                "description": "Retrieve a leaderboard by its unique ID. Use include to load its metrics (by display priority) and entries (by rank, with their participants) in the same request.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1920,17 +2023,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "participants"
+                    "leaderboards"
+                ],
+                "summary": "Get a leaderboard by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related resources to load: metrics, entries",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from an earlier response; the leaderboard is only sent again if it changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
                 ],
-                "summary": "List all participants",
                 "responses": {
                     "200": {
-                        "description": "List of participants",
+                        "description": "Leaderboard details",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.ParticipantResponse"
-                            }
+                            "$ref": "#/definitions/handlers.LeaderboardResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified since the ETag in If-None-Match"
+                    },
+                    "400": {
+                        "description": "Invalid ID or include",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1938,16 +2068,28 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new participant with the provided details",
+                "description": "Update an existing leaderboard with the provided details. Send the version the changes are based on to have them rejected if the leaderboard was updated since.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1955,29 +2097,104 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "participants"
+                    "leaderboards"
                 ],
-                "summary": "Create a new participant",
+                "summary": "Update a leaderboard",
                 "parameters": [
                     {
-                        "description": "Participant data",
-                        "name": "participant",
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated leaderboard data",
+                        "name": "leaderboard",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateParticipantRequest"
+                            "$ref": "#/definitions/handlers.UpdateLeaderboardRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created participant",
+                    "200": {
+                        "description": "Updated leaderboard",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                            "$ref": "#/definitions/handlers.LeaderboardResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request, or an inverted or empty date range",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Leaderboard was updated by another request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft-delete a leaderboard by its ID, together with its entries and leaderboard metrics. A leaderboard that has entries is only deleted with force=true; otherwise the response is 409 LEADERBOARD_NOT_EMPTY with the number of entries and leaderboard metrics that would be deleted in dependents.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboards"
+                ],
+                "summary": "Delete a leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete the leaderboard even though it has entries",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid ID or force",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -1988,6 +2205,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Leaderboard has entries and force is not set",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Server error",
                         "schema": {
@@ -1997,14 +2226,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/participants/{id}": {
+        "/leaderboards/{id}/access/participants": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a participant by its unique ID",
+                "description": "Get the participants whose linked users may read a private leaderboard",
                 "consumes": [
                     "application/json"
                 ],
@@ -2012,13 +2241,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "participants"
+                    "leaderboards"
                 ],
-                "summary": "Get a participant by ID",
+                "summary": "List participant access",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Participant ID",
+                        "description": "Leaderboard ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2026,9 +2255,12 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Participant details",
+                        "description": "Access list",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LeaderboardParticipantAccessResponse"
+                            }
                         }
                     },
                     "400": {
@@ -2043,21 +2275,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Not found",
+                        "description": "Leaderboard not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/leaderboards/{id}/access/participants/{participantId}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing participant with the provided details",
+                "description": "Allow the user linked to a participant to read a private leaderboard",
                 "consumes": [
                     "application/json"
                 ],
@@ -2065,36 +2311,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "participants"
+                    "leaderboards"
                 ],
-                "summary": "Update a participant",
+                "summary": "Grant participant access",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Participant ID",
+                        "description": "Leaderboard ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated participant data",
-                        "name": "participant",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdateParticipantRequest"
-                        }
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "participantId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated participant",
+                        "description": "Access list entry",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                            "$ref": "#/definitions/handlers.LeaderboardParticipantAccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid ID",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -2105,8 +2349,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Not found",
+                        "description": "Leaderboard or participant not found",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -2125,7 +2375,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a participant by its ID",
+                "description": "Remove a participant from a private leaderboard's access list",
                 "consumes": [
                     "application/json"
                 ],
@@ -2133,16 +2383,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "participants"
+                    "leaderboards"
                 ],
-                "summary": "Delete a participant",
+                "summary": "Revoke participant access",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Participant ID",
+                        "description": "Leaderboard ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "participantId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2161,6 +2418,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not found",
                         "schema": {
@@ -2176,14 +2439,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/participants/{participant_id}/metric-values": {
+        "/leaderboards/{id}/changes": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of metric values with optional filtering by metric ID and/or participant ID",
+                "description": "Get entry mutations (created, updated, deleted, rank changed) in the order they happened. Omit the cursor for a full sync, then pass next_cursor from the previous response to resume. Keep fetching while has_more is true.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2191,43 +2454,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "metric-values"
+                    "leaderboards"
                 ],
-                "summary": "List metric values",
+                "summary": "Get the change feed for a leaderboard",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by participant ID",
-                        "name": "participant_id",
-                        "in": "path"
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "format": "date-time",
-                        "description": "Filter by timestamp (greater than or equal)",
-                        "name": "from_time",
+                        "description": "Opaque cursor returned as next_cursor by the previous call",
+                        "name": "cursor",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "format": "date-time",
-                        "description": "Filter by timestamp (less than or equal)",
-                        "name": "to_time",
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of changes to return (max 500)",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of metric values",
+                        "description": "Page of changes",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.MetricValueResponse"
-                            }
+                            "$ref": "#/definitions/handlers.ChangeFeedResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -2237,16 +2497,115 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Leaderboard not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/leaderboards/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the leaderboard's standings, or those of one of its snapshots, as CSV, JSON or an Excel workbook. Each row has the rank, participant ID, name and display name, score and, for each of the board's metrics, its value and weighted value. The file is sent as an attachment named after the leaderboard and the time the standings were taken. In CSV, text starting with =, +, -, @, tab or carriage return is prefixed with ' so spreadsheet programs do not run it as a formula.",
+                "produces": [
+                    "text/csv",
+                    "application/json",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "leaderboards"
+                ],
+                "summary": "Export leaderboard standings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format: csv, json or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Snapshot to export instead of the live standings",
+                        "name": "snapshot_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Standings export (JSON format)",
+                        "schema": {
+                            "$ref": "#/definitions/services.StandingsExport"
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "attachment; filename=..."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leaderboard or snapshot not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards/{id}/members": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new metric value record for a participant",
+                "description": "Get the users that have been granted a role on a leaderboard. The owner is not listed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2254,31 +2613,109 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "metric-values"
+                    "leaderboards"
                 ],
-                "summary": "Create a new metric value",
+                "summary": "List leaderboard members",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Participant ID",
-                        "name": "participant_id",
-                        "in": "path"
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of members",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LeaderboardMemberResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leaderboard not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards/{id}/members/{userId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Grant a user the editor or viewer role on a leaderboard. Editors can update the leaderboard and manage its entries and metrics.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboards"
+                ],
+                "summary": "Add or update a leaderboard member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "Metric value data",
-                        "name": "metric_value",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member role",
+                        "name": "member",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateMetricValueRequest"
+                            "$ref": "#/definitions/handlers.SetLeaderboardMemberRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created metric value",
+                    "200": {
+                        "description": "Leaderboard member",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MetricValueResponse"
+                            "$ref": "#/definitions/handlers.LeaderboardMemberResponse"
                         }
                     },
                     "400": {
@@ -2293,8 +2730,83 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Metric or participant not found",
+                        "description": "Leaderboard or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a user's role on a leaderboard",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboards"
+                ],
+                "summary": "Remove a leaderboard member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/middleware.ErrorResponse"
                         }
@@ -2307,563 +2819,7084 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.CreateLeaderboardEntryRequest": {
+        },
+        "/leaderboards/{id}/recompute": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recalculate every team entry of a team leaderboard from the members' metric values and re-rank the board. Team boards are also kept up to date automatically as metric values are recorded; use this after changing the board's metrics, dates, team scoring or team rosters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboards"
+                ],
+                "summary": "Recompute team scores",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Re-ranked leaderboard entries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or not a team leaderboard",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Leaderboard has ended",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bring back a soft-deleted leaderboard and the entries and leaderboard metrics deleted with it. Deleted leaderboards can be found by listing with include_deleted=true. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboards"
+                ],
+                "summary": "Restore a deleted leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored leaderboard",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LeaderboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not deleted or conflicts with live data",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards/{id}/snapshots": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the leaderboard's snapshots newest first, without their entries.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboards"
+                ],
+                "summary": "List leaderboard snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshots",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LeaderboardSnapshotResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid leaderboard ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leaderboard not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save the leaderboard's current standings, as returned by GET /leaderboards/{id}/standings, with each entry's rank, score and metric breakdown. Snapshots keep only participant IDs, so they always show participants' current names.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboards"
+                ],
+                "summary": "Take a leaderboard snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created snapshot",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LeaderboardSnapshotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid leaderboard ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leaderboard not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards/{id}/snapshots/{a}/diff/{b}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get each participant's rank and score movement from snapshot a to snapshot b, for recaps such as week over week. Movements are ordered by rank in b, followed by participants who are only in a. Ranks and scores are left out for the snapshot a participant is not in; participants only in b are new, and those only in a are down with no rank change.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboards"
+                ],
+                "summary": "Compare two leaderboard snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the earlier snapshot",
+                        "name": "a",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the later snapshot",
+                        "name": "b",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Movements between the snapshots",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SnapshotDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Snapshot not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards/{id}/snapshots/{snapshotId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a snapshot of the leaderboard with its entries best rank first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboards"
+                ],
+                "summary": "Get a leaderboard snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Snapshot ID",
+                        "name": "snapshotId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LeaderboardSnapshotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Snapshot not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards/{id}/standings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the leaderboard's entries best rank first, each with its participant, tier, trend and rank_change (as for GET /participants/{id}/leaderboards) and the participant's aggregated values for each of the board's metrics within the board's date range, lowest display_priority first. Inactive participants are left out. With level, the entries are rolled up to that level of the group hierarchy and each row has the participant, rank, score and entry_count instead: level 1 is the participants directly below the leaderboard's group, or the top-level participants when the leaderboard has no group, and entries above the requested level are left out. With compact=true, each row only has the entry and participant IDs, the participant's participant_name (its display name, or its name when it has none) and avatar_url, rank, score, top_percent, tier and last_updated, read from the entries alone without joining participants or aggregating metric values; level takes precedence over compact.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboards"
+                ],
+                "summary": "List leaderboard standings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Hierarchy level to roll up to",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return CompactStandingResponse rows for display only",
+                        "name": "compact",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Standings, or CompactStandingResponse rows with compact=true",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.StandingResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leaderboard not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards/{leaderboard_id}/entries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all entries/rankings for a specific leaderboard",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard-entries"
+                ],
+                "summary": "List all entries for a leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by leaderboard ID",
+                        "name": "leaderboard_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by participant ID",
+                        "name": "participant_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "rank",
+                            "score",
+                            "last_updated",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Field to sort by, defaults to rank",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction, defaults to asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "participant"
+                        ],
+                        "type": "string",
+                        "description": "Related resources to load; entries always include their participant",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list soft-deleted entries (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from an earlier response; on /leaderboards/{leaderboard_id}/entries the entries are only sent again if they changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of leaderboard entries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified since the ETag in If-None-Match"
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "include_deleted without the admin role",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new entry/ranking in a leaderboard",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard-entries"
+                ],
+                "summary": "Create a new leaderboard entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboard_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "Leaderboard entry data",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateLeaderboardEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created leaderboard entry",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leaderboard or participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Participant already has an entry on the leaderboard, returned in existing, or the leaderboard is full or has ended",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards/{leaderboard_id}/metrics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all metrics associated with a specific leaderboard. Without a leaderboard, metrics of private leaderboards the caller cannot read are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard-metrics"
+                ],
+                "summary": "List all metrics for a leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by leaderboard ID",
+                        "name": "leaderboard_id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of leaderboard metrics",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new metric for a leaderboard",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard-metrics"
+                ],
+                "summary": "Create a new leaderboard metric",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboard_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "Leaderboard metric data",
+                        "name": "metric",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateLeaderboardMetricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created leaderboard metric",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leaderboard or metric not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Metric is already attached to the leaderboard, returned in existing",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the participant linked to the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get my participant",
+                "responses": {
+                    "200": {
+                        "description": "Linked participant",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No participant linked to this user",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/entries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the leaderboard entries of the participant linked to the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "List my leaderboard entries",
+                "responses": {
+                    "200": {
+                        "description": "List of entries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No participant linked to this user",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/metric-values": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get metric values of the participant linked to the authenticated user, optionally filtered by metric and time range",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "List my metric values",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by metric ID",
+                        "name": "metric_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter values recorded at or after this time (RFC3339)",
+                        "name": "from_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter values recorded at or before this time (RFC3339)",
+                        "name": "to_time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of metric values",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.MetricValueResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No participant linked to this user",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/rank/{leaderboard_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the entry, including rank and score, of the participant linked to the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get my rank on a leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "leaderboard_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leaderboard entry",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metric-values": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of metric values with optional filtering by metric ID and/or participant ID. When there are more values, the X-Next-Cursor header holds the cursor to pass for the next page; keep the same filters and sort while paging.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metric-values"
+                ],
+                "summary": "List metric values",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Filter by timestamp (greater than or equal)",
+                        "name": "from_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Filter by timestamp (less than or equal)",
+                        "name": "to_time",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "timestamp",
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Field to sort by; defaults to the most recent timestamp first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction, defaults to asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned in X-Next-Cursor by the previous call",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of values to return (max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of metric values",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.MetricValueResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page, absent on the last page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new metric value record for a participant. Send the value in value for integer and decimal metrics, bool_value for boolean metrics and string_value for string metrics.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metric-values"
+                ],
+                "summary": "Create a new metric value",
+                "parameters": [
+                    {
+                        "description": "Metric value data",
+                        "name": "metric_value",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateMetricValueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created metric value",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MetricValueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, or a value not of the metric's data type",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Metric or participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metric-values/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record many metric values at once from a CSV body whose header names the columns: metric_id, value, either participant_id or participant_external_id, and optionally timestamp (RFC3339, defaults to now) and source. Values are read as their metric's data type: a number, true or false, or any text. Values are stored in batches in one transaction, so either every row is imported or none is. Imported values do not emit metric_value.created events; recompute team leaderboards afterwards if they use the metrics.",
+                "consumes": [
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metric-values"
+                ],
+                "summary": "Import metric values",
+                "parameters": [
+                    {
+                        "description": "CSV with a header row, at most 50000 rows",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Number of values imported",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid CSV or rows, or a value not of its metric's data type",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "A row's metric or participant was not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metric-values/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a metric value by its unique ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metric-values"
+                ],
+                "summary": "Get a metric value by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Metric Value ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Metric value details",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MetricValueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing metric value with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metric-values"
+                ],
+                "summary": "Update a metric value",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Metric Value ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated metric value data",
+                        "name": "metric_value",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateMetricValueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated metric value",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MetricValueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, or a value not of the metric's data type",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a metric value by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metric-values"
+                ],
+                "summary": "Delete a metric value",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Metric Value ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all metrics. Pass ids to fetch several metrics by ID in one request; IDs that don't exist are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metrics"
+                ],
+                "summary": "List all metrics",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Also list soft-deleted metrics (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only the metrics with these IDs, comma-separated or repeated (max 100)",
+                        "name": "ids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of metrics",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.MetricResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "include_deleted without the admin role",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new metric with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metrics"
+                ],
+                "summary": "Create a new metric",
+                "parameters": [
+                    {
+                        "description": "Metric data",
+                        "name": "metric",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateMetricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created metric",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MetricResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a metric by its unique ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metrics"
+                ],
+                "summary": "Get a metric by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Metric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Metric details",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MetricResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing metric with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metrics"
+                ],
+                "summary": "Update a metric",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Metric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated metric data",
+                        "name": "metric",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateMetricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated metric",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MetricResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a metric by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metrics"
+                ],
+                "summary": "Delete a metric",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Metric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/{id}/aggregate": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aggregate the metric's values with its aggregation type, in total and, with group_by, per UTC hour, day, week (starting Monday) or month. value holds the aggregate the metric is configured for; count, sum, min, max and average are always included. Intervals without values are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metrics"
+                ],
+                "summary": "Aggregate a metric's values",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Metric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only aggregate this participant's values",
+                        "name": "participant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Only values recorded at or after this time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Only values recorded at or before this time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hour",
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "Interval to aggregate values per",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Aggregated values",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MetricAggregationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Metric not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bring back a soft-deleted metric. Deleted metrics can be found by listing with include_deleted=true. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metrics"
+                ],
+                "summary": "Restore a deleted metric",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Metric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored metric",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MetricResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not deleted or conflicts with live data",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics/{metric_id}/values": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of metric values with optional filtering by metric ID and/or participant ID. When there are more values, the X-Next-Cursor header holds the cursor to pass for the next page; keep the same filters and sort while paging.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metric-values"
+                ],
+                "summary": "List metric values",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by metric ID",
+                        "name": "metric_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Filter by timestamp (greater than or equal)",
+                        "name": "from_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Filter by timestamp (less than or equal)",
+                        "name": "to_time",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "timestamp",
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Field to sort by; defaults to the most recent timestamp first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction, defaults to asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned in X-Next-Cursor by the previous call",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of values to return (max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of metric values",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.MetricValueResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page, absent on the last page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new metric value record for a participant. Send the value in value for integer and decimal metrics, bool_value for boolean metrics and string_value for string metrics.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metric-values"
+                ],
+                "summary": "Create a new metric value",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Metric ID",
+                        "name": "metric_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "Metric value data",
+                        "name": "metric_value",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateMetricValueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created metric value",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MetricValueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, or a value not of the metric's data type",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Metric or participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all participants, optionally only those that have every one of the given tags. Participants are listed oldest first unless a sort is given. Pass ids to fetch several participants by ID in one request; IDs that don't exist are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "List all participants",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only participants with this tag; repeat or comma-separate to require several",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "display_name",
+                            "type",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction, defaults to asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list soft-deleted participants (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only the participants with these IDs, comma-separated or repeated (max 100)",
+                        "name": "ids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of participants",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ParticipantResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sort parameters",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "include_deleted without the admin role",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new participant with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Create a new participant",
+                "parameters": [
+                    {
+                        "description": "Participant data",
+                        "name": "participant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateParticipantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created participant",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already linked to another participant or external_id in use",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/by-external-id/{external_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a participant by the external_id it was created with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Get a participant by external ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "External ID",
+                        "name": "external_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Participant details",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create many participants at once from a CSV body whose header names the columns: name, type, and optionally external_id, display_name, avatar_url and country. Participants are created active, in batches in one transaction, so either every row is imported or none is.",
+                "consumes": [
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Import participants",
+                "parameters": [
+                    {
+                        "description": "CSV with a header row, at most 50000 rows",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Number of participants imported",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid CSV or rows",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A row's external_id is already in use",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/tags/add": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add every tag to every listed participant. Tags a participant already has are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Tag participants",
+                "parameters": [
+                    {
+                        "description": "Participants and tags",
+                        "name": "tags",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BulkTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of tags added",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BulkTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/tags/remove": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove every tag from every listed participant. Tags a participant does not have are ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Untag participants",
+                "parameters": [
+                    {
+                        "description": "Participants and tags",
+                        "name": "tags",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BulkTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of tags removed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BulkTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a participant by its unique ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Get a participant by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Participant details",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing participant with the provided details. Set is_active to false to leave the participant out of published standings while keeping its entries; team boards re-rank on their next recompute.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Update a participant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated participant data",
+                        "name": "participant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateParticipantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated participant",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already linked to another participant or external_id in use",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a participant by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Delete a participant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}/anonymize": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Irreversibly scrub a participant's personal data (name, external_id, metadata, profile fields, linked user and name history). Leaderboard entries and scores are kept under a random pseudonym. Set delete_metric_values to also permanently delete the participant's raw metric values. The operation is recorded in an audit record, which is returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Anonymize a participant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Anonymization options",
+                        "name": "anonymization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AnonymizeParticipantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit record of the anonymization",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ParticipantAnonymizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Participant already anonymized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}/children": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the participants directly below a participant in the group hierarchy, or every descendant with recursive=true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "List participant children",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include every descendant, not just direct children",
+                        "name": "recursive",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Child participants",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ParticipantResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid participant ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}/device-tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all push notification device tokens registered for a participant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "List device tokens for a participant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of device tokens",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.DeviceTokenResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Map a push notification device token to a participant. Registering an existing token moves it to this participant.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Register a device token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Device token data",
+                        "name": "deviceToken",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterDeviceTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Registered device token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}/device-tokens/{tokenId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop sending push notifications to a device",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Delete a device token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device token ID",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the names and display names a participant has used, oldest first, with the period each was in effect. Pass at for only the name in use at that time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Get participant name history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name in use at this time (RFC3339)",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Name history",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ParticipantNameChangeResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}/leaderboards": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every leaderboard the participant has an entry on, best rank first, with its rank, score, tier and trend. The tier buckets the rank by the share of ranked entries at or above it: diamond (top 1%), platinum (5%), gold (10%), silver (25%), bronze (50%) or none. The trend and rank_change come from the entry's most recent rank change. Leaderboards the caller cannot read are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "List a participant's leaderboards",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leaderboard standings",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ParticipantLeaderboardResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid participant ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the members of a team participant. By default returns the current roster; pass at for the roster at a point in time, or history=true for every past and present membership.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "List team members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Roster at this time (RFC3339), defaults to now",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return every membership, including former members",
+                        "name": "history",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Team roster",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.TeamMemberResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or participant is not a team",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a participant to a team from joined_at, or from now. Teams cannot be members of other teams.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Add a team member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member to add",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddTeamMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Team membership",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TeamMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Participant is already on the team",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}/members/{memberId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "End a member's current membership at left_at, or now. The membership is kept in the team's history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Remove a team member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member participant ID",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "When the member left (RFC3339), defaults to now",
+                        "name": "left_at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ended team membership",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TeamMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found or participant not on the team",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}/metrics/{metric_id}/timeseries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aggregate the participant's values for the metric per UTC hour, day, week (starting Monday) or month, computed in SQL. Points cover every interval from from to to, or from the first value up to now, zero-filled where nothing was recorded, so they can be charted as is. value holds the aggregate the metric is configured for. A range can span at most 1000 points.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Get a participant's metric time series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metric ID",
+                        "name": "metric_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "hour",
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Interval of each point",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Only values recorded at or after this time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Only values recorded at or before this time",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time series",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MetricTimeseriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Participant or metric not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}/parent": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a participant, and everything below it, under a group participant. The parent must have type \"group\" and cannot be one of the participant's descendants.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Set participant parent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Parent group",
+                        "name": "parent",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetParticipantParentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated participant",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or hierarchy",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Participant or parent not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a participant from its group so it sits at the top level. Its own descendants move with it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Clear participant parent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated participant",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid participant ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bring back a soft-deleted participant. Deleted participants can be found by listing with include_deleted=true. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Restore a deleted participant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored participant",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not deleted or conflicts with live data",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Summarise a participant: per-metric aggregates over its lifetime and the metric's current reset period (UTC, weeks start on Monday), its current rank on each leaderboard, the best rank it has held, and counts of recorded metric values overall and over the last 30 days. Leaderboards the caller cannot read are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "participants"
+                ],
+                "summary": "Get participant statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Participant statistics",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ParticipantStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid participant ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/participants/{participant_id}/metric-values": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of metric values with optional filtering by metric ID and/or participant ID. When there are more values, the X-Next-Cursor header holds the cursor to pass for the next page; keep the same filters and sort while paging.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metric-values"
+                ],
+                "summary": "List metric values",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by participant ID",
+                        "name": "participant_id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Filter by timestamp (greater than or equal)",
+                        "name": "from_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Filter by timestamp (less than or equal)",
+                        "name": "to_time",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "timestamp",
+                            "value",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Field to sort by; defaults to the most recent timestamp first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction, defaults to asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned in X-Next-Cursor by the previous call",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of values to return (max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of metric values",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.MetricValueResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page, absent on the last page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new metric value record for a participant. Send the value in value for integer and decimal metrics, bool_value for boolean metrics and string_value for string metrics.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metric-values"
+                ],
+                "summary": "Create a new metric value",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Participant ID",
+                        "name": "participant_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "Metric value data",
+                        "name": "metric_value",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateMetricValueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created metric value",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MetricValueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, or a value not of the metric's data type",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Metric or participant not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/leaderboards/{id}": {
+            "get": {
+                "description": "Retrieve a public leaderboard without a token, like GET /leaderboards/{id}. Responses are the same for every caller, so they carry Cache-Control: public and can be kept by shared caches. Private leaderboards respond with 404.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboards"
+                ],
+                "summary": "Get a public leaderboard by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related resources to load: metrics, entries",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from an earlier response; the leaderboard is only sent again if it changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leaderboard details",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LeaderboardResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified since the ETag in If-None-Match"
+                    },
+                    "400": {
+                        "description": "Invalid ID or include",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/leaderboards/{id}/entries": {
+            "get": {
+                "description": "Get the entries of a public leaderboard without a token, like GET /leaderboards/{id}/entries. Responses are the same for every caller, so they carry Cache-Control: public and can be kept by shared caches. Private leaderboards respond with 404.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard-entries"
+                ],
+                "summary": "List the entries of a public leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leaderboard ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by participant ID",
+                        "name": "participant_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "rank",
+                            "score",
+                            "last_updated",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Field to sort by, defaults to rank",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction, defaults to asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "participant"
+                        ],
+                        "type": "string",
+                        "description": "Related resources to load; entries always include their participant",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from an earlier response; the entries are only sent again if they changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of leaderboard entries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified since the ETag in If-None-Match"
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leaderboard not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks that the database can be reached, that it has had every migration this build knows and that the standings cache can be reached when REDIS_URL is set. Returns 200 when every check passes and 503 otherwise, with the result of each check either way.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Ready",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Not ready",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Search leaderboards, participants (by name or display name) and metrics whose name contains q, ignoring case. Exact matches come first, then names starting with q, then the rest by name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text to search for",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of types to search: leaderboard, participant, metric (default all)",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page of results to return",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of results per page (max 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching resources",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.SearchResultResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Page": {
+                                "type": "integer",
+                                "description": "Page returned"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Number of matches across all pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient scope",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/service-tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every service token, including revoked and expired ones. Token values are never returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service-tokens"
+                ],
+                "summary": "List service tokens",
+                "responses": {
+                    "200": {
+                        "description": "List of service tokens",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ServiceTokenResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mint a JWT for a machine client with an explicit audience, scopes and expiry. Include leaderboard-service in the audience for the token to be accepted by this API. The token is returned only in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service-tokens"
+                ],
+                "summary": "Create a service token",
+                "parameters": [
+                    {
+                        "description": "Service token data",
+                        "name": "serviceToken",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateServiceTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created service token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreatedServiceTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/service-tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a service token so it can no longer authenticate. The record stays in the inventory.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service-tokens"
+                ],
+                "summary": "Revoke a service token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all webhook subscriptions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "List of webhook subscriptions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.WebhookSubscriptionResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a target URL that will receive signed event deliveries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create a webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Webhook subscription data",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateWebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created webhook subscription",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookSubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leaderboard not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a webhook subscription and its last delivery status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook subscription by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook subscription details",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookSubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the target, secret, event types, or leaderboard filter of a subscription",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated webhook subscription data",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateWebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated webhook subscription",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookSubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a webhook subscription by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/test": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Synchronously deliver a signed webhook.test event and report the receiver's response",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Send a test delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery outcome",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookTestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440007"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "metrics-ingest"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "lbk_Zx8kQ2mP"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "moderator"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read",
+                        "write"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                }
+            }
+        },
+        "handlers.ActivityStatsResponse": {
+            "type": "object",
+            "properties": {
+                "first_recorded_at": {
+                    "type": "string",
+                    "example": "2022-06-01T09:00:00Z"
+                },
+                "last_recorded_at": {
+                    "type": "string",
+                    "example": "2023-01-05T12:00:00Z"
+                },
+                "leaderboard_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "metric_value_count": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "recent_active_days": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "recent_value_count": {
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "handlers.AddTeamMemberRequest": {
+            "type": "object",
+            "required": [
+                "member_id"
+            ],
+            "properties": {
+                "joined_at": {
+                    "description": "Defaults to now",
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "member_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                }
+            }
+        },
+        "handlers.AdminOverviewResponse": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "$ref": "#/definitions/handlers.OverviewCountsResponse"
+                },
+                "database_pool": {
+                    "$ref": "#/definitions/handlers.PoolStatsResponse"
+                },
+                "generated_at": {
+                    "type": "string",
+                    "example": "2023-01-08T12:00:00Z"
+                },
+                "metric_values_per_day": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.DailyCountResponse"
+                    }
+                },
+                "recent_errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.RecentErrorResponse"
+                    }
+                }
+            }
+        },
+        "handlers.AnonymizeParticipantRequest": {
+            "type": "object",
+            "properties": {
+                "delete_metric_values": {
+                    "description": "Also permanently delete the participant's raw metric values",
+                    "type": "boolean",
+                    "example": true
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "GDPR erasure request #1234"
+                }
+            }
+        },
+        "handlers.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "actor_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "actor_type": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "after": {},
+                "before": {},
+                "changes": {},
+                "entity_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "leaderboard"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                }
+            }
+        },
+        "handlers.BoardRankResponse": {
+            "type": "object",
+            "properties": {
+                "best_rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_updated": {
+                    "type": "string",
+                    "example": "2023-01-05T12:00:00Z"
+                },
+                "leaderboard_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "leaderboard_name": {
+                    "type": "string",
+                    "example": "Weekly Sales"
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "score": {
+                    "type": "number",
+                    "example": 420
+                }
+            }
+        },
+        "handlers.BulkTagRequest": {
+            "type": "object",
+            "required": [
+                "participant_ids",
+                "tags"
+            ],
+            "properties": {
+                "participant_ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440000"
+                    ]
+                },
+                "tags": {
+                    "description": "Trimmed and lowercased",
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vip"
+                    ]
+                }
+            }
+        },
+        "handlers.BulkTagResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Tags added or removed",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "handlers.ChangeFeedResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.EventResponse"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "ZXY6NDI"
+                }
+            }
+        },
+        "handlers.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "role",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "metrics-ingest"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "moderator",
+                        "user"
+                    ],
+                    "example": "moderator"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read",
+                        "write"
+                    ]
+                }
+            }
+        },
+        "handlers.CreateLeaderboardEntryRequest": {
+            "type": "object",
+            "required": [
+                "leaderboard_id",
+                "rank",
+                "score"
+            ],
+            "properties": {
+                "last_updated": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "leaderboard_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "participant_external_id": {
+                    "description": "Alternative to participant_id: the external_id the participant was created with",
+                    "type": "string",
+                    "example": "external-123"
+                },
+                "participant_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "rank": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "score": {
+                    "type": "number",
+                    "example": 100.5
+                }
+            }
+        },
+        "handlers.CreateLeaderboardMetricRequest": {
+            "type": "object",
+            "required": [
+                "leaderboard_id",
+                "metric_id",
+                "weight"
+            ],
+            "properties": {
+                "display_priority": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "leaderboard_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "metric_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "weight": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 1
+                }
+            }
+        },
+        "handlers.CreateLeaderboardMetricsItem": {
+            "type": "object",
+            "required": [
+                "metric_id",
+                "weight"
+            ],
+            "properties": {
+                "display_priority": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "metric_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "weight": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 1
+                }
+            }
+        },
+        "handlers.CreateLeaderboardRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "name",
+                "sort_order",
+                "time_frame",
+                "type",
+                "visibility_scope"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "tournament"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Weekly tournament for active players"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2023-01-07T23:59:59Z"
+                },
+                "group_id": {
+                    "description": "Only rank participants below this group",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-44665544000d"
+                },
+                "is_active": {
+                    "description": "Defaults to true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_entries": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 100
+                },
+                "max_entries_policy": {
+                    "description": "What happens to new entries once max_entries is reached",
+                    "type": "string",
+                    "enum": [
+                        "reject",
+                        "evict_last"
+                    ],
+                    "example": "reject"
+                },
+                "metrics": {
+                    "description": "Metrics to attach, created together with the leaderboard",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.CreateLeaderboardMetricsItem"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Weekly Tournament"
+                },
+                "sort_order": {
+                    "type": "string",
+                    "enum": [
+                        "ascending",
+                        "descending"
+                    ],
+                    "example": "descending"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "team_score_aggregation": {
+                    "description": "Team boards only: how member scores roll up into the team's score",
+                    "type": "string",
+                    "enum": [
+                        "sum",
+                        "average",
+                        "top_k"
+                    ],
+                    "example": "sum"
+                },
+                "team_score_top_k": {
+                    "description": "Required for top_k",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                },
+                "time_frame": {
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly",
+                        "monthly",
+                        "yearly",
+                        "all-time",
+                        "custom"
+                    ],
+                    "example": "weekly"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "individual",
+                        "team"
+                    ],
+                    "example": "individual"
+                },
+                "visibility_scope": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "private"
+                    ],
+                    "example": "public"
+                }
+            }
+        },
+        "handlers.CreateMetricRequest": {
+            "type": "object",
+            "required": [
+                "aggregation_type",
+                "data_type",
+                "name",
+                "reset_period"
+            ],
+            "properties": {
+                "aggregation_type": {
+                    "description": "Boolean and string metrics only support count and last",
+                    "type": "string",
+                    "enum": [
+                        "sum",
+                        "average",
+                        "count",
+                        "min",
+                        "max",
+                        "last"
+                    ],
+                    "example": "sum"
+                },
+                "data_type": {
+                    "type": "string",
+                    "enum": [
+                        "integer",
+                        "decimal",
+                        "boolean",
+                        "string"
+                    ],
+                    "example": "integer"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Number of calls completed in a month"
+                },
+                "is_higher_better": {
+                    "description": "Defaults to true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "monthly_calls_completed"
+                },
+                "reset_period": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "daily",
+                        "weekly",
+                        "monthly",
+                        "yearly"
+                    ],
+                    "example": "monthly"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "calls"
+                }
+            }
+        },
+        "handlers.CreateMetricValueRequest": {
+            "type": "object",
+            "required": [
+                "metric_id"
+            ],
+            "properties": {
+                "bool_value": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "context": {},
+                "metric_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "participant_external_id": {
+                    "description": "Alternative to participant_id: the external_id the participant was created with",
+                    "type": "string",
+                    "example": "external-123"
+                },
+                "participant_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "call_system"
+                },
+                "string_value": {
+                    "type": "string",
+                    "example": "gold"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "value": {
+                    "description": "The value goes in the field of the metric's data type: value for integer and decimal metrics, bool_value for\nboolean ones and string_value for string ones",
+                    "type": "number",
+                    "example": 42.5
+                }
+            }
+        },
+        "handlers.CreateParticipantRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "avatar_url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://cdn.example.com/avatars/john.png"
+                },
+                "country": {
+                    "description": "Uppercase ISO 3166-1 alpha-2 code",
+                    "type": "string",
+                    "example": "US"
+                },
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Johnny D"
+                },
+                "external_id": {
+                    "description": "Must be unique when set",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "external-123"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "individual",
+                        "team",
+                        "group"
+                    ],
+                    "example": "individual"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440008"
+                }
+            }
+        },
+        "handlers.CreateServiceTokenRequest": {
+            "type": "object",
+            "required": [
+                "audience",
+                "expires_at",
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "audience": {
+                    "description": "Defaults to this service",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "leaderboard-service"
+                    ]
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "rankings-worker"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "metrics:write"
+                    ]
+                }
+            }
+        },
+        "handlers.CreateWebhookSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "secret",
+                "target_url"
+            ],
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "entry.created",
+                        "entry.rank_changed"
+                    ]
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "leaderboard_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "secret": {
+                    "type": "string",
+                    "minLength": 16,
+                    "example": "a-long-random-shared-secret"
+                },
+                "target_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/leaderboards"
+                }
+            }
+        },
+        "handlers.CreatedAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "$ref": "#/definitions/handlers.APIKeyResponse"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "lbk_Zx8kQ2mPq9..."
+                }
+            }
+        },
+        "handlers.CreatedServiceTokenResponse": {
+            "type": "object",
+            "properties": {
+                "service_token": {
+                    "$ref": "#/definitions/handlers.ServiceTokenResponse"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsImtpZCI6ImRlZmF1bHQifQ..."
+                }
+            }
+        },
+        "handlers.DailyCountResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 4210
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2023-01-08"
+                }
+            }
+        },
+        "handlers.DeviceTokenResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440006"
+                },
+                "participant_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "fcm"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "dGhpcyBpcyBhIGRldmljZSB0b2tlbg"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                }
+            }
+        },
+        "handlers.EventResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "data": {},
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "leaderboard_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "entry.rank_changed"
+                }
+            }
+        },
+        "handlers.FeatureFlagResponse": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "description": "Value the instance was started with",
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Serve the standings of public leaderboards from Redis, when REDIS_URL is set"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "standings_cache"
+                },
+                "overridden": {
+                    "description": "Whether an admin has set the flag",
+                    "type": "boolean",
+                    "example": true
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "updated_by": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440008"
+                }
+            }
+        },
+        "handlers.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "handlers.IncrementScoreRequest": {
+            "type": "object",
+            "required": [
+                "delta"
+            ],
+            "properties": {
+                "delta": {
+                    "description": "Added to the score, negative to take away",
+                    "type": "number",
+                    "example": 5
+                }
+            }
+        },
+        "handlers.LeaderboardEntryResponse": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/avatars/jane.png"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "last_updated": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "leaderboard_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "participant": {
+                    "description": "Participant profile for display",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                        }
+                    ]
+                },
+                "participant_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "participant_name": {
+                    "description": "Participant's shown name and avatar, kept on the entry",
+                    "type": "string",
+                    "example": "Jane D."
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "score": {
+                    "type": "number",
+                    "example": 100.5
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.LeaderboardMemberResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440009"
+                },
+                "leaderboard_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "editor"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440008"
+                }
+            }
+        },
+        "handlers.LeaderboardMetricResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "display_priority": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440004"
+                },
+                "leaderboard_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "metric_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.LeaderboardParticipantAccessResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-44665544000a"
+                },
+                "leaderboard_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "participant_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                }
+            }
+        },
+        "handlers.LeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "tournament"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Weekly tournament for active players"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2023-01-07T23:59:59Z"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.LeaderboardEntryResponse"
+                    }
+                },
+                "group_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-44665544000d"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_entries": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "max_entries_policy": {
+                    "type": "string",
+                    "example": "reject"
+                },
+                "metrics": {
+                    "description": "Only loaded with ?include=metrics and ?include=entries",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.LeaderboardMetricResponse"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Weekly Tournament"
+                },
+                "owner_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440008"
+                },
+                "sort_order": {
+                    "type": "string",
+                    "example": "descending"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "team_score_aggregation": {
+                    "type": "string",
+                    "example": "sum"
+                },
+                "team_score_top_k": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "time_frame": {
+                    "type": "string",
+                    "example": "weekly"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "individual"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "version": {
+                    "description": "Incremented by every update; send it back in an update to only apply it to this version",
+                    "type": "integer",
+                    "example": 1
+                },
+                "visibility_scope": {
+                    "type": "string",
+                    "example": "public"
+                }
+            }
+        },
+        "handlers.LeaderboardSnapshotEntryResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440011"
+                },
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SnapshotMetricResponse"
+                    }
+                },
+                "participant": {
+                    "description": "Participant profile for display, as it is now",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handlers.ParticipantResponse"
+                        }
+                    ]
+                },
+                "participant_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "score": {
+                    "type": "number",
+                    "example": 420
+                },
+                "snapshot_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440010"
+                }
+            }
+        },
+        "handlers.LeaderboardSnapshotResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-08T00:00:00Z"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.LeaderboardSnapshotEntryResponse"
+                    }
+                },
+                "entry_count": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440010"
+                },
+                "leaderboard_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "taken_at": {
+                    "type": "string",
+                    "example": "2023-01-08T00:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-01-08T00:00:00Z"
+                }
+            }
+        },
+        "handlers.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "scopes": {
+                    "description": "Optional, restricts the token to fewer scopes than the role allows",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "leaderboards:write"
+                    ]
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "handlers.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "leaderboards:write",
+                        "leaderboards:admin"
+                    ]
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "handlers.MetricAggregateResponse": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number",
+                    "example": 35
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "last_bool_value": {
+                    "description": "Last value of a boolean or string metric",
+                    "type": "boolean",
+                    "example": true
+                },
+                "last_recorded_at": {
+                    "type": "string",
+                    "example": "2023-01-05T12:00:00Z"
+                },
+                "last_string_value": {
+                    "type": "string",
+                    "example": "gold"
+                },
+                "max": {
+                    "type": "number",
+                    "example": 80
+                },
+                "min": {
+                    "type": "number",
+                    "example": 10
+                },
+                "sum": {
+                    "type": "number",
+                    "example": 420
+                },
+                "value": {
+                    "type": "number",
+                    "example": 420
+                }
+            }
+        },
+        "handlers.MetricAggregationResponse": {
+            "type": "object",
+            "properties": {
+                "aggregation_type": {
+                    "type": "string",
+                    "example": "sum"
+                },
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.MetricBucketResponse"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "group_by": {
+                    "type": "string",
+                    "example": "day"
+                },
+                "metric_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "metric_name": {
+                    "type": "string",
+                    "example": "Calls Made"
+                },
+                "participant_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2023-01-31T23:59:59Z"
+                },
+                "total": {
+                    "$ref": "#/definitions/handlers.MetricAggregateResponse"
+                }
+            }
+        },
+        "handlers.MetricBucketResponse": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number",
+                    "example": 14
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "last_bool_value": {
+                    "description": "Last value of a boolean or string metric",
+                    "type": "boolean",
+                    "example": true
+                },
+                "last_recorded_at": {
+                    "type": "string",
+                    "example": "2023-01-02T17:30:00Z"
+                },
+                "last_string_value": {
+                    "type": "string",
+                    "example": "gold"
+                },
+                "max": {
+                    "type": "number",
+                    "example": 20
+                },
+                "min": {
+                    "type": "number",
+                    "example": 10
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "2023-01-02T00:00:00Z"
+                },
+                "sum": {
+                    "type": "number",
+                    "example": 42
+                },
+                "value": {
+                    "type": "number",
+                    "example": 42
+                }
+            }
+        },
+        "handlers.MetricResponse": {
+            "type": "object",
+            "properties": {
+                "aggregation_type": {
+                    "type": "string",
+                    "example": "sum"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "data_type": {
+                    "type": "string",
+                    "example": "integer"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Number of calls completed in a month"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "is_higher_better": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "monthly_calls_completed"
+                },
+                "reset_period": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "calls"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                }
+            }
+        },
+        "handlers.MetricStatsResponse": {
+            "type": "object",
+            "properties": {
+                "aggregation_type": {
+                    "type": "string",
+                    "example": "sum"
+                },
+                "current_period": {
+                    "$ref": "#/definitions/handlers.MetricAggregateResponse"
+                },
+                "lifetime": {
+                    "$ref": "#/definitions/handlers.MetricAggregateResponse"
+                },
+                "metric_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "metric_name": {
+                    "type": "string",
+                    "example": "Calls Made"
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "2023-01-02T00:00:00Z"
+                },
+                "reset_period": {
+                    "type": "string",
+                    "example": "weekly"
+                }
+            }
+        },
+        "handlers.MetricTimeseriesResponse": {
+            "type": "object",
+            "properties": {
+                "aggregation_type": {
+                    "type": "string",
+                    "example": "sum"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "day"
+                },
+                "metric_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "metric_name": {
+                    "type": "string",
+                    "example": "Calls Made"
+                },
+                "participant_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.MetricBucketResponse"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2023-01-31T23:59:59Z"
+                }
+            }
+        },
+        "handlers.MetricValueResponse": {
+            "type": "object",
+            "properties": {
+                "bool_value": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "context": {},
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "metric_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "participant_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "call_system"
+                },
+                "string_value": {
+                    "type": "string",
+                    "example": "gold"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "value": {
+                    "description": "1 or 0 for boolean metrics and 0 for string ones",
+                    "type": "number",
+                    "example": 42.5
+                }
+            }
+        },
+        "handlers.OverviewCountsResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "integer",
+                    "example": 1520
+                },
+                "leaderboards": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "participants": {
+                    "type": "integer",
+                    "example": 340
+                }
+            }
+        },
+        "handlers.ParticipantAnonymizationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-44665544000f"
+                },
+                "metric_values_deleted": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "participant_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "pseudonym": {
+                    "type": "string",
+                    "example": "Anonymous 3f2a9c1b"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "GDPR erasure request #1234"
+                },
+                "requested_by": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440008"
+                }
+            }
+        },
+        "handlers.ParticipantLeaderboardResponse": {
             "type": "object",
-            "required": [
-                "leaderboard_id",
-                "participant_id",
-                "rank",
-                "score"
-            ],
             "properties": {
                 "last_updated": {
                     "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                    "example": "2023-01-05T12:00:00Z"
                 },
-                "leaderboard_id": {
+                "leaderboard": {
+                    "$ref": "#/definitions/handlers.LeaderboardResponse"
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "rank_change": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "ranked_entries": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "score": {
+                    "type": "number",
+                    "example": 420
+                },
+                "tier": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "enum": [
+                        "diamond",
+                        "platinum",
+                        "gold",
+                        "silver",
+                        "bronze",
+                        "none"
+                    ],
+                    "example": "platinum"
+                },
+                "top_percent": {
+                    "type": "number",
+                    "example": 2.5
+                },
+                "trend": {
+                    "type": "string",
+                    "enum": [
+                        "up",
+                        "down",
+                        "steady",
+                        "new"
+                    ],
+                    "example": "up"
+                }
+            }
+        },
+        "handlers.ParticipantMovementResponse": {
+            "type": "object",
+            "properties": {
+                "from_rank": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "from_score": {
+                    "type": "number",
+                    "example": 310
+                },
+                "participant": {
+                    "$ref": "#/definitions/handlers.ParticipantResponse"
                 },
                 "participant_id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440001"
                 },
-                "rank": {
+                "rank_change": {
                     "type": "integer",
-                    "minimum": 1,
-                    "example": 1
+                    "example": 3
                 },
-                "score": {
+                "score_change": {
                     "type": "number",
-                    "example": 100.5
+                    "example": 110
+                },
+                "to_rank": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "to_score": {
+                    "type": "number",
+                    "example": 420
+                },
+                "trend": {
+                    "type": "string",
+                    "enum": [
+                        "up",
+                        "down",
+                        "steady",
+                        "new"
+                    ],
+                    "example": "up"
                 }
             }
         },
-        "handlers.CreateLeaderboardMetricRequest": {
+        "handlers.ParticipantNameChangeResponse": {
             "type": "object",
-            "required": [
-                "leaderboard_id",
-                "metric_id",
-                "weight"
-            ],
             "properties": {
-                "display_priority": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 0
+                "display_name": {
+                    "type": "string",
+                    "example": "Johnny D"
                 },
-                "leaderboard_id": {
+                "effective_from": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "2023-01-01T00:00:00Z"
                 },
-                "metric_id": {
+                "effective_to": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                    "example": "2023-06-01T00:00:00Z"
                 },
-                "weight": {
-                    "type": "number",
-                    "minimum": 0,
-                    "example": 1
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-44665544000e"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "participant_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 }
             }
         },
-        "handlers.CreateLeaderboardRequest": {
+        "handlers.ParticipantResponse": {
             "type": "object",
-            "required": [
-                "category",
-                "name",
-                "sort_order",
-                "time_frame",
-                "type",
-                "visibility_scope"
-            ],
             "properties": {
-                "category": {
+                "anonymized_at": {
                     "type": "string",
-                    "example": "tournament"
+                    "example": "2023-06-01T00:00:00Z"
                 },
-                "description": {
+                "avatar_url": {
                     "type": "string",
-                    "example": "Weekly tournament for active players"
+                    "example": "https://cdn.example.com/avatars/john.png"
                 },
-                "end_date": {
+                "country": {
                     "type": "string",
-                    "example": "2023-01-07T23:59:59Z"
+                    "example": "US"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "display_name": {
+                    "type": "string",
+                    "example": "Johnny D"
+                },
+                "external_id": {
+                    "type": "string",
+                    "example": "external-123"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
                 "is_active": {
                     "type": "boolean",
                     "example": true
                 },
-                "max_entries": {
-                    "type": "integer",
-                    "minimum": 1,
-                    "example": 100
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
                 },
                 "name": {
                     "type": "string",
-                    "example": "Weekly Tournament"
+                    "example": "John Doe"
                 },
-                "sort_order": {
+                "parent_id": {
                     "type": "string",
-                    "enum": [
-                        "ascending",
-                        "descending"
-                    ],
-                    "example": "descending"
+                    "example": "550e8400-e29b-41d4-a716-44665544000d"
                 },
-                "start_date": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vip"
+                    ]
+                },
+                "type": {
                     "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                    "example": "individual"
                 },
-                "time_frame": {
+                "updated_at": {
                     "type": "string",
-                    "enum": [
-                        "daily",
-                        "weekly",
-                        "monthly",
-                        "yearly",
-                        "all-time",
-                        "custom"
-                    ],
-                    "example": "weekly"
+                    "example": "2023-01-01T00:00:00Z"
                 },
-                "type": {
+                "user_id": {
                     "type": "string",
-                    "enum": [
-                        "individual",
-                        "team"
-                    ],
-                    "example": "individual"
+                    "example": "550e8400-e29b-41d4-a716-446655440008"
+                }
+            }
+        },
+        "handlers.ParticipantStatsResponse": {
+            "type": "object",
+            "properties": {
+                "activity": {
+                    "$ref": "#/definitions/handlers.ActivityStatsResponse"
                 },
-                "visibility_scope": {
+                "best_rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "current_ranks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BoardRankResponse"
+                    }
+                },
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.MetricStatsResponse"
+                    }
+                },
+                "participant_id": {
                     "type": "string",
-                    "enum": [
-                        "public",
-                        "private"
-                    ],
-                    "example": "public"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 }
             }
         },
-        "handlers.CreateMetricRequest": {
+        "handlers.PasswordResetConfirmRequest": {
             "type": "object",
             "required": [
-                "aggregation_type",
-                "data_type",
-                "name",
-                "reset_period"
+                "password",
+                "token"
             ],
             "properties": {
-                "aggregation_type": {
+                "password": {
+                    "description": "bcrypt ignores anything past 72 bytes",
                     "type": "string",
-                    "enum": [
-                        "sum",
-                        "average",
-                        "count",
-                        "min",
-                        "max",
-                        "last"
-                    ],
-                    "example": "sum"
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "newsecurepass123"
                 },
-                "data_type": {
+                "token": {
                     "type": "string",
-                    "enum": [
-                        "integer",
-                        "decimal",
-                        "boolean",
-                        "string"
-                    ],
-                    "example": "integer"
-                },
-                "description": {
+                    "example": "q1w2e3r4t5y6u7i8o9p0..."
+                }
+            }
+        },
+        "handlers.PasswordResetRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
                     "type": "string",
-                    "example": "Number of calls completed in a month"
+                    "example": "user@example.com"
+                }
+            }
+        },
+        "handlers.PoolStatsResponse": {
+            "type": "object",
+            "properties": {
+                "idle": {
+                    "type": "integer",
+                    "example": 9
                 },
-                "is_higher_better": {
-                    "type": "boolean",
-                    "example": true
+                "in_use": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "name": {
+                "max_idle_closed": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "max_lifetime_closed": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "max_open_connections": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "open_connections": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "wait_count": {
+                    "type": "integer",
+                    "example": 41
+                },
+                "wait_duration_ms": {
+                    "type": "integer",
+                    "example": 820
+                }
+            }
+        },
+        "handlers.ReadinessCheckResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string",
-                    "example": "monthly_calls_completed"
+                    "example": "dial tcp 10.0.0.5:5432: connect: connection refused"
                 },
-                "reset_period": {
+                "status": {
                     "type": "string",
                     "enum": [
-                        "none",
-                        "daily",
-                        "weekly",
-                        "monthly",
-                        "yearly"
+                        "ok",
+                        "failing",
+                        "disabled"
                     ],
-                    "example": "monthly"
+                    "example": "ok"
+                }
+            }
+        },
+        "handlers.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/handlers.ReadinessCheckResponse"
+                    }
                 },
-                "unit": {
-                    "type": "string",
-                    "example": "calls"
+                "ready": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "handlers.CreateMetricValueRequest": {
+        "handlers.RecentErrorResponse": {
             "type": "object",
-            "required": [
-                "metric_id",
-                "participant_id",
-                "value"
-            ],
             "properties": {
-                "context": {},
-                "metric_id": {
+                "method": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "GET"
                 },
-                "participant_id": {
+                "occurred_at": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                    "example": "2023-01-08T11:58:00Z"
                 },
-                "source": {
+                "path": {
                     "type": "string",
-                    "example": "call_system"
+                    "example": "/api/v1/leaderboards/550e8400-e29b-41d4-a716-446655440000/standings"
                 },
-                "timestamp": {
+                "request_id": {
                     "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                    "example": "host/abcdef-000042"
                 },
-                "value": {
-                    "type": "number",
-                    "example": 42.5
+                "status": {
+                    "type": "integer",
+                    "example": 500
                 }
             }
         },
-        "handlers.CreateParticipantRequest": {
+        "handlers.RegisterDeviceTokenRequest": {
             "type": "object",
             "required": [
-                "name",
-                "type"
+                "provider",
+                "token"
             ],
             "properties": {
-                "external_id": {
-                    "type": "string",
-                    "example": "external-123"
-                },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "name": {
-                    "type": "string",
-                    "example": "John Doe"
-                },
-                "type": {
+                "provider": {
                     "type": "string",
                     "enum": [
-                        "individual",
-                        "team",
-                        "group"
+                        "fcm",
+                        "apns"
                     ],
-                    "example": "individual"
+                    "example": "fcm"
+                },
+                "token": {
+                    "type": "string",
+                    "maxLength": 4096,
+                    "example": "dGhpcyBpcyBhIGRldmljZSB0b2tlbg"
                 }
             }
         },
-        "handlers.LeaderboardEntryResponse": {
+        "handlers.RegisterRequest": {
             "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440002"
-                },
-                "last_updated": {
+                "email": {
                     "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                    "example": "user@example.com"
                 },
-                "leaderboard_id": {
+                "password": {
+                    "description": "bcrypt ignores anything past 72 bytes",
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "securepass123"
                 },
-                "participant_id": {
+                "username": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440001"
-                },
-                "rank": {
-                    "type": "integer",
-                    "example": 1
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "example": "newuser"
+                }
+            }
+        },
+        "handlers.ScoreIncrementAccepted": {
+            "type": "object",
+            "properties": {
+                "buffered": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "score": {
+                "delta": {
                     "type": "number",
-                    "example": 100.5
+                    "example": 5
                 },
-                "updated_at": {
+                "entry_id": {
                     "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
                 }
             }
         },
-        "handlers.LeaderboardMetricResponse": {
+        "handlers.SearchResultResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
-                },
-                "display_priority": {
-                    "type": "integer",
-                    "example": 0
-                },
                 "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440004"
-                },
-                "leaderboard_id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "metric_id": {
+                "name": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                    "example": "Weekly Sales"
                 },
-                "updated_at": {
+                "type": {
                     "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
-                },
-                "weight": {
-                    "type": "number",
-                    "example": 1
+                    "example": "leaderboard"
                 }
             }
         },
-        "handlers.LeaderboardResponse": {
+        "handlers.ServiceTokenResponse": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string",
-                    "example": "tournament"
+                "audience": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "leaderboard-service"
+                    ]
                 },
                 "created_at": {
                     "type": "string",
                     "example": "2023-01-01T00:00:00Z"
                 },
-                "description": {
+                "created_by": {
                     "type": "string",
-                    "example": "Weekly tournament for active players"
+                    "example": "550e8400-e29b-41d4-a716-446655440008"
                 },
-                "end_date": {
+                "expires_at": {
                     "type": "string",
-                    "example": "2023-01-07T23:59:59Z"
+                    "example": "2030-01-01T00:00:00Z"
                 },
                 "id": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "max_entries": {
-                    "type": "integer",
-                    "example": 100
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Weekly Tournament"
-                },
-                "sort_order": {
-                    "type": "string",
-                    "example": "descending"
+                    "example": "550e8400-e29b-41d4-a716-44665544000b"
                 },
-                "start_date": {
+                "last_used_at": {
                     "type": "string",
                     "example": "2023-01-01T00:00:00Z"
                 },
-                "time_frame": {
+                "name": {
                     "type": "string",
-                    "example": "weekly"
+                    "example": "rankings-worker"
                 },
-                "type": {
+                "revoked_at": {
                     "type": "string",
-                    "example": "individual"
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "metrics:write"
+                    ]
                 },
                 "updated_at": {
                     "type": "string",
                     "example": "2023-01-01T00:00:00Z"
-                },
-                "visibility_scope": {
-                    "type": "string",
-                    "example": "public"
                 }
             }
         },
-        "handlers.LoginRequest": {
+        "handlers.SetFeatureFlagRequest": {
             "type": "object",
+            "required": [
+                "enabled"
+            ],
             "properties": {
-                "password": {
-                    "type": "string",
-                    "example": "password123"
-                },
-                "username": {
-                    "type": "string",
-                    "example": "admin"
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
-        "handlers.LoginResponse": {
+        "handlers.SetLeaderboardMemberRequest": {
             "type": "object",
+            "required": [
+                "role"
+            ],
             "properties": {
                 "role": {
                     "type": "string",
-                    "example": "admin"
-                },
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "token_type": {
-                    "type": "string",
-                    "example": "Bearer"
-                },
-                "user_id": {
+                    "enum": [
+                        "editor",
+                        "viewer"
+                    ],
+                    "example": "editor"
+                }
+            }
+        },
+        "handlers.SetParticipantParentRequest": {
+            "type": "object",
+            "required": [
+                "parent_id"
+            ],
+            "properties": {
+                "parent_id": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "550e8400-e29b-41d4-a716-44665544000d"
                 }
             }
         },
-        "handlers.MetricResponse": {
+        "handlers.SnapshotDiffResponse": {
             "type": "object",
             "properties": {
-                "aggregation_type": {
+                "from_snapshot_id": {
                     "type": "string",
-                    "example": "sum"
+                    "example": "550e8400-e29b-41d4-a716-446655440010"
                 },
-                "created_at": {
+                "from_taken_at": {
                     "type": "string",
                     "example": "2023-01-01T00:00:00Z"
                 },
-                "data_type": {
-                    "type": "string",
-                    "example": "integer"
-                },
-                "description": {
-                    "type": "string",
-                    "example": "Number of calls completed in a month"
-                },
-                "id": {
+                "leaderboard_id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "is_higher_better": {
-                    "type": "boolean",
-                    "example": true
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ParticipantMovementResponse"
+                    }
                 },
-                "name": {
+                "to_snapshot_id": {
                     "type": "string",
-                    "example": "monthly_calls_completed"
+                    "example": "550e8400-e29b-41d4-a716-446655440012"
                 },
-                "reset_period": {
+                "to_taken_at": {
                     "type": "string",
-                    "example": "monthly"
-                },
-                "unit": {
+                    "example": "2023-01-08T00:00:00Z"
+                }
+            }
+        },
+        "handlers.SnapshotMetricResponse": {
+            "type": "object",
+            "properties": {
+                "metric_id": {
                     "type": "string",
-                    "example": "calls"
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
                 },
-                "updated_at": {
+                "metric_name": {
                     "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                    "example": "Calls Made"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 420
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "weighted_value": {
+                    "type": "number",
+                    "example": 630
                 }
             }
         },
-        "handlers.MetricValueResponse": {
+        "handlers.StandingMetricResponse": {
             "type": "object",
             "properties": {
-                "context": {},
-                "created_at": {
-                    "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                "aggregate": {
+                    "$ref": "#/definitions/handlers.MetricAggregateResponse"
                 },
-                "id": {
+                "aggregation_type": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                    "example": "sum"
                 },
                 "metric_id": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
                 },
-                "participant_id": {
+                "metric_name": {
+                    "type": "string",
+                    "example": "Calls Made"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "weighted_value": {
+                    "type": "number",
+                    "example": 630
+                }
+            }
+        },
+        "handlers.StandingResponse": {
+            "type": "object",
+            "properties": {
+                "entry_id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440001"
                 },
-                "source": {
+                "last_updated": {
                     "type": "string",
-                    "example": "call_system"
+                    "example": "2023-01-05T12:00:00Z"
+                },
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.StandingMetricResponse"
+                    }
+                },
+                "participant": {
+                    "$ref": "#/definitions/handlers.ParticipantResponse"
                 },
-                "timestamp": {
+                "participant_id": {
                     "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "updated_at": {
+                "rank": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "rank_change": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "score": {
+                    "type": "number",
+                    "example": 420
+                },
+                "tier": {
                     "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                    "enum": [
+                        "diamond",
+                        "platinum",
+                        "gold",
+                        "silver",
+                        "bronze",
+                        "none"
+                    ],
+                    "example": "platinum"
                 },
-                "value": {
+                "top_percent": {
                     "type": "number",
-                    "example": 42.5
+                    "example": 2.5
+                },
+                "trend": {
+                    "type": "string",
+                    "enum": [
+                        "up",
+                        "down",
+                        "steady",
+                        "new"
+                    ],
+                    "example": "up"
                 }
             }
         },
-        "handlers.ParticipantResponse": {
+        "handlers.TeamMemberResponse": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string",
                     "example": "2023-01-01T00:00:00Z"
                 },
-                "external_id": {
-                    "type": "string",
-                    "example": "external-123"
-                },
                 "id": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": true
+                    "example": "550e8400-e29b-41d4-a716-44665544000c"
                 },
-                "name": {
+                "joined_at": {
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "2023-01-01T00:00:00Z"
                 },
-                "type": {
+                "left_at": {
                     "type": "string",
-                    "example": "individual"
+                    "example": "2023-06-01T00:00:00Z"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
-                }
-            }
-        },
-        "handlers.RegisterRequest": {
-            "type": "object",
-            "properties": {
-                "email": {
+                "member": {
+                    "$ref": "#/definitions/handlers.ParticipantResponse"
+                },
+                "member_id": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
                 },
-                "password": {
+                "team_id": {
                     "type": "string",
-                    "example": "securepass123"
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
                 },
-                "username": {
+                "updated_at": {
                     "type": "string",
-                    "example": "newuser"
+                    "example": "2023-01-01T00:00:00Z"
                 }
             }
         },
@@ -2882,6 +9915,12 @@ const docTemplate = `{
                 "score": {
                     "type": "number",
                     "example": 200.75
+                },
+                "version": {
+                    "description": "Version the update is based on; it is rejected with 409 if the entry was updated or re-ranked since",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
                 }
             }
         },
@@ -2915,6 +9954,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2023-02-28T23:59:59Z"
                 },
+                "group_id": {
+                    "description": "Send an empty string to stop targeting a group",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-44665544000d"
+                },
                 "is_active": {
                     "type": "boolean",
                     "example": false
@@ -2924,6 +9968,14 @@ const docTemplate = `{
                     "minimum": 1,
                     "example": 50
                 },
+                "max_entries_policy": {
+                    "type": "string",
+                    "enum": [
+                        "reject",
+                        "evict_last"
+                    ],
+                    "example": "evict_last"
+                },
                 "name": {
                     "type": "string",
                     "example": "Updated Tournament"
@@ -2940,6 +9992,20 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2023-02-01T00:00:00Z"
                 },
+                "team_score_aggregation": {
+                    "type": "string",
+                    "enum": [
+                        "sum",
+                        "average",
+                        "top_k"
+                    ],
+                    "example": "top_k"
+                },
+                "team_score_top_k": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 5
+                },
                 "time_frame": {
                     "type": "string",
                     "enum": [
@@ -2960,6 +10026,12 @@ const docTemplate = `{
                     ],
                     "example": "team"
                 },
+                "version": {
+                    "description": "Version the update is based on; it is rejected with 409 if the leaderboard was updated since",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                },
                 "visibility_scope": {
                     "type": "string",
                     "enum": [
@@ -3027,16 +10099,25 @@ const docTemplate = `{
         "handlers.UpdateMetricValueRequest": {
             "type": "object",
             "properties": {
+                "bool_value": {
+                    "type": "boolean",
+                    "example": false
+                },
                 "context": {},
                 "source": {
                     "type": "string",
                     "example": "text_system"
                 },
+                "string_value": {
+                    "type": "string",
+                    "example": "silver"
+                },
                 "timestamp": {
                     "type": "string",
                     "example": "2023-01-02T00:00:00Z"
                 },
                 "value": {
+                    "description": "At most one, in the field of the metric's data type as when creating the value",
                     "type": "number",
                     "example": 50.75
                 }
@@ -3045,10 +10126,31 @@ const docTemplate = `{
         "handlers.UpdateParticipantRequest": {
             "type": "object",
             "properties": {
+                "avatar_url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://cdn.example.com/avatars/jane.png"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "GB"
+                },
+                "display_name": {
+                    "description": "Send an empty string to clear a profile field",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Jane D"
+                },
                 "external_id": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "external-123"
                 },
+                "is_active": {
+                    "description": "Inactive participants are left out of published standings",
+                    "type": "boolean",
+                    "example": false
+                },
                 "metadata": {
                     "type": "object",
                     "additionalProperties": true
@@ -3065,13 +10167,142 @@ const docTemplate = `{
                         "group"
                     ],
                     "example": "team"
+                },
+                "user_id": {
+                    "description": "Send an empty string to unlink the user",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440008"
+                }
+            }
+        },
+        "handlers.UpdateWebhookSubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "entry.updated"
+                    ]
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "leaderboard_id": {
+                    "description": "Send an empty string to remove the filter",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "secret": {
+                    "type": "string",
+                    "minLength": 16,
+                    "example": "a-rotated-shared-secret"
+                },
+                "target_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/v2"
+                }
+            }
+        },
+        "handlers.WebhookSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "entry.created",
+                        "entry.rank_changed"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440005"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "last_delivery_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "last_delivery_error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "last_delivery_status": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "leaderboard_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "target_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/leaderboards"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                }
+            }
+        },
+        "handlers.WebhookTestResponse": {
+            "type": "object",
+            "properties": {
+                "delivered": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
                 }
             }
         },
         "middleware.ErrorResponse": {
             "type": "object",
             "properties": {
+                "code": {
+                    "description": "Stable identifier clients can branch on instead of the message",
+                    "type": "string",
+                    "example": "LEADERBOARD_NOT_FOUND"
+                },
+                "dependents": {
+                    "description": "How many records of each kind a delete refused with LEADERBOARD_NOT_EMPTY would also remove",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "details": {
+                    "description": "One entry per invalid field for VALIDATION_FAILED",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validation.FieldError"
+                    }
+                },
                 "error": {},
+                "existing": {
+                    "description": "The resource that already exists, for conflicts such as ENTRY_EXISTS that name one",
+                    "type": "object"
+                },
                 "message": {
                     "type": "string"
                 },
@@ -3079,9 +10310,151 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "middleware.JSONWebKey": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "EC",
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "description": "RSA",
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                },
+                "y": {
+                    "type": "string"
+                }
+            }
+        },
+        "middleware.JSONWebKeySet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/middleware.JSONWebKey"
+                    }
+                }
+            }
+        },
+        "services.ExportMetric": {
+            "type": "object",
+            "properties": {
+                "metric_id": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weighted_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "services.ExportRow": {
+            "type": "object",
+            "properties": {
+                "display_name": {
+                    "type": "string"
+                },
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ExportMetric"
+                    }
+                },
+                "participant_id": {
+                    "type": "string"
+                },
+                "participant_name": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "services.StandingsExport": {
+            "type": "object",
+            "properties": {
+                "leaderboard_id": {
+                    "type": "string"
+                },
+                "leaderboard_name": {
+                    "type": "string"
+                },
+                "metrics": {
+                    "description": "Names of the metrics broken down in every row, in column order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ExportRow"
+                    }
+                },
+                "snapshot_id": {
+                    "description": "Unset for the live standings",
+                    "type": "string"
+                },
+                "taken_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "validation.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "name"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "name is required"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "required"
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API key for service-to-service calls.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "Type \"Bearer\" followed by a space and the JWT token.",
             "type": "apiKey",
@@ -3095,7 +10468,7 @@ const docTemplate = `{
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
 	Host:             "localhost:8080",
-	BasePath:         "/",
+	BasePath:         "/api/v1",
 	Schemes:          []string{},
 	Title:            "Leaderboard Service API",
 	Description:      "API for managing leaderboards, entries, participants, and metrics",
@@ -0,0 +1,55 @@
+package enums
+
+import (
+	"database/sql/driver"
+	"errors"
+)
+
+// AuditAction is the kind of change an audit log records
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+)
+
+// Scan implements the sql.Scanner interface for AuditAction
+func (aa *AuditAction) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("invalid data for AuditAction")
+	}
+
+	switch str {
+	case string(AuditCreate), string(AuditUpdate), string(AuditDelete), string(AuditRestore):
+		*aa = AuditAction(str)
+		return nil
+	default:
+		return errors.New("invalid value for AuditAction")
+	}
+}
+
+// Value implements the driver.Valuer interface for AuditAction
+func (aa AuditAction) Value() (driver.Value, error) {
+	switch aa {
+	case AuditCreate, AuditUpdate, AuditDelete, AuditRestore:
+		return string(aa), nil
+	default:
+		return nil, errors.New("invalid AuditAction")
+	}
+}
+
+// Valid checks if the enum value is valid
+func (aa AuditAction) Valid() bool {
+	switch aa {
+	case AuditCreate, AuditUpdate, AuditDelete, AuditRestore:
+		return true
+	}
+	return false
+}
+
+func GetValidAuditActions() []string {
+	return []string{string(AuditCreate), string(AuditUpdate), string(AuditDelete), string(AuditRestore)}
+}
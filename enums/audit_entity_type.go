@@ -0,0 +1,55 @@
+package enums
+
+import (
+	"database/sql/driver"
+	"errors"
+)
+
+// AuditEntityType is the kind of entity an audit log records a change to
+type AuditEntityType string
+
+const (
+	AuditLeaderboard      AuditEntityType = "leaderboard"
+	AuditLeaderboardEntry AuditEntityType = "leaderboard_entry"
+	AuditMetric           AuditEntityType = "metric"
+	AuditParticipant      AuditEntityType = "participant"
+)
+
+// Scan implements the sql.Scanner interface for AuditEntityType
+func (ae *AuditEntityType) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("invalid data for AuditEntityType")
+	}
+
+	switch str {
+	case string(AuditLeaderboard), string(AuditLeaderboardEntry), string(AuditMetric), string(AuditParticipant):
+		*ae = AuditEntityType(str)
+		return nil
+	default:
+		return errors.New("invalid value for AuditEntityType")
+	}
+}
+
+// Value implements the driver.Valuer interface for AuditEntityType
+func (ae AuditEntityType) Value() (driver.Value, error) {
+	switch ae {
+	case AuditLeaderboard, AuditLeaderboardEntry, AuditMetric, AuditParticipant:
+		return string(ae), nil
+	default:
+		return nil, errors.New("invalid AuditEntityType")
+	}
+}
+
+// Valid checks if the enum value is valid
+func (ae AuditEntityType) Valid() bool {
+	switch ae {
+	case AuditLeaderboard, AuditLeaderboardEntry, AuditMetric, AuditParticipant:
+		return true
+	}
+	return false
+}
+
+func GetValidAuditEntityTypes() []string {
+	return []string{string(AuditLeaderboard), string(AuditLeaderboardEntry), string(AuditMetric), string(AuditParticipant)}
+}
@@ -0,0 +1,72 @@
+package enums
+
+import (
+	"database/sql/driver"
+	"errors"
+)
+
+// EventType identifies a domain event emitted when leaderboard data changes
+type EventType string
+
+const (
+	EntryCreated     EventType = "entry.created"
+	EntryUpdated     EventType = "entry.updated"
+	EntryDeleted     EventType = "entry.deleted"
+	EntryRankChanged EventType = "entry.rank_changed"
+	WebhookTest      EventType = "webhook.test"
+
+	// EntryScoreIncremented records an increment to an entry's score ahead of applying it
+	EntryScoreIncremented EventType = "entry.score_incremented"
+
+	MetricValueCreated EventType = "metric_value.created"
+	MetricValueUpdated EventType = "metric_value.updated"
+	MetricValueDeleted EventType = "metric_value.deleted"
+)
+
+// Scan implements the sql.Scanner interface for EventType
+func (et *EventType) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("invalid data for EventType")
+	}
+
+	switch str {
+	case string(EntryCreated), string(EntryUpdated), string(EntryDeleted), string(EntryRankChanged), string(WebhookTest),
+		string(EntryScoreIncremented), string(MetricValueCreated), string(MetricValueUpdated), string(MetricValueDeleted):
+		*et = EventType(str)
+		return nil
+	default:
+		return errors.New("invalid value for EventType")
+	}
+}
+
+// Value implements the driver.Valuer interface for EventType
+func (et EventType) Value() (driver.Value, error) {
+	switch et {
+	case EntryCreated, EntryUpdated, EntryDeleted, EntryRankChanged, WebhookTest, EntryScoreIncremented,
+		MetricValueCreated, MetricValueUpdated, MetricValueDeleted:
+		return string(et), nil
+	default:
+		return nil, errors.New("invalid EventType")
+	}
+}
+
+// Valid checks if the enum value is valid
+func (et EventType) Valid() bool {
+	switch et {
+	case EntryCreated, EntryUpdated, EntryDeleted, EntryRankChanged, WebhookTest, EntryScoreIncremented,
+		MetricValueCreated, MetricValueUpdated, MetricValueDeleted:
+		return true
+	}
+	return false
+}
+
+// GetValidEventTypes returns all event types that can be subscribed to
+func GetValidEventTypes() []string {
+	return []string{
+		string(EntryCreated),
+		string(EntryUpdated),
+		string(EntryDeleted),
+		string(EntryRankChanged),
+	}
+}
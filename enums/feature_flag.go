@@ -0,0 +1,55 @@
+package enums
+
+import (
+	"database/sql/driver"
+	"errors"
+)
+
+// FeatureFlag names an optional behaviour that can be switched on and off at runtime
+type FeatureFlag string
+
+const (
+	FeatureStandingsCache   FeatureFlag = "standings_cache"
+	FeatureStandingsSummary FeatureFlag = "standings_summary"
+	FeatureScoreBuffer      FeatureFlag = "score_buffer"
+	FeatureMaintenanceMode  FeatureFlag = "maintenance_mode"
+)
+
+// Scan implements the sql.Scanner interface for FeatureFlag
+func (ff *FeatureFlag) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("invalid data for FeatureFlag")
+	}
+
+	switch str {
+	case string(FeatureStandingsCache), string(FeatureStandingsSummary), string(FeatureScoreBuffer), string(FeatureMaintenanceMode):
+		*ff = FeatureFlag(str)
+		return nil
+	default:
+		return errors.New("invalid value for FeatureFlag")
+	}
+}
+
+// Value implements the driver.Valuer interface for FeatureFlag
+func (ff FeatureFlag) Value() (driver.Value, error) {
+	switch ff {
+	case FeatureStandingsCache, FeatureStandingsSummary, FeatureScoreBuffer, FeatureMaintenanceMode:
+		return string(ff), nil
+	default:
+		return nil, errors.New("invalid FeatureFlag")
+	}
+}
+
+// Valid checks if the enum value is valid
+func (ff FeatureFlag) Valid() bool {
+	switch ff {
+	case FeatureStandingsCache, FeatureStandingsSummary, FeatureScoreBuffer, FeatureMaintenanceMode:
+		return true
+	}
+	return false
+}
+
+func GetValidFeatureFlags() []string {
+	return []string{string(FeatureStandingsCache), string(FeatureStandingsSummary), string(FeatureScoreBuffer), string(FeatureMaintenanceMode)}
+}
@@ -0,0 +1,59 @@
+package enums
+
+import (
+	"database/sql/driver"
+	"errors"
+)
+
+// LeaderboardRole represents a user's permissions on a single leaderboard
+type LeaderboardRole string
+
+const (
+	LeaderboardOwner  LeaderboardRole = "owner"
+	LeaderboardEditor LeaderboardRole = "editor"
+	LeaderboardViewer LeaderboardRole = "viewer"
+)
+
+// Scan implements the sql.Scanner interface for LeaderboardRole
+func (lr *LeaderboardRole) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("invalid data for LeaderboardRole")
+	}
+
+	switch str {
+	case string(LeaderboardOwner), string(LeaderboardEditor), string(LeaderboardViewer):
+		*lr = LeaderboardRole(str)
+		return nil
+	default:
+		return errors.New("invalid value for LeaderboardRole")
+	}
+}
+
+// Value implements the driver.Valuer interface for LeaderboardRole
+func (lr LeaderboardRole) Value() (driver.Value, error) {
+	switch lr {
+	case LeaderboardOwner, LeaderboardEditor, LeaderboardViewer:
+		return string(lr), nil
+	default:
+		return nil, errors.New("invalid LeaderboardRole")
+	}
+}
+
+// Valid checks if the enum value is valid
+func (lr LeaderboardRole) Valid() bool {
+	switch lr {
+	case LeaderboardOwner, LeaderboardEditor, LeaderboardViewer:
+		return true
+	}
+	return false
+}
+
+// GetValidLeaderboardRoles returns all valid leaderboard roles
+func GetValidLeaderboardRoles() []string {
+	return []string{
+		string(LeaderboardOwner),
+		string(LeaderboardEditor),
+		string(LeaderboardViewer),
+	}
+}
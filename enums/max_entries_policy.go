@@ -0,0 +1,57 @@
+package enums
+
+import (
+	"database/sql/driver"
+	"errors"
+)
+
+// MaxEntriesPolicy represents what happens to a new entry on a leaderboard that already has max_entries entries
+type MaxEntriesPolicy string
+
+const (
+	MaxEntriesReject    MaxEntriesPolicy = "reject"     // The new entry is refused
+	MaxEntriesEvictLast MaxEntriesPolicy = "evict_last" // The entry in last place makes room when the new one beats it
+)
+
+// Scan implements the sql.Scanner interface for MaxEntriesPolicy
+func (mp *MaxEntriesPolicy) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("invalid data for MaxEntriesPolicy")
+	}
+
+	switch str {
+	case string(MaxEntriesReject), string(MaxEntriesEvictLast):
+		*mp = MaxEntriesPolicy(str)
+		return nil
+	default:
+		return errors.New("invalid value for MaxEntriesPolicy")
+	}
+}
+
+// Value implements the driver.Valuer interface for MaxEntriesPolicy
+func (mp MaxEntriesPolicy) Value() (driver.Value, error) {
+	switch mp {
+	case MaxEntriesReject, MaxEntriesEvictLast:
+		return string(mp), nil
+	default:
+		return nil, errors.New("invalid MaxEntriesPolicy")
+	}
+}
+
+// Valid checks if the enum value is valid
+func (mp MaxEntriesPolicy) Valid() bool {
+	switch mp {
+	case MaxEntriesReject, MaxEntriesEvictLast:
+		return true
+	}
+	return false
+}
+
+// GetValidMaxEntriesPolicies returns all valid max entries policies
+func GetValidMaxEntriesPolicies() []string {
+	return []string{
+		string(MaxEntriesReject),
+		string(MaxEntriesEvictLast),
+	}
+}
@@ -50,6 +50,12 @@ func (dt MetricDataType) Valid() bool {
 	return false
 }
 
+// IsNumeric reports whether values of the type are numbers. Boolean and string values can only be counted or have
+// their last value taken.
+func (dt MetricDataType) IsNumeric() bool {
+	return dt == Integer || dt == Decimal
+}
+
 // GetValidMetricDataTypes returns all valid metric data types
 func GetValidMetricDataTypes() []string {
 	return []string{
@@ -0,0 +1,57 @@
+package enums
+
+import (
+	"database/sql/driver"
+	"errors"
+)
+
+// PushProvider represents the push notification service a device token belongs to
+type PushProvider string
+
+const (
+	FCM  PushProvider = "fcm"
+	APNs PushProvider = "apns"
+)
+
+// Scan implements the sql.Scanner interface for PushProvider
+func (pp *PushProvider) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("invalid data for PushProvider")
+	}
+
+	switch str {
+	case string(FCM), string(APNs):
+		*pp = PushProvider(str)
+		return nil
+	default:
+		return errors.New("invalid value for PushProvider")
+	}
+}
+
+// Value implements the driver.Valuer interface for PushProvider
+func (pp PushProvider) Value() (driver.Value, error) {
+	switch pp {
+	case FCM, APNs:
+		return string(pp), nil
+	default:
+		return nil, errors.New("invalid PushProvider")
+	}
+}
+
+// Valid checks if the enum value is valid
+func (pp PushProvider) Valid() bool {
+	switch pp {
+	case FCM, APNs:
+		return true
+	}
+	return false
+}
+
+// GetValidPushProviders returns all valid push providers
+func GetValidPushProviders() []string {
+	return []string{
+		string(FCM),
+		string(APNs),
+	}
+}
@@ -0,0 +1,59 @@
+package enums
+
+import (
+	"database/sql/driver"
+	"errors"
+)
+
+// TeamScoreAggregation represents how member scores are combined into a team's score
+type TeamScoreAggregation string
+
+const (
+	TeamScoreSum     TeamScoreAggregation = "sum"
+	TeamScoreAverage TeamScoreAggregation = "average"
+	TeamScoreTopK    TeamScoreAggregation = "top_k"
+)
+
+// Scan implements the sql.Scanner interface for TeamScoreAggregation
+func (ta *TeamScoreAggregation) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("invalid data for TeamScoreAggregation")
+	}
+
+	switch str {
+	case string(TeamScoreSum), string(TeamScoreAverage), string(TeamScoreTopK):
+		*ta = TeamScoreAggregation(str)
+		return nil
+	default:
+		return errors.New("invalid value for TeamScoreAggregation")
+	}
+}
+
+// Value implements the driver.Valuer interface for TeamScoreAggregation
+func (ta TeamScoreAggregation) Value() (driver.Value, error) {
+	switch ta {
+	case TeamScoreSum, TeamScoreAverage, TeamScoreTopK:
+		return string(ta), nil
+	default:
+		return nil, errors.New("invalid TeamScoreAggregation")
+	}
+}
+
+// Valid checks if the enum value is valid
+func (ta TeamScoreAggregation) Valid() bool {
+	switch ta {
+	case TeamScoreSum, TeamScoreAverage, TeamScoreTopK:
+		return true
+	}
+	return false
+}
+
+// GetValidTeamScoreAggregations returns all valid team score aggregations
+func GetValidTeamScoreAggregations() []string {
+	return []string{
+		string(TeamScoreSum),
+		string(TeamScoreAverage),
+		string(TeamScoreTopK),
+	}
+}
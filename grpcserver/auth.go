@@ -0,0 +1,141 @@
+package grpcserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// newAuthInterceptor authenticates every call with either x-api-key or authorization metadata, the gRPC
+// equivalents of the X-API-Key and Authorization headers, and stores the caller in the context like
+// middleware.Authenticate does
+func newAuthInterceptor() grpc.UnaryServerInterceptor {
+	apiKeyService := services.NewAPIKeyService(repositories.NewAPIKeyRepository())
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		if rawKey := firstValue(md, "x-api-key"); rawKey != "" {
+			apiKey, err := apiKeyService.Authenticate(ctx, rawKey)
+			if err != nil {
+				if err.Error() == "invalid api key" || err.Error() == "api key expired" {
+					return nil, status.Error(codes.Unauthenticated, "invalid API key")
+				}
+				return nil, status.Errorf(codes.Internal, "failed to verify API key: %v", err)
+			}
+
+			// Read-only keys may only call the Get and List methods
+			method := http.MethodPost
+			if isReadMethod(info.FullMethod) {
+				method = http.MethodGet
+			}
+			if !apiKey.AllowsMethod(method) {
+				return nil, status.Error(codes.PermissionDenied, "API key scope does not allow this request")
+			}
+
+			return handler(middleware.WithAPIKey(ctx, apiKey), req)
+		}
+
+		authHeader := firstValue(md, "authorization")
+		if authHeader == "" {
+			return nil, status.Error(codes.Unauthenticated, middleware.ErrTokenMissing.Error())
+		}
+		claims, err := middleware.ValidateBearerToken(ctx, authHeader)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		return handler(context.WithValue(ctx, middleware.UserContextKey, claims), req)
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// isReadMethod reports whether a full method name such as /leaderboard.v1.LeaderboardService/GetLeaderboard only reads
+func isReadMethod(fullMethod string) bool {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	return strings.HasPrefix(name, "Get") || strings.HasPrefix(name, "List")
+}
+
+// requireScope checks that the caller's token grants the scope, like middleware.RequireScope
+func requireScope(ctx context.Context, scope string) error {
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "unauthorized access")
+	}
+	if !claims.HasScope(scope) {
+		return status.Error(codes.PermissionDenied, "insufficient scope, requires "+scope)
+	}
+	return nil
+}
+
+// requireLeaderboardRead hides private leaderboards from callers who are not on their access list, like
+// middleware.RequireLeaderboardRead
+func requireLeaderboardRead(ctx context.Context, accessService services.LeaderboardAccessService, leaderboardID uuid.UUID) error {
+	canRead, err := accessService.CanRead(ctx, leaderboardID, middleware.GetViewer(ctx))
+	if err != nil {
+		return serviceError(err, "check leaderboard permissions")
+	}
+	if !canRead {
+		return status.Error(codes.NotFound, "leaderboard not found")
+	}
+	return nil
+}
+
+// requireLeaderboardRole allows tokens with the leaderboards:admin scope, and otherwise requires leaderboards:write
+// plus one of the roles on the leaderboard, like middleware.RequireLeaderboardRole
+func requireLeaderboardRole(ctx context.Context, accessService services.LeaderboardAccessService, leaderboardID uuid.UUID,
+	roles ...enums.LeaderboardRole) error {
+
+	claims, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "unauthorized access")
+	}
+	if claims.HasScope(middleware.ScopeLeaderboardsAdmin) {
+		return nil
+	}
+	if err := requireScope(ctx, middleware.ScopeLeaderboardsWrite); err != nil {
+		return err
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	role, err := accessService.GetRole(ctx, leaderboardID, userID)
+	if err != nil {
+		return serviceError(err, "check leaderboard permissions")
+	}
+	for _, allowed := range roles {
+		if role == allowed {
+			return nil
+		}
+	}
+	return status.Error(codes.PermissionDenied, "insufficient permissions")
+}
+
+// optionalUserID returns the calling user's ID, or nil when the caller is not a user (e.g. an API key)
+func optionalUserID(ctx context.Context) *uuid.UUID {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,195 @@
+package grpcserver
+
+import (
+	"encoding/json"
+	"time"
+
+	"leaderboard-service/models"
+	leaderboardv1 "leaderboard-service/proto/leaderboard/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toLeaderboard(leaderboard *models.Leaderboard) *leaderboardv1.Leaderboard {
+	message := &leaderboardv1.Leaderboard{
+		Id:                   leaderboard.ID.String(),
+		Name:                 leaderboard.Name,
+		Description:          leaderboard.Description,
+		Category:             leaderboard.Category,
+		Type:                 string(leaderboard.Type),
+		TimeFrame:            string(leaderboard.TimeFrame),
+		StartDate:            optionalTimestamp(leaderboard.StartDate),
+		EndDate:              optionalTimestamp(leaderboard.EndDate),
+		SortOrder:            string(leaderboard.SortOrder),
+		VisibilityScope:      string(leaderboard.VisibilityScope),
+		MaxEntries:           int32(leaderboard.MaxEntries),
+		MaxEntriesPolicy:     string(leaderboard.MaxEntriesPolicy),
+		IsActive:             leaderboard.IsActive,
+		OwnerId:              optionalID(leaderboard.OwnerID),
+		TeamScoreAggregation: string(leaderboard.TeamScoreAggregation),
+		TeamScoreTopK:        int32(leaderboard.TeamScoreTopK),
+		GroupId:              optionalID(leaderboard.GroupID),
+		CreatedAt:            timestamppb.New(leaderboard.CreatedAt),
+		UpdatedAt:            timestamppb.New(leaderboard.UpdatedAt),
+		Version:              int32(leaderboard.Version),
+	}
+	for i := range leaderboard.Metrics {
+		message.Metrics = append(message.Metrics, toLeaderboardMetric(&leaderboard.Metrics[i]))
+	}
+	for i := range leaderboard.Entries {
+		message.Entries = append(message.Entries, toLeaderboardEntry(&leaderboard.Entries[i]))
+	}
+	return message
+}
+
+func toLeaderboardMetric(metric *models.LeaderboardMetric) *leaderboardv1.LeaderboardMetric {
+	return &leaderboardv1.LeaderboardMetric{
+		Id:              metric.ID.String(),
+		LeaderboardId:   metric.LeaderboardID.String(),
+		MetricId:        metric.MetricID.String(),
+		Weight:          metric.Weight,
+		DisplayPriority: int32(metric.DisplayPriority),
+		CreatedAt:       timestamppb.New(metric.CreatedAt),
+		UpdatedAt:       timestamppb.New(metric.UpdatedAt),
+	}
+}
+
+func toLeaderboardEntry(entry *models.LeaderboardEntry) *leaderboardv1.LeaderboardEntry {
+	message := &leaderboardv1.LeaderboardEntry{
+		Id:            entry.ID.String(),
+		LeaderboardId: entry.LeaderboardID.String(),
+		ParticipantId: entry.ParticipantID.String(),
+		Rank:          int32(entry.Rank),
+		Score:         entry.Score,
+		LastUpdated:   timestamppb.New(entry.LastUpdated),
+		CreatedAt:     timestamppb.New(entry.CreatedAt),
+		UpdatedAt:     timestamppb.New(entry.UpdatedAt),
+		Version:       int32(entry.Version),
+	}
+	if entry.Participant != nil {
+		message.Participant = toParticipant(entry.Participant)
+	}
+	return message
+}
+
+func toParticipant(participant *models.Participant) *leaderboardv1.Participant {
+	message := &leaderboardv1.Participant{
+		Id:           participant.ID.String(),
+		ExternalId:   participant.ExternalID,
+		Name:         participant.Name,
+		Type:         participant.Type,
+		Metadata:     jsonValue(participant.Metadata),
+		UserId:       optionalID(participant.UserID),
+		ParentId:     optionalID(participant.ParentID),
+		IsActive:     participant.IsActive,
+		AnonymizedAt: optionalTimestamp(participant.AnonymizedAt),
+		DisplayName:  participant.DisplayName,
+		AvatarUrl:    participant.AvatarURL,
+		Country:      participant.Country,
+		CreatedAt:    timestamppb.New(participant.CreatedAt),
+		UpdatedAt:    timestamppb.New(participant.UpdatedAt),
+	}
+	for _, tag := range participant.Tags {
+		message.Tags = append(message.Tags, tag.Tag)
+	}
+	return message
+}
+
+func toMetric(metric *models.Metric) *leaderboardv1.Metric {
+	return &leaderboardv1.Metric{
+		Id:              metric.ID.String(),
+		Name:            metric.Name,
+		Description:     metric.Description,
+		DataType:        string(metric.DataType),
+		Unit:            metric.Unit,
+		AggregationType: string(metric.AggregationType),
+		ResetPeriod:     string(metric.ResetPeriod),
+		IsHigherBetter:  metric.IsHigherBetter,
+		CreatedAt:       timestamppb.New(metric.CreatedAt),
+		UpdatedAt:       timestamppb.New(metric.UpdatedAt),
+	}
+}
+
+func toMetricValue(value *models.MetricValue) *leaderboardv1.MetricValue {
+	return &leaderboardv1.MetricValue{
+		Id:            value.ID.String(),
+		MetricId:      value.MetricID.String(),
+		ParticipantId: value.ParticipantID.String(),
+		Value:         value.Value,
+		Timestamp:     timestamppb.New(value.Timestamp),
+		Source:        value.Source,
+		Context:       jsonValue(value.Context),
+		CreatedAt:     timestamppb.New(value.CreatedAt),
+		UpdatedAt:     timestamppb.New(value.UpdatedAt),
+		BoolValue:     value.BoolValue,
+		StringValue:   value.StringValue,
+	}
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func optionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	value := id.String()
+	return &value
+}
+
+// jsonValue converts a jsonb column, which is either still the value it was written with or the raw JSON read back
+// from the database, to a protobuf Value. Values that are not JSON are left out.
+func jsonValue(value interface{}) *structpb.Value {
+	if value == nil {
+		return nil
+	}
+
+	var raw []byte
+	switch v := value.(type) {
+	case []byte:
+		raw = v
+	case string:
+		if !json.Valid([]byte(v)) {
+			return structpb.NewStringValue(v)
+		}
+		raw = []byte(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil
+		}
+		raw = encoded
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil
+	}
+	converted, err := structpb.NewValue(decoded)
+	if err != nil {
+		return nil
+	}
+	return converted
+}
+
+func optionalTime(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := t.AsTime()
+	return &value
+}
+
+func optionalInt(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	value := int(*v)
+	return &value
+}
@@ -0,0 +1,286 @@
+package grpcserver
+
+import (
+	"context"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/handlers"
+	"leaderboard-service/middleware"
+	leaderboardv1 "leaderboard-service/proto/leaderboard/v1"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type leaderboardServer struct {
+	leaderboardv1.UnimplementedLeaderboardServiceServer
+	service       services.LeaderboardService
+	accessService services.LeaderboardAccessService
+}
+
+func newLeaderboardServer() *leaderboardServer {
+	repo := repositories.NewLeaderboardRepository()
+	return &leaderboardServer{
+		service:       services.NewLeaderboardService(repo, repositories.NewParticipantRepository(), repositories.NewUnitOfWork()),
+		accessService: newLeaderboardAccessService(),
+	}
+}
+
+func (s *leaderboardServer) GetLeaderboard(ctx context.Context, req *leaderboardv1.GetLeaderboardRequest) (*leaderboardv1.Leaderboard, error) {
+	leaderboardID, err := parseID(req.Id, "leaderboard ID")
+	if err != nil {
+		return nil, err
+	}
+	if err := requireLeaderboardRead(ctx, s.accessService, leaderboardID); err != nil {
+		return nil, err
+	}
+
+	include, err := parseInclude(req.Include, repositories.LeaderboardIncludes)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard, err := s.service.GetLeaderboard(ctx, leaderboardID, include)
+	if err != nil {
+		return nil, serviceError(err, "fetch leaderboard")
+	}
+	return toLeaderboard(leaderboard), nil
+}
+
+func (s *leaderboardServer) ListLeaderboards(ctx context.Context, req *leaderboardv1.ListLeaderboardsRequest) (*leaderboardv1.ListLeaderboardsResponse, error) {
+	var leaderboardType *enums.LeaderboardType
+	if req.Type != nil {
+		parsedType := enums.LeaderboardType(*req.Type)
+		if !parsedType.Valid() {
+			return nil, status.Error(codes.InvalidArgument, "invalid type: "+*req.Type)
+		}
+		leaderboardType = &parsedType
+	}
+
+	var timeFrame *enums.TimeFrame
+	if req.TimeFrame != nil {
+		parsedTimeFrame := enums.TimeFrame(*req.TimeFrame)
+		if !parsedTimeFrame.Valid() {
+			return nil, status.Error(codes.InvalidArgument, "invalid time_frame: "+*req.TimeFrame)
+		}
+		timeFrame = &parsedTimeFrame
+	}
+
+	var visibilityScope *enums.VisibilityScope
+	if req.VisibilityScope != nil {
+		parsedScope := enums.VisibilityScope(*req.VisibilityScope)
+		if !parsedScope.Valid() {
+			return nil, status.Error(codes.InvalidArgument, "invalid visibility_scope: "+*req.VisibilityScope)
+		}
+		visibilityScope = &parsedScope
+	}
+
+	sort, err := parseSort(req.Sort, repositories.LeaderboardSortColumns)
+	if err != nil {
+		return nil, err
+	}
+	include, err := parseInclude(req.Include, repositories.LeaderboardIncludes)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboards, err := s.service.ListLeaderboards(ctx, nil, req.Category, leaderboardType, timeFrame, req.IsActive, visibilityScope, sort, include, false)
+	if err != nil {
+		return nil, serviceError(err, "fetch leaderboards")
+	}
+
+	// Private leaderboards are only listed for callers with access
+	leaderboards, err = s.accessService.FilterReadableLeaderboards(ctx, leaderboards, middleware.GetViewer(ctx))
+	if err != nil {
+		return nil, serviceError(err, "fetch leaderboards")
+	}
+
+	response := &leaderboardv1.ListLeaderboardsResponse{}
+	for i := range leaderboards {
+		response.Leaderboards = append(response.Leaderboards, toLeaderboard(&leaderboards[i]))
+	}
+	return response, nil
+}
+
+func (s *leaderboardServer) CreateLeaderboard(ctx context.Context, req *leaderboardv1.CreateLeaderboardRequest) (*leaderboardv1.Leaderboard, error) {
+	if err := requireScope(ctx, middleware.ScopeLeaderboardsWrite); err != nil {
+		return nil, err
+	}
+
+	body := handlers.CreateLeaderboardRequest{
+		Name:                 req.Name,
+		Description:          req.Description,
+		Category:             req.Category,
+		Type:                 req.Type,
+		TimeFrame:            req.TimeFrame,
+		StartDate:            req.StartDate,
+		EndDate:              req.EndDate,
+		SortOrder:            req.SortOrder,
+		VisibilityScope:      req.VisibilityScope,
+		IsActive:             req.IsActive,
+		MaxEntries:           int(req.MaxEntries),
+		MaxEntriesPolicy:     req.MaxEntriesPolicy,
+		TeamScoreAggregation: req.TeamScoreAggregation,
+		TeamScoreTopK:        int(req.TeamScoreTopK),
+		GroupID:              req.GroupId,
+	}
+	if err := validate(body); err != nil {
+		return nil, err
+	}
+
+	groupID, err := parseOptionalID(req.GroupId, "group ID")
+	if err != nil {
+		return nil, err
+	}
+
+	// The authenticated user becomes the owner; API keys and service tokens create unowned boards
+	ownerID := optionalUserID(ctx)
+
+	leaderboard, err := s.service.CreateLeaderboard(
+		ctx,
+		body.Name,
+		body.Description,
+		body.Category,
+		enums.LeaderboardType(body.Type),
+		enums.TimeFrame(body.TimeFrame),
+		body.StartDate,
+		body.EndDate,
+		enums.SortOrder(body.SortOrder),
+		enums.VisibilityScope(body.VisibilityScope),
+		body.MaxEntries,
+		enums.MaxEntriesPolicy(body.MaxEntriesPolicy),
+		body.IsActive,
+		ownerID,
+		enums.TeamScoreAggregation(body.TeamScoreAggregation),
+		body.TeamScoreTopK,
+		groupID,
+		nil,
+	)
+	if err != nil {
+		return nil, serviceError(err, "create leaderboard")
+	}
+	return toLeaderboard(leaderboard), nil
+}
+
+func (s *leaderboardServer) UpdateLeaderboard(ctx context.Context, req *leaderboardv1.UpdateLeaderboardRequest) (*leaderboardv1.Leaderboard, error) {
+	leaderboardID, err := parseID(req.Id, "leaderboard ID")
+	if err != nil {
+		return nil, err
+	}
+	if err := requireLeaderboardRole(ctx, s.accessService, leaderboardID, enums.LeaderboardOwner, enums.LeaderboardEditor); err != nil {
+		return nil, err
+	}
+
+	body := handlers.UpdateLeaderboardRequest{
+		Name:                 req.Name,
+		Description:          req.Description,
+		Category:             req.Category,
+		Type:                 req.Type,
+		TimeFrame:            req.TimeFrame,
+		StartDate:            req.StartDate,
+		EndDate:              req.EndDate,
+		SortOrder:            req.SortOrder,
+		VisibilityScope:      req.VisibilityScope,
+		IsActive:             req.IsActive,
+		MaxEntries:           optionalInt(req.MaxEntries),
+		MaxEntriesPolicy:     req.MaxEntriesPolicy,
+		TeamScoreAggregation: req.TeamScoreAggregation,
+		TeamScoreTopK:        optionalInt(req.TeamScoreTopK),
+		GroupID:              req.GroupId,
+		Version:              optionalInt(req.Version),
+	}
+	if err := validate(body); err != nil {
+		return nil, err
+	}
+
+	var leaderboardType *enums.LeaderboardType
+	if body.Type != nil {
+		lt := enums.LeaderboardType(*body.Type)
+		leaderboardType = &lt
+	}
+
+	var timeFrame *enums.TimeFrame
+	if body.TimeFrame != nil {
+		tf := enums.TimeFrame(*body.TimeFrame)
+		timeFrame = &tf
+	}
+
+	var sortOrder *enums.SortOrder
+	if body.SortOrder != nil {
+		so := enums.SortOrder(*body.SortOrder)
+		sortOrder = &so
+	}
+
+	var visibilityScope *enums.VisibilityScope
+	if body.VisibilityScope != nil {
+		vs := enums.VisibilityScope(*body.VisibilityScope)
+		visibilityScope = &vs
+	}
+
+	var maxEntriesPolicy *enums.MaxEntriesPolicy
+	if body.MaxEntriesPolicy != nil {
+		mp := enums.MaxEntriesPolicy(*body.MaxEntriesPolicy)
+		maxEntriesPolicy = &mp
+	}
+
+	var teamScoreAggregation *enums.TeamScoreAggregation
+	if body.TeamScoreAggregation != nil {
+		ta := enums.TeamScoreAggregation(*body.TeamScoreAggregation)
+		teamScoreAggregation = &ta
+	}
+
+	// An empty group_id stops targeting a group
+	clearGroup := body.GroupID != nil && *body.GroupID == ""
+	groupIDParam := body.GroupID
+	if clearGroup {
+		groupIDParam = nil
+	}
+	groupID, err := parseOptionalID(groupIDParam, "group ID")
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard, err := s.service.UpdateLeaderboard(
+		ctx,
+		leaderboardID,
+		body.Name,
+		body.Description,
+		body.Category,
+		leaderboardType,
+		timeFrame,
+		body.StartDate,
+		body.EndDate,
+		sortOrder,
+		visibilityScope,
+		body.MaxEntries,
+		maxEntriesPolicy,
+		body.IsActive,
+		teamScoreAggregation,
+		body.TeamScoreTopK,
+		groupID,
+		clearGroup,
+		body.Version,
+	)
+	if err != nil {
+		return nil, serviceError(err, "update leaderboard")
+	}
+	return toLeaderboard(leaderboard), nil
+}
+
+func (s *leaderboardServer) DeleteLeaderboard(ctx context.Context, req *leaderboardv1.DeleteLeaderboardRequest) (*emptypb.Empty, error) {
+	leaderboardID, err := parseID(req.Id, "leaderboard ID")
+	if err != nil {
+		return nil, err
+	}
+	if err := requireLeaderboardRole(ctx, s.accessService, leaderboardID, enums.LeaderboardOwner); err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteLeaderboard(ctx, leaderboardID, req.Force); err != nil {
+		return nil, serviceError(err, "delete leaderboard")
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,172 @@
+package grpcserver
+
+import (
+	"context"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/handlers"
+	"leaderboard-service/middleware"
+	leaderboardv1 "leaderboard-service/proto/leaderboard/v1"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type leaderboardEntryServer struct {
+	leaderboardv1.UnimplementedLeaderboardEntryServiceServer
+	service            services.LeaderboardEntryService
+	accessService      services.LeaderboardAccessService
+	participantService services.ParticipantService
+}
+
+func newLeaderboardEntryServer() *leaderboardEntryServer {
+	service := services.NewLeaderboardEntryService(
+		repositories.NewLeaderboardEntryRepository(),
+		repositories.NewLeaderboardRepository(),
+		repositories.NewParticipantRepository(),
+		repositories.NewScoreIncrementRepository(),
+		repositories.NewUnitOfWork(),
+	)
+	return &leaderboardEntryServer{
+		service:            service,
+		accessService:      newLeaderboardAccessService(),
+		participantService: newParticipantService(),
+	}
+}
+
+func (s *leaderboardEntryServer) GetLeaderboardEntry(ctx context.Context, req *leaderboardv1.GetLeaderboardEntryRequest) (*leaderboardv1.LeaderboardEntry, error) {
+	entryID, err := parseID(req.Id, "leaderboard entry ID")
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.service.GetLeaderboardEntry(ctx, entryID)
+	if err != nil {
+		return nil, serviceError(err, "fetch leaderboard entry")
+	}
+	if err := requireLeaderboardRead(ctx, s.accessService, entry.LeaderboardID); err != nil {
+		return nil, err
+	}
+	return toLeaderboardEntry(entry), nil
+}
+
+func (s *leaderboardEntryServer) ListLeaderboardEntries(ctx context.Context, req *leaderboardv1.ListLeaderboardEntriesRequest) (*leaderboardv1.ListLeaderboardEntriesResponse, error) {
+	leaderboardID, err := parseOptionalID(req.LeaderboardId, "leaderboard ID")
+	if err != nil {
+		return nil, err
+	}
+	participantID, err := parseOptionalID(req.ParticipantId, "participant ID")
+	if err != nil {
+		return nil, err
+	}
+	sort, err := parseSort(req.Sort, repositories.EntrySortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.service.ListFilteredLeaderboardEntries(ctx, leaderboardID, participantID, sort, false)
+	if err != nil {
+		return nil, serviceError(err, "fetch leaderboard entries")
+	}
+
+	// Drop entries from private leaderboards the caller cannot read
+	entries, err = s.accessService.FilterReadableEntries(ctx, entries, middleware.GetViewer(ctx))
+	if err != nil {
+		return nil, serviceError(err, "fetch leaderboard entries")
+	}
+
+	response := &leaderboardv1.ListLeaderboardEntriesResponse{}
+	for i := range entries {
+		response.Entries = append(response.Entries, toLeaderboardEntry(&entries[i]))
+	}
+	return response, nil
+}
+
+func (s *leaderboardEntryServer) CreateLeaderboardEntry(ctx context.Context, req *leaderboardv1.CreateLeaderboardEntryRequest) (*leaderboardv1.LeaderboardEntry, error) {
+	body := handlers.CreateLeaderboardEntryRequest{
+		LeaderboardID:         req.LeaderboardId,
+		ParticipantID:         req.ParticipantId,
+		ParticipantExternalID: req.ParticipantExternalId,
+		Score:                 req.Score,
+		Rank:                  int(req.Rank),
+	}
+	if req.LastUpdated != nil {
+		body.LastUpdated = req.LastUpdated.AsTime()
+	}
+	if err := validate(body); err != nil {
+		return nil, err
+	}
+
+	leaderboardID, err := parseID(body.LeaderboardID, "leaderboard ID")
+	if err != nil {
+		return nil, err
+	}
+	if err := requireLeaderboardRole(ctx, s.accessService, leaderboardID, enums.LeaderboardOwner, enums.LeaderboardEditor); err != nil {
+		return nil, err
+	}
+
+	participantID, err := resolveParticipantID(ctx, s.participantService, body.ParticipantID, body.ParticipantExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.service.CreateLeaderboardEntry(ctx, leaderboardID, participantID, body.Score, body.Rank, body.LastUpdated)
+	if err != nil {
+		return nil, serviceError(err, "create leaderboard entry")
+	}
+	return toLeaderboardEntry(entry), nil
+}
+
+func (s *leaderboardEntryServer) UpdateLeaderboardEntry(ctx context.Context, req *leaderboardv1.UpdateLeaderboardEntryRequest) (*leaderboardv1.LeaderboardEntry, error) {
+	entryID, err := s.authorizeEntry(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	body := handlers.UpdateLeaderboardEntryRequest{
+		Score:       req.Score,
+		Rank:        optionalInt(req.Rank),
+		LastUpdated: optionalTime(req.LastUpdated),
+		Version:     optionalInt(req.Version),
+	}
+	if err := validate(body); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.service.UpdateLeaderboardEntry(ctx, entryID, body.Score, body.Rank, body.LastUpdated, body.Version)
+	if err != nil {
+		return nil, serviceError(err, "update leaderboard entry")
+	}
+	return toLeaderboardEntry(entry), nil
+}
+
+func (s *leaderboardEntryServer) DeleteLeaderboardEntry(ctx context.Context, req *leaderboardv1.DeleteLeaderboardEntryRequest) (*emptypb.Empty, error) {
+	entryID, err := s.authorizeEntry(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteLeaderboardEntry(ctx, entryID); err != nil {
+		return nil, serviceError(err, "delete leaderboard entry")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// authorizeEntry parses an entry ID and checks the caller can edit the entry's leaderboard
+func (s *leaderboardEntryServer) authorizeEntry(ctx context.Context, id string) (uuid.UUID, error) {
+	entryID, err := parseID(id, "leaderboard entry ID")
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	entry, err := s.service.GetLeaderboardEntry(ctx, entryID)
+	if err != nil {
+		return uuid.Nil, serviceError(err, "fetch leaderboard entry")
+	}
+	if err := requireLeaderboardRole(ctx, s.accessService, entry.LeaderboardID, enums.LeaderboardOwner, enums.LeaderboardEditor); err != nil {
+		return uuid.Nil, err
+	}
+	return entryID, nil
+}
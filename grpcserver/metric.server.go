@@ -0,0 +1,159 @@
+package grpcserver
+
+import (
+	"context"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/handlers"
+	"leaderboard-service/middleware"
+	leaderboardv1 "leaderboard-service/proto/leaderboard/v1"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type metricServer struct {
+	leaderboardv1.UnimplementedMetricServiceServer
+	service services.MetricService
+}
+
+func newMetricServer() *metricServer {
+	return &metricServer{
+		service: services.NewMetricService(repositories.NewMetricRepository()),
+	}
+}
+
+func (s *metricServer) GetMetric(ctx context.Context, req *leaderboardv1.GetMetricRequest) (*leaderboardv1.Metric, error) {
+	metricID, err := parseID(req.Id, "metric ID")
+	if err != nil {
+		return nil, err
+	}
+
+	metric, err := s.service.GetMetric(ctx, metricID)
+	if err != nil {
+		return nil, serviceError(err, "fetch metric")
+	}
+	return toMetric(metric), nil
+}
+
+func (s *metricServer) ListMetrics(ctx context.Context, req *leaderboardv1.ListMetricsRequest) (*leaderboardv1.ListMetricsResponse, error) {
+	metrics, err := s.service.ListMetrics(ctx, nil, false)
+	if err != nil {
+		return nil, serviceError(err, "fetch metrics")
+	}
+
+	response := &leaderboardv1.ListMetricsResponse{}
+	for i := range metrics {
+		response.Metrics = append(response.Metrics, toMetric(&metrics[i]))
+	}
+	return response, nil
+}
+
+func (s *metricServer) CreateMetric(ctx context.Context, req *leaderboardv1.CreateMetricRequest) (*leaderboardv1.Metric, error) {
+	if err := requireScope(ctx, middleware.ScopeMetricsWrite); err != nil {
+		return nil, err
+	}
+
+	body := handlers.CreateMetricRequest{
+		Name:            req.Name,
+		Description:     req.Description,
+		DataType:        req.DataType,
+		Unit:            req.Unit,
+		AggregationType: req.AggregationType,
+		ResetPeriod:     req.ResetPeriod,
+		IsHigherBetter:  req.IsHigherBetter,
+	}
+	if err := validate(body); err != nil {
+		return nil, err
+	}
+
+	metric, err := s.service.CreateMetric(
+		ctx,
+		body.Name,
+		body.Description,
+		enums.MetricDataType(body.DataType),
+		body.Unit,
+		enums.AggregationType(body.AggregationType),
+		enums.ResetPeriod(body.ResetPeriod),
+		body.IsHigherBetter,
+	)
+	if err != nil {
+		return nil, serviceError(err, "create metric")
+	}
+	return toMetric(metric), nil
+}
+
+func (s *metricServer) UpdateMetric(ctx context.Context, req *leaderboardv1.UpdateMetricRequest) (*leaderboardv1.Metric, error) {
+	if err := requireScope(ctx, middleware.ScopeMetricsWrite); err != nil {
+		return nil, err
+	}
+
+	metricID, err := parseID(req.Id, "metric ID")
+	if err != nil {
+		return nil, err
+	}
+
+	body := handlers.UpdateMetricRequest{
+		Name:            req.Name,
+		Description:     req.Description,
+		DataType:        req.DataType,
+		Unit:            req.Unit,
+		AggregationType: req.AggregationType,
+		ResetPeriod:     req.ResetPeriod,
+		IsHigherBetter:  req.IsHigherBetter,
+	}
+	if err := validate(body); err != nil {
+		return nil, err
+	}
+
+	var dataType *enums.MetricDataType
+	if body.DataType != nil {
+		dt := enums.MetricDataType(*body.DataType)
+		dataType = &dt
+	}
+
+	var aggregationType *enums.AggregationType
+	if body.AggregationType != nil {
+		at := enums.AggregationType(*body.AggregationType)
+		aggregationType = &at
+	}
+
+	var resetPeriod *enums.ResetPeriod
+	if body.ResetPeriod != nil {
+		rp := enums.ResetPeriod(*body.ResetPeriod)
+		resetPeriod = &rp
+	}
+
+	metric, err := s.service.UpdateMetric(
+		ctx,
+		metricID,
+		body.Name,
+		body.Description,
+		dataType,
+		body.Unit,
+		aggregationType,
+		resetPeriod,
+		body.IsHigherBetter,
+	)
+	if err != nil {
+		return nil, serviceError(err, "update metric")
+	}
+	return toMetric(metric), nil
+}
+
+func (s *metricServer) DeleteMetric(ctx context.Context, req *leaderboardv1.DeleteMetricRequest) (*emptypb.Empty, error) {
+	if err := requireScope(ctx, middleware.ScopeMetricsWrite); err != nil {
+		return nil, err
+	}
+
+	metricID, err := parseID(req.Id, "metric ID")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteMetric(ctx, metricID); err != nil {
+		return nil, serviceError(err, "delete metric")
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,175 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"leaderboard-service/handlers"
+	"leaderboard-service/middleware"
+	leaderboardv1 "leaderboard-service/proto/leaderboard/v1"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type metricValueServer struct {
+	leaderboardv1.UnimplementedMetricValueServiceServer
+	service            services.MetricValueService
+	participantService services.ParticipantService
+}
+
+func newMetricValueServer() *metricValueServer {
+	service := services.NewMetricValueService(
+		repositories.NewMetricValueRepository(),
+		repositories.NewMetricRepository(),
+		repositories.NewParticipantRepository(),
+	)
+	return &metricValueServer{
+		service:            service,
+		participantService: newParticipantService(),
+	}
+}
+
+func (s *metricValueServer) GetMetricValue(ctx context.Context, req *leaderboardv1.GetMetricValueRequest) (*leaderboardv1.MetricValue, error) {
+	valueID, err := parseID(req.Id, "metric value ID")
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := s.service.GetMetricValue(ctx, valueID)
+	if err != nil {
+		return nil, serviceError(err, "fetch metric value")
+	}
+	return toMetricValue(value), nil
+}
+
+func (s *metricValueServer) ListMetricValues(ctx context.Context, req *leaderboardv1.ListMetricValuesRequest) (*leaderboardv1.ListMetricValuesResponse, error) {
+	metricID, err := parseOptionalID(req.MetricId, "metric ID")
+	if err != nil {
+		return nil, err
+	}
+	participantID, err := parseOptionalID(req.ParticipantId, "participant ID")
+	if err != nil {
+		return nil, err
+	}
+	sort, err := parseSort(req.Sort, repositories.MetricValueSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	page, err := s.service.ListFilteredMetricValues(ctx, metricID, participantID, optionalTime(req.FromTime), optionalTime(req.ToTime), sort,
+		req.PageToken, int(req.PageSize))
+	if err != nil {
+		return nil, serviceError(err, "fetch metric values")
+	}
+
+	response := &leaderboardv1.ListMetricValuesResponse{NextPageToken: page.NextCursor}
+	for i := range page.Values {
+		response.MetricValues = append(response.MetricValues, toMetricValue(&page.Values[i]))
+	}
+	return response, nil
+}
+
+func (s *metricValueServer) CreateMetricValue(ctx context.Context, req *leaderboardv1.CreateMetricValueRequest) (*leaderboardv1.MetricValue, error) {
+	if err := requireScope(ctx, middleware.ScopeMetricsWrite); err != nil {
+		return nil, err
+	}
+
+	body := handlers.CreateMetricValueRequest{
+		MetricID:              req.MetricId,
+		ParticipantID:         req.ParticipantId,
+		ParticipantExternalID: req.ParticipantExternalId,
+		BoolValue:             req.BoolValue,
+		StringValue:           req.StringValue,
+		Timestamp:             optionalTime(req.Timestamp),
+		Source:                req.Source,
+	}
+	// proto3 can't tell an unset number from zero, so it counts as set unless another kind of value is
+	if req.BoolValue == nil && req.StringValue == nil {
+		body.Value = &req.Value
+	}
+	if req.Context != nil {
+		body.Context = req.Context.AsInterface()
+	}
+	if err := validate(body); err != nil {
+		return nil, err
+	}
+
+	metricID, err := parseID(body.MetricID, "metric ID")
+	if err != nil {
+		return nil, err
+	}
+	participantID, err := resolveParticipantID(ctx, s.participantService, body.ParticipantID, body.ParticipantExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set timestamp to current time if not provided
+	timestamp := time.Now()
+	if body.Timestamp != nil {
+		timestamp = *body.Timestamp
+	}
+
+	input := services.MetricValueInput{Number: body.Value, Bool: body.BoolValue, String: body.StringValue}
+	value, err := s.service.CreateMetricValue(ctx, metricID, participantID, input, timestamp, body.Source, body.Context)
+	if err != nil {
+		return nil, serviceError(err, "create metric value")
+	}
+	return toMetricValue(value), nil
+}
+
+func (s *metricValueServer) UpdateMetricValue(ctx context.Context, req *leaderboardv1.UpdateMetricValueRequest) (*leaderboardv1.MetricValue, error) {
+	if err := requireScope(ctx, middleware.ScopeMetricsWrite); err != nil {
+		return nil, err
+	}
+
+	valueID, err := parseID(req.Id, "metric value ID")
+	if err != nil {
+		return nil, err
+	}
+
+	body := handlers.UpdateMetricValueRequest{
+		Value:       req.Value,
+		BoolValue:   req.BoolValue,
+		StringValue: req.StringValue,
+		Timestamp:   optionalTime(req.Timestamp),
+		Source:      req.Source,
+	}
+	if req.Context != nil {
+		valueContext := req.Context.AsInterface()
+		body.Context = &valueContext
+	}
+	if err := validate(body); err != nil {
+		return nil, err
+	}
+
+	input := services.MetricValueInput{Number: body.Value, Bool: body.BoolValue, String: body.StringValue}
+	value, err := s.service.UpdateMetricValue(ctx, valueID, input, body.Timestamp, body.Source, body.Context)
+	if err != nil {
+		return nil, serviceError(err, "update metric value")
+	}
+	return toMetricValue(value), nil
+}
+
+func (s *metricValueServer) DeleteMetricValue(ctx context.Context, req *leaderboardv1.DeleteMetricValueRequest) (*emptypb.Empty, error) {
+	if err := requireScope(ctx, middleware.ScopeMetricsWrite); err != nil {
+		return nil, err
+	}
+
+	valueID, err := parseID(req.Id, "metric value ID")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteMetricValue(ctx, valueID); err != nil {
+		return nil, serviceError(err, "delete metric value")
+	}
+	return &emptypb.Empty{}, nil
+}
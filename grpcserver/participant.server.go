@@ -0,0 +1,205 @@
+package grpcserver
+
+import (
+	"context"
+
+	"leaderboard-service/handlers"
+	"leaderboard-service/middleware"
+	"leaderboard-service/models"
+	leaderboardv1 "leaderboard-service/proto/leaderboard/v1"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type participantServer struct {
+	leaderboardv1.UnimplementedParticipantServiceServer
+	service services.ParticipantService
+}
+
+func newParticipantServer() *participantServer {
+	return &participantServer{
+		service: newParticipantService(),
+	}
+}
+
+func (s *participantServer) GetParticipant(ctx context.Context, req *leaderboardv1.GetParticipantRequest) (*leaderboardv1.Participant, error) {
+	if req.Id == "" && req.ExternalId == "" {
+		return nil, status.Error(codes.InvalidArgument, "id or external_id is required")
+	}
+
+	var participant *models.Participant
+	var err error
+	if req.Id != "" {
+		participantID, parseErr := parseID(req.Id, "participant ID")
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		participant, err = s.service.GetParticipant(ctx, participantID)
+	} else {
+		participant, err = s.service.GetParticipantByExternalID(ctx, req.ExternalId)
+	}
+	if err != nil {
+		return nil, serviceError(err, "fetch participant")
+	}
+	return toParticipant(participant), nil
+}
+
+func (s *participantServer) ListParticipants(ctx context.Context, req *leaderboardv1.ListParticipantsRequest) (*leaderboardv1.ListParticipantsResponse, error) {
+	sort, err := parseSort(req.Sort, repositories.ParticipantSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := s.service.ListParticipants(ctx, nil, req.Tags, sort, false)
+	if err != nil {
+		return nil, serviceError(err, "fetch participants")
+	}
+
+	response := &leaderboardv1.ListParticipantsResponse{}
+	for i := range participants {
+		response.Participants = append(response.Participants, toParticipant(&participants[i]))
+	}
+	return response, nil
+}
+
+func (s *participantServer) CreateParticipant(ctx context.Context, req *leaderboardv1.CreateParticipantRequest) (*leaderboardv1.Participant, error) {
+	if err := requireScope(ctx, middleware.ScopeParticipantsWrite); err != nil {
+		return nil, err
+	}
+
+	body := handlers.CreateParticipantRequest{
+		ExternalID:  req.ExternalId,
+		Name:        req.Name,
+		Type:        req.Type,
+		UserID:      req.UserId,
+		DisplayName: req.DisplayName,
+		AvatarURL:   req.AvatarUrl,
+		Country:     req.Country,
+	}
+	if req.Metadata != nil {
+		body.Metadata = req.Metadata.AsMap()
+	}
+	if err := validate(body); err != nil {
+		return nil, err
+	}
+
+	userID, err := parseOptionalID(body.UserID, "user ID")
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := s.service.CreateParticipant(
+		ctx,
+		body.ExternalID,
+		body.Name,
+		body.Type,
+		body.Metadata,
+		userID,
+		body.DisplayName,
+		body.AvatarURL,
+		body.Country,
+	)
+	if err != nil {
+		return nil, serviceError(err, "create participant")
+	}
+	return toParticipant(participant), nil
+}
+
+func (s *participantServer) UpdateParticipant(ctx context.Context, req *leaderboardv1.UpdateParticipantRequest) (*leaderboardv1.Participant, error) {
+	if err := requireScope(ctx, middleware.ScopeParticipantsWrite); err != nil {
+		return nil, err
+	}
+
+	participantID, err := parseID(req.Id, "participant ID")
+	if err != nil {
+		return nil, err
+	}
+
+	body := handlers.UpdateParticipantRequest{
+		ExternalID:  req.ExternalId,
+		Name:        req.Name,
+		Type:        req.Type,
+		UserID:      req.UserId,
+		IsActive:    req.IsActive,
+		DisplayName: req.DisplayName,
+		AvatarURL:   req.AvatarUrl,
+		Country:     req.Country,
+	}
+	if req.Metadata != nil {
+		metadata := req.Metadata.AsMap()
+		body.Metadata = &metadata
+	}
+	if err := validate(body); err != nil {
+		return nil, err
+	}
+
+	var metadata *interface{}
+	if body.Metadata != nil {
+		metadataAsInterface := interface{}(*body.Metadata)
+		metadata = &metadataAsInterface
+	}
+
+	// An empty user_id unlinks the user
+	clearUser := body.UserID != nil && *body.UserID == ""
+	userIDParam := body.UserID
+	if clearUser {
+		userIDParam = nil
+	}
+	userID, err := parseOptionalID(userIDParam, "user ID")
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := s.service.UpdateParticipant(
+		ctx,
+		participantID,
+		body.ExternalID,
+		body.Name,
+		body.Type,
+		metadata,
+		userID,
+		clearUser,
+		body.DisplayName,
+		body.AvatarURL,
+		body.Country,
+		body.IsActive,
+	)
+	if err != nil {
+		return nil, serviceError(err, "update participant")
+	}
+	return toParticipant(participant), nil
+}
+
+func (s *participantServer) DeleteParticipant(ctx context.Context, req *leaderboardv1.DeleteParticipantRequest) (*emptypb.Empty, error) {
+	if err := requireScope(ctx, middleware.ScopeParticipantsWrite); err != nil {
+		return nil, err
+	}
+
+	participantID, err := parseID(req.Id, "participant ID")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteParticipant(ctx, participantID); err != nil {
+		return nil, serviceError(err, "delete participant")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// resolveParticipantID returns the participant named by ID, or else by the external_id it was created with
+func resolveParticipantID(ctx context.Context, service services.ParticipantService, participantID, externalID string) (uuid.UUID, error) {
+	if participantID != "" {
+		return parseID(participantID, "participant ID")
+	}
+
+	participant, err := service.GetParticipantByExternalID(ctx, externalID)
+	if err != nil {
+		return uuid.Nil, serviceError(err, "resolve participant")
+	}
+	return participant.ID, nil
+}
@@ -0,0 +1,67 @@
+package grpcserver
+
+import (
+	"context"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/models"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// redactedMessageFields maps the messages built from models to the model and the proto name of each model field,
+// so the fields middleware hides from REST callers that are not admins are hidden over gRPC too
+var redactedMessageFields = map[protoreflect.FullName]struct {
+	model  interface{}
+	fields map[string]protoreflect.Name
+}{
+	"leaderboard.v1.Participant": {models.Participant{}, map[string]protoreflect.Name{"Metadata": "metadata"}},
+	"leaderboard.v1.MetricValue": {models.MetricValue{}, map[string]protoreflect.Name{"Context": "context"}},
+}
+
+// newRedactionInterceptor clears redacted fields from responses to callers that are not admins, like
+// middleware.FieldRedaction. It must run after the auth interceptor.
+func newRedactionInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		if claims, claimsErr := middleware.GetUserFromContext(ctx); claimsErr == nil && middleware.Role(claims.Role) == middleware.RoleAdmin {
+			return resp, nil
+		}
+		if message, ok := resp.(proto.Message); ok {
+			redactMessage(message.ProtoReflect())
+		}
+		return resp, nil
+	}
+}
+
+// redactMessage clears the redacted fields of a message and of every message nested in it
+func redactMessage(message protoreflect.Message) {
+	if redacted, ok := redactedMessageFields[message.Descriptor().FullName()]; ok {
+		for field, name := range redacted.fields {
+			if middleware.IsFieldRedacted(redacted.model, field) {
+				message.Clear(message.Descriptor().Fields().ByName(name))
+			}
+		}
+	}
+
+	message.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if fd.Message() == nil || fd.IsMap() {
+			return true
+		}
+		if fd.IsList() {
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				redactMessage(list.Get(i).Message())
+			}
+			return true
+		}
+		redactMessage(value.Message())
+		return true
+	})
+}
@@ -0,0 +1,165 @@
+// Package grpcserver serves the typed gRPC API defined in proto/leaderboard/v1. Each RPC goes through the same
+// services, request validation and permission checks as its REST counterpart.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"leaderboard-service/db"
+	"leaderboard-service/enums"
+	leaderboardv1 "leaderboard-service/proto/leaderboard/v1"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer returns a gRPC server with every leaderboard service registered behind authentication
+func NewServer() *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(newMaintenanceInterceptor(), newStatementTimeoutInterceptor(), newAuthInterceptor(),
+		newRedactionInterceptor()))
+
+	leaderboardv1.RegisterLeaderboardServiceServer(server, newLeaderboardServer())
+	leaderboardv1.RegisterLeaderboardEntryServiceServer(server, newLeaderboardEntryServer())
+	leaderboardv1.RegisterParticipantServiceServer(server, newParticipantServer())
+	leaderboardv1.RegisterMetricServiceServer(server, newMetricServer())
+	leaderboardv1.RegisterMetricValueServiceServer(server, newMetricValueServer())
+
+	return server
+}
+
+// newStatementTimeoutInterceptor bounds each call's queries by db.StatementTimeout, like middleware.StatementTimeout.
+// A shorter deadline set by the client still applies.
+func newStatementTimeoutInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if db.StatementTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, db.StatementTimeout)
+			defer cancel()
+		}
+		return handler(ctx, req)
+	}
+}
+
+// newMaintenanceInterceptor rejects calls other than Get and List with UNAVAILABLE while the maintenance_mode feature
+// flag is on, like middleware.MaintenanceMode
+func newMaintenanceInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if services.FeatureEnabled(enums.FeatureMaintenanceMode) && !isReadMethod(info.FullMethod) {
+			return nil, status.Error(codes.Unavailable, "the service is in maintenance mode, only reads are available")
+		}
+		return handler(ctx, req)
+	}
+}
+
+func newLeaderboardAccessService() services.LeaderboardAccessService {
+	return services.NewLeaderboardAccessService(
+		repositories.NewLeaderboardRepository(),
+		repositories.NewLeaderboardMemberRepository(),
+		repositories.NewUserRepository(),
+		repositories.NewParticipantRepository(),
+		repositories.NewLeaderboardParticipantAccessRepository(),
+	)
+}
+
+func newParticipantService() services.ParticipantService {
+	return services.NewParticipantService(repositories.NewParticipantRepository(), repositories.NewParticipantNameChangeRepository(),
+		repositories.NewLeaderboardEntryRepository())
+}
+
+// validate checks a request against the same rules as the REST request body it mirrors
+func validate(req interface{}) error {
+	if err := validation.Validate.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return status.Error(codes.InvalidArgument, validation.FormatValidationErrors(validationErrors).Error())
+		}
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+func parseID(value, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid %s", name)
+	}
+	return id, nil
+}
+
+func parseOptionalID(value *string, name string) (*uuid.UUID, error) {
+	if value == nil {
+		return nil, nil
+	}
+	id, err := parseID(*value, name)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// parseSort checks a requested sort against the list's sortable columns. A sort without a field keeps the default order.
+func parseSort(sort *leaderboardv1.Sort, columns repositories.SortColumns) (*repositories.Sort, error) {
+	if sort == nil || sort.Field == "" {
+		return nil, nil
+	}
+	if !columns.Valid(sort.Field) {
+		return nil, status.Errorf(codes.InvalidArgument, "sort must be one of: %s", strings.Join(columns.Fields(), ", "))
+	}
+	return &repositories.Sort{Field: sort.Field, Desc: sort.Desc}, nil
+}
+
+func parseInclude(include []string, includes repositories.Includes) ([]string, error) {
+	for _, name := range include {
+		if !includes.Valid(name) {
+			return nil, status.Errorf(codes.InvalidArgument, "include must be one of: %s", strings.Join(includes.Names(), ", "))
+		}
+	}
+	return include, nil
+}
+
+// serviceError maps the errors returned by the service layer to the gRPC status codes matching the REST responses
+func serviceError(err error, action string) error {
+	message := err.Error()
+	var notEmptyErr *services.LeaderboardNotEmptyError
+	switch {
+	case errors.As(err, &notEmptyErr):
+		return status.Errorf(codes.FailedPrecondition, "%s: %d entries and %d leaderboard metrics would be deleted with it, set force to delete it anyway",
+			message, notEmptyErr.Entries, notEmptyErr.Metrics)
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Errorf(codes.DeadlineExceeded, "failed to %s: %v", action, err)
+	case errors.Is(err, context.Canceled):
+		return status.Errorf(codes.Canceled, "failed to %s: %v", action, err)
+	case strings.HasSuffix(message, " not found"):
+		return status.Error(codes.NotFound, message)
+	case message == "user already linked to another participant", message == "external_id already in use",
+		message == "participant already has an entry on the leaderboard",
+		message == "metric is already attached to the leaderboard":
+		return status.Error(codes.AlreadyExists, message)
+	case strings.HasSuffix(message, " version conflict"):
+		return status.Error(codes.Aborted, message)
+	case message == "leaderboard has reached max_entries", message == "leaderboard has ended":
+		return status.Error(codes.FailedPrecondition, message)
+	case message == "team_score_top_k is required for top_k aggregation",
+		message == "group_id must be a group participant",
+		message == "participant is not in the leaderboard's group",
+		message == "participant type does not match the leaderboard",
+		message == "metric is listed more than once",
+		message == "invalid cursor",
+		message == "end_date is before start_date",
+		message == "start_date and end_date are the same",
+		message == "end_date is in the past",
+		message == "value does not match the metric's data type",
+		message == "boolean and string metrics only support count and last aggregation",
+		message == "metric data type can't change between numeric and non-numeric":
+		return status.Error(codes.InvalidArgument, message)
+	}
+	return status.Errorf(codes.Internal, "failed to %s: %v", action, err)
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+)
+
+// AdminOverviewResponse is used for Swagger documentation
+type AdminOverviewResponse struct {
+	Counts             OverviewCountsResponse `json:"counts"`
+	MetricValuesPerDay []DailyCountResponse   `json:"metric_values_per_day"`
+	RecentErrors       []RecentErrorResponse  `json:"recent_errors"`
+	DatabasePool       PoolStatsResponse      `json:"database_pool"`
+	GeneratedAt        time.Time              `json:"generated_at" example:"2023-01-08T12:00:00Z"`
+}
+
+// OverviewCountsResponse is used for Swagger documentation
+type OverviewCountsResponse struct {
+	Leaderboards int64 `json:"leaderboards" example:"12"`
+	Participants int64 `json:"participants" example:"340"`
+	Entries      int64 `json:"entries" example:"1520"`
+}
+
+// DailyCountResponse is used for Swagger documentation
+type DailyCountResponse struct {
+	Date  string `json:"date" example:"2023-01-08"`
+	Count int64  `json:"count" example:"4210"`
+}
+
+// RecentErrorResponse is used for Swagger documentation
+type RecentErrorResponse struct {
+	RequestID  string    `json:"request_id" example:"host/abcdef-000042"`
+	Method     string    `json:"method" example:"GET"`
+	Path       string    `json:"path" example:"/api/v1/leaderboards/550e8400-e29b-41d4-a716-446655440000/standings"`
+	Status     int       `json:"status" example:"500"`
+	OccurredAt time.Time `json:"occurred_at" example:"2023-01-08T11:58:00Z"`
+}
+
+// PoolStatsResponse is used for Swagger documentation
+type PoolStatsResponse struct {
+	MaxOpenConnections int   `json:"max_open_connections" example:"25"`
+	OpenConnections    int   `json:"open_connections" example:"12"`
+	InUse              int   `json:"in_use" example:"3"`
+	Idle               int   `json:"idle" example:"9"`
+	WaitCount          int64 `json:"wait_count" example:"41"`
+	WaitDurationMs     int64 `json:"wait_duration_ms" example:"820"`
+	MaxIdleClosed      int64 `json:"max_idle_closed" example:"5"`
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed" example:"18"`
+}
+
+type AdminHandler struct {
+	overviewService services.OverviewService
+}
+
+func NewAdminHandler() *AdminHandler {
+	return &AdminHandler{
+		overviewService: services.NewOverviewService(repositories.NewOverviewRepository()),
+	}
+}
+
+// GetOverview returns a system-wide summary for ops dashboards
+// @Summary Get the admin overview
+// @Description Get counts of live leaderboards, participants and entries, the number of metric values ingested on each UTC day (including days with none), the latest server errors and the state of the database connection pool. Errors and pool statistics belong to each instance, so they cover only the instance that answers, and errors are lost on restart.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Number of days of metric value counts, today included (max 365)" default(30)
+// @Success 200 {object} AdminOverviewResponse "Overview"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid days"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /admin/overview [get]
+func (h *AdminHandler) GetOverview(w http.ResponseWriter, r *http.Request) {
+	days := 0
+	if daysParam := r.URL.Query().Get("days"); daysParam != "" {
+		parsedDays, err := strconv.Atoi(daysParam)
+		if err != nil || parsedDays < 1 || parsedDays > services.MaxOverviewDays {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid days, must be an integer from 1 to 365", err)
+			return
+		}
+		days = parsedDays
+	}
+
+	overview, err := h.overviewService.GetOverview(r.Context(), days)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to build overview", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, overview)
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// CreateAPIKeyRequest represents the request payload for creating an API key
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=255" example:"metrics-ingest"`
+	Role      string     `json:"role" validate:"required,oneof=admin moderator user" example:"moderator" enums:"admin,moderator,user"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,oneof=read write" example:"read,write"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2030-01-01T00:00:00Z"`
+}
+
+// APIKeyResponse is used for Swagger documentation
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-446655440007"`
+	Name       string     `json:"name" example:"metrics-ingest"`
+	Prefix     string     `json:"prefix" example:"lbk_Zx8kQ2mP"`
+	Role       string     `json:"role" example:"moderator"`
+	Scopes     []string   `json:"scopes" example:"read,write"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" example:"2030-01-01T00:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	CreatedAt  time.Time  `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt  time.Time  `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// CreatedAPIKeyResponse includes the plaintext key, which is only returned once
+type CreatedAPIKeyResponse struct {
+	APIKey APIKeyResponse `json:"api_key"`
+	Key    string         `json:"key" example:"lbk_Zx8kQ2mPq9..."`
+}
+
+type APIKeyHandler struct {
+	service services.APIKeyService
+}
+
+func NewAPIKeyHandler() *APIKeyHandler {
+	repo := repositories.NewAPIKeyRepository()
+	service := services.NewAPIKeyService(repo)
+	return &APIKeyHandler{
+		service: service,
+	}
+}
+
+// CreateAPIKey creates a new API key for a backend service
+// @Summary Create an API key
+// @Description Create an API key for service-to-service calls. The key is returned only in this response; send it in the X-API-Key header.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param apiKey body CreateAPIKeyRequest true "API key data"
+// @Success 201 {object} CreatedAPIKeyResponse "Created API key"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req CreateAPIKeyRequest
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		middleware.RespondWithError(w, http.StatusBadRequest, "expires_at must be in the future", nil)
+		return
+	}
+
+	apiKey, rawKey, err := h.service.CreateAPIKey(r.Context(), req.Name, req.Role, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to create API key", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"api_key": apiKey,
+		"key":     rawKey,
+	})
+}
+
+// ListAPIKeys returns all active API keys
+// @Summary List API keys
+// @Description Get all API keys that have not been revoked. Key values are never returned.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} APIKeyResponse "List of API keys"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	apiKeys, err := h.service.ListAPIKeys(r.Context())
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch API keys", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, apiKeys)
+}
+
+// RevokeAPIKey revokes an API key
+// @Summary Revoke an API key
+// @Description Revoke an API key so it can no longer authenticate
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "API key ID"
+// @Success 204 "No content"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid API key ID", err)
+		return
+	}
+
+	err = h.service.RevokeAPIKey(r.Context(), id)
+	if err != nil {
+		if err.Error() == "api key not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "API key not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to revoke API key", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"github.com/google/uuid"
+)
+
+// AuditLogResponse is used for Swagger documentation
+type AuditLogResponse struct {
+	ID         uint64      `json:"id" example:"42"`
+	EntityType string      `json:"entity_type" example:"leaderboard"`
+	EntityID   uuid.UUID   `json:"entity_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Action     string      `json:"action" example:"update"`
+	ActorType  string      `json:"actor_type" example:"user"`
+	ActorID    string      `json:"actor_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	Before     interface{} `json:"before"`
+	After      interface{} `json:"after"`
+	Changes    interface{} `json:"changes"`
+	OccurredAt time.Time   `json:"occurred_at" example:"2023-01-01T00:00:00Z"`
+}
+
+type AuditLogHandler struct {
+	service services.AuditLogService
+}
+
+func NewAuditLogHandler() *AuditLogHandler {
+	repo := repositories.NewAuditLogRepository()
+	service := services.NewAuditLogService(repo)
+	return &AuditLogHandler{
+		service: service,
+	}
+}
+
+// ListAuditLogs returns the audit logs matching the filters after the given ID
+// @Summary List the audit log
+// @Description Get the recorded changes to leaderboards, entries, metrics and participants with an ID greater than after_id, oldest first, with who made each change and the entity before and after it. Pass the ID of the last log received as after_id to get the next page.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entity_type query string false "Type of entity changed" Enums(leaderboard, leaderboard_entry, metric, participant)
+// @Param entity_id query string false "ID of the entity changed"
+// @Param action query string false "Kind of change" Enums(create, update, delete, restore)
+// @Param actor_id query string false "User ID, service token subject or API key ID of the actor"
+// @Param from query string false "Only changes made at or after this time (RFC3339)"
+// @Param to query string false "Only changes made before this time (RFC3339)"
+// @Param after_id query int false "Return logs with an ID greater than this value" default(0)
+// @Param limit query int false "Maximum number of logs to return (max 1000)" default(100)
+// @Success 200 {array} AuditLogResponse "List of audit logs"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /admin/audit-logs [get]
+func (h *AuditLogHandler) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var filter repositories.AuditLogFilter
+
+	if entityTypeParam := query.Get("entity_type"); entityTypeParam != "" {
+		entityType := enums.AuditEntityType(entityTypeParam)
+		if !entityType.Valid() {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid entity_type: "+entityTypeParam, nil)
+			return
+		}
+		filter.EntityType = &entityType
+	}
+	if entityIDParam := query.Get("entity_id"); entityIDParam != "" {
+		entityID, err := uuid.Parse(entityIDParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid entity_id format", err)
+			return
+		}
+		filter.EntityID = &entityID
+	}
+	if actionParam := query.Get("action"); actionParam != "" {
+		action := enums.AuditAction(actionParam)
+		if !action.Valid() {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid action: "+actionParam, nil)
+			return
+		}
+		filter.Action = &action
+	}
+	if actorID := query.Get("actor_id"); actorID != "" {
+		filter.ActorID = &actorID
+	}
+	if fromParam := query.Get("from"); fromParam != "" {
+		from, err := time.Parse(time.RFC3339, fromParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid from format, use RFC3339", err)
+			return
+		}
+		filter.From = &from
+	}
+	if toParam := query.Get("to"); toParam != "" {
+		to, err := time.Parse(time.RFC3339, toParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid to format, use RFC3339", err)
+			return
+		}
+		filter.To = &to
+	}
+
+	var afterID uint64
+	if afterIDParam := query.Get("after_id"); afterIDParam != "" {
+		parsedID, err := strconv.ParseUint(afterIDParam, 10, 64)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid after_id, must be a non-negative integer", err)
+			return
+		}
+		afterID = parsedID
+	}
+
+	limit := 0
+	if limitParam := query.Get("limit"); limitParam != "" {
+		parsedLimit, err := strconv.Atoi(limitParam)
+		if err != nil || parsedLimit < 1 {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid limit, must be a positive integer", err)
+			return
+		}
+		limit = parsedLimit
+	}
+
+	logs, err := h.service.ListAuditLogs(r.Context(), filter, afterID, limit)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch audit logs", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, logs)
+}
@@ -1,111 +1,288 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"leaderboard-service/middleware"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
 
-	"github.com/google/uuid"
+	"github.com/go-playground/validator/v10"
 )
 
 // LoginRequest represents the login credentials
 type LoginRequest struct {
-	Username string `json:"username" example:"admin"`
-	Password string `json:"password" example:"password123"`
+	Username string   `json:"username" validate:"required" example:"admin"`
+	Password string   `json:"password" validate:"required" example:"password123"`
+	Scopes   []string `json:"scopes,omitempty" example:"leaderboards:write"` // Optional, restricts the token to fewer scopes than the role allows
 }
 
 // LoginResponse represents the response after successful login
 type LoginResponse struct {
-	Token     string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	TokenType string `json:"token_type" example:"Bearer"`
-	UserID    string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Role      string `json:"role" example:"admin"`
+	Token     string   `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	TokenType string   `json:"token_type" example:"Bearer"`
+	UserID    string   `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Role      string   `json:"role" example:"admin"`
+	Scopes    []string `json:"scopes" example:"leaderboards:write,leaderboards:admin"`
 }
 
 // RegisterRequest represents registration input
 type RegisterRequest struct {
-	Username string `json:"username" example:"newuser"`
-	Password string `json:"password" example:"securepass123"`
-	Email    string `json:"email" example:"user@example.com"`
+	Username string `json:"username" validate:"required,min=3,max=50,alphanum" example:"newuser"`
+	Password string `json:"password" validate:"required,min=8,max=72" example:"securepass123"` // bcrypt ignores anything past 72 bytes
+	Email    string `json:"email" validate:"required,email" example:"user@example.com"`
+}
+
+// PasswordResetRequest starts a password reset
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email" example:"user@example.com"`
+}
+
+// PasswordResetConfirmRequest sets a new password with an emailed reset token
+type PasswordResetConfirmRequest struct {
+	Token    string `json:"token" validate:"required" example:"q1w2e3r4t5y6u7i8o9p0..."`
+	Password string `json:"password" validate:"required,min=8,max=72" example:"newsecurepass123"` // bcrypt ignores anything past 72 bytes
+}
+
+type AuthHandler struct {
+	service      services.AuthService
+	throttle     services.LoginThrottleService
+	resetService services.PasswordResetService
+}
+
+func NewAuthHandler() *AuthHandler {
+	repo := repositories.NewUserRepository()
+	service := services.NewAuthService(repo)
+	throttle := services.NewLoginThrottleService(repositories.NewLoginAttemptRepository())
+	resetService := services.NewPasswordResetService(repo, repositories.NewPasswordResetTokenRepository())
+	return &AuthHandler{
+		service:      service,
+		throttle:     throttle,
+		resetService: resetService,
+	}
 }
 
 // Login handles user authentication and token generation
 // @Summary Log in a user
-// @Description Authenticate a user and generate a JWT token
+// @Description Authenticate a user and generate a JWT token. Pass scopes to get a token limited to a subset of the account's permissions.
+// @Description Repeated failures temporarily lock out the username and the client IP address.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param loginRequest body LoginRequest true "Login credentials"
 // @Success 200 {object} LoginResponse "Login successful"
 // @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Invalid username or password"
+// @Failure 403 {object} middleware.ErrorResponse "Requested scopes not allowed"
+// @Failure 429 {object} middleware.ErrorResponse "Too many failed attempts, see the Retry-After header"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /auth/login [post]
-func Login(w http.ResponseWriter, r *http.Request) {
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
 
 	// Parse request body
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	// TODO: Replace with actual user authentication logic
-	// For demonstration purposes, we'll accept any username/password and
-	// generate a token with a random UUID as the user ID
-
-	// In a real application, you would:
-	// 1. Validate username/password against the database
-	// 2. If valid, generate a token with the user's actual ID and role
-	// 3. Return the token to the client
-
-	// Mock user authentication
-	if req.Username == "" || req.Password == "" {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Username and password are required", nil)
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
 		return
 	}
 
-	// Generate a mock user ID and role
-	userID := uuid.New().String()
-	userRole := "user"
-
-	// Assign admin role for a specific username (for testing)
-	if req.Username == "admin" {
-		userRole = "admin"
+	// Reject locked out usernames and addresses before checking the password
+	ipAddress := middleware.ClientIP(r)
+	if err := h.throttle.Check(r.Context(), req.Username, ipAddress); err != nil {
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			h.throttle.RecordFailure(r.Context(), req.Username, ipAddress, models.LoginFailureThrottled)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			middleware.RespondWithError(w, http.StatusTooManyRequests, "Too many failed login attempts, try again later", nil)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to authenticate", err)
+		return
 	}
 
-	// Generate JWT token
-	token, err := middleware.GenerateToken(userID, userRole)
+	user, err := h.service.Authenticate(r.Context(), req.Username, req.Password)
 	if err != nil {
-		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to generate token", err)
+		if err.Error() == "invalid credentials" {
+			h.throttle.RecordFailure(r.Context(), req.Username, ipAddress, models.LoginFailureInvalidCredentials)
+			middleware.RespondWithError(w, http.StatusUnauthorized, "Invalid username or password", nil)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to authenticate", err)
 		return
 	}
+	h.throttle.RecordSuccess(r.Context(), req.Username, ipAddress)
 
-	// Create response
-	resp := LoginResponse{
-		Token:     token,
-		TokenType: "Bearer",
-		UserID:    userID,
-		Role:      userRole,
+	if req.Scopes != nil && !middleware.ScopesAllowedForRole(middleware.Role(user.Role), req.Scopes) {
+		middleware.RespondWithError(w, http.StatusForbidden, "Requested scopes exceed those allowed for this account", nil)
+		return
 	}
 
-	middleware.RespondWithJSON(w, http.StatusOK, resp)
+	h.respondWithToken(w, http.StatusOK, user, req.Scopes)
 }
 
 // Register handles user registration
 // @Summary Register a new user
-// @Description Register a new user account
+// @Description Register a new user account and log it in
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param registerRequest body RegisterRequest true "Registration data"
 // @Success 201 {object} LoginResponse "Registration successful"
 // @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 409 {object} middleware.ErrorResponse "Username or email already in use"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /auth/register [post]
-func Register(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement user registration
-	// This is a placeholder for future implementation
-	middleware.RespondWithError(w, http.StatusNotImplemented, "Registration not implemented yet", nil)
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req RegisterRequest
+
+	// Parse request body
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	user, err := h.service.Register(r.Context(), req.Username, req.Email, req.Password)
+	if err != nil {
+		switch err.Error() {
+		case "username already taken":
+			middleware.RespondWithError(w, http.StatusConflict, "Username already taken", err)
+		case "email already registered":
+			middleware.RespondWithError(w, http.StatusConflict, "Email already registered", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to register user", err)
+		}
+		return
+	}
+
+	h.respondWithToken(w, http.StatusCreated, user, nil)
+}
+
+// RequestPasswordReset emails a password reset token
+// @Summary Request a password reset
+// @Description Email a single-use password reset token to the account with this address. The response is the same whether or not an account exists.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body PasswordResetRequest true "Account email"
+// @Success 202 "Reset email sent if the account exists"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /auth/password-reset/request [post]
+func (h *AuthHandler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req PasswordResetRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	if err := h.resetService.RequestReset(r.Context(), req.Email); err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to request password reset", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ConfirmPasswordReset sets a new password using a reset token
+// @Summary Confirm a password reset
+// @Description Set a new password with the token from the reset email. Tokens expire after an hour and can only be used once.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body PasswordResetConfirmRequest true "Reset token and new password"
+// @Success 204 "Password changed"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request or invalid or expired token"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /auth/password-reset/confirm [post]
+func (h *AuthHandler) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req PasswordResetConfirmRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	err := h.resetService.ConfirmReset(r.Context(), req.Token, req.Password)
+	if err != nil {
+		if err.Error() == "invalid or expired reset token" {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid or expired reset token", nil)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to reset password", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// JWKS publishes the keys that verify tokens issued by this service
+// @Summary Get the JSON Web Key Set
+// @Description Get the public keys other services use to verify tokens issued by this service. Tokens name their key in the kid header. HMAC secrets are never published, so the set is empty unless RS256 or ES256 signing is configured.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} middleware.JSONWebKeySet "Key set"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	jwks, err := middleware.PublicJWKS()
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to load signing keys", err)
+		return
+	}
+
+	// Let verifiers cache the keys briefly so rotations propagate quickly
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	middleware.RespondWithJSON(w, http.StatusOK, jwks)
+}
+
+// respondWithToken issues a JWT for the user and writes the login response.
+// A nil scopes list issues the role's default scopes.
+func (h *AuthHandler) respondWithToken(w http.ResponseWriter, status int, user *models.User, scopes []string) {
+	if scopes == nil {
+		scopes = middleware.DefaultScopesForRole(middleware.Role(user.Role))
+	}
+
+	token, err := middleware.GenerateToken(user.ID.String(), user.Role, scopes)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to generate token", err)
+		return
+	}
+
+	resp := LoginResponse{
+		Token:     token,
+		TokenType: "Bearer",
+		UserID:    user.ID.String(),
+		Role:      user.Role,
+		Scopes:    scopes,
+	}
+
+	middleware.RespondWithJSON(w, status, resp)
 }
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ChangeFeedResponse is used for Swagger documentation
+type ChangeFeedResponse struct {
+	Changes    []EventResponse `json:"changes"`
+	NextCursor string          `json:"next_cursor" example:"ZXY6NDI"`
+	HasMore    bool            `json:"has_more" example:"false"`
+}
+
+type ChangeFeedHandler struct {
+	service services.ChangeFeedService
+}
+
+func NewChangeFeedHandler() *ChangeFeedHandler {
+	eventRepo := repositories.NewEventRepository()
+	leaderboardRepo := repositories.NewLeaderboardRepository()
+	service := services.NewChangeFeedService(eventRepo, leaderboardRepo)
+	return &ChangeFeedHandler{
+		service: service,
+	}
+}
+
+// ListLeaderboardChanges returns the entry mutations of a leaderboard since the cursor
+// @Summary Get the change feed for a leaderboard
+// @Description Get entry mutations (created, updated, deleted, rank changed) in the order they happened. Omit the cursor for a full sync, then pass next_cursor from the previous response to resume. Keep fetching while has_more is true.
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous call"
+// @Param limit query int false "Maximum number of changes to return (max 500)" default(100)
+// @Success 200 {object} ChangeFeedResponse "Page of changes"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Leaderboard not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/changes [get]
+func (h *ChangeFeedHandler) ListLeaderboardChanges(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	limit := 0
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsedLimit, err := strconv.Atoi(limitParam)
+		if err != nil || parsedLimit < 1 {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid limit, must be a positive integer", err)
+			return
+		}
+		limit = parsedLimit
+	}
+
+	feed, err := h.service.GetChanges(r.Context(), leaderboardID, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		switch err.Error() {
+		case "invalid cursor":
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid cursor", err)
+		case "leaderboard not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch changes", err)
+		}
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, feed)
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// RegisterDeviceTokenRequest represents the request payload for registering a device for push notifications
+type RegisterDeviceTokenRequest struct {
+	Provider string `json:"provider" validate:"required,oneof=fcm apns" example:"fcm" enums:"fcm,apns"`
+	Token    string `json:"token" validate:"required,max=4096" example:"dGhpcyBpcyBhIGRldmljZSB0b2tlbg"`
+}
+
+// DeviceTokenResponse is used for Swagger documentation
+type DeviceTokenResponse struct {
+	ID            uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440006"`
+	ParticipantID uuid.UUID `json:"participant_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Provider      string    `json:"provider" example:"fcm"`
+	Token         string    `json:"token" example:"dGhpcyBpcyBhIGRldmljZSB0b2tlbg"`
+	CreatedAt     time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt     time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+type DeviceTokenHandler struct {
+	service services.DeviceTokenService
+}
+
+func NewDeviceTokenHandler() *DeviceTokenHandler {
+	repo := repositories.NewDeviceTokenRepository()
+	participantRepo := repositories.NewParticipantRepository()
+	service := services.NewDeviceTokenService(repo, participantRepo)
+	return &DeviceTokenHandler{
+		service: service,
+	}
+}
+
+// RegisterDeviceToken registers a device to receive rank change push notifications
+// @Summary Register a device token
+// @Description Map a push notification device token to a participant. Registering an existing token moves it to this participant.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Participant ID"
+// @Param deviceToken body RegisterDeviceTokenRequest true "Device token data"
+// @Success 201 {object} DeviceTokenResponse "Registered device token"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Participant not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/device-tokens [post]
+func (h *DeviceTokenHandler) RegisterDeviceToken(w http.ResponseWriter, r *http.Request) {
+	participantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	var req RegisterDeviceTokenRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	deviceToken, err := h.service.RegisterDeviceToken(r.Context(), participantID, enums.PushProvider(req.Provider), req.Token)
+	if err != nil {
+		if err.Error() == "participant not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to register device token", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusCreated, deviceToken)
+}
+
+// ListDeviceTokens returns the devices registered for a participant
+// @Summary List device tokens for a participant
+// @Description Get all push notification device tokens registered for a participant
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Participant ID"
+// @Success 200 {array} DeviceTokenResponse "List of device tokens"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Participant not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/device-tokens [get]
+func (h *DeviceTokenHandler) ListDeviceTokens(w http.ResponseWriter, r *http.Request) {
+	participantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	deviceTokens, err := h.service.ListDeviceTokens(r.Context(), participantID)
+	if err != nil {
+		if err.Error() == "participant not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch device tokens", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, deviceTokens)
+}
+
+// DeleteDeviceToken unregisters a device from push notifications
+// @Summary Delete a device token
+// @Description Stop sending push notifications to a device
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Participant ID"
+// @Param tokenId path string true "Device token ID"
+// @Success 204 "No content"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/device-tokens/{tokenId} [delete]
+func (h *DeviceTokenHandler) DeleteDeviceToken(w http.ResponseWriter, r *http.Request) {
+	participantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	tokenID, err := uuid.Parse(chi.URLParam(r, "tokenId"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid device token ID", err)
+		return
+	}
+
+	err = h.service.DeleteDeviceToken(r.Context(), participantID, tokenID)
+	if err != nil {
+		if err.Error() == "device token not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Device token not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to delete device token", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
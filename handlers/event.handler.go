@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"github.com/google/uuid"
+)
+
+// EventResponse is used for Swagger documentation
+type EventResponse struct {
+	ID            uint64      `json:"id" example:"42"`
+	Type          string      `json:"type" example:"entry.rank_changed"`
+	LeaderboardID *uuid.UUID  `json:"leaderboard_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Data          interface{} `json:"data"`
+	OccurredAt    time.Time   `json:"occurred_at" example:"2023-01-01T00:00:00Z"`
+	CreatedAt     time.Time   `json:"created_at" example:"2023-01-01T00:00:00Z"`
+}
+
+type EventHandler struct {
+	service       services.EventLogService
+	accessService services.LeaderboardAccessService
+}
+
+func NewEventHandler() *EventHandler {
+	repo := repositories.NewEventRepository()
+	service := services.NewEventLogService(repo)
+	return &EventHandler{
+		service:       service,
+		accessService: newLeaderboardAccessService(),
+	}
+}
+
+// ListEvents returns persisted events after the given ID
+// @Summary Poll the event log
+// @Description Get events with an ID greater than after_id, oldest first. Store the ID of the last event received and pass it as after_id on the next poll; events may be delivered more than once. Tokens without the leaderboards:admin scope only get the events of leaderboards they can read.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param after_id query int false "Return events with an ID greater than this value" default(0)
+// @Param types query string false "Comma-separated list of event types to include"
+// @Param limit query int false "Maximum number of events to return (max 1000)" default(100)
+// @Success 200 {array} EventResponse "List of events"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Router /events [get]
+func (h *EventHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	afterIDParam := r.URL.Query().Get("after_id")
+	typesParam := r.URL.Query().Get("types")
+	limitParam := r.URL.Query().Get("limit")
+
+	var afterID uint64
+	if afterIDParam != "" {
+		parsedID, err := strconv.ParseUint(afterIDParam, 10, 64)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid after_id, must be a non-negative integer", err)
+			return
+		}
+		afterID = parsedID
+	}
+
+	var types []string
+	if typesParam != "" {
+		for _, t := range strings.Split(typesParam, ",") {
+			t = strings.TrimSpace(t)
+			if !enums.EventType(t).Valid() {
+				middleware.RespondWithError(w, http.StatusBadRequest, "Invalid event type: "+t, nil)
+				return
+			}
+			types = append(types, t)
+		}
+	}
+
+	limit := 0
+	if limitParam != "" {
+		parsedLimit, err := strconv.Atoi(limitParam)
+		if err != nil || parsedLimit < 1 {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid limit, must be a positive integer", err)
+			return
+		}
+		limit = parsedLimit
+	}
+
+	// Private leaderboards' events are only for those who can read them, and events not tied to a leaderboard for admins
+	var leaderboardIDs []uuid.UUID
+	if viewer := middleware.GetViewerFromContext(r); !viewer.CanReadAll {
+		readable, err := h.accessService.ReadableLeaderboardIDs(r.Context(), viewer)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to check leaderboard permissions", err)
+			return
+		}
+		leaderboardIDs = readable
+	}
+
+	events, err := h.service.ListEvents(r.Context(), afterID, types, leaderboardIDs, limit)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch events", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, events)
+}
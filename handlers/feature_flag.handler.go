@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// SetFeatureFlagRequest represents the request payload for turning a feature flag on or off
+type SetFeatureFlagRequest struct {
+	Enabled *bool `json:"enabled" validate:"required" example:"false"`
+}
+
+// FeatureFlagResponse is used for Swagger documentation
+type FeatureFlagResponse struct {
+	Name        string     `json:"name" example:"standings_cache"`
+	Description string     `json:"description" example:"Serve the standings of public leaderboards from Redis, when REDIS_URL is set"`
+	Enabled     bool       `json:"enabled" example:"false"`
+	Default     bool       `json:"default" example:"true"`    // Value the instance was started with
+	Overridden  bool       `json:"overridden" example:"true"` // Whether an admin has set the flag
+	UpdatedBy   string     `json:"updated_by" example:"550e8400-e29b-41d4-a716-446655440008"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty" example:"2023-01-01T00:00:00Z"`
+}
+
+type FeatureFlagHandler struct {
+	service services.FeatureFlagService
+}
+
+func NewFeatureFlagHandler() *FeatureFlagHandler {
+	repo := repositories.NewFeatureFlagRepository()
+	service := services.NewFeatureFlagService(repo)
+	return &FeatureFlagHandler{
+		service: service,
+	}
+}
+
+// ListFeatureFlags returns every feature flag with its value
+// @Summary List feature flags
+// @Description Get every feature flag with its value, the value instances were started with (FEATURE_<NAME> or built in) and, when an admin has set it, who set it and when.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} FeatureFlagResponse "List of feature flags"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /admin/feature-flags [get]
+func (h *FeatureFlagHandler) ListFeatureFlags(w http.ResponseWriter, r *http.Request) {
+	flags, err := h.service.ListFeatureFlags(r.Context())
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch feature flags", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, flags)
+}
+
+// SetFeatureFlag turns a feature flag on or off
+// @Summary Set a feature flag
+// @Description Turn a feature flag on or off on every instance until it is reset. The instance answering applies it right away, the others within FEATURE_FLAG_REFRESH_INTERVAL seconds.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Feature flag name" Enums(standings_cache, standings_summary, score_buffer, maintenance_mode)
+// @Param flag body SetFeatureFlagRequest true "Value of the flag"
+// @Success 200 {object} FeatureFlagResponse "Feature flag"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Feature flag not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /admin/feature-flags/{name} [put]
+func (h *FeatureFlagHandler) SetFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	var req SetFeatureFlagRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	flag, err := h.service.SetFeatureFlag(r.Context(), enums.FeatureFlag(chi.URLParam(r, "name")), *req.Enabled, claims.Subject)
+	if err != nil {
+		h.respondWithError(w, err, "Failed to set feature flag")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, flag)
+}
+
+// ResetFeatureFlag gives a feature flag back the value instances were started with
+// @Summary Reset a feature flag
+// @Description Drop the value an admin set for a feature flag, so every instance goes back to the value it was started with (FEATURE_<NAME> or built in).
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Feature flag name" Enums(standings_cache, standings_summary, score_buffer, maintenance_mode)
+// @Success 200 {object} FeatureFlagResponse "Feature flag"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Feature flag not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /admin/feature-flags/{name} [delete]
+func (h *FeatureFlagHandler) ResetFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	flag, err := h.service.ResetFeatureFlag(r.Context(), enums.FeatureFlag(chi.URLParam(r, "name")))
+	if err != nil {
+		h.respondWithError(w, err, "Failed to reset feature flag")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, flag)
+}
+
+func (h *FeatureFlagHandler) respondWithError(w http.ResponseWriter, err error, message string) {
+	switch err.Error() {
+	case "feature flag not found":
+		middleware.RespondWithError(w, http.StatusNotFound, "Feature flag not found", err)
+	default:
+		middleware.RespondWithError(w, http.StatusInternalServerError, message, err)
+	}
+}
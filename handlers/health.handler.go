@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+)
+
+// ReadinessResponse is used for Swagger documentation
+type ReadinessResponse struct {
+	Ready  bool                              `json:"ready" example:"true"`
+	Checks map[string]ReadinessCheckResponse `json:"checks"`
+}
+
+// ReadinessCheckResponse is used for Swagger documentation
+type ReadinessCheckResponse struct {
+	Status string `json:"status" example:"ok" enums:"ok,failing,disabled"`
+	Error  string `json:"error,omitempty" example:"dial tcp 10.0.0.5:5432: connect: connection refused"`
+}
+
+type HealthHandler struct {
+	healthService services.HealthService
+}
+
+func NewHealthHandler() *HealthHandler {
+	return &HealthHandler{
+		healthService: services.NewHealthService(repositories.NewHealthRepository()),
+	}
+}
+
+// Liveness reports that the process is up
+// @Summary Liveness probe
+// @Description Returns 200 while the process can answer requests. It checks no dependency, so an outage of the database doesn't get every instance restarted; use /readyz to take an instance out of rotation.
+// @Tags health
+// @Success 200 "Alive"
+// @Router /healthz [get]
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// Readiness reports whether the instance can serve traffic
+// @Summary Readiness probe
+// @Description Checks that the database can be reached, that it has had every migration this build knows and that the standings cache can be reached when REDIS_URL is set. Returns 200 when every check passes and 503 otherwise, with the result of each check either way.
+// @Tags health
+// @Produce json
+// @Success 200 {object} ReadinessResponse "Ready"
+// @Failure 503 {object} ReadinessResponse "Not ready"
+// @Router /readyz [get]
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	readiness := h.healthService.Readiness(r.Context())
+
+	status := http.StatusOK
+	if !readiness.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	middleware.RespondWithJSON(w, status, readiness)
+}
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxImportRows caps the rows of a single import, which is stored in one transaction
+	maxImportRows = 50000
+	// maxImportErrors caps the invalid rows reported back for one import
+	maxImportErrors = 100
+)
+
+var (
+	metricValueImportColumns = []string{"metric_id", "participant_id", "participant_external_id", "value", "timestamp", "source"}
+	participantImportColumns = []string{"external_id", "name", "type", "display_name", "avatar_url", "country"}
+)
+
+// ImportResponse is used for Swagger documentation
+type ImportResponse struct {
+	Imported int `json:"imported" example:"1200"`
+}
+
+type ImportHandler struct {
+	metricValueService services.MetricValueService
+	participantService services.ParticipantService
+}
+
+func NewImportHandler() *ImportHandler {
+	participantRepo := repositories.NewParticipantRepository()
+	return &ImportHandler{
+		metricValueService: services.NewMetricValueService(repositories.NewMetricValueRepository(),
+			repositories.NewMetricRepository(), participantRepo),
+		participantService: services.NewParticipantService(participantRepo, repositories.NewParticipantNameChangeRepository(),
+			repositories.NewLeaderboardEntryRepository()),
+	}
+}
+
+// ImportMetricValues records metric values in bulk from a CSV file
+// @Summary Import metric values
+// @Description Record many metric values at once from a CSV body whose header names the columns: metric_id, value, either participant_id or participant_external_id, and optionally timestamp (RFC3339, defaults to now) and source. Values are read as their metric's data type: a number, true or false, or any text. Values are stored in batches in one transaction, so either every row is imported or none is. Imported values do not emit metric_value.created events; recompute team leaderboards afterwards if they use the metrics.
+// @Tags metric-values
+// @Accept text/csv
+// @Produce json
+// @Security BearerAuth
+// @Param file body string true "CSV with a header row, at most 50000 rows"
+// @Success 201 {object} ImportResponse "Number of values imported"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid CSV or rows, or a value not of its metric's data type"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "A row's metric or participant was not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /metric-values/import [post]
+func (h *ImportHandler) ImportMetricValues(w http.ResponseWriter, r *http.Request) {
+	records, ok := readImportCSV(w, r, metricValueImportColumns)
+	if !ok {
+		return
+	}
+
+	rows := make([]services.MetricValueImport, len(records))
+	var rowErrors validation.Errors
+	for i, record := range records {
+		req := CreateMetricValueRequest{
+			MetricID:              record["metric_id"],
+			ParticipantID:         record["participant_id"],
+			ParticipantExternalID: record["participant_external_id"],
+			Source:                record["source"],
+		}
+		// Values are read as their metric's data type when the rows are stored
+		if record["value"] == "" {
+			rowErrors = appendRowError(rowErrors, i+1, validation.FieldError{Field: "value", Rule: "required", Message: "value is required"})
+			continue
+		}
+		if timestamp := record["timestamp"]; timestamp != "" {
+			parsed, err := time.Parse(time.RFC3339, timestamp)
+			if err != nil {
+				rowErrors = appendRowError(rowErrors, i+1, validation.FieldError{Field: "timestamp", Rule: "datetime", Message: "timestamp must be an RFC3339 date-time"})
+				continue
+			}
+			req.Timestamp = &parsed
+		}
+		if err := validation.Validate.StructExcept(req, "Value"); err != nil {
+			rowErrors = appendRowError(rowErrors, i+1, validation.FormatValidationErrors(err.(validator.ValidationErrors)).(validation.Errors)...)
+			continue
+		}
+
+		rows[i] = services.MetricValueImport{
+			MetricID:              uuid.MustParse(req.MetricID),
+			ParticipantExternalID: req.ParticipantExternalID,
+			Value:                 record["value"],
+			Source:                req.Source,
+		}
+		if req.ParticipantID != "" {
+			participantID := uuid.MustParse(req.ParticipantID)
+			rows[i].ParticipantID = &participantID
+		}
+		if req.Timestamp != nil {
+			rows[i].Timestamp = *req.Timestamp
+		}
+	}
+	if len(rowErrors) > 0 {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", rowErrors)
+		return
+	}
+
+	imported, err := h.metricValueService.ImportMetricValues(r.Context(), rows)
+	if err != nil {
+		var rowErr *services.ImportRowError
+		if errors.As(err, &rowErr) {
+			status := http.StatusNotFound
+			if rowErr.Err.Error() == "value does not match the metric's data type" {
+				status = http.StatusBadRequest
+			}
+			middleware.RespondWithError(w, status, rowErr.Error(), err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to import metric values", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusCreated, ImportResponse{Imported: imported})
+}
+
+// ImportParticipants creates participants in bulk from a CSV file
+// @Summary Import participants
+// @Description Create many participants at once from a CSV body whose header names the columns: name, type, and optionally external_id, display_name, avatar_url and country. Participants are created active, in batches in one transaction, so either every row is imported or none is.
+// @Tags participants
+// @Accept text/csv
+// @Produce json
+// @Security BearerAuth
+// @Param file body string true "CSV with a header row, at most 50000 rows"
+// @Success 201 {object} ImportResponse "Number of participants imported"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid CSV or rows"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 409 {object} middleware.ErrorResponse "A row's external_id is already in use"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/import [post]
+func (h *ImportHandler) ImportParticipants(w http.ResponseWriter, r *http.Request) {
+	records, ok := readImportCSV(w, r, participantImportColumns)
+	if !ok {
+		return
+	}
+
+	rows := make([]services.ParticipantImport, len(records))
+	var rowErrors validation.Errors
+	for i, record := range records {
+		req := CreateParticipantRequest{
+			ExternalID:  record["external_id"],
+			Name:        record["name"],
+			Type:        record["type"],
+			DisplayName: record["display_name"],
+			AvatarURL:   record["avatar_url"],
+			Country:     record["country"],
+		}
+		if err := validation.Validate.Struct(req); err != nil {
+			rowErrors = appendRowError(rowErrors, i+1, validation.FormatValidationErrors(err.(validator.ValidationErrors)).(validation.Errors)...)
+			continue
+		}
+
+		rows[i] = services.ParticipantImport{
+			ExternalID:  req.ExternalID,
+			Name:        req.Name,
+			Type:        req.Type,
+			DisplayName: req.DisplayName,
+			AvatarURL:   req.AvatarURL,
+			Country:     req.Country,
+		}
+	}
+	if len(rowErrors) > 0 {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", rowErrors)
+		return
+	}
+
+	imported, err := h.participantService.ImportParticipants(r.Context(), rows)
+	if err != nil {
+		var rowErr *services.ImportRowError
+		if errors.As(err, &rowErr) {
+			middleware.RespondWithError(w, http.StatusConflict, rowErr.Error(), err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to import participants", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusCreated, ImportResponse{Imported: imported})
+}
+
+// readImportCSV reads a CSV import body into one map per row after the header, keyed by column name. It responds
+// with 400 and reports false when the body is not valid CSV, names a column that is not allowed, or has no rows or
+// too many, and with 413 when it is larger than maxImportBodyBytes.
+func readImportCSV(w http.ResponseWriter, r *http.Request, columns []string) ([]map[string]string, bool) {
+	reader := csv.NewReader(http.MaxBytesReader(w, r.Body, maxImportBodyBytes))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid CSV, expected a header row", err)
+		return nil, false
+	}
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(columns, header[i]) {
+			middleware.RespondWithError(w, http.StatusBadRequest,
+				fmt.Sprintf("Unknown column %q, allowed columns are: %s", header[i], strings.Join(columns, ", ")), nil)
+			return nil, false
+		}
+		if slices.Contains(header[:i], header[i]) {
+			middleware.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Column %q appears twice", header[i]), nil)
+			return nil, false
+		}
+	}
+
+	var records []map[string]string
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				respondWithBodyError(w, err)
+				return nil, false
+			}
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid CSV", err)
+			return nil, false
+		}
+		if len(records) == maxImportRows {
+			middleware.RespondWithError(w, http.StatusBadRequest,
+				fmt.Sprintf("Too many rows, at most %d can be imported at once", maxImportRows), nil)
+			return nil, false
+		}
+
+		record := make(map[string]string, len(header))
+		for i, column := range header {
+			record[column] = strings.TrimSpace(fields[i])
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		middleware.RespondWithError(w, http.StatusBadRequest, "The CSV has no rows to import", nil)
+		return nil, false
+	}
+	return records, true
+}
+
+// appendRowError adds a row's field errors, prefixed with the row number, until maxImportErrors have been collected
+func appendRowError(rowErrors validation.Errors, row int, fieldErrors ...validation.FieldError) validation.Errors {
+	for _, fieldError := range fieldErrors {
+		if len(rowErrors) == maxImportErrors {
+			break
+		}
+		fieldError.Message = fmt.Sprintf("row %d: %s", row, fieldError.Message)
+		rowErrors = append(rowErrors, fieldError)
+	}
+	return rowErrors
+}
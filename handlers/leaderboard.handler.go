@@ -1,12 +1,16 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"leaderboard-service/enums"
 	"leaderboard-service/middleware"
+	"leaderboard-service/models"
 	"leaderboard-service/repositories"
 	"leaderboard-service/services"
 	"leaderboard-service/validation"
@@ -23,12 +27,31 @@ type CreateLeaderboardRequest struct {
 	Category        string  `json:"category" validate:"required" example:"tournament"`
 	Type            string  `json:"type" validate:"required,oneof=individual team" example:"individual" enums:"individual,team"`
 	TimeFrame       string  `json:"time_frame" validate:"required,oneof=daily weekly monthly yearly all-time custom,custom_timeframe" example:"weekly" enums:"daily,weekly,monthly,yearly,all-time,custom"`
-	StartDate       *string `json:"start_date,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z" example:"2023-01-01T00:00:00Z"`
-	EndDate         *string `json:"end_date,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z" example:"2023-01-07T23:59:59Z"`
+	StartDate       *string `json:"start_date,omitempty" validate:"omitempty,rfc3339" example:"2023-01-01T00:00:00Z"`
+	EndDate         *string `json:"end_date,omitempty" validate:"omitempty,rfc3339" example:"2023-01-07T23:59:59Z"`
 	SortOrder       string  `json:"sort_order" validate:"required,oneof=ascending descending" example:"descending" enums:"ascending,descending"`
 	VisibilityScope string  `json:"visibility_scope" validate:"required,oneof=public private" example:"public" enums:"public,private"`
-	IsActive        bool    `json:"is_active" example:"true"`
+	IsActive        *bool   `json:"is_active,omitempty" example:"true"` // Defaults to true
 	MaxEntries      int     `json:"max_entries" validate:"omitempty,min=1" example:"100"`
+
+	// What happens to new entries once max_entries is reached
+	MaxEntriesPolicy string `json:"max_entries_policy,omitempty" validate:"omitempty,oneof=reject evict_last" example:"reject" enums:"reject,evict_last"` // Defaults to reject
+
+	// Team boards only: how member scores roll up into the team's score
+	TeamScoreAggregation string `json:"team_score_aggregation,omitempty" validate:"omitempty,oneof=sum average top_k" example:"sum" enums:"sum,average,top_k"` // Defaults to sum
+	TeamScoreTopK        int    `json:"team_score_top_k,omitempty" validate:"omitempty,min=1" example:"3"`                                                     // Required for top_k
+
+	GroupID *string `json:"group_id,omitempty" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-44665544000d"` // Only rank participants below this group
+
+	// Metrics to attach, created together with the leaderboard
+	Metrics []CreateLeaderboardMetricsItem `json:"metrics,omitempty" validate:"omitempty,dive"`
+}
+
+// CreateLeaderboardMetricsItem is a metric attached to a leaderboard as it is created
+type CreateLeaderboardMetricsItem struct {
+	MetricID        string  `json:"metric_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440003"`
+	Weight          float64 `json:"weight" validate:"required,min=0" example:"1.0"`
+	DisplayPriority int     `json:"display_priority" validate:"omitempty,min=0" example:"0"`
 }
 
 // UpdateLeaderboardRequest represents the request payload for updating a leaderboard
@@ -38,12 +61,22 @@ type UpdateLeaderboardRequest struct {
 	Category        *string `json:"category,omitempty" validate:"omitempty" example:"competition"`
 	Type            *string `json:"type,omitempty" validate:"omitempty,oneof=individual team" example:"team" enums:"individual,team"`
 	TimeFrame       *string `json:"time_frame,omitempty" validate:"omitempty,oneof=daily weekly monthly yearly all-time custom,custom_timeframe" example:"monthly" enums:"daily,weekly,monthly,yearly,all-time,custom"`
-	StartDate       *string `json:"start_date,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z" example:"2023-02-01T00:00:00Z"`
-	EndDate         *string `json:"end_date,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z" example:"2023-02-28T23:59:59Z"`
+	StartDate       *string `json:"start_date,omitempty" validate:"omitempty,rfc3339" example:"2023-02-01T00:00:00Z"`
+	EndDate         *string `json:"end_date,omitempty" validate:"omitempty,rfc3339" example:"2023-02-28T23:59:59Z"`
 	SortOrder       *string `json:"sort_order,omitempty" validate:"omitempty,oneof=ascending descending" example:"ascending" enums:"ascending,descending"`
 	VisibilityScope *string `json:"visibility_scope,omitempty" validate:"omitempty,oneof=public private" example:"private" enums:"public,private"`
 	IsActive        *bool   `json:"is_active,omitempty" example:"false"`
 	MaxEntries      *int    `json:"max_entries,omitempty" validate:"omitempty,min=1" example:"50"`
+
+	MaxEntriesPolicy *string `json:"max_entries_policy,omitempty" validate:"omitempty,oneof=reject evict_last" example:"evict_last" enums:"reject,evict_last"`
+
+	TeamScoreAggregation *string `json:"team_score_aggregation,omitempty" validate:"omitempty,oneof=sum average top_k" example:"top_k" enums:"sum,average,top_k"`
+	TeamScoreTopK        *int    `json:"team_score_top_k,omitempty" validate:"omitempty,min=1" example:"5"`
+
+	GroupID *string `json:"group_id,omitempty" validate:"omitempty,len=0|uuid" example:"550e8400-e29b-41d4-a716-44665544000d"` // Send an empty string to stop targeting a group
+
+	// Version the update is based on; it is rejected with 409 if the leaderboard was updated since
+	Version *int `json:"version,omitempty" validate:"omitempty,min=1" example:"3"`
 }
 
 // LeaderboardResponse is used for Swagger documentation
@@ -60,41 +93,71 @@ type LeaderboardResponse struct {
 	VisibilityScope string    `json:"visibility_scope" example:"public"`
 	IsActive        bool      `json:"is_active" example:"true"`
 	MaxEntries      int       `json:"max_entries" example:"100"`
-	CreatedAt       time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt       time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	OwnerID         uuid.UUID `json:"owner_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440008"`
+
+	MaxEntriesPolicy string `json:"max_entries_policy" example:"reject"`
+
+	TeamScoreAggregation string `json:"team_score_aggregation" example:"sum"`
+	TeamScoreTopK        int    `json:"team_score_top_k" example:"0"`
+
+	GroupID *uuid.UUID `json:"group_id,omitempty" example:"550e8400-e29b-41d4-a716-44665544000d"`
+
+	// Incremented by every update; send it back in an update to only apply it to this version
+	Version int `json:"version" example:"1"`
+
+	// Only loaded with ?include=metrics and ?include=entries
+	Metrics []LeaderboardMetricResponse `json:"metrics,omitempty"`
+	Entries []LeaderboardEntryResponse  `json:"entries,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
 }
 
 type LeaderboardHandler struct {
-	service services.LeaderboardService
+	service          services.LeaderboardService
+	accessService    services.LeaderboardAccessService
+	teamScoreService services.TeamScoreService
 }
 
 func NewLeaderboardHandler() *LeaderboardHandler {
 	repo := repositories.NewLeaderboardRepository()
-	service := services.NewLeaderboardService(repo)
+	service := services.NewLeaderboardService(repo, repositories.NewParticipantRepository(), repositories.NewUnitOfWork())
 	return &LeaderboardHandler{
-		service: service,
+		service:          service,
+		accessService:    newLeaderboardAccessService(),
+		teamScoreService: newTeamScoreService(),
 	}
 }
 
+func newTeamScoreService() services.TeamScoreService {
+	return services.NewTeamScoreService(
+		repositories.NewLeaderboardRepository(),
+		repositories.NewMetricRepository(),
+		repositories.NewMetricValueRepository(),
+		repositories.NewTeamMemberRepository(),
+		repositories.NewLeaderboardEntryRepository(),
+		repositories.NewParticipantRepository(),
+	)
+}
+
 // CreateLeaderboard creates a new leaderboard
 // @Summary Create a new leaderboard
-// @Description Create a new leaderboard with the provided details
+// @Description Create a new leaderboard with the provided details. The authenticated user becomes its owner. Metrics in the request are attached in the same transaction, so the leaderboard is only created if all of them exist.
 // @Tags leaderboards
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param leaderboard body CreateLeaderboardRequest true "Leaderboard data"
 // @Success 201 {object} LeaderboardResponse "Created leaderboard"
-// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request, or a date range that is inverted, empty or already over"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Group or metric not found"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /leaderboards [post]
 func (h *LeaderboardHandler) CreateLeaderboard(w http.ResponseWriter, r *http.Request) {
 	var req CreateLeaderboardRequest
 
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -105,7 +168,28 @@ func (h *LeaderboardHandler) CreateLeaderboard(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	var groupID *uuid.UUID
+	if req.GroupID != nil {
+		parsedID, err := uuid.Parse(*req.GroupID)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid group ID format", err)
+			return
+		}
+		groupID = &parsedID
+	}
+
+	metrics := make([]models.LeaderboardMetric, len(req.Metrics))
+	for i, item := range req.Metrics {
+		metricID, err := uuid.Parse(item.MetricID)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid metric ID format", err)
+			return
+		}
+		metrics[i] = models.LeaderboardMetric{MetricID: metricID, Weight: item.Weight, DisplayPriority: item.DisplayPriority}
+	}
+
 	leaderboard, err := h.service.CreateLeaderboard(
+		r.Context(),
 		req.Name,
 		req.Description,
 		req.Category,
@@ -116,10 +200,29 @@ func (h *LeaderboardHandler) CreateLeaderboard(w http.ResponseWriter, r *http.Re
 		enums.SortOrder(req.SortOrder),
 		enums.VisibilityScope(req.VisibilityScope),
 		req.MaxEntries,
+		enums.MaxEntriesPolicy(req.MaxEntriesPolicy),
 		req.IsActive,
+		requestOwnerID(r),
+		enums.TeamScoreAggregation(req.TeamScoreAggregation),
+		req.TeamScoreTopK,
+		groupID,
+		metrics,
 	)
 
 	if err != nil {
+		switch err.Error() {
+		case "team_score_top_k is required for top_k aggregation", "group_id must be a group participant",
+			"end_date is before start_date", "start_date and end_date are the same", "end_date is in the past",
+			"metric is listed more than once":
+			middleware.RespondWithError(w, http.StatusBadRequest, err.Error(), err)
+			return
+		case "group not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Group not found", err)
+			return
+		case "metric not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Metric not found", err)
+			return
+		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to create leaderboard", err)
 		return
 	}
@@ -129,16 +232,20 @@ func (h *LeaderboardHandler) CreateLeaderboard(w http.ResponseWriter, r *http.Re
 
 // GetLeaderboard retrieves a leaderboard by ID
 // @Summary Get a leaderboard by ID
-// @Description Retrieve a leaderboard by its unique ID
+// @Description Retrieve a leaderboard by its unique ID. Use include to load its metrics (by display priority) and entries (by rank, with their participants) in the same request.
 // @Tags leaderboards
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Leaderboard ID"
+// @Param include query string false "Comma-separated related resources to load: metrics, entries"
+// @Param If-None-Match header string false "ETag from an earlier response; the leaderboard is only sent again if it changed"
 // @Success 200 {object} LeaderboardResponse "Leaderboard details"
-// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Success 304 "Not modified since the ETag in If-None-Match"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID or include"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /leaderboards/{id} [get]
 func (h *LeaderboardHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
@@ -148,27 +255,139 @@ func (h *LeaderboardHandler) GetLeaderboard(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	leaderboard, err := h.service.GetLeaderboard(leaderboardId)
+	include, ok := parseInclude(w, r, repositories.LeaderboardIncludes)
+	if !ok {
+		return
+	}
+
+	leaderboard, err := h.service.GetLeaderboard(r.Context(), leaderboardId, include)
 	if err != nil {
-		middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+		if err.Error() == "leaderboard not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch leaderboard", err)
 		return
 	}
 
 	middleware.RespondWithJSON(w, http.StatusOK, leaderboard)
 }
 
+// GetPublicLeaderboard retrieves a public leaderboard without authentication
+// @Summary Get a public leaderboard by ID
+// @Description Retrieve a public leaderboard without a token, like GET /leaderboards/{id}. Responses are the same for every caller, so they carry Cache-Control: public and can be kept by shared caches. Private leaderboards respond with 404.
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Param id path string true "Leaderboard ID"
+// @Param include query string false "Comma-separated related resources to load: metrics, entries"
+// @Param If-None-Match header string false "ETag from an earlier response; the leaderboard is only sent again if it changed"
+// @Success 200 {object} LeaderboardResponse "Leaderboard details"
+// @Success 304 "Not modified since the ETag in If-None-Match"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID or include"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Router /public/leaderboards/{id} [get]
+func (h *LeaderboardHandler) GetPublicLeaderboard(w http.ResponseWriter, r *http.Request) {
+	h.GetLeaderboard(w, r)
+}
+
 // ListLeaderboards returns all leaderboards
 // @Summary List all leaderboards
-// @Description Get a list of all leaderboards
+// @Description Get a list of all leaderboards, optionally only those matching every given filter, oldest first unless a sort is given. Private leaderboards are only included for users with access. Pass ids to fetch several leaderboards by ID in one request; IDs that don't exist or can't be read are left out.
 // @Tags leaderboards
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param category query string false "Filter by category"
+// @Param type query string false "Filter by leaderboard type" Enums(individual, team)
+// @Param time_frame query string false "Filter by time frame" Enums(daily, weekly, monthly, yearly, all-time)
+// @Param is_active query bool false "Filter by active state"
+// @Param visibility_scope query string false "Filter by visibility scope" Enums(public, private)
+// @Param sort query string false "Field to sort by" Enums(name, category, start_date, end_date, created_at, updated_at)
+// @Param order query string false "Sort direction, defaults to asc" Enums(asc, desc)
+// @Param include query string false "Comma-separated related resources to load: metrics, entries"
+// @Param include_deleted query bool false "Also list soft-deleted leaderboards (admin only)"
+// @Param ids query []string false "Only the leaderboards with these IDs, comma-separated or repeated (max 100)" collectionFormat(multi)
 // @Success 200 {array} LeaderboardResponse "List of leaderboards"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "include_deleted without the admin role"
 // @Router /leaderboards [get]
 func (h *LeaderboardHandler) ListLeaderboards(w http.ResponseWriter, r *http.Request) {
-	leaderboards, err := h.service.ListLeaderboards()
+	query := r.URL.Query()
+
+	var category *string
+	if categoryParam := query.Get("category"); categoryParam != "" {
+		category = &categoryParam
+	}
+
+	var leaderboardType *enums.LeaderboardType
+	if typeParam := query.Get("type"); typeParam != "" {
+		parsedType := enums.LeaderboardType(typeParam)
+		if !parsedType.Valid() {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid type: "+typeParam, nil)
+			return
+		}
+		leaderboardType = &parsedType
+	}
+
+	var timeFrame *enums.TimeFrame
+	if timeFrameParam := query.Get("time_frame"); timeFrameParam != "" {
+		parsedTimeFrame := enums.TimeFrame(timeFrameParam)
+		if !parsedTimeFrame.Valid() {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid time_frame: "+timeFrameParam, nil)
+			return
+		}
+		timeFrame = &parsedTimeFrame
+	}
+
+	var isActive *bool
+	if isActiveParam := query.Get("is_active"); isActiveParam != "" {
+		parsedIsActive, err := strconv.ParseBool(isActiveParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid is_active, must be true or false", err)
+			return
+		}
+		isActive = &parsedIsActive
+	}
+
+	var visibilityScope *enums.VisibilityScope
+	if visibilityScopeParam := query.Get("visibility_scope"); visibilityScopeParam != "" {
+		parsedScope := enums.VisibilityScope(visibilityScopeParam)
+		if !parsedScope.Valid() {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid visibility_scope: "+visibilityScopeParam, nil)
+			return
+		}
+		visibilityScope = &parsedScope
+	}
+
+	sort, ok := parseSort(w, r, repositories.LeaderboardSortColumns)
+	if !ok {
+		return
+	}
+
+	include, ok := parseInclude(w, r, repositories.LeaderboardIncludes)
+	if !ok {
+		return
+	}
+
+	includeDeleted, ok := parseIncludeDeleted(w, r)
+	if !ok {
+		return
+	}
+
+	ids, ok := parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	leaderboards, err := h.service.ListLeaderboards(r.Context(), ids, category, leaderboardType, timeFrame, isActive, visibilityScope, sort, include, includeDeleted)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch leaderboards", err)
+		return
+	}
+
+	leaderboards, err = h.accessService.FilterReadableLeaderboards(r.Context(), leaderboards, middleware.GetViewerFromContext(r))
 	if err != nil {
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch leaderboards", err)
 		return
@@ -179,7 +398,7 @@ func (h *LeaderboardHandler) ListLeaderboards(w http.ResponseWriter, r *http.Req
 
 // UpdateLeaderboard updates an existing leaderboard
 // @Summary Update a leaderboard
-// @Description Update an existing leaderboard with the provided details
+// @Description Update an existing leaderboard with the provided details. Send the version the changes are based on to have them rejected if the leaderboard was updated since.
 // @Tags leaderboards
 // @Accept json
 // @Produce json
@@ -187,9 +406,10 @@ func (h *LeaderboardHandler) ListLeaderboards(w http.ResponseWriter, r *http.Req
 // @Param id path string true "Leaderboard ID"
 // @Param leaderboard body UpdateLeaderboardRequest true "Updated leaderboard data"
 // @Success 200 {object} LeaderboardResponse "Updated leaderboard"
-// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request, or an inverted or empty date range"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 409 {object} middleware.ErrorResponse "Leaderboard was updated by another request"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /leaderboards/{id} [put]
 func (h *LeaderboardHandler) UpdateLeaderboard(w http.ResponseWriter, r *http.Request) {
@@ -201,9 +421,7 @@ func (h *LeaderboardHandler) UpdateLeaderboard(w http.ResponseWriter, r *http.Re
 	}
 
 	var req UpdateLeaderboardRequest
-	err = json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -239,7 +457,36 @@ func (h *LeaderboardHandler) UpdateLeaderboard(w http.ResponseWriter, r *http.Re
 		visibilityScope = &vs
 	}
 
+	var maxEntriesPolicy *enums.MaxEntriesPolicy
+	if req.MaxEntriesPolicy != nil {
+		mp := enums.MaxEntriesPolicy(*req.MaxEntriesPolicy)
+		maxEntriesPolicy = &mp
+	}
+
+	var teamScoreAggregation *enums.TeamScoreAggregation
+	if req.TeamScoreAggregation != nil {
+		ta := enums.TeamScoreAggregation(*req.TeamScoreAggregation)
+		teamScoreAggregation = &ta
+	}
+
+	// An empty group_id stops targeting a group
+	var groupID *uuid.UUID
+	clearGroup := false
+	if req.GroupID != nil {
+		if *req.GroupID == "" {
+			clearGroup = true
+		} else {
+			parsedID, err := uuid.Parse(*req.GroupID)
+			if err != nil {
+				middleware.RespondWithError(w, http.StatusBadRequest, "Invalid group ID format", err)
+				return
+			}
+			groupID = &parsedID
+		}
+	}
+
 	updatedLeaderboard, err := h.service.UpdateLeaderboard(
+		r.Context(),
 		leaderboardID,
 		req.Name,
 		req.Description,
@@ -251,7 +498,13 @@ func (h *LeaderboardHandler) UpdateLeaderboard(w http.ResponseWriter, r *http.Re
 		sortOrder,
 		visibilityScope,
 		req.MaxEntries,
+		maxEntriesPolicy,
 		req.IsActive,
+		teamScoreAggregation,
+		req.TeamScoreTopK,
+		groupID,
+		clearGroup,
+		req.Version,
 	)
 
 	if err != nil {
@@ -259,6 +512,19 @@ func (h *LeaderboardHandler) UpdateLeaderboard(w http.ResponseWriter, r *http.Re
 			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
 			return
 		}
+		if err.Error() == "leaderboard version conflict" {
+			middleware.RespondWithError(w, http.StatusConflict, "Leaderboard was updated by another request", err)
+			return
+		}
+		switch err.Error() {
+		case "team_score_top_k is required for top_k aggregation", "group_id must be a group participant",
+			"end_date is before start_date", "start_date and end_date are the same":
+			middleware.RespondWithError(w, http.StatusBadRequest, err.Error(), err)
+			return
+		case "group not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Group not found", err)
+			return
+		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to update leaderboard", err)
 		return
 	}
@@ -268,16 +534,18 @@ func (h *LeaderboardHandler) UpdateLeaderboard(w http.ResponseWriter, r *http.Re
 
 // DeleteLeaderboard deletes a leaderboard by ID
 // @Summary Delete a leaderboard
-// @Description Delete a leaderboard by its ID
+// @Description Soft-delete a leaderboard by its ID, together with its entries and leaderboard metrics. A leaderboard that has entries is only deleted with force=true; otherwise the response is 409 LEADERBOARD_NOT_EMPTY with the number of entries and leaderboard metrics that would be deleted in dependents.
 // @Tags leaderboards
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Leaderboard ID"
+// @Param force query bool false "Delete the leaderboard even though it has entries"
 // @Success 204 "No content"
-// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID or force"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 409 {object} middleware.ErrorResponse "Leaderboard has entries and force is not set"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /leaderboards/{id} [delete]
 func (h *LeaderboardHandler) DeleteLeaderboard(w http.ResponseWriter, r *http.Request) {
@@ -288,15 +556,232 @@ func (h *LeaderboardHandler) DeleteLeaderboard(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	err = h.service.DeleteLeaderboard(leaderboardID)
+	force := false
+	if forceParam := r.URL.Query().Get("force"); forceParam != "" {
+		force, err = strconv.ParseBool(forceParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid force, must be true or false", err)
+			return
+		}
+	}
+
+	err = h.service.DeleteLeaderboard(r.Context(), leaderboardID, force)
 	if err != nil {
 		if err.Error() == "leaderboard not found" {
 			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
 			return
 		}
+		var notEmptyErr *services.LeaderboardNotEmptyError
+		if errors.As(err, &notEmptyErr) {
+			middleware.RespondWithDependents(w, "Leaderboard has entries, delete it with force=true", err, map[string]int64{
+				"entries":             notEmptyErr.Entries,
+				"leaderboard_metrics": notEmptyErr.Metrics,
+			})
+			return
+		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to delete leaderboard", err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// RestoreLeaderboard undoes the soft delete of a leaderboard
+// @Summary Restore a deleted leaderboard
+// @Description Bring back a soft-deleted leaderboard and the entries and leaderboard metrics deleted with it. Deleted leaderboards can be found by listing with include_deleted=true. Admin only.
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Success 200 {object} LeaderboardResponse "Restored leaderboard"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 409 {object} middleware.ErrorResponse "Not deleted or conflicts with live data"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/restore [post]
+func (h *LeaderboardHandler) RestoreLeaderboard(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	restored, err := h.service.RestoreLeaderboard(r.Context(), id)
+	if err != nil {
+		switch err.Error() {
+		case "leaderboard not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+		case "leaderboard is not deleted":
+			middleware.RespondWithError(w, http.StatusConflict, "Leaderboard is not deleted", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to restore leaderboard", err)
+		}
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, restored)
+}
+
+// requestOwnerID returns the authenticated user's ID, or nil when the caller is not a user (e.g. an API key)
+func requestOwnerID(r *http.Request) *uuid.UUID {
+	userID, ok := middleware.GetRequestUserID(r)
+	if !ok {
+		return nil
+	}
+	return &userID
+}
+
+// parseSort reads the sort and order query parameters against the list's sortable columns, responding with a bad
+// request when either is invalid. The sort is nil when none was asked for, leaving the list in its default order.
+func parseSort(w http.ResponseWriter, r *http.Request, columns repositories.SortColumns) (*repositories.Sort, bool) {
+	field := r.URL.Query().Get("sort")
+	order := r.URL.Query().Get("order")
+	if field == "" {
+		if order != "" {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid query parameters", errors.New("order requires sort"))
+			return nil, false
+		}
+		return nil, true
+	}
+
+	if !columns.Valid(field) {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid sort field",
+			fmt.Errorf("sort must be one of: %s", strings.Join(columns.Fields(), ", ")))
+		return nil, false
+	}
+
+	switch order {
+	case "", "asc":
+		return &repositories.Sort{Field: field}, true
+	case "desc":
+		return &repositories.Sort{Field: field, Desc: true}, true
+	default:
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid sort order", errors.New("order must be asc or desc"))
+		return nil, false
+	}
+}
+
+// parseInclude reads the comma-separated include query parameter against the related resources the endpoint can
+// load, responding with a bad request when one is unknown
+func parseInclude(w http.ResponseWriter, r *http.Request, includes repositories.Includes) ([]string, bool) {
+	param := r.URL.Query().Get("include")
+	if param == "" {
+		return nil, true
+	}
+
+	var include []string
+	for _, name := range strings.Split(param, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !includes.Valid(name) {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid include: "+name,
+				fmt.Errorf("include must be one of: %s", strings.Join(includes.Names(), ", ")))
+			return nil, false
+		}
+		include = append(include, name)
+	}
+	return include, true
+}
+
+// parseIncludeDeleted reads the include_deleted query parameter, responding with a bad request when it is not a
+// boolean and forbidden when soft-deleted resources are asked for by a caller that is not an admin
+func parseIncludeDeleted(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	param := r.URL.Query().Get("include_deleted")
+	if param == "" {
+		return false, true
+	}
+
+	includeDeleted, err := strconv.ParseBool(param)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid include_deleted, must be true or false", err)
+		return false, false
+	}
+
+	if includeDeleted {
+		claims, err := middleware.GetUserFromContext(r.Context())
+		if err != nil || middleware.Role(claims.Role) != middleware.RoleAdmin {
+			middleware.RespondWithError(w, http.StatusForbidden, "Insufficient permissions", errors.New("include_deleted requires the admin role"))
+			return false, false
+		}
+	}
+	return includeDeleted, true
+}
+
+// maxListIDs caps how many records a list can be asked for by ID at once
+const maxListIDs = 100
+
+// parseIDs reads the ids query parameter, comma-separated or repeated, for fetching several records by ID in one
+// request. It responds with a bad request when an ID is not a UUID or there are more than maxListIDs of them.
+func parseIDs(w http.ResponseWriter, r *http.Request) ([]uuid.UUID, bool) {
+	var ids []uuid.UUID
+	seen := map[uuid.UUID]bool{}
+	for _, param := range r.URL.Query()["ids"] {
+		for _, value := range strings.Split(param, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			id, err := uuid.Parse(value)
+			if err != nil {
+				middleware.RespondWithError(w, http.StatusBadRequest, "Invalid ids, "+value+" is not a valid ID", err)
+				return nil, false
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	if len(ids) > maxListIDs {
+		middleware.RespondWithError(w, http.StatusBadRequest,
+			"Too many ids, at most "+strconv.Itoa(maxListIDs)+" can be fetched at once", nil)
+		return nil, false
+	}
+	return ids, true
+}
+
+// RecomputeTeamScores rebuilds a team leaderboard from its members' metric values
+// @Summary Recompute team scores
+// @Description Recalculate every team entry of a team leaderboard from the members' metric values and re-rank the board. Team boards are also kept up to date automatically as metric values are recorded; use this after changing the board's metrics, dates, team scoring or team rosters.
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Success 200 {array} LeaderboardEntryResponse "Re-ranked leaderboard entries"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID or not a team leaderboard"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 409 {object} middleware.ErrorResponse "Leaderboard has ended"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/recompute [post]
+func (h *LeaderboardHandler) RecomputeTeamScores(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	leaderboardID, err := uuid.Parse(idParam)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	entries, err := h.teamScoreService.RecomputeLeaderboard(r.Context(), leaderboardID)
+	if err != nil {
+		switch err.Error() {
+		case "leaderboard not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+		case "leaderboard is not a team leaderboard":
+			middleware.RespondWithError(w, http.StatusBadRequest, "Only team leaderboards can be recomputed", err)
+		case "leaderboard has ended":
+			middleware.RespondWithError(w, http.StatusConflict, "Leaderboard has ended", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to recompute team scores", err)
+		}
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, entries)
+}
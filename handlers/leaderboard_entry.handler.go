@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -17,11 +17,13 @@ import (
 
 // CreateLeaderboardEntryRequest represents the request payload for creating a leaderboard entry
 type CreateLeaderboardEntryRequest struct {
-	LeaderboardID string    `json:"leaderboard_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
-	ParticipantID string    `json:"participant_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440001"`
-	Score         float64   `json:"score" validate:"required" example:"100.5"`
-	Rank          int       `json:"rank" validate:"required,min=1" example:"1"`
-	LastUpdated   time.Time `json:"last_updated,omitempty" example:"2023-01-01T00:00:00Z"`
+	LeaderboardID string `json:"leaderboard_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ParticipantID string `json:"participant_id,omitempty" validate:"required_without=ParticipantExternalID,omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440001"`
+	// Alternative to participant_id: the external_id the participant was created with
+	ParticipantExternalID string    `json:"participant_external_id,omitempty" example:"external-123"`
+	Score                 float64   `json:"score" validate:"required" example:"100.5"`
+	Rank                  int       `json:"rank" validate:"required,min=1" example:"1"`
+	LastUpdated           time.Time `json:"last_updated,omitempty" example:"2023-01-01T00:00:00Z"`
 }
 
 // UpdateLeaderboardEntryRequest represents the request payload for updating a leaderboard entry
@@ -29,6 +31,21 @@ type UpdateLeaderboardEntryRequest struct {
 	Score       *float64   `json:"score,omitempty" validate:"omitempty" example:"200.75"`
 	Rank        *int       `json:"rank,omitempty" validate:"omitempty,min=1" example:"2"`
 	LastUpdated *time.Time `json:"last_updated,omitempty" example:"2023-01-02T00:00:00Z"`
+	// Version the update is based on; it is rejected with 409 if the entry was updated or re-ranked since
+	Version *int `json:"version,omitempty" validate:"omitempty,min=1" example:"3"`
+}
+
+// IncrementScoreRequest represents the request payload for incrementing a leaderboard entry's score
+type IncrementScoreRequest struct {
+	// Added to the score, negative to take away
+	Delta float64 `json:"delta" validate:"required" example:"5"`
+}
+
+// ScoreIncrementAccepted is returned for an increment that is buffered until the next flush
+type ScoreIncrementAccepted struct {
+	EntryID  uuid.UUID `json:"entry_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	Delta    float64   `json:"delta" example:"5"`
+	Buffered bool      `json:"buffered" example:"true"`
 }
 
 // LeaderboardEntryResponse is used for Swagger documentation
@@ -39,22 +56,33 @@ type LeaderboardEntryResponse struct {
 	Rank          int       `json:"rank" example:"1"`
 	Score         float64   `json:"score" example:"100.5"`
 	LastUpdated   time.Time `json:"last_updated" example:"2023-01-01T00:00:00Z"`
-	CreatedAt     time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt     time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	Version       int       `json:"version" example:"1"`
+	// Participant's shown name and avatar, kept on the entry
+	ParticipantName string `json:"participant_name" example:"Jane D."`
+	AvatarURL       string `json:"avatar_url" example:"https://cdn.example.com/avatars/jane.png"`
+	// Participant profile for display
+	Participant *ParticipantResponse `json:"participant,omitempty"`
+	CreatedAt   time.Time            `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt   time.Time            `json:"updated_at" example:"2023-01-01T00:00:00Z"`
 }
 
 type LeaderboardEntryHandler struct {
-	service services.LeaderboardEntryService
+	service            services.LeaderboardEntryService
+	accessService      services.LeaderboardAccessService
+	participantService services.ParticipantService
 }
 
 func NewLeaderboardEntryHandler() *LeaderboardEntryHandler {
 	leaderboardEntryRepo := repositories.NewLeaderboardEntryRepository()
 	leaderboardRepo := repositories.NewLeaderboardRepository()
 	participantRepo := repositories.NewParticipantRepository()
-	service := services.NewLeaderboardEntryService(leaderboardEntryRepo, leaderboardRepo, participantRepo)
+	service := services.NewLeaderboardEntryService(leaderboardEntryRepo, leaderboardRepo, participantRepo,
+		repositories.NewScoreIncrementRepository(), repositories.NewUnitOfWork())
 
 	return &LeaderboardEntryHandler{
-		service: service,
+		service:            service,
+		accessService:      newLeaderboardAccessService(),
+		participantService: services.NewParticipantService(participantRepo, repositories.NewParticipantNameChangeRepository(), leaderboardEntryRepo),
 	}
 }
 
@@ -71,15 +99,14 @@ func NewLeaderboardEntryHandler() *LeaderboardEntryHandler {
 // @Failure 400 {object} middleware.ErrorResponse "Invalid request"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Leaderboard or participant not found"
+// @Failure 409 {object} middleware.ErrorResponse "Participant already has an entry on the leaderboard, returned in existing, or the leaderboard is full or has ended"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /leaderboard-entries [post]
 // @Router /leaderboards/{leaderboard_id}/entries [post]
 func (h *LeaderboardEntryHandler) CreateLeaderboardEntry(w http.ResponseWriter, r *http.Request) {
 	var req CreateLeaderboardEntryRequest
 
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -105,13 +132,13 @@ func (h *LeaderboardEntryHandler) CreateLeaderboardEntry(w http.ResponseWriter,
 		return
 	}
 
-	participantID, err := uuid.Parse(req.ParticipantID)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID format", err)
+	participantID, ok := resolveParticipantID(w, r, h.participantService, req.ParticipantID, req.ParticipantExternalID)
+	if !ok {
 		return
 	}
 
 	entry, err := h.service.CreateLeaderboardEntry(
+		r.Context(),
 		leaderboardID,
 		participantID,
 		req.Score,
@@ -124,6 +151,24 @@ func (h *LeaderboardEntryHandler) CreateLeaderboardEntry(w http.ResponseWriter,
 			middleware.RespondWithError(w, http.StatusNotFound, err.Error(), err)
 			return
 		}
+		if err.Error() == "participant is not in the leaderboard's group" ||
+			err.Error() == "participant type does not match the leaderboard" {
+			middleware.RespondWithError(w, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		var existsErr *services.EntryExistsError
+		if errors.As(err, &existsErr) {
+			middleware.RespondWithConflict(w, "Participant already has an entry on the leaderboard", err, existsErr.Entry)
+			return
+		}
+		if err.Error() == "leaderboard has ended" {
+			middleware.RespondWithError(w, http.StatusConflict, "Leaderboard has ended", err)
+			return
+		}
+		if err.Error() == "leaderboard has reached max_entries" {
+			middleware.RespondWithError(w, http.StatusConflict, "Leaderboard has reached its max_entries", err)
+			return
+		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to create leaderboard entry", err)
 		return
 	}
@@ -143,6 +188,7 @@ func (h *LeaderboardEntryHandler) CreateLeaderboardEntry(w http.ResponseWriter,
 // @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /leaderboard-entries/{id} [get]
 func (h *LeaderboardEntryHandler) GetLeaderboardEntry(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
@@ -152,9 +198,13 @@ func (h *LeaderboardEntryHandler) GetLeaderboardEntry(w http.ResponseWriter, r *
 		return
 	}
 
-	entry, err := h.service.GetLeaderboardEntry(entryID)
+	entry, err := h.service.GetLeaderboardEntry(r.Context(), entryID)
 	if err != nil {
-		middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard entry not found", err)
+		if err.Error() == "leaderboard entry not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard entry not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch leaderboard entry", err)
 		return
 	}
 
@@ -170,9 +220,16 @@ func (h *LeaderboardEntryHandler) GetLeaderboardEntry(w http.ResponseWriter, r *
 // @Security BearerAuth
 // @Param leaderboard_id path string false "Filter by leaderboard ID"
 // @Param participant_id query string false "Filter by participant ID"
+// @Param sort query string false "Field to sort by, defaults to rank" Enums(rank, score, last_updated, created_at)
+// @Param order query string false "Sort direction, defaults to asc" Enums(asc, desc)
+// @Param include query string false "Related resources to load; entries always include their participant" Enums(participant)
+// @Param include_deleted query bool false "Also list soft-deleted entries (admin only)"
+// @Param If-None-Match header string false "ETag from an earlier response; on /leaderboards/{leaderboard_id}/entries the entries are only sent again if they changed"
 // @Success 200 {array} LeaderboardEntryResponse "List of leaderboard entries"
+// @Success 304 "Not modified since the ETag in If-None-Match"
 // @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "include_deleted without the admin role"
 // @Router /leaderboard-entries [get]
 // @Router /leaderboards/{leaderboard_id}/entries [get]
 func (h *LeaderboardEntryHandler) ListLeaderboardEntries(w http.ResponseWriter, r *http.Request) {
@@ -210,7 +267,29 @@ func (h *LeaderboardEntryHandler) ListLeaderboardEntries(w http.ResponseWriter,
 		participantID = &parsedID
 	}
 
-	entries, err := h.service.ListFilteredLeaderboardEntries(leaderboardID, participantID)
+	sort, ok := parseSort(w, r, repositories.EntrySortColumns)
+	if !ok {
+		return
+	}
+
+	// The participant is always loaded, so include only needs checking
+	if _, ok := parseInclude(w, r, repositories.EntryIncludes); !ok {
+		return
+	}
+
+	includeDeleted, ok := parseIncludeDeleted(w, r)
+	if !ok {
+		return
+	}
+
+	entries, err := h.service.ListFilteredLeaderboardEntries(r.Context(), leaderboardID, participantID, sort, includeDeleted)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch leaderboard entries", err)
+		return
+	}
+
+	// Drop entries from private leaderboards the caller cannot read
+	entries, err = h.accessService.FilterReadableEntries(r.Context(), entries, middleware.GetViewerFromContext(r))
 	if err != nil {
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch leaderboard entries", err)
 		return
@@ -219,9 +298,30 @@ func (h *LeaderboardEntryHandler) ListLeaderboardEntries(w http.ResponseWriter,
 	middleware.RespondWithJSON(w, http.StatusOK, entries)
 }
 
+// ListPublicLeaderboardEntries returns the entries of a public leaderboard without authentication
+// @Summary List the entries of a public leaderboard
+// @Description Get the entries of a public leaderboard without a token, like GET /leaderboards/{id}/entries. Responses are the same for every caller, so they carry Cache-Control: public and can be kept by shared caches. Private leaderboards respond with 404.
+// @Tags leaderboard-entries
+// @Accept json
+// @Produce json
+// @Param id path string true "Leaderboard ID"
+// @Param participant_id query string false "Filter by participant ID"
+// @Param sort query string false "Field to sort by, defaults to rank" Enums(rank, score, last_updated, created_at)
+// @Param order query string false "Sort direction, defaults to asc" Enums(asc, desc)
+// @Param include query string false "Related resources to load; entries always include their participant" Enums(participant)
+// @Param If-None-Match header string false "ETag from an earlier response; the entries are only sent again if they changed"
+// @Success 200 {array} LeaderboardEntryResponse "List of leaderboard entries"
+// @Success 304 "Not modified since the ETag in If-None-Match"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters"
+// @Failure 404 {object} middleware.ErrorResponse "Leaderboard not found"
+// @Router /public/leaderboards/{id}/entries [get]
+func (h *LeaderboardEntryHandler) ListPublicLeaderboardEntries(w http.ResponseWriter, r *http.Request) {
+	h.ListLeaderboardEntries(w, r)
+}
+
 // UpdateLeaderboardEntry updates an existing leaderboard entry
 // @Summary Update a leaderboard entry
-// @Description Update an existing leaderboard entry with the provided details
+// @Description Update an existing leaderboard entry with the provided details. Send the version the changes are based on to have them rejected if the entry was updated or re-ranked since.
 // @Tags leaderboard-entries
 // @Accept json
 // @Produce json
@@ -232,6 +332,7 @@ func (h *LeaderboardEntryHandler) ListLeaderboardEntries(w http.ResponseWriter,
 // @Failure 400 {object} middleware.ErrorResponse "Invalid request"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 409 {object} middleware.ErrorResponse "Leaderboard entry was updated by another request, or the leaderboard has ended"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /leaderboard-entries/{id} [put]
 func (h *LeaderboardEntryHandler) UpdateLeaderboardEntry(w http.ResponseWriter, r *http.Request) {
@@ -243,9 +344,7 @@ func (h *LeaderboardEntryHandler) UpdateLeaderboardEntry(w http.ResponseWriter,
 	}
 
 	var req UpdateLeaderboardEntryRequest
-	err = json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -257,10 +356,12 @@ func (h *LeaderboardEntryHandler) UpdateLeaderboardEntry(w http.ResponseWriter,
 	}
 
 	updatedEntry, err := h.service.UpdateLeaderboardEntry(
+		r.Context(),
 		entryID,
 		req.Score,
 		req.Rank,
 		req.LastUpdated,
+		req.Version,
 	)
 
 	if err != nil {
@@ -268,6 +369,14 @@ func (h *LeaderboardEntryHandler) UpdateLeaderboardEntry(w http.ResponseWriter,
 			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard entry not found", err)
 			return
 		}
+		if err.Error() == "leaderboard entry version conflict" {
+			middleware.RespondWithError(w, http.StatusConflict, "Leaderboard entry was updated by another request", err)
+			return
+		}
+		if err.Error() == "leaderboard has ended" {
+			middleware.RespondWithError(w, http.StatusConflict, "Leaderboard has ended", err)
+			return
+		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to update leaderboard entry", err)
 		return
 	}
@@ -275,6 +384,68 @@ func (h *LeaderboardEntryHandler) UpdateLeaderboardEntry(w http.ResponseWriter,
 	middleware.RespondWithJSON(w, http.StatusOK, updatedEntry)
 }
 
+// IncrementScore adds to a leaderboard entry's score
+// @Summary Increment a leaderboard entry's score
+// @Description Add a delta to the entry's score and re-rank its leaderboard. While the score buffer is enabled the
+// @Description increment is accepted and applied with the next flush instead.
+// @Tags leaderboard-entries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard Entry ID"
+// @Param increment body IncrementScoreRequest true "Score increment"
+// @Success 200 {object} LeaderboardEntryResponse "Incremented leaderboard entry"
+// @Success 202 {object} ScoreIncrementAccepted "Increment buffered"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request or team leaderboard"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 409 {object} middleware.ErrorResponse "Leaderboard has ended"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboard-entries/{id}/increment [post]
+func (h *LeaderboardEntryHandler) IncrementScore(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	entryID, err := uuid.Parse(idParam)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard entry ID", err)
+		return
+	}
+
+	var req IncrementScoreRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	entry, buffered, err := h.service.IncrementScore(r.Context(), entryID, req.Delta)
+	if err != nil {
+		switch err.Error() {
+		case "leaderboard entry not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard entry not found", err)
+		case "leaderboard not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+		case "team leaderboard scores can't be incremented":
+			middleware.RespondWithError(w, http.StatusBadRequest, err.Error(), err)
+		case "leaderboard has ended":
+			middleware.RespondWithError(w, http.StatusConflict, "Leaderboard has ended", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to increment leaderboard entry score", err)
+		}
+		return
+	}
+
+	if buffered {
+		middleware.RespondWithJSON(w, http.StatusAccepted, ScoreIncrementAccepted{EntryID: entry.ID, Delta: req.Delta, Buffered: true})
+		return
+	}
+	middleware.RespondWithJSON(w, http.StatusOK, entry)
+}
+
 // DeleteLeaderboardEntry deletes a leaderboard entry by ID
 // @Summary Delete a leaderboard entry
 // @Description Delete a leaderboard entry by its ID
@@ -297,7 +468,7 @@ func (h *LeaderboardEntryHandler) DeleteLeaderboardEntry(w http.ResponseWriter,
 		return
 	}
 
-	err = h.service.DeleteLeaderboardEntry(entryID)
+	err = h.service.DeleteLeaderboardEntry(r.Context(), entryID)
 	if err != nil {
 		if err.Error() == "leaderboard entry not found" {
 			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard entry not found", err)
@@ -309,3 +480,52 @@ func (h *LeaderboardEntryHandler) DeleteLeaderboardEntry(w http.ResponseWriter,
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// RestoreLeaderboardEntry undoes the soft delete of a leaderboard entry
+// @Summary Restore a deleted leaderboard entry
+// @Description Bring back a soft-deleted leaderboard entry. Deleted entries can be found by listing with include_deleted=true. Admin only.
+// @Tags leaderboard-entries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard entry ID"
+// @Success 200 {object} LeaderboardEntryResponse "Restored leaderboard entry"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 409 {object} middleware.ErrorResponse "Not deleted or conflicts with live data"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboard-entries/{id}/restore [post]
+func (h *LeaderboardEntryHandler) RestoreLeaderboardEntry(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard entry ID", err)
+		return
+	}
+
+	restored, err := h.service.RestoreLeaderboardEntry(r.Context(), id)
+	if err != nil {
+		var existsErr *services.EntryExistsError
+		if errors.As(err, &existsErr) {
+			middleware.RespondWithConflict(w, "Participant already has an entry on the leaderboard", err, existsErr.Entry)
+			return
+		}
+		switch err.Error() {
+		case "leaderboard entry not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard entry not found", err)
+		case "leaderboard entry is not deleted":
+			middleware.RespondWithError(w, http.StatusConflict, "Leaderboard entry is not deleted", err)
+		case "leaderboard of the entry is deleted":
+			middleware.RespondWithError(w, http.StatusConflict, "Leaderboard of the entry is deleted, restore it first", err)
+		case "participant of the entry is deleted":
+			middleware.RespondWithError(w, http.StatusConflict, "Participant of the entry is deleted, restore it first", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to restore leaderboard entry", err)
+		}
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, restored)
+}
@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// SetLeaderboardMemberRequest represents the request payload for granting a user a role on a leaderboard
+type SetLeaderboardMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=editor viewer" example:"editor" enums:"editor,viewer"`
+}
+
+// LeaderboardMemberResponse is used for Swagger documentation
+type LeaderboardMemberResponse struct {
+	ID            uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440009"`
+	LeaderboardID uuid.UUID `json:"leaderboard_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserID        uuid.UUID `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440008"`
+	Role          string    `json:"role" example:"editor"`
+	CreatedAt     time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt     time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// LeaderboardParticipantAccessResponse is used for Swagger documentation
+type LeaderboardParticipantAccessResponse struct {
+	ID            uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-44665544000a"`
+	LeaderboardID uuid.UUID `json:"leaderboard_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ParticipantID uuid.UUID `json:"participant_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	CreatedAt     time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt     time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+type LeaderboardMemberHandler struct {
+	service services.LeaderboardAccessService
+}
+
+func NewLeaderboardMemberHandler() *LeaderboardMemberHandler {
+	return &LeaderboardMemberHandler{
+		service: newLeaderboardAccessService(),
+	}
+}
+
+func newLeaderboardAccessService() services.LeaderboardAccessService {
+	leaderboardRepo := repositories.NewLeaderboardRepository()
+	memberRepo := repositories.NewLeaderboardMemberRepository()
+	userRepo := repositories.NewUserRepository()
+	participantRepo := repositories.NewParticipantRepository()
+	participantAccessRepo := repositories.NewLeaderboardParticipantAccessRepository()
+	return services.NewLeaderboardAccessService(leaderboardRepo, memberRepo, userRepo, participantRepo, participantAccessRepo)
+}
+
+// ListLeaderboardMembers returns the users that have a role on a leaderboard
+// @Summary List leaderboard members
+// @Description Get the users that have been granted a role on a leaderboard. The owner is not listed.
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Success 200 {array} LeaderboardMemberResponse "List of members"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Leaderboard not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/members [get]
+func (h *LeaderboardMemberHandler) ListLeaderboardMembers(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	members, err := h.service.ListMembers(r.Context(), leaderboardID)
+	if err != nil {
+		if err.Error() == "leaderboard not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch leaderboard members", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, members)
+}
+
+// SetLeaderboardMember grants or changes a user's role on a leaderboard
+// @Summary Add or update a leaderboard member
+// @Description Grant a user the editor or viewer role on a leaderboard. Editors can update the leaderboard and manage its entries and metrics.
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Param userId path string true "User ID"
+// @Param member body SetLeaderboardMemberRequest true "Member role"
+// @Success 200 {object} LeaderboardMemberResponse "Leaderboard member"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Leaderboard or user not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/members/{userId} [put]
+func (h *LeaderboardMemberHandler) SetLeaderboardMember(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req SetLeaderboardMemberRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	member, err := h.service.SetMember(r.Context(), leaderboardID, userID, enums.LeaderboardRole(req.Role))
+	if err != nil {
+		switch err.Error() {
+		case "leaderboard not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+		case "user not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "User not found", err)
+		case "owner role cannot be assigned":
+			middleware.RespondWithError(w, http.StatusBadRequest, "The owner's role cannot be changed", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to update leaderboard member", err)
+		}
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, member)
+}
+
+// RemoveLeaderboardMember revokes a user's role on a leaderboard
+// @Summary Remove a leaderboard member
+// @Description Revoke a user's role on a leaderboard
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Param userId path string true "User ID"
+// @Success 204 "No content"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/members/{userId} [delete]
+func (h *LeaderboardMemberHandler) RemoveLeaderboardMember(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	err = h.service.RemoveMember(r.Context(), leaderboardID, userID)
+	if err != nil {
+		switch err.Error() {
+		case "leaderboard not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+		case "member not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Member not found", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to remove leaderboard member", err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListLeaderboardParticipantAccess returns the participants allowed to read a private leaderboard
+// @Summary List participant access
+// @Description Get the participants whose linked users may read a private leaderboard
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Success 200 {array} LeaderboardParticipantAccessResponse "Access list"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Leaderboard not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/access/participants [get]
+func (h *LeaderboardMemberHandler) ListLeaderboardParticipantAccess(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	accesses, err := h.service.ListParticipantAccess(r.Context(), leaderboardID)
+	if err != nil {
+		if err.Error() == "leaderboard not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch leaderboard access list", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, accesses)
+}
+
+// GrantLeaderboardParticipantAccess adds a participant to a leaderboard's access list
+// @Summary Grant participant access
+// @Description Allow the user linked to a participant to read a private leaderboard
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Param participantId path string true "Participant ID"
+// @Success 200 {object} LeaderboardParticipantAccessResponse "Access list entry"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Leaderboard or participant not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/access/participants/{participantId} [put]
+func (h *LeaderboardMemberHandler) GrantLeaderboardParticipantAccess(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	participantID, err := uuid.Parse(chi.URLParam(r, "participantId"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	access, err := h.service.GrantParticipantAccess(r.Context(), leaderboardID, participantID)
+	if err != nil {
+		switch err.Error() {
+		case "leaderboard not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+		case "participant not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to grant leaderboard access", err)
+		}
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, access)
+}
+
+// RevokeLeaderboardParticipantAccess removes a participant from a leaderboard's access list
+// @Summary Revoke participant access
+// @Description Remove a participant from a private leaderboard's access list
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Param participantId path string true "Participant ID"
+// @Success 204 "No content"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/access/participants/{participantId} [delete]
+func (h *LeaderboardMemberHandler) RevokeLeaderboardParticipantAccess(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	participantID, err := uuid.Parse(chi.URLParam(r, "participantId"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	err = h.service.RevokeParticipantAccess(r.Context(), leaderboardID, participantID)
+	if err != nil {
+		switch err.Error() {
+		case "leaderboard not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+		case "participant access not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Participant access not found", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to revoke leaderboard access", err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,13 +1,13 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
-	"leaderboard-service/db"
 	"leaderboard-service/middleware"
-	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
 	"leaderboard-service/validation"
 
 	"github.com/go-chi/chi/v5"
@@ -15,6 +15,22 @@ import (
 	"github.com/google/uuid"
 )
 
+// LeaderboardMetricHandler handles HTTP requests for the metrics attached to leaderboards
+type LeaderboardMetricHandler struct {
+	service       services.LeaderboardMetricService
+	accessService services.LeaderboardAccessService
+}
+
+// NewLeaderboardMetricHandler creates a new leaderboard metric handler
+func NewLeaderboardMetricHandler() *LeaderboardMetricHandler {
+	service := services.NewLeaderboardMetricService(repositories.NewLeaderboardMetricRepository(), repositories.NewUnitOfWork())
+
+	return &LeaderboardMetricHandler{
+		service:       service,
+		accessService: newLeaderboardAccessService(),
+	}
+}
+
 // CreateLeaderboardMetricRequest represents the request payload for creating a leaderboard metric
 type CreateLeaderboardMetricRequest struct {
 	LeaderboardID   string  `json:"leaderboard_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
@@ -53,15 +69,14 @@ type LeaderboardMetricResponse struct {
 // @Failure 400 {object} middleware.ErrorResponse "Invalid request"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Leaderboard or metric not found"
+// @Failure 409 {object} middleware.ErrorResponse "Metric is already attached to the leaderboard, returned in existing"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /leaderboard-metrics [post]
 // @Router /leaderboards/{leaderboard_id}/metrics [post]
-func CreateLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
+func (h *LeaderboardMetricHandler) CreateLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 	var req CreateLeaderboardMetricRequest
 
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -93,28 +108,27 @@ func CreateLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Verify leaderboard exists
-	var leaderboard models.Leaderboard
-	if err := db.DB.First(&leaderboard, "id = ?", leaderboardID).Error; err != nil {
-		middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
-		return
-	}
-
 	// Set default value for display priority if not provided
 	displayPriority := req.DisplayPriority
 	if displayPriority < 0 {
 		displayPriority = 0
 	}
 
-	leaderboardMetric := models.LeaderboardMetric{
-		LeaderboardID:   leaderboardID,
-		MetricID:        metricID,
-		Weight:          req.Weight,
-		DisplayPriority: displayPriority,
-	}
-
-	err = db.DB.Create(&leaderboardMetric).Error
+	leaderboardMetric, err := h.service.CreateLeaderboardMetric(r.Context(), leaderboardID, metricID, req.Weight, displayPriority)
 	if err != nil {
+		if err.Error() == "leaderboard not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+			return
+		}
+		if err.Error() == "metric not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Metric not found", err)
+			return
+		}
+		var existsErr *services.LeaderboardMetricExistsError
+		if errors.As(err, &existsErr) {
+			middleware.RespondWithConflict(w, "Metric is already attached to the leaderboard", err, existsErr.LeaderboardMetric)
+			return
+		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to create leaderboard metric", err)
 		return
 	}
@@ -134,8 +148,9 @@ func CreateLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /leaderboard-metrics/{id} [get]
-func GetLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
+func (h *LeaderboardMetricHandler) GetLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	metricID, err := uuid.Parse(idParam)
 	if err != nil {
@@ -143,9 +158,13 @@ func GetLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	metric := models.LeaderboardMetric{}
-	if err := db.DB.First(&metric, "id = ?", metricID).Error; err != nil {
-		middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard metric not found", err)
+	metric, err := h.service.GetLeaderboardMetric(r.Context(), metricID)
+	if err != nil {
+		if err.Error() == "leaderboard metric not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard metric not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch leaderboard metric", err)
 		return
 	}
 
@@ -154,7 +173,7 @@ func GetLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 
 // ListLeaderboardMetrics returns all metrics for a specific leaderboard
 // @Summary List all metrics for a leaderboard
-// @Description Get a list of all metrics associated with a specific leaderboard
+// @Description Get a list of all metrics associated with a specific leaderboard. Without a leaderboard, metrics of private leaderboards the caller cannot read are left out.
 // @Tags leaderboard-metrics
 // @Accept json
 // @Produce json
@@ -163,9 +182,10 @@ func GetLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {array} LeaderboardMetricResponse "List of leaderboard metrics"
 // @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /leaderboard-metrics [get]
 // @Router /leaderboards/{leaderboard_id}/metrics [get]
-func ListLeaderboardMetrics(w http.ResponseWriter, r *http.Request) {
+func (h *LeaderboardMetricHandler) ListLeaderboardMetrics(w http.ResponseWriter, r *http.Request) {
 	// Check if this is a nested route call
 	leaderboardIDParam := chi.URLParam(r, "id")
 
@@ -174,21 +194,36 @@ func ListLeaderboardMetrics(w http.ResponseWriter, r *http.Request) {
 		leaderboardIDParam = r.URL.Query().Get("leaderboard_id")
 	}
 
-	metrics := []models.LeaderboardMetric{}
-	query := db.DB
-
 	// Apply filter if provided
+	var leaderboardID *uuid.UUID
 	if leaderboardIDParam != "" {
-		leaderboardID, err := uuid.Parse(leaderboardIDParam)
+		id, err := uuid.Parse(leaderboardIDParam)
 		if err != nil {
 			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID format", err)
 			return
 		}
-		query = query.Where("leaderboard_id = ?", leaderboardID)
+		leaderboardID = &id
+	}
+
+	// The nested route has already checked the leaderboard can be read; the flat one leaves out private leaderboards
+	// the caller cannot read
+	var readableIDs []uuid.UUID
+	if chi.URLParam(r, "id") == "" {
+		if viewer := middleware.GetViewerFromContext(r); !viewer.CanReadAll {
+			var err error
+			readableIDs, err = h.accessService.ReadableLeaderboardIDs(r.Context(), viewer)
+			if err != nil {
+				middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to check leaderboard permissions", err)
+				return
+			}
+		}
 	}
 
-	// Order by display priority
-	query.Order("display_priority asc").Find(&metrics)
+	metrics, err := h.service.ListLeaderboardMetrics(r.Context(), leaderboardID, readableIDs)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch leaderboard metrics", err)
+		return
+	}
 
 	middleware.RespondWithJSON(w, http.StatusOK, metrics)
 }
@@ -208,7 +243,7 @@ func ListLeaderboardMetrics(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /leaderboard-metrics/{id} [put]
-func UpdateLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
+func (h *LeaderboardMetricHandler) UpdateLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	metricID, err := uuid.Parse(idParam)
 	if err != nil {
@@ -216,17 +251,8 @@ func UpdateLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Fetch existing metric
-	var metric models.LeaderboardMetric
-	if err := db.DB.First(&metric, "id = ?", metricID).Error; err != nil {
-		middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard metric not found", err)
-		return
-	}
-
 	var req UpdateLeaderboardMetricRequest
-	err = json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -237,16 +263,12 @@ func UpdateLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Apply the updates to the metric
-	if req.Weight != nil {
-		metric.Weight = *req.Weight
-	}
-	if req.DisplayPriority != nil {
-		metric.DisplayPriority = *req.DisplayPriority
-	}
-
-	// Save the updated record
-	if err := db.DB.Save(&metric).Error; err != nil {
+	metric, err := h.service.UpdateLeaderboardMetric(r.Context(), metricID, req.Weight, req.DisplayPriority)
+	if err != nil {
+		if err.Error() == "leaderboard metric not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard metric not found", err)
+			return
+		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to update leaderboard metric", err)
 		return
 	}
@@ -268,7 +290,7 @@ func UpdateLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /leaderboard-metrics/{id} [delete]
-func DeleteLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
+func (h *LeaderboardMetricHandler) DeleteLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	metricID, err := uuid.Parse(idParam)
 	if err != nil {
@@ -276,15 +298,12 @@ func DeleteLeaderboardMetric(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if the metric exists
-	metric := models.LeaderboardMetric{}
-	if err := db.DB.First(&metric, "id = ?", metricID).Error; err != nil {
-		middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard metric not found", err)
-		return
-	}
-
-	// Delete the metric
-	if err := db.DB.Delete(&models.LeaderboardMetric{}, "id = ?", metricID).Error; err != nil {
+	err = h.service.DeleteLeaderboardMetric(r.Context(), metricID)
+	if err != nil {
+		if err.Error() == "leaderboard metric not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard metric not found", err)
+			return
+		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to delete leaderboard metric", err)
 		return
 	}
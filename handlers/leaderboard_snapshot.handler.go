@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// LeaderboardSnapshotResponse is used for Swagger documentation
+type LeaderboardSnapshotResponse struct {
+	ID            uuid.UUID                          `json:"id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	LeaderboardID uuid.UUID                          `json:"leaderboard_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TakenAt       time.Time                          `json:"taken_at" example:"2023-01-08T00:00:00Z"`
+	EntryCount    int                                `json:"entry_count" example:"120"`
+	Entries       []LeaderboardSnapshotEntryResponse `json:"entries,omitempty"`
+	CreatedAt     time.Time                          `json:"created_at" example:"2023-01-08T00:00:00Z"`
+	UpdatedAt     time.Time                          `json:"updated_at" example:"2023-01-08T00:00:00Z"`
+}
+
+// LeaderboardSnapshotEntryResponse is used for Swagger documentation
+type LeaderboardSnapshotEntryResponse struct {
+	ID            uuid.UUID                `json:"id" example:"550e8400-e29b-41d4-a716-446655440011"`
+	SnapshotID    uuid.UUID                `json:"snapshot_id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	ParticipantID uuid.UUID                `json:"participant_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Rank          int                      `json:"rank" example:"1"`
+	Score         float64                  `json:"score" example:"420"`
+	Metrics       []SnapshotMetricResponse `json:"metrics"`
+	// Participant profile for display, as it is now
+	Participant *ParticipantResponse `json:"participant,omitempty"`
+}
+
+// SnapshotMetricResponse is used for Swagger documentation
+type SnapshotMetricResponse struct {
+	MetricID      uuid.UUID `json:"metric_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	MetricName    string    `json:"metric_name" example:"Calls Made"`
+	Value         float64   `json:"value" example:"420"`
+	Weight        float64   `json:"weight" example:"1.5"`
+	WeightedValue float64   `json:"weighted_value" example:"630"`
+}
+
+// SnapshotDiffResponse is used for Swagger documentation
+type SnapshotDiffResponse struct {
+	LeaderboardID  uuid.UUID                     `json:"leaderboard_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FromSnapshotID uuid.UUID                     `json:"from_snapshot_id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	FromTakenAt    time.Time                     `json:"from_taken_at" example:"2023-01-01T00:00:00Z"`
+	ToSnapshotID   uuid.UUID                     `json:"to_snapshot_id" example:"550e8400-e29b-41d4-a716-446655440012"`
+	ToTakenAt      time.Time                     `json:"to_taken_at" example:"2023-01-08T00:00:00Z"`
+	Movements      []ParticipantMovementResponse `json:"movements"`
+}
+
+// ParticipantMovementResponse is used for Swagger documentation
+type ParticipantMovementResponse struct {
+	ParticipantID uuid.UUID            `json:"participant_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Participant   *ParticipantResponse `json:"participant,omitempty"`
+	FromRank      *int                 `json:"from_rank,omitempty" example:"5"`
+	ToRank        *int                 `json:"to_rank,omitempty" example:"2"`
+	Trend         string               `json:"trend" example:"up" enums:"up,down,steady,new"`
+	RankChange    int                  `json:"rank_change" example:"3"`
+	FromScore     *float64             `json:"from_score,omitempty" example:"310"`
+	ToScore       *float64             `json:"to_score,omitempty" example:"420"`
+	ScoreChange   float64              `json:"score_change" example:"110"`
+}
+
+type LeaderboardSnapshotHandler struct {
+	service services.LeaderboardSnapshotService
+}
+
+func NewLeaderboardSnapshotHandler() *LeaderboardSnapshotHandler {
+	return &LeaderboardSnapshotHandler{
+		service: newLeaderboardSnapshotService(),
+	}
+}
+
+// newLeaderboardSnapshotService builds the snapshot service shared by the snapshot and export handlers
+func newLeaderboardSnapshotService() services.LeaderboardSnapshotService {
+	return services.NewLeaderboardSnapshotService(
+		repositories.NewLeaderboardSnapshotRepository(),
+		repositories.NewLeaderboardRepository(),
+		newStandingsService(),
+	)
+}
+
+// CreateLeaderboardSnapshot saves a leaderboard's current standings
+// @Summary Take a leaderboard snapshot
+// @Description Save the leaderboard's current standings, as returned by GET /leaderboards/{id}/standings, with each entry's rank, score and metric breakdown. Snapshots keep only participant IDs, so they always show participants' current names.
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Success 201 {object} LeaderboardSnapshotResponse "Created snapshot"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid leaderboard ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Leaderboard not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/snapshots [post]
+func (h *LeaderboardSnapshotHandler) CreateLeaderboardSnapshot(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	snapshot, err := h.service.CreateSnapshot(r.Context(), leaderboardID)
+	if err != nil {
+		respondWithSnapshotError(w, err, "Failed to create snapshot")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusCreated, snapshot)
+}
+
+// ListLeaderboardSnapshots returns the snapshots taken of a leaderboard
+// @Summary List leaderboard snapshots
+// @Description Get the leaderboard's snapshots newest first, without their entries.
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Success 200 {array} LeaderboardSnapshotResponse "Snapshots"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid leaderboard ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Leaderboard not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/snapshots [get]
+func (h *LeaderboardSnapshotHandler) ListLeaderboardSnapshots(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	snapshots, err := h.service.ListSnapshots(r.Context(), leaderboardID)
+	if err != nil {
+		respondWithSnapshotError(w, err, "Failed to fetch snapshots")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, snapshots)
+}
+
+// GetLeaderboardSnapshot returns one snapshot of a leaderboard with its entries
+// @Summary Get a leaderboard snapshot
+// @Description Get a snapshot of the leaderboard with its entries best rank first.
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Param snapshotId path string true "Snapshot ID"
+// @Success 200 {object} LeaderboardSnapshotResponse "Snapshot"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Snapshot not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/snapshots/{snapshotId} [get]
+func (h *LeaderboardSnapshotHandler) GetLeaderboardSnapshot(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+	snapshotID, err := uuid.Parse(chi.URLParam(r, "snapshotId"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid snapshot ID", err)
+		return
+	}
+
+	snapshot, err := h.service.GetSnapshot(r.Context(), leaderboardID, snapshotID)
+	if err != nil {
+		respondWithSnapshotError(w, err, "Failed to fetch snapshot")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, snapshot)
+}
+
+// DiffLeaderboardSnapshots compares two snapshots of a leaderboard
+// @Summary Compare two leaderboard snapshots
+// @Description Get each participant's rank and score movement from snapshot a to snapshot b, for recaps such as week over week. Movements are ordered by rank in b, followed by participants who are only in a. Ranks and scores are left out for the snapshot a participant is not in; participants only in b are new, and those only in a are down with no rank change.
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Param a path string true "ID of the earlier snapshot"
+// @Param b path string true "ID of the later snapshot"
+// @Success 200 {object} SnapshotDiffResponse "Movements between the snapshots"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Snapshot not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/snapshots/{a}/diff/{b} [get]
+func (h *LeaderboardSnapshotHandler) DiffLeaderboardSnapshots(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+	fromSnapshotID, err := uuid.Parse(chi.URLParam(r, "a"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid snapshot ID", err)
+		return
+	}
+	toSnapshotID, err := uuid.Parse(chi.URLParam(r, "b"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid snapshot ID", err)
+		return
+	}
+
+	diff, err := h.service.DiffSnapshots(r.Context(), leaderboardID, fromSnapshotID, toSnapshotID)
+	if err != nil {
+		respondWithSnapshotError(w, err, "Failed to compare snapshots")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, diff)
+}
+
+func respondWithSnapshotError(w http.ResponseWriter, err error, message string) {
+	switch err.Error() {
+	case "leaderboard not found":
+		middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+	case "snapshot not found":
+		middleware.RespondWithError(w, http.StatusNotFound, "Snapshot not found", err)
+	default:
+		middleware.RespondWithError(w, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MaintenanceHandler switches maintenance mode, which is kept as the maintenance_mode feature flag so it reaches
+// every instance
+type MaintenanceHandler struct {
+	service services.FeatureFlagService
+}
+
+func NewMaintenanceHandler() *MaintenanceHandler {
+	repo := repositories.NewFeatureFlagRepository()
+	service := services.NewFeatureFlagService(repo)
+	return &MaintenanceHandler{
+		service: service,
+	}
+}
+
+// GetMaintenance returns whether the service is in maintenance mode
+// @Summary Get maintenance mode
+// @Description Get whether the service is in maintenance mode, rejecting requests that change data with 503 while reads stay available, and who switched it and when.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} FeatureFlagResponse "Maintenance mode"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /admin/maintenance [get]
+func (h *MaintenanceHandler) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	flag, err := h.service.GetFeatureFlag(r.Context(), enums.FeatureMaintenanceMode)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch maintenance mode", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, flag)
+}
+
+// SetMaintenance switches maintenance mode on or off
+// @Summary Set maintenance mode
+// @Description Switch maintenance mode on or off on every instance. While it is on, POST, PUT, PATCH and DELETE requests and gRPC calls other than Get and List are rejected with 503, except logging in and the admin maintenance and feature flag endpoints. The instance answering applies it right away, the others within FEATURE_FLAG_REFRESH_INTERVAL seconds.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param maintenance body SetFeatureFlagRequest true "Whether maintenance mode is on"
+// @Success 200 {object} FeatureFlagResponse "Maintenance mode"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /admin/maintenance [put]
+func (h *MaintenanceHandler) SetMaintenance(w http.ResponseWriter, r *http.Request) {
+	var req SetFeatureFlagRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	flag, err := h.service.SetFeatureFlag(r.Context(), enums.FeatureMaintenanceMode, *req.Enabled, claims.Subject)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to set maintenance mode", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, flag)
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type MeHandler struct {
+	service services.MeService
+}
+
+func NewMeHandler() *MeHandler {
+	participantRepo := repositories.NewParticipantRepository()
+	entryRepo := repositories.NewLeaderboardEntryRepository()
+	metricValueRepo := repositories.NewMetricValueRepository()
+	leaderboardRepo := repositories.NewLeaderboardRepository()
+	service := services.NewMeService(participantRepo, entryRepo, metricValueRepo, leaderboardRepo)
+	return &MeHandler{
+		service: service,
+	}
+}
+
+// GetMyParticipant returns the participant linked to the authenticated user
+// @Summary Get my participant
+// @Description Get the participant linked to the authenticated user
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ParticipantResponse "Linked participant"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "No participant linked to this user"
+// @Router /me [get]
+func (h *MeHandler) GetMyParticipant(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	participant, err := h.service.GetParticipant(r.Context(), userID)
+	if err != nil {
+		h.respondWithError(w, err, "Failed to fetch participant")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, participant)
+}
+
+// ListMyEntries returns the authenticated user's entries across all leaderboards
+// @Summary List my leaderboard entries
+// @Description Get the leaderboard entries of the participant linked to the authenticated user
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} LeaderboardEntryResponse "List of entries"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "No participant linked to this user"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /me/entries [get]
+func (h *MeHandler) ListMyEntries(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	entries, err := h.service.ListEntries(r.Context(), userID)
+	if err != nil {
+		h.respondWithError(w, err, "Failed to fetch leaderboard entries")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, entries)
+}
+
+// GetMyRank returns the authenticated user's entry on a leaderboard
+// @Summary Get my rank on a leaderboard
+// @Description Get the entry, including rank and score, of the participant linked to the authenticated user
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param leaderboard_id path string true "Leaderboard ID"
+// @Success 200 {object} LeaderboardEntryResponse "Leaderboard entry"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /me/rank/{leaderboard_id} [get]
+func (h *MeHandler) GetMyRank(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "leaderboard_id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	entry, err := h.service.GetRank(r.Context(), userID, leaderboardID)
+	if err != nil {
+		h.respondWithError(w, err, "Failed to fetch rank")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, entry)
+}
+
+// ListMyMetricValues returns the metric values recorded for the authenticated user
+// @Summary List my metric values
+// @Description Get metric values of the participant linked to the authenticated user, optionally filtered by metric and time range
+// @Tags me
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param metric_id query string false "Filter by metric ID"
+// @Param from_time query string false "Filter values recorded at or after this time (RFC3339)"
+// @Param to_time query string false "Filter values recorded at or before this time (RFC3339)"
+// @Success 200 {array} MetricValueResponse "List of metric values"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "No participant linked to this user"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /me/metric-values [get]
+func (h *MeHandler) ListMyMetricValues(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var metricID *uuid.UUID
+	if metricIDParam := r.URL.Query().Get("metric_id"); metricIDParam != "" {
+		parsedMetricID, err := uuid.Parse(metricIDParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid metric ID format", err)
+			return
+		}
+		metricID = &parsedMetricID
+	}
+
+	var fromTime, toTime *time.Time
+	if fromTimeParam := r.URL.Query().Get("from_time"); fromTimeParam != "" {
+		parsedFromTime, err := time.Parse(time.RFC3339, fromTimeParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid from_time format, use RFC3339", err)
+			return
+		}
+		fromTime = &parsedFromTime
+	}
+	if toTimeParam := r.URL.Query().Get("to_time"); toTimeParam != "" {
+		parsedToTime, err := time.Parse(time.RFC3339, toTimeParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid to_time format, use RFC3339", err)
+			return
+		}
+		toTime = &parsedToTime
+	}
+
+	values, err := h.service.ListMetricValues(r.Context(), userID, metricID, fromTime, toTime)
+	if err != nil {
+		h.respondWithError(w, err, "Failed to fetch metric values")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, values)
+}
+
+// userID extracts the authenticated user's ID, writing an error response if there is none
+func (h *MeHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	if _, err := middleware.GetUserFromContext(r.Context()); err != nil {
+		middleware.RespondWithError(w, http.StatusUnauthorized, "Unauthorized access", err)
+		return uuid.Nil, false
+	}
+
+	userID, ok := middleware.GetRequestUserID(r)
+	if !ok {
+		middleware.RespondWithError(w, http.StatusForbidden, "Only user tokens have a linked participant", errors.New("token does not identify a user"))
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func (h *MeHandler) respondWithError(w http.ResponseWriter, err error, message string) {
+	switch err.Error() {
+	case "no participant linked to user":
+		middleware.RespondWithError(w, http.StatusNotFound, "No participant is linked to this user", err)
+	case "leaderboard not found":
+		middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+	case "leaderboard entry not found":
+		middleware.RespondWithError(w, http.StatusNotFound, "You have no entry on this leaderboard", err)
+	default:
+		middleware.RespondWithError(w, http.StatusInternalServerError, message, err)
+	}
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 	"time"
 
@@ -22,9 +21,9 @@ type CreateMetricRequest struct {
 	Description     string `json:"description" example:"Number of calls completed in a month"`
 	DataType        string `json:"data_type" validate:"required,oneof=integer decimal boolean string" example:"integer" enums:"integer,decimal,boolean,string"`
 	Unit            string `json:"unit" example:"calls"`
-	AggregationType string `json:"aggregation_type" validate:"required,oneof=sum average count min max last" example:"sum" enums:"sum,average,count,min,max,last"`
+	AggregationType string `json:"aggregation_type" validate:"required,oneof=sum average count min max last" example:"sum" enums:"sum,average,count,min,max,last"` // Boolean and string metrics only support count and last
 	ResetPeriod     string `json:"reset_period" validate:"required,oneof=none daily weekly monthly yearly" example:"monthly" enums:"none,daily,weekly,monthly,yearly"`
-	IsHigherBetter  bool   `json:"is_higher_better" example:"true"`
+	IsHigherBetter  *bool  `json:"is_higher_better,omitempty" example:"true"` // Defaults to true
 }
 
 // UpdateMetricRequest represents the request payload for updating a metric
@@ -80,9 +79,7 @@ func NewMetricHandler() *MetricHandler {
 func (h *MetricHandler) CreateMetric(w http.ResponseWriter, r *http.Request) {
 	var req CreateMetricRequest
 
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -94,6 +91,7 @@ func (h *MetricHandler) CreateMetric(w http.ResponseWriter, r *http.Request) {
 	}
 
 	metric, err := h.service.CreateMetric(
+		r.Context(),
 		req.Name,
 		req.Description,
 		enums.MetricDataType(req.DataType),
@@ -104,6 +102,10 @@ func (h *MetricHandler) CreateMetric(w http.ResponseWriter, r *http.Request) {
 	)
 
 	if err != nil {
+		if err.Error() == "boolean and string metrics only support count and last aggregation" {
+			middleware.RespondWithError(w, http.StatusBadRequest, err.Error(), err)
+			return
+		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to create metric", err)
 		return
 	}
@@ -123,6 +125,7 @@ func (h *MetricHandler) CreateMetric(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /metrics/{id} [get]
 func (h *MetricHandler) GetMetric(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
@@ -132,9 +135,13 @@ func (h *MetricHandler) GetMetric(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	metric, err := h.service.GetMetric(metricID)
+	metric, err := h.service.GetMetric(r.Context(), metricID)
 	if err != nil {
-		middleware.RespondWithError(w, http.StatusNotFound, "Metric not found", err)
+		if err.Error() == "metric not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Metric not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch metric", err)
 		return
 	}
 
@@ -143,16 +150,30 @@ func (h *MetricHandler) GetMetric(w http.ResponseWriter, r *http.Request) {
 
 // ListMetrics returns all metrics
 // @Summary List all metrics
-// @Description Get a list of all metrics
+// @Description Get a list of all metrics. Pass ids to fetch several metrics by ID in one request; IDs that don't exist are left out.
 // @Tags metrics
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param include_deleted query bool false "Also list soft-deleted metrics (admin only)"
+// @Param ids query []string false "Only the metrics with these IDs, comma-separated or repeated (max 100)" collectionFormat(multi)
 // @Success 200 {array} MetricResponse "List of metrics"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "include_deleted without the admin role"
 // @Router /metrics [get]
 func (h *MetricHandler) ListMetrics(w http.ResponseWriter, r *http.Request) {
-	metrics, err := h.service.ListMetrics()
+	includeDeleted, ok := parseIncludeDeleted(w, r)
+	if !ok {
+		return
+	}
+
+	ids, ok := parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	metrics, err := h.service.ListMetrics(r.Context(), ids, includeDeleted)
 	if err != nil {
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch metrics", err)
 		return
@@ -185,9 +206,7 @@ func (h *MetricHandler) UpdateMetric(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req UpdateMetricRequest
-	err = json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -218,6 +237,7 @@ func (h *MetricHandler) UpdateMetric(w http.ResponseWriter, r *http.Request) {
 	}
 
 	updatedMetric, err := h.service.UpdateMetric(
+		r.Context(),
 		metricID,
 		req.Name,
 		req.Description,
@@ -229,9 +249,14 @@ func (h *MetricHandler) UpdateMetric(w http.ResponseWriter, r *http.Request) {
 	)
 
 	if err != nil {
-		if err.Error() == "metric not found" {
+		switch err.Error() {
+		case "metric not found":
 			middleware.RespondWithError(w, http.StatusNotFound, "Metric not found", err)
 			return
+		case "boolean and string metrics only support count and last aggregation",
+			"metric data type can't change between numeric and non-numeric":
+			middleware.RespondWithError(w, http.StatusBadRequest, err.Error(), err)
+			return
 		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to update metric", err)
 		return
@@ -262,7 +287,7 @@ func (h *MetricHandler) DeleteMetric(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.service.DeleteMetric(metricID)
+	err = h.service.DeleteMetric(r.Context(), metricID)
 	if err != nil {
 		if err.Error() == "metric not found" {
 			middleware.RespondWithError(w, http.StatusNotFound, "Metric not found", err)
@@ -274,3 +299,43 @@ func (h *MetricHandler) DeleteMetric(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// RestoreMetric undoes the soft delete of a metric
+// @Summary Restore a deleted metric
+// @Description Bring back a soft-deleted metric. Deleted metrics can be found by listing with include_deleted=true. Admin only.
+// @Tags metrics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Metric ID"
+// @Success 200 {object} MetricResponse "Restored metric"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 409 {object} middleware.ErrorResponse "Not deleted or conflicts with live data"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /metrics/{id}/restore [post]
+func (h *MetricHandler) RestoreMetric(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid metric ID", err)
+		return
+	}
+
+	restored, err := h.service.RestoreMetric(r.Context(), id)
+	if err != nil {
+		switch err.Error() {
+		case "metric not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Metric not found", err)
+		case "metric is not deleted":
+			middleware.RespondWithError(w, http.StatusConflict, "Metric is not deleted", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to restore metric", err)
+		}
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, restored)
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// MetricAggregationResponse is used for Swagger documentation
+type MetricAggregationResponse struct {
+	MetricID        uuid.UUID               `json:"metric_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	MetricName      string                  `json:"metric_name" example:"Calls Made"`
+	AggregationType string                  `json:"aggregation_type" example:"sum"`
+	ParticipantID   *uuid.UUID              `json:"participant_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	From            *time.Time              `json:"from,omitempty" example:"2023-01-01T00:00:00Z"`
+	To              *time.Time              `json:"to,omitempty" example:"2023-01-31T23:59:59Z"`
+	GroupBy         string                  `json:"group_by,omitempty" example:"day"`
+	Total           MetricAggregateResponse `json:"total"`
+	Buckets         []MetricBucketResponse  `json:"buckets,omitempty"`
+}
+
+// MetricBucketResponse is used for Swagger documentation
+type MetricBucketResponse struct {
+	PeriodStart time.Time `json:"period_start" example:"2023-01-02T00:00:00Z"`
+	Value       float64   `json:"value" example:"42"`
+	Count       int64     `json:"count" example:"3"`
+	Sum         float64   `json:"sum" example:"42"`
+	Min         float64   `json:"min" example:"10"`
+	Max         float64   `json:"max" example:"20"`
+	Average     float64   `json:"average" example:"14"`
+	// Last value of a boolean or string metric
+	LastBoolValue   *bool      `json:"last_bool_value,omitempty" example:"true"`
+	LastStringValue *string    `json:"last_string_value,omitempty" example:"gold"`
+	LastRecordedAt  *time.Time `json:"last_recorded_at,omitempty" example:"2023-01-02T17:30:00Z"`
+}
+
+// MetricTimeseriesResponse is used for Swagger documentation
+type MetricTimeseriesResponse struct {
+	ParticipantID   uuid.UUID              `json:"participant_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	MetricID        uuid.UUID              `json:"metric_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	MetricName      string                 `json:"metric_name" example:"Calls Made"`
+	AggregationType string                 `json:"aggregation_type" example:"sum"`
+	Interval        string                 `json:"interval" example:"day"`
+	From            *time.Time             `json:"from,omitempty" example:"2023-01-01T00:00:00Z"`
+	To              *time.Time             `json:"to,omitempty" example:"2023-01-31T23:59:59Z"`
+	Points          []MetricBucketResponse `json:"points"`
+}
+
+type MetricAggregationHandler struct {
+	service services.MetricAggregationService
+}
+
+func NewMetricAggregationHandler() *MetricAggregationHandler {
+	return &MetricAggregationHandler{
+		service: services.NewMetricAggregationService(
+			repositories.NewMetricRepository(),
+			repositories.NewMetricValueRepository(),
+			repositories.NewParticipantRepository(),
+		),
+	}
+}
+
+// AggregateMetric returns a metric's values aggregated by the server
+// @Summary Aggregate a metric's values
+// @Description Aggregate the metric's values with its aggregation type, in total and, with group_by, per UTC hour, day, week (starting Monday) or month. value holds the aggregate the metric is configured for; count, sum, min, max and average are always included. Intervals without values are left out.
+// @Tags metrics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Metric ID"
+// @Param participant_id query string false "Only aggregate this participant's values"
+// @Param from query string false "Only values recorded at or after this time" format(date-time)
+// @Param to query string false "Only values recorded at or before this time" format(date-time)
+// @Param group_by query string false "Interval to aggregate values per" Enums(hour, day, week, month)
+// @Success 200 {object} MetricAggregationResponse "Aggregated values"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Metric not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /metrics/{id}/aggregate [get]
+func (h *MetricAggregationHandler) AggregateMetric(w http.ResponseWriter, r *http.Request) {
+	metricID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid metric ID", err)
+		return
+	}
+
+	query, ok := parseAggregationQuery(w, r, "group_by")
+	if !ok {
+		return
+	}
+	if participantIDParam := r.URL.Query().Get("participant_id"); participantIDParam != "" {
+		participantID, err := uuid.Parse(participantIDParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID format", err)
+			return
+		}
+		query.ParticipantID = &participantID
+	}
+
+	aggregation, err := h.service.AggregateMetric(r.Context(), metricID, query)
+	if err != nil {
+		respondWithAggregationError(w, err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, aggregation)
+}
+
+// GetParticipantTimeseries returns a participant's values for a metric per interval, for charting
+// @Summary Get a participant's metric time series
+// @Description Aggregate the participant's values for the metric per UTC hour, day, week (starting Monday) or month, computed in SQL. Points cover every interval from from to to, or from the first value up to now, zero-filled where nothing was recorded, so they can be charted as is. value holds the aggregate the metric is configured for. A range can span at most 1000 points.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Participant ID"
+// @Param metric_id path string true "Metric ID"
+// @Param interval query string false "Interval of each point" Enums(hour, day, week, month) default(day)
+// @Param from query string false "Only values recorded at or after this time" format(date-time)
+// @Param to query string false "Only values recorded at or before this time" format(date-time)
+// @Success 200 {object} MetricTimeseriesResponse "Time series"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Participant or metric not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/metrics/{metric_id}/timeseries [get]
+func (h *MetricAggregationHandler) GetParticipantTimeseries(w http.ResponseWriter, r *http.Request) {
+	participantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+	metricID, err := uuid.Parse(chi.URLParam(r, "metric_id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid metric ID", err)
+		return
+	}
+
+	query, ok := parseAggregationQuery(w, r, "interval")
+	if !ok {
+		return
+	}
+
+	timeseries, err := h.service.ParticipantTimeseries(r.Context(), participantID, metricID, query)
+	if err != nil {
+		respondWithAggregationError(w, err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, timeseries)
+}
+
+// parseAggregationQuery reads the from and to times and the interval, taken from intervalParam, of an aggregation.
+// It writes a 400 and returns false when any of them is invalid.
+func parseAggregationQuery(w http.ResponseWriter, r *http.Request, intervalParam string) (services.MetricAggregationQuery, bool) {
+	var query services.MetricAggregationQuery
+
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{{"from", &query.From}, {"to", &query.To}} {
+		value := r.URL.Query().Get(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid "+param.name+" format, use RFC3339", err)
+			return query, false
+		}
+		*param.target = &parsed
+	}
+	if query.From != nil && query.To != nil && query.To.Before(*query.From) {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid time range, to must not be before from", nil)
+		return query, false
+	}
+
+	query.GroupBy = strings.ToLower(r.URL.Query().Get(intervalParam))
+	if query.GroupBy != "" && !slices.Contains(repositories.AggregateIntervals, query.GroupBy) {
+		middleware.RespondWithError(w, http.StatusBadRequest,
+			"Invalid "+intervalParam+", must be one of: "+strings.Join(repositories.AggregateIntervals, ", "), nil)
+		return query, false
+	}
+	return query, true
+}
+
+func respondWithAggregationError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "metric not found":
+		middleware.RespondWithError(w, http.StatusNotFound, "Metric not found", err)
+	case "participant not found":
+		middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+	case "too many points":
+		middleware.RespondWithError(w, http.StatusBadRequest,
+			"Time range too long for the interval, at most "+strconv.Itoa(services.MaxTimeseriesPoints)+" points", err)
+	default:
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to aggregate metric values", err)
+	}
+}
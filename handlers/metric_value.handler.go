@@ -1,8 +1,9 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"leaderboard-service/middleware"
@@ -17,20 +18,29 @@ import (
 
 // CreateMetricValueRequest represents the request payload for creating a metric value
 type CreateMetricValueRequest struct {
-	MetricID      string      `json:"metric_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
-	ParticipantID string      `json:"participant_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440001"`
-	Value         float64     `json:"value" validate:"required" example:"42.5"`
-	Timestamp     *time.Time  `json:"timestamp,omitempty" example:"2023-01-01T00:00:00Z"`
-	Source        string      `json:"source,omitempty" example:"call_system"`
-	Context       interface{} `json:"context,omitempty"`
+	MetricID      string `json:"metric_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ParticipantID string `json:"participant_id,omitempty" validate:"required_without=ParticipantExternalID,omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440001"`
+	// Alternative to participant_id: the external_id the participant was created with
+	ParticipantExternalID string `json:"participant_external_id,omitempty" example:"external-123"`
+	// The value goes in the field of the metric's data type: value for integer and decimal metrics, bool_value for
+	// boolean ones and string_value for string ones
+	Value       *float64    `json:"value,omitempty" validate:"required_without_all=BoolValue StringValue" example:"42.5"`
+	BoolValue   *bool       `json:"bool_value,omitempty" example:"true"`
+	StringValue *string     `json:"string_value,omitempty" example:"gold"`
+	Timestamp   *time.Time  `json:"timestamp,omitempty" example:"2023-01-01T00:00:00Z"`
+	Source      string      `json:"source,omitempty" example:"call_system"`
+	Context     interface{} `json:"context,omitempty"`
 }
 
 // UpdateMetricValueRequest represents the request payload for updating a metric value
 type UpdateMetricValueRequest struct {
-	Value     *float64     `json:"value,omitempty" validate:"omitempty" example:"50.75"`
-	Timestamp *time.Time   `json:"timestamp,omitempty" example:"2023-01-02T00:00:00Z"`
-	Source    *string      `json:"source,omitempty" example:"text_system"`
-	Context   *interface{} `json:"context,omitempty"`
+	// At most one, in the field of the metric's data type as when creating the value
+	Value       *float64     `json:"value,omitempty" validate:"omitempty" example:"50.75"`
+	BoolValue   *bool        `json:"bool_value,omitempty" example:"false"`
+	StringValue *string      `json:"string_value,omitempty" example:"silver"`
+	Timestamp   *time.Time   `json:"timestamp,omitempty" example:"2023-01-02T00:00:00Z"`
+	Source      *string      `json:"source,omitempty" example:"text_system"`
+	Context     *interface{} `json:"context,omitempty"`
 }
 
 // MetricValueResponse is used for Swagger documentation
@@ -38,7 +48,9 @@ type MetricValueResponse struct {
 	ID            uuid.UUID   `json:"id" example:"550e8400-e29b-41d4-a716-446655440002"`
 	MetricID      uuid.UUID   `json:"metric_id" example:"550e8400-e29b-41d4-a716-446655440000"`
 	ParticipantID uuid.UUID   `json:"participant_id" example:"550e8400-e29b-41d4-a716-446655440001"`
-	Value         float64     `json:"value" example:"42.5"`
+	Value         float64     `json:"value" example:"42.5"` // 1 or 0 for boolean metrics and 0 for string ones
+	BoolValue     *bool       `json:"bool_value,omitempty" example:"true"`
+	StringValue   *string     `json:"string_value,omitempty" example:"gold"`
 	Timestamp     time.Time   `json:"timestamp" example:"2023-01-01T00:00:00Z"`
 	Source        string      `json:"source,omitempty" example:"call_system"`
 	Context       interface{} `json:"context,omitempty"`
@@ -46,8 +58,13 @@ type MetricValueResponse struct {
 	UpdatedAt     time.Time   `json:"updated_at" example:"2023-01-01T00:00:00Z"`
 }
 
+// Returned for a value sent in the field of another data type than the metric's
+const valueTypeMessage = "Value does not match the metric's data type: send value for integer and decimal metrics, " +
+	"bool_value for boolean metrics and string_value for string metrics"
+
 type MetricValueHandler struct {
-	service services.MetricValueService
+	service            services.MetricValueService
+	participantService services.ParticipantService
 }
 
 func NewMetricValueHandler() *MetricValueHandler {
@@ -56,14 +73,18 @@ func NewMetricValueHandler() *MetricValueHandler {
 	participantRepo := repositories.NewParticipantRepository()
 	service := services.NewMetricValueService(metricValueRepo, metricRepo, participantRepo)
 
+	participantService := services.NewParticipantService(participantRepo, repositories.NewParticipantNameChangeRepository(),
+		repositories.NewLeaderboardEntryRepository())
+
 	return &MetricValueHandler{
-		service: service,
+		service:            service,
+		participantService: participantService,
 	}
 }
 
 // CreateMetricValue creates a new metric value
 // @Summary Create a new metric value
-// @Description Create a new metric value record for a participant
+// @Description Create a new metric value record for a participant. Send the value in value for integer and decimal metrics, bool_value for boolean metrics and string_value for string metrics.
 // @Tags metric-values
 // @Accept json
 // @Produce json
@@ -72,7 +93,7 @@ func NewMetricValueHandler() *MetricValueHandler {
 // @Param participant_id path string false "Participant ID"
 // @Param metric_value body CreateMetricValueRequest true "Metric value data"
 // @Success 201 {object} MetricValueResponse "Created metric value"
-// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request, or a value not of the metric's data type"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Metric or participant not found"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
@@ -82,9 +103,7 @@ func NewMetricValueHandler() *MetricValueHandler {
 func (h *MetricValueHandler) CreateMetricValue(w http.ResponseWriter, r *http.Request) {
 	var req CreateMetricValueRequest
 
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -93,15 +112,7 @@ func (h *MetricValueHandler) CreateMetricValue(w http.ResponseWriter, r *http.Re
 	participantIDPath := chi.URLParam(r, "id")
 
 	// Determine the context of the call (which nested route we're using)
-	routePath := r.URL.Path
-	isMetricNested := false
-	isParticipantNested := false
-
-	if len(routePath) >= 8 && routePath[:8] == "/metrics" {
-		isMetricNested = true
-	} else if len(routePath) >= 13 && routePath[:13] == "/participants" {
-		isParticipantNested = true
-	}
+	isMetricNested, isParticipantNested := nestedMetricValueRoute(r)
 
 	// Override request values with path parameters if available
 	if isMetricNested && metricIDPath != "" {
@@ -126,9 +137,8 @@ func (h *MetricValueHandler) CreateMetricValue(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	participantID, err := uuid.Parse(req.ParticipantID)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID format", err)
+	participantID, ok := resolveParticipantID(w, r, h.participantService, req.ParticipantID, req.ParticipantExternalID)
+	if !ok {
 		return
 	}
 
@@ -139,18 +149,23 @@ func (h *MetricValueHandler) CreateMetricValue(w http.ResponseWriter, r *http.Re
 	}
 
 	metricValue, err := h.service.CreateMetricValue(
+		r.Context(),
 		metricID,
 		participantID,
-		req.Value,
+		services.MetricValueInput{Number: req.Value, Bool: req.BoolValue, String: req.StringValue},
 		timestamp,
 		req.Source,
 		req.Context,
 	)
 
 	if err != nil {
-		if err.Error() == "metric not found" || err.Error() == "participant not found" {
+		switch err.Error() {
+		case "metric not found", "participant not found":
 			middleware.RespondWithError(w, http.StatusNotFound, err.Error(), err)
 			return
+		case "value does not match the metric's data type":
+			middleware.RespondWithError(w, http.StatusBadRequest, valueTypeMessage, err)
+			return
 		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to create metric value", err)
 		return
@@ -171,6 +186,7 @@ func (h *MetricValueHandler) CreateMetricValue(w http.ResponseWriter, r *http.Re
 // @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /metric-values/{id} [get]
 func (h *MetricValueHandler) GetMetricValue(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
@@ -180,9 +196,13 @@ func (h *MetricValueHandler) GetMetricValue(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	value, err := h.service.GetMetricValue(valueID)
+	value, err := h.service.GetMetricValue(r.Context(), valueID)
 	if err != nil {
-		middleware.RespondWithError(w, http.StatusNotFound, "Metric value not found", err)
+		if err.Error() == "metric value not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Metric value not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch metric value", err)
 		return
 	}
 
@@ -191,7 +211,7 @@ func (h *MetricValueHandler) GetMetricValue(w http.ResponseWriter, r *http.Reque
 
 // ListMetricValues returns metric values with optional filtering
 // @Summary List metric values
-// @Description Get a list of metric values with optional filtering by metric ID and/or participant ID
+// @Description Get a page of metric values with optional filtering by metric ID and/or participant ID. When there are more values, the X-Next-Cursor header holds the cursor to pass for the next page; keep the same filters and sort while paging.
 // @Tags metric-values
 // @Accept json
 // @Produce json
@@ -200,8 +220,13 @@ func (h *MetricValueHandler) GetMetricValue(w http.ResponseWriter, r *http.Reque
 // @Param participant_id path string false "Filter by participant ID"
 // @Param from_time query string false "Filter by timestamp (greater than or equal)" format(date-time)
 // @Param to_time query string false "Filter by timestamp (less than or equal)" format(date-time)
+// @Param sort query string false "Field to sort by; defaults to the most recent timestamp first" Enums(timestamp, value, created_at)
+// @Param order query string false "Sort direction, defaults to asc" Enums(asc, desc)
+// @Param cursor query string false "Opaque cursor returned in X-Next-Cursor by the previous call"
+// @Param limit query int false "Maximum number of values to return (max 1000)" default(100)
 // @Success 200 {array} MetricValueResponse "List of metric values"
-// @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters or cursor"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Router /metric-values [get]
 // @Router /metrics/{metric_id}/values [get]
@@ -212,15 +237,7 @@ func (h *MetricValueHandler) ListMetricValues(w http.ResponseWriter, r *http.Req
 	participantIDPath := chi.URLParam(r, "id")
 
 	// Determine the context of the call (which nested route we're using)
-	routePath := r.URL.Path
-	isMetricNested := false
-	isParticipantNested := false
-
-	if len(routePath) >= 8 && routePath[:8] == "/metrics" {
-		isMetricNested = true
-	} else if len(routePath) >= 13 && routePath[:13] == "/participants" {
-		isParticipantNested = true
-	}
+	isMetricNested, isParticipantNested := nestedMetricValueRoute(r)
 
 	// Get query parameters (for flat route)
 	metricIDQuery := r.URL.Query().Get("metric_id")
@@ -289,13 +306,36 @@ func (h *MetricValueHandler) ListMetricValues(w http.ResponseWriter, r *http.Req
 		toTime = &parsedToTime
 	}
 
-	values, err := h.service.ListFilteredMetricValues(metricID, participantID, fromTime, toTime)
+	sort, ok := parseSort(w, r, repositories.MetricValueSortColumns)
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsedLimit, err := strconv.Atoi(limitParam)
+		if err != nil || parsedLimit < 1 {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid limit, must be a positive integer", err)
+			return
+		}
+		limit = parsedLimit
+	}
+
+	page, err := h.service.ListFilteredMetricValues(r.Context(), metricID, participantID, fromTime, toTime, sort,
+		r.URL.Query().Get("cursor"), limit)
 	if err != nil {
+		if err.Error() == "invalid cursor" {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid cursor, or it was issued for a different sort", err)
+			return
+		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch metric values", err)
 		return
 	}
 
-	middleware.RespondWithJSON(w, http.StatusOK, values)
+	if page.NextCursor != "" {
+		w.Header().Set(middleware.NextCursorHeader, page.NextCursor)
+	}
+	middleware.RespondWithJSON(w, http.StatusOK, page.Values)
 }
 
 // UpdateMetricValue updates an existing metric value
@@ -308,7 +348,7 @@ func (h *MetricValueHandler) ListMetricValues(w http.ResponseWriter, r *http.Req
 // @Param id path string true "Metric Value ID"
 // @Param metric_value body UpdateMetricValueRequest true "Updated metric value data"
 // @Success 200 {object} MetricValueResponse "Updated metric value"
-// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request, or a value not of the metric's data type"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
@@ -322,9 +362,7 @@ func (h *MetricValueHandler) UpdateMetricValue(w http.ResponseWriter, r *http.Re
 	}
 
 	var req UpdateMetricValueRequest
-	err = json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -336,17 +374,22 @@ func (h *MetricValueHandler) UpdateMetricValue(w http.ResponseWriter, r *http.Re
 	}
 
 	updatedValue, err := h.service.UpdateMetricValue(
+		r.Context(),
 		valueID,
-		req.Value,
+		services.MetricValueInput{Number: req.Value, Bool: req.BoolValue, String: req.StringValue},
 		req.Timestamp,
 		req.Source,
 		req.Context,
 	)
 
 	if err != nil {
-		if err.Error() == "metric value not found" {
+		switch err.Error() {
+		case "metric value not found":
 			middleware.RespondWithError(w, http.StatusNotFound, "Metric value not found", err)
 			return
+		case "value does not match the metric's data type":
+			middleware.RespondWithError(w, http.StatusBadRequest, valueTypeMessage, err)
+			return
 		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to update metric value", err)
 		return
@@ -377,7 +420,7 @@ func (h *MetricValueHandler) DeleteMetricValue(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	err = h.service.DeleteMetricValue(valueID)
+	err = h.service.DeleteMetricValue(r.Context(), valueID)
 	if err != nil {
 		if err.Error() == "metric value not found" {
 			middleware.RespondWithError(w, http.StatusNotFound, "Metric value not found", err)
@@ -389,3 +432,10 @@ func (h *MetricValueHandler) DeleteMetricValue(w http.ResponseWriter, r *http.Re
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// nestedMetricValueRoute reports whether the request came in under /metrics/{id} or /participants/{id}. The matched
+// route pattern is checked rather than the path so it holds under any /api/<version> prefix.
+func nestedMetricValueRoute(r *http.Request) (isMetricNested, isParticipantNested bool) {
+	pattern := chi.RouteContext(r.Context()).RoutePattern()
+	return strings.Contains(pattern, "/metrics/{id}"), strings.Contains(pattern, "/participants/{id}")
+}
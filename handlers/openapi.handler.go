@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"sync"
+
+	"leaderboard-service/docs"
+	"leaderboard-service/middleware"
+	"leaderboard-service/openapi"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// requestBodies maps each handler that decodes a JSON body to the request struct it decodes into. A handler
+// missing here is documented without a request body.
+var requestBodies = map[string]interface{}{
+	openapi.HandlerName((*APIKeyHandler).CreateAPIKey):                           CreateAPIKeyRequest{},
+	openapi.HandlerName((*AuthHandler).Login):                                    LoginRequest{},
+	openapi.HandlerName((*AuthHandler).Register):                                 RegisterRequest{},
+	openapi.HandlerName((*AuthHandler).RequestPasswordReset):                     PasswordResetRequest{},
+	openapi.HandlerName((*AuthHandler).ConfirmPasswordReset):                     PasswordResetConfirmRequest{},
+	openapi.HandlerName((*DeviceTokenHandler).RegisterDeviceToken):               RegisterDeviceTokenRequest{},
+	openapi.HandlerName((*LeaderboardHandler).CreateLeaderboard):                 CreateLeaderboardRequest{},
+	openapi.HandlerName((*LeaderboardHandler).UpdateLeaderboard):                 UpdateLeaderboardRequest{},
+	openapi.HandlerName((*LeaderboardEntryHandler).CreateLeaderboardEntry):       CreateLeaderboardEntryRequest{},
+	openapi.HandlerName((*LeaderboardEntryHandler).UpdateLeaderboardEntry):       UpdateLeaderboardEntryRequest{},
+	openapi.HandlerName((*LeaderboardMemberHandler).SetLeaderboardMember):        SetLeaderboardMemberRequest{},
+	openapi.HandlerName((*LeaderboardMetricHandler).CreateLeaderboardMetric):     CreateLeaderboardMetricRequest{},
+	openapi.HandlerName((*LeaderboardMetricHandler).UpdateLeaderboardMetric):     UpdateLeaderboardMetricRequest{},
+	openapi.HandlerName((*MetricHandler).CreateMetric):                           CreateMetricRequest{},
+	openapi.HandlerName((*MetricHandler).UpdateMetric):                           UpdateMetricRequest{},
+	openapi.HandlerName((*MetricValueHandler).CreateMetricValue):                 CreateMetricValueRequest{},
+	openapi.HandlerName((*MetricValueHandler).UpdateMetricValue):                 UpdateMetricValueRequest{},
+	openapi.HandlerName((*ParticipantHandler).CreateParticipant):                 CreateParticipantRequest{},
+	openapi.HandlerName((*ParticipantHandler).UpdateParticipant):                 UpdateParticipantRequest{},
+	openapi.HandlerName((*ParticipantAnonymizationHandler).AnonymizeParticipant): AnonymizeParticipantRequest{},
+	openapi.HandlerName((*ParticipantGroupHandler).SetParticipantParent):         SetParticipantParentRequest{},
+	openapi.HandlerName((*ParticipantTagHandler).TagParticipants):                BulkTagRequest{},
+	openapi.HandlerName((*ParticipantTagHandler).UntagParticipants):              BulkTagRequest{},
+	openapi.HandlerName((*ServiceTokenHandler).CreateServiceToken):               CreateServiceTokenRequest{},
+	openapi.HandlerName((*TeamMemberHandler).AddTeamMember):                      AddTeamMemberRequest{},
+	openapi.HandlerName((*WebhookSubscriptionHandler).CreateWebhookSubscription): CreateWebhookSubscriptionRequest{},
+	openapi.HandlerName((*WebhookSubscriptionHandler).UpdateWebhookSubscription): UpdateWebhookSubscriptionRequest{},
+}
+
+type OpenAPIHandler struct {
+	routes chi.Routes
+
+	once     sync.Once
+	document *openapi.Document
+	err      error
+}
+
+// NewOpenAPIHandler describes the routes of a router. The document is generated on the first request, once every
+// route has been registered.
+func NewOpenAPIHandler(routes chi.Routes) *OpenAPIHandler {
+	return &OpenAPIHandler{
+		routes: routes,
+	}
+}
+
+// GetOpenAPI returns the OpenAPI document of the API
+func (h *OpenAPIHandler) GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	h.once.Do(func() {
+		h.document, h.err = openapi.Generate(h.routes, openapi.Config{
+			Info: openapi.Info{
+				Title:       docs.SwaggerInfo.Title,
+				Description: docs.SwaggerInfo.Description,
+				Version:     docs.SwaggerInfo.Version,
+			},
+			RequestBodies: requestBodies,
+			ErrorResponse: middleware.ErrorResponse{},
+			Authenticate:  middleware.Authenticate,
+		})
+	})
+	if h.err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to generate OpenAPI document", h.err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, h.document)
+}
@@ -1,8 +1,8 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
 
 	"leaderboard-service/middleware"
@@ -17,18 +17,30 @@ import (
 
 // CreateParticipantRequest represents the request payload for creating a participant
 type CreateParticipantRequest struct {
-	ExternalID string                 `json:"external_id,omitempty" example:"external-123"`
+	ExternalID string                 `json:"external_id,omitempty" validate:"omitempty,max=255" example:"external-123"` // Must be unique when set
 	Name       string                 `json:"name" validate:"required" example:"John Doe"`
 	Type       string                 `json:"type" validate:"required,oneof=individual team group" example:"individual" enums:"individual,team,group"`
 	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	UserID     *string                `json:"user_id,omitempty" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440008"`
+
+	DisplayName string `json:"display_name,omitempty" validate:"omitempty,max=100" example:"Johnny D"`
+	AvatarURL   string `json:"avatar_url,omitempty" validate:"omitempty,max=2048,http_url" example:"https://cdn.example.com/avatars/john.png"`
+	Country     string `json:"country,omitempty" validate:"omitempty,iso3166_1_alpha2" example:"US"` // Uppercase ISO 3166-1 alpha-2 code
 }
 
 // UpdateParticipantRequest represents the request payload for updating a participant
 type UpdateParticipantRequest struct {
-	ExternalID *string                 `json:"external_id,omitempty" example:"external-123"`
+	ExternalID *string                 `json:"external_id,omitempty" validate:"omitempty,max=255" example:"external-123"`
 	Name       *string                 `json:"name,omitempty" validate:"omitempty" example:"Jane Doe"`
 	Type       *string                 `json:"type,omitempty" validate:"omitempty,oneof=individual team group" example:"team" enums:"individual,team,group"`
 	Metadata   *map[string]interface{} `json:"metadata,omitempty"`
+	UserID     *string                 `json:"user_id,omitempty" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440008"` // Send an empty string to unlink the user
+	IsActive   *bool                   `json:"is_active,omitempty" example:"false"`                                                        // Inactive participants are left out of published standings
+
+	// Send an empty string to clear a profile field
+	DisplayName *string `json:"display_name,omitempty" validate:"omitempty,max=100" example:"Jane D"`
+	AvatarURL   *string `json:"avatar_url,omitempty" validate:"omitempty,max=2048,len=0|http_url" example:"https://cdn.example.com/avatars/jane.png"`
+	Country     *string `json:"country,omitempty" validate:"omitempty,len=0|iso3166_1_alpha2" example:"GB"`
 }
 
 // ParticipantResponse is used for Swagger documentation
@@ -38,8 +50,29 @@ type ParticipantResponse struct {
 	Name       string                 `json:"name" example:"John Doe"`
 	Type       string                 `json:"type" example:"individual"`
 	Metadata   map[string]interface{} `json:"metadata,omitempty"`
-	CreatedAt  time.Time              `json:"created_at" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt  time.Time              `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	UserID     *uuid.UUID             `json:"user_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440008"`
+	ParentID   *uuid.UUID             `json:"parent_id,omitempty" example:"550e8400-e29b-41d4-a716-44665544000d"`
+	IsActive   bool                   `json:"is_active" example:"true"`
+
+	AnonymizedAt *time.Time `json:"anonymized_at,omitempty" example:"2023-06-01T00:00:00Z"`
+	Tags         []string   `json:"tags,omitempty" example:"vip"`
+
+	DisplayName string `json:"display_name,omitempty" example:"Johnny D"`
+	AvatarURL   string `json:"avatar_url,omitempty" example:"https://cdn.example.com/avatars/john.png"`
+	Country     string `json:"country,omitempty" example:"US"`
+
+	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// ParticipantNameChangeResponse is used for Swagger documentation
+type ParticipantNameChangeResponse struct {
+	ID            uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-44665544000e"`
+	ParticipantID uuid.UUID  `json:"participant_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name          string     `json:"name" example:"John Doe"`
+	DisplayName   string     `json:"display_name,omitempty" example:"Johnny D"`
+	EffectiveFrom time.Time  `json:"effective_from" example:"2023-01-01T00:00:00Z"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty" example:"2023-06-01T00:00:00Z"`
 }
 
 type ParticipantHandler struct {
@@ -48,7 +81,8 @@ type ParticipantHandler struct {
 
 func NewParticipantHandler() *ParticipantHandler {
 	repo := repositories.NewParticipantRepository()
-	service := services.NewParticipantService(repo)
+	service := services.NewParticipantService(repo, repositories.NewParticipantNameChangeRepository(),
+		repositories.NewLeaderboardEntryRepository())
 	return &ParticipantHandler{
 		service: service,
 	}
@@ -65,14 +99,13 @@ func NewParticipantHandler() *ParticipantHandler {
 // @Success 201 {object} ParticipantResponse "Created participant"
 // @Failure 400 {object} middleware.ErrorResponse "Invalid request"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 409 {object} middleware.ErrorResponse "User already linked to another participant or external_id in use"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /participants [post]
 func (h *ParticipantHandler) CreateParticipant(w http.ResponseWriter, r *http.Request) {
 	var req CreateParticipantRequest
 
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -83,14 +116,37 @@ func (h *ParticipantHandler) CreateParticipant(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	var userID *uuid.UUID
+	if req.UserID != nil {
+		parsedID, err := uuid.Parse(*req.UserID)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid user ID format", err)
+			return
+		}
+		userID = &parsedID
+	}
+
 	participant, err := h.service.CreateParticipant(
+		r.Context(),
 		req.ExternalID,
 		req.Name,
 		req.Type,
 		req.Metadata,
+		userID,
+		req.DisplayName,
+		req.AvatarURL,
+		req.Country,
 	)
 
 	if err != nil {
+		if err.Error() == "user already linked to another participant" {
+			middleware.RespondWithError(w, http.StatusConflict, "User is already linked to another participant", err)
+			return
+		}
+		if err.Error() == "external_id already in use" {
+			middleware.RespondWithError(w, http.StatusConflict, "Another participant already uses this external_id", err)
+			return
+		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to create participant", err)
 		return
 	}
@@ -110,6 +166,7 @@ func (h *ParticipantHandler) CreateParticipant(w http.ResponseWriter, r *http.Re
 // @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /participants/{id} [get]
 func (h *ParticipantHandler) GetParticipant(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
@@ -119,27 +176,154 @@ func (h *ParticipantHandler) GetParticipant(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	participant, err := h.service.GetParticipant(participantID)
+	participant, err := h.service.GetParticipant(r.Context(), participantID)
+	if err != nil {
+		if err.Error() == "participant not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch participant", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, participant)
+}
+
+// GetParticipantHistory returns the names a participant has used
+// @Summary Get participant name history
+// @Description Get the names and display names a participant has used, oldest first, with the period each was in effect. Pass at for only the name in use at that time.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Participant ID"
+// @Param at query string false "Name in use at this time (RFC3339)"
+// @Success 200 {array} ParticipantNameChangeResponse "Name history"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/history [get]
+func (h *ParticipantHandler) GetParticipantHistory(w http.ResponseWriter, r *http.Request) {
+	participantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	var at *time.Time
+	if atParam := r.URL.Query().Get("at"); atParam != "" {
+		parsedAt, err := time.Parse(time.RFC3339, atParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid at format, use RFC3339", err)
+			return
+		}
+		at = &parsedAt
+	}
+
+	history, err := h.service.GetNameHistory(r.Context(), participantID, at)
+	if err != nil {
+		if err.Error() == "participant not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch participant history", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, history)
+}
+
+// GetParticipantByExternalID retrieves a participant by the caller's external ID
+// @Summary Get a participant by external ID
+// @Description Retrieve a participant by the external_id it was created with
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param external_id path string true "External ID"
+// @Success 200 {object} ParticipantResponse "Participant details"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/by-external-id/{external_id} [get]
+func (h *ParticipantHandler) GetParticipantByExternalID(w http.ResponseWriter, r *http.Request) {
+	participant, err := h.service.GetParticipantByExternalID(r.Context(), chi.URLParam(r, "external_id"))
 	if err != nil {
-		middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+		if err.Error() == "participant not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch participant", err)
 		return
 	}
 
 	middleware.RespondWithJSON(w, http.StatusOK, participant)
 }
 
+// resolveParticipantID returns the participant named by an ingestion payload, either by our ID or the caller's
+// external ID. It writes the error response and reports false when the participant cannot be resolved.
+func resolveParticipantID(w http.ResponseWriter, r *http.Request, service services.ParticipantService, participantID, externalID string) (uuid.UUID, bool) {
+	if participantID != "" {
+		id, err := uuid.Parse(participantID)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID format", err)
+			return uuid.Nil, false
+		}
+		return id, true
+	}
+
+	participant, err := service.GetParticipantByExternalID(r.Context(), externalID)
+	if err != nil {
+		if err.Error() == "participant not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "participant not found", err)
+			return uuid.Nil, false
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to resolve participant", err)
+		return uuid.Nil, false
+	}
+	return participant.ID, true
+}
+
 // ListParticipants returns all participants
 // @Summary List all participants
-// @Description Get a list of all participants
+// @Description Get a list of all participants, optionally only those that have every one of the given tags. Participants are listed oldest first unless a sort is given. Pass ids to fetch several participants by ID in one request; IDs that don't exist are left out.
 // @Tags participants
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param tag query []string false "Only participants with this tag; repeat or comma-separate to require several" collectionFormat(multi)
+// @Param sort query string false "Field to sort by" Enums(name, display_name, type, created_at, updated_at)
+// @Param order query string false "Sort direction, defaults to asc" Enums(asc, desc)
+// @Param include_deleted query bool false "Also list soft-deleted participants (admin only)"
+// @Param ids query []string false "Only the participants with these IDs, comma-separated or repeated (max 100)" collectionFormat(multi)
 // @Success 200 {array} ParticipantResponse "List of participants"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid sort parameters"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "include_deleted without the admin role"
 // @Router /participants [get]
 func (h *ParticipantHandler) ListParticipants(w http.ResponseWriter, r *http.Request) {
-	participants, err := h.service.ListParticipants()
+	var tags []string
+	for _, tagParam := range r.URL.Query()["tag"] {
+		tags = append(tags, strings.Split(tagParam, ",")...)
+	}
+
+	sort, ok := parseSort(w, r, repositories.ParticipantSortColumns)
+	if !ok {
+		return
+	}
+
+	includeDeleted, ok := parseIncludeDeleted(w, r)
+	if !ok {
+		return
+	}
+
+	ids, ok := parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	participants, err := h.service.ListParticipants(r.Context(), ids, tags, sort, includeDeleted)
 	if err != nil {
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch participants", err)
 		return
@@ -150,7 +334,7 @@ func (h *ParticipantHandler) ListParticipants(w http.ResponseWriter, r *http.Req
 
 // UpdateParticipant updates an existing participant
 // @Summary Update a participant
-// @Description Update an existing participant with the provided details
+// @Description Update an existing participant with the provided details. Set is_active to false to leave the participant out of published standings while keeping its entries; team boards re-rank on their next recompute.
 // @Tags participants
 // @Accept json
 // @Produce json
@@ -161,6 +345,7 @@ func (h *ParticipantHandler) ListParticipants(w http.ResponseWriter, r *http.Req
 // @Failure 400 {object} middleware.ErrorResponse "Invalid request"
 // @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
 // @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 409 {object} middleware.ErrorResponse "User already linked to another participant or external_id in use"
 // @Failure 500 {object} middleware.ErrorResponse "Server error"
 // @Router /participants/{id} [put]
 func (h *ParticipantHandler) UpdateParticipant(w http.ResponseWriter, r *http.Request) {
@@ -172,9 +357,7 @@ func (h *ParticipantHandler) UpdateParticipant(w http.ResponseWriter, r *http.Re
 	}
 
 	var req UpdateParticipantRequest
-	err = json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -192,12 +375,35 @@ func (h *ParticipantHandler) UpdateParticipant(w http.ResponseWriter, r *http.Re
 		metadataInterface = &metadataAsInterface
 	}
 
+	// An empty user_id unlinks the user
+	var userID *uuid.UUID
+	clearUser := false
+	if req.UserID != nil {
+		if *req.UserID == "" {
+			clearUser = true
+		} else {
+			parsedID, err := uuid.Parse(*req.UserID)
+			if err != nil {
+				middleware.RespondWithError(w, http.StatusBadRequest, "Invalid user ID format", err)
+				return
+			}
+			userID = &parsedID
+		}
+	}
+
 	updatedParticipant, err := h.service.UpdateParticipant(
+		r.Context(),
 		participantID,
 		req.ExternalID,
 		req.Name,
 		req.Type,
 		metadataInterface,
+		userID,
+		clearUser,
+		req.DisplayName,
+		req.AvatarURL,
+		req.Country,
+		req.IsActive,
 	)
 
 	if err != nil {
@@ -205,6 +411,14 @@ func (h *ParticipantHandler) UpdateParticipant(w http.ResponseWriter, r *http.Re
 			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
 			return
 		}
+		if err.Error() == "user already linked to another participant" {
+			middleware.RespondWithError(w, http.StatusConflict, "User is already linked to another participant", err)
+			return
+		}
+		if err.Error() == "external_id already in use" {
+			middleware.RespondWithError(w, http.StatusConflict, "Another participant already uses this external_id", err)
+			return
+		}
 		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to update participant", err)
 		return
 	}
@@ -234,7 +448,7 @@ func (h *ParticipantHandler) DeleteParticipant(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	err = h.service.DeleteParticipant(participantID)
+	err = h.service.DeleteParticipant(r.Context(), participantID)
 	if err != nil {
 		if err.Error() == "participant not found" {
 			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
@@ -246,3 +460,47 @@ func (h *ParticipantHandler) DeleteParticipant(w http.ResponseWriter, r *http.Re
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// RestoreParticipant undoes the soft delete of a participant
+// @Summary Restore a deleted participant
+// @Description Bring back a soft-deleted participant. Deleted participants can be found by listing with include_deleted=true. Admin only.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Participant ID"
+// @Success 200 {object} ParticipantResponse "Restored participant"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 409 {object} middleware.ErrorResponse "Not deleted or conflicts with live data"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/restore [post]
+func (h *ParticipantHandler) RestoreParticipant(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	restored, err := h.service.RestoreParticipant(r.Context(), id)
+	if err != nil {
+		switch err.Error() {
+		case "participant not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+		case "participant is not deleted":
+			middleware.RespondWithError(w, http.StatusConflict, "Participant is not deleted", err)
+		case "user already linked to another participant":
+			middleware.RespondWithError(w, http.StatusConflict, "User is already linked to another participant", err)
+		case "external_id already in use":
+			middleware.RespondWithError(w, http.StatusConflict, "Another participant already uses this external_id", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to restore participant", err)
+		}
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, restored)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// AnonymizeParticipantRequest represents the request payload for anonymizing a participant
+type AnonymizeParticipantRequest struct {
+	DeleteMetricValues bool   `json:"delete_metric_values" example:"true"` // Also permanently delete the participant's raw metric values
+	Reason             string `json:"reason,omitempty" validate:"max=500" example:"GDPR erasure request #1234"`
+}
+
+// ParticipantAnonymizationResponse is used for Swagger documentation
+type ParticipantAnonymizationResponse struct {
+	ID                  uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-44665544000f"`
+	ParticipantID       uuid.UUID `json:"participant_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Pseudonym           string    `json:"pseudonym" example:"Anonymous 3f2a9c1b"`
+	RequestedBy         string    `json:"requested_by" example:"550e8400-e29b-41d4-a716-446655440008"`
+	Reason              string    `json:"reason,omitempty" example:"GDPR erasure request #1234"`
+	MetricValuesDeleted int64     `json:"metric_values_deleted" example:"42"`
+	CreatedAt           time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+}
+
+type ParticipantAnonymizationHandler struct {
+	service services.ParticipantAnonymizationService
+}
+
+func NewParticipantAnonymizationHandler() *ParticipantAnonymizationHandler {
+	service := services.NewParticipantAnonymizationService(repositories.NewParticipantRepository(), repositories.NewUnitOfWork())
+	return &ParticipantAnonymizationHandler{
+		service: service,
+	}
+}
+
+// AnonymizeParticipant scrubs a participant's personal data
+// @Summary Anonymize a participant
+// @Description Irreversibly scrub a participant's personal data (name, external_id, metadata, profile fields, linked user and name history). Leaderboard entries and scores are kept under a random pseudonym. Set delete_metric_values to also permanently delete the participant's raw metric values. The operation is recorded in an audit record, which is returned.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Participant ID"
+// @Param anonymization body AnonymizeParticipantRequest true "Anonymization options"
+// @Success 200 {object} ParticipantAnonymizationResponse "Audit record of the anonymization"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Participant not found"
+// @Failure 409 {object} middleware.ErrorResponse "Participant already anonymized"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/anonymize [post]
+func (h *ParticipantAnonymizationHandler) AnonymizeParticipant(w http.ResponseWriter, r *http.Request) {
+	participantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	var req AnonymizeParticipantRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	anonymization, err := h.service.Anonymize(r.Context(), participantID, req.DeleteMetricValues, claims.Subject, req.Reason)
+	if err != nil {
+		switch err.Error() {
+		case "participant not found":
+			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+		case "participant already anonymized":
+			middleware.RespondWithError(w, http.StatusConflict, "Participant has already been anonymized", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to anonymize participant", err)
+		}
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, anonymization)
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// SetParticipantParentRequest represents the request payload for moving a participant under a group
+type SetParticipantParentRequest struct {
+	ParentID uuid.UUID `json:"parent_id" validate:"required" example:"550e8400-e29b-41d4-a716-44665544000d"`
+}
+
+// GroupStandingResponse is used for Swagger documentation
+type GroupStandingResponse struct {
+	Participant *ParticipantResponse `json:"participant"`
+	Rank        int                  `json:"rank" example:"1"`
+	Score       float64              `json:"score" example:"1250.5"`
+	EntryCount  int                  `json:"entry_count" example:"12"`
+}
+
+type ParticipantGroupHandler struct {
+	service services.ParticipantGroupService
+}
+
+func NewParticipantGroupHandler() *ParticipantGroupHandler {
+	service := services.NewParticipantGroupService(
+		repositories.NewParticipantRepository(),
+		repositories.NewLeaderboardRepository(),
+		repositories.NewLeaderboardEntryRepository(),
+	)
+	return &ParticipantGroupHandler{
+		service: service,
+	}
+}
+
+// ListParticipantChildren returns the participants below a group
+// @Summary List participant children
+// @Description Get the participants directly below a participant in the group hierarchy, or every descendant with recursive=true.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Participant ID"
+// @Param recursive query bool false "Include every descendant, not just direct children"
+// @Success 200 {array} ParticipantResponse "Child participants"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid participant ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Participant not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/children [get]
+func (h *ParticipantGroupHandler) ListParticipantChildren(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	children, err := h.service.ListChildren(r.Context(), id, r.URL.Query().Get("recursive") == "true")
+	if err != nil {
+		h.respondWithError(w, err, "Failed to fetch child participants")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, children)
+}
+
+// SetParticipantParent moves a participant under a group
+// @Summary Set participant parent
+// @Description Move a participant, and everything below it, under a group participant. The parent must have type "group" and cannot be one of the participant's descendants.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Participant ID"
+// @Param parent body SetParticipantParentRequest true "Parent group"
+// @Success 200 {object} ParticipantResponse "Updated participant"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request or hierarchy"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Participant or parent not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/parent [put]
+func (h *ParticipantGroupHandler) SetParticipantParent(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	var req SetParticipantParentRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	participant, err := h.service.SetParent(r.Context(), id, req.ParentID)
+	if err != nil {
+		h.respondWithError(w, err, "Failed to set participant parent")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, participant)
+}
+
+// ClearParticipantParent moves a participant to the top of the hierarchy
+// @Summary Clear participant parent
+// @Description Remove a participant from its group so it sits at the top level. Its own descendants move with it.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Participant ID"
+// @Success 200 {object} ParticipantResponse "Updated participant"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid participant ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Participant not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/parent [delete]
+func (h *ParticipantGroupHandler) ClearParticipantParent(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	participant, err := h.service.ClearParent(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err, "Failed to clear participant parent")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, participant)
+}
+
+// ListGroupStandings returns a leaderboard rolled up to a level of the group hierarchy, defaulting to level 1.
+// It serves GET /leaderboards/{id}/standings when level is given, see StandingsHandler.ListStandings.
+func (h *ParticipantGroupHandler) ListGroupStandings(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	level := 1
+	if levelParam := r.URL.Query().Get("level"); levelParam != "" {
+		level, err = strconv.Atoi(levelParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid level", err)
+			return
+		}
+	}
+
+	standings, err := h.service.RollUpStandings(r.Context(), leaderboardID, level)
+	if err != nil {
+		h.respondWithError(w, err, "Failed to fetch group standings")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, standings)
+}
+
+func (h *ParticipantGroupHandler) respondWithError(w http.ResponseWriter, err error, message string) {
+	switch err.Error() {
+	case "participant not found":
+		middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+	case "parent not found":
+		middleware.RespondWithError(w, http.StatusNotFound, "Parent not found", err)
+	case "leaderboard not found":
+		middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+	case "participant cannot be its own parent", "parent must be a group", "parent is a descendant of the participant",
+		"group hierarchy too deep", "level must be at least 1":
+		middleware.RespondWithError(w, http.StatusBadRequest, err.Error(), err)
+	default:
+		middleware.RespondWithError(w, http.StatusInternalServerError, message, err)
+	}
+}
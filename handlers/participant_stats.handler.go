@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ParticipantStatsResponse is used for Swagger documentation
+type ParticipantStatsResponse struct {
+	ParticipantID uuid.UUID             `json:"participant_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Metrics       []MetricStatsResponse `json:"metrics"`
+	BestRank      *int                  `json:"best_rank,omitempty" example:"1"`
+	CurrentRanks  []BoardRankResponse   `json:"current_ranks"`
+	Activity      ActivityStatsResponse `json:"activity"`
+}
+
+// MetricStatsResponse is used for Swagger documentation
+type MetricStatsResponse struct {
+	MetricID        uuid.UUID                `json:"metric_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	MetricName      string                   `json:"metric_name" example:"Calls Made"`
+	AggregationType string                   `json:"aggregation_type" example:"sum"`
+	ResetPeriod     string                   `json:"reset_period" example:"weekly"`
+	Lifetime        MetricAggregateResponse  `json:"lifetime"`
+	PeriodStart     *time.Time               `json:"period_start,omitempty" example:"2023-01-02T00:00:00Z"`
+	CurrentPeriod   *MetricAggregateResponse `json:"current_period,omitempty"`
+}
+
+// MetricAggregateResponse is used for Swagger documentation
+type MetricAggregateResponse struct {
+	Value   float64 `json:"value" example:"420"`
+	Count   int64   `json:"count" example:"12"`
+	Sum     float64 `json:"sum" example:"420"`
+	Min     float64 `json:"min" example:"10"`
+	Max     float64 `json:"max" example:"80"`
+	Average float64 `json:"average" example:"35"`
+	// Last value of a boolean or string metric
+	LastBoolValue   *bool      `json:"last_bool_value,omitempty" example:"true"`
+	LastStringValue *string    `json:"last_string_value,omitempty" example:"gold"`
+	LastRecordedAt  *time.Time `json:"last_recorded_at,omitempty" example:"2023-01-05T12:00:00Z"`
+}
+
+// BoardRankResponse is used for Swagger documentation
+type BoardRankResponse struct {
+	LeaderboardID   uuid.UUID `json:"leaderboard_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	LeaderboardName string    `json:"leaderboard_name" example:"Weekly Sales"`
+	Rank            int       `json:"rank" example:"3"`
+	Score           float64   `json:"score" example:"420"`
+	BestRank        *int      `json:"best_rank,omitempty" example:"1"`
+	LastUpdated     time.Time `json:"last_updated" example:"2023-01-05T12:00:00Z"`
+}
+
+// ActivityStatsResponse is used for Swagger documentation
+type ActivityStatsResponse struct {
+	MetricValueCount int64      `json:"metric_value_count" example:"250"`
+	RecentValueCount int64      `json:"recent_value_count" example:"40"`
+	RecentActiveDays int64      `json:"recent_active_days" example:"12"`
+	LeaderboardCount int        `json:"leaderboard_count" example:"3"`
+	FirstRecordedAt  *time.Time `json:"first_recorded_at,omitempty" example:"2022-06-01T09:00:00Z"`
+	LastRecordedAt   *time.Time `json:"last_recorded_at,omitempty" example:"2023-01-05T12:00:00Z"`
+}
+
+// ParticipantLeaderboardResponse is used for Swagger documentation
+type ParticipantLeaderboardResponse struct {
+	Leaderboard   *LeaderboardResponse `json:"leaderboard"`
+	Rank          int                  `json:"rank" example:"3"`
+	Score         float64              `json:"score" example:"420"`
+	RankedEntries int64                `json:"ranked_entries" example:"120"`
+	TopPercent    float64              `json:"top_percent" example:"2.5"`
+	Tier          string               `json:"tier" example:"platinum" enums:"diamond,platinum,gold,silver,bronze,none"`
+	Trend         string               `json:"trend" example:"up" enums:"up,down,steady,new"`
+	RankChange    int                  `json:"rank_change" example:"2"`
+	LastUpdated   time.Time            `json:"last_updated" example:"2023-01-05T12:00:00Z"`
+}
+
+type ParticipantStatsHandler struct {
+	service services.ParticipantStatsService
+}
+
+func NewParticipantStatsHandler() *ParticipantStatsHandler {
+	service := services.NewParticipantStatsService(
+		repositories.NewParticipantRepository(),
+		repositories.NewMetricRepository(),
+		repositories.NewMetricValueRepository(),
+		repositories.NewLeaderboardEntryRepository(),
+		repositories.NewLeaderboardRepository(),
+		repositories.NewEventRepository(),
+		newLeaderboardAccessService(),
+	)
+	return &ParticipantStatsHandler{
+		service: service,
+	}
+}
+
+// GetParticipantStats returns a summary of a participant's activity
+// @Summary Get participant statistics
+// @Description Summarise a participant: per-metric aggregates over its lifetime and the metric's current reset period (UTC, weeks start on Monday), its current rank on each leaderboard, the best rank it has held, and counts of recorded metric values overall and over the last 30 days. Leaderboards the caller cannot read are left out.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Participant ID"
+// @Success 200 {object} ParticipantStatsResponse "Participant statistics"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid participant ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Participant not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/stats [get]
+func (h *ParticipantStatsHandler) GetParticipantStats(w http.ResponseWriter, r *http.Request) {
+	participantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	stats, err := h.service.GetStats(r.Context(), participantID, middleware.GetViewerFromContext(r))
+	if err != nil {
+		if err.Error() == "participant not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch participant statistics", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, stats)
+}
+
+// ListParticipantLeaderboards returns a participant's standing on every leaderboard it appears in
+// @Summary List a participant's leaderboards
+// @Description Get every leaderboard the participant has an entry on, best rank first, with its rank, score, tier and trend. The tier buckets the rank by the share of ranked entries at or above it: diamond (top 1%), platinum (5%), gold (10%), silver (25%), bronze (50%) or none. The trend and rank_change come from the entry's most recent rank change. Leaderboards the caller cannot read are left out.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Participant ID"
+// @Success 200 {array} ParticipantLeaderboardResponse "Leaderboard standings"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid participant ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Participant not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/leaderboards [get]
+func (h *ParticipantStatsHandler) ListParticipantLeaderboards(w http.ResponseWriter, r *http.Request) {
+	participantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid participant ID", err)
+		return
+	}
+
+	standings, err := h.service.ListLeaderboards(r.Context(), participantID, middleware.GetViewerFromContext(r))
+	if err != nil {
+		if err.Error() == "participant not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch participant leaderboards", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, standings)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// BulkTagRequest represents the request payload for tagging or untagging participants in bulk
+type BulkTagRequest struct {
+	ParticipantIDs []uuid.UUID `json:"participant_ids" validate:"required,min=1,max=1000" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Tags           []string    `json:"tags" validate:"required,min=1,max=20,dive,required,max=50" example:"vip"` // Trimmed and lowercased
+}
+
+// BulkTagResponse is used for Swagger documentation
+type BulkTagResponse struct {
+	Count int64 `json:"count" example:"12"` // Tags added or removed
+}
+
+type ParticipantTagHandler struct {
+	service services.ParticipantTagService
+}
+
+func NewParticipantTagHandler() *ParticipantTagHandler {
+	repo := repositories.NewParticipantTagRepository()
+	participantRepo := repositories.NewParticipantRepository()
+	service := services.NewParticipantTagService(repo, participantRepo)
+	return &ParticipantTagHandler{
+		service: service,
+	}
+}
+
+// TagParticipants adds tags to participants in bulk
+// @Summary Tag participants
+// @Description Add every tag to every listed participant. Tags a participant already has are skipped.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tags body BulkTagRequest true "Participants and tags"
+// @Success 200 {object} BulkTagResponse "Number of tags added"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Participant not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/tags/add [post]
+func (h *ParticipantTagHandler) TagParticipants(w http.ResponseWriter, r *http.Request) {
+	h.bulkTag(w, r, h.service.TagParticipants, "Failed to tag participants")
+}
+
+// UntagParticipants removes tags from participants in bulk
+// @Summary Untag participants
+// @Description Remove every tag from every listed participant. Tags a participant does not have are ignored.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tags body BulkTagRequest true "Participants and tags"
+// @Success 200 {object} BulkTagResponse "Number of tags removed"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Participant not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/tags/remove [post]
+func (h *ParticipantTagHandler) UntagParticipants(w http.ResponseWriter, r *http.Request) {
+	h.bulkTag(w, r, h.service.UntagParticipants, "Failed to untag participants")
+}
+
+func (h *ParticipantTagHandler) bulkTag(w http.ResponseWriter, r *http.Request,
+	apply func(ctx context.Context, participantIDs []uuid.UUID, tags []string) (int64, error), message string) {
+
+	var req BulkTagRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	count, err := apply(r.Context(), req.ParticipantIDs, req.Tags)
+	if err != nil {
+		if err.Error() == "participant not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Participant not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, message, err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"count": count,
+	})
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"leaderboard-service/middleware"
+)
+
+const (
+	// DefaultMaxBodyBytes caps JSON request bodies when MAX_REQUEST_BODY_BYTES is not set
+	DefaultMaxBodyBytes = 1 << 20
+	// maxImportBodyBytes caps a CSV import body, which is allowed to be much larger than a JSON one
+	maxImportBodyBytes = 64 << 20
+)
+
+// MaxBodyBytes is the largest JSON request body a handler reads
+var MaxBodyBytes int64 = DefaultMaxBodyBytes
+
+// errTrailingData is returned when a JSON body holds more than one value
+var errTrailingData = errors.New("request body must hold a single JSON object")
+
+// decodeJSON decodes the request body into v. It responds with 413 and reports false when the body is larger than
+// MaxBodyBytes, and with 400 when it isn't a single JSON value or has a field v doesn't, so a misspelt field fails
+// instead of being ignored.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(v)
+	if err == nil && decoder.Decode(&struct{}{}) != io.EOF {
+		err = errTrailingData
+	}
+	if err != nil {
+		respondWithBodyError(w, err)
+		return false
+	}
+	return true
+}
+
+// respondWithBodyError responds with 413 when reading the body failed because it is too large, and with 400 otherwise
+func respondWithBodyError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		middleware.RespondWithError(w, http.StatusRequestEntityTooLarge, "Request body too large", err)
+		return
+	}
+	middleware.RespondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+}
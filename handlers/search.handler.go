@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+)
+
+// SearchResultResponse is used for Swagger documentation
+type SearchResultResponse struct {
+	Type string `json:"type" example:"leaderboard"`
+	ID   string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name string `json:"name" example:"Weekly Sales"`
+}
+
+type SearchHandler struct {
+	service services.SearchService
+}
+
+func NewSearchHandler() *SearchHandler {
+	repo := repositories.NewSearchRepository()
+	service := services.NewSearchService(repo)
+	return &SearchHandler{
+		service: service,
+	}
+}
+
+// Search finds leaderboards, participants and metrics by name
+// @Summary Search by name
+// @Description Search leaderboards, participants (by name or display name) and metrics whose name contains q, ignoring case. Exact matches come first, then names starting with q, then the rest by name.
+// @Tags search
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Text to search for"
+// @Param types query string false "Comma-separated list of types to search: leaderboard, participant, metric (default all)"
+// @Param page query int false "Page of results to return" default(1)
+// @Param per_page query int false "Number of results per page (max 100)" default(20)
+// @Success 200 {array} SearchResultResponse "Matching resources"
+// @Header 200 {integer} X-Total-Count "Number of matches across all pages"
+// @Header 200 {integer} X-Page "Page returned"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Insufficient scope"
+// @Router /search [get]
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Missing search query, q is required", nil)
+		return
+	}
+
+	var types []string
+	if typesParam := r.URL.Query().Get("types"); typesParam != "" {
+		for _, t := range strings.Split(typesParam, ",") {
+			t = strings.TrimSpace(t)
+			if !slices.Contains(repositories.SearchTypes, t) {
+				middleware.RespondWithError(w, http.StatusBadRequest, "Invalid search type: "+t+", must be one of "+strings.Join(repositories.SearchTypes, ", "), nil)
+				return
+			}
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+
+	page := 1
+	if pageParam := r.URL.Query().Get("page"); pageParam != "" {
+		parsedPage, err := strconv.Atoi(pageParam)
+		if err != nil || parsedPage < 1 {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid page, must be a positive integer", err)
+			return
+		}
+		page = parsedPage
+	}
+
+	perPage := 0
+	if perPageParam := r.URL.Query().Get("per_page"); perPageParam != "" {
+		parsedPerPage, err := strconv.Atoi(perPageParam)
+		if err != nil || parsedPerPage < 1 {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid per_page, must be a positive integer", err)
+			return
+		}
+		perPage = parsedPerPage
+	}
+
+	results, total, err := h.service.Search(r.Context(), query, types, page, perPage)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to search", err)
+		return
+	}
+
+	w.Header().Set(middleware.PageHeader, strconv.Itoa(page))
+	w.Header().Set(middleware.TotalCountHeader, strconv.FormatInt(total, 10))
+	middleware.RespondWithJSON(w, http.StatusOK, results)
+}
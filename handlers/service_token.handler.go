@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// CreateServiceTokenRequest represents the request payload for minting a service token
+type CreateServiceTokenRequest struct {
+	Name      string    `json:"name" validate:"required,max=255" example:"rankings-worker"`
+	Audience  []string  `json:"audience,omitempty" validate:"omitempty,dive,required,max=255" example:"leaderboard-service"` // Defaults to this service
+	Scopes    []string  `json:"scopes" validate:"required,min=1" example:"metrics:write"`
+	ExpiresAt time.Time `json:"expires_at" validate:"required" example:"2030-01-01T00:00:00Z"`
+}
+
+// ServiceTokenResponse is used for Swagger documentation
+type ServiceTokenResponse struct {
+	ID         uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-44665544000b"`
+	Name       string     `json:"name" example:"rankings-worker"`
+	Audience   []string   `json:"audience" example:"leaderboard-service"`
+	Scopes     []string   `json:"scopes" example:"metrics:write"`
+	ExpiresAt  time.Time  `json:"expires_at" example:"2030-01-01T00:00:00Z"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" example:"2024-01-01T00:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	CreatedBy  *uuid.UUID `json:"created_by,omitempty" example:"550e8400-e29b-41d4-a716-446655440008"`
+	CreatedAt  time.Time  `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt  time.Time  `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// CreatedServiceTokenResponse includes the signed token, which is only returned once
+type CreatedServiceTokenResponse struct {
+	ServiceToken ServiceTokenResponse `json:"service_token"`
+	Token        string               `json:"token" example:"eyJhbGciOiJIUzI1NiIsImtpZCI6ImRlZmF1bHQifQ..."`
+}
+
+type ServiceTokenHandler struct {
+	service services.ServiceTokenService
+}
+
+func NewServiceTokenHandler() *ServiceTokenHandler {
+	repo := repositories.NewServiceTokenRepository()
+	service := services.NewServiceTokenService(repo, middleware.GenerateServiceToken)
+	return &ServiceTokenHandler{
+		service: service,
+	}
+}
+
+// CreateServiceToken mints a long-lived machine token
+// @Summary Create a service token
+// @Description Mint a JWT for a machine client with an explicit audience, scopes and expiry. Include leaderboard-service in the audience for the token to be accepted by this API. The token is returned only in this response.
+// @Tags service-tokens
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param serviceToken body CreateServiceTokenRequest true "Service token data"
+// @Success 201 {object} CreatedServiceTokenResponse "Created service token"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /service-tokens [post]
+func (h *ServiceTokenHandler) CreateServiceToken(w http.ResponseWriter, r *http.Request) {
+	var req CreateServiceTokenRequest
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	// Callers cannot mint tokens more powerful than their own
+	claims, err := middleware.GetUserFromContext(r.Context())
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusUnauthorized, "Unauthorized access", err)
+		return
+	}
+	for _, scope := range req.Scopes {
+		if !slices.Contains(middleware.GetValidScopes(), scope) {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Unknown scope "+scope, nil)
+			return
+		}
+		if !claims.HasScope(scope) {
+			middleware.RespondWithError(w, http.StatusForbidden, "Cannot grant scope "+scope+" that your token does not have", nil)
+			return
+		}
+	}
+
+	audience := req.Audience
+	if len(audience) == 0 {
+		audience = []string{middleware.TokenIssuer}
+	}
+
+	serviceToken, signed, err := h.service.CreateServiceToken(r.Context(), req.Name, audience, req.Scopes, req.ExpiresAt, requestOwnerID(r))
+	if err != nil {
+		switch err.Error() {
+		case "expiry must be in the future":
+			middleware.RespondWithError(w, http.StatusBadRequest, "expires_at must be in the future", err)
+		case "expiry exceeds the maximum lifetime":
+			middleware.RespondWithError(w, http.StatusBadRequest, "expires_at may be at most two years away", err)
+		default:
+			middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to create service token", err)
+		}
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"service_token": serviceToken,
+		"token":         signed,
+	})
+}
+
+// ListServiceTokens returns the service token inventory
+// @Summary List service tokens
+// @Description Get every service token, including revoked and expired ones. Token values are never returned.
+// @Tags service-tokens
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} ServiceTokenResponse "List of service tokens"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /service-tokens [get]
+func (h *ServiceTokenHandler) ListServiceTokens(w http.ResponseWriter, r *http.Request) {
+	serviceTokens, err := h.service.ListServiceTokens(r.Context())
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch service tokens", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, serviceTokens)
+}
+
+// RevokeServiceToken revokes a service token
+// @Summary Revoke a service token
+// @Description Revoke a service token so it can no longer authenticate. The record stays in the inventory.
+// @Tags service-tokens
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Service token ID"
+// @Success 204 "No content"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /service-tokens/{id} [delete]
+func (h *ServiceTokenHandler) RevokeServiceToken(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid service token ID", err)
+		return
+	}
+
+	err = h.service.RevokeServiceToken(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, services.ErrServiceTokenNotFound) {
+			middleware.RespondWithError(w, http.StatusNotFound, "Service token not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to revoke service token", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
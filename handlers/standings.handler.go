@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/utils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// StandingResponse is used for Swagger documentation
+type StandingResponse struct {
+	EntryID       uuid.UUID                `json:"entry_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	ParticipantID uuid.UUID                `json:"participant_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Participant   *ParticipantResponse     `json:"participant"`
+	Rank          int                      `json:"rank" example:"3"`
+	Score         float64                  `json:"score" example:"420"`
+	TopPercent    float64                  `json:"top_percent" example:"2.5"`
+	Tier          string                   `json:"tier" example:"platinum" enums:"diamond,platinum,gold,silver,bronze,none"`
+	Trend         string                   `json:"trend" example:"up" enums:"up,down,steady,new"`
+	RankChange    int                      `json:"rank_change" example:"2"`
+	LastUpdated   time.Time                `json:"last_updated" example:"2023-01-05T12:00:00Z"`
+	Metrics       []StandingMetricResponse `json:"metrics"`
+}
+
+// CompactStandingResponse is used for Swagger documentation
+type CompactStandingResponse struct {
+	EntryID         uuid.UUID `json:"entry_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	ParticipantID   uuid.UUID `json:"participant_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ParticipantName string    `json:"participant_name" example:"Jane D."`
+	AvatarURL       string    `json:"avatar_url" example:"https://cdn.example.com/avatars/jane.png"`
+	Rank            int       `json:"rank" example:"3"`
+	Score           float64   `json:"score" example:"420"`
+	TopPercent      float64   `json:"top_percent" example:"2.5"`
+	Tier            string    `json:"tier" example:"platinum" enums:"diamond,platinum,gold,silver,bronze,none"`
+	LastUpdated     time.Time `json:"last_updated" example:"2023-01-05T12:00:00Z"`
+}
+
+// StandingMetricResponse is used for Swagger documentation
+type StandingMetricResponse struct {
+	MetricID        uuid.UUID               `json:"metric_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	MetricName      string                  `json:"metric_name" example:"Calls Made"`
+	AggregationType string                  `json:"aggregation_type" example:"sum"`
+	Weight          float64                 `json:"weight" example:"1.5"`
+	Aggregate       MetricAggregateResponse `json:"aggregate"`
+	WeightedValue   float64                 `json:"weighted_value" example:"630"`
+}
+
+type StandingsHandler struct {
+	service       services.StandingsService
+	exportService services.StandingsExportService
+	groupHandler  *ParticipantGroupHandler
+}
+
+func NewStandingsHandler() *StandingsHandler {
+	service := newStandingsService()
+	exportService := services.NewStandingsExportService(
+		repositories.NewLeaderboardRepository(),
+		service,
+		newLeaderboardSnapshotService(),
+	)
+	return &StandingsHandler{
+		service:       service,
+		exportService: exportService,
+		groupHandler:  NewParticipantGroupHandler(),
+	}
+}
+
+func newStandingsService() services.StandingsService {
+	service := services.NewStandingsService(
+		repositories.NewLeaderboardRepository(),
+		repositories.NewLeaderboardEntryRepository(),
+		repositories.NewMetricRepository(),
+		repositories.NewMetricValueRepository(),
+		repositories.NewEventRepository(),
+	)
+	return services.WithStandingsCache(service, repositories.NewLeaderboardRepository())
+}
+
+// ListStandings returns a leaderboard's entries with everything needed to display them
+// @Summary List leaderboard standings
+// @Description Get the leaderboard's entries best rank first, each with its participant, tier, trend and rank_change (as for GET /participants/{id}/leaderboards) and the participant's aggregated values for each of the board's metrics within the board's date range, lowest display_priority first. Inactive participants are left out. With level, the entries are rolled up to that level of the group hierarchy and each row has the participant, rank, score and entry_count instead: level 1 is the participants directly below the leaderboard's group, or the top-level participants when the leaderboard has no group, and entries above the requested level are left out. With compact=true, each row only has the entry and participant IDs, the participant's participant_name (its display name, or its name when it has none) and avatar_url, rank, score, top_percent, tier and last_updated, read from the entries alone without joining participants or aggregating metric values; level takes precedence over compact.
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Param level query int false "Hierarchy level to roll up to"
+// @Param compact query bool false "Return CompactStandingResponse rows for display only"
+// @Success 200 {array} StandingResponse "Standings, or CompactStandingResponse rows with compact=true"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Leaderboard not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/standings [get]
+func (h *StandingsHandler) ListStandings(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("level") {
+		h.groupHandler.ListGroupStandings(w, r)
+		return
+	}
+
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	if compactParam := r.URL.Query().Get("compact"); compactParam != "" {
+		compact, err := strconv.ParseBool(compactParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid compact, must be true or false", err)
+			return
+		}
+		if compact {
+			h.listCompactStandings(w, r, leaderboardID)
+			return
+		}
+	}
+
+	standings, err := h.service.ListStandings(r.Context(), leaderboardID)
+	if err != nil {
+		if err.Error() == "leaderboard not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch standings", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, standings)
+}
+
+func (h *StandingsHandler) listCompactStandings(w http.ResponseWriter, r *http.Request, leaderboardID uuid.UUID) {
+	standings, err := h.service.ListCompactStandings(r.Context(), leaderboardID)
+	if err != nil {
+		if err.Error() == "leaderboard not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch standings", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, standings)
+}
+
+// ExportStandings downloads a leaderboard's standings as a report
+// @Summary Export leaderboard standings
+// @Description Download the leaderboard's standings, or those of one of its snapshots, as CSV, JSON or an Excel workbook. Each row has the rank, participant ID, name and display name, score and, for each of the board's metrics, its value and weighted value. The file is sent as an attachment named after the leaderboard and the time the standings were taken. In CSV, text starting with =, +, -, @, tab or carriage return is prefixed with ' so spreadsheet programs do not run it as a formula.
+// @Tags leaderboards
+// @Produce text/csv
+// @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param id path string true "Leaderboard ID"
+// @Param format query string false "File format: csv, json or xlsx" default(csv)
+// @Param snapshot_id query string false "Snapshot to export instead of the live standings"
+// @Success 200 {object} services.StandingsExport "Standings export (JSON format)"
+// @Header 200 {string} Content-Disposition "attachment; filename=..."
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Leaderboard or snapshot not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /leaderboards/{id}/export [get]
+func (h *StandingsHandler) ExportStandings(w http.ResponseWriter, r *http.Request) {
+	leaderboardID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID", err)
+		return
+	}
+
+	format := services.ExportFormats[0]
+	if formatParam := r.URL.Query().Get("format"); formatParam != "" {
+		if !slices.Contains(services.ExportFormats, formatParam) {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid format: "+formatParam+", must be one of "+strings.Join(services.ExportFormats, ", "), nil)
+			return
+		}
+		format = formatParam
+	}
+
+	var snapshotID *uuid.UUID
+	if snapshotParam := r.URL.Query().Get("snapshot_id"); snapshotParam != "" {
+		parsed, err := uuid.Parse(snapshotParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid snapshot ID", err)
+			return
+		}
+		snapshotID = &parsed
+	}
+
+	export, err := h.exportService.ExportStandings(r.Context(), leaderboardID, snapshotID)
+	if err != nil {
+		respondWithSnapshotError(w, err, "Failed to export standings")
+		return
+	}
+
+	filename := "standings-" + export.LeaderboardID.String() + "-" + export.TakenAt.UTC().Format("20060102T150405Z") + "." + format
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+
+	switch format {
+	case services.ExportFormatJSON:
+		middleware.RespondWithJSON(w, http.StatusOK, export)
+	case services.ExportFormatXLSX:
+		w.Header().Set("Content-Type", utils.XLSXContentType)
+		w.WriteHeader(http.StatusOK)
+		if err := utils.WriteXLSX(w, export.LeaderboardName, exportTable(export, false)); err != nil {
+			log.Printf("export: failed to write standings of leaderboard %s: %v", leaderboardID, err)
+		}
+	default:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		writer := csv.NewWriter(w)
+		for _, row := range exportTable(export, true) {
+			record := make([]string, len(row))
+			for i, value := range row {
+				if number, ok := value.(float64); ok {
+					record[i] = strconv.FormatFloat(number, 'f', -1, 64)
+				} else {
+					record[i] = fmt.Sprint(value)
+				}
+			}
+			if err := writer.Write(record); err != nil {
+				log.Printf("export: failed to write standings of leaderboard %s: %v", leaderboardID, err)
+				return
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			log.Printf("export: failed to write standings of leaderboard %s: %v", leaderboardID, err)
+		}
+	}
+}
+
+// exportTable lays an export out as a header row followed by one row per standing. For CSV, text that spreadsheet
+// programs would run as a formula is escaped.
+func exportTable(export *services.StandingsExport, escapeFormulas bool) [][]interface{} {
+	text := func(value string) string {
+		if escapeFormulas && value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+			return "'" + value
+		}
+		return value
+	}
+
+	header := []interface{}{"rank", "participant_id", "participant_name", "display_name", "score"}
+	for _, name := range export.Metrics {
+		header = append(header, text(name), text(name+" (weighted)"))
+	}
+
+	table := make([][]interface{}, 0, len(export.Rows)+1)
+	table = append(table, header)
+	for _, row := range export.Rows {
+		values := []interface{}{row.Rank, row.ParticipantID.String(), text(row.ParticipantName), text(row.DisplayName), row.Score}
+		for _, metric := range row.Metrics {
+			values = append(values, metric.Value, metric.WeightedValue)
+		}
+		table = append(table, values)
+	}
+	return table
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// AddTeamMemberRequest represents the request payload for adding a participant to a team
+type AddTeamMemberRequest struct {
+	MemberID uuid.UUID  `json:"member_id" validate:"required" example:"550e8400-e29b-41d4-a716-446655440001"`
+	JoinedAt *time.Time `json:"joined_at,omitempty" example:"2023-01-01T00:00:00Z"` // Defaults to now
+}
+
+// TeamMemberResponse is used for Swagger documentation
+type TeamMemberResponse struct {
+	ID        uuid.UUID            `json:"id" example:"550e8400-e29b-41d4-a716-44665544000c"`
+	TeamID    uuid.UUID            `json:"team_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	MemberID  uuid.UUID            `json:"member_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	JoinedAt  time.Time            `json:"joined_at" example:"2023-01-01T00:00:00Z"`
+	LeftAt    *time.Time           `json:"left_at,omitempty" example:"2023-06-01T00:00:00Z"`
+	Member    *ParticipantResponse `json:"member,omitempty"`
+	CreatedAt time.Time            `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt time.Time            `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+type TeamMemberHandler struct {
+	service services.TeamMemberService
+}
+
+func NewTeamMemberHandler() *TeamMemberHandler {
+	repo := repositories.NewTeamMemberRepository()
+	participantRepo := repositories.NewParticipantRepository()
+	service := services.NewTeamMemberService(repo, participantRepo)
+	return &TeamMemberHandler{
+		service: service,
+	}
+}
+
+// ListTeamMembers returns a team's roster
+// @Summary List team members
+// @Description Get the members of a team participant. By default returns the current roster; pass at for the roster at a point in time, or history=true for every past and present membership.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Team participant ID"
+// @Param at query string false "Roster at this time (RFC3339), defaults to now"
+// @Param history query bool false "Return every membership, including former members"
+// @Success 200 {array} TeamMemberResponse "Team roster"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request or participant is not a team"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Team not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/members [get]
+func (h *TeamMemberHandler) ListTeamMembers(w http.ResponseWriter, r *http.Request) {
+	teamID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	var at *time.Time
+	if r.URL.Query().Get("history") != "true" {
+		now := time.Now().UTC()
+		at = &now
+		if atParam := r.URL.Query().Get("at"); atParam != "" {
+			parsedAt, err := time.Parse(time.RFC3339, atParam)
+			if err != nil {
+				middleware.RespondWithError(w, http.StatusBadRequest, "Invalid at format, use RFC3339", err)
+				return
+			}
+			at = &parsedAt
+		}
+	}
+
+	members, err := h.service.ListRoster(r.Context(), teamID, at)
+	if err != nil {
+		h.respondWithError(w, err, "Failed to fetch team members")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, members)
+}
+
+// AddTeamMember adds a participant to a team
+// @Summary Add a team member
+// @Description Add a participant to a team from joined_at, or from now. Teams cannot be members of other teams.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Team participant ID"
+// @Param member body AddTeamMemberRequest true "Member to add"
+// @Success 201 {object} TeamMemberResponse "Team membership"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Team or member not found"
+// @Failure 409 {object} middleware.ErrorResponse "Participant is already on the team"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/members [post]
+func (h *TeamMemberHandler) AddTeamMember(w http.ResponseWriter, r *http.Request) {
+	teamID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	var req AddTeamMemberRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	member, err := h.service.AddMember(r.Context(), teamID, req.MemberID, req.JoinedAt)
+	if err != nil {
+		h.respondWithError(w, err, "Failed to add team member")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusCreated, member)
+}
+
+// RemoveTeamMember ends a participant's membership of a team
+// @Summary Remove a team member
+// @Description End a member's current membership at left_at, or now. The membership is kept in the team's history.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Team participant ID"
+// @Param memberId path string true "Member participant ID"
+// @Param left_at query string false "When the member left (RFC3339), defaults to now"
+// @Success 200 {object} TeamMemberResponse "Ended team membership"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 403 {object} middleware.ErrorResponse "Forbidden"
+// @Failure 404 {object} middleware.ErrorResponse "Team not found or participant not on the team"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /participants/{id}/members/{memberId} [delete]
+func (h *TeamMemberHandler) RemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	teamID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid team ID", err)
+		return
+	}
+
+	memberID, err := uuid.Parse(chi.URLParam(r, "memberId"))
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid member ID", err)
+		return
+	}
+
+	var leftAt *time.Time
+	if leftAtParam := r.URL.Query().Get("left_at"); leftAtParam != "" {
+		parsedLeftAt, err := time.Parse(time.RFC3339, leftAtParam)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid left_at format, use RFC3339", err)
+			return
+		}
+		leftAt = &parsedLeftAt
+	}
+
+	member, err := h.service.RemoveMember(r.Context(), teamID, memberID, leftAt)
+	if err != nil {
+		h.respondWithError(w, err, "Failed to remove team member")
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, member)
+}
+
+func (h *TeamMemberHandler) respondWithError(w http.ResponseWriter, err error, message string) {
+	switch err.Error() {
+	case "team not found":
+		middleware.RespondWithError(w, http.StatusNotFound, "Team not found", err)
+	case "member not found":
+		middleware.RespondWithError(w, http.StatusNotFound, "Member not found", err)
+	case "member not on team":
+		middleware.RespondWithError(w, http.StatusNotFound, "Participant is not a current member of the team", err)
+	case "member already on team":
+		middleware.RespondWithError(w, http.StatusConflict, "Participant is already a member of the team", err)
+	case "participant is not a team", "team cannot be a member of itself", "teams cannot be members of a team",
+		"left_at must be after joined_at", "joined_at overlaps a previous membership":
+		middleware.RespondWithError(w, http.StatusBadRequest, err.Error(), err)
+	default:
+		middleware.RespondWithError(w, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"leaderboard-service/middleware"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+	"leaderboard-service/validation"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// CreateWebhookSubscriptionRequest represents the request payload for creating a webhook subscription
+type CreateWebhookSubscriptionRequest struct {
+	TargetURL     string   `json:"target_url" validate:"required,url" example:"https://example.com/hooks/leaderboards"`
+	Secret        string   `json:"secret" validate:"required,min=16" example:"a-long-random-shared-secret"`
+	EventTypes    []string `json:"event_types" validate:"required,min=1,dive,oneof=entry.created entry.updated entry.deleted entry.rank_changed" example:"entry.created,entry.rank_changed"`
+	LeaderboardID *string  `json:"leaderboard_id,omitempty" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	IsActive      *bool    `json:"is_active,omitempty" example:"true"`
+}
+
+// UpdateWebhookSubscriptionRequest represents the request payload for updating a webhook subscription
+type UpdateWebhookSubscriptionRequest struct {
+	TargetURL     *string   `json:"target_url,omitempty" validate:"omitempty,url" example:"https://example.com/hooks/v2"`
+	Secret        *string   `json:"secret,omitempty" validate:"omitempty,min=16" example:"a-rotated-shared-secret"`
+	EventTypes    *[]string `json:"event_types,omitempty" validate:"omitempty,min=1,dive,oneof=entry.created entry.updated entry.deleted entry.rank_changed" example:"entry.updated"`
+	LeaderboardID *string   `json:"leaderboard_id,omitempty" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"` // Send an empty string to remove the filter
+	IsActive      *bool     `json:"is_active,omitempty" example:"false"`
+}
+
+// WebhookSubscriptionResponse is used for Swagger documentation
+type WebhookSubscriptionResponse struct {
+	ID                 uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-446655440005"`
+	TargetURL          string     `json:"target_url" example:"https://example.com/hooks/leaderboards"`
+	EventTypes         []string   `json:"event_types" example:"entry.created,entry.rank_changed"`
+	LeaderboardID      *uuid.UUID `json:"leaderboard_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	IsActive           bool       `json:"is_active" example:"true"`
+	LastDeliveryAt     *time.Time `json:"last_delivery_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	LastDeliveryStatus int        `json:"last_delivery_status" example:"200"`
+	LastDeliveryError  string     `json:"last_delivery_error,omitempty" example:""`
+	CreatedAt          time.Time  `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt          time.Time  `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// WebhookTestResponse reports the outcome of a test delivery
+type WebhookTestResponse struct {
+	Delivered  bool   `json:"delivered" example:"true"`
+	StatusCode int    `json:"status_code" example:"200"`
+	Error      string `json:"error,omitempty" example:""`
+}
+
+type WebhookSubscriptionHandler struct {
+	service services.WebhookSubscriptionService
+}
+
+func NewWebhookSubscriptionHandler() *WebhookSubscriptionHandler {
+	subscriptionRepo := repositories.NewWebhookSubscriptionRepository()
+	leaderboardRepo := repositories.NewLeaderboardRepository()
+	dispatcher := services.NewWebhookDispatcher(subscriptionRepo)
+	service := services.NewWebhookSubscriptionService(subscriptionRepo, leaderboardRepo, dispatcher)
+
+	return &WebhookSubscriptionHandler{
+		service: service,
+	}
+}
+
+// CreateWebhookSubscription creates a new webhook subscription
+// @Summary Create a webhook subscription
+// @Description Register a target URL that will receive signed event deliveries
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param subscription body CreateWebhookSubscriptionRequest true "Webhook subscription data"
+// @Success 201 {object} WebhookSubscriptionResponse "Created webhook subscription"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Leaderboard not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /webhooks [post]
+func (h *WebhookSubscriptionHandler) CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	var req CreateWebhookSubscriptionRequest
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	var leaderboardID *uuid.UUID
+	if req.LeaderboardID != nil {
+		parsedID, err := uuid.Parse(*req.LeaderboardID)
+		if err != nil {
+			middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID format", err)
+			return
+		}
+		leaderboardID = &parsedID
+	}
+
+	// Subscriptions are active unless explicitly disabled
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	subscription, err := h.service.CreateSubscription(
+		r.Context(),
+		req.TargetURL,
+		req.Secret,
+		req.EventTypes,
+		leaderboardID,
+		isActive,
+	)
+
+	if err != nil {
+		if err.Error() == "leaderboard not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to create webhook subscription", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusCreated, subscription)
+}
+
+// GetWebhookSubscription retrieves a webhook subscription by ID
+// @Summary Get a webhook subscription by ID
+// @Description Retrieve a webhook subscription and its last delivery status
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook Subscription ID"
+// @Success 200 {object} WebhookSubscriptionResponse "Webhook subscription details"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /webhooks/{id} [get]
+func (h *WebhookSubscriptionHandler) GetWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	subscriptionID, err := uuid.Parse(idParam)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid webhook subscription ID", err)
+		return
+	}
+
+	subscription, err := h.service.GetSubscription(r.Context(), subscriptionID)
+	if err != nil {
+		if err.Error() == "webhook subscription not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Webhook subscription not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch webhook subscription", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, subscription)
+}
+
+// ListWebhookSubscriptions returns all webhook subscriptions
+// @Summary List webhook subscriptions
+// @Description Get a list of all webhook subscriptions
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} WebhookSubscriptionResponse "List of webhook subscriptions"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Router /webhooks [get]
+func (h *WebhookSubscriptionHandler) ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.service.ListSubscriptions(r.Context())
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to fetch webhook subscriptions", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, subscriptions)
+}
+
+// UpdateWebhookSubscription updates an existing webhook subscription
+// @Summary Update a webhook subscription
+// @Description Update the target, secret, event types, or leaderboard filter of a subscription
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook Subscription ID"
+// @Param subscription body UpdateWebhookSubscriptionRequest true "Updated webhook subscription data"
+// @Success 200 {object} WebhookSubscriptionResponse "Updated webhook subscription"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid request"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /webhooks/{id} [put]
+func (h *WebhookSubscriptionHandler) UpdateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	subscriptionID, err := uuid.Parse(idParam)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid webhook subscription ID", err)
+		return
+	}
+
+	var req UpdateWebhookSubscriptionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// Validate using validator package
+	if err := validation.Validate.Struct(req); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		middleware.RespondWithError(w, http.StatusBadRequest, "Validation error", validation.FormatValidationErrors(validationErrors))
+		return
+	}
+
+	// An empty leaderboard_id removes the filter
+	var leaderboardID *uuid.UUID
+	clearLeaderboard := false
+	if req.LeaderboardID != nil {
+		if *req.LeaderboardID == "" {
+			clearLeaderboard = true
+		} else {
+			parsedID, err := uuid.Parse(*req.LeaderboardID)
+			if err != nil {
+				middleware.RespondWithError(w, http.StatusBadRequest, "Invalid leaderboard ID format", err)
+				return
+			}
+			leaderboardID = &parsedID
+		}
+	}
+
+	updatedSubscription, err := h.service.UpdateSubscription(
+		r.Context(),
+		subscriptionID,
+		req.TargetURL,
+		req.Secret,
+		req.EventTypes,
+		leaderboardID,
+		clearLeaderboard,
+		req.IsActive,
+	)
+
+	if err != nil {
+		if err.Error() == "webhook subscription not found" || err.Error() == "leaderboard not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, err.Error(), err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to update webhook subscription", err)
+		return
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, updatedSubscription)
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription by ID
+// @Summary Delete a webhook subscription
+// @Description Delete a webhook subscription by its ID
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook Subscription ID"
+// @Success 204 "No content"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Failure 500 {object} middleware.ErrorResponse "Server error"
+// @Router /webhooks/{id} [delete]
+func (h *WebhookSubscriptionHandler) DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	subscriptionID, err := uuid.Parse(idParam)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid webhook subscription ID", err)
+		return
+	}
+
+	err = h.service.DeleteSubscription(r.Context(), subscriptionID)
+	if err != nil {
+		if err.Error() == "webhook subscription not found" {
+			middleware.RespondWithError(w, http.StatusNotFound, "Webhook subscription not found", err)
+			return
+		}
+		middleware.RespondWithError(w, http.StatusInternalServerError, "Failed to delete webhook subscription", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TestWebhookSubscription sends a test event to the subscription's target URL
+// @Summary Send a test delivery
+// @Description Synchronously deliver a signed webhook.test event and report the receiver's response
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook Subscription ID"
+// @Success 200 {object} WebhookTestResponse "Delivery outcome"
+// @Failure 400 {object} middleware.ErrorResponse "Invalid ID"
+// @Failure 401 {object} middleware.ErrorResponse "Unauthorized"
+// @Failure 404 {object} middleware.ErrorResponse "Not found"
+// @Router /webhooks/{id}/test [post]
+func (h *WebhookSubscriptionHandler) TestWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	subscriptionID, err := uuid.Parse(idParam)
+	if err != nil {
+		middleware.RespondWithError(w, http.StatusBadRequest, "Invalid webhook subscription ID", err)
+		return
+	}
+
+	statusCode, err := h.service.TestSubscription(r.Context(), subscriptionID)
+	if err != nil && err.Error() == "webhook subscription not found" {
+		middleware.RespondWithError(w, http.StatusNotFound, "Webhook subscription not found", err)
+		return
+	}
+
+	// Delivery failures are reported in the body, the test request itself succeeded
+	resp := WebhookTestResponse{
+		Delivered:  err == nil,
+		StatusCode: statusCode,
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+
+	middleware.RespondWithJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,72 @@
+// Package httpserver serves the HTTP API, terminating TLS itself when it is configured to.
+package httpserver
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"leaderboard-service/config"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// ListenAndServe serves handler on the configured address until either server fails. With TLS configured it is
+// served over HTTPS, from the certificate files or with certificates obtained from Let's Encrypt, and the redirect
+// port, when set, sends plain HTTP requests to it.
+func ListenAndServe(cfg config.ServerConfig, handler http.Handler) error {
+	server := &http.Server{Addr: cfg.Addr(), Handler: handler}
+	if !cfg.TLS.Enabled() {
+		return server.ListenAndServe()
+	}
+
+	redirect := redirectToHTTPS(cfg.Port)
+	if len(cfg.TLS.AutocertDomains) > 0 {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.TLS.AutocertDomains...),
+			Cache:      autocert.DirCache(cfg.TLS.AutocertCacheDir),
+			Email:      cfg.TLS.AutocertEmail,
+		}
+		server.TLSConfig = manager.TLSConfig()
+		// HTTP challenges are answered on the redirect port; without it Let's Encrypt uses the TLS port instead
+		redirect = manager.HTTPHandler(redirect)
+	} else {
+		// Loaded up front so a bad pair fails the start rather than every handshake
+		certificate, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if err != nil {
+			return fmt.Errorf("error loading TLS certificate: %w", err)
+		}
+		server.TLSConfig = &tls.Config{Certificates: []tls.Certificate{certificate}}
+	}
+	server.TLSConfig.MinVersion = tls.VersionTLS12
+
+	errs := make(chan error, 2)
+	if cfg.TLS.RedirectPort != 0 {
+		redirectAddr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.TLS.RedirectPort))
+		go func() {
+			errs <- http.ListenAndServe(redirectAddr, redirect)
+		}()
+	}
+	go func() {
+		errs <- server.ListenAndServeTLS("", "")
+	}()
+	return <-errs
+}
+
+// redirectToHTTPS redirects each request to the same URL over HTTPS on the given port. 308 keeps the method and
+// body, so API clients posting to the plain address are redirected too.
+func redirectToHTTPS(port int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			host = hostname
+		}
+		if port != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(port))
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
@@ -1,17 +1,11 @@
 package main
 
 import (
-	"fmt"
 	"log"
-	"net/http"
+	"os"
 
-	"leaderboard-service/db"
-	"leaderboard-service/db/migrations"
+	"leaderboard-service/cli"
 	_ "leaderboard-service/docs" // Import generated Swagger docs
-	"leaderboard-service/models"
-	"leaderboard-service/routes"
-
-	"github.com/joho/godotenv"
 )
 
 // @title Leaderboard Service API
@@ -24,42 +18,17 @@ import (
 // @license.name Apache 2.0
 // @license.url http://www.apache.org/licenses/LICENSE-2.0.html
 // @host localhost:8080
-// @BasePath /
+// @BasePath /api/v1
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
 // @description Type "Bearer" followed by a space and the JWT token.
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key for service-to-service calls.
 func main() {
-	// Load environment variables
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file")
-	}
-
-	db.InitDB()
-
-	// Run custom migrations first
-	err = migrations.RegisterMigrations(db.DB)
-	if err != nil {
-		log.Fatal("Error running custom migrations: ", err)
+	if err := cli.Run(os.Args[1:]); err != nil {
+		log.Fatal(err)
 	}
-
-	// Then run auto-migration
-	err = db.DB.AutoMigrate(
-		&models.Leaderboard{},
-		&models.LeaderboardMetric{},
-		&models.LeaderboardEntry{},
-		&models.Participant{},
-		&models.Metric{},
-		&models.MetricValue{},
-	)
-	if err != nil {
-		log.Fatal("Error migrating database: ", err)
-	}
-
-	r := router.Router()
-
-	fmt.Println("Server is running on port 8080")
-	fmt.Println("Swagger UI is available at http://localhost:8080/swagger/index.html")
-	log.Fatal(http.ListenAndServe("localhost:8080", r))
 }
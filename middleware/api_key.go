@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+)
+
+const (
+	// APIKeyHeader is the header backend services use to authenticate with an API key
+	APIKeyHeader = "X-API-Key"
+
+	// APIKeyContextKey is the key used to store the authenticated API key in the request context
+	APIKeyContextKey ContextKey = "api_key"
+)
+
+// Authenticate accepts either an X-API-Key header or a JWT bearer token.
+// API key requests get claims carrying the key's role and its default scopes so route checks work the same for both.
+func Authenticate(next http.Handler) http.Handler {
+	apiKeyService := services.NewAPIKeyService(repositories.NewAPIKeyRepository())
+	jwtAuth := JWTAuth(next)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawKey := r.Header.Get(APIKeyHeader)
+		if rawKey == "" {
+			jwtAuth.ServeHTTP(w, r)
+			return
+		}
+
+		apiKey, err := apiKeyService.Authenticate(r.Context(), rawKey)
+		if err != nil {
+			if err.Error() == "invalid api key" || err.Error() == "api key expired" {
+				RespondWithError(w, http.StatusUnauthorized, "Invalid API key", err)
+				return
+			}
+			RespondWithError(w, http.StatusInternalServerError, "Failed to verify API key", err)
+			return
+		}
+
+		if !apiKey.AllowsMethod(r.Method) {
+			RespondWithError(w, http.StatusForbidden, "API key scope does not allow this request", nil)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithAPIKey(r.Context(), apiKey)))
+	})
+}
+
+// WithAPIKey stores an authenticated API key in the context, along with claims carrying the key's role and its
+// default scopes
+func WithAPIKey(ctx context.Context, apiKey *models.APIKey) context.Context {
+	claims := &Claims{
+		UserID: apiKey.ID.String(),
+		Role:   apiKey.Role,
+		Scopes: DefaultScopesForRole(Role(apiKey.Role)),
+	}
+	claims.Subject = "api-key:" + apiKey.ID.String()
+
+	ctx = context.WithValue(ctx, UserContextKey, claims)
+	return context.WithValue(ctx, APIKeyContextKey, apiKey)
+}
+
+// GetAPIKeyFromContext returns the API key that authenticated the request, or nil for JWT requests
+func GetAPIKeyFromContext(ctx context.Context) *models.APIKey {
+	apiKey, _ := ctx.Value(APIKeyContextKey).(*models.APIKey)
+	return apiKey
+}
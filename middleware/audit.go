@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+
+	"leaderboard-service/services"
+)
+
+// GetAuditActor describes the caller authenticated in the context for the audit log. Changes made without an
+// authenticated caller, by background jobs and commands, are the system's.
+func GetAuditActor(ctx context.Context) services.AuditActor {
+	claims, err := GetUserFromContext(ctx)
+	if err != nil {
+		return services.AuditActor{Type: services.AuditActorSystem}
+	}
+
+	if apiKey := GetAPIKeyFromContext(ctx); apiKey != nil {
+		return services.AuditActor{Type: services.AuditActorAPIKey, ID: apiKey.ID.String()}
+	}
+	if claims.IsServiceToken() {
+		return services.AuditActor{Type: services.AuditActorService, ID: claims.Subject}
+	}
+	// External tokens may not carry a user ID, only their subject
+	actorID := claims.UserID
+	if actorID == "" {
+		actorID = claims.Subject
+	}
+	return services.AuditActor{Type: services.AuditActorUser, ID: actorID}
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"leaderboard-service/repositories"
+
+	"gorm.io/gorm"
+)
+
+// CacheMaxAge is how long shared caches may serve a public leaderboard without revalidating it. It is 0 unless
+// HTTP_CACHE_MAX_AGE is set, which lets caches store responses but revalidate them on every request.
+var CacheMaxAge time.Duration
+
+// LeaderboardCacheHeaders adds Cache-Control and Last-Modified to successful reads of the leaderboard resolved from
+// the request, and answers If-Modified-Since with 304 Not Modified before the handler runs when nothing on the board
+// has changed. Responses to authenticated requests depend on who asked, so only the client may keep them.
+// It must run after RequireLeaderboardRead so unreadable boards are rejected first.
+func LeaderboardCacheHeaders(resolve LeaderboardResolver) func(http.Handler) http.Handler {
+	return leaderboardCacheHeaders(resolve, "private, no-cache")
+}
+
+// PublicLeaderboardCacheHeaders is LeaderboardCacheHeaders for the unauthenticated public board routes, whose
+// responses are the same for every caller and may be kept by shared caches such as CDNs for CacheMaxAge
+func PublicLeaderboardCacheHeaders(resolve LeaderboardResolver) func(http.Handler) http.Handler {
+	cacheControl := "public, no-cache"
+	if maxAge := int(CacheMaxAge / time.Second); maxAge > 0 {
+		cacheControl = "public, max-age=" + strconv.Itoa(maxAge)
+	}
+	return leaderboardCacheHeaders(resolve, cacheControl)
+}
+
+func leaderboardCacheHeaders(resolve LeaderboardResolver, cacheControl string) func(http.Handler) http.Handler {
+	leaderboardRepo := repositories.NewLeaderboardRepository()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			leaderboardID, err := resolve(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			lastModified, err := leaderboardRepo.LastModified(r.Context(), leaderboardID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				RespondWithError(w, http.StatusInternalServerError, "Failed to check leaderboard changes", err)
+				return
+			}
+			lastModified = lastModified.UTC().Truncate(time.Second)
+
+			// If-None-Match takes precedence over If-Modified-Since when a client sends both
+			if r.Header.Get("If-None-Match") == "" && !lastModified.IsZero() {
+				if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !lastModified.After(since) {
+					w.Header().Set("Cache-Control", cacheControl)
+					w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+			}
+
+			next.ServeHTTP(&cacheHeaderWriter{
+				ResponseWriter: w,
+				cacheControl:   cacheControl,
+				lastModified:   lastModified,
+			}, r)
+		})
+	}
+}
+
+// cacheHeaderWriter adds the caching headers when the response turns out to be cacheable, so errors are never cached
+type cacheHeaderWriter struct {
+	http.ResponseWriter
+	cacheControl string
+	lastModified time.Time
+	wroteHeader  bool
+}
+
+func (w *cacheHeaderWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if code == http.StatusOK || code == http.StatusNotModified {
+			w.Header().Set("Cache-Control", w.cacheControl)
+			if !w.lastModified.IsZero() {
+				w.Header().Set("Last-Modified", w.lastModified.Format(http.TimeFormat))
+			}
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheHeaderWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *cacheHeaderWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
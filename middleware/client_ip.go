@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// PeerAddressContextKey is the key used to store the address of the connection a request came in on
+const PeerAddressContextKey ContextKey = "peer_address"
+
+// TrustedProxies are the proxies whose X-Forwarded-For and X-Real-IP headers ClientIP believes. It is empty unless
+// TRUSTED_PROXIES is set, so a client can't pick the address it is throttled under by sending those headers.
+var TrustedProxies []*net.IPNet
+
+// PeerAddress records the address of the connection a request came in on. It must run before chi's RealIP, which
+// replaces RemoteAddr with whatever the proxy headers claim.
+func PeerAddress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), PeerAddressContextKey, r.RemoteAddr)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ClientIP returns the caller's address without the port, for decisions a forged header must not sway such as login
+// throttling. It is the address of the connection, unless that is one of the TrustedProxies: then it is the last
+// address in X-Forwarded-For that isn't a trusted proxy too, as every proxy appends the address it was called from,
+// or else X-Real-IP.
+func ClientIP(r *http.Request) string {
+	peer, ok := r.Context().Value(PeerAddressContextKey).(string)
+	if !ok {
+		peer = r.RemoteAddr
+	}
+	ip := peer
+	if host, _, err := net.SplitHostPort(peer); err == nil {
+		ip = host
+	}
+	if !isTrustedProxy(ip) {
+		return ip
+	}
+
+	if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+		forwarded := strings.Split(forwardedFor, ",")
+		for i := len(forwarded) - 1; i >= 0; i-- {
+			address := strings.TrimSpace(forwarded[i])
+			if net.ParseIP(address) == nil {
+				break
+			}
+			if !isTrustedProxy(address) {
+				return address
+			}
+		}
+	}
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+	return ip
+}
+
+// isTrustedProxy reports whether the address is in one of the TrustedProxies
+func isTrustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range TrustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/segmentio/encoding/json"
+)
+
+// redactedValue replaces the values of credentials and personal data in debug logs
+const redactedValue = "[REDACTED]"
+
+// DebugLogConfig sets which requests are logged in full
+type DebugLogConfig struct {
+	SampleRate   float64 // Share of requests logged, from 0 to 1
+	MaxBodyBytes int     // Bodies larger than this are left out, as they can't be redacted once cut short
+}
+
+// debugLog is the debug logging configuration once EnableDebugLog is called
+var debugLog *DebugLogConfig
+
+// EnableDebugLog logs the full request and response of a sample of requests, with credentials and personal data
+// redacted. Call it before the router is built.
+func EnableDebugLog(config DebugLogConfig) {
+	debugLog = &config
+}
+
+// debugLogRedactedHeaders are headers whose values are never logged
+var debugLogRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-API-Key"}
+
+// debugLogRedactedNames are JSON fields, form fields and query parameters whose values are never logged, matched
+// ignoring case, underscores and dashes. Names containing "password" or "secret", or ending in "token" or "key",
+// are redacted too.
+var debugLogRedactedNames = map[string]bool{
+	"authorization":   true,
+	"email":           true,
+	"phone":           true,
+	"phonenumber":     true,
+	"metadata":        true,
+	"externalid":      true,
+	"username":        true,
+	"name":            true,
+	"displayname":     true,
+	"participantname": true,
+	"avatarurl":       true,
+	"country":         true,
+}
+
+// RedactDebugLogFields redacts more JSON fields, form fields and query parameters in debug logs, e.g.
+// RedactDebugLogFields("address"). It is meant to be called from init functions.
+func RedactDebugLogFields(names ...string) {
+	for _, name := range names {
+		debugLogRedactedNames[normalizeDebugLogName(name)] = true
+	}
+}
+
+func normalizeDebugLogName(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+}
+
+func isDebugLogRedacted(name string) bool {
+	name = normalizeDebugLogName(name)
+	return debugLogRedactedNames[name] ||
+		strings.Contains(name, "password") || strings.Contains(name, "secret") ||
+		strings.HasSuffix(name, "token") || strings.HasSuffix(name, "key")
+}
+
+// debugLogEntry is the line logged for a sampled request
+type debugLogEntry struct {
+	Method          string              `json:"method"`
+	Path            string              `json:"path"`
+	Query           string              `json:"query,omitempty"`
+	RequestHeaders  map[string][]string `json:"request_headers"`
+	RequestBody     interface{}         `json:"request_body,omitempty"`
+	Status          int                 `json:"status"`
+	ResponseHeaders map[string][]string `json:"response_headers"`
+	ResponseBody    interface{}         `json:"response_body,omitempty"`
+	DurationMs      int64               `json:"duration_ms"`
+}
+
+// DebugLogger logs the full request and response, headers and bodies, of a sample of requests once EnableDebugLog
+// is called, to debug the payloads of integrators. Other requests, and every request when it isn't enabled, pass
+// through untouched.
+func DebugLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if debugLog == nil || rand.Float64() >= debugLog.SampleRate {
+			next.ServeHTTP(w, r)
+			return
+		}
+		start := time.Now()
+
+		// Read up to one byte past the limit to tell whether the body fits, then hand the handler the whole body
+		var requestBody []byte
+		if r.Body != nil && r.Body != http.NoBody {
+			requestBody, _ = io.ReadAll(io.LimitReader(r.Body, int64(debugLog.MaxBodyBytes)+1))
+			r.Body = readCloser{io.MultiReader(bytes.NewReader(requestBody), r.Body), r.Body}
+		}
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		responseBody := &limitedBuffer{limit: debugLog.MaxBodyBytes}
+		ww.Tee(responseBody)
+
+		next.ServeHTTP(ww, r)
+
+		entry := debugLogEntry{
+			Method:          r.Method,
+			Path:            r.URL.Path,
+			Query:           redactQuery(r.URL.Query()),
+			RequestHeaders:  redactHeaders(r.Header),
+			RequestBody:     redactBody(requestBody, len(requestBody) > debugLog.MaxBodyBytes, r.Header.Get("Content-Type")),
+			Status:          ww.Status(),
+			ResponseHeaders: redactHeaders(w.Header()),
+			ResponseBody:    redactBody(responseBody.Bytes(), responseBody.truncated, w.Header().Get("Content-Type")),
+			DurationMs:      time.Since(start).Milliseconds(),
+		}
+		line, err := json.Marshal(entry)
+		if err != nil {
+			log.Printf("[%s] debug log: failed to encode: %v", middleware.GetReqID(r.Context()), err)
+			return
+		}
+		log.Printf("[%s] debug log: %s", middleware.GetReqID(r.Context()), line)
+	})
+}
+
+// readCloser reads the captured start of a body and then the rest of it, closing the original
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// limitedBuffer keeps the first limit bytes written to it and notes whether more were written
+type limitedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); len(p) > room {
+		b.truncated = true
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+func redactHeaders(header http.Header) map[string][]string {
+	redacted := make(map[string][]string, len(header))
+	for name, values := range header {
+		redacted[name] = values
+	}
+	for _, name := range debugLogRedactedHeaders {
+		if _, ok := redacted[http.CanonicalHeaderKey(name)]; ok {
+			redacted[http.CanonicalHeaderKey(name)] = []string{redactedValue}
+		}
+	}
+	return redacted
+}
+
+func redactQuery(query url.Values) string {
+	for name := range query {
+		if isDebugLogRedacted(name) {
+			query[name] = []string{redactedValue}
+		}
+	}
+	return query.Encode()
+}
+
+// redactBody returns a JSON body with sensitive fields redacted, a form body as redacted fields, or a note in place
+// of bodies it can't redact: those cut short and those of other content types
+func redactBody(body []byte, truncated bool, contentType string) interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "" {
+		mediaType = "untyped"
+	}
+	if truncated {
+		return fmt.Sprintf("[%s body over %d bytes not logged]", mediaType, debugLog.MaxBodyBytes)
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var payload interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return fmt.Sprintf("[invalid JSON body of %d bytes not logged]", len(body))
+		}
+		return redactJSON(payload)
+	case mediaType == "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return fmt.Sprintf("[invalid form body of %d bytes not logged]", len(body))
+		}
+		return redactQuery(form)
+	default:
+		return fmt.Sprintf("[%s body of %d bytes not logged]", mediaType, len(body))
+	}
+}
+
+func redactJSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for name, field := range value {
+			if isDebugLogRedacted(name) {
+				value[name] = redactedValue
+			} else {
+				value[name] = redactJSON(field)
+			}
+		}
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactJSON(item)
+		}
+		return value
+	default:
+		return value
+	}
+}
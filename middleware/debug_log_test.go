@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsDebugLogRedacted(t *testing.T) {
+	testCases := []struct {
+		name             string
+		field            string
+		expectedRedacted bool
+	}{
+		{name: "Password", field: "password", expectedRedacted: true},
+		{name: "Password with a prefix", field: "new_password", expectedRedacted: true},
+		{name: "Secret", field: "client_secret", expectedRedacted: true},
+		{name: "Token", field: "access_token", expectedRedacted: true},
+		{name: "Key in kebab case", field: "api-key", expectedRedacted: true},
+		{name: "Email in another case", field: "Email", expectedRedacted: true},
+		{name: "External ID in snake case", field: "external_id", expectedRedacted: true},
+		{name: "Name", field: "name", expectedRedacted: true},
+		{name: "Display name", field: "display_name", expectedRedacted: true},
+		{name: "Participant name in Go case", field: "ParticipantName", expectedRedacted: true},
+		{name: "Avatar URL in camel case", field: "avatarUrl", expectedRedacted: true},
+		{name: "Country", field: "country", expectedRedacted: true},
+		{name: "Score", field: "score", expectedRedacted: false},
+		{name: "Participant ID", field: "participant_id", expectedRedacted: false},
+		{name: "Token count", field: "tokens_used", expectedRedacted: false},
+		{name: "Key in the middle of the name", field: "keyword", expectedRedacted: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if redacted := isDebugLogRedacted(tc.field); redacted != tc.expectedRedacted {
+				t.Errorf("Expected redacted to be %v for %q but got %v", tc.expectedRedacted, tc.field, redacted)
+			}
+		})
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+	}{
+		{
+			name:     "Flat object",
+			input:    map[string]interface{}{"username": "ada", "password": "hunter2", "score": 42.0},
+			expected: map[string]interface{}{"username": redactedValue, "password": redactedValue, "score": 42.0},
+		},
+		{
+			name: "Nested object",
+			input: map[string]interface{}{
+				"participant": map[string]interface{}{"ID": "p-1", "Name": "Ada", "Country": "GB"},
+			},
+			expected: map[string]interface{}{
+				"participant": map[string]interface{}{"ID": "p-1", "Name": redactedValue, "Country": redactedValue},
+			},
+		},
+		{
+			name: "Array of objects",
+			input: []interface{}{
+				map[string]interface{}{"rank": 1.0, "ParticipantName": "Ada", "AvatarURL": "https://example.com/ada.png"},
+				map[string]interface{}{"rank": 2.0, "ParticipantName": "Grace"},
+			},
+			expected: []interface{}{
+				map[string]interface{}{"rank": 1.0, "ParticipantName": redactedValue, "AvatarURL": redactedValue},
+				map[string]interface{}{"rank": 2.0, "ParticipantName": redactedValue},
+			},
+		},
+		{
+			name:     "Redacted object replaced as a whole",
+			input:    map[string]interface{}{"metadata": map[string]interface{}{"team": "blue"}},
+			expected: map[string]interface{}{"metadata": redactedValue},
+		},
+		{
+			name:     "Scalar",
+			input:    "plain",
+			expected: "plain",
+		},
+		{
+			name:     "Null",
+			input:    nil,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if redacted := redactJSON(tc.input); !reflect.DeepEqual(redacted, tc.expected) {
+				t.Errorf("Expected %v but got %v", tc.expected, redacted)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/segmentio/encoding/json"
+)
+
+const (
+	// EnvelopeHeader opts a request into enveloped list responses
+	EnvelopeHeader = "X-Response-Envelope"
+	// PageHeader carries the page of a paginated list response, and its meta.page when enveloped
+	PageHeader = "X-Page"
+	// TotalCountHeader carries the number of items a list holds across all its pages, and its meta.total when enveloped
+	TotalCountHeader = "X-Total-Count"
+	// NextCursorHeader carries the cursor of the next page of a cursor-paginated list, and its meta.next_cursor when
+	// enveloped
+	NextCursorHeader = "X-Next-Cursor"
+)
+
+// ListEnvelope wraps a list response so metadata can be added without changing the shape of the list itself
+type ListEnvelope struct {
+	Data json.RawMessage `json:"data"`
+	Meta ListMeta        `json:"meta"`
+}
+
+// ListMeta describes the list held in a ListEnvelope
+type ListMeta struct {
+	Total int `json:"total" example:"25"` // Number of items in the list across all pages
+	Page  int `json:"page" example:"1"`   // Page of a paginated list, 1 for lists that are not paginated
+	// Cursor of the next page of a cursor-paginated list, left out on its last page and for other lists
+	NextCursor string `json:"next_cursor,omitempty" example:"bXY6dGltZXN0YW1w"`
+}
+
+// ResponseEnvelope wraps successful JSON array responses in a ListEnvelope, as { "data": [...], "meta": { ... } },
+// when the request sends X-Response-Envelope: true. Other requests, and responses that are not arrays, are passed
+// through untouched so existing clients keep getting bare lists.
+func ResponseEnvelope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", EnvelopeHeader)
+		if !strings.EqualFold(r.Header.Get(EnvelopeHeader), "true") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		bw := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(bw, r)
+
+		body := bw.body.Bytes()
+		if bw.status >= 200 && bw.status < 300 && strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			page, err := strconv.Atoi(w.Header().Get(PageHeader))
+			if err != nil || page < 1 {
+				page = 1
+			}
+			total, err := strconv.Atoi(w.Header().Get(TotalCountHeader))
+			if err != nil {
+				total = -1
+			}
+			if wrapped, err := wrapList(body, page, total, w.Header().Get(NextCursorHeader)); err == nil {
+				body = wrapped
+			}
+		}
+
+		w.WriteHeader(bw.status)
+		w.Write(body)
+	})
+}
+
+// wrapList puts a JSON array into a ListEnvelope, counting its items when the total is not known (negative).
+// Anything that is not an array is returned as it is.
+func wrapList(body []byte, page, total int, nextCursor string) ([]byte, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		return body, nil
+	}
+
+	if total < 0 {
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, err
+		}
+		total = len(items)
+	}
+
+	return json.Marshal(ListEnvelope{
+		Data: trimmed,
+		Meta: ListMeta{Total: total, Page: page, NextCursor: nextCursor},
+	})
+}
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Stable, machine-readable error codes returned in ErrorResponse.Code. Clients should branch on the code rather
+// than the message, which is written for people and may change. Not-found errors for a specific resource use
+// <RESOURCE>_NOT_FOUND, e.g. LEADERBOARD_NOT_FOUND or METRIC_VALUE_NOT_FOUND.
+const (
+	CodeValidationFailed   = "VALIDATION_FAILED"
+	CodeInvalidPayload     = "INVALID_PAYLOAD"
+	CodePayloadTooLarge    = "PAYLOAD_TOO_LARGE"
+	CodeInvalidID          = "INVALID_ID"
+	CodeInvalidQuery       = "INVALID_QUERY"
+	CodeBadRequest         = "BAD_REQUEST"
+	CodeUnauthorized       = "UNAUTHORIZED"
+	CodeInvalidCredentials = "INVALID_CREDENTIALS"
+	CodeInvalidAPIKey      = "INVALID_API_KEY"
+	CodeForbidden          = "FORBIDDEN"
+	CodeInsufficientScope  = "INSUFFICIENT_SCOPE"
+	CodeNotFound           = "NOT_FOUND"
+	CodeUserAlreadyLinked  = "USER_ALREADY_LINKED"
+	CodeExternalIDInUse    = "EXTERNAL_ID_IN_USE"
+	CodeUsernameTaken      = "USERNAME_TAKEN"
+	CodeEmailTaken         = "EMAIL_TAKEN"
+	CodeAlreadyTeamMember  = "ALREADY_TEAM_MEMBER"
+	CodeAlreadyAnonymized  = "ALREADY_ANONYMIZED"
+	CodeNotDeleted         = "NOT_DELETED"
+	CodeEntryExists        = "ENTRY_EXISTS"
+	CodeMetricAttached     = "METRIC_ALREADY_ATTACHED"
+	CodeVersionConflict    = "VERSION_CONFLICT"
+	CodeEndBeforeStart     = "END_BEFORE_START"
+	CodeEmptyDateRange     = "EMPTY_DATE_RANGE"
+	CodeEndDateInPast      = "END_DATE_IN_PAST"
+	CodeTypeMismatch       = "PARTICIPANT_TYPE_MISMATCH"
+	CodeMaxEntries         = "MAX_ENTRIES_EXCEEDED"
+	CodeLeaderboardEnded   = "LEADERBOARD_ENDED"
+	CodeNotEmpty           = "LEADERBOARD_NOT_EMPTY"
+	CodeConflict           = "CONFLICT"
+	CodeTooManyAttempts    = "TOO_MANY_ATTEMPTS"
+	CodeQueryTimeout       = "QUERY_TIMEOUT"
+	CodeMaintenanceMode    = "MAINTENANCE_MODE"
+	CodeInternalError      = "INTERNAL_ERROR"
+)
+
+// queryTimeoutMessage replaces the message of server errors caused by a request running out of time
+const queryTimeoutMessage = "The request took too long and was cancelled"
+
+// messageCodes pins the code of responses whose message alone identifies the error
+var messageCodes = map[string]string{
+	"Validation error":                                    CodeValidationFailed,
+	"Invalid request payload":                             CodeInvalidPayload,
+	"Request body too large":                              CodePayloadTooLarge,
+	"Invalid query parameters":                            CodeInvalidQuery,
+	"Invalid username or password":                        CodeInvalidCredentials,
+	"Invalid API key":                                     CodeInvalidAPIKey,
+	"User is already linked to another participant":       CodeUserAlreadyLinked,
+	"Another participant already uses this external_id":   CodeExternalIDInUse,
+	"Username already taken":                              CodeUsernameTaken,
+	"Email already registered":                            CodeEmailTaken,
+	"Participant is already a member of the team":         CodeAlreadyTeamMember,
+	"Participant has already been anonymized":             CodeAlreadyAnonymized,
+	"Participant already has an entry on the leaderboard": CodeEntryExists,
+	"Metric is already attached to the leaderboard":       CodeMetricAttached,
+	"Leaderboard was updated by another request":          CodeVersionConflict,
+	"Leaderboard entry was updated by another request":    CodeVersionConflict,
+	"Leaderboard has reached its max_entries":             CodeMaxEntries,
+	"Leaderboard has ended":                               CodeLeaderboardEnded,
+	"Leaderboard has entries, delete it with force=true":  CodeNotEmpty,
+	"end_date is before start_date":                       CodeEndBeforeStart,
+	"start_date and end_date are the same":                CodeEmptyDateRange,
+	"end_date is in the past":                             CodeEndDateInPast,
+	"participant type does not match the leaderboard":     CodeTypeMismatch,
+	queryTimeoutMessage:                                   CodeQueryTimeout,
+	maintenanceModeMessage:                                CodeMaintenanceMode,
+}
+
+// statusCodes is the fallback code for each status when nothing more specific applies
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          CodeBadRequest,
+	http.StatusUnauthorized:        CodeUnauthorized,
+	http.StatusForbidden:           CodeForbidden,
+	http.StatusNotFound:            CodeNotFound,
+	http.StatusConflict:            CodeConflict,
+	http.StatusTooManyRequests:     CodeTooManyAttempts,
+	http.StatusInternalServerError: CodeInternalError,
+}
+
+// errorCode picks the code for an error response from its status and message
+func errorCode(status int, message string) string {
+	if code, ok := messageCodes[message]; ok {
+		return code
+	}
+
+	switch {
+	case status == http.StatusForbidden && strings.HasPrefix(message, "Insufficient scope"):
+		return CodeInsufficientScope
+	case status == http.StatusBadRequest && strings.HasPrefix(message, "Invalid ") &&
+		(strings.HasSuffix(message, " ID") || strings.HasSuffix(message, " ID format")):
+		return CodeInvalidID
+	case status == http.StatusConflict && strings.HasSuffix(message, " is not deleted"):
+		return CodeNotDeleted
+	case status == http.StatusNotFound && strings.HasSuffix(strings.ToLower(message), " not found"):
+		// "Leaderboard entry not found" becomes LEADERBOARD_ENTRY_NOT_FOUND
+		resource := strings.TrimSuffix(strings.ToLower(message), " not found")
+		if resource != "resource" {
+			return strings.ToUpper(strings.ReplaceAll(resource, " ", "_")) + "_NOT_FOUND"
+		}
+	}
+
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/segmentio/encoding/json"
+)
+
+// SparseFields trims successful JSON responses to GET requests down to the fields listed in ?fields=, so clients
+// can ask for only what they render (e.g. ?fields=id,name,score). Fields are matched against the top-level keys of
+// the response object, or of each object in a response array, ignoring case and underscores, so participant_id
+// matches both ParticipantID and participant_id. Requests without the parameter are passed through untouched.
+func SparseFields(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields := parseFields(r.URL.Query().Get("fields"))
+		if r.Method != http.MethodGet || len(fields) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		bw := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(bw, r)
+
+		body := bw.body.Bytes()
+		if bw.status >= 200 && bw.status < 300 && strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			if filtered, err := filterFields(body, fields); err == nil {
+				body = filtered
+			}
+		}
+
+		w.WriteHeader(bw.status)
+		w.Write(body)
+	})
+}
+
+// bufferedResponseWriter holds the response back so it can be filtered before it is sent
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// parseFields returns the normalized names from a comma-separated fields parameter
+func parseFields(param string) map[string]bool {
+	fields := make(map[string]bool)
+	for _, field := range strings.Split(param, ",") {
+		if field = normalizeField(field); field != "" {
+			fields[field] = true
+		}
+	}
+	return fields
+}
+
+func normalizeField(field string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(field), "_", ""))
+}
+
+// filterFields keeps only the requested keys of a JSON object, or of each object in a JSON array.
+// Values are copied verbatim; anything that is not an object is left as it is.
+func filterFields(body []byte, fields map[string]bool) ([]byte, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return body, nil
+	}
+
+	switch trimmed[0] {
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, err
+		}
+		filtered := make([]json.RawMessage, len(items))
+		for i, item := range items {
+			filteredItem, err := filterFields(item, fields)
+			if err != nil {
+				return nil, err
+			}
+			filtered[i] = filteredItem
+		}
+		return json.Marshal(filtered)
+	case '{':
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &object); err != nil {
+			return nil, err
+		}
+		for key := range object {
+			if !fields[normalizeField(key)] {
+				delete(object, key)
+			}
+		}
+		return json.Marshal(object)
+	default:
+		return body, nil
+	}
+}
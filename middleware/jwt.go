@@ -5,20 +5,43 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"leaderboard-service/models"
+	"leaderboard-service/services"
+
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// TokenIssuer is the iss claim of tokens issued by this service. Service tokens must include it in their audience to call this API.
+	TokenIssuer = "leaderboard-service"
+	// ServiceTokenType marks machine tokens minted by an admin, as opposed to user sessions
+	ServiceTokenType = "service"
+	// DefaultTokenExpiration is how long issued tokens are valid unless JWT_EXPIRATION_HOURS is set
+	DefaultTokenExpiration = 24 * time.Hour
 )
 
+// TokenExpiration is how long the tokens GenerateToken issues are valid
+var TokenExpiration = DefaultTokenExpiration
+
 // Define custom claims structure
 type Claims struct {
-	UserID string `json:"user_id"`
-	Role   string `json:"role,omitempty"`
+	UserID    string   `json:"user_id"`
+	Role      string   `json:"role,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	TokenType string   `json:"token_type,omitempty"` // Empty for user sessions
 	jwt.RegisteredClaims
 }
 
+// IsServiceToken reports whether the claims belong to a service token rather than a user session
+func (c *Claims) IsServiceToken() bool {
+	return c.TokenType == ServiceTokenType
+}
+
 // Define error constants
 var (
 	ErrTokenMissing      = errors.New("token is missing")
@@ -53,7 +76,7 @@ func JWTAuth(next http.Handler) http.Handler {
 		}
 
 		// Parse and validate the token
-		claims, err := validateToken(tokenString)
+		claims, err := validateToken(r.Context(), tokenString)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
@@ -67,6 +90,16 @@ func JWTAuth(next http.Handler) http.Handler {
 	})
 }
 
+// ValidateBearerToken extracts the JWT from an Authorization header value and validates it.
+// It lets transports other than HTTP, such as the gRPC server, authenticate the same tokens.
+func ValidateBearerToken(ctx context.Context, authHeader string) (*Claims, error) {
+	tokenString := extractTokenFromHeader(authHeader)
+	if tokenString == "" {
+		return nil, fmt.Errorf("%w: invalid authorization header format", ErrTokenInvalid)
+	}
+	return validateToken(ctx, tokenString)
+}
+
 // extractTokenFromHeader extracts the JWT token from various header formats
 func extractTokenFromHeader(authHeader string) string {
 	// Check standard Bearer format first
@@ -93,23 +126,38 @@ func extractTokenFromHeader(authHeader string) string {
 }
 
 // validateToken parses and validates the JWT token
-func validateToken(tokenString string) (*Claims, error) {
-	// Get JWT secret from environment
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		return nil, errors.New("JWT_SECRET environment variable not set")
+func validateToken(ctx context.Context, tokenString string) (*Claims, error) {
+	// Tokens from the external identity provider are verified against its published keys
+	if oidcProvider != nil {
+		unverified := jwt.MapClaims{}
+		if _, _, err := jwt.NewParser().ParseUnverified(tokenString, unverified); err == nil {
+			if issuer, _ := unverified.GetIssuer(); oidcProvider.Issues(issuer) {
+				return oidcProvider.Validate(tokenString)
+			}
+		}
+	}
+
+	keys, err := currentSigningKeys()
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse the token
+	// Parse the token, picking the verification key from its kid header so rotated keys keep working
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&Claims{},
 		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			key, ok := keys.Lookup(kid)
+			if !ok {
+				return nil, fmt.Errorf("unknown signing key %q", kid)
+			}
+
 			// Validate the signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			if token.Method.Alg() != key.Method.Alg() {
 				return nil, fmt.Errorf("%w: %v", ErrInvalidSignMethod, token.Header["alg"])
 			}
-			return []byte(secretKey), nil
+			return key.VerifyKey, nil
 		},
 	)
 
@@ -122,44 +170,50 @@ func validateToken(tokenString string) (*Claims, error) {
 
 	// Extract the claims
 	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+		if claims.IsServiceToken() {
+			if err := verifyServiceToken(ctx, claims); err != nil {
+				return nil, err
+			}
+		}
 		return claims, nil
 	}
 
 	return nil, ErrTokenInvalid
 }
 
-// GenerateToken creates a new JWT token for a user
-func GenerateToken(userID, role string) (string, error) {
-	// Get JWT secret and expiration from environment
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		return "", errors.New("JWT_SECRET environment variable not set")
-	}
-
-	expirationHours := 24 // Default to 24 hours
-	if os.Getenv("JWT_EXPIRATION_HOURS") != "" {
-		fmt.Sscanf(os.Getenv("JWT_EXPIRATION_HOURS"), "%d", &expirationHours)
+// GenerateToken creates a new JWT token for a user. A nil scopes list grants the role's default scopes.
+func GenerateToken(userID, role string, scopes []string) (string, error) {
+	keys, err := currentSigningKeys()
+	if err != nil {
+		return "", err
 	}
 
 	// Set up the claims
 	now := time.Now()
+	if scopes == nil {
+		scopes = DefaultScopesForRole(Role(role))
+	}
+
 	claims := &Claims{
 		UserID: userID,
 		Role:   role,
+		Scopes: scopes,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(expirationHours) * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(TokenExpiration)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
-			Issuer:    "leaderboard-service",
+			Issuer:    TokenIssuer,
 			Subject:   userID,
 		},
 	}
 
-	// Create the token using the claims
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	// Create the token using the claims, naming the signing key so verifiers can find it after a rotation
+	key := keys.Active()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
 
-	// Sign the token with the secret key
-	tokenString, err := token.SignedString([]byte(secretKey))
+	// Sign the token with the active key
+	tokenString, err := token.SignedString(key.SignKey)
 	if err != nil {
 		return "", err
 	}
@@ -167,6 +221,95 @@ func GenerateToken(userID, role string) (string, error) {
 	return tokenString, nil
 }
 
+// GenerateServiceToken signs the JWT for a service token record. The jti is the record ID so the token can be revoked.
+func GenerateServiceToken(serviceToken *models.ServiceToken) (string, error) {
+	keys, err := currentSigningKeys()
+	if err != nil {
+		return "", err
+	}
+
+	id := serviceToken.ID.String()
+	claims := &Claims{
+		UserID:    id,
+		Scopes:    serviceToken.Scopes,
+		TokenType: ServiceTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			ExpiresAt: jwt.NewNumericDate(serviceToken.ExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(serviceToken.CreatedAt),
+			NotBefore: jwt.NewNumericDate(serviceToken.CreatedAt),
+			Issuer:    TokenIssuer,
+			Subject:   "service-token:" + id,
+			Audience:  serviceToken.Audience,
+		},
+	}
+
+	key := keys.Active()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+
+	return token.SignedString(key.SignKey)
+}
+
+// ServiceTokenVerifier checks that the service token named by a JWT's jti is still active, returning
+// services.ErrServiceTokenNotFound, ErrServiceTokenRevoked or ErrServiceTokenExpired when it can't be used
+type ServiceTokenVerifier interface {
+	Verify(ctx context.Context, id uuid.UUID) (*models.ServiceToken, error)
+}
+
+// Global verifier, nil until service tokens are enabled at startup
+var serviceTokenVerifier ServiceTokenVerifier
+
+// EnableServiceTokens makes the JWT middleware accept service tokens the verifier finds active. Until it is called
+// every service token is rejected.
+func EnableServiceTokens(verifier ServiceTokenVerifier) {
+	serviceTokenVerifier = verifier
+}
+
+// verifyServiceToken checks that a service token is meant for this service and has not been revoked
+func verifyServiceToken(ctx context.Context, claims *Claims) error {
+	if !slices.Contains(claims.Audience, TokenIssuer) {
+		return fmt.Errorf("%w: audience does not include %s", ErrTokenInvalid, TokenIssuer)
+	}
+
+	id, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return fmt.Errorf("%w: invalid token ID", ErrTokenInvalid)
+	}
+
+	if serviceTokenVerifier == nil {
+		return fmt.Errorf("%w: service tokens are not enabled", ErrTokenInvalid)
+	}
+	if _, err := serviceTokenVerifier.Verify(ctx, id); err != nil {
+		if errors.Is(err, services.ErrServiceTokenNotFound) || errors.Is(err, services.ErrServiceTokenRevoked) ||
+			errors.Is(err, services.ErrServiceTokenExpired) {
+			return fmt.Errorf("%w: %v", ErrTokenInvalid, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetRequestUserID returns the ID of the user making the request. It reports false for
+// API keys, service tokens and external tokens whose subject is not a user ID.
+func GetRequestUserID(r *http.Request) (uuid.UUID, bool) {
+	return GetUserIDFromContext(r.Context())
+}
+
+// GetUserIDFromContext is GetRequestUserID for callers that only have the context
+func GetUserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	claims, err := GetUserFromContext(ctx)
+	if err != nil || claims.IsServiceToken() || GetAPIKeyFromContext(ctx) != nil {
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
 // GetUserFromContext extracts user claims from the request context
 func GetUserFromContext(ctx context.Context) (*Claims, error) {
 	if ctx == nil {
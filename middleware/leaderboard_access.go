@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LeaderboardResolver finds the leaderboard a request operates on
+type LeaderboardResolver func(r *http.Request) (uuid.UUID, error)
+
+// errInvalidID is returned by resolvers whose route parameter is not a UUID
+var errInvalidID = errors.New("invalid ID")
+
+// parseIDParam reads a UUID from a route parameter
+func parseIDParam(r *http.Request, param string) (uuid.UUID, error) {
+	id, err := uuid.Parse(chi.URLParam(r, param))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", errInvalidID, err)
+	}
+	return id, nil
+}
+
+// respondWithResolveError responds to a resolver that failed, telling a malformed ID and a missing resource apart
+// from a lookup that failed
+func respondWithResolveError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		RespondWithError(w, http.StatusNotFound, "Resource not found", err)
+	case errors.Is(err, errInvalidID):
+		RespondWithError(w, http.StatusBadRequest, "Invalid ID", err)
+	default:
+		RespondWithError(w, http.StatusInternalServerError, "Failed to find the leaderboard", err)
+	}
+}
+
+// LeaderboardFromURLParam reads the leaderboard ID from a route parameter
+func LeaderboardFromURLParam(param string) LeaderboardResolver {
+	return func(r *http.Request) (uuid.UUID, error) {
+		return parseIDParam(r, param)
+	}
+}
+
+// LeaderboardFromEntry loads the entry named by a route parameter and returns its leaderboard
+func LeaderboardFromEntry(param string) LeaderboardResolver {
+	repo := repositories.NewLeaderboardEntryRepository()
+	return func(r *http.Request) (uuid.UUID, error) {
+		entryID, err := parseIDParam(r, param)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		entry, err := repo.FindByID(r.Context(), entryID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return entry.LeaderboardID, nil
+	}
+}
+
+// LeaderboardFromLeaderboardMetric loads the leaderboard metric named by a route parameter and returns its leaderboard
+func LeaderboardFromLeaderboardMetric(param string) LeaderboardResolver {
+	repo := repositories.NewLeaderboardMetricRepository()
+	return func(r *http.Request) (uuid.UUID, error) {
+		metricID, err := parseIDParam(r, param)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		metric, err := repo.FindByID(r.Context(), metricID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return metric.LeaderboardID, nil
+	}
+}
+
+func newLeaderboardAccessService() services.LeaderboardAccessService {
+	return services.NewLeaderboardAccessService(
+		repositories.NewLeaderboardRepository(),
+		repositories.NewLeaderboardMemberRepository(),
+		repositories.NewUserRepository(),
+		repositories.NewParticipantRepository(),
+		repositories.NewLeaderboardParticipantAccessRepository(),
+	)
+}
+
+// GetViewerFromContext describes the authenticated caller for leaderboard read checks
+func GetViewerFromContext(r *http.Request) services.Viewer {
+	return GetViewer(r.Context())
+}
+
+// GetViewer describes the caller authenticated in the context for leaderboard read checks
+func GetViewer(ctx context.Context) services.Viewer {
+	claims, err := GetUserFromContext(ctx)
+	if err != nil {
+		return services.Viewer{}
+	}
+
+	viewer := services.Viewer{CanReadAll: claims.HasScope(ScopeLeaderboardsAdmin)}
+	if userID, ok := GetUserIDFromContext(ctx); ok {
+		viewer.UserID = &userID
+	}
+	return viewer
+}
+
+// RequireLeaderboardRead hides private leaderboards from callers who are not on their access list.
+// Unreadable leaderboards respond with 404 so their existence is not disclosed.
+func RequireLeaderboardRead(resolve LeaderboardResolver) func(http.Handler) http.Handler {
+	accessService := newLeaderboardAccessService()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			leaderboardID, err := resolve(r)
+			if err != nil {
+				respondWithResolveError(w, err)
+				return
+			}
+
+			canRead, err := accessService.CanRead(r.Context(), leaderboardID, GetViewerFromContext(r))
+			if err != nil {
+				if err.Error() == "leaderboard not found" {
+					RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+					return
+				}
+				RespondWithError(w, http.StatusInternalServerError, "Failed to check leaderboard permissions", err)
+				return
+			}
+			if !canRead {
+				RespondWithError(w, http.StatusNotFound, "Leaderboard not found", nil)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireLeaderboardRole allows tokens with the leaderboards:admin scope, and otherwise requires
+// leaderboards:write plus one of the given roles on the leaderboard resolved from the request
+func RequireLeaderboardRole(resolve LeaderboardResolver, roles ...enums.LeaderboardRole) func(http.Handler) http.Handler {
+	accessService := newLeaderboardAccessService()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Get user from context
+			claims, err := GetUserFromContext(r.Context())
+			if err != nil {
+				RespondWithError(w, http.StatusUnauthorized, "Unauthorized access", err)
+				return
+			}
+
+			// Leaderboard admins can manage every leaderboard
+			if claims.HasScope(ScopeLeaderboardsAdmin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !claims.HasScope(ScopeLeaderboardsWrite) {
+				RespondWithError(w, http.StatusForbidden, "Insufficient scope, requires "+ScopeLeaderboardsWrite, nil)
+				return
+			}
+
+			leaderboardID, err := resolve(r)
+			if err != nil {
+				respondWithResolveError(w, err)
+				return
+			}
+
+			userID, err := uuid.Parse(claims.UserID)
+			if err != nil {
+				RespondWithError(w, http.StatusForbidden, "Insufficient permissions", nil)
+				return
+			}
+
+			role, err := accessService.GetRole(r.Context(), leaderboardID, userID)
+			if err != nil {
+				if err.Error() == "leaderboard not found" {
+					RespondWithError(w, http.StatusNotFound, "Leaderboard not found", err)
+					return
+				}
+				RespondWithError(w, http.StatusInternalServerError, "Failed to check leaderboard permissions", err)
+				return
+			}
+
+			for _, allowed := range roles {
+				if role == allowed {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			RespondWithError(w, http.StatusForbidden, "Insufficient permissions", nil)
+		})
+	}
+}
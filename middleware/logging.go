@@ -6,10 +6,13 @@ import (
 	"net/http"
 	"time"
 
+	"leaderboard-service/services"
+
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-// RequestLogger logs information about each request including user information if available
+// RequestLogger logs information about each request including user information if available, and keeps server
+// errors for the admin overview
 func RequestLogger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -43,5 +46,15 @@ func RequestLogger(next http.Handler) http.Handler {
 			duration,
 			userInfo,
 		)
+
+		if ww.Status() >= http.StatusInternalServerError {
+			services.RecordError(services.RecentError{
+				RequestID:  requestID,
+				Method:     r.Method,
+				Path:       r.URL.Path,
+				Status:     ww.Status(),
+				OccurredAt: start.UTC(),
+			})
+		}
 	})
 }
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/services"
+)
+
+// maintenanceModeMessage is the message of requests rejected in maintenance mode
+const maintenanceModeMessage = "The service is in maintenance mode, only reads are available"
+
+// versionPrefix matches the /api/<version> prefix of versioned paths
+var versionPrefix = regexp.MustCompile(`^/api/v[0-9]+`)
+
+// maintenanceAllowed are the paths, after any /api/<version> prefix, that still accept writes in maintenance mode:
+// logging in, and the admin endpoints that change how the service runs rather than its data, so admins can end it
+var maintenanceAllowed = []string{"/auth/login", "/admin/maintenance", "/admin/feature-flags"}
+
+// MaintenanceMode rejects requests that may change data with 503 while the maintenance_mode feature flag is on.
+// GET, HEAD and OPTIONS requests are still served.
+func MaintenanceMode(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !services.FeatureEnabled(enums.FeatureMaintenanceMode) || isSafeMethod(r.Method) || maintenanceAllowedPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		RespondWithError(w, http.StatusServiceUnavailable, maintenanceModeMessage, nil)
+	})
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func maintenanceAllowedPath(path string) bool {
+	path = versionPrefix.ReplaceAllString(path, "")
+	for _, allowed := range maintenanceAllowed {
+		if path == allowed || strings.HasPrefix(path, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,266 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	oidcHTTPTimeout = 10 * time.Second
+	// Unknown key IDs trigger a JWKS refresh, but no more often than this
+	oidcMinRefreshInterval = time.Minute
+)
+
+// OIDCConfig configures validation of tokens issued by an external identity provider
+type OIDCConfig struct {
+	IssuerURL   string
+	Audience    string
+	RolesClaim  string          // Dot separated path to the provider's roles or groups claim, e.g. "realm_access.roles"
+	RoleMapping map[string]Role // Provider role or group name to internal role
+	DefaultRole Role            // Role for users without a mapped provider role
+}
+
+// OIDCProvider validates provider tokens against keys discovered from the issuer
+type OIDCProvider struct {
+	config  OIDCConfig
+	jwksURI string
+	client  *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]interface{}
+	lastRefresh time.Time
+}
+
+// Global provider, nil unless OIDC is enabled at startup
+var oidcProvider *OIDCProvider
+
+// EnableOIDC makes the JWT middleware accept tokens issued by the provider
+func EnableOIDC(provider *OIDCProvider) {
+	oidcProvider = provider
+}
+
+// NewOIDCProvider runs OIDC discovery for the issuer and loads its signing keys
+func NewOIDCProvider(config OIDCConfig) (*OIDCProvider, error) {
+	config.IssuerURL = strings.TrimSuffix(config.IssuerURL, "/")
+	if config.RolesClaim == "" {
+		config.RolesClaim = "roles"
+	}
+	if config.DefaultRole == "" {
+		config.DefaultRole = RoleUser
+	}
+
+	provider := &OIDCProvider{
+		config: config,
+		client: &http.Client{Timeout: oidcHTTPTimeout},
+	}
+
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := provider.getJSON(config.IssuerURL+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != config.IssuerURL {
+		return nil, fmt.Errorf("OIDC discovery returned issuer %q, expected %q", discovery.Issuer, config.IssuerURL)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery document has no jwks_uri")
+	}
+	provider.jwksURI = discovery.JWKSURI
+
+	if err := provider.refreshKeys(); err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+// Issues reports whether a token claims to come from this provider
+func (p *OIDCProvider) Issues(issuer string) bool {
+	return strings.TrimSuffix(issuer, "/") == p.config.IssuerURL
+}
+
+// Validate verifies a provider token and maps it to internal claims
+func (p *OIDCProvider) Validate(tokenString string) (*Claims, error) {
+	providerClaims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(
+		tokenString,
+		providerClaims,
+		p.keyFunc,
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(p.config.IssuerURL),
+		jwt.WithAudience(p.config.Audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrTokenExpired
+		}
+		return nil, fmt.Errorf("%w: %v", ErrTokenInvalid, err)
+	}
+
+	subject, _ := providerClaims.GetSubject()
+	if subject == "" {
+		return nil, fmt.Errorf("%w: missing sub claim", ErrTokenInvalid)
+	}
+
+	// Derive a stable UUID so provider users can own leaderboards like local users
+	userID := uuid.NewSHA1(uuid.NameSpaceURL, []byte(p.config.IssuerURL+"#"+subject))
+
+	claims := &Claims{
+		UserID: userID.String(),
+		Role:   string(p.mapRole(providerClaims)),
+	}
+	claims.Issuer = p.config.IssuerURL
+	claims.Subject = subject
+	return claims, nil
+}
+
+// mapRole picks the most privileged internal role granted by the provider's roles claim
+func (p *OIDCProvider) mapRole(providerClaims jwt.MapClaims) Role {
+	var value interface{} = map[string]interface{}(providerClaims)
+	for _, part := range strings.Split(p.config.RolesClaim, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return p.config.DefaultRole
+		}
+		value = object[part]
+	}
+
+	var providerRoles []string
+	switch v := value.(type) {
+	case string:
+		providerRoles = strings.Fields(v)
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				providerRoles = append(providerRoles, s)
+			}
+		}
+	}
+
+	best := p.config.DefaultRole
+	for _, providerRole := range providerRoles {
+		if role, ok := p.config.RoleMapping[providerRole]; ok && rolePrivilege(role) > rolePrivilege(best) {
+			best = role
+		}
+	}
+	return best
+}
+
+func rolePrivilege(role Role) int {
+	switch role {
+	case RoleAdmin:
+		return 3
+	case RoleModerator:
+		return 2
+	case RoleUser:
+		return 1
+	}
+	return 0
+}
+
+func (p *OIDCProvider) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	if key := p.lookupKey(kid); key != nil {
+		return key, nil
+	}
+
+	// The provider may have rotated its keys since we last fetched them
+	p.mu.RLock()
+	canRefresh := time.Since(p.lastRefresh) > oidcMinRefreshInterval
+	p.mu.RUnlock()
+	if canRefresh {
+		if err := p.refreshKeys(); err != nil {
+			log.Printf("oidc: failed to refresh signing keys: %v", err)
+		}
+		if key := p.lookupKey(kid); key != nil {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (p *OIDCProvider) lookupKey(kid string) interface{} {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key
+		}
+	}
+	return p.keys[kid]
+}
+
+func (p *OIDCProvider) refreshKeys() error {
+	var set JSONWebKeySet
+	if err := p.getJSON(p.jwksURI, &set); err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			log.Printf("oidc: skipping signing key %q: %v", jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return errors.New("JWKS contains no usable signing keys")
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.lastRefresh = time.Now()
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *OIDCProvider) getJSON(url string, target interface{}) error {
+	resp, err := p.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// ParseRoleMapping parses "provider-role=internal-role" pairs separated by commas
+func ParseRoleMapping(value string) (map[string]Role, error) {
+	mapping := map[string]Role{}
+	if strings.TrimSpace(value) == "" {
+		return mapping, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		providerRole, role, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		providerRole, role = strings.TrimSpace(providerRole), strings.TrimSpace(role)
+		if !ok || providerRole == "" || rolePrivilege(Role(role)) == 0 {
+			return nil, fmt.Errorf("invalid role mapping %q, expected provider-role=admin|moderator|user", pair)
+		}
+		mapping[providerRole] = Role(role)
+	}
+	return mapping, nil
+}
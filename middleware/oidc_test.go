@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestParseRoleMapping(t *testing.T) {
+	testCases := []struct {
+		name            string
+		input           string
+		expectedMapping map[string]Role
+		expectedValid   bool
+	}{
+		{
+			name:            "Empty value",
+			input:           "",
+			expectedMapping: map[string]Role{},
+			expectedValid:   true,
+		},
+		{
+			name:            "Single mapping",
+			input:           "leaderboard-admins=admin",
+			expectedMapping: map[string]Role{"leaderboard-admins": RoleAdmin},
+			expectedValid:   true,
+		},
+		{
+			name:  "Several mappings with spaces",
+			input: " staff = moderator , players=user,ops=admin ",
+			expectedMapping: map[string]Role{
+				"staff":   RoleModerator,
+				"players": RoleUser,
+				"ops":     RoleAdmin,
+			},
+			expectedValid: true,
+		},
+		{
+			name:          "Unknown internal role",
+			input:         "staff=superuser",
+			expectedValid: false,
+		},
+		{
+			name:          "Missing provider role",
+			input:         "=admin",
+			expectedValid: false,
+		},
+		{
+			name:          "Missing separator",
+			input:         "admin",
+			expectedValid: false,
+		},
+		{
+			name:          "Trailing comma",
+			input:         "ops=admin,",
+			expectedValid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mapping, err := ParseRoleMapping(tc.input)
+			isValid := err == nil
+
+			if isValid != tc.expectedValid {
+				if tc.expectedValid {
+					t.Errorf("Expected parsing to pass but got error: %v", err)
+				} else {
+					t.Errorf("Expected parsing to fail but it passed with %v", mapping)
+				}
+			}
+			if isValid && !reflect.DeepEqual(mapping, tc.expectedMapping) {
+				t.Errorf("Expected mapping %v but got %v", tc.expectedMapping, mapping)
+			}
+		})
+	}
+}
+
+func TestMapRole(t *testing.T) {
+	roleMapping := map[string]Role{
+		"leaderboard-admins": RoleAdmin,
+		"staff":              RoleModerator,
+		"players":            RoleUser,
+	}
+
+	testCases := []struct {
+		name         string
+		rolesClaim   string
+		defaultRole  Role
+		claims       jwt.MapClaims
+		expectedRole Role
+	}{
+		{
+			name:         "List of roles",
+			rolesClaim:   "roles",
+			defaultRole:  RoleUser,
+			claims:       jwt.MapClaims{"roles": []interface{}{"staff"}},
+			expectedRole: RoleModerator,
+		},
+		{
+			name:         "Most privileged role wins",
+			rolesClaim:   "roles",
+			defaultRole:  RoleUser,
+			claims:       jwt.MapClaims{"roles": []interface{}{"players", "leaderboard-admins", "staff"}},
+			expectedRole: RoleAdmin,
+		},
+		{
+			name:         "Space separated roles",
+			rolesClaim:   "roles",
+			defaultRole:  RoleUser,
+			claims:       jwt.MapClaims{"roles": "players staff"},
+			expectedRole: RoleModerator,
+		},
+		{
+			name:         "Nested claim",
+			rolesClaim:   "realm_access.roles",
+			defaultRole:  RoleUser,
+			claims:       jwt.MapClaims{"realm_access": map[string]interface{}{"roles": []interface{}{"leaderboard-admins"}}},
+			expectedRole: RoleAdmin,
+		},
+		{
+			name:         "Unmapped roles get the default role",
+			rolesClaim:   "roles",
+			defaultRole:  RoleUser,
+			claims:       jwt.MapClaims{"roles": []interface{}{"billing", "support"}},
+			expectedRole: RoleUser,
+		},
+		{
+			name:         "Mapped role less privileged than the default",
+			rolesClaim:   "roles",
+			defaultRole:  RoleModerator,
+			claims:       jwt.MapClaims{"roles": []interface{}{"players"}},
+			expectedRole: RoleModerator,
+		},
+		{
+			name:         "Missing claim",
+			rolesClaim:   "roles",
+			defaultRole:  RoleUser,
+			claims:       jwt.MapClaims{"sub": "user-1"},
+			expectedRole: RoleUser,
+		},
+		{
+			name:         "Nested claim under a value that is not an object",
+			rolesClaim:   "realm_access.roles",
+			defaultRole:  RoleUser,
+			claims:       jwt.MapClaims{"realm_access": "leaderboard-admins"},
+			expectedRole: RoleUser,
+		},
+		{
+			name:         "Roles that are not strings are ignored",
+			rolesClaim:   "roles",
+			defaultRole:  RoleUser,
+			claims:       jwt.MapClaims{"roles": []interface{}{42, true, "staff"}},
+			expectedRole: RoleModerator,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := &OIDCProvider{config: OIDCConfig{
+				RolesClaim:  tc.rolesClaim,
+				RoleMapping: roleMapping,
+				DefaultRole: tc.defaultRole,
+			}}
+
+			if role := provider.mapRole(tc.claims); role != tc.expectedRole {
+				t.Errorf("Expected role %q but got %q", tc.expectedRole, role)
+			}
+		})
+	}
+}
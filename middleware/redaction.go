@@ -0,0 +1,297 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"leaderboard-service/models"
+
+	"github.com/segmentio/encoding/json"
+)
+
+// redactedFields lists, per model, the fields only admins get to see. Fields of embedded models such as BaseModel
+// are hidden on every model that embeds them.
+var redactedFields = map[reflect.Type][]string{
+	reflect.TypeOf(models.BaseModel{}):   {"DeletedAt"},
+	reflect.TypeOf(models.Participant{}): {"Metadata"},
+	reflect.TypeOf(models.MetricValue{}): {"Context"},
+}
+
+var (
+	redactionMu    sync.RWMutex
+	redactionCache = map[reflect.Type]bool{}
+)
+
+// RedactFields hides more fields of a model from callers that are not admins, e.g.
+// RedactFields(models.Participant{}, "ExternalID"). It is meant to be called from init functions.
+func RedactFields(model interface{}, fields ...string) {
+	redactionMu.Lock()
+	defer redactionMu.Unlock()
+
+	t := reflect.TypeOf(model)
+	redactedFields[t] = append(redactedFields[t], fields...)
+	redactionCache = map[reflect.Type]bool{}
+}
+
+// IsFieldRedacted reports whether a field of a model is hidden from callers that are not admins
+func IsFieldRedacted(model interface{}, field string) bool {
+	return typeFieldRedacted(reflect.TypeOf(model), field)
+}
+
+func typeFieldRedacted(t reflect.Type, field string) bool {
+	if hiddenFields(t, nil)[field] {
+		return true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if embedded := t.Field(i); embedded.Anonymous && embedded.Type.Kind() == reflect.Struct && typeFieldRedacted(embedded.Type, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldRedaction hides the fields listed in redactedFields from responses to callers that are not admins. Admins
+// get the full model. It must run after authentication so the caller's role is known.
+func FieldRedaction(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if claims, err := GetUserFromContext(r.Context()); err == nil && Role(claims.Role) == RoleAdmin {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(&redactingResponseWriter{ResponseWriter: w}, r)
+	})
+}
+
+// redactingResponseWriter marks a response as going to a caller that must not see redacted fields.
+// RespondWithJSON looks for it through any writers wrapped around it.
+type redactingResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w *redactingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// shouldRedact reports whether the response is written through a redactingResponseWriter
+func shouldRedact(w http.ResponseWriter) bool {
+	for {
+		switch writer := w.(type) {
+		case *redactingResponseWriter:
+			return true
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = writer.Unwrap()
+		default:
+			return false
+		}
+	}
+}
+
+// Redact returns a copy of the payload without the fields in redactedFields, ready to be marshaled.
+// Payloads that hold no redacted model are returned as they are.
+func Redact(payload interface{}) interface{} {
+	if payload == nil {
+		return nil
+	}
+	return redactValue(reflect.ValueOf(payload))
+}
+
+func redactValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if !mayHoldRedacted(v.Type()) || implementsMarshaler(v) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return redactValue(v.Elem())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = redactValue(v.Index(i))
+		}
+		return items
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+		object := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			object[iter.Key().String()] = redactValue(iter.Value())
+		}
+		return object
+	case reflect.Struct:
+		object := orderedObject{}
+		appendStructFields(&object, v, nil)
+		return object
+	default:
+		return v.Interface()
+	}
+}
+
+// appendStructFields adds a struct's fields to the object the way encoding/json would name them, skipping redacted
+// ones. Embedded structs without a JSON name have their fields promoted, like encoding/json does.
+func appendStructFields(object *orderedObject, v reflect.Value, hidden map[string]bool) {
+	t := v.Type()
+	hidden = hiddenFields(t, hidden)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+
+		value := v.Field(i)
+		if field.Anonymous && name == "" {
+			if value.Kind() == reflect.Pointer {
+				if value.IsNil() {
+					continue
+				}
+				value = value.Elem()
+			}
+			if value.Kind() == reflect.Struct && !implementsMarshaler(value) {
+				appendStructFields(object, value, hidden)
+				continue
+			}
+		}
+		if !field.IsExported() || hidden[field.Name] {
+			continue
+		}
+		if strings.Contains(","+options+",", ",omitempty,") && isEmptyValue(value) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		*object = append(*object, objectField{Key: name, Value: redactValue(value)})
+	}
+}
+
+// hiddenFields adds the redacted fields of a struct type to those hidden by the structs it is embedded in
+func hiddenFields(t reflect.Type, inherited map[string]bool) map[string]bool {
+	fields := redactedFields[t]
+	if len(fields) == 0 {
+		return inherited
+	}
+
+	hidden := make(map[string]bool, len(inherited)+len(fields))
+	for name := range inherited {
+		hidden[name] = true
+	}
+	for _, name := range fields {
+		hidden[name] = true
+	}
+	return hidden
+}
+
+// mayHoldRedacted reports whether values of the type can contain a redacted field, so everything else is
+// marshaled directly. Interface values are checked when they are reached since any model can be behind them.
+func mayHoldRedacted(t reflect.Type) bool {
+	redactionMu.RLock()
+	result, ok := redactionCache[t]
+	redactionMu.RUnlock()
+	if ok {
+		return result
+	}
+
+	result = typeHoldsRedacted(t, map[reflect.Type]bool{})
+
+	redactionMu.Lock()
+	redactionCache[t] = result
+	redactionMu.Unlock()
+	return result
+}
+
+func typeHoldsRedacted(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return typeHoldsRedacted(t.Elem(), seen)
+	case reflect.Struct:
+		if len(redactedFields[t]) > 0 {
+			return true
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if typeHoldsRedacted(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func implementsMarshaler(v reflect.Value) bool {
+	marshaler := reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	if v.Kind() == reflect.Interface {
+		return false
+	}
+	return v.Type().Implements(marshaler) || (v.CanAddr() && v.Addr().Type().Implements(marshaler))
+}
+
+// isEmptyValue mirrors encoding/json's omitempty rules
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// orderedObject is a JSON object that keeps its keys in struct field order
+type orderedObject []objectField
+
+type objectField struct {
+	Key   string
+	Value interface{}
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leaderboard-service/models"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/segmentio/encoding/json"
+)
+
+func TestIsFieldRedacted(t *testing.T) {
+	testCases := []struct {
+		name             string
+		model            interface{}
+		field            string
+		expectedRedacted bool
+	}{
+		{
+			name:             "Participant metadata",
+			model:            models.Participant{},
+			field:            "Metadata",
+			expectedRedacted: true,
+		},
+		{
+			name:             "Participant name",
+			model:            models.Participant{},
+			field:            "Name",
+			expectedRedacted: false,
+		},
+		{
+			name:             "Metric value context",
+			model:            models.MetricValue{},
+			field:            "Context",
+			expectedRedacted: true,
+		},
+		{
+			name:             "Deletion time of a model embedding BaseModel",
+			model:            models.Leaderboard{},
+			field:            "DeletedAt",
+			expectedRedacted: true,
+		},
+		{
+			name:             "ID of a model embedding BaseModel",
+			model:            models.Leaderboard{},
+			field:            "ID",
+			expectedRedacted: false,
+		},
+		{
+			name:             "Field redacted on another model",
+			model:            models.Leaderboard{},
+			field:            "Metadata",
+			expectedRedacted: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if redacted := IsFieldRedacted(tc.model, tc.field); redacted != tc.expectedRedacted {
+				t.Errorf("Expected redacted to be %v but got %v", tc.expectedRedacted, redacted)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	participant := models.Participant{
+		Name:     "Ada",
+		Type:     "individual",
+		Metadata: map[string]interface{}{"email": "ada@example.com"},
+	}
+	metricValue := models.MetricValue{Value: 3, Context: map[string]interface{}{"source": "crm"}}
+
+	testCases := []struct {
+		name           string
+		payload        interface{}
+		expectedJSON   string
+		expectedFields []string // Top-level fields, or those of the first item, that must be present
+		hiddenFields   []string // Top-level fields, or those of the first item, that must be absent
+	}{
+		{
+			name:           "Model with a redacted field",
+			payload:        participant,
+			expectedFields: []string{"ID", "Name", "Type", "CreatedAt"},
+			hiddenFields:   []string{"Metadata", "DeletedAt"},
+		},
+		{
+			name:           "Pointer to a model",
+			payload:        &metricValue,
+			expectedFields: []string{"ID", "Value"},
+			hiddenFields:   []string{"Context", "DeletedAt"},
+		},
+		{
+			name:           "Slice of models",
+			payload:        []models.Participant{participant, participant},
+			expectedFields: []string{"Name"},
+			hiddenFields:   []string{"Metadata"},
+		},
+		{
+			name:         "Payload without redacted models",
+			payload:      map[string]int{"count": 2},
+			expectedJSON: `{"count":2}`,
+		},
+		{
+			name:         "Nil payload",
+			payload:      nil,
+			expectedJSON: `null`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, err := json.Marshal(Redact(tc.payload))
+			if err != nil {
+				t.Fatalf("Failed to marshal the redacted payload: %v", err)
+			}
+			if tc.expectedJSON != "" {
+				if string(body) != tc.expectedJSON {
+					t.Errorf("Expected %s but got %s", tc.expectedJSON, body)
+				}
+				return
+			}
+
+			var decoded interface{}
+			if err := json.Unmarshal(body, &decoded); err != nil {
+				t.Fatalf("Failed to decode %s: %v", body, err)
+			}
+			if items, ok := decoded.([]interface{}); ok {
+				if len(items) == 0 {
+					t.Fatalf("Expected items but got %s", body)
+				}
+				decoded = items[0]
+			}
+			object, ok := decoded.(map[string]interface{})
+			if !ok {
+				t.Fatalf("Expected an object but got %s", body)
+			}
+
+			for _, field := range tc.expectedFields {
+				if _, present := object[field]; !present {
+					t.Errorf("Expected field %q in %s", field, body)
+				}
+			}
+			for _, field := range tc.hiddenFields {
+				if _, present := object[field]; present {
+					t.Errorf("Expected field %q to be redacted from %s", field, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactNestedModel(t *testing.T) {
+	body, err := json.Marshal(Redact(map[string]interface{}{
+		"participant": models.Participant{Name: "Ada", Metadata: map[string]interface{}{"email": "ada@example.com"}},
+	}))
+	if err != nil {
+		t.Fatalf("Failed to marshal the redacted payload: %v", err)
+	}
+
+	var decoded map[string]map[string]interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("Failed to decode %s: %v", body, err)
+	}
+	if _, present := decoded["participant"]["Metadata"]; present {
+		t.Errorf("Expected the nested participant's Metadata to be redacted from %s", body)
+	}
+	if decoded["participant"]["Name"] != "Ada" {
+		t.Errorf("Expected the nested participant's Name to be kept in %s", body)
+	}
+}
+
+func TestShouldRedact(t *testing.T) {
+	testCases := []struct {
+		name           string
+		writer         http.ResponseWriter
+		expectedRedact bool
+	}{
+		{
+			name:           "Admin response",
+			writer:         httptest.NewRecorder(),
+			expectedRedact: false,
+		},
+		{
+			name:           "Response to a caller that is not an admin",
+			writer:         &redactingResponseWriter{ResponseWriter: httptest.NewRecorder()},
+			expectedRedact: true,
+		},
+		{
+			name:           "Admin response wrapped by another middleware",
+			writer:         middleware.NewWrapResponseWriter(httptest.NewRecorder(), 1),
+			expectedRedact: false,
+		},
+		{
+			name:           "Response to a caller that is not an admin wrapped by another middleware",
+			writer:         middleware.NewWrapResponseWriter(&redactingResponseWriter{ResponseWriter: httptest.NewRecorder()}, 1),
+			expectedRedact: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if redact := shouldRedact(tc.writer); redact != tc.expectedRedact {
+				t.Errorf("Expected shouldRedact to be %v but got %v", tc.expectedRedact, redact)
+			}
+		})
+	}
+}
@@ -1,19 +1,39 @@
 package middleware
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"net/http"
+	"reflect"
+	"strconv"
+
+	"leaderboard-service/validation"
+
+	// Drop-in replacement for encoding/json that produces identical output with far
+	// fewer allocations on large entry lists (see BenchmarkRespondWithJSONEntries)
+	"github.com/segmentio/encoding/json"
 )
 
 // ErrorResponse represents an error response for the API
 type ErrorResponse struct {
-	Status  int         `json:"status"`
-	Message string      `json:"message"`
-	Error   interface{} `json:"error,omitempty"`
+	Status  int                     `json:"status"`
+	Code    string                  `json:"code" example:"LEADERBOARD_NOT_FOUND"` // Stable identifier clients can branch on instead of the message
+	Message string                  `json:"message"`
+	Error   interface{}             `json:"error,omitempty"`
+	Details []validation.FieldError `json:"details,omitempty"` // One entry per invalid field for VALIDATION_FAILED
+	// The resource that already exists, for conflicts such as ENTRY_EXISTS that name one
+	Existing interface{} `json:"existing,omitempty" swaggertype:"object"`
+	// How many records of each kind a delete refused with LEADERBOARD_NOT_EMPTY would also remove
+	Dependents map[string]int64 `json:"dependents,omitempty"`
 }
 
-// RespondWithError sends an error response to the client
+// RespondWithError sends an error response to the client. Server errors caused by the request running out of
+// time are sent as 503 QUERY_TIMEOUT instead, so clients know to retry later.
 func RespondWithError(w http.ResponseWriter, code int, message string, err error) {
+	if code == http.StatusInternalServerError && errors.Is(err, context.DeadlineExceeded) {
+		code, message = http.StatusServiceUnavailable, queryTimeoutMessage
+	}
+
 	var errMsg interface{}
 	if err != nil {
 		errMsg = err.Error()
@@ -21,15 +41,56 @@ func RespondWithError(w http.ResponseWriter, code int, message string, err error
 
 	response := ErrorResponse{
 		Status:  code,
+		Code:    errorCode(code, message),
 		Message: message,
 		Error:   errMsg,
 	}
 
+	var fieldErrors validation.Errors
+	if errors.As(err, &fieldErrors) {
+		response.Details = fieldErrors
+	}
+
 	RespondWithJSON(w, code, response)
 }
 
+// RespondWithConflict sends a 409 error response that carries the existing resource the request conflicts with, so
+// clients can carry on with it instead of looking it up
+func RespondWithConflict(w http.ResponseWriter, message string, err error, existing interface{}) {
+	response := ErrorResponse{
+		Status:   http.StatusConflict,
+		Code:     errorCode(http.StatusConflict, message),
+		Message:  message,
+		Error:    err.Error(),
+		Existing: existing,
+	}
+	RespondWithJSON(w, http.StatusConflict, response)
+}
+
+// RespondWithDependents sends a 409 error response for a delete that was refused because other records depend on
+// the resource, saying how many of each kind would be removed with it
+func RespondWithDependents(w http.ResponseWriter, message string, err error, dependents map[string]int64) {
+	response := ErrorResponse{
+		Status:     http.StatusConflict,
+		Code:       errorCode(http.StatusConflict, message),
+		Message:    message,
+		Error:      err.Error(),
+		Dependents: dependents,
+	}
+	RespondWithJSON(w, http.StatusConflict, response)
+}
+
 // RespondWithJSON sends a JSON response to the client
 func RespondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	// Lists that are not paginated hold every item, so their length is the total count
+	if value := reflect.ValueOf(payload); value.Kind() == reflect.Slice && w.Header().Get(TotalCountHeader) == "" {
+		w.Header().Set(TotalCountHeader, strconv.Itoa(value.Len()))
+	}
+
+	if shouldRedact(w) {
+		payload = Redact(payload)
+	}
+
 	response, _ := json.Marshal(payload)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	stdjson "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"leaderboard-service/models"
+
+	"github.com/google/uuid"
+)
+
+// buildEntries creates a leaderboard-sized list of entries for encoding tests
+func buildEntries(n int) []models.LeaderboardEntry {
+	now := time.Date(2023, 1, 1, 12, 30, 0, 123456789, time.UTC)
+	leaderboardID := uuid.New()
+
+	entries := make([]models.LeaderboardEntry, n)
+	for i := range entries {
+		entries[i] = models.LeaderboardEntry{
+			LeaderboardID: leaderboardID,
+			ParticipantID: uuid.New(),
+			Rank:          i + 1,
+			Score:         float64(n-i) * 1.25,
+			LastUpdated:   now,
+		}
+		entries[i].ID = uuid.New()
+		entries[i].CreatedAt = now
+		entries[i].UpdatedAt = now
+	}
+	return entries
+}
+
+func TestRespondWithJSONMatchesStandardLibrary(t *testing.T) {
+	entries := buildEntries(100)
+
+	expected, err := stdjson.Marshal(entries)
+	if err != nil {
+		t.Fatalf("encoding/json failed: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	RespondWithJSON(rec, http.StatusOK, entries)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d but got %d", http.StatusOK, rec.Code)
+	}
+	if got := rec.Body.String(); got != string(expected) {
+		t.Errorf("Encoded output differs from encoding/json\nexpected: %.200s\ngot:      %.200s", expected, got)
+	}
+}
+
+func BenchmarkRespondWithJSONEntries(b *testing.B) {
+	entries := buildEntries(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		RespondWithJSON(httptest.NewRecorder(), http.StatusOK, entries)
+	}
+}
+
+// BenchmarkStandardLibraryEntries is the encoding/json baseline for BenchmarkRespondWithJSONEntries
+func BenchmarkStandardLibraryEntries(b *testing.B) {
+	entries := buildEntries(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		response, _ := stdjson.Marshal(entries)
+		rec := httptest.NewRecorder()
+		rec.Write(response)
+	}
+}
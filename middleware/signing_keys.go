@@ -0,0 +1,281 @@
+package middleware
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultSigningKeyID is the kid used for JWT_SECRET when JWT_KEY_ID is not set
+const DefaultSigningKeyID = "default"
+
+// SigningKey is a key the service signs or verifies its own tokens with
+type SigningKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	// SignKey is the HMAC secret or private key; nil for keys only kept for verification
+	SignKey interface{}
+	// VerifyKey is the HMAC secret or public key
+	VerifyKey interface{}
+}
+
+// KeySet holds the key new tokens are signed with and every key still accepted for verification
+type KeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+// NewKeySet builds a key set that signs with the active key and also verifies tokens signed by the others
+func NewKeySet(active *SigningKey, others ...*SigningKey) (*KeySet, error) {
+	if active == nil || active.SignKey == nil {
+		return nil, errors.New("active signing key is required")
+	}
+
+	set := &KeySet{active: active, keys: map[string]*SigningKey{}}
+	for _, key := range append([]*SigningKey{active}, others...) {
+		if key.ID == "" {
+			return nil, errors.New("signing key ID is required")
+		}
+		if _, exists := set.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate signing key ID %q", key.ID)
+		}
+		set.keys[key.ID] = key
+	}
+	return set, nil
+}
+
+// Active returns the key new tokens are signed with
+func (s *KeySet) Active() *SigningKey {
+	return s.active
+}
+
+// Lookup returns the verification key for a token's kid header
+func (s *KeySet) Lookup(kid string) (*SigningKey, bool) {
+	// Tokens issued before key IDs were introduced were signed with the default key
+	if kid == "" {
+		kid = DefaultSigningKeyID
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// JWKS returns the public keys of the set. HMAC secrets are never published.
+func (s *KeySet) JWKS() JSONWebKeySet {
+	jwks := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range s.keys {
+		switch key.VerifyKey.(type) {
+		case *rsa.PublicKey, *ecdsa.PublicKey:
+			jwk, err := NewJSONWebKey(key.ID, key.Method.Alg(), key.VerifyKey)
+			if err == nil {
+				jwks.Keys = append(jwks.Keys, jwk)
+			}
+		}
+	}
+	sort.Slice(jwks.Keys, func(i, j int) bool { return jwks.Keys[i].Kid < jwks.Keys[j].Kid })
+	return jwks
+}
+
+var (
+	signingKeysMu sync.RWMutex
+	signingKeys   *KeySet
+)
+
+// SetSigningKeys replaces the key set used to sign and verify the service's own tokens
+func SetSigningKeys(keys *KeySet) {
+	signingKeysMu.Lock()
+	defer signingKeysMu.Unlock()
+	signingKeys = keys
+}
+
+// LoadSigningKeys builds the key set from the JWT_* environment variables and installs it
+func LoadSigningKeys() error {
+	keys, err := signingKeysFromEnv()
+	if err != nil {
+		return err
+	}
+	SetSigningKeys(keys)
+	return nil
+}
+
+// currentSigningKeys returns the installed key set, loading it from the environment on first use
+func currentSigningKeys() (*KeySet, error) {
+	signingKeysMu.RLock()
+	keys := signingKeys
+	signingKeysMu.RUnlock()
+	if keys != nil {
+		return keys, nil
+	}
+
+	keys, err := signingKeysFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	SetSigningKeys(keys)
+	return keys, nil
+}
+
+func signingKeysFromEnv() (*KeySet, error) {
+	keyID := os.Getenv("JWT_KEY_ID")
+	if keyID == "" {
+		keyID = DefaultSigningKeyID
+	}
+
+	var active *SigningKey
+	switch algorithm := os.Getenv("JWT_SIGNING_ALGORITHM"); algorithm {
+	case "", "HS256":
+		secretKey := os.Getenv("JWT_SECRET")
+		if secretKey == "" {
+			return nil, errors.New("JWT_SECRET environment variable not set")
+		}
+		active = &SigningKey{
+			ID:        keyID,
+			Method:    jwt.SigningMethodHS256,
+			SignKey:   []byte(secretKey),
+			VerifyKey: []byte(secretKey),
+		}
+
+	case "RS256", "ES256":
+		keyFile := os.Getenv("JWT_PRIVATE_KEY_FILE")
+		if keyFile == "" {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY_FILE environment variable is required for %s", algorithm)
+		}
+		pemData, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT_PRIVATE_KEY_FILE: %w", err)
+		}
+		active, err = ParsePrivateSigningKey(keyID, algorithm, pemData)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported JWT_SIGNING_ALGORITHM %q, expected HS256, RS256 or ES256", algorithm)
+	}
+
+	previous, err := ParsePreviousKeys(os.Getenv("JWT_PREVIOUS_KEYS"))
+	if err != nil {
+		return nil, err
+	}
+
+	previousPublic, err := loadPreviousPublicKeys(os.Getenv("JWT_PREVIOUS_PUBLIC_KEYS"))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeySet(active, append(previous, previousPublic...)...)
+}
+
+// ParsePrivateSigningKey parses a PEM encoded RSA (RS256) or P-256 ECDSA (ES256) private key
+func ParsePrivateSigningKey(kid, algorithm string, pemData []byte) (*SigningKey, error) {
+	switch algorithm {
+	case "RS256":
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA private key: %w", err)
+		}
+		return &SigningKey{
+			ID:        kid,
+			Method:    jwt.SigningMethodRS256,
+			SignKey:   privateKey,
+			VerifyKey: &privateKey.PublicKey,
+		}, nil
+
+	case "ES256":
+		privateKey, err := jwt.ParseECPrivateKeyFromPEM(pemData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECDSA private key: %w", err)
+		}
+		if privateKey.Curve.Params().Name != "P-256" {
+			return nil, fmt.Errorf("ES256 requires a P-256 key, got %s", privateKey.Curve.Params().Name)
+		}
+		return &SigningKey{
+			ID:        kid,
+			Method:    jwt.SigningMethodES256,
+			SignKey:   privateKey,
+			VerifyKey: &privateKey.PublicKey,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+}
+
+// ParsePublicSigningKey parses a PEM encoded RSA or P-256 ECDSA public key that is only used for verification
+func ParsePublicSigningKey(kid string, pemData []byte) (*SigningKey, error) {
+	if publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pemData); err == nil {
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodRS256, VerifyKey: publicKey}, nil
+	}
+
+	publicKey, err := jwt.ParseECPublicKeyFromPEM(pemData)
+	if err != nil {
+		return nil, fmt.Errorf("key %q is not an RSA or ECDSA public key", kid)
+	}
+	if publicKey.Curve.Params().Name != "P-256" {
+		return nil, fmt.Errorf("key %q must use the P-256 curve, got %s", kid, publicKey.Curve.Params().Name)
+	}
+	return &SigningKey{ID: kid, Method: jwt.SigningMethodES256, VerifyKey: publicKey}, nil
+}
+
+// loadPreviousPublicKeys reads retired public keys in the form "kid=/path/to/key.pem,kid2=/path/to/key2.pem"
+func loadPreviousPublicKeys(value string) ([]*SigningKey, error) {
+	var keys []*SigningKey
+	if strings.TrimSpace(value) == "" {
+		return keys, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		kid, path, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		kid, path = strings.TrimSpace(kid), strings.TrimSpace(path)
+		if !ok || kid == "" || path == "" {
+			return nil, fmt.Errorf("invalid previous public key %q, expected kid=/path/to/key.pem", pair)
+		}
+		pemData, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read public key %q: %w", kid, err)
+		}
+		key, err := ParsePublicSigningKey(kid, pemData)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ParsePreviousKeys parses retired HMAC secrets in the form "kid=secret,kid2=secret2".
+// They are only used to verify tokens issued before a rotation.
+func ParsePreviousKeys(value string) ([]*SigningKey, error) {
+	var keys []*SigningKey
+	if strings.TrimSpace(value) == "" {
+		return keys, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		kid, secret, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		kid = strings.TrimSpace(kid)
+		if !ok || kid == "" || secret == "" {
+			return nil, fmt.Errorf("invalid previous key %q, expected kid=secret", kid)
+		}
+		keys = append(keys, &SigningKey{
+			ID:        kid,
+			Method:    jwt.SigningMethodHS256,
+			VerifyKey: []byte(secret),
+		})
+	}
+	return keys, nil
+}
+
+// PublicJWKS returns the JWKS document other services use to verify tokens issued by this service
+func PublicJWKS() (JSONWebKeySet, error) {
+	keys, err := currentSigningKeys()
+	if err != nil {
+		return JSONWebKeySet{}, err
+	}
+	return keys.JWKS(), nil
+}
@@ -0,0 +1,233 @@
+package middleware
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// hmacKey creates an HMAC key, only kept for verification unless it can sign
+func hmacKey(kid, secret string, canSign bool) *SigningKey {
+	key := &SigningKey{ID: kid, Method: jwt.SigningMethodHS256, VerifyKey: []byte(secret)}
+	if canSign {
+		key.SignKey = []byte(secret)
+	}
+	return key
+}
+
+func rsaKey(t *testing.T, kid string) *SigningKey {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	return &SigningKey{ID: kid, Method: jwt.SigningMethodRS256, SignKey: privateKey, VerifyKey: &privateKey.PublicKey}
+}
+
+func ecdsaKey(t *testing.T, kid string) *SigningKey {
+	t.Helper()
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate ECDSA key: %v", err)
+	}
+	return &SigningKey{ID: kid, Method: jwt.SigningMethodES256, SignKey: privateKey, VerifyKey: &privateKey.PublicKey}
+}
+
+func TestNewKeySet(t *testing.T) {
+	testCases := []struct {
+		name          string
+		active        *SigningKey
+		others        []*SigningKey
+		expectedValid bool
+	}{
+		{
+			name:          "Active key only",
+			active:        hmacKey(DefaultSigningKeyID, "secret", true),
+			expectedValid: true,
+		},
+		{
+			name:          "Active key with previous keys",
+			active:        hmacKey("2024", "new-secret", true),
+			others:        []*SigningKey{hmacKey("2023", "old-secret", false), hmacKey("2022", "older-secret", false)},
+			expectedValid: true,
+		},
+		{
+			name:          "Missing active key",
+			expectedValid: false,
+		},
+		{
+			name:          "Active key that can't sign",
+			active:        hmacKey(DefaultSigningKeyID, "secret", false),
+			expectedValid: false,
+		},
+		{
+			name:          "Key without an ID",
+			active:        hmacKey(DefaultSigningKeyID, "secret", true),
+			others:        []*SigningKey{hmacKey("", "old-secret", false)},
+			expectedValid: false,
+		},
+		{
+			name:          "Duplicate key ID",
+			active:        hmacKey("2024", "new-secret", true),
+			others:        []*SigningKey{hmacKey("2024", "old-secret", false)},
+			expectedValid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys, err := NewKeySet(tc.active, tc.others...)
+			isValid := err == nil
+
+			if isValid != tc.expectedValid {
+				if tc.expectedValid {
+					t.Errorf("Expected the key set to be built but got error: %v", err)
+				} else {
+					t.Errorf("Expected an error but the key set was built")
+				}
+			}
+			if isValid && keys.Active() != tc.active {
+				t.Errorf("Expected the active key to be %q but got %q", tc.active.ID, keys.Active().ID)
+			}
+		})
+	}
+}
+
+func TestKeySetLookup(t *testing.T) {
+	active := hmacKey(DefaultSigningKeyID, "secret", true)
+	previous := hmacKey("2023", "old-secret", false)
+	retiredRSA := rsaKey(t, "rsa-2022")
+	retiredRSA.SignKey = nil
+
+	keys, err := NewKeySet(active, previous, retiredRSA)
+	if err != nil {
+		t.Fatalf("Failed to build key set: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		kid           string
+		expectedKey   *SigningKey
+		expectedFound bool
+	}{
+		{
+			name:          "Token without a kid uses the default key",
+			kid:           "",
+			expectedKey:   active,
+			expectedFound: true,
+		},
+		{
+			name:          "Active key",
+			kid:           DefaultSigningKeyID,
+			expectedKey:   active,
+			expectedFound: true,
+		},
+		{
+			name:          "Previous HMAC key",
+			kid:           "2023",
+			expectedKey:   previous,
+			expectedFound: true,
+		},
+		{
+			name:          "Previous public key",
+			kid:           "rsa-2022",
+			expectedKey:   retiredRSA,
+			expectedFound: true,
+		},
+		{
+			name:          "Unknown kid",
+			kid:           "2021",
+			expectedFound: false,
+		},
+		{
+			name:          "Kid differing only in case",
+			kid:           "Default",
+			expectedFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, found := keys.Lookup(tc.kid)
+
+			if found != tc.expectedFound {
+				t.Fatalf("Expected found to be %v but got %v", tc.expectedFound, found)
+			}
+			if key != tc.expectedKey {
+				t.Errorf("Expected key %v but got %v", tc.expectedKey, key)
+			}
+		})
+	}
+}
+
+func TestKeySetJWKS(t *testing.T) {
+	activeRSA := rsaKey(t, "rsa-2024")
+	activeECDSA := ecdsaKey(t, "ec-2024")
+	retiredRSA := rsaKey(t, "rsa-2023")
+	retiredRSA.SignKey = nil
+
+	testCases := []struct {
+		name         string
+		active       *SigningKey
+		others       []*SigningKey
+		expectedKids []string
+	}{
+		{
+			name:         "Active HMAC secret is never published",
+			active:       hmacKey(DefaultSigningKeyID, "secret", true),
+			expectedKids: []string{},
+		},
+		{
+			name:         "Previous HMAC secrets are never published",
+			active:       hmacKey(DefaultSigningKeyID, "secret", true),
+			others:       []*SigningKey{hmacKey("2023", "old-secret", false)},
+			expectedKids: []string{},
+		},
+		{
+			name:         "RSA key",
+			active:       activeRSA,
+			expectedKids: []string{"rsa-2024"},
+		},
+		{
+			name:         "ECDSA key with a previous HMAC secret",
+			active:       activeECDSA,
+			others:       []*SigningKey{hmacKey("2023", "old-secret", false)},
+			expectedKids: []string{"ec-2024"},
+		},
+		{
+			name:         "Active and retired public keys sorted by kid",
+			active:       activeRSA,
+			others:       []*SigningKey{retiredRSA, activeECDSA},
+			expectedKids: []string{"ec-2024", "rsa-2023", "rsa-2024"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys, err := NewKeySet(tc.active, tc.others...)
+			if err != nil {
+				t.Fatalf("Failed to build key set: %v", err)
+			}
+			jwks := keys.JWKS()
+
+			if len(jwks.Keys) != len(tc.expectedKids) {
+				t.Fatalf("Expected %d keys but got %d: %+v", len(tc.expectedKids), len(jwks.Keys), jwks.Keys)
+			}
+			for i, jwk := range jwks.Keys {
+				if jwk.Kid != tc.expectedKids[i] {
+					t.Errorf("Expected key %d to be %q but got %q", i, tc.expectedKids[i], jwk.Kid)
+				}
+				if jwk.Use != "sig" {
+					t.Errorf("Expected key %q to be used for signatures but got %q", jwk.Kid, jwk.Use)
+				}
+				if jwk.Kty == "oct" || jwk.Kty == "" {
+					t.Errorf("Expected key %q to be a public key but got type %q", jwk.Kid, jwk.Kty)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"leaderboard-service/db"
+)
+
+// StatementTimeout gives each request a deadline of db.StatementTimeout, so queries run with the request's context
+// are cancelled once it passes. They are also cancelled as soon as the client disconnects.
+func StatementTimeout(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if db.StatementTimeout > 0 {
+			ctx, cancel := context.WithTimeout(r.Context(), db.StatementTimeout)
+			defer cancel()
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
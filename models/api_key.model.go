@@ -0,0 +1,46 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	// APIKeyScopeRead allows safe methods only (GET, HEAD, OPTIONS)
+	APIKeyScopeRead = "read"
+	// APIKeyScopeWrite allows every method
+	APIKeyScopeWrite = "write"
+)
+
+// APIKey authenticates backend services via the X-API-Key header.
+// Only a SHA-256 hash of the key is stored; the plaintext is shown once on creation.
+type APIKey struct {
+	BaseModel
+	Name       string     `gorm:"not null"`
+	Prefix     string     `gorm:"not null"` // First characters of the key so admins can tell keys apart
+	KeyHash    string     `gorm:"not null;uniqueIndex" json:"-"`
+	Role       string     `gorm:"not null"`
+	Scopes     []string   `gorm:"type:jsonb;serializer:json;not null"`
+	ExpiresAt  *time.Time // Optional, nil never expires
+	LastUsedAt *time.Time
+}
+
+// IsExpired reports whether the key is past its expiry time
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// AllowsMethod reports whether the key's scopes permit an HTTP method
+func (k *APIKey) AllowsMethod(method string) bool {
+	for _, scope := range k.Scopes {
+		switch scope {
+		case APIKeyScopeWrite:
+			return true
+		case APIKeyScopeRead:
+			if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// AppliedScoreIncrement marks an entry.score_incremented event whose delta has been added to the entry's score, so
+// an increment is never applied twice
+type AppliedScoreIncrement struct {
+	EventID   uint64    `gorm:"primaryKey;autoIncrement:false"`
+	AppliedAt time.Time `gorm:"not null"`
+}
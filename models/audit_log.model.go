@@ -0,0 +1,24 @@
+package models
+
+import (
+	"leaderboard-service/enums"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLog records a change to a leaderboard, entry, metric or participant: who made it, what the entity was before
+// and after, and the fields that changed. IDs are strictly increasing so clients can page with after_id.
+type AuditLog struct {
+	ID         uint64                `gorm:"primaryKey;autoIncrement"`
+	EntityType enums.AuditEntityType `gorm:"not null;index:idx_audit_logs_entity,priority:1"`
+	EntityID   uuid.UUID             `gorm:"type:uuid;not null;index:idx_audit_logs_entity,priority:2"`
+	Action     enums.AuditAction     `gorm:"not null"`
+	ActorType  string                `gorm:"not null"`                   // user, service, api_key or system
+	ActorID    string                `gorm:"not null;default:'';index"`  // User ID, service token subject or API key ID, empty for the system
+	Before     interface{}           `gorm:"type:jsonb;serializer:json"` // Unset for creates
+	After      interface{}           `gorm:"type:jsonb;serializer:json"` // Unset for deletes
+	// Changes holds the fields whose value differs between Before and After, each as {"before": ..., "after": ...}
+	Changes    interface{} `gorm:"type:jsonb;serializer:json"`
+	OccurredAt time.Time   `gorm:"not null;index"`
+}
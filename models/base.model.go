@@ -13,3 +13,17 @@ type BaseModel struct {
 	UpdatedAt time.Time      `gorm:"default:CURRENT_TIMESTAMP;not null"`
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
+
+// BeforeCreate gives new records a time-ordered UUIDv7, so inserts land at the end of the primary key index instead
+// of all over it. The column default only applies to rows inserted by SQL statements.
+func (m *BaseModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID != uuid.Nil {
+		return nil
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+	m.ID = id
+	return nil
+}
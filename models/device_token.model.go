@@ -0,0 +1,15 @@
+package models
+
+import (
+	"leaderboard-service/enums"
+
+	"github.com/google/uuid"
+)
+
+// DeviceToken maps a participant to a device that can receive push notifications
+type DeviceToken struct {
+	BaseModel
+	ParticipantID uuid.UUID          `gorm:"type:uuid;not null;index"`
+	Provider      enums.PushProvider `gorm:"type:varchar(20);not null"`
+	Token         string             `gorm:"not null;uniqueIndex"`
+}
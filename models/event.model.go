@@ -0,0 +1,18 @@
+package models
+
+import (
+	"leaderboard-service/enums"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is a persisted domain event. IDs are strictly increasing so clients can poll with after_id
+type Event struct {
+	ID            uint64          `gorm:"primaryKey;autoIncrement"`
+	Type          enums.EventType `gorm:"not null;index"`
+	LeaderboardID *uuid.UUID      `gorm:"type:uuid;index"`
+	Data          interface{}     `gorm:"type:jsonb;serializer:json"`
+	OccurredAt    time.Time       `gorm:"not null"`
+	CreatedAt     time.Time       `gorm:"default:CURRENT_TIMESTAMP;not null"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"leaderboard-service/enums"
+	"time"
+)
+
+// FeatureFlag overrides the value a feature flag starts with on every instance, until it is reset
+type FeatureFlag struct {
+	Name      enums.FeatureFlag `gorm:"primaryKey"`
+	Enabled   bool              `gorm:"not null"`
+	UpdatedBy string            `gorm:"not null;default:''"` // Token subject of the admin who set it
+	UpdatedAt time.Time         `gorm:"not null"`
+}
@@ -3,6 +3,8 @@ package models
 import (
 	"leaderboard-service/enums"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type Leaderboard struct {
@@ -18,7 +20,25 @@ type Leaderboard struct {
 	VisibilityScope enums.VisibilityScope `gorm:"not null"`
 	MaxEntries      int
 	IsActive        bool
+	OwnerID         *uuid.UUID `gorm:"type:uuid;index"` // User that created the leaderboard, nil for boards created before ownership existed
+	GroupID         *uuid.UUID `gorm:"type:uuid;index"` // Group participant whose descendants the leaderboard ranks, nil for everyone
+
+	// What happens to a new entry once the board has MaxEntries entries; boards with MaxEntries 0 have no limit
+	MaxEntriesPolicy enums.MaxEntriesPolicy `gorm:"not null;default:'reject'"`
+
+	// How team boards combine member scores, see TeamScoreService
+	TeamScoreAggregation enums.TeamScoreAggregation `gorm:"not null;default:'sum'"`
+	TeamScoreTopK        int                        // Number of best member scores counted by top_k
+
+	Version int `gorm:"not null;default:1"` // Incremented by every update, which only applies to the version it was read at
+
+	// Removed with the leaderboard when it is deleted from the database
+	Metrics []LeaderboardMetric `gorm:"foreignKey:LeaderboardID;references:ID;constraint:OnDelete:CASCADE"`
+	Entries []LeaderboardEntry  `gorm:"foreignKey:LeaderboardID;references:ID;constraint:OnDelete:CASCADE"`
+}
 
-	Metrics []LeaderboardMetric `gorm:"foreignKey:LeaderboardID;references:ID"`
-	Entries []LeaderboardEntry  `gorm:"foreignKey:LeaderboardID;references:ID"`
+// HasEnded reports whether the leaderboard's end_date has passed at the given time, after which its standings are
+// final
+func (l *Leaderboard) HasEnded(at time.Time) bool {
+	return l.EndDate != nil && !l.EndDate.After(at)
 }
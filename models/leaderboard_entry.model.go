@@ -6,12 +6,23 @@ import (
 	"github.com/google/uuid"
 )
 
-// LeaderboardEntry represents an entry/ranking in a leaderboard
+// LeaderboardEntry represents an entry/ranking in a leaderboard. A participant has at most one live entry per
+// leaderboard.
 type LeaderboardEntry struct {
 	BaseModel
-	LeaderboardID uuid.UUID `gorm:"type:uuid;not null"`
-	ParticipantID uuid.UUID `gorm:"type:uuid;not null"`
-	Rank          int       `gorm:"not null"`
+	LeaderboardID uuid.UUID `gorm:"type:uuid;not null;index:idx_leaderboard_entries_leaderboard_rank,priority:1;index:idx_leaderboard_entries_leaderboard_participant,priority:1;uniqueIndex:idx_leaderboard_entries_leaderboard_participant_unique,priority:1,where:deleted_at IS NULL"`
+	ParticipantID uuid.UUID `gorm:"type:uuid;not null;index:idx_leaderboard_entries_leaderboard_participant,priority:2;uniqueIndex:idx_leaderboard_entries_leaderboard_participant_unique,priority:2,where:deleted_at IS NULL"`
+	Rank          int       `gorm:"not null;index:idx_leaderboard_entries_leaderboard_rank,priority:2"`
 	Score         float64   `gorm:"not null"`
 	LastUpdated   time.Time `gorm:"not null"`
+	Version       int       `gorm:"not null;default:1"` // Incremented by every update and re-rank, which only apply to the version they read
+
+	// The participant's shown name and avatar, copied onto the entry so standings can be displayed from entries
+	// alone; the participant service keeps them in sync
+	ParticipantName string `gorm:"not null;default:''"`
+	AvatarURL       string `gorm:"not null;default:''"`
+
+	// Association to the participant, loaded for display when entries are read. The entry is removed with the
+	// participant when it is deleted from the database.
+	Participant *Participant `gorm:"foreignKey:ParticipantID;references:ID;constraint:OnDelete:CASCADE"`
 }
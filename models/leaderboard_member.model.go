@@ -0,0 +1,15 @@
+package models
+
+import (
+	"leaderboard-service/enums"
+
+	"github.com/google/uuid"
+)
+
+// LeaderboardMember grants a user a role on a single leaderboard
+type LeaderboardMember struct {
+	BaseModel
+	LeaderboardID uuid.UUID             `gorm:"type:uuid;not null;uniqueIndex:idx_leaderboard_member"`
+	UserID        uuid.UUID             `gorm:"type:uuid;not null;uniqueIndex:idx_leaderboard_member;index"`
+	Role          enums.LeaderboardRole `gorm:"type:varchar(20);not null"`
+}
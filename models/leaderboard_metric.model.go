@@ -7,8 +7,8 @@ import (
 // LeaderboardMetric represents a metric associated with a leaderboard
 type LeaderboardMetric struct {
 	BaseModel
-	LeaderboardID   uuid.UUID `gorm:"type:uuid;not null"`
-	MetricID        uuid.UUID `gorm:"type:uuid;not null"`
+	LeaderboardID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_leaderboard_metrics_leaderboard_metric_unique,priority:1,where:deleted_at IS NULL"`
+	MetricID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_leaderboard_metrics_leaderboard_metric_unique,priority:2,where:deleted_at IS NULL"`
 	Weight          float64   `gorm:"not null;default:1.0"`
 	DisplayPriority int       `gorm:"not null;default:0"`
 }
@@ -0,0 +1,12 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// LeaderboardParticipantAccess allows the user linked to a participant to read a private leaderboard
+type LeaderboardParticipantAccess struct {
+	BaseModel
+	LeaderboardID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_leaderboard_participant_access"`
+	ParticipantID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_leaderboard_participant_access;index"`
+}
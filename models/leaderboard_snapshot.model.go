@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LeaderboardSnapshot freezes a leaderboard's standings at a point in time for reports and comparisons
+type LeaderboardSnapshot struct {
+	BaseModel
+	LeaderboardID uuid.UUID `gorm:"type:uuid;not null;index"`
+	TakenAt       time.Time `gorm:"not null"`
+	EntryCount    int       `gorm:"not null"`
+
+	Entries []LeaderboardSnapshotEntry `gorm:"foreignKey:SnapshotID;references:ID"`
+}
+
+// LeaderboardSnapshotEntry is one standing in a snapshot. Participant names are not copied, so anonymizing a
+// participant hides it in past snapshots too.
+type LeaderboardSnapshotEntry struct {
+	BaseModel
+	SnapshotID    uuid.UUID        `gorm:"type:uuid;not null;index"`
+	ParticipantID uuid.UUID        `gorm:"type:uuid;not null"`
+	Rank          int              `gorm:"not null"`
+	Score         float64          `gorm:"not null"`
+	Metrics       []SnapshotMetric `gorm:"type:jsonb;serializer:json"` // The participant's value for each of the leaderboard's metrics
+
+	// Association to the participant, loaded for display when snapshots are read
+	Participant *Participant `gorm:"foreignKey:ParticipantID;references:ID"`
+}
+
+// SnapshotMetric is what one of the leaderboard's metrics contributed to a snapshot entry
+type SnapshotMetric struct {
+	MetricID      uuid.UUID
+	MetricName    string
+	Value         float64
+	Weight        float64
+	WeightedValue float64
+}
@@ -0,0 +1,17 @@
+package models
+
+const (
+	// LoginFailureInvalidCredentials counts towards lockout
+	LoginFailureInvalidCredentials = "invalid_credentials"
+	// LoginFailureThrottled is recorded for audit only so retries during a lockout do not extend it
+	LoginFailureThrottled = "throttled"
+)
+
+// LoginAttempt is an audit record of a call to /auth/login, also used to throttle repeated failures
+type LoginAttempt struct {
+	BaseModel
+	Username      string `gorm:"not null;index"` // Lowercased so case variations share a counter
+	IPAddress     string `gorm:"not null;index"`
+	Succeeded     bool   `gorm:"not null"`
+	FailureReason string // Empty for successful attempts
+}
@@ -15,6 +15,9 @@ type Metric struct {
 	ResetPeriod     enums.ResetPeriod     `gorm:"not null"` // e.g., "none", "daily", "weekly", "monthly", "yearly"
 	IsHigherBetter  bool                  `gorm:"not null"`
 
-	// Association to MetricValues
-	Values []MetricValue `gorm:"foreignKey:MetricID;references:ID"`
+	// Association to MetricValues. A metric with recorded values can't be deleted from the database.
+	Values []MetricValue `gorm:"foreignKey:MetricID;references:ID;constraint:OnDelete:RESTRICT"`
+
+	// Leaderboards scored by the metric, only declared for its foreign key
+	LeaderboardMetrics []LeaderboardMetric `gorm:"foreignKey:MetricID;references:ID;constraint:OnDelete:CASCADE" json:"-"`
 }
@@ -9,10 +9,12 @@ import (
 // MetricValue stores actual recorded values for metrics for each participant
 type MetricValue struct {
 	BaseModel
-	MetricID      uuid.UUID   `gorm:"type:uuid;not null"`
-	ParticipantID uuid.UUID   `gorm:"type:uuid;not null"`
-	Value         float64     `gorm:"not null"`
-	Timestamp     time.Time   `gorm:"not null"`
+	MetricID      uuid.UUID   `gorm:"type:uuid;not null;index:idx_metric_values_metric_participant_timestamp,priority:1"`
+	ParticipantID uuid.UUID   `gorm:"type:uuid;not null;index:idx_metric_values_metric_participant_timestamp,priority:2;index:idx_metric_values_participant_timestamp,priority:1"`
+	Value         float64     `gorm:"not null"` // The recorded number, 1 or 0 for boolean values and 0 for string ones
+	BoolValue     *bool       // Set only on values of boolean metrics
+	StringValue   *string     `gorm:"type:text"` // Set only on values of string metrics
+	Timestamp     time.Time   `gorm:"not null;index:idx_metric_values_metric_participant_timestamp,priority:3;index:idx_metric_values_participant_timestamp,priority:2;index:idx_metric_values_timestamp"`
 	Source        string      // Identifies where/how this value was recorded
 	Context       interface{} `gorm:"type:jsonb"` // For any additional data (e.g., distinguishing call vs. text)
 
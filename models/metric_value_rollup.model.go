@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MetricValueRollup summarises a participant's raw values for a metric on one UTC day, once they are old enough to
+// be compacted. The raw values it replaces are deleted, so it is read alongside the remaining raw values.
+type MetricValueRollup struct {
+	BaseModel
+	MetricID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_metric_value_rollups_metric_participant_day,priority:1"`
+	ParticipantID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_metric_value_rollups_metric_participant_day,priority:2"`
+	Day             time.Time `gorm:"not null;uniqueIndex:idx_metric_value_rollups_metric_participant_day,priority:3"` // Midnight UTC
+	Count           int64     `gorm:"not null"`
+	Sum             float64   `gorm:"not null"`
+	Min             float64   `gorm:"not null"`
+	Max             float64   `gorm:"not null"`
+	LastValue       float64   `gorm:"not null"`
+	LastBoolValue   *bool     // Latest boolean value rolled up, for boolean metrics
+	LastStringValue *string   `gorm:"type:text"` // Latest string value rolled up, for string metrics
+	FirstRecordedAt time.Time `gorm:"not null"`  // Timestamp of the earliest value rolled up
+	LastRecordedAt  time.Time `gorm:"not null"`  // Timestamp of the latest value rolled up
+}
@@ -1,12 +1,39 @@
 package models
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type Participant struct {
 	BaseModel
-	ExternalID string      `gorm:"index"`
+	ExternalID string      `gorm:"uniqueIndex:idx_participants_external_id_unique,where:external_id <> '' AND deleted_at IS NULL"` // Caller's own identifier, unique when set
 	Name       string      `gorm:"not null"`
 	Type       string      `gorm:"not null"` // individual, team, group
 	Metadata   interface{} `gorm:"type:jsonb"`
+	UserID     *uuid.UUID  `gorm:"type:uuid;uniqueIndex"` // Authenticated user (JWT user_id) that plays as this participant
+	ParentID   *uuid.UUID  `gorm:"type:uuid;index"`       // Group the participant belongs to in the hierarchy, nil at the top level
+	IsActive   bool        `gorm:"not null;default:true"` // Inactive participants keep their entries but are left out of published standings
+
+	AnonymizedAt *time.Time // Set once the participant's personal data has been scrubbed
+
+	// Profile shown next to the participant's entries; DisplayName falls back to Name in clients
+	DisplayName string
+	AvatarURL   string
+	Country     string `gorm:"size:2"` // ISO 3166-1 alpha-2 code
+
+	// Labels for segmenting boards and notifications, rendered as strings
+	Tags []ParticipantTag `gorm:"foreignKey:ParticipantID;references:ID"`
+
+	// Association to MetricValues, removed with the participant when it is deleted from the database
+	MetricValues []MetricValue `gorm:"foreignKey:ParticipantID;references:ID;constraint:OnDelete:CASCADE"`
+}
 
-	// Association to MetricValues
-	MetricValues []MetricValue `gorm:"foreignKey:ParticipantID;references:ID"`
+// ShownName is the name displayed for the participant: its display name, or its name when it has none
+func (p *Participant) ShownName() string {
+	if p.DisplayName != "" {
+		return p.DisplayName
+	}
+	return p.Name
 }
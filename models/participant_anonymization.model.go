@@ -0,0 +1,14 @@
+package models
+
+import "github.com/google/uuid"
+
+// ParticipantAnonymization is the audit record of a participant's personal data being scrubbed.
+// It holds no personal data itself, only who asked, why and what was removed.
+type ParticipantAnonymization struct {
+	BaseModel
+	ParticipantID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Pseudonym           string    `gorm:"not null"` // Name the participant's scores are kept under
+	RequestedBy         string    `gorm:"not null"` // Subject of the token that made the request
+	Reason              string
+	MetricValuesDeleted int64 // Raw metric values removed, 0 when they were kept
+}
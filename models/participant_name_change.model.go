@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ParticipantNameChange records the name and display name a participant used over a period of time.
+// Renaming a participant ends the current record and starts a new one, so past names can be shown as they were.
+type ParticipantNameChange struct {
+	BaseModel
+	ParticipantID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name          string    `gorm:"not null"`
+	DisplayName   string
+	EffectiveFrom time.Time  `gorm:"not null"`
+	EffectiveTo   *time.Time // Nil for the name in use now
+}
+
+// InEffectAt reports whether the name was in use at the given time
+func (c *ParticipantNameChange) InEffectAt(at time.Time) bool {
+	return !c.EffectiveFrom.After(at) && (c.EffectiveTo == nil || c.EffectiveTo.After(at))
+}
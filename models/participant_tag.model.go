@@ -0,0 +1,19 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// ParticipantTag labels a participant for segmenting leaderboards and notifications, e.g. "vip"
+type ParticipantTag struct {
+	BaseModel
+	ParticipantID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_participant_tags_participant_tag"`
+	Tag           string    `gorm:"not null;size:50;index;uniqueIndex:idx_participant_tags_participant_tag"` // Lowercase
+}
+
+// MarshalJSON renders a tag as its name so participants list their tags as plain strings
+func (t ParticipantTag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Tag)
+}
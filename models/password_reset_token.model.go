@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetToken is a single-use token emailed to a user to set a new password.
+// Only a SHA-256 hash of the token is stored.
+type PasswordResetToken struct {
+	BaseModel
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	TokenHash string    `gorm:"not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceToken is the server-side record of a machine JWT. The token itself is not stored;
+// its jti claim is the record ID so it can be listed and revoked individually.
+type ServiceToken struct {
+	BaseModel
+	Name       string     `gorm:"not null"`
+	Audience   []string   `gorm:"type:jsonb;serializer:json;not null"`
+	Scopes     []string   `gorm:"type:jsonb;serializer:json;not null"`
+	ExpiresAt  time.Time  `gorm:"not null"`
+	RevokedAt  *time.Time `gorm:"index"`
+	LastUsedAt *time.Time
+	CreatedBy  *uuid.UUID `gorm:"type:uuid"` // Nil when minted by a caller that is not a user
+}
+
+// IsActive reports whether the token is neither revoked nor expired
+func (t *ServiceToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StandingSummary records when a leaderboard's standing aggregates were last rebuilt, and the version of the board
+// they were built from
+type StandingSummary struct {
+	BaseModel
+	LeaderboardID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Version       time.Time `gorm:"not null"` // When the leaderboard was last modified as of the rebuild
+	RefreshedAt   time.Time `gorm:"not null"`
+}
+
+// StandingAggregate summarises a participant's values for one of a leaderboard's metrics within the leaderboard's
+// date range. Aggregates are derived from the metric values and rebuilt per leaderboard, never edited.
+type StandingAggregate struct {
+	BaseModel
+	LeaderboardID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_standing_aggregates_leaderboard_participant_metric,priority:1"`
+	ParticipantID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_standing_aggregates_leaderboard_participant_metric,priority:2"`
+	MetricID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_standing_aggregates_leaderboard_participant_metric,priority:3"`
+	Count           int64      `gorm:"not null"`
+	Sum             float64    `gorm:"not null"`
+	Min             float64    `gorm:"not null"`
+	Max             float64    `gorm:"not null"`
+	Average         float64    `gorm:"not null"`
+	LastValue       float64    `gorm:"not null"`
+	LastBoolValue   *bool      // Most recent value of a boolean metric
+	LastStringValue *string    `gorm:"type:text"` // Most recent value of a string metric
+	LastRecordedAt  *time.Time // Timestamp of the most recent value
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TeamMember records a participant's membership of a team participant over a period of time.
+// Leaving sets LeftAt rather than deleting the row, and rejoining adds a new row, so past rosters can be reconstructed.
+type TeamMember struct {
+	BaseModel
+	TeamID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	MemberID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	JoinedAt time.Time  `gorm:"not null"`
+	LeftAt   *time.Time // Nil while the member is still on the team
+
+	// Association to the member participant
+	Member *Participant `gorm:"foreignKey:MemberID;references:ID"`
+}
+
+// IsActiveAt reports whether the membership covers the given time
+func (m *TeamMember) IsActiveAt(at time.Time) bool {
+	return !m.JoinedAt.After(at) && (m.LeftAt == nil || m.LeftAt.After(at))
+}
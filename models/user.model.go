@@ -0,0 +1,10 @@
+package models
+
+// User is an account that can log in to the API
+type User struct {
+	BaseModel
+	Username     string `gorm:"not null;uniqueIndex"`
+	Email        string `gorm:"not null;uniqueIndex"`
+	PasswordHash string `gorm:"not null" json:"-"`
+	Role         string `gorm:"not null"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSubscription registers an external endpoint to receive domain events
+type WebhookSubscription struct {
+	BaseModel
+	TargetURL     string     `gorm:"not null"`
+	Secret        string     `gorm:"not null" json:"-"` // Used to sign deliveries, never returned by the API
+	EventTypes    []string   `gorm:"type:jsonb;serializer:json;not null"`
+	LeaderboardID *uuid.UUID `gorm:"type:uuid;index"` // Optional filter, nil receives events for every leaderboard
+	IsActive      bool       `gorm:"not null"`
+
+	LastDeliveryAt     *time.Time
+	LastDeliveryStatus int
+	LastDeliveryError  string
+}
+
+// Matches reports whether the subscription wants an event of the given type for the given leaderboard
+func (s *WebhookSubscription) Matches(eventType string, leaderboardID *uuid.UUID) bool {
+	if !s.IsActive {
+		return false
+	}
+
+	if s.LeaderboardID != nil && (leaderboardID == nil || *s.LeaderboardID != *leaderboardID) {
+		return false
+	}
+
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
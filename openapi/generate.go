@@ -0,0 +1,200 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Version is the OpenAPI version of the generated documents
+const Version = "3.0.3"
+
+// Config describes what the routes alone can't tell about the API
+type Config struct {
+	Info Info
+	// RequestBodies maps a handler's name, as returned by HandlerName, to a value of the struct it decodes
+	RequestBodies map[string]interface{}
+	// ErrorResponse is a value of the body sent with every error
+	ErrorResponse interface{}
+	// Authenticate is the middleware guarding the routes that need credentials
+	Authenticate func(http.Handler) http.Handler
+}
+
+var pathParam = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// Generate describes every route registered on a router. A route's body schema comes from the struct its handler
+// is registered with in the config and its constraints from the struct's validate tags.
+func Generate(routes chi.Routes, config Config) (*Document, error) {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    config.Info,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]SecurityScheme{
+				"BearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+				"ApiKeyAuth": {Type: "apiKey", In: "header", Name: "X-API-Key"},
+			},
+		},
+	}
+	components := schemas(doc.Components.Schemas)
+
+	errorResponse := Response{Description: "Error"}
+	if config.ErrorResponse != nil {
+		errorResponse.Content = jsonContent(components.ref(reflect.TypeOf(config.ErrorResponse)))
+	}
+
+	var authenticate uintptr
+	if config.Authenticate != nil {
+		authenticate = reflect.ValueOf(config.Authenticate).Pointer()
+	}
+
+	operationIDs := map[string]int{}
+
+	err := walk(routes, "", nil, func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		// Catch-all routes such as the Swagger UI serve files rather than an API
+		if strings.Contains(route, "*") {
+			return nil
+		}
+		if len(route) > 1 {
+			route = strings.TrimSuffix(route, "/")
+		}
+
+		operation := &Operation{
+			Responses: map[string]Response{
+				"2XX":     {Description: "Success"},
+				"default": errorResponse,
+			},
+		}
+		if tag := routeTag(route); tag != "" {
+			operation.Tags = []string{tag}
+		}
+
+		for _, match := range pathParam.FindAllStringSubmatch(route, -1) {
+			operation.Parameters = append(operation.Parameters, Parameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+		route = pathParam.ReplaceAllString(route, "{$1}")
+
+		name := HandlerName(handler)
+		if body, ok := config.RequestBodies[name]; ok {
+			operation.RequestBody = &RequestBody{
+				Required: true,
+				Content:  jsonContent(components.ref(reflect.TypeOf(body))),
+			}
+		}
+		if operationID := operationName(name); operationID != "" {
+			operationIDs[operationID]++
+			operation.OperationID = operationID
+		}
+
+		for _, mw := range middlewares {
+			if authenticate != 0 && reflect.ValueOf(mw).Pointer() == authenticate {
+				operation.Security = []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}}
+				break
+			}
+		}
+
+		if doc.Paths[route] == nil {
+			doc.Paths[route] = PathItem{}
+		}
+		doc.Paths[route][strings.ToLower(method)] = operation
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Operation IDs must be unique, so handlers serving several routes are told apart by their method and path
+	for route, item := range doc.Paths {
+		for method, operation := range item {
+			if operationIDs[operation.OperationID] > 1 {
+				operation.OperationID = operation.OperationID + "_" + method + strings.NewReplacer("/", "_", "{", "", "}", "", ".", "", "-", "_").Replace(route)
+			}
+		}
+	}
+
+	return doc, nil
+}
+
+// walk visits every route like chi.Walk, but also passes on the middlewares of groups that subrouters are mounted
+// in, such as the authentication of the protected routes
+func walk(routes chi.Routes, parent string, parentMiddlewares []func(http.Handler) http.Handler, walkFn chi.WalkFunc) error {
+	middlewares := append(append([]func(http.Handler) http.Handler{}, parentMiddlewares...), routes.Middlewares()...)
+
+	for _, route := range routes.Routes() {
+		if route.SubRoutes != nil {
+			subMiddlewares := middlewares
+			for _, handler := range route.Handlers {
+				if chain, ok := handler.(*chi.ChainHandler); ok {
+					subMiddlewares = append(append([]func(http.Handler) http.Handler{}, middlewares...), chain.Middlewares...)
+				}
+				break
+			}
+			if err := walk(route.SubRoutes, parent+strings.TrimSuffix(route.Pattern, "/*"), subMiddlewares, walkFn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for method, handler := range route.Handlers {
+			// Routes registered for every method are also listed under each method
+			if method == "*" {
+				continue
+			}
+			handlerMiddlewares := middlewares
+			if chain, ok := handler.(*chi.ChainHandler); ok {
+				handler = chain.Endpoint
+				handlerMiddlewares = append(append([]func(http.Handler) http.Handler{}, middlewares...), chain.Middlewares...)
+			}
+			if err := walkFn(method, parent+route.Pattern, handler, handlerMiddlewares...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// HandlerName names the function behind a handler, the same for a method value such as h.CreateLeaderboard and
+// the method expression (*LeaderboardHandler).CreateLeaderboard
+func HandlerName(handler interface{}) string {
+	value := reflect.ValueOf(handler)
+	if value.Kind() != reflect.Func {
+		return ""
+	}
+	fn := runtime.FuncForPC(value.Pointer())
+	if fn == nil {
+		return ""
+	}
+	return strings.TrimSuffix(fn.Name(), "-fm")
+}
+
+// operationName is the method or function name of a handler, empty for closures
+func operationName(handlerName string) string {
+	if handlerName == "" || strings.Contains(handlerName, ".func") {
+		return ""
+	}
+	return handlerName[strings.LastIndex(handlerName, ".")+1:]
+}
+
+// routeTag groups operations by the first segment of their path after the API version. Unversioned routes
+// such as health checks are not grouped.
+func routeTag(route string) string {
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	if len(segments) < 3 || segments[0] != "api" {
+		return ""
+	}
+	return segments[2]
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
@@ -0,0 +1,223 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemas builds component schemas from Go types the way encoding/json sees them, with the constraints of their
+// validate tags
+type schemas map[string]*Schema
+
+// ref returns a reference to the schema of a named struct type, adding it and the structs it uses to the components
+func (s schemas) ref(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.Name() == "" || t == timeType {
+		return s.of(t)
+	}
+
+	if _, ok := s[t.Name()]; !ok {
+		// Claim the name first so self-referencing types don't recurse forever
+		s[t.Name()] = &Schema{}
+		*s[t.Name()] = *s.object(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + t.Name()}
+}
+
+// of returns the schema of a type, referring to named structs
+func (s schemas) of(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0.0
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.ref(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.ref(t.Elem())}
+	case reflect.Struct:
+		if t.Name() != "" {
+			return s.ref(t)
+		}
+		return s.object(t)
+	default:
+		// Interfaces hold any JSON value
+		return &Schema{}
+	}
+}
+
+// object describes a struct's JSON fields, promoting the fields of embedded structs like encoding/json
+func (s schemas) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			embedded := s.object(fieldType)
+			for property, propertySchema := range embedded.Properties {
+				if _, ok := schema.Properties[property]; !ok {
+					schema.Properties[property] = propertySchema
+				}
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := s.of(field.Type)
+		if property.Ref == "" {
+			if example, ok := field.Tag.Lookup("example"); ok {
+				property.Example = exampleValue(example, property.Type)
+			}
+			if enums, ok := field.Tag.Lookup("enums"); ok {
+				property.Enum = strings.Split(enums, ",")
+			}
+		}
+		if applyValidation(property, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+// applyValidation adds the constraints of a validate tag to a field's schema and reports whether the field is
+// required. Rules after dive apply to the items of a list.
+func applyValidation(schema *Schema, tag string) bool {
+	if tag == "" {
+		return false
+	}
+
+	rules := strings.Split(tag, ",")
+	required := false
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "dive":
+			if schema.Items != nil && schema.Items.Ref == "" {
+				applyValidation(schema.Items, strings.Join(rules[i+1:], ","))
+			}
+			return required
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		case "email":
+			schema.Format = "email"
+		case "url", "http_url":
+			schema.Format = "uri"
+		case "uuid", "uuid4":
+			schema.Format = "uuid"
+		case "datetime":
+			schema.Format = "date-time"
+		case "min", "max", "len":
+			bound, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				continue
+			}
+			setBound(schema, name, bound)
+		}
+	}
+	return required
+}
+
+// setBound applies a min, max or len rule, which bounds the length of strings and lists and the value of numbers
+func setBound(schema *Schema, rule string, bound float64) {
+	length := int(bound)
+	switch schema.Type {
+	case "string":
+		if rule != "max" {
+			schema.MinLength = &length
+		}
+		if rule != "min" {
+			schema.MaxLength = &length
+		}
+	case "array":
+		if rule != "max" {
+			schema.MinItems = &length
+		}
+		if rule != "min" {
+			schema.MaxItems = &length
+		}
+	case "integer", "number":
+		if rule != "max" {
+			schema.Minimum = &bound
+		}
+		if rule != "min" {
+			schema.Maximum = &bound
+		}
+	}
+}
+
+// exampleValue converts an example tag to the JSON type of its field, keeping it as text when it does not parse
+func exampleValue(example, schemaType string) interface{} {
+	switch schemaType {
+	case "integer", "number":
+		if number, err := strconv.ParseFloat(example, 64); err == nil {
+			return number
+		}
+	case "boolean":
+		if value, err := strconv.ParseBool(example); err == nil {
+			return value
+		}
+	case "array", "object", "":
+		return nil
+	}
+	return example
+}
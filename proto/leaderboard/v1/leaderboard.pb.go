@@ -0,0 +1,3617 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: leaderboard/v1/leaderboard.proto
+
+// Typed API for internal services. It mirrors the REST resources under /api/v1 and goes through the same service
+// layer, validation and permission checks. Enum-like fields carry the same strings as the REST API.
+
+package leaderboardv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Sort orders a list by one of its sortable fields, the same ones the REST ?sort= parameter accepts
+type Sort struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Desc          bool                   `protobuf:"varint,2,opt,name=desc,proto3" json:"desc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sort) Reset() {
+	*x = Sort{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sort) ProtoMessage() {}
+
+func (x *Sort) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sort.ProtoReflect.Descriptor instead.
+func (*Sort) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Sort) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Sort) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+type Leaderboard struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Category             string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Type                 string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`                            // individual, team
+	TimeFrame            string                 `protobuf:"bytes,6,opt,name=time_frame,json=timeFrame,proto3" json:"time_frame,omitempty"` // daily, weekly, monthly, yearly, all-time, custom
+	StartDate            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate              *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	SortOrder            string                 `protobuf:"bytes,9,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`                    // ascending, descending
+	VisibilityScope      string                 `protobuf:"bytes,10,opt,name=visibility_scope,json=visibilityScope,proto3" json:"visibility_scope,omitempty"` // public, private
+	MaxEntries           int32                  `protobuf:"varint,11,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	IsActive             bool                   `protobuf:"varint,12,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	OwnerId              *string                `protobuf:"bytes,13,opt,name=owner_id,json=ownerId,proto3,oneof" json:"owner_id,omitempty"`
+	TeamScoreAggregation string                 `protobuf:"bytes,14,opt,name=team_score_aggregation,json=teamScoreAggregation,proto3" json:"team_score_aggregation,omitempty"` // sum, average, top_k
+	TeamScoreTopK        int32                  `protobuf:"varint,15,opt,name=team_score_top_k,json=teamScoreTopK,proto3" json:"team_score_top_k,omitempty"`
+	GroupId              *string                `protobuf:"bytes,16,opt,name=group_id,json=groupId,proto3,oneof" json:"group_id,omitempty"`
+	// Only loaded when asked for with include
+	Metrics          []*LeaderboardMetric   `protobuf:"bytes,17,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	Entries          []*LeaderboardEntry    `protobuf:"bytes,18,rep,name=entries,proto3" json:"entries,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version          int32                  `protobuf:"varint,21,opt,name=version,proto3" json:"version,omitempty"`                                            // Incremented by every update
+	MaxEntriesPolicy string                 `protobuf:"bytes,22,opt,name=max_entries_policy,json=maxEntriesPolicy,proto3" json:"max_entries_policy,omitempty"` // reject, evict_last
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Leaderboard) Reset() {
+	*x = Leaderboard{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Leaderboard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Leaderboard) ProtoMessage() {}
+
+func (x *Leaderboard) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Leaderboard.ProtoReflect.Descriptor instead.
+func (*Leaderboard) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Leaderboard) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Leaderboard) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Leaderboard) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Leaderboard) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Leaderboard) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Leaderboard) GetTimeFrame() string {
+	if x != nil {
+		return x.TimeFrame
+	}
+	return ""
+}
+
+func (x *Leaderboard) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *Leaderboard) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *Leaderboard) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *Leaderboard) GetVisibilityScope() string {
+	if x != nil {
+		return x.VisibilityScope
+	}
+	return ""
+}
+
+func (x *Leaderboard) GetMaxEntries() int32 {
+	if x != nil {
+		return x.MaxEntries
+	}
+	return 0
+}
+
+func (x *Leaderboard) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Leaderboard) GetOwnerId() string {
+	if x != nil && x.OwnerId != nil {
+		return *x.OwnerId
+	}
+	return ""
+}
+
+func (x *Leaderboard) GetTeamScoreAggregation() string {
+	if x != nil {
+		return x.TeamScoreAggregation
+	}
+	return ""
+}
+
+func (x *Leaderboard) GetTeamScoreTopK() int32 {
+	if x != nil {
+		return x.TeamScoreTopK
+	}
+	return 0
+}
+
+func (x *Leaderboard) GetGroupId() string {
+	if x != nil && x.GroupId != nil {
+		return *x.GroupId
+	}
+	return ""
+}
+
+func (x *Leaderboard) GetMetrics() []*LeaderboardMetric {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *Leaderboard) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *Leaderboard) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Leaderboard) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Leaderboard) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Leaderboard) GetMaxEntriesPolicy() string {
+	if x != nil {
+		return x.MaxEntriesPolicy
+	}
+	return ""
+}
+
+type LeaderboardMetric struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LeaderboardId   string                 `protobuf:"bytes,2,opt,name=leaderboard_id,json=leaderboardId,proto3" json:"leaderboard_id,omitempty"`
+	MetricId        string                 `protobuf:"bytes,3,opt,name=metric_id,json=metricId,proto3" json:"metric_id,omitempty"`
+	Weight          float64                `protobuf:"fixed64,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	DisplayPriority int32                  `protobuf:"varint,5,opt,name=display_priority,json=displayPriority,proto3" json:"display_priority,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LeaderboardMetric) Reset() {
+	*x = LeaderboardMetric{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardMetric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardMetric) ProtoMessage() {}
+
+func (x *LeaderboardMetric) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardMetric.ProtoReflect.Descriptor instead.
+func (*LeaderboardMetric) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LeaderboardMetric) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LeaderboardMetric) GetLeaderboardId() string {
+	if x != nil {
+		return x.LeaderboardId
+	}
+	return ""
+}
+
+func (x *LeaderboardMetric) GetMetricId() string {
+	if x != nil {
+		return x.MetricId
+	}
+	return ""
+}
+
+func (x *LeaderboardMetric) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *LeaderboardMetric) GetDisplayPriority() int32 {
+	if x != nil {
+		return x.DisplayPriority
+	}
+	return 0
+}
+
+func (x *LeaderboardMetric) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *LeaderboardMetric) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetLeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Include       []string               `protobuf:"bytes,2,rep,name=include,proto3" json:"include,omitempty"` // metrics, entries
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardRequest) Reset() {
+	*x = GetLeaderboardRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardRequest) ProtoMessage() {}
+
+func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetLeaderboardRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetLeaderboardRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+type ListLeaderboardsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Category        *string                `protobuf:"bytes,1,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	Type            *string                `protobuf:"bytes,2,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	TimeFrame       *string                `protobuf:"bytes,3,opt,name=time_frame,json=timeFrame,proto3,oneof" json:"time_frame,omitempty"`
+	IsActive        *bool                  `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	VisibilityScope *string                `protobuf:"bytes,5,opt,name=visibility_scope,json=visibilityScope,proto3,oneof" json:"visibility_scope,omitempty"`
+	Sort            *Sort                  `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"` // name, category, start_date, end_date, created_at, updated_at
+	Include         []string               `protobuf:"bytes,7,rep,name=include,proto3" json:"include,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListLeaderboardsRequest) Reset() {
+	*x = ListLeaderboardsRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLeaderboardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLeaderboardsRequest) ProtoMessage() {}
+
+func (x *ListLeaderboardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLeaderboardsRequest.ProtoReflect.Descriptor instead.
+func (*ListLeaderboardsRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListLeaderboardsRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *ListLeaderboardsRequest) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *ListLeaderboardsRequest) GetTimeFrame() string {
+	if x != nil && x.TimeFrame != nil {
+		return *x.TimeFrame
+	}
+	return ""
+}
+
+func (x *ListLeaderboardsRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *ListLeaderboardsRequest) GetVisibilityScope() string {
+	if x != nil && x.VisibilityScope != nil {
+		return *x.VisibilityScope
+	}
+	return ""
+}
+
+func (x *ListLeaderboardsRequest) GetSort() *Sort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *ListLeaderboardsRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+type ListLeaderboardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leaderboards  []*Leaderboard         `protobuf:"bytes,1,rep,name=leaderboards,proto3" json:"leaderboards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLeaderboardsResponse) Reset() {
+	*x = ListLeaderboardsResponse{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLeaderboardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLeaderboardsResponse) ProtoMessage() {}
+
+func (x *ListLeaderboardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLeaderboardsResponse.ProtoReflect.Descriptor instead.
+func (*ListLeaderboardsResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListLeaderboardsResponse) GetLeaderboards() []*Leaderboard {
+	if x != nil {
+		return x.Leaderboards
+	}
+	return nil
+}
+
+// Dates are RFC 3339 timestamps, e.g. 2023-01-01T00:00:00Z or 2023-01-01T02:00:00+02:00, and are stored in UTC
+type CreateLeaderboardRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Category             string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Type                 string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	TimeFrame            string                 `protobuf:"bytes,5,opt,name=time_frame,json=timeFrame,proto3" json:"time_frame,omitempty"`
+	StartDate            *string                `protobuf:"bytes,6,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"`
+	EndDate              *string                `protobuf:"bytes,7,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
+	SortOrder            string                 `protobuf:"bytes,8,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	VisibilityScope      string                 `protobuf:"bytes,9,opt,name=visibility_scope,json=visibilityScope,proto3" json:"visibility_scope,omitempty"`
+	IsActive             *bool                  `protobuf:"varint,10,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"` // Defaults to true
+	MaxEntries           int32                  `protobuf:"varint,11,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	TeamScoreAggregation string                 `protobuf:"bytes,12,opt,name=team_score_aggregation,json=teamScoreAggregation,proto3" json:"team_score_aggregation,omitempty"`
+	TeamScoreTopK        int32                  `protobuf:"varint,13,opt,name=team_score_top_k,json=teamScoreTopK,proto3" json:"team_score_top_k,omitempty"`
+	GroupId              *string                `protobuf:"bytes,14,opt,name=group_id,json=groupId,proto3,oneof" json:"group_id,omitempty"`
+	MaxEntriesPolicy     string                 `protobuf:"bytes,15,opt,name=max_entries_policy,json=maxEntriesPolicy,proto3" json:"max_entries_policy,omitempty"` // Defaults to reject
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CreateLeaderboardRequest) Reset() {
+	*x = CreateLeaderboardRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLeaderboardRequest) ProtoMessage() {}
+
+func (x *CreateLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*CreateLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateLeaderboardRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardRequest) GetTimeFrame() string {
+	if x != nil {
+		return x.TimeFrame
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardRequest) GetStartDate() string {
+	if x != nil && x.StartDate != nil {
+		return *x.StartDate
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardRequest) GetEndDate() string {
+	if x != nil && x.EndDate != nil {
+		return *x.EndDate
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardRequest) GetVisibilityScope() string {
+	if x != nil {
+		return x.VisibilityScope
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *CreateLeaderboardRequest) GetMaxEntries() int32 {
+	if x != nil {
+		return x.MaxEntries
+	}
+	return 0
+}
+
+func (x *CreateLeaderboardRequest) GetTeamScoreAggregation() string {
+	if x != nil {
+		return x.TeamScoreAggregation
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardRequest) GetTeamScoreTopK() int32 {
+	if x != nil {
+		return x.TeamScoreTopK
+	}
+	return 0
+}
+
+func (x *CreateLeaderboardRequest) GetGroupId() string {
+	if x != nil && x.GroupId != nil {
+		return *x.GroupId
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardRequest) GetMaxEntriesPolicy() string {
+	if x != nil {
+		return x.MaxEntriesPolicy
+	}
+	return ""
+}
+
+// Only the fields that are set are changed. An empty group_id stops targeting a group.
+type UpdateLeaderboardRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description          *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Category             *string                `protobuf:"bytes,4,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	Type                 *string                `protobuf:"bytes,5,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	TimeFrame            *string                `protobuf:"bytes,6,opt,name=time_frame,json=timeFrame,proto3,oneof" json:"time_frame,omitempty"`
+	StartDate            *string                `protobuf:"bytes,7,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"`
+	EndDate              *string                `protobuf:"bytes,8,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
+	SortOrder            *string                `protobuf:"bytes,9,opt,name=sort_order,json=sortOrder,proto3,oneof" json:"sort_order,omitempty"`
+	VisibilityScope      *string                `protobuf:"bytes,10,opt,name=visibility_scope,json=visibilityScope,proto3,oneof" json:"visibility_scope,omitempty"`
+	IsActive             *bool                  `protobuf:"varint,11,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	MaxEntries           *int32                 `protobuf:"varint,12,opt,name=max_entries,json=maxEntries,proto3,oneof" json:"max_entries,omitempty"`
+	TeamScoreAggregation *string                `protobuf:"bytes,13,opt,name=team_score_aggregation,json=teamScoreAggregation,proto3,oneof" json:"team_score_aggregation,omitempty"`
+	TeamScoreTopK        *int32                 `protobuf:"varint,14,opt,name=team_score_top_k,json=teamScoreTopK,proto3,oneof" json:"team_score_top_k,omitempty"`
+	GroupId              *string                `protobuf:"bytes,15,opt,name=group_id,json=groupId,proto3,oneof" json:"group_id,omitempty"`
+	Version              *int32                 `protobuf:"varint,16,opt,name=version,proto3,oneof" json:"version,omitempty"` // Rejected with ABORTED if the leaderboard was updated since this version
+	MaxEntriesPolicy     *string                `protobuf:"bytes,17,opt,name=max_entries_policy,json=maxEntriesPolicy,proto3,oneof" json:"max_entries_policy,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UpdateLeaderboardRequest) Reset() {
+	*x = UpdateLeaderboardRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLeaderboardRequest) ProtoMessage() {}
+
+func (x *UpdateLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateLeaderboardRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardRequest) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardRequest) GetTimeFrame() string {
+	if x != nil && x.TimeFrame != nil {
+		return *x.TimeFrame
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardRequest) GetStartDate() string {
+	if x != nil && x.StartDate != nil {
+		return *x.StartDate
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardRequest) GetEndDate() string {
+	if x != nil && x.EndDate != nil {
+		return *x.EndDate
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardRequest) GetSortOrder() string {
+	if x != nil && x.SortOrder != nil {
+		return *x.SortOrder
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardRequest) GetVisibilityScope() string {
+	if x != nil && x.VisibilityScope != nil {
+		return *x.VisibilityScope
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *UpdateLeaderboardRequest) GetMaxEntries() int32 {
+	if x != nil && x.MaxEntries != nil {
+		return *x.MaxEntries
+	}
+	return 0
+}
+
+func (x *UpdateLeaderboardRequest) GetTeamScoreAggregation() string {
+	if x != nil && x.TeamScoreAggregation != nil {
+		return *x.TeamScoreAggregation
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardRequest) GetTeamScoreTopK() int32 {
+	if x != nil && x.TeamScoreTopK != nil {
+		return *x.TeamScoreTopK
+	}
+	return 0
+}
+
+func (x *UpdateLeaderboardRequest) GetGroupId() string {
+	if x != nil && x.GroupId != nil {
+		return *x.GroupId
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardRequest) GetVersion() int32 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+func (x *UpdateLeaderboardRequest) GetMaxEntriesPolicy() string {
+	if x != nil && x.MaxEntriesPolicy != nil {
+		return *x.MaxEntriesPolicy
+	}
+	return ""
+}
+
+type DeleteLeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"` // Required to delete a leaderboard that has entries
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLeaderboardRequest) Reset() {
+	*x = DeleteLeaderboardRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLeaderboardRequest) ProtoMessage() {}
+
+func (x *DeleteLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteLeaderboardRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteLeaderboardRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LeaderboardId string                 `protobuf:"bytes,2,opt,name=leaderboard_id,json=leaderboardId,proto3" json:"leaderboard_id,omitempty"`
+	ParticipantId string                 `protobuf:"bytes,3,opt,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
+	Rank          int32                  `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	Score         float64                `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	LastUpdated   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Participant   *Participant           `protobuf:"bytes,7,opt,name=participant,proto3" json:"participant,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       int32                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"` // Incremented by every update and re-rank
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LeaderboardEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetLeaderboardId() string {
+	if x != nil {
+		return x.LeaderboardId
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetParticipantId() string {
+	if x != nil {
+		return x.ParticipantId
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *LeaderboardEntry) GetParticipant() *Participant {
+	if x != nil {
+		return x.Participant
+	}
+	return nil
+}
+
+func (x *LeaderboardEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *LeaderboardEntry) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *LeaderboardEntry) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type GetLeaderboardEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardEntryRequest) Reset() {
+	*x = GetLeaderboardEntryRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardEntryRequest) ProtoMessage() {}
+
+func (x *GetLeaderboardEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardEntryRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardEntryRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetLeaderboardEntryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListLeaderboardEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeaderboardId *string                `protobuf:"bytes,1,opt,name=leaderboard_id,json=leaderboardId,proto3,oneof" json:"leaderboard_id,omitempty"`
+	ParticipantId *string                `protobuf:"bytes,2,opt,name=participant_id,json=participantId,proto3,oneof" json:"participant_id,omitempty"`
+	Sort          *Sort                  `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"` // rank, score, last_updated, created_at
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLeaderboardEntriesRequest) Reset() {
+	*x = ListLeaderboardEntriesRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLeaderboardEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLeaderboardEntriesRequest) ProtoMessage() {}
+
+func (x *ListLeaderboardEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLeaderboardEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListLeaderboardEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListLeaderboardEntriesRequest) GetLeaderboardId() string {
+	if x != nil && x.LeaderboardId != nil {
+		return *x.LeaderboardId
+	}
+	return ""
+}
+
+func (x *ListLeaderboardEntriesRequest) GetParticipantId() string {
+	if x != nil && x.ParticipantId != nil {
+		return *x.ParticipantId
+	}
+	return ""
+}
+
+func (x *ListLeaderboardEntriesRequest) GetSort() *Sort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+type ListLeaderboardEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LeaderboardEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLeaderboardEntriesResponse) Reset() {
+	*x = ListLeaderboardEntriesResponse{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLeaderboardEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLeaderboardEntriesResponse) ProtoMessage() {}
+
+func (x *ListLeaderboardEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLeaderboardEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListLeaderboardEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListLeaderboardEntriesResponse) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// Set either participant_id or participant_external_id
+type CreateLeaderboardEntryRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	LeaderboardId         string                 `protobuf:"bytes,1,opt,name=leaderboard_id,json=leaderboardId,proto3" json:"leaderboard_id,omitempty"`
+	ParticipantId         string                 `protobuf:"bytes,2,opt,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
+	ParticipantExternalId string                 `protobuf:"bytes,3,opt,name=participant_external_id,json=participantExternalId,proto3" json:"participant_external_id,omitempty"`
+	Score                 float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	Rank                  int32                  `protobuf:"varint,5,opt,name=rank,proto3" json:"rank,omitempty"`
+	LastUpdated           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CreateLeaderboardEntryRequest) Reset() {
+	*x = CreateLeaderboardEntryRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLeaderboardEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLeaderboardEntryRequest) ProtoMessage() {}
+
+func (x *CreateLeaderboardEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLeaderboardEntryRequest.ProtoReflect.Descriptor instead.
+func (*CreateLeaderboardEntryRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateLeaderboardEntryRequest) GetLeaderboardId() string {
+	if x != nil {
+		return x.LeaderboardId
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardEntryRequest) GetParticipantId() string {
+	if x != nil {
+		return x.ParticipantId
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardEntryRequest) GetParticipantExternalId() string {
+	if x != nil {
+		return x.ParticipantExternalId
+	}
+	return ""
+}
+
+func (x *CreateLeaderboardEntryRequest) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *CreateLeaderboardEntryRequest) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *CreateLeaderboardEntryRequest) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+type UpdateLeaderboardEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Score         *float64               `protobuf:"fixed64,2,opt,name=score,proto3,oneof" json:"score,omitempty"`
+	Rank          *int32                 `protobuf:"varint,3,opt,name=rank,proto3,oneof" json:"rank,omitempty"`
+	LastUpdated   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Version       *int32                 `protobuf:"varint,5,opt,name=version,proto3,oneof" json:"version,omitempty"` // Rejected with ABORTED if the entry was updated or re-ranked since this version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLeaderboardEntryRequest) Reset() {
+	*x = UpdateLeaderboardEntryRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLeaderboardEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLeaderboardEntryRequest) ProtoMessage() {}
+
+func (x *UpdateLeaderboardEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLeaderboardEntryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLeaderboardEntryRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateLeaderboardEntryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateLeaderboardEntryRequest) GetScore() float64 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+func (x *UpdateLeaderboardEntryRequest) GetRank() int32 {
+	if x != nil && x.Rank != nil {
+		return *x.Rank
+	}
+	return 0
+}
+
+func (x *UpdateLeaderboardEntryRequest) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *UpdateLeaderboardEntryRequest) GetVersion() int32 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type DeleteLeaderboardEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLeaderboardEntryRequest) Reset() {
+	*x = DeleteLeaderboardEntryRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLeaderboardEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLeaderboardEntryRequest) ProtoMessage() {}
+
+func (x *DeleteLeaderboardEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLeaderboardEntryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLeaderboardEntryRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteLeaderboardEntryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Participant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExternalId    string                 `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // individual, team, group
+	Metadata      *structpb.Value        `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	UserId        *string                `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	ParentId      *string                `protobuf:"bytes,7,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	IsActive      bool                   `protobuf:"varint,8,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	AnonymizedAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=anonymized_at,json=anonymizedAt,proto3" json:"anonymized_at,omitempty"`
+	Tags          []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,11,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,12,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Country       string                 `protobuf:"bytes,13,opt,name=country,proto3" json:"country,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Participant) Reset() {
+	*x = Participant{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Participant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Participant) ProtoMessage() {}
+
+func (x *Participant) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Participant.ProtoReflect.Descriptor instead.
+func (*Participant) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Participant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Participant) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *Participant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Participant) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Participant) GetMetadata() *structpb.Value {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Participant) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *Participant) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+func (x *Participant) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Participant) GetAnonymizedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AnonymizedAt
+	}
+	return nil
+}
+
+func (x *Participant) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Participant) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *Participant) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *Participant) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Participant) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Participant) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Set either id or external_id
+type GetParticipantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExternalId    string                 `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetParticipantRequest) Reset() {
+	*x = GetParticipantRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetParticipantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetParticipantRequest) ProtoMessage() {}
+
+func (x *GetParticipantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetParticipantRequest.ProtoReflect.Descriptor instead.
+func (*GetParticipantRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetParticipantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetParticipantRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+type ListParticipantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"` // Only participants with every one of the tags
+	Sort          *Sort                  `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"` // name, display_name, type, created_at, updated_at
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParticipantsRequest) Reset() {
+	*x = ListParticipantsRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParticipantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParticipantsRequest) ProtoMessage() {}
+
+func (x *ListParticipantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParticipantsRequest.ProtoReflect.Descriptor instead.
+func (*ListParticipantsRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListParticipantsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListParticipantsRequest) GetSort() *Sort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+type ListParticipantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Participants  []*Participant         `protobuf:"bytes,1,rep,name=participants,proto3" json:"participants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParticipantsResponse) Reset() {
+	*x = ListParticipantsResponse{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParticipantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParticipantsResponse) ProtoMessage() {}
+
+func (x *ListParticipantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParticipantsResponse.ProtoReflect.Descriptor instead.
+func (*ListParticipantsResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListParticipantsResponse) GetParticipants() []*Participant {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+type CreateParticipantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExternalId    string                 `protobuf:"bytes,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	UserId        *string                `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,6,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,7,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Country       string                 `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateParticipantRequest) Reset() {
+	*x = CreateParticipantRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateParticipantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateParticipantRequest) ProtoMessage() {}
+
+func (x *CreateParticipantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateParticipantRequest.ProtoReflect.Descriptor instead.
+func (*CreateParticipantRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateParticipantRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *CreateParticipantRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateParticipantRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateParticipantRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateParticipantRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *CreateParticipantRequest) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *CreateParticipantRequest) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *CreateParticipantRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+// Only the fields that are set are changed. An empty user_id unlinks the user and empty profile fields are cleared.
+type UpdateParticipantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExternalId    *string                `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3,oneof" json:"external_id,omitempty"`
+	Name          *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Type          *string                `protobuf:"bytes,4,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	UserId        *string                `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	IsActive      *bool                  `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	DisplayName   *string                `protobuf:"bytes,8,opt,name=display_name,json=displayName,proto3,oneof" json:"display_name,omitempty"`
+	AvatarUrl     *string                `protobuf:"bytes,9,opt,name=avatar_url,json=avatarUrl,proto3,oneof" json:"avatar_url,omitempty"`
+	Country       *string                `protobuf:"bytes,10,opt,name=country,proto3,oneof" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateParticipantRequest) Reset() {
+	*x = UpdateParticipantRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateParticipantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateParticipantRequest) ProtoMessage() {}
+
+func (x *UpdateParticipantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateParticipantRequest.ProtoReflect.Descriptor instead.
+func (*UpdateParticipantRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateParticipantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateParticipantRequest) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *UpdateParticipantRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateParticipantRequest) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *UpdateParticipantRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateParticipantRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateParticipantRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *UpdateParticipantRequest) GetDisplayName() string {
+	if x != nil && x.DisplayName != nil {
+		return *x.DisplayName
+	}
+	return ""
+}
+
+func (x *UpdateParticipantRequest) GetAvatarUrl() string {
+	if x != nil && x.AvatarUrl != nil {
+		return *x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *UpdateParticipantRequest) GetCountry() string {
+	if x != nil && x.Country != nil {
+		return *x.Country
+	}
+	return ""
+}
+
+type DeleteParticipantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteParticipantRequest) Reset() {
+	*x = DeleteParticipantRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteParticipantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteParticipantRequest) ProtoMessage() {}
+
+func (x *DeleteParticipantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteParticipantRequest.ProtoReflect.Descriptor instead.
+func (*DeleteParticipantRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteParticipantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Metric struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	DataType        string                 `protobuf:"bytes,4,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"` // integer, decimal, boolean, string
+	Unit            string                 `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+	AggregationType string                 `protobuf:"bytes,6,opt,name=aggregation_type,json=aggregationType,proto3" json:"aggregation_type,omitempty"` // sum, average, count, min, max, last
+	ResetPeriod     string                 `protobuf:"bytes,7,opt,name=reset_period,json=resetPeriod,proto3" json:"reset_period,omitempty"`             // none, daily, weekly, monthly, yearly
+	IsHigherBetter  bool                   `protobuf:"varint,8,opt,name=is_higher_better,json=isHigherBetter,proto3" json:"is_higher_better,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Metric) Reset() {
+	*x = Metric{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metric) ProtoMessage() {}
+
+func (x *Metric) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metric.ProtoReflect.Descriptor instead.
+func (*Metric) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Metric) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Metric) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Metric) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Metric) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *Metric) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *Metric) GetAggregationType() string {
+	if x != nil {
+		return x.AggregationType
+	}
+	return ""
+}
+
+func (x *Metric) GetResetPeriod() string {
+	if x != nil {
+		return x.ResetPeriod
+	}
+	return ""
+}
+
+func (x *Metric) GetIsHigherBetter() bool {
+	if x != nil {
+		return x.IsHigherBetter
+	}
+	return false
+}
+
+func (x *Metric) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Metric) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetMetricRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMetricRequest) Reset() {
+	*x = GetMetricRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetricRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetricRequest) ProtoMessage() {}
+
+func (x *GetMetricRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetricRequest.ProtoReflect.Descriptor instead.
+func (*GetMetricRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetMetricRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListMetricsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMetricsRequest) Reset() {
+	*x = ListMetricsRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMetricsRequest) ProtoMessage() {}
+
+func (x *ListMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMetricsRequest.ProtoReflect.Descriptor instead.
+func (*ListMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{25}
+}
+
+type ListMetricsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metrics       []*Metric              `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMetricsResponse) Reset() {
+	*x = ListMetricsResponse{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMetricsResponse) ProtoMessage() {}
+
+func (x *ListMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMetricsResponse.ProtoReflect.Descriptor instead.
+func (*ListMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListMetricsResponse) GetMetrics() []*Metric {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+type CreateMetricRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	DataType        string                 `protobuf:"bytes,3,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
+	Unit            string                 `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	AggregationType string                 `protobuf:"bytes,5,opt,name=aggregation_type,json=aggregationType,proto3" json:"aggregation_type,omitempty"`
+	ResetPeriod     string                 `protobuf:"bytes,6,opt,name=reset_period,json=resetPeriod,proto3" json:"reset_period,omitempty"`
+	IsHigherBetter  *bool                  `protobuf:"varint,7,opt,name=is_higher_better,json=isHigherBetter,proto3,oneof" json:"is_higher_better,omitempty"` // Defaults to true
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateMetricRequest) Reset() {
+	*x = CreateMetricRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMetricRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMetricRequest) ProtoMessage() {}
+
+func (x *CreateMetricRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMetricRequest.ProtoReflect.Descriptor instead.
+func (*CreateMetricRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateMetricRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateMetricRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateMetricRequest) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *CreateMetricRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *CreateMetricRequest) GetAggregationType() string {
+	if x != nil {
+		return x.AggregationType
+	}
+	return ""
+}
+
+func (x *CreateMetricRequest) GetResetPeriod() string {
+	if x != nil {
+		return x.ResetPeriod
+	}
+	return ""
+}
+
+func (x *CreateMetricRequest) GetIsHigherBetter() bool {
+	if x != nil && x.IsHigherBetter != nil {
+		return *x.IsHigherBetter
+	}
+	return false
+}
+
+type UpdateMetricRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description     *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	DataType        *string                `protobuf:"bytes,4,opt,name=data_type,json=dataType,proto3,oneof" json:"data_type,omitempty"`
+	Unit            *string                `protobuf:"bytes,5,opt,name=unit,proto3,oneof" json:"unit,omitempty"`
+	AggregationType *string                `protobuf:"bytes,6,opt,name=aggregation_type,json=aggregationType,proto3,oneof" json:"aggregation_type,omitempty"`
+	ResetPeriod     *string                `protobuf:"bytes,7,opt,name=reset_period,json=resetPeriod,proto3,oneof" json:"reset_period,omitempty"`
+	IsHigherBetter  *bool                  `protobuf:"varint,8,opt,name=is_higher_better,json=isHigherBetter,proto3,oneof" json:"is_higher_better,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateMetricRequest) Reset() {
+	*x = UpdateMetricRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMetricRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMetricRequest) ProtoMessage() {}
+
+func (x *UpdateMetricRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMetricRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMetricRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateMetricRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateMetricRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateMetricRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateMetricRequest) GetDataType() string {
+	if x != nil && x.DataType != nil {
+		return *x.DataType
+	}
+	return ""
+}
+
+func (x *UpdateMetricRequest) GetUnit() string {
+	if x != nil && x.Unit != nil {
+		return *x.Unit
+	}
+	return ""
+}
+
+func (x *UpdateMetricRequest) GetAggregationType() string {
+	if x != nil && x.AggregationType != nil {
+		return *x.AggregationType
+	}
+	return ""
+}
+
+func (x *UpdateMetricRequest) GetResetPeriod() string {
+	if x != nil && x.ResetPeriod != nil {
+		return *x.ResetPeriod
+	}
+	return ""
+}
+
+func (x *UpdateMetricRequest) GetIsHigherBetter() bool {
+	if x != nil && x.IsHigherBetter != nil {
+		return *x.IsHigherBetter
+	}
+	return false
+}
+
+type DeleteMetricRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMetricRequest) Reset() {
+	*x = DeleteMetricRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMetricRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMetricRequest) ProtoMessage() {}
+
+func (x *DeleteMetricRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMetricRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMetricRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteMetricRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type MetricValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MetricId      string                 `protobuf:"bytes,2,opt,name=metric_id,json=metricId,proto3" json:"metric_id,omitempty"`
+	ParticipantId string                 `protobuf:"bytes,3,opt,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
+	Value         float64                `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"` // 1 or 0 for boolean metrics and 0 for string ones
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Source        string                 `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	Context       *structpb.Value        `protobuf:"bytes,7,opt,name=context,proto3" json:"context,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	BoolValue     *bool                  `protobuf:"varint,10,opt,name=bool_value,json=boolValue,proto3,oneof" json:"bool_value,omitempty"`      // Set on values of boolean metrics
+	StringValue   *string                `protobuf:"bytes,11,opt,name=string_value,json=stringValue,proto3,oneof" json:"string_value,omitempty"` // Set on values of string metrics
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetricValue) Reset() {
+	*x = MetricValue{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricValue) ProtoMessage() {}
+
+func (x *MetricValue) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricValue.ProtoReflect.Descriptor instead.
+func (*MetricValue) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *MetricValue) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MetricValue) GetMetricId() string {
+	if x != nil {
+		return x.MetricId
+	}
+	return ""
+}
+
+func (x *MetricValue) GetParticipantId() string {
+	if x != nil {
+		return x.ParticipantId
+	}
+	return ""
+}
+
+func (x *MetricValue) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *MetricValue) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *MetricValue) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *MetricValue) GetContext() *structpb.Value {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *MetricValue) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *MetricValue) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *MetricValue) GetBoolValue() bool {
+	if x != nil && x.BoolValue != nil {
+		return *x.BoolValue
+	}
+	return false
+}
+
+func (x *MetricValue) GetStringValue() string {
+	if x != nil && x.StringValue != nil {
+		return *x.StringValue
+	}
+	return ""
+}
+
+type GetMetricValueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMetricValueRequest) Reset() {
+	*x = GetMetricValueRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetricValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetricValueRequest) ProtoMessage() {}
+
+func (x *GetMetricValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetricValueRequest.ProtoReflect.Descriptor instead.
+func (*GetMetricValueRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetMetricValueRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListMetricValuesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MetricId      *string                `protobuf:"bytes,1,opt,name=metric_id,json=metricId,proto3,oneof" json:"metric_id,omitempty"`
+	ParticipantId *string                `protobuf:"bytes,2,opt,name=participant_id,json=participantId,proto3,oneof" json:"participant_id,omitempty"`
+	FromTime      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from_time,json=fromTime,proto3" json:"from_time,omitempty"`
+	ToTime        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to_time,json=toTime,proto3" json:"to_time,omitempty"`
+	Sort          *Sort                  `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`                            // timestamp, value, created_at
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Defaults to 100, at most 1000
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous call, with the same filters and sort
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMetricValuesRequest) Reset() {
+	*x = ListMetricValuesRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMetricValuesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMetricValuesRequest) ProtoMessage() {}
+
+func (x *ListMetricValuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMetricValuesRequest.ProtoReflect.Descriptor instead.
+func (*ListMetricValuesRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListMetricValuesRequest) GetMetricId() string {
+	if x != nil && x.MetricId != nil {
+		return *x.MetricId
+	}
+	return ""
+}
+
+func (x *ListMetricValuesRequest) GetParticipantId() string {
+	if x != nil && x.ParticipantId != nil {
+		return *x.ParticipantId
+	}
+	return ""
+}
+
+func (x *ListMetricValuesRequest) GetFromTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FromTime
+	}
+	return nil
+}
+
+func (x *ListMetricValuesRequest) GetToTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ToTime
+	}
+	return nil
+}
+
+func (x *ListMetricValuesRequest) GetSort() *Sort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *ListMetricValuesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMetricValuesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListMetricValuesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MetricValues  []*MetricValue         `protobuf:"bytes,1,rep,name=metric_values,json=metricValues,proto3" json:"metric_values,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMetricValuesResponse) Reset() {
+	*x = ListMetricValuesResponse{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMetricValuesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMetricValuesResponse) ProtoMessage() {}
+
+func (x *ListMetricValuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMetricValuesResponse.ProtoReflect.Descriptor instead.
+func (*ListMetricValuesResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListMetricValuesResponse) GetMetricValues() []*MetricValue {
+	if x != nil {
+		return x.MetricValues
+	}
+	return nil
+}
+
+func (x *ListMetricValuesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Set either participant_id or participant_external_id. The timestamp defaults to now.
+type CreateMetricValueRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	MetricId              string                 `protobuf:"bytes,1,opt,name=metric_id,json=metricId,proto3" json:"metric_id,omitempty"`
+	ParticipantId         string                 `protobuf:"bytes,2,opt,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
+	ParticipantExternalId string                 `protobuf:"bytes,3,opt,name=participant_external_id,json=participantExternalId,proto3" json:"participant_external_id,omitempty"`
+	Value                 float64                `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"` // Of integer and decimal metrics, used when neither bool_value nor string_value is set
+	Timestamp             *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Source                string                 `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	Context               *structpb.Value        `protobuf:"bytes,7,opt,name=context,proto3" json:"context,omitempty"`
+	BoolValue             *bool                  `protobuf:"varint,8,opt,name=bool_value,json=boolValue,proto3,oneof" json:"bool_value,omitempty"`      // Of boolean metrics
+	StringValue           *string                `protobuf:"bytes,9,opt,name=string_value,json=stringValue,proto3,oneof" json:"string_value,omitempty"` // Of string metrics
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CreateMetricValueRequest) Reset() {
+	*x = CreateMetricValueRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMetricValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMetricValueRequest) ProtoMessage() {}
+
+func (x *CreateMetricValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMetricValueRequest.ProtoReflect.Descriptor instead.
+func (*CreateMetricValueRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CreateMetricValueRequest) GetMetricId() string {
+	if x != nil {
+		return x.MetricId
+	}
+	return ""
+}
+
+func (x *CreateMetricValueRequest) GetParticipantId() string {
+	if x != nil {
+		return x.ParticipantId
+	}
+	return ""
+}
+
+func (x *CreateMetricValueRequest) GetParticipantExternalId() string {
+	if x != nil {
+		return x.ParticipantExternalId
+	}
+	return ""
+}
+
+func (x *CreateMetricValueRequest) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CreateMetricValueRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *CreateMetricValueRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *CreateMetricValueRequest) GetContext() *structpb.Value {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *CreateMetricValueRequest) GetBoolValue() bool {
+	if x != nil && x.BoolValue != nil {
+		return *x.BoolValue
+	}
+	return false
+}
+
+func (x *CreateMetricValueRequest) GetStringValue() string {
+	if x != nil && x.StringValue != nil {
+		return *x.StringValue
+	}
+	return ""
+}
+
+type UpdateMetricValueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value         *float64               `protobuf:"fixed64,2,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Source        *string                `protobuf:"bytes,4,opt,name=source,proto3,oneof" json:"source,omitempty"`
+	Context       *structpb.Value        `protobuf:"bytes,5,opt,name=context,proto3" json:"context,omitempty"`
+	BoolValue     *bool                  `protobuf:"varint,6,opt,name=bool_value,json=boolValue,proto3,oneof" json:"bool_value,omitempty"`
+	StringValue   *string                `protobuf:"bytes,7,opt,name=string_value,json=stringValue,proto3,oneof" json:"string_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMetricValueRequest) Reset() {
+	*x = UpdateMetricValueRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMetricValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMetricValueRequest) ProtoMessage() {}
+
+func (x *UpdateMetricValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMetricValueRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMetricValueRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpdateMetricValueRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateMetricValueRequest) GetValue() float64 {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return 0
+}
+
+func (x *UpdateMetricValueRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *UpdateMetricValueRequest) GetSource() string {
+	if x != nil && x.Source != nil {
+		return *x.Source
+	}
+	return ""
+}
+
+func (x *UpdateMetricValueRequest) GetContext() *structpb.Value {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *UpdateMetricValueRequest) GetBoolValue() bool {
+	if x != nil && x.BoolValue != nil {
+		return *x.BoolValue
+	}
+	return false
+}
+
+func (x *UpdateMetricValueRequest) GetStringValue() string {
+	if x != nil && x.StringValue != nil {
+		return *x.StringValue
+	}
+	return ""
+}
+
+type DeleteMetricValueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMetricValueRequest) Reset() {
+	*x = DeleteMetricValueRequest{}
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMetricValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMetricValueRequest) ProtoMessage() {}
+
+func (x *DeleteMetricValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_v1_leaderboard_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMetricValueRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMetricValueRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_v1_leaderboard_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteMetricValueRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_leaderboard_v1_leaderboard_proto protoreflect.FileDescriptor
+
+const file_leaderboard_v1_leaderboard_proto_rawDesc = "" +
+	"\n" +
+	" leaderboard/v1/leaderboard.proto\x12\x0eleaderboard.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"0\n" +
+	"\x04Sort\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04desc\x18\x02 \x01(\bR\x04desc\"\x8c\a\n" +
+	"\vLeaderboard\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"time_frame\x18\x06 \x01(\tR\ttimeFrame\x129\n" +
+	"\n" +
+	"start_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\t \x01(\tR\tsortOrder\x12)\n" +
+	"\x10visibility_scope\x18\n" +
+	" \x01(\tR\x0fvisibilityScope\x12\x1f\n" +
+	"\vmax_entries\x18\v \x01(\x05R\n" +
+	"maxEntries\x12\x1b\n" +
+	"\tis_active\x18\f \x01(\bR\bisActive\x12\x1e\n" +
+	"\bowner_id\x18\r \x01(\tH\x00R\aownerId\x88\x01\x01\x124\n" +
+	"\x16team_score_aggregation\x18\x0e \x01(\tR\x14teamScoreAggregation\x12'\n" +
+	"\x10team_score_top_k\x18\x0f \x01(\x05R\rteamScoreTopK\x12\x1e\n" +
+	"\bgroup_id\x18\x10 \x01(\tH\x01R\agroupId\x88\x01\x01\x12;\n" +
+	"\ametrics\x18\x11 \x03(\v2!.leaderboard.v1.LeaderboardMetricR\ametrics\x12:\n" +
+	"\aentries\x18\x12 \x03(\v2 .leaderboard.v1.LeaderboardEntryR\aentries\x129\n" +
+	"\n" +
+	"created_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\x15 \x01(\x05R\aversion\x12,\n" +
+	"\x12max_entries_policy\x18\x16 \x01(\tR\x10maxEntriesPolicyB\v\n" +
+	"\t_owner_idB\v\n" +
+	"\t_group_id\"\xa0\x02\n" +
+	"\x11LeaderboardMetric\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0eleaderboard_id\x18\x02 \x01(\tR\rleaderboardId\x12\x1b\n" +
+	"\tmetric_id\x18\x03 \x01(\tR\bmetricId\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x01R\x06weight\x12)\n" +
+	"\x10display_priority\x18\x05 \x01(\x05R\x0fdisplayPriority\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"A\n" +
+	"\x15GetLeaderboardRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\ainclude\x18\x02 \x03(\tR\ainclude\"\xd5\x02\n" +
+	"\x17ListLeaderboardsRequest\x12\x1f\n" +
+	"\bcategory\x18\x01 \x01(\tH\x00R\bcategory\x88\x01\x01\x12\x17\n" +
+	"\x04type\x18\x02 \x01(\tH\x01R\x04type\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"time_frame\x18\x03 \x01(\tH\x02R\ttimeFrame\x88\x01\x01\x12 \n" +
+	"\tis_active\x18\x04 \x01(\bH\x03R\bisActive\x88\x01\x01\x12.\n" +
+	"\x10visibility_scope\x18\x05 \x01(\tH\x04R\x0fvisibilityScope\x88\x01\x01\x12(\n" +
+	"\x04sort\x18\x06 \x01(\v2\x14.leaderboard.v1.SortR\x04sort\x12\x18\n" +
+	"\ainclude\x18\a \x03(\tR\aincludeB\v\n" +
+	"\t_categoryB\a\n" +
+	"\x05_typeB\r\n" +
+	"\v_time_frameB\f\n" +
+	"\n" +
+	"_is_activeB\x13\n" +
+	"\x11_visibility_scope\"[\n" +
+	"\x18ListLeaderboardsResponse\x12?\n" +
+	"\fleaderboards\x18\x01 \x03(\v2\x1b.leaderboard.v1.LeaderboardR\fleaderboards\"\xd4\x04\n" +
+	"\x18CreateLeaderboardRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"time_frame\x18\x05 \x01(\tR\ttimeFrame\x12\"\n" +
+	"\n" +
+	"start_date\x18\x06 \x01(\tH\x00R\tstartDate\x88\x01\x01\x12\x1e\n" +
+	"\bend_date\x18\a \x01(\tH\x01R\aendDate\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\b \x01(\tR\tsortOrder\x12)\n" +
+	"\x10visibility_scope\x18\t \x01(\tR\x0fvisibilityScope\x12 \n" +
+	"\tis_active\x18\n" +
+	" \x01(\bH\x02R\bisActive\x88\x01\x01\x12\x1f\n" +
+	"\vmax_entries\x18\v \x01(\x05R\n" +
+	"maxEntries\x124\n" +
+	"\x16team_score_aggregation\x18\f \x01(\tR\x14teamScoreAggregation\x12'\n" +
+	"\x10team_score_top_k\x18\r \x01(\x05R\rteamScoreTopK\x12\x1e\n" +
+	"\bgroup_id\x18\x0e \x01(\tH\x03R\agroupId\x88\x01\x01\x12,\n" +
+	"\x12max_entries_policy\x18\x0f \x01(\tR\x10maxEntriesPolicyB\r\n" +
+	"\v_start_dateB\v\n" +
+	"\t_end_dateB\f\n" +
+	"\n" +
+	"_is_activeB\v\n" +
+	"\t_group_id\"\xff\x06\n" +
+	"\x18UpdateLeaderboardRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1f\n" +
+	"\bcategory\x18\x04 \x01(\tH\x02R\bcategory\x88\x01\x01\x12\x17\n" +
+	"\x04type\x18\x05 \x01(\tH\x03R\x04type\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"time_frame\x18\x06 \x01(\tH\x04R\ttimeFrame\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"start_date\x18\a \x01(\tH\x05R\tstartDate\x88\x01\x01\x12\x1e\n" +
+	"\bend_date\x18\b \x01(\tH\x06R\aendDate\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"sort_order\x18\t \x01(\tH\aR\tsortOrder\x88\x01\x01\x12.\n" +
+	"\x10visibility_scope\x18\n" +
+	" \x01(\tH\bR\x0fvisibilityScope\x88\x01\x01\x12 \n" +
+	"\tis_active\x18\v \x01(\bH\tR\bisActive\x88\x01\x01\x12$\n" +
+	"\vmax_entries\x18\f \x01(\x05H\n" +
+	"R\n" +
+	"maxEntries\x88\x01\x01\x129\n" +
+	"\x16team_score_aggregation\x18\r \x01(\tH\vR\x14teamScoreAggregation\x88\x01\x01\x12,\n" +
+	"\x10team_score_top_k\x18\x0e \x01(\x05H\fR\rteamScoreTopK\x88\x01\x01\x12\x1e\n" +
+	"\bgroup_id\x18\x0f \x01(\tH\rR\agroupId\x88\x01\x01\x12\x1d\n" +
+	"\aversion\x18\x10 \x01(\x05H\x0eR\aversion\x88\x01\x01\x121\n" +
+	"\x12max_entries_policy\x18\x11 \x01(\tH\x0fR\x10maxEntriesPolicy\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\v\n" +
+	"\t_categoryB\a\n" +
+	"\x05_typeB\r\n" +
+	"\v_time_frameB\r\n" +
+	"\v_start_dateB\v\n" +
+	"\t_end_dateB\r\n" +
+	"\v_sort_orderB\x13\n" +
+	"\x11_visibility_scopeB\f\n" +
+	"\n" +
+	"_is_activeB\x0e\n" +
+	"\f_max_entriesB\x19\n" +
+	"\x17_team_score_aggregationB\x13\n" +
+	"\x11_team_score_top_kB\v\n" +
+	"\t_group_idB\n" +
+	"\n" +
+	"\b_versionB\x15\n" +
+	"\x13_max_entries_policy\"@\n" +
+	"\x18DeleteLeaderboardRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"\xa8\x03\n" +
+	"\x10LeaderboardEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0eleaderboard_id\x18\x02 \x01(\tR\rleaderboardId\x12%\n" +
+	"\x0eparticipant_id\x18\x03 \x01(\tR\rparticipantId\x12\x12\n" +
+	"\x04rank\x18\x04 \x01(\x05R\x04rank\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x01R\x05score\x12=\n" +
+	"\flast_updated\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x12=\n" +
+	"\vparticipant\x18\a \x01(\v2\x1b.leaderboard.v1.ParticipantR\vparticipant\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x05R\aversion\",\n" +
+	"\x1aGetLeaderboardEntryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xc7\x01\n" +
+	"\x1dListLeaderboardEntriesRequest\x12*\n" +
+	"\x0eleaderboard_id\x18\x01 \x01(\tH\x00R\rleaderboardId\x88\x01\x01\x12*\n" +
+	"\x0eparticipant_id\x18\x02 \x01(\tH\x01R\rparticipantId\x88\x01\x01\x12(\n" +
+	"\x04sort\x18\x03 \x01(\v2\x14.leaderboard.v1.SortR\x04sortB\x11\n" +
+	"\x0f_leaderboard_idB\x11\n" +
+	"\x0f_participant_id\"\\\n" +
+	"\x1eListLeaderboardEntriesResponse\x12:\n" +
+	"\aentries\x18\x01 \x03(\v2 .leaderboard.v1.LeaderboardEntryR\aentries\"\x8e\x02\n" +
+	"\x1dCreateLeaderboardEntryRequest\x12%\n" +
+	"\x0eleaderboard_id\x18\x01 \x01(\tR\rleaderboardId\x12%\n" +
+	"\x0eparticipant_id\x18\x02 \x01(\tR\rparticipantId\x126\n" +
+	"\x17participant_external_id\x18\x03 \x01(\tR\x15participantExternalId\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\x12\x12\n" +
+	"\x04rank\x18\x05 \x01(\x05R\x04rank\x12=\n" +
+	"\flast_updated\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\"\xe0\x01\n" +
+	"\x1dUpdateLeaderboardEntryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05score\x18\x02 \x01(\x01H\x00R\x05score\x88\x01\x01\x12\x17\n" +
+	"\x04rank\x18\x03 \x01(\x05H\x01R\x04rank\x88\x01\x01\x12=\n" +
+	"\flast_updated\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x12\x1d\n" +
+	"\aversion\x18\x05 \x01(\x05H\x02R\aversion\x88\x01\x01B\b\n" +
+	"\x06_scoreB\a\n" +
+	"\x05_rankB\n" +
+	"\n" +
+	"\b_version\"/\n" +
+	"\x1dDeleteLeaderboardEntryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb8\x04\n" +
+	"\vParticipant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x122\n" +
+	"\bmetadata\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\bmetadata\x12\x1c\n" +
+	"\auser_id\x18\x06 \x01(\tH\x00R\x06userId\x88\x01\x01\x12 \n" +
+	"\tparent_id\x18\a \x01(\tH\x01R\bparentId\x88\x01\x01\x12\x1b\n" +
+	"\tis_active\x18\b \x01(\bR\bisActive\x12?\n" +
+	"\ranonymized_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fanonymizedAt\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12!\n" +
+	"\fdisplay_name\x18\v \x01(\tR\vdisplayName\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\f \x01(\tR\tavatarUrl\x12\x18\n" +
+	"\acountry\x18\r \x01(\tR\acountry\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\n" +
+	"\n" +
+	"\b_user_idB\f\n" +
+	"\n" +
+	"_parent_id\"H\n" +
+	"\x15GetParticipantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\"W\n" +
+	"\x17ListParticipantsRequest\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x12(\n" +
+	"\x04sort\x18\x02 \x01(\v2\x14.leaderboard.v1.SortR\x04sort\"[\n" +
+	"\x18ListParticipantsResponse\x12?\n" +
+	"\fparticipants\x18\x01 \x03(\v2\x1b.leaderboard.v1.ParticipantR\fparticipants\"\x9e\x02\n" +
+	"\x18CreateParticipantRequest\x12\x1f\n" +
+	"\vexternal_id\x18\x01 \x01(\tR\n" +
+	"externalId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x123\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12\x1c\n" +
+	"\auser_id\x18\x05 \x01(\tH\x00R\x06userId\x88\x01\x01\x12!\n" +
+	"\fdisplay_name\x18\x06 \x01(\tR\vdisplayName\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\a \x01(\tR\tavatarUrl\x12\x18\n" +
+	"\acountry\x18\b \x01(\tR\acountryB\n" +
+	"\n" +
+	"\b_user_id\"\xca\x03\n" +
+	"\x18UpdateParticipantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
+	"\vexternal_id\x18\x02 \x01(\tH\x00R\n" +
+	"externalId\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x01R\x04name\x88\x01\x01\x12\x17\n" +
+	"\x04type\x18\x04 \x01(\tH\x02R\x04type\x88\x01\x01\x123\n" +
+	"\bmetadata\x18\x05 \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12\x1c\n" +
+	"\auser_id\x18\x06 \x01(\tH\x03R\x06userId\x88\x01\x01\x12 \n" +
+	"\tis_active\x18\a \x01(\bH\x04R\bisActive\x88\x01\x01\x12&\n" +
+	"\fdisplay_name\x18\b \x01(\tH\x05R\vdisplayName\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"avatar_url\x18\t \x01(\tH\x06R\tavatarUrl\x88\x01\x01\x12\x1d\n" +
+	"\acountry\x18\n" +
+	" \x01(\tH\aR\acountry\x88\x01\x01B\x0e\n" +
+	"\f_external_idB\a\n" +
+	"\x05_nameB\a\n" +
+	"\x05_typeB\n" +
+	"\n" +
+	"\b_user_idB\f\n" +
+	"\n" +
+	"_is_activeB\x0f\n" +
+	"\r_display_nameB\r\n" +
+	"\v_avatar_urlB\n" +
+	"\n" +
+	"\b_country\"*\n" +
+	"\x18DeleteParticipantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xed\x02\n" +
+	"\x06Metric\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tdata_type\x18\x04 \x01(\tR\bdataType\x12\x12\n" +
+	"\x04unit\x18\x05 \x01(\tR\x04unit\x12)\n" +
+	"\x10aggregation_type\x18\x06 \x01(\tR\x0faggregationType\x12!\n" +
+	"\freset_period\x18\a \x01(\tR\vresetPeriod\x12(\n" +
+	"\x10is_higher_better\x18\b \x01(\bR\x0eisHigherBetter\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\"\n" +
+	"\x10GetMetricRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
+	"\x12ListMetricsRequest\"G\n" +
+	"\x13ListMetricsResponse\x120\n" +
+	"\ametrics\x18\x01 \x03(\v2\x16.leaderboard.v1.MetricR\ametrics\"\x8e\x02\n" +
+	"\x13CreateMetricRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tdata_type\x18\x03 \x01(\tR\bdataType\x12\x12\n" +
+	"\x04unit\x18\x04 \x01(\tR\x04unit\x12)\n" +
+	"\x10aggregation_type\x18\x05 \x01(\tR\x0faggregationType\x12!\n" +
+	"\freset_period\x18\x06 \x01(\tR\vresetPeriod\x12-\n" +
+	"\x10is_higher_better\x18\a \x01(\bH\x00R\x0eisHigherBetter\x88\x01\x01B\x13\n" +
+	"\x11_is_higher_better\"\x92\x03\n" +
+	"\x13UpdateMetricRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12 \n" +
+	"\tdata_type\x18\x04 \x01(\tH\x02R\bdataType\x88\x01\x01\x12\x17\n" +
+	"\x04unit\x18\x05 \x01(\tH\x03R\x04unit\x88\x01\x01\x12.\n" +
+	"\x10aggregation_type\x18\x06 \x01(\tH\x04R\x0faggregationType\x88\x01\x01\x12&\n" +
+	"\freset_period\x18\a \x01(\tH\x05R\vresetPeriod\x88\x01\x01\x12-\n" +
+	"\x10is_higher_better\x18\b \x01(\bH\x06R\x0eisHigherBetter\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\f\n" +
+	"\n" +
+	"_data_typeB\a\n" +
+	"\x05_unitB\x13\n" +
+	"\x11_aggregation_typeB\x0f\n" +
+	"\r_reset_periodB\x13\n" +
+	"\x11_is_higher_better\"%\n" +
+	"\x13DeleteMetricRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xdd\x03\n" +
+	"\vMetricValue\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tmetric_id\x18\x02 \x01(\tR\bmetricId\x12%\n" +
+	"\x0eparticipant_id\x18\x03 \x01(\tR\rparticipantId\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x16\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\x120\n" +
+	"\acontext\x18\a \x01(\v2\x16.google.protobuf.ValueR\acontext\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\"\n" +
+	"\n" +
+	"bool_value\x18\n" +
+	" \x01(\bH\x00R\tboolValue\x88\x01\x01\x12&\n" +
+	"\fstring_value\x18\v \x01(\tH\x01R\vstringValue\x88\x01\x01B\r\n" +
+	"\v_bool_valueB\x0f\n" +
+	"\r_string_value\"'\n" +
+	"\x15GetMetricValueRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xdc\x02\n" +
+	"\x17ListMetricValuesRequest\x12 \n" +
+	"\tmetric_id\x18\x01 \x01(\tH\x00R\bmetricId\x88\x01\x01\x12*\n" +
+	"\x0eparticipant_id\x18\x02 \x01(\tH\x01R\rparticipantId\x88\x01\x01\x127\n" +
+	"\tfrom_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bfromTime\x123\n" +
+	"\ato_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06toTime\x12(\n" +
+	"\x04sort\x18\x05 \x01(\v2\x14.leaderboard.v1.SortR\x04sort\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageTokenB\f\n" +
+	"\n" +
+	"_metric_idB\x11\n" +
+	"\x0f_participant_id\"\x84\x01\n" +
+	"\x18ListMetricValuesResponse\x12@\n" +
+	"\rmetric_values\x18\x01 \x03(\v2\x1b.leaderboard.v1.MetricValueR\fmetricValues\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x9c\x03\n" +
+	"\x18CreateMetricValueRequest\x12\x1b\n" +
+	"\tmetric_id\x18\x01 \x01(\tR\bmetricId\x12%\n" +
+	"\x0eparticipant_id\x18\x02 \x01(\tR\rparticipantId\x126\n" +
+	"\x17participant_external_id\x18\x03 \x01(\tR\x15participantExternalId\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x16\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\x120\n" +
+	"\acontext\x18\a \x01(\v2\x16.google.protobuf.ValueR\acontext\x12\"\n" +
+	"\n" +
+	"bool_value\x18\b \x01(\bH\x00R\tboolValue\x88\x01\x01\x12&\n" +
+	"\fstring_value\x18\t \x01(\tH\x01R\vstringValue\x88\x01\x01B\r\n" +
+	"\v_bool_valueB\x0f\n" +
+	"\r_string_value\"\xcf\x02\n" +
+	"\x18UpdateMetricValueRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05value\x18\x02 \x01(\x01H\x00R\x05value\x88\x01\x01\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1b\n" +
+	"\x06source\x18\x04 \x01(\tH\x01R\x06source\x88\x01\x01\x120\n" +
+	"\acontext\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\acontext\x12\"\n" +
+	"\n" +
+	"bool_value\x18\x06 \x01(\bH\x02R\tboolValue\x88\x01\x01\x12&\n" +
+	"\fstring_value\x18\a \x01(\tH\x03R\vstringValue\x88\x01\x01B\b\n" +
+	"\x06_valueB\t\n" +
+	"\a_sourceB\r\n" +
+	"\v_bool_valueB\x0f\n" +
+	"\r_string_value\"*\n" +
+	"\x18DeleteMetricValueRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\xe0\x03\n" +
+	"\x12LeaderboardService\x12T\n" +
+	"\x0eGetLeaderboard\x12%.leaderboard.v1.GetLeaderboardRequest\x1a\x1b.leaderboard.v1.Leaderboard\x12e\n" +
+	"\x10ListLeaderboards\x12'.leaderboard.v1.ListLeaderboardsRequest\x1a(.leaderboard.v1.ListLeaderboardsResponse\x12Z\n" +
+	"\x11CreateLeaderboard\x12(.leaderboard.v1.CreateLeaderboardRequest\x1a\x1b.leaderboard.v1.Leaderboard\x12Z\n" +
+	"\x11UpdateLeaderboard\x12(.leaderboard.v1.UpdateLeaderboardRequest\x1a\x1b.leaderboard.v1.Leaderboard\x12U\n" +
+	"\x11DeleteLeaderboard\x12(.leaderboard.v1.DeleteLeaderboardRequest\x1a\x16.google.protobuf.Empty2\xae\x04\n" +
+	"\x17LeaderboardEntryService\x12c\n" +
+	"\x13GetLeaderboardEntry\x12*.leaderboard.v1.GetLeaderboardEntryRequest\x1a .leaderboard.v1.LeaderboardEntry\x12w\n" +
+	"\x16ListLeaderboardEntries\x12-.leaderboard.v1.ListLeaderboardEntriesRequest\x1a..leaderboard.v1.ListLeaderboardEntriesResponse\x12i\n" +
+	"\x16CreateLeaderboardEntry\x12-.leaderboard.v1.CreateLeaderboardEntryRequest\x1a .leaderboard.v1.LeaderboardEntry\x12i\n" +
+	"\x16UpdateLeaderboardEntry\x12-.leaderboard.v1.UpdateLeaderboardEntryRequest\x1a .leaderboard.v1.LeaderboardEntry\x12_\n" +
+	"\x16DeleteLeaderboardEntry\x12-.leaderboard.v1.DeleteLeaderboardEntryRequest\x1a\x16.google.protobuf.Empty2\xe0\x03\n" +
+	"\x12ParticipantService\x12T\n" +
+	"\x0eGetParticipant\x12%.leaderboard.v1.GetParticipantRequest\x1a\x1b.leaderboard.v1.Participant\x12e\n" +
+	"\x10ListParticipants\x12'.leaderboard.v1.ListParticipantsRequest\x1a(.leaderboard.v1.ListParticipantsResponse\x12Z\n" +
+	"\x11CreateParticipant\x12(.leaderboard.v1.CreateParticipantRequest\x1a\x1b.leaderboard.v1.Participant\x12Z\n" +
+	"\x11UpdateParticipant\x12(.leaderboard.v1.UpdateParticipantRequest\x1a\x1b.leaderboard.v1.Participant\x12U\n" +
+	"\x11DeleteParticipant\x12(.leaderboard.v1.DeleteParticipantRequest\x1a\x16.google.protobuf.Empty2\x95\x03\n" +
+	"\rMetricService\x12E\n" +
+	"\tGetMetric\x12 .leaderboard.v1.GetMetricRequest\x1a\x16.leaderboard.v1.Metric\x12V\n" +
+	"\vListMetrics\x12\".leaderboard.v1.ListMetricsRequest\x1a#.leaderboard.v1.ListMetricsResponse\x12K\n" +
+	"\fCreateMetric\x12#.leaderboard.v1.CreateMetricRequest\x1a\x16.leaderboard.v1.Metric\x12K\n" +
+	"\fUpdateMetric\x12#.leaderboard.v1.UpdateMetricRequest\x1a\x16.leaderboard.v1.Metric\x12K\n" +
+	"\fDeleteMetric\x12#.leaderboard.v1.DeleteMetricRequest\x1a\x16.google.protobuf.Empty2\xe0\x03\n" +
+	"\x12MetricValueService\x12T\n" +
+	"\x0eGetMetricValue\x12%.leaderboard.v1.GetMetricValueRequest\x1a\x1b.leaderboard.v1.MetricValue\x12e\n" +
+	"\x10ListMetricValues\x12'.leaderboard.v1.ListMetricValuesRequest\x1a(.leaderboard.v1.ListMetricValuesResponse\x12Z\n" +
+	"\x11CreateMetricValue\x12(.leaderboard.v1.CreateMetricValueRequest\x1a\x1b.leaderboard.v1.MetricValue\x12Z\n" +
+	"\x11UpdateMetricValue\x12(.leaderboard.v1.UpdateMetricValueRequest\x1a\x1b.leaderboard.v1.MetricValue\x12U\n" +
+	"\x11DeleteMetricValue\x12(.leaderboard.v1.DeleteMetricValueRequest\x1a\x16.google.protobuf.EmptyB8Z6leaderboard-service/proto/leaderboard/v1;leaderboardv1b\x06proto3"
+
+var (
+	file_leaderboard_v1_leaderboard_proto_rawDescOnce sync.Once
+	file_leaderboard_v1_leaderboard_proto_rawDescData []byte
+)
+
+func file_leaderboard_v1_leaderboard_proto_rawDescGZIP() []byte {
+	file_leaderboard_v1_leaderboard_proto_rawDescOnce.Do(func() {
+		file_leaderboard_v1_leaderboard_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_leaderboard_v1_leaderboard_proto_rawDesc), len(file_leaderboard_v1_leaderboard_proto_rawDesc)))
+	})
+	return file_leaderboard_v1_leaderboard_proto_rawDescData
+}
+
+var file_leaderboard_v1_leaderboard_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_leaderboard_v1_leaderboard_proto_goTypes = []any{
+	(*Sort)(nil),                           // 0: leaderboard.v1.Sort
+	(*Leaderboard)(nil),                    // 1: leaderboard.v1.Leaderboard
+	(*LeaderboardMetric)(nil),              // 2: leaderboard.v1.LeaderboardMetric
+	(*GetLeaderboardRequest)(nil),          // 3: leaderboard.v1.GetLeaderboardRequest
+	(*ListLeaderboardsRequest)(nil),        // 4: leaderboard.v1.ListLeaderboardsRequest
+	(*ListLeaderboardsResponse)(nil),       // 5: leaderboard.v1.ListLeaderboardsResponse
+	(*CreateLeaderboardRequest)(nil),       // 6: leaderboard.v1.CreateLeaderboardRequest
+	(*UpdateLeaderboardRequest)(nil),       // 7: leaderboard.v1.UpdateLeaderboardRequest
+	(*DeleteLeaderboardRequest)(nil),       // 8: leaderboard.v1.DeleteLeaderboardRequest
+	(*LeaderboardEntry)(nil),               // 9: leaderboard.v1.LeaderboardEntry
+	(*GetLeaderboardEntryRequest)(nil),     // 10: leaderboard.v1.GetLeaderboardEntryRequest
+	(*ListLeaderboardEntriesRequest)(nil),  // 11: leaderboard.v1.ListLeaderboardEntriesRequest
+	(*ListLeaderboardEntriesResponse)(nil), // 12: leaderboard.v1.ListLeaderboardEntriesResponse
+	(*CreateLeaderboardEntryRequest)(nil),  // 13: leaderboard.v1.CreateLeaderboardEntryRequest
+	(*UpdateLeaderboardEntryRequest)(nil),  // 14: leaderboard.v1.UpdateLeaderboardEntryRequest
+	(*DeleteLeaderboardEntryRequest)(nil),  // 15: leaderboard.v1.DeleteLeaderboardEntryRequest
+	(*Participant)(nil),                    // 16: leaderboard.v1.Participant
+	(*GetParticipantRequest)(nil),          // 17: leaderboard.v1.GetParticipantRequest
+	(*ListParticipantsRequest)(nil),        // 18: leaderboard.v1.ListParticipantsRequest
+	(*ListParticipantsResponse)(nil),       // 19: leaderboard.v1.ListParticipantsResponse
+	(*CreateParticipantRequest)(nil),       // 20: leaderboard.v1.CreateParticipantRequest
+	(*UpdateParticipantRequest)(nil),       // 21: leaderboard.v1.UpdateParticipantRequest
+	(*DeleteParticipantRequest)(nil),       // 22: leaderboard.v1.DeleteParticipantRequest
+	(*Metric)(nil),                         // 23: leaderboard.v1.Metric
+	(*GetMetricRequest)(nil),               // 24: leaderboard.v1.GetMetricRequest
+	(*ListMetricsRequest)(nil),             // 25: leaderboard.v1.ListMetricsRequest
+	(*ListMetricsResponse)(nil),            // 26: leaderboard.v1.ListMetricsResponse
+	(*CreateMetricRequest)(nil),            // 27: leaderboard.v1.CreateMetricRequest
+	(*UpdateMetricRequest)(nil),            // 28: leaderboard.v1.UpdateMetricRequest
+	(*DeleteMetricRequest)(nil),            // 29: leaderboard.v1.DeleteMetricRequest
+	(*MetricValue)(nil),                    // 30: leaderboard.v1.MetricValue
+	(*GetMetricValueRequest)(nil),          // 31: leaderboard.v1.GetMetricValueRequest
+	(*ListMetricValuesRequest)(nil),        // 32: leaderboard.v1.ListMetricValuesRequest
+	(*ListMetricValuesResponse)(nil),       // 33: leaderboard.v1.ListMetricValuesResponse
+	(*CreateMetricValueRequest)(nil),       // 34: leaderboard.v1.CreateMetricValueRequest
+	(*UpdateMetricValueRequest)(nil),       // 35: leaderboard.v1.UpdateMetricValueRequest
+	(*DeleteMetricValueRequest)(nil),       // 36: leaderboard.v1.DeleteMetricValueRequest
+	(*timestamppb.Timestamp)(nil),          // 37: google.protobuf.Timestamp
+	(*structpb.Value)(nil),                 // 38: google.protobuf.Value
+	(*structpb.Struct)(nil),                // 39: google.protobuf.Struct
+	(*emptypb.Empty)(nil),                  // 40: google.protobuf.Empty
+}
+var file_leaderboard_v1_leaderboard_proto_depIdxs = []int32{
+	37, // 0: leaderboard.v1.Leaderboard.start_date:type_name -> google.protobuf.Timestamp
+	37, // 1: leaderboard.v1.Leaderboard.end_date:type_name -> google.protobuf.Timestamp
+	2,  // 2: leaderboard.v1.Leaderboard.metrics:type_name -> leaderboard.v1.LeaderboardMetric
+	9,  // 3: leaderboard.v1.Leaderboard.entries:type_name -> leaderboard.v1.LeaderboardEntry
+	37, // 4: leaderboard.v1.Leaderboard.created_at:type_name -> google.protobuf.Timestamp
+	37, // 5: leaderboard.v1.Leaderboard.updated_at:type_name -> google.protobuf.Timestamp
+	37, // 6: leaderboard.v1.LeaderboardMetric.created_at:type_name -> google.protobuf.Timestamp
+	37, // 7: leaderboard.v1.LeaderboardMetric.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: leaderboard.v1.ListLeaderboardsRequest.sort:type_name -> leaderboard.v1.Sort
+	1,  // 9: leaderboard.v1.ListLeaderboardsResponse.leaderboards:type_name -> leaderboard.v1.Leaderboard
+	37, // 10: leaderboard.v1.LeaderboardEntry.last_updated:type_name -> google.protobuf.Timestamp
+	16, // 11: leaderboard.v1.LeaderboardEntry.participant:type_name -> leaderboard.v1.Participant
+	37, // 12: leaderboard.v1.LeaderboardEntry.created_at:type_name -> google.protobuf.Timestamp
+	37, // 13: leaderboard.v1.LeaderboardEntry.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 14: leaderboard.v1.ListLeaderboardEntriesRequest.sort:type_name -> leaderboard.v1.Sort
+	9,  // 15: leaderboard.v1.ListLeaderboardEntriesResponse.entries:type_name -> leaderboard.v1.LeaderboardEntry
+	37, // 16: leaderboard.v1.CreateLeaderboardEntryRequest.last_updated:type_name -> google.protobuf.Timestamp
+	37, // 17: leaderboard.v1.UpdateLeaderboardEntryRequest.last_updated:type_name -> google.protobuf.Timestamp
+	38, // 18: leaderboard.v1.Participant.metadata:type_name -> google.protobuf.Value
+	37, // 19: leaderboard.v1.Participant.anonymized_at:type_name -> google.protobuf.Timestamp
+	37, // 20: leaderboard.v1.Participant.created_at:type_name -> google.protobuf.Timestamp
+	37, // 21: leaderboard.v1.Participant.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 22: leaderboard.v1.ListParticipantsRequest.sort:type_name -> leaderboard.v1.Sort
+	16, // 23: leaderboard.v1.ListParticipantsResponse.participants:type_name -> leaderboard.v1.Participant
+	39, // 24: leaderboard.v1.CreateParticipantRequest.metadata:type_name -> google.protobuf.Struct
+	39, // 25: leaderboard.v1.UpdateParticipantRequest.metadata:type_name -> google.protobuf.Struct
+	37, // 26: leaderboard.v1.Metric.created_at:type_name -> google.protobuf.Timestamp
+	37, // 27: leaderboard.v1.Metric.updated_at:type_name -> google.protobuf.Timestamp
+	23, // 28: leaderboard.v1.ListMetricsResponse.metrics:type_name -> leaderboard.v1.Metric
+	37, // 29: leaderboard.v1.MetricValue.timestamp:type_name -> google.protobuf.Timestamp
+	38, // 30: leaderboard.v1.MetricValue.context:type_name -> google.protobuf.Value
+	37, // 31: leaderboard.v1.MetricValue.created_at:type_name -> google.protobuf.Timestamp
+	37, // 32: leaderboard.v1.MetricValue.updated_at:type_name -> google.protobuf.Timestamp
+	37, // 33: leaderboard.v1.ListMetricValuesRequest.from_time:type_name -> google.protobuf.Timestamp
+	37, // 34: leaderboard.v1.ListMetricValuesRequest.to_time:type_name -> google.protobuf.Timestamp
+	0,  // 35: leaderboard.v1.ListMetricValuesRequest.sort:type_name -> leaderboard.v1.Sort
+	30, // 36: leaderboard.v1.ListMetricValuesResponse.metric_values:type_name -> leaderboard.v1.MetricValue
+	37, // 37: leaderboard.v1.CreateMetricValueRequest.timestamp:type_name -> google.protobuf.Timestamp
+	38, // 38: leaderboard.v1.CreateMetricValueRequest.context:type_name -> google.protobuf.Value
+	37, // 39: leaderboard.v1.UpdateMetricValueRequest.timestamp:type_name -> google.protobuf.Timestamp
+	38, // 40: leaderboard.v1.UpdateMetricValueRequest.context:type_name -> google.protobuf.Value
+	3,  // 41: leaderboard.v1.LeaderboardService.GetLeaderboard:input_type -> leaderboard.v1.GetLeaderboardRequest
+	4,  // 42: leaderboard.v1.LeaderboardService.ListLeaderboards:input_type -> leaderboard.v1.ListLeaderboardsRequest
+	6,  // 43: leaderboard.v1.LeaderboardService.CreateLeaderboard:input_type -> leaderboard.v1.CreateLeaderboardRequest
+	7,  // 44: leaderboard.v1.LeaderboardService.UpdateLeaderboard:input_type -> leaderboard.v1.UpdateLeaderboardRequest
+	8,  // 45: leaderboard.v1.LeaderboardService.DeleteLeaderboard:input_type -> leaderboard.v1.DeleteLeaderboardRequest
+	10, // 46: leaderboard.v1.LeaderboardEntryService.GetLeaderboardEntry:input_type -> leaderboard.v1.GetLeaderboardEntryRequest
+	11, // 47: leaderboard.v1.LeaderboardEntryService.ListLeaderboardEntries:input_type -> leaderboard.v1.ListLeaderboardEntriesRequest
+	13, // 48: leaderboard.v1.LeaderboardEntryService.CreateLeaderboardEntry:input_type -> leaderboard.v1.CreateLeaderboardEntryRequest
+	14, // 49: leaderboard.v1.LeaderboardEntryService.UpdateLeaderboardEntry:input_type -> leaderboard.v1.UpdateLeaderboardEntryRequest
+	15, // 50: leaderboard.v1.LeaderboardEntryService.DeleteLeaderboardEntry:input_type -> leaderboard.v1.DeleteLeaderboardEntryRequest
+	17, // 51: leaderboard.v1.ParticipantService.GetParticipant:input_type -> leaderboard.v1.GetParticipantRequest
+	18, // 52: leaderboard.v1.ParticipantService.ListParticipants:input_type -> leaderboard.v1.ListParticipantsRequest
+	20, // 53: leaderboard.v1.ParticipantService.CreateParticipant:input_type -> leaderboard.v1.CreateParticipantRequest
+	21, // 54: leaderboard.v1.ParticipantService.UpdateParticipant:input_type -> leaderboard.v1.UpdateParticipantRequest
+	22, // 55: leaderboard.v1.ParticipantService.DeleteParticipant:input_type -> leaderboard.v1.DeleteParticipantRequest
+	24, // 56: leaderboard.v1.MetricService.GetMetric:input_type -> leaderboard.v1.GetMetricRequest
+	25, // 57: leaderboard.v1.MetricService.ListMetrics:input_type -> leaderboard.v1.ListMetricsRequest
+	27, // 58: leaderboard.v1.MetricService.CreateMetric:input_type -> leaderboard.v1.CreateMetricRequest
+	28, // 59: leaderboard.v1.MetricService.UpdateMetric:input_type -> leaderboard.v1.UpdateMetricRequest
+	29, // 60: leaderboard.v1.MetricService.DeleteMetric:input_type -> leaderboard.v1.DeleteMetricRequest
+	31, // 61: leaderboard.v1.MetricValueService.GetMetricValue:input_type -> leaderboard.v1.GetMetricValueRequest
+	32, // 62: leaderboard.v1.MetricValueService.ListMetricValues:input_type -> leaderboard.v1.ListMetricValuesRequest
+	34, // 63: leaderboard.v1.MetricValueService.CreateMetricValue:input_type -> leaderboard.v1.CreateMetricValueRequest
+	35, // 64: leaderboard.v1.MetricValueService.UpdateMetricValue:input_type -> leaderboard.v1.UpdateMetricValueRequest
+	36, // 65: leaderboard.v1.MetricValueService.DeleteMetricValue:input_type -> leaderboard.v1.DeleteMetricValueRequest
+	1,  // 66: leaderboard.v1.LeaderboardService.GetLeaderboard:output_type -> leaderboard.v1.Leaderboard
+	5,  // 67: leaderboard.v1.LeaderboardService.ListLeaderboards:output_type -> leaderboard.v1.ListLeaderboardsResponse
+	1,  // 68: leaderboard.v1.LeaderboardService.CreateLeaderboard:output_type -> leaderboard.v1.Leaderboard
+	1,  // 69: leaderboard.v1.LeaderboardService.UpdateLeaderboard:output_type -> leaderboard.v1.Leaderboard
+	40, // 70: leaderboard.v1.LeaderboardService.DeleteLeaderboard:output_type -> google.protobuf.Empty
+	9,  // 71: leaderboard.v1.LeaderboardEntryService.GetLeaderboardEntry:output_type -> leaderboard.v1.LeaderboardEntry
+	12, // 72: leaderboard.v1.LeaderboardEntryService.ListLeaderboardEntries:output_type -> leaderboard.v1.ListLeaderboardEntriesResponse
+	9,  // 73: leaderboard.v1.LeaderboardEntryService.CreateLeaderboardEntry:output_type -> leaderboard.v1.LeaderboardEntry
+	9,  // 74: leaderboard.v1.LeaderboardEntryService.UpdateLeaderboardEntry:output_type -> leaderboard.v1.LeaderboardEntry
+	40, // 75: leaderboard.v1.LeaderboardEntryService.DeleteLeaderboardEntry:output_type -> google.protobuf.Empty
+	16, // 76: leaderboard.v1.ParticipantService.GetParticipant:output_type -> leaderboard.v1.Participant
+	19, // 77: leaderboard.v1.ParticipantService.ListParticipants:output_type -> leaderboard.v1.ListParticipantsResponse
+	16, // 78: leaderboard.v1.ParticipantService.CreateParticipant:output_type -> leaderboard.v1.Participant
+	16, // 79: leaderboard.v1.ParticipantService.UpdateParticipant:output_type -> leaderboard.v1.Participant
+	40, // 80: leaderboard.v1.ParticipantService.DeleteParticipant:output_type -> google.protobuf.Empty
+	23, // 81: leaderboard.v1.MetricService.GetMetric:output_type -> leaderboard.v1.Metric
+	26, // 82: leaderboard.v1.MetricService.ListMetrics:output_type -> leaderboard.v1.ListMetricsResponse
+	23, // 83: leaderboard.v1.MetricService.CreateMetric:output_type -> leaderboard.v1.Metric
+	23, // 84: leaderboard.v1.MetricService.UpdateMetric:output_type -> leaderboard.v1.Metric
+	40, // 85: leaderboard.v1.MetricService.DeleteMetric:output_type -> google.protobuf.Empty
+	30, // 86: leaderboard.v1.MetricValueService.GetMetricValue:output_type -> leaderboard.v1.MetricValue
+	33, // 87: leaderboard.v1.MetricValueService.ListMetricValues:output_type -> leaderboard.v1.ListMetricValuesResponse
+	30, // 88: leaderboard.v1.MetricValueService.CreateMetricValue:output_type -> leaderboard.v1.MetricValue
+	30, // 89: leaderboard.v1.MetricValueService.UpdateMetricValue:output_type -> leaderboard.v1.MetricValue
+	40, // 90: leaderboard.v1.MetricValueService.DeleteMetricValue:output_type -> google.protobuf.Empty
+	66, // [66:91] is the sub-list for method output_type
+	41, // [41:66] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
+}
+
+func init() { file_leaderboard_v1_leaderboard_proto_init() }
+func file_leaderboard_v1_leaderboard_proto_init() {
+	if File_leaderboard_v1_leaderboard_proto != nil {
+		return
+	}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[1].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[4].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[6].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[7].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[11].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[14].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[16].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[20].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[21].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[27].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[28].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[30].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[32].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[34].OneofWrappers = []any{}
+	file_leaderboard_v1_leaderboard_proto_msgTypes[35].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_leaderboard_v1_leaderboard_proto_rawDesc), len(file_leaderboard_v1_leaderboard_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   37,
+			NumExtensions: 0,
+			NumServices:   5,
+		},
+		GoTypes:           file_leaderboard_v1_leaderboard_proto_goTypes,
+		DependencyIndexes: file_leaderboard_v1_leaderboard_proto_depIdxs,
+		MessageInfos:      file_leaderboard_v1_leaderboard_proto_msgTypes,
+	}.Build()
+	File_leaderboard_v1_leaderboard_proto = out.File
+	file_leaderboard_v1_leaderboard_proto_goTypes = nil
+	file_leaderboard_v1_leaderboard_proto_depIdxs = nil
+}
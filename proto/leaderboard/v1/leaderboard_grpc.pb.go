@@ -0,0 +1,1298 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: leaderboard/v1/leaderboard.proto
+
+// Typed API for internal services. It mirrors the REST resources under /api/v1 and goes through the same service
+// layer, validation and permission checks. Enum-like fields carry the same strings as the REST API.
+
+package leaderboardv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LeaderboardService_GetLeaderboard_FullMethodName    = "/leaderboard.v1.LeaderboardService/GetLeaderboard"
+	LeaderboardService_ListLeaderboards_FullMethodName  = "/leaderboard.v1.LeaderboardService/ListLeaderboards"
+	LeaderboardService_CreateLeaderboard_FullMethodName = "/leaderboard.v1.LeaderboardService/CreateLeaderboard"
+	LeaderboardService_UpdateLeaderboard_FullMethodName = "/leaderboard.v1.LeaderboardService/UpdateLeaderboard"
+	LeaderboardService_DeleteLeaderboard_FullMethodName = "/leaderboard.v1.LeaderboardService/DeleteLeaderboard"
+)
+
+// LeaderboardServiceClient is the client API for LeaderboardService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Private leaderboards respond with NOT_FOUND to callers without access, as over REST
+type LeaderboardServiceClient interface {
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*Leaderboard, error)
+	ListLeaderboards(ctx context.Context, in *ListLeaderboardsRequest, opts ...grpc.CallOption) (*ListLeaderboardsResponse, error)
+	CreateLeaderboard(ctx context.Context, in *CreateLeaderboardRequest, opts ...grpc.CallOption) (*Leaderboard, error)
+	UpdateLeaderboard(ctx context.Context, in *UpdateLeaderboardRequest, opts ...grpc.CallOption) (*Leaderboard, error)
+	DeleteLeaderboard(ctx context.Context, in *DeleteLeaderboardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type leaderboardServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeaderboardServiceClient(cc grpc.ClientConnInterface) LeaderboardServiceClient {
+	return &leaderboardServiceClient{cc}
+}
+
+func (c *leaderboardServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*Leaderboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Leaderboard)
+	err := c.cc.Invoke(ctx, LeaderboardService_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) ListLeaderboards(ctx context.Context, in *ListLeaderboardsRequest, opts ...grpc.CallOption) (*ListLeaderboardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLeaderboardsResponse)
+	err := c.cc.Invoke(ctx, LeaderboardService_ListLeaderboards_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) CreateLeaderboard(ctx context.Context, in *CreateLeaderboardRequest, opts ...grpc.CallOption) (*Leaderboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Leaderboard)
+	err := c.cc.Invoke(ctx, LeaderboardService_CreateLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) UpdateLeaderboard(ctx context.Context, in *UpdateLeaderboardRequest, opts ...grpc.CallOption) (*Leaderboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Leaderboard)
+	err := c.cc.Invoke(ctx, LeaderboardService_UpdateLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) DeleteLeaderboard(ctx context.Context, in *DeleteLeaderboardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LeaderboardService_DeleteLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LeaderboardServiceServer is the server API for LeaderboardService service.
+// All implementations must embed UnimplementedLeaderboardServiceServer
+// for forward compatibility.
+//
+// Private leaderboards respond with NOT_FOUND to callers without access, as over REST
+type LeaderboardServiceServer interface {
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*Leaderboard, error)
+	ListLeaderboards(context.Context, *ListLeaderboardsRequest) (*ListLeaderboardsResponse, error)
+	CreateLeaderboard(context.Context, *CreateLeaderboardRequest) (*Leaderboard, error)
+	UpdateLeaderboard(context.Context, *UpdateLeaderboardRequest) (*Leaderboard, error)
+	DeleteLeaderboard(context.Context, *DeleteLeaderboardRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedLeaderboardServiceServer()
+}
+
+// UnimplementedLeaderboardServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLeaderboardServiceServer struct{}
+
+func (UnimplementedLeaderboardServiceServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*Leaderboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) ListLeaderboards(context.Context, *ListLeaderboardsRequest) (*ListLeaderboardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLeaderboards not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) CreateLeaderboard(context.Context, *CreateLeaderboardRequest) (*Leaderboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLeaderboard not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) UpdateLeaderboard(context.Context, *UpdateLeaderboardRequest) (*Leaderboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLeaderboard not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) DeleteLeaderboard(context.Context, *DeleteLeaderboardRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLeaderboard not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) mustEmbedUnimplementedLeaderboardServiceServer() {}
+func (UnimplementedLeaderboardServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeLeaderboardServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeaderboardServiceServer will
+// result in compilation errors.
+type UnsafeLeaderboardServiceServer interface {
+	mustEmbedUnimplementedLeaderboardServiceServer()
+}
+
+func RegisterLeaderboardServiceServer(s grpc.ServiceRegistrar, srv LeaderboardServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLeaderboardServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LeaderboardService_ServiceDesc, srv)
+}
+
+func _LeaderboardService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_ListLeaderboards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeaderboardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).ListLeaderboards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_ListLeaderboards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).ListLeaderboards(ctx, req.(*ListLeaderboardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_CreateLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).CreateLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_CreateLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).CreateLeaderboard(ctx, req.(*CreateLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_UpdateLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).UpdateLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_UpdateLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).UpdateLeaderboard(ctx, req.(*UpdateLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_DeleteLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).DeleteLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_DeleteLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).DeleteLeaderboard(ctx, req.(*DeleteLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LeaderboardService_ServiceDesc is the grpc.ServiceDesc for LeaderboardService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LeaderboardService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaderboard.v1.LeaderboardService",
+	HandlerType: (*LeaderboardServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _LeaderboardService_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "ListLeaderboards",
+			Handler:    _LeaderboardService_ListLeaderboards_Handler,
+		},
+		{
+			MethodName: "CreateLeaderboard",
+			Handler:    _LeaderboardService_CreateLeaderboard_Handler,
+		},
+		{
+			MethodName: "UpdateLeaderboard",
+			Handler:    _LeaderboardService_UpdateLeaderboard_Handler,
+		},
+		{
+			MethodName: "DeleteLeaderboard",
+			Handler:    _LeaderboardService_DeleteLeaderboard_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "leaderboard/v1/leaderboard.proto",
+}
+
+const (
+	LeaderboardEntryService_GetLeaderboardEntry_FullMethodName    = "/leaderboard.v1.LeaderboardEntryService/GetLeaderboardEntry"
+	LeaderboardEntryService_ListLeaderboardEntries_FullMethodName = "/leaderboard.v1.LeaderboardEntryService/ListLeaderboardEntries"
+	LeaderboardEntryService_CreateLeaderboardEntry_FullMethodName = "/leaderboard.v1.LeaderboardEntryService/CreateLeaderboardEntry"
+	LeaderboardEntryService_UpdateLeaderboardEntry_FullMethodName = "/leaderboard.v1.LeaderboardEntryService/UpdateLeaderboardEntry"
+	LeaderboardEntryService_DeleteLeaderboardEntry_FullMethodName = "/leaderboard.v1.LeaderboardEntryService/DeleteLeaderboardEntry"
+)
+
+// LeaderboardEntryServiceClient is the client API for LeaderboardEntryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LeaderboardEntryServiceClient interface {
+	GetLeaderboardEntry(ctx context.Context, in *GetLeaderboardEntryRequest, opts ...grpc.CallOption) (*LeaderboardEntry, error)
+	ListLeaderboardEntries(ctx context.Context, in *ListLeaderboardEntriesRequest, opts ...grpc.CallOption) (*ListLeaderboardEntriesResponse, error)
+	CreateLeaderboardEntry(ctx context.Context, in *CreateLeaderboardEntryRequest, opts ...grpc.CallOption) (*LeaderboardEntry, error)
+	UpdateLeaderboardEntry(ctx context.Context, in *UpdateLeaderboardEntryRequest, opts ...grpc.CallOption) (*LeaderboardEntry, error)
+	DeleteLeaderboardEntry(ctx context.Context, in *DeleteLeaderboardEntryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type leaderboardEntryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeaderboardEntryServiceClient(cc grpc.ClientConnInterface) LeaderboardEntryServiceClient {
+	return &leaderboardEntryServiceClient{cc}
+}
+
+func (c *leaderboardEntryServiceClient) GetLeaderboardEntry(ctx context.Context, in *GetLeaderboardEntryRequest, opts ...grpc.CallOption) (*LeaderboardEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaderboardEntry)
+	err := c.cc.Invoke(ctx, LeaderboardEntryService_GetLeaderboardEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardEntryServiceClient) ListLeaderboardEntries(ctx context.Context, in *ListLeaderboardEntriesRequest, opts ...grpc.CallOption) (*ListLeaderboardEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLeaderboardEntriesResponse)
+	err := c.cc.Invoke(ctx, LeaderboardEntryService_ListLeaderboardEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardEntryServiceClient) CreateLeaderboardEntry(ctx context.Context, in *CreateLeaderboardEntryRequest, opts ...grpc.CallOption) (*LeaderboardEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaderboardEntry)
+	err := c.cc.Invoke(ctx, LeaderboardEntryService_CreateLeaderboardEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardEntryServiceClient) UpdateLeaderboardEntry(ctx context.Context, in *UpdateLeaderboardEntryRequest, opts ...grpc.CallOption) (*LeaderboardEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaderboardEntry)
+	err := c.cc.Invoke(ctx, LeaderboardEntryService_UpdateLeaderboardEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardEntryServiceClient) DeleteLeaderboardEntry(ctx context.Context, in *DeleteLeaderboardEntryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LeaderboardEntryService_DeleteLeaderboardEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LeaderboardEntryServiceServer is the server API for LeaderboardEntryService service.
+// All implementations must embed UnimplementedLeaderboardEntryServiceServer
+// for forward compatibility.
+type LeaderboardEntryServiceServer interface {
+	GetLeaderboardEntry(context.Context, *GetLeaderboardEntryRequest) (*LeaderboardEntry, error)
+	ListLeaderboardEntries(context.Context, *ListLeaderboardEntriesRequest) (*ListLeaderboardEntriesResponse, error)
+	CreateLeaderboardEntry(context.Context, *CreateLeaderboardEntryRequest) (*LeaderboardEntry, error)
+	UpdateLeaderboardEntry(context.Context, *UpdateLeaderboardEntryRequest) (*LeaderboardEntry, error)
+	DeleteLeaderboardEntry(context.Context, *DeleteLeaderboardEntryRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedLeaderboardEntryServiceServer()
+}
+
+// UnimplementedLeaderboardEntryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLeaderboardEntryServiceServer struct{}
+
+func (UnimplementedLeaderboardEntryServiceServer) GetLeaderboardEntry(context.Context, *GetLeaderboardEntryRequest) (*LeaderboardEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboardEntry not implemented")
+}
+func (UnimplementedLeaderboardEntryServiceServer) ListLeaderboardEntries(context.Context, *ListLeaderboardEntriesRequest) (*ListLeaderboardEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLeaderboardEntries not implemented")
+}
+func (UnimplementedLeaderboardEntryServiceServer) CreateLeaderboardEntry(context.Context, *CreateLeaderboardEntryRequest) (*LeaderboardEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLeaderboardEntry not implemented")
+}
+func (UnimplementedLeaderboardEntryServiceServer) UpdateLeaderboardEntry(context.Context, *UpdateLeaderboardEntryRequest) (*LeaderboardEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLeaderboardEntry not implemented")
+}
+func (UnimplementedLeaderboardEntryServiceServer) DeleteLeaderboardEntry(context.Context, *DeleteLeaderboardEntryRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLeaderboardEntry not implemented")
+}
+func (UnimplementedLeaderboardEntryServiceServer) mustEmbedUnimplementedLeaderboardEntryServiceServer() {
+}
+func (UnimplementedLeaderboardEntryServiceServer) testEmbeddedByValue() {}
+
+// UnsafeLeaderboardEntryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeaderboardEntryServiceServer will
+// result in compilation errors.
+type UnsafeLeaderboardEntryServiceServer interface {
+	mustEmbedUnimplementedLeaderboardEntryServiceServer()
+}
+
+func RegisterLeaderboardEntryServiceServer(s grpc.ServiceRegistrar, srv LeaderboardEntryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLeaderboardEntryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LeaderboardEntryService_ServiceDesc, srv)
+}
+
+func _LeaderboardEntryService_GetLeaderboardEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardEntryServiceServer).GetLeaderboardEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardEntryService_GetLeaderboardEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardEntryServiceServer).GetLeaderboardEntry(ctx, req.(*GetLeaderboardEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardEntryService_ListLeaderboardEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeaderboardEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardEntryServiceServer).ListLeaderboardEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardEntryService_ListLeaderboardEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardEntryServiceServer).ListLeaderboardEntries(ctx, req.(*ListLeaderboardEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardEntryService_CreateLeaderboardEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLeaderboardEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardEntryServiceServer).CreateLeaderboardEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardEntryService_CreateLeaderboardEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardEntryServiceServer).CreateLeaderboardEntry(ctx, req.(*CreateLeaderboardEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardEntryService_UpdateLeaderboardEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLeaderboardEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardEntryServiceServer).UpdateLeaderboardEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardEntryService_UpdateLeaderboardEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardEntryServiceServer).UpdateLeaderboardEntry(ctx, req.(*UpdateLeaderboardEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardEntryService_DeleteLeaderboardEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLeaderboardEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardEntryServiceServer).DeleteLeaderboardEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardEntryService_DeleteLeaderboardEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardEntryServiceServer).DeleteLeaderboardEntry(ctx, req.(*DeleteLeaderboardEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LeaderboardEntryService_ServiceDesc is the grpc.ServiceDesc for LeaderboardEntryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LeaderboardEntryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaderboard.v1.LeaderboardEntryService",
+	HandlerType: (*LeaderboardEntryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLeaderboardEntry",
+			Handler:    _LeaderboardEntryService_GetLeaderboardEntry_Handler,
+		},
+		{
+			MethodName: "ListLeaderboardEntries",
+			Handler:    _LeaderboardEntryService_ListLeaderboardEntries_Handler,
+		},
+		{
+			MethodName: "CreateLeaderboardEntry",
+			Handler:    _LeaderboardEntryService_CreateLeaderboardEntry_Handler,
+		},
+		{
+			MethodName: "UpdateLeaderboardEntry",
+			Handler:    _LeaderboardEntryService_UpdateLeaderboardEntry_Handler,
+		},
+		{
+			MethodName: "DeleteLeaderboardEntry",
+			Handler:    _LeaderboardEntryService_DeleteLeaderboardEntry_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "leaderboard/v1/leaderboard.proto",
+}
+
+const (
+	ParticipantService_GetParticipant_FullMethodName    = "/leaderboard.v1.ParticipantService/GetParticipant"
+	ParticipantService_ListParticipants_FullMethodName  = "/leaderboard.v1.ParticipantService/ListParticipants"
+	ParticipantService_CreateParticipant_FullMethodName = "/leaderboard.v1.ParticipantService/CreateParticipant"
+	ParticipantService_UpdateParticipant_FullMethodName = "/leaderboard.v1.ParticipantService/UpdateParticipant"
+	ParticipantService_DeleteParticipant_FullMethodName = "/leaderboard.v1.ParticipantService/DeleteParticipant"
+)
+
+// ParticipantServiceClient is the client API for ParticipantService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ParticipantServiceClient interface {
+	GetParticipant(ctx context.Context, in *GetParticipantRequest, opts ...grpc.CallOption) (*Participant, error)
+	ListParticipants(ctx context.Context, in *ListParticipantsRequest, opts ...grpc.CallOption) (*ListParticipantsResponse, error)
+	CreateParticipant(ctx context.Context, in *CreateParticipantRequest, opts ...grpc.CallOption) (*Participant, error)
+	UpdateParticipant(ctx context.Context, in *UpdateParticipantRequest, opts ...grpc.CallOption) (*Participant, error)
+	DeleteParticipant(ctx context.Context, in *DeleteParticipantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type participantServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewParticipantServiceClient(cc grpc.ClientConnInterface) ParticipantServiceClient {
+	return &participantServiceClient{cc}
+}
+
+func (c *participantServiceClient) GetParticipant(ctx context.Context, in *GetParticipantRequest, opts ...grpc.CallOption) (*Participant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Participant)
+	err := c.cc.Invoke(ctx, ParticipantService_GetParticipant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *participantServiceClient) ListParticipants(ctx context.Context, in *ListParticipantsRequest, opts ...grpc.CallOption) (*ListParticipantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListParticipantsResponse)
+	err := c.cc.Invoke(ctx, ParticipantService_ListParticipants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *participantServiceClient) CreateParticipant(ctx context.Context, in *CreateParticipantRequest, opts ...grpc.CallOption) (*Participant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Participant)
+	err := c.cc.Invoke(ctx, ParticipantService_CreateParticipant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *participantServiceClient) UpdateParticipant(ctx context.Context, in *UpdateParticipantRequest, opts ...grpc.CallOption) (*Participant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Participant)
+	err := c.cc.Invoke(ctx, ParticipantService_UpdateParticipant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *participantServiceClient) DeleteParticipant(ctx context.Context, in *DeleteParticipantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ParticipantService_DeleteParticipant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ParticipantServiceServer is the server API for ParticipantService service.
+// All implementations must embed UnimplementedParticipantServiceServer
+// for forward compatibility.
+type ParticipantServiceServer interface {
+	GetParticipant(context.Context, *GetParticipantRequest) (*Participant, error)
+	ListParticipants(context.Context, *ListParticipantsRequest) (*ListParticipantsResponse, error)
+	CreateParticipant(context.Context, *CreateParticipantRequest) (*Participant, error)
+	UpdateParticipant(context.Context, *UpdateParticipantRequest) (*Participant, error)
+	DeleteParticipant(context.Context, *DeleteParticipantRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedParticipantServiceServer()
+}
+
+// UnimplementedParticipantServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedParticipantServiceServer struct{}
+
+func (UnimplementedParticipantServiceServer) GetParticipant(context.Context, *GetParticipantRequest) (*Participant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetParticipant not implemented")
+}
+func (UnimplementedParticipantServiceServer) ListParticipants(context.Context, *ListParticipantsRequest) (*ListParticipantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListParticipants not implemented")
+}
+func (UnimplementedParticipantServiceServer) CreateParticipant(context.Context, *CreateParticipantRequest) (*Participant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateParticipant not implemented")
+}
+func (UnimplementedParticipantServiceServer) UpdateParticipant(context.Context, *UpdateParticipantRequest) (*Participant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateParticipant not implemented")
+}
+func (UnimplementedParticipantServiceServer) DeleteParticipant(context.Context, *DeleteParticipantRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteParticipant not implemented")
+}
+func (UnimplementedParticipantServiceServer) mustEmbedUnimplementedParticipantServiceServer() {}
+func (UnimplementedParticipantServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeParticipantServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ParticipantServiceServer will
+// result in compilation errors.
+type UnsafeParticipantServiceServer interface {
+	mustEmbedUnimplementedParticipantServiceServer()
+}
+
+func RegisterParticipantServiceServer(s grpc.ServiceRegistrar, srv ParticipantServiceServer) {
+	// If the following call pancis, it indicates UnimplementedParticipantServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ParticipantService_ServiceDesc, srv)
+}
+
+func _ParticipantService_GetParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetParticipantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParticipantServiceServer).GetParticipant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParticipantService_GetParticipant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParticipantServiceServer).GetParticipant(ctx, req.(*GetParticipantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParticipantService_ListParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListParticipantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParticipantServiceServer).ListParticipants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParticipantService_ListParticipants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParticipantServiceServer).ListParticipants(ctx, req.(*ListParticipantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParticipantService_CreateParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateParticipantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParticipantServiceServer).CreateParticipant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParticipantService_CreateParticipant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParticipantServiceServer).CreateParticipant(ctx, req.(*CreateParticipantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParticipantService_UpdateParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateParticipantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParticipantServiceServer).UpdateParticipant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParticipantService_UpdateParticipant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParticipantServiceServer).UpdateParticipant(ctx, req.(*UpdateParticipantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParticipantService_DeleteParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteParticipantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParticipantServiceServer).DeleteParticipant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParticipantService_DeleteParticipant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParticipantServiceServer).DeleteParticipant(ctx, req.(*DeleteParticipantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ParticipantService_ServiceDesc is the grpc.ServiceDesc for ParticipantService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ParticipantService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaderboard.v1.ParticipantService",
+	HandlerType: (*ParticipantServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetParticipant",
+			Handler:    _ParticipantService_GetParticipant_Handler,
+		},
+		{
+			MethodName: "ListParticipants",
+			Handler:    _ParticipantService_ListParticipants_Handler,
+		},
+		{
+			MethodName: "CreateParticipant",
+			Handler:    _ParticipantService_CreateParticipant_Handler,
+		},
+		{
+			MethodName: "UpdateParticipant",
+			Handler:    _ParticipantService_UpdateParticipant_Handler,
+		},
+		{
+			MethodName: "DeleteParticipant",
+			Handler:    _ParticipantService_DeleteParticipant_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "leaderboard/v1/leaderboard.proto",
+}
+
+const (
+	MetricService_GetMetric_FullMethodName    = "/leaderboard.v1.MetricService/GetMetric"
+	MetricService_ListMetrics_FullMethodName  = "/leaderboard.v1.MetricService/ListMetrics"
+	MetricService_CreateMetric_FullMethodName = "/leaderboard.v1.MetricService/CreateMetric"
+	MetricService_UpdateMetric_FullMethodName = "/leaderboard.v1.MetricService/UpdateMetric"
+	MetricService_DeleteMetric_FullMethodName = "/leaderboard.v1.MetricService/DeleteMetric"
+)
+
+// MetricServiceClient is the client API for MetricService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MetricServiceClient interface {
+	GetMetric(ctx context.Context, in *GetMetricRequest, opts ...grpc.CallOption) (*Metric, error)
+	ListMetrics(ctx context.Context, in *ListMetricsRequest, opts ...grpc.CallOption) (*ListMetricsResponse, error)
+	CreateMetric(ctx context.Context, in *CreateMetricRequest, opts ...grpc.CallOption) (*Metric, error)
+	UpdateMetric(ctx context.Context, in *UpdateMetricRequest, opts ...grpc.CallOption) (*Metric, error)
+	DeleteMetric(ctx context.Context, in *DeleteMetricRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type metricServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMetricServiceClient(cc grpc.ClientConnInterface) MetricServiceClient {
+	return &metricServiceClient{cc}
+}
+
+func (c *metricServiceClient) GetMetric(ctx context.Context, in *GetMetricRequest, opts ...grpc.CallOption) (*Metric, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Metric)
+	err := c.cc.Invoke(ctx, MetricService_GetMetric_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricServiceClient) ListMetrics(ctx context.Context, in *ListMetricsRequest, opts ...grpc.CallOption) (*ListMetricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMetricsResponse)
+	err := c.cc.Invoke(ctx, MetricService_ListMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricServiceClient) CreateMetric(ctx context.Context, in *CreateMetricRequest, opts ...grpc.CallOption) (*Metric, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Metric)
+	err := c.cc.Invoke(ctx, MetricService_CreateMetric_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricServiceClient) UpdateMetric(ctx context.Context, in *UpdateMetricRequest, opts ...grpc.CallOption) (*Metric, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Metric)
+	err := c.cc.Invoke(ctx, MetricService_UpdateMetric_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricServiceClient) DeleteMetric(ctx context.Context, in *DeleteMetricRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MetricService_DeleteMetric_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MetricServiceServer is the server API for MetricService service.
+// All implementations must embed UnimplementedMetricServiceServer
+// for forward compatibility.
+type MetricServiceServer interface {
+	GetMetric(context.Context, *GetMetricRequest) (*Metric, error)
+	ListMetrics(context.Context, *ListMetricsRequest) (*ListMetricsResponse, error)
+	CreateMetric(context.Context, *CreateMetricRequest) (*Metric, error)
+	UpdateMetric(context.Context, *UpdateMetricRequest) (*Metric, error)
+	DeleteMetric(context.Context, *DeleteMetricRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedMetricServiceServer()
+}
+
+// UnimplementedMetricServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMetricServiceServer struct{}
+
+func (UnimplementedMetricServiceServer) GetMetric(context.Context, *GetMetricRequest) (*Metric, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetric not implemented")
+}
+func (UnimplementedMetricServiceServer) ListMetrics(context.Context, *ListMetricsRequest) (*ListMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMetrics not implemented")
+}
+func (UnimplementedMetricServiceServer) CreateMetric(context.Context, *CreateMetricRequest) (*Metric, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMetric not implemented")
+}
+func (UnimplementedMetricServiceServer) UpdateMetric(context.Context, *UpdateMetricRequest) (*Metric, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMetric not implemented")
+}
+func (UnimplementedMetricServiceServer) DeleteMetric(context.Context, *DeleteMetricRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMetric not implemented")
+}
+func (UnimplementedMetricServiceServer) mustEmbedUnimplementedMetricServiceServer() {}
+func (UnimplementedMetricServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeMetricServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MetricServiceServer will
+// result in compilation errors.
+type UnsafeMetricServiceServer interface {
+	mustEmbedUnimplementedMetricServiceServer()
+}
+
+func RegisterMetricServiceServer(s grpc.ServiceRegistrar, srv MetricServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMetricServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MetricService_ServiceDesc, srv)
+}
+
+func _MetricService_GetMetric_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMetricRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricServiceServer).GetMetric(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricService_GetMetric_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricServiceServer).GetMetric(ctx, req.(*GetMetricRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetricService_ListMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricServiceServer).ListMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricService_ListMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricServiceServer).ListMetrics(ctx, req.(*ListMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetricService_CreateMetric_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMetricRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricServiceServer).CreateMetric(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricService_CreateMetric_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricServiceServer).CreateMetric(ctx, req.(*CreateMetricRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetricService_UpdateMetric_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMetricRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricServiceServer).UpdateMetric(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricService_UpdateMetric_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricServiceServer).UpdateMetric(ctx, req.(*UpdateMetricRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetricService_DeleteMetric_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMetricRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricServiceServer).DeleteMetric(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricService_DeleteMetric_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricServiceServer).DeleteMetric(ctx, req.(*DeleteMetricRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MetricService_ServiceDesc is the grpc.ServiceDesc for MetricService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MetricService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaderboard.v1.MetricService",
+	HandlerType: (*MetricServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMetric",
+			Handler:    _MetricService_GetMetric_Handler,
+		},
+		{
+			MethodName: "ListMetrics",
+			Handler:    _MetricService_ListMetrics_Handler,
+		},
+		{
+			MethodName: "CreateMetric",
+			Handler:    _MetricService_CreateMetric_Handler,
+		},
+		{
+			MethodName: "UpdateMetric",
+			Handler:    _MetricService_UpdateMetric_Handler,
+		},
+		{
+			MethodName: "DeleteMetric",
+			Handler:    _MetricService_DeleteMetric_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "leaderboard/v1/leaderboard.proto",
+}
+
+const (
+	MetricValueService_GetMetricValue_FullMethodName    = "/leaderboard.v1.MetricValueService/GetMetricValue"
+	MetricValueService_ListMetricValues_FullMethodName  = "/leaderboard.v1.MetricValueService/ListMetricValues"
+	MetricValueService_CreateMetricValue_FullMethodName = "/leaderboard.v1.MetricValueService/CreateMetricValue"
+	MetricValueService_UpdateMetricValue_FullMethodName = "/leaderboard.v1.MetricValueService/UpdateMetricValue"
+	MetricValueService_DeleteMetricValue_FullMethodName = "/leaderboard.v1.MetricValueService/DeleteMetricValue"
+)
+
+// MetricValueServiceClient is the client API for MetricValueService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MetricValueServiceClient interface {
+	GetMetricValue(ctx context.Context, in *GetMetricValueRequest, opts ...grpc.CallOption) (*MetricValue, error)
+	ListMetricValues(ctx context.Context, in *ListMetricValuesRequest, opts ...grpc.CallOption) (*ListMetricValuesResponse, error)
+	CreateMetricValue(ctx context.Context, in *CreateMetricValueRequest, opts ...grpc.CallOption) (*MetricValue, error)
+	UpdateMetricValue(ctx context.Context, in *UpdateMetricValueRequest, opts ...grpc.CallOption) (*MetricValue, error)
+	DeleteMetricValue(ctx context.Context, in *DeleteMetricValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type metricValueServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMetricValueServiceClient(cc grpc.ClientConnInterface) MetricValueServiceClient {
+	return &metricValueServiceClient{cc}
+}
+
+func (c *metricValueServiceClient) GetMetricValue(ctx context.Context, in *GetMetricValueRequest, opts ...grpc.CallOption) (*MetricValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MetricValue)
+	err := c.cc.Invoke(ctx, MetricValueService_GetMetricValue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricValueServiceClient) ListMetricValues(ctx context.Context, in *ListMetricValuesRequest, opts ...grpc.CallOption) (*ListMetricValuesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMetricValuesResponse)
+	err := c.cc.Invoke(ctx, MetricValueService_ListMetricValues_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricValueServiceClient) CreateMetricValue(ctx context.Context, in *CreateMetricValueRequest, opts ...grpc.CallOption) (*MetricValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MetricValue)
+	err := c.cc.Invoke(ctx, MetricValueService_CreateMetricValue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricValueServiceClient) UpdateMetricValue(ctx context.Context, in *UpdateMetricValueRequest, opts ...grpc.CallOption) (*MetricValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MetricValue)
+	err := c.cc.Invoke(ctx, MetricValueService_UpdateMetricValue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricValueServiceClient) DeleteMetricValue(ctx context.Context, in *DeleteMetricValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MetricValueService_DeleteMetricValue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MetricValueServiceServer is the server API for MetricValueService service.
+// All implementations must embed UnimplementedMetricValueServiceServer
+// for forward compatibility.
+type MetricValueServiceServer interface {
+	GetMetricValue(context.Context, *GetMetricValueRequest) (*MetricValue, error)
+	ListMetricValues(context.Context, *ListMetricValuesRequest) (*ListMetricValuesResponse, error)
+	CreateMetricValue(context.Context, *CreateMetricValueRequest) (*MetricValue, error)
+	UpdateMetricValue(context.Context, *UpdateMetricValueRequest) (*MetricValue, error)
+	DeleteMetricValue(context.Context, *DeleteMetricValueRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedMetricValueServiceServer()
+}
+
+// UnimplementedMetricValueServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMetricValueServiceServer struct{}
+
+func (UnimplementedMetricValueServiceServer) GetMetricValue(context.Context, *GetMetricValueRequest) (*MetricValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetricValue not implemented")
+}
+func (UnimplementedMetricValueServiceServer) ListMetricValues(context.Context, *ListMetricValuesRequest) (*ListMetricValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMetricValues not implemented")
+}
+func (UnimplementedMetricValueServiceServer) CreateMetricValue(context.Context, *CreateMetricValueRequest) (*MetricValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMetricValue not implemented")
+}
+func (UnimplementedMetricValueServiceServer) UpdateMetricValue(context.Context, *UpdateMetricValueRequest) (*MetricValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMetricValue not implemented")
+}
+func (UnimplementedMetricValueServiceServer) DeleteMetricValue(context.Context, *DeleteMetricValueRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMetricValue not implemented")
+}
+func (UnimplementedMetricValueServiceServer) mustEmbedUnimplementedMetricValueServiceServer() {}
+func (UnimplementedMetricValueServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeMetricValueServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MetricValueServiceServer will
+// result in compilation errors.
+type UnsafeMetricValueServiceServer interface {
+	mustEmbedUnimplementedMetricValueServiceServer()
+}
+
+func RegisterMetricValueServiceServer(s grpc.ServiceRegistrar, srv MetricValueServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMetricValueServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MetricValueService_ServiceDesc, srv)
+}
+
+func _MetricValueService_GetMetricValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMetricValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricValueServiceServer).GetMetricValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricValueService_GetMetricValue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricValueServiceServer).GetMetricValue(ctx, req.(*GetMetricValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetricValueService_ListMetricValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMetricValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricValueServiceServer).ListMetricValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricValueService_ListMetricValues_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricValueServiceServer).ListMetricValues(ctx, req.(*ListMetricValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetricValueService_CreateMetricValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMetricValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricValueServiceServer).CreateMetricValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricValueService_CreateMetricValue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricValueServiceServer).CreateMetricValue(ctx, req.(*CreateMetricValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetricValueService_UpdateMetricValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMetricValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricValueServiceServer).UpdateMetricValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricValueService_UpdateMetricValue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricValueServiceServer).UpdateMetricValue(ctx, req.(*UpdateMetricValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetricValueService_DeleteMetricValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMetricValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricValueServiceServer).DeleteMetricValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricValueService_DeleteMetricValue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricValueServiceServer).DeleteMetricValue(ctx, req.(*DeleteMetricValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MetricValueService_ServiceDesc is the grpc.ServiceDesc for MetricValueService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MetricValueService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaderboard.v1.MetricValueService",
+	HandlerType: (*MetricValueServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMetricValue",
+			Handler:    _MetricValueService_GetMetricValue_Handler,
+		},
+		{
+			MethodName: "ListMetricValues",
+			Handler:    _MetricValueService_ListMetricValues_Handler,
+		},
+		{
+			MethodName: "CreateMetricValue",
+			Handler:    _MetricValueService_CreateMetricValue_Handler,
+		},
+		{
+			MethodName: "UpdateMetricValue",
+			Handler:    _MetricValueService_UpdateMetricValue_Handler,
+		},
+		{
+			MethodName: "DeleteMetricValue",
+			Handler:    _MetricValueService_DeleteMetricValue_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "leaderboard/v1/leaderboard.proto",
+}
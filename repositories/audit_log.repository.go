@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"leaderboard-service/db"
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditLogFilter narrows the audit logs a query returns. Unset fields match every log.
+type AuditLogFilter struct {
+	EntityType *enums.AuditEntityType
+	EntityID   *uuid.UUID
+	Action     *enums.AuditAction
+	ActorID    *string
+	From       *time.Time // Inclusive
+	To         *time.Time // Exclusive
+}
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *models.AuditLog) error
+	CreateBatch(ctx context.Context, logs []models.AuditLog) error
+	// ScrubParticipant removes the participant's data from the logs of changes to it, and its shown name and avatar
+	// from the logs of its entries, leaving what changed when and by whom
+	ScrubParticipant(ctx context.Context, participantID uuid.UUID) error
+	// FindAfter returns the logs matching the filter with an ID greater than afterID that occurred before
+	// visibleBefore, oldest first
+	FindAfter(ctx context.Context, filter AuditLogFilter, afterID uint64, visibleBefore time.Time, limit int) ([]models.AuditLog, error)
+}
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository() AuditLogRepository {
+	return &auditLogRepository{
+		db: db.DB,
+	}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, log *models.AuditLog) error {
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+func (r *auditLogRepository) CreateBatch(ctx context.Context, logs []models.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(logs, createBatchSize).Error
+}
+
+func (r *auditLogRepository) ScrubParticipant(ctx context.Context, participantID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.AuditLog{}).
+			Where("entity_type = ? AND entity_id = ?", enums.AuditParticipant, participantID).
+			Updates(map[string]interface{}{"before": nil, "after": nil, "changes": nil}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`UPDATE audit_logs
+			SET before = before - 'ParticipantName' - 'AvatarURL',
+				after = after - 'ParticipantName' - 'AvatarURL',
+				changes = changes - 'ParticipantName' - 'AvatarURL'
+			WHERE entity_type = ? AND (before->>'ParticipantID' = ? OR after->>'ParticipantID' = ?)`,
+			enums.AuditLeaderboardEntry, participantID.String(), participantID.String()).Error
+	})
+}
+
+func (r *auditLogRepository) FindAfter(ctx context.Context, filter AuditLogFilter, afterID uint64,
+	visibleBefore time.Time, limit int) ([]models.AuditLog, error) {
+
+	query := r.db.WithContext(ctx).Where("id > ?", afterID).Where("occurred_at < ?", visibleBefore)
+	if filter.EntityType != nil {
+		query = query.Where("entity_type = ?", *filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Action != nil {
+		query = query.Where("action = ?", *filter.Action)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.From != nil {
+		query = query.Where("occurred_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("occurred_at < ?", *filter.To)
+	}
+
+	// Order by ID so the last returned log is the next cursor
+	var logs []models.AuditLog
+	err := query.Order("id asc").Limit(limit).Find(&logs).Error
+	return logs, err
+}
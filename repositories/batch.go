@@ -0,0 +1,5 @@
+package repositories
+
+// createBatchSize is how many rows bulk creates insert per multi-row INSERT. Postgres allows at most 65535 bind
+// parameters per statement, which keeps batches of even the widest imported rows well inside the limit.
+const createBatchSize = 1000
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceTokenRepository interface {
+	Upsert(ctx context.Context, deviceToken *models.DeviceToken) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.DeviceToken, error)
+	FindByParticipantID(ctx context.Context, participantID uuid.UUID) ([]models.DeviceToken, error)
+	FindByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]models.DeviceToken, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type deviceTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewDeviceTokenRepository() DeviceTokenRepository {
+	return &deviceTokenRepository{
+		db: db.DB,
+	}
+}
+
+func (r *deviceTokenRepository) Upsert(ctx context.Context, deviceToken *models.DeviceToken) error {
+	// A token moves to the new participant if the device was previously registered to someone else
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"participant_id", "provider", "updated_at"}),
+	}).Create(deviceToken).Error
+}
+
+func (r *deviceTokenRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.DeviceToken, error) {
+	var deviceToken models.DeviceToken
+	err := r.db.WithContext(ctx).First(&deviceToken, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &deviceToken, nil
+}
+
+func (r *deviceTokenRepository) FindByParticipantID(ctx context.Context, participantID uuid.UUID) ([]models.DeviceToken, error) {
+	var deviceTokens []models.DeviceToken
+	err := r.db.WithContext(ctx).Where("participant_id = ?", participantID).Order("created_at asc").Find(&deviceTokens).Error
+	return deviceTokens, err
+}
+
+func (r *deviceTokenRepository) FindByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]models.DeviceToken, error) {
+	var deviceTokens []models.DeviceToken
+	if len(participantIDs) == 0 {
+		return deviceTokens, nil
+	}
+	err := r.db.WithContext(ctx).Where("participant_id IN ?", participantIDs).Find(&deviceTokens).Error
+	return deviceTokens, err
+}
+
+func (r *deviceTokenRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	// Hard delete so the unique token can be registered again
+	return r.db.WithContext(ctx).Unscoped().Delete(&models.DeviceToken{}, "id = ?", id).Error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the Postgres error code of a write that breaks a unique index
+const uniqueViolation = "23505"
+
+// IsUniqueViolation reports whether the write failed because a row with the same unique key already exists
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
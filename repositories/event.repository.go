@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"leaderboard-service/db"
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EventRepository interface {
+	Create(ctx context.Context, event *models.Event) error
+	FindAfter(ctx context.Context, afterID uint64, types []string, leaderboardID *uuid.UUID, visibleBefore time.Time, limit int) ([]models.Event, error)
+	// FindAfterInLeaderboards is FindAfter limited to the events of the given leaderboards
+	FindAfterInLeaderboards(ctx context.Context, afterID uint64, types []string, leaderboardIDs []uuid.UUID, visibleBefore time.Time, limit int) ([]models.Event, error)
+	// ScrubParticipant removes the participant's shown name, avatar and loaded participant from the payloads of its
+	// events, leaving their IDs, ranks and scores
+	ScrubParticipant(ctx context.Context, participantID uuid.UUID) error
+	// BestRanksForParticipant returns the best rank the participant's entry events recorded on each leaderboard
+	BestRanksForParticipant(ctx context.Context, participantID uuid.UUID) (map[uuid.UUID]int, error)
+	// LatestRankChangesForParticipant returns the most recent rank change of the participant's entry on each leaderboard
+	LatestRankChangesForParticipant(ctx context.Context, participantID uuid.UUID) (map[uuid.UUID]RankMove, error)
+	// LatestRankChangesForLeaderboard returns the most recent rank change of each participant's entry on the leaderboard
+	LatestRankChangesForLeaderboard(ctx context.Context, leaderboardID uuid.UUID) (map[uuid.UUID]RankMove, error)
+}
+
+// RankMove is the previous and current rank recorded by an entry.rank_changed event
+type RankMove struct {
+	PreviousRank int
+	CurrentRank  int
+}
+
+type eventRepository struct {
+	db *gorm.DB
+}
+
+func NewEventRepository() EventRepository {
+	return &eventRepository{
+		db: db.DB,
+	}
+}
+
+func (r *eventRepository) Create(ctx context.Context, event *models.Event) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *eventRepository) FindAfter(ctx context.Context, afterID uint64, types []string, leaderboardID *uuid.UUID,
+	visibleBefore time.Time, limit int) ([]models.Event, error) {
+
+	var events []models.Event
+	query := r.db.WithContext(ctx).Where("id > ?", afterID).Where("created_at < ?", visibleBefore)
+
+	if len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+
+	if leaderboardID != nil {
+		query = query.Where("leaderboard_id = ?", *leaderboardID)
+	}
+
+	// Order by ID so the last returned event is the next cursor
+	err := query.Order("id asc").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+func (r *eventRepository) FindAfterInLeaderboards(ctx context.Context, afterID uint64, types []string, leaderboardIDs []uuid.UUID,
+	visibleBefore time.Time, limit int) ([]models.Event, error) {
+
+	var events []models.Event
+	if len(leaderboardIDs) == 0 {
+		return events, nil
+	}
+	query := r.db.WithContext(ctx).Where("id > ?", afterID).Where("created_at < ?", visibleBefore).Where("leaderboard_id IN ?", leaderboardIDs)
+
+	if len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+
+	err := query.Order("id asc").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+func (r *eventRepository) ScrubParticipant(ctx context.Context, participantID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Entry and metric value events carry the participant's fields themselves, rank changes under "entry"
+		err := tx.Exec(`UPDATE events SET data = data - 'ParticipantName' - 'AvatarURL' - 'Participant'
+			WHERE data->>'ParticipantID' = ?`, participantID.String()).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`UPDATE events
+			SET data = jsonb_set(data, '{entry}', (data->'entry') - 'ParticipantName' - 'AvatarURL' - 'Participant')
+			WHERE data->'entry'->>'ParticipantID' = ?`, participantID.String()).Error
+	})
+}
+
+func (r *eventRepository) BestRanksForParticipant(ctx context.Context, participantID uuid.UUID) (map[uuid.UUID]int, error) {
+	// Rank changes carry the entry under "entry"; created and updated events carry the entry itself
+	var rows []struct {
+		LeaderboardID uuid.UUID
+		BestRank      int
+	}
+	err := r.db.WithContext(ctx).Model(&models.Event{}).
+		Select("leaderboard_id, MIN(CASE WHEN type = @rankChanged THEN (data->>'current_rank')::int "+
+			"ELSE (data->>'Rank')::int END) AS best_rank", map[string]interface{}{"rankChanged": enums.EntryRankChanged}).
+		Where("type IN ?", []enums.EventType{enums.EntryCreated, enums.EntryUpdated, enums.EntryRankChanged}).
+		Where("leaderboard_id IS NOT NULL").
+		Where("COALESCE(data->'entry'->>'ParticipantID', data->>'ParticipantID') = ?", participantID.String()).
+		Where("CASE WHEN type = ? THEN (data->>'current_rank')::int ELSE (data->>'Rank')::int END >= 1", enums.EntryRankChanged).
+		Group("leaderboard_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	bestRanks := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		bestRanks[row.LeaderboardID] = row.BestRank
+	}
+	return bestRanks, nil
+}
+
+func (r *eventRepository) LatestRankChangesForParticipant(ctx context.Context, participantID uuid.UUID) (map[uuid.UUID]RankMove, error) {
+	var rows []struct {
+		LeaderboardID uuid.UUID
+		PreviousRank  int
+		CurrentRank   int
+	}
+	err := r.db.WithContext(ctx).Model(&models.Event{}).
+		Select("DISTINCT ON (leaderboard_id) leaderboard_id, "+
+			"(data->>'previous_rank')::int AS previous_rank, (data->>'current_rank')::int AS current_rank").
+		Where("type = ? AND leaderboard_id IS NOT NULL", enums.EntryRankChanged).
+		Where("data->'entry'->>'ParticipantID' = ?", participantID.String()).
+		Order("leaderboard_id, id desc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	moves := make(map[uuid.UUID]RankMove, len(rows))
+	for _, row := range rows {
+		moves[row.LeaderboardID] = RankMove{PreviousRank: row.PreviousRank, CurrentRank: row.CurrentRank}
+	}
+	return moves, nil
+}
+
+func (r *eventRepository) LatestRankChangesForLeaderboard(ctx context.Context, leaderboardID uuid.UUID) (map[uuid.UUID]RankMove, error) {
+	var rows []struct {
+		ParticipantID uuid.UUID
+		PreviousRank  int
+		CurrentRank   int
+	}
+	err := r.db.WithContext(ctx).Model(&models.Event{}).
+		Select("DISTINCT ON (data->'entry'->>'ParticipantID') data->'entry'->>'ParticipantID' AS participant_id, "+
+			"(data->>'previous_rank')::int AS previous_rank, (data->>'current_rank')::int AS current_rank").
+		Where("type = ? AND leaderboard_id = ?", enums.EntryRankChanged, leaderboardID).
+		Order("data->'entry'->>'ParticipantID', id desc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	moves := make(map[uuid.UUID]RankMove, len(rows))
+	for _, row := range rows {
+		moves[row.ParticipantID] = RankMove{PreviousRank: row.PreviousRank, CurrentRank: row.CurrentRank}
+	}
+	return moves, nil
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"leaderboard-service/db"
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FeatureFlagRepository stores the feature flags admins have set at runtime
+type FeatureFlagRepository interface {
+	FindAll(ctx context.Context) ([]models.FeatureFlag, error)
+	// Save sets the flag, replacing any earlier value
+	Save(ctx context.Context, flag *models.FeatureFlag) error
+	Delete(ctx context.Context, name enums.FeatureFlag) error
+}
+
+type featureFlagRepository struct {
+	db *gorm.DB
+}
+
+func NewFeatureFlagRepository() FeatureFlagRepository {
+	return &featureFlagRepository{
+		db: db.DB,
+	}
+}
+
+func (r *featureFlagRepository) FindAll(ctx context.Context) ([]models.FeatureFlag, error) {
+	var flags []models.FeatureFlag
+	err := r.db.WithContext(ctx).Order("name").Find(&flags).Error
+	return flags, err
+}
+
+func (r *featureFlagRepository) Save(ctx context.Context, flag *models.FeatureFlag) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_by", "updated_at"}),
+	}).Create(flag).Error
+}
+
+func (r *featureFlagRepository) Delete(ctx context.Context, name enums.FeatureFlag) error {
+	return r.db.WithContext(ctx).Delete(&models.FeatureFlag{}, "name = ?", name).Error
+}
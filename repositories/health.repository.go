@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+
+	"leaderboard-service/db"
+	"leaderboard-service/db/migrations"
+
+	"gorm.io/gorm"
+)
+
+// HealthRepository checks the database for readiness probes
+type HealthRepository interface {
+	// Ping checks that a connection to the database can be made
+	Ping(ctx context.Context) error
+	// PendingMigrations returns how many migrations the database hasn't had yet
+	PendingMigrations(ctx context.Context) (int, error)
+}
+
+type healthRepository struct {
+	db *gorm.DB
+}
+
+func NewHealthRepository() HealthRepository {
+	return &healthRepository{
+		db: db.DB,
+	}
+}
+
+func (r *healthRepository) Ping(ctx context.Context) error {
+	sqlDB, err := r.db.WithContext(ctx).DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (r *healthRepository) PendingMigrations(ctx context.Context) (int, error) {
+	pending, err := migrations.Pending(r.db.WithContext(ctx))
+	return len(pending), err
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"errors"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// Includes whitelists the related resources a query can load alongside its rows, mapping their API names to the
+// preloads that load them
+type Includes map[string]func(query *gorm.DB) *gorm.DB
+
+var (
+	LeaderboardIncludes = Includes{
+		"metrics": func(query *gorm.DB) *gorm.DB {
+			return query.Preload("Metrics", func(db *gorm.DB) *gorm.DB {
+				return db.Order("display_priority asc")
+			})
+		},
+		// Entries come with their participant from one joined query and, like published standings, leave out those
+		// of inactive participants
+		"entries": func(query *gorm.DB) *gorm.DB {
+			return query.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+				return publishedEntries(db.Joins("Participant")).Order("leaderboard_entries.rank asc")
+			})
+		},
+	}
+	// Entries always load their participant for display, so including it only spells that out
+	EntryIncludes = Includes{
+		"participant": func(query *gorm.DB) *gorm.DB {
+			return query.Preload("Participant")
+		},
+	}
+)
+
+// Names returns the includable resource names in alphabetical order
+func (i Includes) Names() []string {
+	names := make([]string, 0, len(i))
+	for name := range i {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Valid reports whether the resource can be included
+func (i Includes) Valid(name string) bool {
+	_, ok := i[name]
+	return ok
+}
+
+// apply adds the preloads for each of the included resources to the query
+func (i Includes) apply(query *gorm.DB, include []string) (*gorm.DB, error) {
+	for _, name := range include {
+		preload, ok := i[name]
+		if !ok {
+			return nil, errors.New("invalid include")
+		}
+		query = preload(query)
+	}
+	return query, nil
+}
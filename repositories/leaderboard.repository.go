@@ -1,23 +1,58 @@
 package repositories
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
 	"leaderboard-service/db"
+	"leaderboard-service/enums"
 	"leaderboard-service/models"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type LeaderboardRepository interface {
-	Create(leaderboard *models.Leaderboard) error
-	FindByID(id uuid.UUID) (*models.Leaderboard, error)
-	FindAll() ([]models.Leaderboard, error)
-	Update(leaderboard *models.Leaderboard) error
-	Delete(id uuid.UUID) error
+	Create(ctx context.Context, leaderboard *models.Leaderboard) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Leaderboard, error)
+	// FindByIDForShare reads the leaderboard past the lookup cache and, in a UnitOfWork, keeps it from being updated or
+	// deleted until the transaction ends
+	FindByIDForShare(ctx context.Context, id uuid.UUID) (*models.Leaderboard, error)
+	// FindByIDForUpdate is FindByIDForShare that also waits for, and holds off, other transactions locking the
+	// leaderboard, so they work on it one after another
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Leaderboard, error)
+	FindAll(ctx context.Context) ([]models.Leaderboard, error)
+	// FindByIDWithIncludes returns the leaderboard with the included related resources loaded
+	FindByIDWithIncludes(ctx context.Context, id uuid.UUID, include []string) (*models.Leaderboard, error)
+	// FindFiltered returns the leaderboards with the given IDs, or all of them when there are none, matching every
+	// filter that is set, in the given order or oldest first when it is nil, with the included related resources
+	// loaded. Soft-deleted leaderboards are only returned when asked for.
+	FindFiltered(ctx context.Context, ids []uuid.UUID, category *string, leaderboardType *enums.LeaderboardType, timeFrame *enums.TimeFrame, isActive *bool,
+		visibilityScope *enums.VisibilityScope, sort *Sort, include []string, includeDeleted bool) ([]models.Leaderboard, error)
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Leaderboard, error)
+	// FindWithMetrics returns the leaderboard with its metric associations loaded
+	FindWithMetrics(ctx context.Context, id uuid.UUID) (*models.Leaderboard, error)
+	// FindActiveByMetricID returns the active leaderboards of the given type that use the metric and haven't ended, with
+	// their metrics loaded
+	FindActiveByMetricID(ctx context.Context, metricID uuid.UUID, leaderboardType enums.LeaderboardType) ([]models.Leaderboard, error)
+	// LastModified returns when the leaderboard, its metrics, its entries, their participants or the participants' values
+	// for its metrics last changed
+	LastModified(ctx context.Context, id uuid.UUID) (time.Time, error)
+	// Update saves the leaderboard and increments its version, or returns ErrVersionConflict if the stored version is
+	// no longer the one it was read at
+	Update(ctx context.Context, leaderboard *models.Leaderboard) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// FindDeletedByID returns the leaderboard if it is soft-deleted
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*models.Leaderboard, error)
+	// Restore undoes the soft delete of a leaderboard
+	Restore(ctx context.Context, id uuid.UUID) error
 }
 
 type leaderboardRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	removals *cacheRemovals // Set in a unit of work, see uncache
 }
 
 func NewLeaderboardRepository() LeaderboardRepository {
@@ -26,29 +61,189 @@ func NewLeaderboardRepository() LeaderboardRepository {
 	}
 }
 
-func (r *leaderboardRepository) Create(leaderboard *models.Leaderboard) error {
-	return r.db.Create(leaderboard).Error
+func (r *leaderboardRepository) Create(ctx context.Context, leaderboard *models.Leaderboard) error {
+	return r.db.WithContext(ctx).Create(leaderboard).Error
+}
+
+func (r *leaderboardRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Leaderboard, error) {
+	return lookup(r.removals, leaderboardCache, id, func() (*models.Leaderboard, error) {
+		var leaderboard models.Leaderboard
+		err := r.db.WithContext(ctx).First(&leaderboard, "id = ?", id).Error
+		if err != nil {
+			return nil, err
+		}
+		return &leaderboard, nil
+	}, copyLeaderboard)
+}
+
+func (r *leaderboardRepository) FindByIDForShare(ctx context.Context, id uuid.UUID) (*models.Leaderboard, error) {
+	var leaderboard models.Leaderboard
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "SHARE"}).First(&leaderboard, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &leaderboard, nil
+}
+
+func (r *leaderboardRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Leaderboard, error) {
+	var leaderboard models.Leaderboard
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&leaderboard, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &leaderboard, nil
+}
+
+func (r *leaderboardRepository) FindAll(ctx context.Context) ([]models.Leaderboard, error) {
+	var leaderboards []models.Leaderboard
+	err := r.db.WithContext(ctx).Find(&leaderboards).Error
+	return leaderboards, err
+}
+
+func (r *leaderboardRepository) FindByIDWithIncludes(ctx context.Context, id uuid.UUID, include []string) (*models.Leaderboard, error) {
+	var leaderboard models.Leaderboard
+	query, err := LeaderboardIncludes.apply(r.db.WithContext(ctx), include)
+	if err != nil {
+		return nil, err
+	}
+	err = query.First(&leaderboard, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &leaderboard, nil
+}
+
+func (r *leaderboardRepository) FindFiltered(ctx context.Context, ids []uuid.UUID, category *string, leaderboardType *enums.LeaderboardType,
+	timeFrame *enums.TimeFrame, isActive *bool, visibilityScope *enums.VisibilityScope, sort *Sort,
+	include []string, includeDeleted bool) ([]models.Leaderboard, error) {
+	var leaderboards []models.Leaderboard
+	query, err := LeaderboardIncludes.apply(r.db.WithContext(ctx), include)
+	if err != nil {
+		return nil, err
+	}
+	query = withDeleted(query, includeDeleted)
+
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+
+	if category != nil {
+		query = query.Where("category = ?", *category)
+	}
+
+	if leaderboardType != nil {
+		query = query.Where("type = ?", *leaderboardType)
+	}
+
+	if timeFrame != nil {
+		query = query.Where("time_frame = ?", *timeFrame)
+	}
+
+	if isActive != nil {
+		query = query.Where("is_active = ?", *isActive)
+	}
+
+	if visibilityScope != nil {
+		query = query.Where("visibility_scope = ?", *visibilityScope)
+	}
+
+	query, err = LeaderboardSortColumns.apply(query, sort, "created_at asc")
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Find(&leaderboards).Error
+	return leaderboards, err
+}
+
+func (r *leaderboardRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Leaderboard, error) {
+	var leaderboards []models.Leaderboard
+	if len(ids) == 0 {
+		return leaderboards, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&leaderboards).Error
+	return leaderboards, err
 }
 
-func (r *leaderboardRepository) FindByID(id uuid.UUID) (*models.Leaderboard, error) {
+func (r *leaderboardRepository) FindWithMetrics(ctx context.Context, id uuid.UUID) (*models.Leaderboard, error) {
 	var leaderboard models.Leaderboard
-	err := r.db.First(&leaderboard, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Preload("Metrics").First(&leaderboard, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &leaderboard, nil
 }
 
-func (r *leaderboardRepository) FindAll() ([]models.Leaderboard, error) {
+func (r *leaderboardRepository) FindActiveByMetricID(ctx context.Context, metricID uuid.UUID, leaderboardType enums.LeaderboardType) ([]models.Leaderboard, error) {
 	var leaderboards []models.Leaderboard
-	err := r.db.Find(&leaderboards).Error
+	err := r.db.WithContext(ctx).Preload("Metrics").
+		Where("type = ? AND is_active = ?", leaderboardType, true).
+		Where("end_date IS NULL OR end_date > ?", time.Now()).
+		Where("id IN (?)", r.db.WithContext(ctx).Model(&models.LeaderboardMetric{}).Select("leaderboard_id").Where("metric_id = ?", metricID)).
+		Find(&leaderboards).Error
 	return leaderboards, err
 }
 
-func (r *leaderboardRepository) Update(leaderboard *models.Leaderboard) error {
-	return r.db.Save(leaderboard).Error
+func (r *leaderboardRepository) LastModified(ctx context.Context, id uuid.UUID) (time.Time, error) {
+	return lastModified(r.db.WithContext(ctx), id)
+}
+
+// lastModified is LeaderboardRepository.LastModified on the given connection, so it can be read in a transaction
+func lastModified(db *gorm.DB, id uuid.UUID) (time.Time, error) {
+	// Deleted rows count as changes too, so soft-deleted metrics, entries and values are included by their deleted_at.
+	// GREATEST skips whichever of the parts are NULL.
+	var modified struct {
+		LastModified *time.Time
+	}
+	err := db.Raw(`SELECT GREATEST(
+		(SELECT updated_at FROM leaderboards WHERE id = @id),
+		(SELECT MAX(GREATEST(updated_at, deleted_at)) FROM leaderboard_metrics WHERE leaderboard_id = @id),
+		(SELECT MAX(GREATEST(updated_at, deleted_at)) FROM leaderboard_entries WHERE leaderboard_id = @id),
+		(SELECT MAX(participants.updated_at) FROM participants
+			JOIN leaderboard_entries ON leaderboard_entries.participant_id = participants.id
+			WHERE leaderboard_entries.leaderboard_id = @id AND leaderboard_entries.deleted_at IS NULL),
+		(SELECT MAX(GREATEST(metrics.updated_at, metrics.deleted_at)) FROM metrics
+			JOIN leaderboard_metrics ON leaderboard_metrics.metric_id = metrics.id
+			WHERE leaderboard_metrics.leaderboard_id = @id AND leaderboard_metrics.deleted_at IS NULL),
+		(SELECT MAX(GREATEST(metric_values.updated_at, metric_values.deleted_at)) FROM metric_values
+			JOIN leaderboard_metrics ON leaderboard_metrics.metric_id = metric_values.metric_id
+			JOIN leaderboard_entries ON leaderboard_entries.participant_id = metric_values.participant_id
+			WHERE leaderboard_metrics.leaderboard_id = @id AND leaderboard_metrics.deleted_at IS NULL
+			AND leaderboard_entries.leaderboard_id = @id AND leaderboard_entries.deleted_at IS NULL),
+		(SELECT MAX(metric_value_rollups.updated_at) FROM metric_value_rollups
+			JOIN leaderboard_metrics ON leaderboard_metrics.metric_id = metric_value_rollups.metric_id
+			JOIN leaderboard_entries ON leaderboard_entries.participant_id = metric_value_rollups.participant_id
+			WHERE leaderboard_metrics.leaderboard_id = @id AND leaderboard_metrics.deleted_at IS NULL
+			AND leaderboard_entries.leaderboard_id = @id AND leaderboard_entries.deleted_at IS NULL)
+	) AS last_modified`, sql.Named("id", id)).Scan(&modified).Error
+	if err != nil {
+		return time.Time{}, err
+	}
+	if modified.LastModified == nil {
+		return time.Time{}, gorm.ErrRecordNotFound
+	}
+	return *modified.LastModified, nil
+}
+
+func (r *leaderboardRepository) Update(ctx context.Context, leaderboard *models.Leaderboard) error {
+	defer uncache(r.removals, leaderboardCache, leaderboard.ID)
+	return updateVersioned(r.db.WithContext(ctx), leaderboard, &leaderboard.Version)
+}
+
+func (r *leaderboardRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer uncache(r.removals, leaderboardCache, id)
+	return r.db.WithContext(ctx).Delete(&models.Leaderboard{}, "id = ?", id).Error
+}
+
+func (r *leaderboardRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*models.Leaderboard, error) {
+	var leaderboard models.Leaderboard
+	if err := findDeleted(r.db.WithContext(ctx), &leaderboard, id); err != nil {
+		return nil, err
+	}
+	return &leaderboard, nil
 }
 
-func (r *leaderboardRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Leaderboard{}, "id = ?", id).Error
+func (r *leaderboardRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	defer uncache(r.removals, leaderboardCache, id)
+	return restore(r.db.WithContext(ctx), &models.Leaderboard{}, id)
 }
@@ -1,22 +1,72 @@
 package repositories
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
 	"leaderboard-service/db"
+	"leaderboard-service/enums"
 	"leaderboard-service/models"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// RankUpdate is an entry whose rank BulkUpdateRanks changed
+type RankUpdate struct {
+	EntryID      uuid.UUID
+	PreviousRank int
+	Rank         int
+}
+
 type LeaderboardEntryRepository interface {
-	Create(entry *models.LeaderboardEntry) error
-	FindByID(id uuid.UUID) (*models.LeaderboardEntry, error)
-	FindAll() ([]models.LeaderboardEntry, error)
-	FindByLeaderboardID(leaderboardID uuid.UUID) ([]models.LeaderboardEntry, error)
-	FindByParticipantID(participantID uuid.UUID) ([]models.LeaderboardEntry, error)
-	FindFiltered(leaderboardID, participantID *uuid.UUID) ([]models.LeaderboardEntry, error)
-	Update(entry *models.LeaderboardEntry) error
-	Delete(id uuid.UUID) error
+	Create(ctx context.Context, entry *models.LeaderboardEntry) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.LeaderboardEntry, error)
+	// FindByIDs returns the entries with the given IDs with their participants, in no particular order
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]models.LeaderboardEntry, error)
+	FindAll(ctx context.Context) ([]models.LeaderboardEntry, error)
+	FindByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardEntry, error)
+	// FindPublishedByLeaderboardID returns the leaderboard's entries by rank with their participants, read in a single
+	// joined query, leaving out the entries of inactive participants as published standings do
+	FindPublishedByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardEntry, error)
+	FindByParticipantID(ctx context.Context, participantID uuid.UUID) ([]models.LeaderboardEntry, error)
+	// FindByLeaderboardAndParticipant returns the participant's live entry on the leaderboard with its participant;
+	// there is at most one
+	FindByLeaderboardAndParticipant(ctx context.Context, leaderboardID, participantID uuid.UUID) (*models.LeaderboardEntry, error)
+	// FindPublishedWithoutParticipants is FindPublishedByLeaderboardID from the entries table alone: instead of their
+	// participant, entries only carry its shown name and avatar
+	FindPublishedWithoutParticipants(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardEntry, error)
+	// UpdateParticipantDisplay copies the participant's shown name and avatar onto all its entries, deleted ones too
+	UpdateParticipantDisplay(ctx context.Context, participant *models.Participant) error
+	// FindFiltered returns the entries of the leaderboard and participant when set, in the given order or by rank when
+	// it is nil. Soft-deleted entries are only returned when asked for.
+	FindFiltered(ctx context.Context, leaderboardID, participantID *uuid.UUID, sort *Sort, includeDeleted bool) ([]models.LeaderboardEntry, error)
+	FindByRankRange(ctx context.Context, leaderboardID uuid.UUID, fromRank, toRank int) ([]models.LeaderboardEntry, error)
+	// CountRankedByLeaderboardIDs returns how many ranked entries each of the leaderboards has
+	CountRankedByLeaderboardIDs(ctx context.Context, leaderboardIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+	// CountByLeaderboardID returns how many live entries the leaderboard has, ranked or not
+	CountByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) (int64, error)
+	// FindLast returns the leaderboard's entry with the worst score in the sort order, the most recently created one
+	// of those tied for last
+	FindLast(ctx context.Context, leaderboardID uuid.UUID, sortOrder enums.SortOrder) (*models.LeaderboardEntry, error)
+	// Update saves the entry and increments its version, or returns ErrVersionConflict if the stored version is no
+	// longer the one it was read at
+	Update(ctx context.Context, entry *models.LeaderboardEntry) error
+	// BulkUpdateRanks re-ranks the leaderboard's entries by their stored scores in the sort order in a single
+	// statement, giving tied scores the same rank and leaving the entries of inactive or deleted participants
+	// unranked. Entries whose rank changes get lastUpdated; only they are returned.
+	BulkUpdateRanks(ctx context.Context, leaderboardID uuid.UUID, sortOrder enums.SortOrder, lastUpdated time.Time) ([]RankUpdate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// FindDeletedByID returns the entry if it is soft-deleted
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*models.LeaderboardEntry, error)
+	// Restore undoes the soft delete of an entry
+	Restore(ctx context.Context, id uuid.UUID) error
+	// DeleteByLeaderboardID soft-deletes the live entries of a leaderboard as of deletedAt
+	DeleteByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID, deletedAt time.Time) error
+	// RestoreByLeaderboardID undoes the soft delete of the leaderboard's entries deleted at deletedAt
+	RestoreByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID, deletedAt time.Time) error
 }
 
 type leaderboardEntryRepository struct {
@@ -29,40 +79,97 @@ func NewLeaderboardEntryRepository() LeaderboardEntryRepository {
 	}
 }
 
-func (r *leaderboardEntryRepository) Create(entry *models.LeaderboardEntry) error {
-	return r.db.Create(entry).Error
+func (r *leaderboardEntryRepository) Create(ctx context.Context, entry *models.LeaderboardEntry) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(entry).Error
 }
 
-func (r *leaderboardEntryRepository) FindByID(id uuid.UUID) (*models.LeaderboardEntry, error) {
+func (r *leaderboardEntryRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.LeaderboardEntry, error) {
 	var entry models.LeaderboardEntry
-	err := r.db.First(&entry, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Preload("Participant").First(&entry, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &entry, nil
 }
 
-func (r *leaderboardEntryRepository) FindAll() ([]models.LeaderboardEntry, error) {
+func (r *leaderboardEntryRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]models.LeaderboardEntry, error) {
+	var entries []models.LeaderboardEntry
+	if len(ids) == 0 {
+		return entries, nil
+	}
+	err := r.db.WithContext(ctx).Preload("Participant").Where("id IN ?", ids).Find(&entries).Error
+	return entries, err
+}
+
+func (r *leaderboardEntryRepository) FindAll(ctx context.Context) ([]models.LeaderboardEntry, error) {
 	var entries []models.LeaderboardEntry
-	err := r.db.Find(&entries).Error
+	err := r.db.WithContext(ctx).Preload("Participant").Find(&entries).Error
 	return entries, err
 }
 
-func (r *leaderboardEntryRepository) FindByLeaderboardID(leaderboardID uuid.UUID) ([]models.LeaderboardEntry, error) {
+func (r *leaderboardEntryRepository) FindByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardEntry, error) {
 	var entries []models.LeaderboardEntry
-	err := r.db.Where("leaderboard_id = ?", leaderboardID).Order("rank asc").Find(&entries).Error
+	err := r.db.WithContext(ctx).Preload("Participant").Where("leaderboard_id = ?", leaderboardID).Order("rank asc").Find(&entries).Error
 	return entries, err
 }
 
-func (r *leaderboardEntryRepository) FindByParticipantID(participantID uuid.UUID) ([]models.LeaderboardEntry, error) {
+func (r *leaderboardEntryRepository) FindPublishedByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardEntry, error) {
 	var entries []models.LeaderboardEntry
-	err := r.db.Where("participant_id = ?", participantID).Find(&entries).Error
+	err := publishedEntries(r.db.WithContext(ctx).Joins("Participant")).
+		Where("leaderboard_entries.leaderboard_id = ?", leaderboardID).
+		Order("leaderboard_entries.rank asc").
+		Find(&entries).Error
 	return entries, err
 }
 
-func (r *leaderboardEntryRepository) FindFiltered(leaderboardID, participantID *uuid.UUID) ([]models.LeaderboardEntry, error) {
+func (r *leaderboardEntryRepository) FindPublishedWithoutParticipants(ctx context.Context,
+	leaderboardID uuid.UUID) ([]models.LeaderboardEntry, error) {
 	var entries []models.LeaderboardEntry
-	query := r.db
+	// Only inactive participants are looked up, by primary key, so no participant columns are read
+	err := r.db.WithContext(ctx).
+		Where("leaderboard_id = ?", leaderboardID).
+		Where(`NOT EXISTS (SELECT 1 FROM participants WHERE participants.id = leaderboard_entries.participant_id
+			AND participants.is_active = false AND participants.deleted_at IS NULL)`).
+		Order("rank asc").
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *leaderboardEntryRepository) UpdateParticipantDisplay(ctx context.Context, participant *models.Participant) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&models.LeaderboardEntry{}).
+		Where("participant_id = ?", participant.ID).
+		Updates(map[string]interface{}{
+			"participant_name": participant.ShownName(),
+			"avatar_url":       participant.AvatarURL,
+		}).Error
+}
+
+// publishedEntries leaves out the entries of inactive participants from a query joining entries to their
+// participant. Entries whose participant was deleted have no participant to check and are kept.
+func publishedEntries(query *gorm.DB) *gorm.DB {
+	return query.Where(`"Participant"."is_active" IS NOT FALSE`)
+}
+
+func (r *leaderboardEntryRepository) FindByParticipantID(ctx context.Context, participantID uuid.UUID) ([]models.LeaderboardEntry, error) {
+	var entries []models.LeaderboardEntry
+	err := r.db.WithContext(ctx).Preload("Participant").Where("participant_id = ?", participantID).Find(&entries).Error
+	return entries, err
+}
+
+func (r *leaderboardEntryRepository) FindByLeaderboardAndParticipant(ctx context.Context, leaderboardID, participantID uuid.UUID) (*models.LeaderboardEntry, error) {
+	var entry models.LeaderboardEntry
+	err := r.db.WithContext(ctx).Preload("Participant").
+		First(&entry, "leaderboard_id = ? AND participant_id = ?", leaderboardID, participantID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *leaderboardEntryRepository) FindFiltered(ctx context.Context, leaderboardID, participantID *uuid.UUID, sort *Sort,
+	includeDeleted bool) ([]models.LeaderboardEntry, error) {
+	var entries []models.LeaderboardEntry
+	query := withDeleted(r.db.WithContext(ctx).Preload("Participant"), includeDeleted)
 
 	if leaderboardID != nil {
 		query = query.Where("leaderboard_id = ?", *leaderboardID)
@@ -72,15 +179,121 @@ func (r *leaderboardEntryRepository) FindFiltered(leaderboardID, participantID *
 		query = query.Where("participant_id = ?", *participantID)
 	}
 
-	// Order by rank
-	err := query.Order("rank asc").Find(&entries).Error
+	query, err := EntrySortColumns.apply(query, sort, "rank asc")
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Find(&entries).Error
 	return entries, err
 }
 
-func (r *leaderboardEntryRepository) Update(entry *models.LeaderboardEntry) error {
-	return r.db.Save(entry).Error
+func (r *leaderboardEntryRepository) FindByRankRange(ctx context.Context, leaderboardID uuid.UUID, fromRank, toRank int) ([]models.LeaderboardEntry, error) {
+	var entries []models.LeaderboardEntry
+	err := r.db.WithContext(ctx).Where("leaderboard_id = ? AND rank BETWEEN ? AND ?", leaderboardID, fromRank, toRank).
+		Order("rank asc").Find(&entries).Error
+	return entries, err
+}
+
+func (r *leaderboardEntryRepository) CountRankedByLeaderboardIDs(ctx context.Context, leaderboardIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64, len(leaderboardIDs))
+	if len(leaderboardIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		LeaderboardID uuid.UUID
+		Count         int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.LeaderboardEntry{}).
+		Select("leaderboard_id, COUNT(*) AS count").
+		Where("leaderboard_id IN ? AND rank >= 1", leaderboardIDs).
+		Group("leaderboard_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.LeaderboardID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *leaderboardEntryRepository) CountByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.LeaderboardEntry{}).Where("leaderboard_id = ?", leaderboardID).Count(&count).Error
+	return count, err
+}
+
+func (r *leaderboardEntryRepository) FindLast(ctx context.Context, leaderboardID uuid.UUID, sortOrder enums.SortOrder) (*models.LeaderboardEntry, error) {
+	// Last place has the lowest score on descending boards and the highest on ascending ones
+	order := "score ASC"
+	if sortOrder == enums.Ascending {
+		order = "score DESC"
+	}
+
+	var entry models.LeaderboardEntry
+	err := r.db.WithContext(ctx).Where("leaderboard_id = ?", leaderboardID).Order(order).Order("created_at DESC").First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *leaderboardEntryRepository) Update(ctx context.Context, entry *models.LeaderboardEntry) error {
+	return updateVersioned(r.db.WithContext(ctx), entry, &entry.Version)
+}
+
+func (r *leaderboardEntryRepository) BulkUpdateRanks(ctx context.Context, leaderboardID uuid.UUID, sortOrder enums.SortOrder,
+	lastUpdated time.Time) ([]RankUpdate, error) {
+
+	// The direction is one of the two sort orders, never text from a request
+	direction := "DESC"
+	if sortOrder == enums.Ascending {
+		direction = "ASC"
+	}
+
+	var updates []RankUpdate
+	err := r.db.WithContext(ctx).Raw(`UPDATE leaderboard_entries
+		SET rank = ranked.rank, last_updated = @last_updated, updated_at = @last_updated, version = leaderboard_entries.version + 1
+		FROM (
+			SELECT leaderboard_entries.id, leaderboard_entries.rank AS previous_rank,
+				CASE WHEN COALESCE(participants.is_active, false) IS FALSE THEN 0
+				ELSE RANK() OVER (PARTITION BY COALESCE(participants.is_active, false) IS FALSE
+					ORDER BY leaderboard_entries.score `+direction+`)
+				END AS rank
+			FROM leaderboard_entries
+			LEFT JOIN participants ON participants.id = leaderboard_entries.participant_id AND participants.deleted_at IS NULL
+			WHERE leaderboard_entries.leaderboard_id = @leaderboard_id AND leaderboard_entries.deleted_at IS NULL
+		) AS ranked
+		WHERE leaderboard_entries.id = ranked.id AND leaderboard_entries.rank <> ranked.rank
+		RETURNING leaderboard_entries.id AS entry_id, ranked.previous_rank, leaderboard_entries.rank`,
+		sql.Named("leaderboard_id", leaderboardID), sql.Named("last_updated", lastUpdated)).
+		Scan(&updates).Error
+	return updates, err
+}
+
+func (r *leaderboardEntryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.LeaderboardEntry{}, "id = ?", id).Error
+}
+
+func (r *leaderboardEntryRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*models.LeaderboardEntry, error) {
+	var entry models.LeaderboardEntry
+	if err := findDeleted(r.db.WithContext(ctx), &entry, id); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *leaderboardEntryRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return restore(r.db.WithContext(ctx), &models.LeaderboardEntry{}, id)
+}
+
+func (r *leaderboardEntryRepository) DeleteByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID, deletedAt time.Time) error {
+	return deleteChildren(r.db.WithContext(ctx), &models.LeaderboardEntry{}, "leaderboard_id", leaderboardID, deletedAt)
 }
 
-func (r *leaderboardEntryRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.LeaderboardEntry{}, "id = ?", id).Error
+func (r *leaderboardEntryRepository) RestoreByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID, deletedAt time.Time) error {
+	return restoreChildren(r.db.WithContext(ctx), &models.LeaderboardEntry{}, "leaderboard_id", leaderboardID, deletedAt)
 }
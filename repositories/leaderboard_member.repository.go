@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LeaderboardMemberRepository interface {
+	Upsert(ctx context.Context, member *models.LeaderboardMember) error
+	Find(ctx context.Context, leaderboardID, userID uuid.UUID) (*models.LeaderboardMember, error)
+	FindByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardMember, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]models.LeaderboardMember, error)
+	Delete(ctx context.Context, leaderboardID, userID uuid.UUID) error
+}
+
+type leaderboardMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaderboardMemberRepository() LeaderboardMemberRepository {
+	return &leaderboardMemberRepository{
+		db: db.DB,
+	}
+}
+
+func (r *leaderboardMemberRepository) Upsert(ctx context.Context, member *models.LeaderboardMember) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "leaderboard_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+	}).Create(member).Error
+}
+
+func (r *leaderboardMemberRepository) Find(ctx context.Context, leaderboardID, userID uuid.UUID) (*models.LeaderboardMember, error) {
+	var member models.LeaderboardMember
+	err := r.db.WithContext(ctx).First(&member, "leaderboard_id = ? AND user_id = ?", leaderboardID, userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *leaderboardMemberRepository) FindByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardMember, error) {
+	var members []models.LeaderboardMember
+	err := r.db.WithContext(ctx).Where("leaderboard_id = ?", leaderboardID).Order("created_at asc").Find(&members).Error
+	return members, err
+}
+
+func (r *leaderboardMemberRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]models.LeaderboardMember, error) {
+	var members []models.LeaderboardMember
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&members).Error
+	return members, err
+}
+
+func (r *leaderboardMemberRepository) Delete(ctx context.Context, leaderboardID, userID uuid.UUID) error {
+	// Hard delete so the user can be added again later
+	return r.db.WithContext(ctx).Unscoped().Delete(&models.LeaderboardMember{}, "leaderboard_id = ? AND user_id = ?", leaderboardID, userID).Error
+}
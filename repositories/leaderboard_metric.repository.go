@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LeaderboardMetricRepository interface {
+	Create(ctx context.Context, leaderboardMetric *models.LeaderboardMetric) error
+	CreateBatch(ctx context.Context, leaderboardMetrics []models.LeaderboardMetric) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.LeaderboardMetric, error)
+	// FindByLeaderboardAndMetric returns the live association of the metric with the leaderboard; there is at most one
+	FindByLeaderboardAndMetric(ctx context.Context, leaderboardID, metricID uuid.UUID) (*models.LeaderboardMetric, error)
+	// FindFiltered returns the metrics of a leaderboard, or of every leaderboard when leaderboardID is nil, in
+	// display priority order. Unless it is nil, only the metrics of the leaderboards in readableIDs are returned.
+	FindFiltered(ctx context.Context, leaderboardID *uuid.UUID, readableIDs []uuid.UUID) ([]models.LeaderboardMetric, error)
+	// CountByLeaderboardID returns how many live metrics are attached to the leaderboard
+	CountByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) (int64, error)
+	Update(ctx context.Context, leaderboardMetric *models.LeaderboardMetric) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// DeleteByLeaderboardID soft-deletes the live metrics of a leaderboard as of deletedAt
+	DeleteByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID, deletedAt time.Time) error
+	// RestoreByLeaderboardID undoes the soft delete of the leaderboard's metrics deleted at deletedAt
+	RestoreByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID, deletedAt time.Time) error
+}
+
+type leaderboardMetricRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaderboardMetricRepository() LeaderboardMetricRepository {
+	return &leaderboardMetricRepository{
+		db: db.DB,
+	}
+}
+
+func (r *leaderboardMetricRepository) Create(ctx context.Context, leaderboardMetric *models.LeaderboardMetric) error {
+	return r.db.WithContext(ctx).Create(leaderboardMetric).Error
+}
+
+func (r *leaderboardMetricRepository) CreateBatch(ctx context.Context, leaderboardMetrics []models.LeaderboardMetric) error {
+	if len(leaderboardMetrics) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(leaderboardMetrics, createBatchSize).Error
+}
+
+func (r *leaderboardMetricRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.LeaderboardMetric, error) {
+	var leaderboardMetric models.LeaderboardMetric
+	err := r.db.WithContext(ctx).First(&leaderboardMetric, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &leaderboardMetric, nil
+}
+
+func (r *leaderboardMetricRepository) FindByLeaderboardAndMetric(ctx context.Context, leaderboardID, metricID uuid.UUID) (*models.LeaderboardMetric, error) {
+	var leaderboardMetric models.LeaderboardMetric
+	err := r.db.WithContext(ctx).First(&leaderboardMetric, "leaderboard_id = ? AND metric_id = ?", leaderboardID, metricID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &leaderboardMetric, nil
+}
+
+func (r *leaderboardMetricRepository) FindFiltered(ctx context.Context, leaderboardID *uuid.UUID,
+	readableIDs []uuid.UUID) ([]models.LeaderboardMetric, error) {
+
+	leaderboardMetrics := []models.LeaderboardMetric{}
+	if readableIDs != nil && len(readableIDs) == 0 {
+		return leaderboardMetrics, nil
+	}
+	query := r.db.WithContext(ctx)
+	if leaderboardID != nil {
+		query = query.Where("leaderboard_id = ?", *leaderboardID)
+	}
+	if readableIDs != nil {
+		query = query.Where("leaderboard_id IN ?", readableIDs)
+	}
+	err := query.Order("display_priority asc").Find(&leaderboardMetrics).Error
+	return leaderboardMetrics, err
+}
+
+func (r *leaderboardMetricRepository) CountByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.LeaderboardMetric{}).Where("leaderboard_id = ?", leaderboardID).Count(&count).Error
+	return count, err
+}
+
+func (r *leaderboardMetricRepository) Update(ctx context.Context, leaderboardMetric *models.LeaderboardMetric) error {
+	return r.db.WithContext(ctx).Save(leaderboardMetric).Error
+}
+
+func (r *leaderboardMetricRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.LeaderboardMetric{}, "id = ?", id).Error
+}
+
+func (r *leaderboardMetricRepository) DeleteByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID, deletedAt time.Time) error {
+	return deleteChildren(r.db.WithContext(ctx), &models.LeaderboardMetric{}, "leaderboard_id", leaderboardID, deletedAt)
+}
+
+func (r *leaderboardMetricRepository) RestoreByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID, deletedAt time.Time) error {
+	return restoreChildren(r.db.WithContext(ctx), &models.LeaderboardMetric{}, "leaderboard_id", leaderboardID, deletedAt)
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LeaderboardParticipantAccessRepository interface {
+	Create(ctx context.Context, access *models.LeaderboardParticipantAccess) error
+	Exists(ctx context.Context, leaderboardID, participantID uuid.UUID) (bool, error)
+	FindByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardParticipantAccess, error)
+	FindByParticipantID(ctx context.Context, participantID uuid.UUID) ([]models.LeaderboardParticipantAccess, error)
+	Delete(ctx context.Context, leaderboardID, participantID uuid.UUID) error
+}
+
+type leaderboardParticipantAccessRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaderboardParticipantAccessRepository() LeaderboardParticipantAccessRepository {
+	return &leaderboardParticipantAccessRepository{
+		db: db.DB,
+	}
+}
+
+func (r *leaderboardParticipantAccessRepository) Create(ctx context.Context, access *models.LeaderboardParticipantAccess) error {
+	// Granting access twice is a no-op
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(access).Error
+}
+
+func (r *leaderboardParticipantAccessRepository) Exists(ctx context.Context, leaderboardID, participantID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.LeaderboardParticipantAccess{}).
+		Where("leaderboard_id = ? AND participant_id = ?", leaderboardID, participantID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *leaderboardParticipantAccessRepository) FindByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardParticipantAccess, error) {
+	var accesses []models.LeaderboardParticipantAccess
+	err := r.db.WithContext(ctx).Where("leaderboard_id = ?", leaderboardID).Order("created_at asc").Find(&accesses).Error
+	return accesses, err
+}
+
+func (r *leaderboardParticipantAccessRepository) FindByParticipantID(ctx context.Context, participantID uuid.UUID) ([]models.LeaderboardParticipantAccess, error) {
+	var accesses []models.LeaderboardParticipantAccess
+	err := r.db.WithContext(ctx).Where("participant_id = ?", participantID).Find(&accesses).Error
+	return accesses, err
+}
+
+func (r *leaderboardParticipantAccessRepository) Delete(ctx context.Context, leaderboardID, participantID uuid.UUID) error {
+	// Hard delete so access can be granted again later
+	return r.db.WithContext(ctx).Unscoped().Delete(&models.LeaderboardParticipantAccess{},
+		"leaderboard_id = ? AND participant_id = ?", leaderboardID, participantID).Error
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LeaderboardSnapshotRepository interface {
+	// Create stores the snapshot together with its entries
+	Create(ctx context.Context, snapshot *models.LeaderboardSnapshot) error
+	// FindByID returns the snapshot with its entries best rank first and their participants loaded
+	FindByID(ctx context.Context, id uuid.UUID) (*models.LeaderboardSnapshot, error)
+	// FindByLeaderboardID returns the leaderboard's snapshots newest first, without their entries
+	FindByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardSnapshot, error)
+}
+
+type leaderboardSnapshotRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaderboardSnapshotRepository() LeaderboardSnapshotRepository {
+	return &leaderboardSnapshotRepository{
+		db: db.DB,
+	}
+}
+
+func (r *leaderboardSnapshotRepository) Create(ctx context.Context, snapshot *models.LeaderboardSnapshot) error {
+	return r.db.WithContext(ctx).Create(snapshot).Error
+}
+
+func (r *leaderboardSnapshotRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.LeaderboardSnapshot, error) {
+	var snapshot models.LeaderboardSnapshot
+	err := r.db.WithContext(ctx).Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		// Unranked entries sort after the ranked ones, as in the live standings
+		return db.Order("rank < 1, rank asc")
+	}).Preload("Entries.Participant").First(&snapshot, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+func (r *leaderboardSnapshotRepository) FindByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardSnapshot, error) {
+	var snapshots []models.LeaderboardSnapshot
+	err := r.db.WithContext(ctx).Where("leaderboard_id = ?", leaderboardID).Order("taken_at desc").Find(&snapshots).Error
+	return snapshots, err
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FailureStats summarises the failed login attempts matching a filter
+type FailureStats struct {
+	Count  int64
+	Latest *time.Time
+}
+
+type LoginAttemptRepository interface {
+	Create(ctx context.Context, attempt *models.LoginAttempt) error
+	UsernameFailuresSince(ctx context.Context, username string, since time.Time) (FailureStats, error)
+	IPFailuresSince(ctx context.Context, ipAddress string, since time.Time) (FailureStats, error)
+	LastSuccessByUsername(ctx context.Context, username string) (*time.Time, error)
+}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository() LoginAttemptRepository {
+	return &loginAttemptRepository{
+		db: db.DB,
+	}
+}
+
+func (r *loginAttemptRepository) Create(ctx context.Context, attempt *models.LoginAttempt) error {
+	return r.db.WithContext(ctx).Create(attempt).Error
+}
+
+func (r *loginAttemptRepository) UsernameFailuresSince(ctx context.Context, username string, since time.Time) (FailureStats, error) {
+	return r.failuresSince(ctx, "username = ?", username, since)
+}
+
+func (r *loginAttemptRepository) IPFailuresSince(ctx context.Context, ipAddress string, since time.Time) (FailureStats, error) {
+	return r.failuresSince(ctx, "ip_address = ?", ipAddress, since)
+}
+
+func (r *loginAttemptRepository) LastSuccessByUsername(ctx context.Context, username string) (*time.Time, error) {
+	var attempt models.LoginAttempt
+	err := r.db.WithContext(ctx).Where("username = ? AND succeeded = ?", username, true).
+		Order("created_at desc").
+		First(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt.CreatedAt, nil
+}
+
+// failuresSince counts the failures that count towards lockout, ignoring attempts rejected while throttled
+func (r *loginAttemptRepository) failuresSince(ctx context.Context, condition string, value string, since time.Time) (FailureStats, error) {
+	var row struct {
+		Count  int64
+		Latest *time.Time
+	}
+	err := r.db.WithContext(ctx).Model(&models.LoginAttempt{}).
+		Select("COUNT(*) AS count, MAX(created_at) AS latest").
+		Where(condition, value).
+		Where("succeeded = ? AND failure_reason = ? AND created_at > ?", false, models.LoginFailureInvalidCredentials, since).
+		Scan(&row).Error
+	if err != nil {
+		return FailureStats{}, err
+	}
+	return FailureStats{Count: row.Count, Latest: row.Latest}, nil
+}
@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"leaderboard-service/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultLookupCacheTTL is how long a leaderboard, metric or participant found by ID is served from memory
+	DefaultLookupCacheTTL = 30 * time.Second
+	lookupCacheSize       = 10000
+)
+
+// Caches of the rows found by ID, shared by every repository instance. Writes through the repositories remove the
+// rows they change; changes made by other instances are picked up once the TTL runs out.
+var (
+	leaderboardCache = newLookupCache[models.Leaderboard](lookupCacheSize, DefaultLookupCacheTTL)
+	metricCache      = newLookupCache[models.Metric](lookupCacheSize, DefaultLookupCacheTTL)
+	participantCache = newLookupCache[models.Participant](lookupCacheSize, DefaultLookupCacheTTL)
+)
+
+// ConfigureLookupCache sets how long leaderboards, metrics and participants found by ID are cached. A TTL of zero
+// disables the caches.
+func ConfigureLookupCache(ttl time.Duration) {
+	leaderboardCache.configure(ttl)
+	metricCache.configure(ttl)
+	participantCache.configure(ttl)
+}
+
+// lookupCache is a small in-process LRU cache of rows by ID whose entries expire after a TTL. It is safe for
+// concurrent use.
+type lookupCache[V any] struct {
+	mu         sync.Mutex
+	capacity   int
+	ttl        time.Duration
+	order      *list.List // Most recently used first
+	items      map[uuid.UUID]*list.Element
+	generation uint64 // Bumped by every removal so loads that raced a write are not cached
+}
+
+type lookupCacheItem[V any] struct {
+	id        uuid.UUID
+	value     V
+	expiresAt time.Time
+}
+
+func newLookupCache[V any](capacity int, ttl time.Duration) *lookupCache[V] {
+	return &lookupCache[V]{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		items:    make(map[uuid.UUID]*list.Element),
+	}
+}
+
+func (c *lookupCache[V]) configure(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+	c.order.Init()
+	clear(c.items)
+	c.generation++
+}
+
+// load returns a copy of the cached row with the ID, or the row found by find, which is cached unless the row was
+// removed while it was being found. Errors, including gorm.ErrRecordNotFound, are never cached.
+func (c *lookupCache[V]) load(id uuid.UUID, find func() (*V, error), copyValue func(V) V) (*V, error) {
+	c.mu.Lock()
+	if c.ttl <= 0 {
+		c.mu.Unlock()
+		return find()
+	}
+	if element, ok := c.items[id]; ok {
+		item := element.Value.(*lookupCacheItem[V])
+		if time.Now().Before(item.expiresAt) {
+			c.order.MoveToFront(element)
+			value := copyValue(item.value)
+			c.mu.Unlock()
+			return &value, nil
+		}
+		c.order.Remove(element)
+		delete(c.items, id)
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	found, err := find()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != generation || c.ttl <= 0 {
+		return found, nil
+	}
+	item := &lookupCacheItem[V]{id: id, value: copyValue(*found), expiresAt: time.Now().Add(c.ttl)}
+	if element, ok := c.items[id]; ok {
+		element.Value = item
+		c.order.MoveToFront(element)
+	} else {
+		c.items[id] = c.order.PushFront(item)
+	}
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lookupCacheItem[V]).id)
+	}
+	return found, nil
+}
+
+// remove drops the rows with the IDs so the next lookup reads them again
+func (c *lookupCache[V]) remove(ids ...uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for _, id := range ids {
+		if element, ok := c.items[id]; ok {
+			c.order.Remove(element)
+			delete(c.items, id)
+		}
+	}
+}
+
+// cacheRemovals holds back the cache removals of writes made in a transaction until it has finished. Removing a row
+// before the commit would let a lookup cache the old row again and keep serving it after the commit.
+type cacheRemovals struct {
+	pending []func()
+}
+
+func (c *cacheRemovals) run() {
+	for _, remove := range c.pending {
+		remove()
+	}
+}
+
+// uncache removes the rows with the IDs from the cache, right away unless the write is in a transaction whose
+// removals are held back until it has finished
+func uncache[V any](removals *cacheRemovals, cache *lookupCache[V], ids ...uuid.UUID) {
+	if removals == nil {
+		cache.remove(ids...)
+		return
+	}
+	removals.pending = append(removals.pending, func() {
+		cache.remove(ids...)
+	})
+}
+
+// lookup loads the row with the ID through the cache, except in a transaction, whose reads must see its own writes
+// and must not cache rows that are not committed yet
+func lookup[V any](removals *cacheRemovals, cache *lookupCache[V], id uuid.UUID, find func() (*V, error),
+	copyValue func(V) V) (*V, error) {
+
+	if removals != nil {
+		return find()
+	}
+	return cache.load(id, find, copyValue)
+}
+
+// copyLeaderboard, copyMetric and copyParticipant copy a cached row so callers can change what they get back
+// without changing the cache
+func copyLeaderboard(leaderboard models.Leaderboard) models.Leaderboard {
+	leaderboard.StartDate = copyPointer(leaderboard.StartDate)
+	leaderboard.EndDate = copyPointer(leaderboard.EndDate)
+	leaderboard.OwnerID = copyPointer(leaderboard.OwnerID)
+	leaderboard.GroupID = copyPointer(leaderboard.GroupID)
+	return leaderboard
+}
+
+func copyMetric(metric models.Metric) models.Metric {
+	return metric
+}
+
+func copyParticipant(participant models.Participant) models.Participant {
+	participant.UserID = copyPointer(participant.UserID)
+	participant.ParentID = copyPointer(participant.ParentID)
+	participant.AnonymizedAt = copyPointer(participant.AnonymizedAt)
+	if participant.Tags != nil {
+		participant.Tags = append(make([]models.ParticipantTag, 0, len(participant.Tags)), participant.Tags...)
+	}
+	return participant
+}
+
+func copyPointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
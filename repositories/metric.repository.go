@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"leaderboard-service/db"
 	"leaderboard-service/models"
 
@@ -8,16 +10,35 @@ import (
 	"gorm.io/gorm"
 )
 
+// LeaderboardMetricWeight is one of a leaderboard's metrics joined with how the leaderboard weighs and orders it
+type LeaderboardMetricWeight struct {
+	models.Metric
+	Weight          float64 // Sum of the weights when the metric is linked to the leaderboard more than once
+	DisplayPriority int     // Lowest display priority the metric is linked with
+}
+
 type MetricRepository interface {
-	Create(metric *models.Metric) error
-	FindByID(id uuid.UUID) (*models.Metric, error)
-	FindAll() ([]models.Metric, error)
-	Update(metric *models.Metric) error
-	Delete(id uuid.UUID) error
+	Create(ctx context.Context, metric *models.Metric) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Metric, error)
+	FindAll(ctx context.Context) ([]models.Metric, error)
+	// FindFiltered returns the metrics with the given IDs, or every metric when there are none. Soft-deleted
+	// metrics are only returned when asked for.
+	FindFiltered(ctx context.Context, ids []uuid.UUID, includeDeleted bool) ([]models.Metric, error)
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Metric, error)
+	// FindByLeaderboardID returns the leaderboard's live metrics with their weights, lowest display priority first,
+	// joined in a single query
+	FindByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) ([]LeaderboardMetricWeight, error)
+	Update(ctx context.Context, metric *models.Metric) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// FindDeletedByID returns the metric if it is soft-deleted
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*models.Metric, error)
+	// Restore undoes the soft delete of a metric
+	Restore(ctx context.Context, id uuid.UUID) error
 }
 
 type metricRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	removals *cacheRemovals // Set in a unit of work, see uncache
 }
 
 func NewMetricRepository() MetricRepository {
@@ -26,29 +47,77 @@ func NewMetricRepository() MetricRepository {
 	}
 }
 
-func (r *metricRepository) Create(metric *models.Metric) error {
-	return r.db.Create(metric).Error
+func (r *metricRepository) Create(ctx context.Context, metric *models.Metric) error {
+	return r.db.WithContext(ctx).Create(metric).Error
 }
 
-func (r *metricRepository) FindByID(id uuid.UUID) (*models.Metric, error) {
-	var metric models.Metric
-	err := r.db.First(&metric, "id = ?", id).Error
-	if err != nil {
-		return nil, err
+func (r *metricRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Metric, error) {
+	return lookup(r.removals, metricCache, id, func() (*models.Metric, error) {
+		var metric models.Metric
+		err := r.db.WithContext(ctx).First(&metric, "id = ?", id).Error
+		if err != nil {
+			return nil, err
+		}
+		return &metric, nil
+	}, copyMetric)
+}
+
+func (r *metricRepository) FindAll(ctx context.Context) ([]models.Metric, error) {
+	var metrics []models.Metric
+	err := r.db.WithContext(ctx).Find(&metrics).Error
+	return metrics, err
+}
+
+func (r *metricRepository) FindFiltered(ctx context.Context, ids []uuid.UUID, includeDeleted bool) ([]models.Metric, error) {
+	var metrics []models.Metric
+	query := withDeleted(r.db.WithContext(ctx), includeDeleted)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
 	}
-	return &metric, nil
+	err := query.Find(&metrics).Error
+	return metrics, err
 }
 
-func (r *metricRepository) FindAll() ([]models.Metric, error) {
+func (r *metricRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Metric, error) {
 	var metrics []models.Metric
-	err := r.db.Find(&metrics).Error
+	if len(ids) == 0 {
+		return metrics, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&metrics).Error
 	return metrics, err
 }
 
-func (r *metricRepository) Update(metric *models.Metric) error {
-	return r.db.Save(metric).Error
+func (r *metricRepository) FindByLeaderboardID(ctx context.Context, leaderboardID uuid.UUID) ([]LeaderboardMetricWeight, error) {
+	var metrics []LeaderboardMetricWeight
+	err := r.db.WithContext(ctx).Model(&models.Metric{}).
+		Select("metrics.*, SUM(leaderboard_metrics.weight) AS weight, MIN(leaderboard_metrics.display_priority) AS display_priority").
+		Joins("JOIN leaderboard_metrics ON leaderboard_metrics.metric_id = metrics.id AND leaderboard_metrics.deleted_at IS NULL").
+		Where("leaderboard_metrics.leaderboard_id = ?", leaderboardID).
+		Group("metrics.id").
+		Order("display_priority asc, MIN(leaderboard_metrics.created_at) asc").
+		Scan(&metrics).Error
+	return metrics, err
+}
+
+func (r *metricRepository) Update(ctx context.Context, metric *models.Metric) error {
+	defer uncache(r.removals, metricCache, metric.ID)
+	return r.db.WithContext(ctx).Save(metric).Error
+}
+
+func (r *metricRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer uncache(r.removals, metricCache, id)
+	return r.db.WithContext(ctx).Delete(&models.Metric{}, "id = ?", id).Error
+}
+
+func (r *metricRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*models.Metric, error) {
+	var metric models.Metric
+	if err := findDeleted(r.db.WithContext(ctx), &metric, id); err != nil {
+		return nil, err
+	}
+	return &metric, nil
 }
 
-func (r *metricRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Metric{}, "id = ?", id).Error
+func (r *metricRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	defer uncache(r.removals, metricCache, id)
+	return restore(r.db.WithContext(ctx), &models.Metric{}, id)
 }
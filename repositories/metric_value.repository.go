@@ -1,23 +1,131 @@
 package repositories
 
 import (
+	"context"
+	"fmt"
 	"leaderboard-service/db"
 	"leaderboard-service/models"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// MetricValueAggregate summarises a participant's values for one metric
+type MetricValueAggregate struct {
+	MetricID  uuid.UUID
+	Count     int64
+	Sum       float64
+	Min       float64
+	Max       float64
+	Average   float64
+	LastValue float64
+	// The last boolean or string value, of boolean and string metrics
+	LastBoolValue   *bool
+	LastStringValue *string
+	LastRecordedAt  *time.Time
+}
+
+// ParticipantMetricAggregate summarises one participant's values for one metric
+type ParticipantMetricAggregate struct {
+	ParticipantID uuid.UUID
+	MetricValueAggregate
+}
+
+// MetricValuePart is a partial aggregate of a participant's values for a metric: a single raw value, or a daily
+// rollup of the values compacted into it. A part never straddles a leaderboard's date range or a team membership,
+// so its FirstRecordedAt tells whether all of it is in one.
+type MetricValuePart struct {
+	MetricID        uuid.UUID
+	ParticipantID   uuid.UUID
+	Count           int64
+	Sum             float64
+	Min             float64
+	Max             float64
+	LastValue       float64
+	LastBoolValue   *bool
+	LastStringValue *string
+	FirstRecordedAt time.Time
+	LastRecordedAt  time.Time
+}
+
+// metricValueParts selects the live raw values and the rollups alike as parts, so aggregates are summed over both
+// regardless of which values have been compacted. Filters on the outer query are pushed down into both branches.
+const metricValueParts = `(SELECT metric_id, participant_id, 1::bigint AS count, value AS sum, value AS min, value AS max,
+		value AS last_value, bool_value AS last_bool_value, string_value AS last_string_value,
+		timestamp AS first_recorded_at, timestamp AS last_recorded_at
+	FROM metric_values WHERE deleted_at IS NULL
+	UNION ALL
+	SELECT metric_id, participant_id, count, sum, min, max, last_value, last_bool_value, last_string_value,
+		first_recorded_at, last_recorded_at
+	FROM metric_value_rollups WHERE deleted_at IS NULL) AS metric_value_parts`
+
+// partAggregates combines parts into the columns of a MetricValueAggregate
+const partAggregates = "SUM(count)::bigint AS count, SUM(sum) AS sum, MIN(min) AS min, MAX(max) AS max, " +
+	"SUM(sum) / SUM(count)::float8 AS average, (ARRAY_AGG(last_value ORDER BY last_recorded_at DESC))[1] AS last_value, " +
+	"(ARRAY_AGG(last_bool_value ORDER BY last_recorded_at DESC))[1] AS last_bool_value, " +
+	"(ARRAY_AGG(last_string_value ORDER BY last_recorded_at DESC))[1] AS last_string_value, " +
+	"MAX(last_recorded_at) AS last_recorded_at"
+
+// Intervals metric values can be grouped into, each a UTC calendar period
+const (
+	IntervalHour  = "hour"
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// AggregateIntervals lists every interval values can be grouped by
+var AggregateIntervals = []string{IntervalHour, IntervalDay, IntervalWeek, IntervalMonth}
+
+// MetricValueBucket summarises one metric's values recorded in one interval; PeriodStart is unset when the values
+// are not grouped
+type MetricValueBucket struct {
+	PeriodStart *time.Time
+	MetricValueAggregate
+}
+
+// ParticipantActivity counts the values a participant has recorded, overall and since a point in time
+type ParticipantActivity struct {
+	Total            int64
+	Recent           int64
+	RecentActiveDays int64 // Distinct UTC days with a recent value
+	FirstRecordedAt  *time.Time
+	LastRecordedAt   *time.Time
+}
+
 type MetricValueRepository interface {
-	Create(metricValue *models.MetricValue) error
-	FindByID(id uuid.UUID) (*models.MetricValue, error)
-	FindAll() ([]models.MetricValue, error)
-	FindByMetricID(metricID uuid.UUID) ([]models.MetricValue, error)
-	FindByParticipantID(participantID uuid.UUID) ([]models.MetricValue, error)
-	FindFiltered(metricID, participantID *uuid.UUID, fromTime, toTime *time.Time) ([]models.MetricValue, error)
-	Update(metricValue *models.MetricValue) error
-	Delete(id uuid.UUID) error
+	Create(ctx context.Context, metricValue *models.MetricValue) error
+	// CreateBatch inserts the values with multi-row INSERTs in a single transaction, so either all of them are stored
+	// or none are
+	CreateBatch(ctx context.Context, metricValues []models.MetricValue) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.MetricValue, error)
+	FindAll(ctx context.Context) ([]models.MetricValue, error)
+	FindByMetricID(ctx context.Context, metricID uuid.UUID) ([]models.MetricValue, error)
+	FindByParticipantID(ctx context.Context, participantID uuid.UUID) ([]models.MetricValue, error)
+	FindFiltered(ctx context.Context, metricID, participantID *uuid.UUID, fromTime, toTime *time.Time, sort *Sort) ([]models.MetricValue, error)
+	// FindPage returns up to limit of the values matching the filters in the sort's order, starting after the keyset
+	// when it is set
+	FindPage(ctx context.Context, metricID, participantID *uuid.UUID, fromTime, toTime *time.Time, sort Sort, after *Keyset, limit int) ([]models.MetricValue, error)
+	// FindPartsForParticipants returns the participants' raw values and rollups for any of the metrics in the time
+	// range, oldest first
+	FindPartsForParticipants(ctx context.Context, metricIDs, participantIDs []uuid.UUID, fromTime, toTime *time.Time) ([]MetricValuePart, error)
+	Update(ctx context.Context, metricValue *models.MetricValue) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// AggregateByMetric summarises the participant's values per metric. With since set, only the metrics in it are
+	// summarised, each over the values recorded from its own start time.
+	AggregateByMetric(ctx context.Context, participantID uuid.UUID, since map[uuid.UUID]time.Time) ([]MetricValueAggregate, error)
+	// AggregateForParticipants summarises each participant's values per metric, over the values recorded in the time range
+	AggregateForParticipants(ctx context.Context, metricIDs, participantIDs []uuid.UUID, fromTime, toTime *time.Time) ([]ParticipantMetricAggregate, error)
+	// AggregateByInterval summarises a metric's values in the time range, optionally of one participant, per UTC
+	// interval oldest first, or as a single bucket when interval is empty
+	AggregateByInterval(ctx context.Context, metricID uuid.UUID, participantID *uuid.UUID, fromTime, toTime *time.Time, interval string) ([]MetricValueBucket, error)
+	// ActivityForParticipant counts the participant's values, treating those recorded from recentSince as recent
+	ActivityForParticipant(ctx context.Context, participantID uuid.UUID, recentSince time.Time) (ParticipantActivity, error)
+	// DeleteByParticipantID permanently removes every value of the participant, raw or rolled up, and returns how many
+	// there were
+	DeleteByParticipantID(ctx context.Context, participantID uuid.UUID) (int64, error)
 }
 
 type metricValueRepository struct {
@@ -30,39 +138,83 @@ func NewMetricValueRepository() MetricValueRepository {
 	}
 }
 
-func (r *metricValueRepository) Create(metricValue *models.MetricValue) error {
-	return r.db.Create(metricValue).Error
+func (r *metricValueRepository) Create(ctx context.Context, metricValue *models.MetricValue) error {
+	return r.db.WithContext(ctx).Create(metricValue).Error
 }
 
-func (r *metricValueRepository) FindByID(id uuid.UUID) (*models.MetricValue, error) {
+func (r *metricValueRepository) CreateBatch(ctx context.Context, metricValues []models.MetricValue) error {
+	if len(metricValues) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(metricValues, createBatchSize).Error
+	})
+}
+
+func (r *metricValueRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.MetricValue, error) {
 	var metricValue models.MetricValue
-	err := r.db.First(&metricValue, "id = ?", id).Error
+	err := r.db.WithContext(ctx).First(&metricValue, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &metricValue, nil
 }
 
-func (r *metricValueRepository) FindAll() ([]models.MetricValue, error) {
+func (r *metricValueRepository) FindAll(ctx context.Context) ([]models.MetricValue, error) {
 	var metricValues []models.MetricValue
-	err := r.db.Find(&metricValues).Error
+	err := r.db.WithContext(ctx).Find(&metricValues).Error
 	return metricValues, err
 }
 
-func (r *metricValueRepository) FindByMetricID(metricID uuid.UUID) ([]models.MetricValue, error) {
+func (r *metricValueRepository) FindByMetricID(ctx context.Context, metricID uuid.UUID) ([]models.MetricValue, error) {
 	var metricValues []models.MetricValue
-	err := r.db.Where("metric_id = ?", metricID).Find(&metricValues).Error
+	err := r.db.WithContext(ctx).Where("metric_id = ?", metricID).Find(&metricValues).Error
 	return metricValues, err
 }
 
-func (r *metricValueRepository) FindByParticipantID(participantID uuid.UUID) ([]models.MetricValue, error) {
+func (r *metricValueRepository) FindByParticipantID(ctx context.Context, participantID uuid.UUID) ([]models.MetricValue, error) {
 	var metricValues []models.MetricValue
-	err := r.db.Where("participant_id = ?", participantID).Find(&metricValues).Error
+	err := r.db.WithContext(ctx).Where("participant_id = ?", participantID).Find(&metricValues).Error
 	return metricValues, err
 }
 
-func (r *metricValueRepository) FindFiltered(metricID, participantID *uuid.UUID, fromTime, toTime *time.Time) ([]models.MetricValue, error) {
+func (r *metricValueRepository) FindFiltered(ctx context.Context, metricID, participantID *uuid.UUID, fromTime, toTime *time.Time, sort *Sort) ([]models.MetricValue, error) {
 	var metricValues []models.MetricValue
+
+	// Default to the most recent first
+	query, err := MetricValueSortColumns.apply(r.filtered(metricID, participantID, fromTime, toTime).WithContext(ctx), sort, "timestamp desc")
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Find(&metricValues).Error
+	return metricValues, err
+}
+
+func (r *metricValueRepository) FindPage(ctx context.Context, metricID, participantID *uuid.UUID, fromTime, toTime *time.Time,
+	sort Sort, after *Keyset, limit int) ([]models.MetricValue, error) {
+	var metricValues []models.MetricValue
+	query := r.filtered(metricID, participantID, fromTime, toTime).WithContext(ctx)
+
+	if after != nil {
+		var err error
+		query, err = MetricValueSortColumns.after(query, sort, *after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	query, err := MetricValueSortColumns.apply(query, &sort, "")
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Limit(limit).Find(&metricValues).Error
+	return metricValues, err
+}
+
+// filtered narrows the metric values down to those matching the filters that are set
+func (r *metricValueRepository) filtered(metricID, participantID *uuid.UUID, fromTime, toTime *time.Time) *gorm.DB {
 	query := r.db
 
 	if metricID != nil {
@@ -81,15 +233,139 @@ func (r *metricValueRepository) FindFiltered(metricID, participantID *uuid.UUID,
 		query = query.Where("timestamp <= ?", *toTime)
 	}
 
-	// Order by timestamp, most recent first
-	err := query.Order("timestamp desc").Find(&metricValues).Error
-	return metricValues, err
+	return query
 }
 
-func (r *metricValueRepository) Update(metricValue *models.MetricValue) error {
-	return r.db.Save(metricValue).Error
+func (r *metricValueRepository) FindPartsForParticipants(ctx context.Context, metricIDs, participantIDs []uuid.UUID,
+	fromTime, toTime *time.Time) ([]MetricValuePart, error) {
+	var parts []MetricValuePart
+	if len(metricIDs) == 0 || len(participantIDs) == 0 {
+		return parts, nil
+	}
+
+	query := r.db.WithContext(ctx).Table(metricValueParts).Where("metric_id IN ? AND participant_id IN ?", metricIDs, participantIDs)
+	query = partsInRange(query, fromTime, toTime)
+
+	err := query.Order("last_recorded_at asc").Scan(&parts).Error
+	return parts, err
+}
+
+// partsInRange keeps the parts recorded within the time range. A rollup is kept only when all of its values are.
+func partsInRange(query *gorm.DB, fromTime, toTime *time.Time) *gorm.DB {
+	if fromTime != nil {
+		query = query.Where("first_recorded_at >= ?", *fromTime)
+	}
+	if toTime != nil {
+		query = query.Where("last_recorded_at <= ?", *toTime)
+	}
+	return query
+}
+
+func (r *metricValueRepository) Update(ctx context.Context, metricValue *models.MetricValue) error {
+	return r.db.WithContext(ctx).Save(metricValue).Error
+}
+
+func (r *metricValueRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.MetricValue{}, "id = ?", id).Error
+}
+
+func (r *metricValueRepository) DeleteByParticipantID(ctx context.Context, participantID uuid.UUID) (int64, error) {
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rolledUp int64
+		err := tx.Unscoped().Model(&models.MetricValueRollup{}).Where("participant_id = ?", participantID).
+			Select("COALESCE(SUM(count), 0)").Scan(&rolledUp).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&models.MetricValueRollup{}, "participant_id = ?", participantID).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Delete(&models.MetricValue{}, "participant_id = ?", participantID)
+		deleted = result.RowsAffected + rolledUp
+		return result.Error
+	})
+	return deleted, err
+}
+
+func (r *metricValueRepository) AggregateByMetric(ctx context.Context, participantID uuid.UUID,
+	since map[uuid.UUID]time.Time) ([]MetricValueAggregate, error) {
+
+	var aggregates []MetricValueAggregate
+	query := r.db.WithContext(ctx).Table(metricValueParts).
+		Select("metric_id, "+partAggregates).
+		Where("participant_id = ?", participantID)
+
+	if since != nil {
+		if len(since) == 0 {
+			return aggregates, nil
+		}
+		periods := r.db.Where("1 = 0")
+		for metricID, from := range since {
+			periods = periods.Or("metric_id = ? AND first_recorded_at >= ?", metricID, from)
+		}
+		query = query.Where(periods)
+	}
+
+	err := query.Group("metric_id").Scan(&aggregates).Error
+	return aggregates, err
+}
+
+func (r *metricValueRepository) AggregateForParticipants(ctx context.Context, metricIDs, participantIDs []uuid.UUID,
+	fromTime, toTime *time.Time) ([]ParticipantMetricAggregate, error) {
+
+	var aggregates []ParticipantMetricAggregate
+	if len(metricIDs) == 0 || len(participantIDs) == 0 {
+		return aggregates, nil
+	}
+
+	query := r.db.WithContext(ctx).Table(metricValueParts).
+		Select("participant_id, metric_id, "+partAggregates).
+		Where("metric_id IN ? AND participant_id IN ?", metricIDs, participantIDs)
+	query = partsInRange(query, fromTime, toTime)
+
+	err := query.Group("participant_id, metric_id").Scan(&aggregates).Error
+	return aggregates, err
+}
+
+func (r *metricValueRepository) AggregateByInterval(ctx context.Context, metricID uuid.UUID, participantID *uuid.UUID,
+	fromTime, toTime *time.Time, interval string) ([]MetricValueBucket, error) {
+
+	var buckets []MetricValueBucket
+	if interval != "" && !slices.Contains(AggregateIntervals, interval) {
+		return buckets, fmt.Errorf("unknown interval %q", interval)
+	}
+
+	// The interval is one of the constants above, so it can go into the SQL as is; a bound parameter would make
+	// the grouped expression differ from the selected one. Rollups fall into the interval of their first value, so
+	// a compacted day is a single hourly point.
+	periodStart := "NULL::timestamp"
+	group := "metric_id"
+	if interval != "" {
+		periodStart = "date_trunc('" + interval + "', first_recorded_at AT TIME ZONE 'UTC')"
+		group = "metric_id, period_start"
+	}
+
+	query := r.db.WithContext(ctx).Table(metricValueParts).
+		Select(periodStart+" AS period_start, metric_id, "+partAggregates).
+		Where("metric_id = ?", metricID)
+	if participantID != nil {
+		query = query.Where("participant_id = ?", *participantID)
+	}
+	query = partsInRange(query, fromTime, toTime)
+
+	err := query.Group(group).Order("period_start").Scan(&buckets).Error
+	return buckets, err
 }
 
-func (r *metricValueRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.MetricValue{}, "id = ?", id).Error
+func (r *metricValueRepository) ActivityForParticipant(ctx context.Context, participantID uuid.UUID, recentSince time.Time) (ParticipantActivity, error) {
+	var activity ParticipantActivity
+	err := r.db.WithContext(ctx).Table(metricValueParts).
+		Select("COALESCE(SUM(count), 0)::bigint AS total, COALESCE(SUM(count) FILTER (WHERE first_recorded_at >= @since), 0)::bigint AS recent, "+
+			"COUNT(DISTINCT DATE(first_recorded_at AT TIME ZONE 'UTC')) FILTER (WHERE first_recorded_at >= @since) AS recent_active_days, "+
+			"MIN(first_recorded_at) AS first_recorded_at, MAX(last_recorded_at) AS last_recorded_at", map[string]interface{}{"since": recentSince}).
+		Where("participant_id = ?", participantID).
+		Scan(&activity).Error
+	return activity, err
 }
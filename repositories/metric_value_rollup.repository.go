@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"leaderboard-service/db"
+
+	"gorm.io/gorm"
+)
+
+type MetricValueRollupRepository interface {
+	// FindCompactableDays returns the UTC days, oldest first, that have raw values recorded before the given time
+	FindCompactableDays(ctx context.Context, before time.Time) ([]time.Time, error)
+	// CompactDay rolls the raw values recorded on the UTC day starting at the given midnight into one rollup per
+	// participant and metric, merged into any rollup already made for the day, and permanently deletes them. Values
+	// are left raw while a leaderboard's date range or a team membership starts or ends between two of them, so every
+	// rollup is wholly inside or outside each period it is scored for. It returns how many values were rolled up.
+	CompactDay(ctx context.Context, day time.Time) (int64, error)
+}
+
+type metricValueRollupRepository struct {
+	db *gorm.DB
+}
+
+func NewMetricValueRollupRepository() MetricValueRollupRepository {
+	return &metricValueRollupRepository{
+		db: db.DB,
+	}
+}
+
+func (r *metricValueRollupRepository) FindCompactableDays(ctx context.Context, before time.Time) ([]time.Time, error) {
+	var days []time.Time
+	err := r.db.WithContext(ctx).Raw(`SELECT DISTINCT date_trunc('day', timestamp AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day
+		FROM metric_values
+		WHERE deleted_at IS NULL AND timestamp < ?
+		ORDER BY 1`, before).
+		Scan(&days).Error
+	return days, err
+}
+
+func (r *metricValueRollupRepository) CompactDay(ctx context.Context, day time.Time) (int64, error) {
+	var compacted int64
+	// The values are rolled up and deleted from the same snapshot, so a value edited meanwhile fails the transaction
+	// instead of being deleted with its old value rolled up
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`WITH candidates AS (
+			SELECT id, metric_id, participant_id, value, bool_value, string_value, timestamp FROM metric_values
+			WHERE deleted_at IS NULL AND timestamp >= @day AND timestamp < @next
+		), groups AS (
+			SELECT metric_id, participant_id, COUNT(*) AS count, SUM(value) AS sum, MIN(value) AS min, MAX(value) AS max,
+				(ARRAY_AGG(value ORDER BY timestamp DESC))[1] AS last_value,
+				(ARRAY_AGG(bool_value ORDER BY timestamp DESC))[1] AS last_bool_value,
+				(ARRAY_AGG(string_value ORDER BY timestamp DESC))[1] AS last_string_value,
+				MIN(timestamp) AS first_recorded_at, MAX(timestamp) AS last_recorded_at
+			FROM candidates
+			GROUP BY metric_id, participant_id
+		), merged AS (
+			-- LEAST and GREATEST skip the NULLs of days without a rollup yet
+			SELECT groups.metric_id, groups.participant_id,
+				groups.count + COALESCE(rollups.count, 0) AS count,
+				groups.sum + COALESCE(rollups.sum, 0) AS sum,
+				LEAST(groups.min, rollups.min) AS min,
+				GREATEST(groups.max, rollups.max) AS max,
+				CASE WHEN rollups.last_recorded_at > groups.last_recorded_at THEN rollups.last_value
+					ELSE groups.last_value END AS last_value,
+				CASE WHEN rollups.last_recorded_at > groups.last_recorded_at THEN rollups.last_bool_value
+					ELSE groups.last_bool_value END AS last_bool_value,
+				CASE WHEN rollups.last_recorded_at > groups.last_recorded_at THEN rollups.last_string_value
+					ELSE groups.last_string_value END AS last_string_value,
+				LEAST(groups.first_recorded_at, rollups.first_recorded_at) AS first_recorded_at,
+				GREATEST(groups.last_recorded_at, rollups.last_recorded_at) AS last_recorded_at
+			FROM groups
+			LEFT JOIN metric_value_rollups AS rollups ON rollups.metric_id = groups.metric_id
+				AND rollups.participant_id = groups.participant_id AND rollups.day = @day
+		), compactable AS (
+			SELECT * FROM merged
+			WHERE NOT EXISTS (
+				SELECT 1 FROM leaderboard_metrics
+				JOIN leaderboards ON leaderboards.id = leaderboard_metrics.leaderboard_id AND leaderboards.deleted_at IS NULL
+				WHERE leaderboard_metrics.metric_id = merged.metric_id AND leaderboard_metrics.deleted_at IS NULL
+					AND ((leaderboards.start_date > merged.first_recorded_at AND leaderboards.start_date <= merged.last_recorded_at)
+						OR (leaderboards.end_date >= merged.first_recorded_at AND leaderboards.end_date < merged.last_recorded_at)))
+			AND NOT EXISTS (
+				SELECT 1 FROM team_members
+				WHERE team_members.member_id = merged.participant_id AND team_members.deleted_at IS NULL
+					AND ((team_members.joined_at > merged.first_recorded_at AND team_members.joined_at <= merged.last_recorded_at)
+						OR (team_members.left_at > merged.first_recorded_at AND team_members.left_at <= merged.last_recorded_at)))
+		), rolled AS (
+			INSERT INTO metric_value_rollups
+				(metric_id, participant_id, day, count, sum, min, max, last_value, last_bool_value, last_string_value,
+					first_recorded_at, last_recorded_at)
+			SELECT metric_id, participant_id, @day, count, sum, min, max, last_value, last_bool_value, last_string_value,
+				first_recorded_at, last_recorded_at
+			FROM compactable
+			ON CONFLICT (metric_id, participant_id, day) DO UPDATE SET
+				count = EXCLUDED.count, sum = EXCLUDED.sum, min = EXCLUDED.min, max = EXCLUDED.max,
+				last_value = EXCLUDED.last_value, last_bool_value = EXCLUDED.last_bool_value,
+				last_string_value = EXCLUDED.last_string_value, first_recorded_at = EXCLUDED.first_recorded_at,
+				last_recorded_at = EXCLUDED.last_recorded_at, updated_at = CURRENT_TIMESTAMP
+		)
+		DELETE FROM metric_values USING candidates, compactable
+		WHERE metric_values.id = candidates.id
+			AND candidates.metric_id = compactable.metric_id AND candidates.participant_id = compactable.participant_id`,
+			sql.Named("day", day), sql.Named("next", day.AddDate(0, 0, 1)))
+		compacted = result.RowsAffected
+		return result.Error
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	return compacted, err
+}
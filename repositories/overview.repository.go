@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"leaderboard-service/db"
+
+	"gorm.io/gorm"
+)
+
+// OverviewCounts holds how many live resources of each kind there are
+type OverviewCounts struct {
+	Leaderboards int64 `json:"leaderboards"`
+	Participants int64 `json:"participants"`
+	Entries      int64 `json:"entries"`
+}
+
+// DailyCount is the number of records created on one UTC day
+type DailyCount struct {
+	Day   time.Time `json:"-"`
+	Date  string    `json:"date"` // Day as YYYY-MM-DD
+	Count int64     `json:"count"`
+}
+
+// PoolStats describes this instance's database connection pool
+type PoolStats struct {
+	MaxOpenConnections int   `json:"max_open_connections"` // 0 when unlimited
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`       // Times a query had to wait for a free connection
+	WaitDurationMs     int64 `json:"wait_duration_ms"` // Total time spent waiting for one
+	MaxIdleClosed      int64 `json:"max_idle_closed"`  // Connections closed because too many were idle
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`
+}
+
+// OverviewRepository counts across tables for the admin overview
+type OverviewRepository interface {
+	Counts(ctx context.Context) (*OverviewCounts, error)
+	MetricValuesPerDay(ctx context.Context, since time.Time) ([]DailyCount, error)
+	// PoolStats reports the state of the connection pool since startup
+	PoolStats(ctx context.Context) (*PoolStats, error)
+}
+
+type overviewRepository struct {
+	db *gorm.DB
+}
+
+func NewOverviewRepository() OverviewRepository {
+	return &overviewRepository{
+		db: db.DB,
+	}
+}
+
+// Counts counts the leaderboards, participants and entries that are not soft-deleted, in one round trip
+func (r *overviewRepository) Counts(ctx context.Context) (*OverviewCounts, error) {
+	var counts OverviewCounts
+	err := r.db.WithContext(ctx).Raw(`SELECT
+		(SELECT COUNT(*) FROM leaderboards WHERE deleted_at IS NULL) AS leaderboards,
+		(SELECT COUNT(*) FROM participants WHERE deleted_at IS NULL) AS participants,
+		(SELECT COUNT(*) FROM leaderboard_entries WHERE deleted_at IS NULL) AS entries`).
+		Scan(&counts).Error
+	return &counts, err
+}
+
+// MetricValuesPerDay counts the metric values ingested on each UTC day since the given time, oldest first. Days
+// without any are left out.
+func (r *overviewRepository) MetricValuesPerDay(ctx context.Context, since time.Time) ([]DailyCount, error) {
+	var counts []DailyCount
+	err := r.db.WithContext(ctx).Raw(`SELECT date_trunc('day', created_at AT TIME ZONE 'UTC') AS day, COUNT(*) AS count
+		FROM metric_values
+		WHERE deleted_at IS NULL AND created_at >= ?
+		GROUP BY 1
+		ORDER BY 1`, since).
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *overviewRepository) PoolStats(ctx context.Context) (*PoolStats, error) {
+	sqlDB, err := r.db.WithContext(ctx).DB()
+	if err != nil {
+		return nil, err
+	}
+	stats := sqlDB.Stats()
+	return &PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}, nil
+}
@@ -1,23 +1,51 @@
 package repositories
 
 import (
+	"context"
+
 	"leaderboard-service/db"
 	"leaderboard-service/models"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ParticipantRepository interface {
-	Create(participant *models.Participant) error
-	FindByID(id uuid.UUID) (*models.Participant, error)
-	FindAll() ([]models.Participant, error)
-	Update(participant *models.Participant) error
-	Delete(id uuid.UUID) error
+	Create(ctx context.Context, participant *models.Participant) error
+	// CreateBatch inserts the participants and the first entry of each one's name history with multi-row INSERTs in a
+	// single transaction, so either all of them are stored or none are
+	CreateBatch(ctx context.Context, participants []models.Participant) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Participant, error)
+	// FindByIDForShare reads the participant past the lookup cache and, in a UnitOfWork, keeps it from being updated or
+	// deleted until the transaction ends
+	FindByIDForShare(ctx context.Context, id uuid.UUID) (*models.Participant, error)
+	FindAll(ctx context.Context) ([]models.Participant, error)
+	// FindFiltered returns the participants with the given IDs, or all of them when there are none, that have every
+	// one of the tags, in the given order or oldest first when it is nil. Soft-deleted participants are only returned
+	// when asked for.
+	FindFiltered(ctx context.Context, ids []uuid.UUID, tags []string, sort *Sort, includeDeleted bool) ([]models.Participant, error)
+	// FindExistingIDs returns which of the IDs belong to participants
+	FindExistingIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*models.Participant, error)
+	FindByExternalID(ctx context.Context, externalID string) (*models.Participant, error)
+	// FindByExternalIDs returns the participants with any of the external IDs, without their tags
+	FindByExternalIDs(ctx context.Context, externalIDs []string) ([]models.Participant, error)
+	FindByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]models.Participant, error)
+	// FindAncestors returns every live group above the participants in the hierarchy in one recursive query, up to
+	// the first deleted group on each path
+	FindAncestors(ctx context.Context, ids []uuid.UUID) ([]models.Participant, error)
+	Update(ctx context.Context, participant *models.Participant) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// FindDeletedByID returns the participant if it is soft-deleted
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*models.Participant, error)
+	// Restore undoes the soft delete of a participant
+	Restore(ctx context.Context, id uuid.UUID) error
 }
 
 type participantRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	removals *cacheRemovals // Set in a unit of work, see uncache
 }
 
 func NewParticipantRepository() ParticipantRepository {
@@ -26,29 +54,167 @@ func NewParticipantRepository() ParticipantRepository {
 	}
 }
 
-func (r *participantRepository) Create(participant *models.Participant) error {
-	return r.db.Create(participant).Error
+func (r *participantRepository) Create(ctx context.Context, participant *models.Participant) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(participant).Error
+}
+
+func (r *participantRepository) CreateBatch(ctx context.Context, participants []models.Participant) error {
+	if len(participants) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).CreateInBatches(participants, createBatchSize).Error; err != nil {
+			return err
+		}
+		nameChanges := make([]models.ParticipantNameChange, len(participants))
+		for i, participant := range participants {
+			nameChanges[i] = models.ParticipantNameChange{
+				ParticipantID: participant.ID,
+				Name:          participant.Name,
+				DisplayName:   participant.DisplayName,
+				EffectiveFrom: participant.CreatedAt,
+			}
+		}
+		return tx.CreateInBatches(nameChanges, createBatchSize).Error
+	})
+}
+
+func (r *participantRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Participant, error) {
+	return lookup(r.removals, participantCache, id, func() (*models.Participant, error) {
+		var participant models.Participant
+		err := r.db.WithContext(ctx).Preload("Tags").First(&participant, "id = ?", id).Error
+		if err != nil {
+			return nil, err
+		}
+		return &participant, nil
+	}, copyParticipant)
 }
 
-func (r *participantRepository) FindByID(id uuid.UUID) (*models.Participant, error) {
+func (r *participantRepository) FindByIDForShare(ctx context.Context, id uuid.UUID) (*models.Participant, error) {
 	var participant models.Participant
-	err := r.db.First(&participant, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "SHARE"}).
+		Preload("Tags").First(&participant, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &participant, nil
 }
 
-func (r *participantRepository) FindAll() ([]models.Participant, error) {
+func (r *participantRepository) FindAll(ctx context.Context) ([]models.Participant, error) {
 	var participants []models.Participant
-	err := r.db.Find(&participants).Error
+	err := r.db.WithContext(ctx).Preload("Tags").Find(&participants).Error
 	return participants, err
 }
 
-func (r *participantRepository) Update(participant *models.Participant) error {
-	return r.db.Save(participant).Error
+func (r *participantRepository) FindFiltered(ctx context.Context, ids []uuid.UUID, tags []string, sort *Sort, includeDeleted bool) ([]models.Participant, error) {
+	var participants []models.Participant
+	query := withDeleted(r.db.WithContext(ctx).Preload("Tags"), includeDeleted)
+
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+
+	if len(tags) > 0 {
+		tagged := r.db.WithContext(ctx).Model(&models.ParticipantTag{}).
+			Select("participant_id").
+			Where("tag IN ?", tags).
+			Group("participant_id").
+			Having("COUNT(DISTINCT tag) = ?", len(tags))
+		query = query.Where("id IN (?)", tagged)
+	}
+
+	query, err := ParticipantSortColumns.apply(query, sort, "created_at asc")
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Find(&participants).Error
+	return participants, err
+}
+
+func (r *participantRepository) FindExistingIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	var existing []uuid.UUID
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	err := r.db.WithContext(ctx).Model(&models.Participant{}).Where("id IN ?", ids).Pluck("id", &existing).Error
+	return existing, err
+}
+
+func (r *participantRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*models.Participant, error) {
+	var participant models.Participant
+	err := r.db.WithContext(ctx).Preload("Tags").First(&participant, "user_id = ?", userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+func (r *participantRepository) FindByExternalID(ctx context.Context, externalID string) (*models.Participant, error) {
+	var participant models.Participant
+	err := r.db.WithContext(ctx).Preload("Tags").First(&participant, "external_id = ?", externalID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+func (r *participantRepository) FindByExternalIDs(ctx context.Context, externalIDs []string) ([]models.Participant, error) {
+	var participants []models.Participant
+	if len(externalIDs) == 0 {
+		return participants, nil
+	}
+	err := r.db.WithContext(ctx).Where("external_id IN ?", externalIDs).Find(&participants).Error
+	return participants, err
+}
+
+func (r *participantRepository) FindByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]models.Participant, error) {
+	var participants []models.Participant
+	if len(parentIDs) == 0 {
+		return participants, nil
+	}
+	err := r.db.WithContext(ctx).Where("parent_id IN ?", parentIDs).Order("name asc").Find(&participants).Error
+	return participants, err
+}
+
+func (r *participantRepository) FindAncestors(ctx context.Context, ids []uuid.UUID) ([]models.Participant, error) {
+	var ancestors []models.Participant
+	if len(ids) == 0 {
+		return ancestors, nil
+	}
+	// UNION drops parents already visited, so a cycle in the hierarchy cannot make the recursion run forever
+	err := r.db.WithContext(ctx).Preload("Tags").Where(`id IN (
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_id FROM participants WHERE id IN ? AND parent_id IS NOT NULL
+			UNION
+			SELECT participants.parent_id FROM participants
+				JOIN ancestors ON participants.id = ancestors.parent_id
+				WHERE participants.parent_id IS NOT NULL AND participants.deleted_at IS NULL
+		)
+		SELECT parent_id FROM ancestors
+	)`, ids).Find(&ancestors).Error
+	return ancestors, err
+}
+
+func (r *participantRepository) Update(ctx context.Context, participant *models.Participant) error {
+	defer uncache(r.removals, participantCache, participant.ID)
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(participant).Error
+}
+
+func (r *participantRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer uncache(r.removals, participantCache, id)
+	return r.db.WithContext(ctx).Delete(&models.Participant{}, "id = ?", id).Error
+}
+
+func (r *participantRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*models.Participant, error) {
+	var participant models.Participant
+	if err := findDeleted(r.db.WithContext(ctx), &participant, id); err != nil {
+		return nil, err
+	}
+	return &participant, nil
 }
 
-func (r *participantRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Participant{}, "id = ?", id).Error
+func (r *participantRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	defer uncache(r.removals, participantCache, id)
+	return restore(r.db.WithContext(ctx), &models.Participant{}, id)
 }
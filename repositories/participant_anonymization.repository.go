@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+
+	"gorm.io/gorm"
+)
+
+type ParticipantAnonymizationRepository interface {
+	Create(ctx context.Context, anonymization *models.ParticipantAnonymization) error
+}
+
+type participantAnonymizationRepository struct {
+	db *gorm.DB
+}
+
+func NewParticipantAnonymizationRepository() ParticipantAnonymizationRepository {
+	return &participantAnonymizationRepository{
+		db: db.DB,
+	}
+}
+
+func (r *participantAnonymizationRepository) Create(ctx context.Context, anonymization *models.ParticipantAnonymization) error {
+	return r.db.WithContext(ctx).Create(anonymization).Error
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ParticipantNameChangeRepository interface {
+	Create(ctx context.Context, change *models.ParticipantNameChange) error
+	// FindCurrent returns the participant's name record that has not ended yet
+	FindCurrent(ctx context.Context, participantID uuid.UUID) (*models.ParticipantNameChange, error)
+	// FindByParticipantID returns the participant's name records oldest first
+	FindByParticipantID(ctx context.Context, participantID uuid.UUID) ([]models.ParticipantNameChange, error)
+	Update(ctx context.Context, change *models.ParticipantNameChange) error
+	// DeleteByParticipantID permanently removes the participant's name history
+	DeleteByParticipantID(ctx context.Context, participantID uuid.UUID) error
+}
+
+type participantNameChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewParticipantNameChangeRepository() ParticipantNameChangeRepository {
+	return &participantNameChangeRepository{
+		db: db.DB,
+	}
+}
+
+func (r *participantNameChangeRepository) Create(ctx context.Context, change *models.ParticipantNameChange) error {
+	return r.db.WithContext(ctx).Create(change).Error
+}
+
+func (r *participantNameChangeRepository) FindCurrent(ctx context.Context, participantID uuid.UUID) (*models.ParticipantNameChange, error) {
+	var change models.ParticipantNameChange
+	err := r.db.WithContext(ctx).Where("participant_id = ? AND effective_to IS NULL", participantID).
+		First(&change).Error
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (r *participantNameChangeRepository) FindByParticipantID(ctx context.Context, participantID uuid.UUID) ([]models.ParticipantNameChange, error) {
+	var changes []models.ParticipantNameChange
+	err := r.db.WithContext(ctx).Where("participant_id = ?", participantID).Order("effective_from asc").Find(&changes).Error
+	return changes, err
+}
+
+func (r *participantNameChangeRepository) Update(ctx context.Context, change *models.ParticipantNameChange) error {
+	return r.db.WithContext(ctx).Save(change).Error
+}
+
+func (r *participantNameChangeRepository) DeleteByParticipantID(ctx context.Context, participantID uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&models.ParticipantNameChange{}, "participant_id = ?", participantID).Error
+}
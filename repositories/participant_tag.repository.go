@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ParticipantTagRepository interface {
+	// AddTags tags every participant with every tag, skipping tags they already have, and returns how many were added
+	AddTags(ctx context.Context, participantIDs []uuid.UUID, tags []string) (int64, error)
+	// RemoveTags removes the tags from the participants and returns how many were removed
+	RemoveTags(ctx context.Context, participantIDs []uuid.UUID, tags []string) (int64, error)
+}
+
+type participantTagRepository struct {
+	db *gorm.DB
+}
+
+func NewParticipantTagRepository() ParticipantTagRepository {
+	return &participantTagRepository{
+		db: db.DB,
+	}
+}
+
+func (r *participantTagRepository) AddTags(ctx context.Context, participantIDs []uuid.UUID, tags []string) (int64, error) {
+	rows := make([]models.ParticipantTag, 0, len(participantIDs)*len(tags))
+	for _, participantID := range participantIDs {
+		for _, tag := range tags {
+			rows = append(rows, models.ParticipantTag{ParticipantID: participantID, Tag: tag})
+		}
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	defer participantCache.remove(participantIDs...)
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows)
+	return result.RowsAffected, result.Error
+}
+
+func (r *participantTagRepository) RemoveTags(ctx context.Context, participantIDs []uuid.UUID, tags []string) (int64, error) {
+	if len(participantIDs) == 0 || len(tags) == 0 {
+		return 0, nil
+	}
+
+	// Removed tags are deleted outright so the participant can be tagged again
+	defer participantCache.remove(participantIDs...)
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("participant_id IN ? AND tag IN ?", participantIDs, tags).
+		Delete(&models.ParticipantTag{})
+	return result.RowsAffected, result.Error
+}
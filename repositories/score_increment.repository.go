@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"leaderboard-service/db"
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScoreIncrement is the data of an entry.score_incremented event
+type ScoreIncrement struct {
+	EntryID uuid.UUID `json:"entry_id"`
+	Delta   float64   `json:"delta"`
+}
+
+// IncrementedEntry is an entry whose score was changed by applying increments
+type IncrementedEntry struct {
+	EntryID       uuid.UUID
+	LeaderboardID uuid.UUID
+}
+
+type ScoreIncrementRepository interface {
+	// Record appends the increment to the event log and returns the event's ID; it changes no score until applied
+	Record(ctx context.Context, leaderboardID uuid.UUID, increment ScoreIncrement) (uint64, error)
+	// Apply adds the increments recorded by the events to their entries' scores and marks them applied in one
+	// statement. Increments already applied are skipped, so each one counts exactly once however often it is
+	// applied. It returns the entries whose score changed; deleted entries are left alone.
+	Apply(ctx context.Context, eventIDs []uint64, appliedAt time.Time) ([]IncrementedEntry, error)
+	// FindUnapplied returns the IDs of increments recorded between after and before that were never applied
+	FindUnapplied(ctx context.Context, after, before time.Time, limit int) ([]uint64, error)
+}
+
+type scoreIncrementRepository struct {
+	db *gorm.DB
+}
+
+func NewScoreIncrementRepository() ScoreIncrementRepository {
+	return &scoreIncrementRepository{
+		db: db.DB,
+	}
+}
+
+func (r *scoreIncrementRepository) Record(ctx context.Context, leaderboardID uuid.UUID, increment ScoreIncrement) (uint64, error) {
+	event := models.Event{
+		Type:          enums.EntryScoreIncremented,
+		LeaderboardID: &leaderboardID,
+		Data:          increment,
+		OccurredAt:    time.Now().UTC(),
+	}
+	err := r.db.WithContext(ctx).Create(&event).Error
+	return event.ID, err
+}
+
+func (r *scoreIncrementRepository) Apply(ctx context.Context, eventIDs []uint64, appliedAt time.Time) ([]IncrementedEntry, error) {
+	if len(eventIDs) == 0 {
+		return nil, nil
+	}
+	var entries []IncrementedEntry
+	err := r.db.WithContext(ctx).Raw(`WITH increments AS (
+			SELECT id AS event_id, (data->>'entry_id')::uuid AS entry_id, (data->>'delta')::float8 AS delta
+			FROM events
+			WHERE id IN @event_ids AND type = @type
+		), applied AS (
+			INSERT INTO applied_score_increments (event_id, applied_at)
+			SELECT event_id, @applied_at FROM increments
+			ON CONFLICT (event_id) DO NOTHING
+			RETURNING event_id
+		), deltas AS (
+			SELECT increments.entry_id, SUM(increments.delta) AS delta
+			FROM increments
+			JOIN applied ON applied.event_id = increments.event_id
+			GROUP BY increments.entry_id
+		)
+		UPDATE leaderboard_entries
+		SET score = leaderboard_entries.score + deltas.delta, last_updated = @applied_at, updated_at = @applied_at,
+			version = leaderboard_entries.version + 1
+		FROM deltas
+		WHERE leaderboard_entries.id = deltas.entry_id AND leaderboard_entries.deleted_at IS NULL
+		RETURNING leaderboard_entries.id AS entry_id, leaderboard_entries.leaderboard_id`,
+		sql.Named("event_ids", eventIDs), sql.Named("type", enums.EntryScoreIncremented),
+		sql.Named("applied_at", appliedAt)).
+		Scan(&entries).Error
+	return entries, err
+}
+
+func (r *scoreIncrementRepository) FindUnapplied(ctx context.Context, after, before time.Time, limit int) ([]uint64, error) {
+	var eventIDs []uint64
+	err := r.db.WithContext(ctx).Model(&models.Event{}).
+		Where("type = ? AND created_at > ? AND created_at < ?", enums.EntryScoreIncremented, after, before).
+		Where("NOT EXISTS (SELECT 1 FROM applied_score_increments WHERE applied_score_increments.event_id = events.id)").
+		Order("id").
+		Limit(limit).
+		Pluck("id", &eventIDs).Error
+	return eventIDs, err
+}
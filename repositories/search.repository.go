@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"leaderboard-service/db"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Resource types that can be searched
+const (
+	SearchTypeLeaderboard = "leaderboard"
+	SearchTypeParticipant = "participant"
+	SearchTypeMetric      = "metric"
+)
+
+// SearchTypes lists every searchable resource type, in the order results of equal relevance are listed
+var SearchTypes = []string{SearchTypeLeaderboard, SearchTypeParticipant, SearchTypeMetric}
+
+// SearchResult is a resource whose name matched a search
+type SearchResult struct {
+	Type string    `json:"type"`
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// searchSources holds the query matching each resource type; participants also match on their display name
+var searchSources = map[string]string{
+	SearchTypeLeaderboard: "SELECT 'leaderboard' AS type, id, name FROM leaderboards WHERE deleted_at IS NULL AND name ILIKE @pattern",
+	SearchTypeParticipant: "SELECT 'participant' AS type, id, name FROM participants WHERE deleted_at IS NULL AND (name ILIKE @pattern OR display_name ILIKE @pattern)",
+	SearchTypeMetric:      "SELECT 'metric' AS type, id, name FROM metrics WHERE deleted_at IS NULL AND name ILIKE @pattern",
+}
+
+type SearchRepository interface {
+	Search(ctx context.Context, query string, types []string, limit, offset int) ([]SearchResult, int64, error)
+}
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository() SearchRepository {
+	return &searchRepository{
+		db: db.DB,
+	}
+}
+
+// searchRow is a search result with the number of matches on every page, counted by the same query
+type searchRow struct {
+	SearchResult
+	Total int64
+}
+
+// Search finds resources of the given types whose name contains the query, ignoring case, and counts all the
+// matches. Exact matches come first, then names starting with the query, then the rest by name.
+func (r *searchRepository) Search(ctx context.Context, query string, types []string, limit, offset int) ([]SearchResult, int64, error) {
+	var sources []string
+	for _, searchType := range types {
+		sources = append(sources, searchSources[searchType])
+	}
+
+	results := []SearchResult{}
+	if len(sources) == 0 {
+		return results, 0, nil
+	}
+
+	matches := "(" + strings.Join(sources, " UNION ALL ") + ") AS results"
+	escaped := escapeLike(query)
+	params := map[string]interface{}{
+		"query":   query,
+		"pattern": "%" + escaped + "%",
+		"prefix":  escaped + "%",
+		"limit":   limit,
+		"offset":  offset,
+	}
+
+	var rows []searchRow
+	err := r.db.WithContext(ctx).Raw("SELECT type, id, name, COUNT(*) OVER () AS total FROM "+matches+
+		" ORDER BY CASE WHEN lower(name) = lower(@query) THEN 0 WHEN name ILIKE @prefix THEN 1 ELSE 2 END, name, type, id"+
+		" LIMIT @limit OFFSET @offset", params).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	for _, row := range rows {
+		results = append(results, row.SearchResult)
+		total = row.Total
+	}
+
+	// A page past the last match has no row to carry the count
+	if len(rows) == 0 && offset > 0 {
+		err = r.db.WithContext(ctx).Raw("SELECT COUNT(*) FROM "+matches, params).Scan(&total).Error
+	}
+	return results, total, err
+}
+
+// escapeLike makes LIKE wildcards in user input match literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ServiceTokenRepository interface {
+	Create(ctx context.Context, token *models.ServiceToken) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.ServiceToken, error)
+	FindAll(ctx context.Context) ([]models.ServiceToken, error)
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+type serviceTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceTokenRepository() ServiceTokenRepository {
+	return &serviceTokenRepository{
+		db: db.DB,
+	}
+}
+
+func (r *serviceTokenRepository) Create(ctx context.Context, token *models.ServiceToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *serviceTokenRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.ServiceToken, error) {
+	var token models.ServiceToken
+	err := r.db.WithContext(ctx).First(&token, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *serviceTokenRepository) FindAll(ctx context.Context) ([]models.ServiceToken, error) {
+	var tokens []models.ServiceToken
+	err := r.db.WithContext(ctx).Order("created_at asc").Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *serviceTokenRepository) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.ServiceToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt).Error
+}
+
+func (r *serviceTokenRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.ServiceToken{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
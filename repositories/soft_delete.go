@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// withDeleted lets a query see soft-deleted rows when asked to. Preloaded associations still only load live rows.
+func withDeleted(query *gorm.DB, includeDeleted bool) *gorm.DB {
+	if includeDeleted {
+		return query.Unscoped()
+	}
+	return query
+}
+
+// findDeleted loads the soft-deleted row with the ID into dest, or returns gorm.ErrRecordNotFound when the row is
+// live or does not exist
+func findDeleted(db *gorm.DB, dest interface{}, id uuid.UUID) error {
+	return db.Unscoped().Where("deleted_at IS NOT NULL").First(dest, "id = ?", id).Error
+}
+
+// restore clears deleted_at on the soft-deleted row with the ID, returning gorm.ErrRecordNotFound when there is none
+func restore(db *gorm.DB, model interface{}, id uuid.UUID) error {
+	result := db.Unscoped().Model(model).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// deleteChildren soft-deletes the live rows of the model whose column references the parent, giving them deletedAt so
+// restoreChildren can tell them apart from rows deleted before
+func deleteChildren(db *gorm.DB, model interface{}, column string, parentID uuid.UUID, deletedAt time.Time) error {
+	return db.Model(model).Where(column+" = ?", parentID).Update("deleted_at", deletedAt).Error
+}
+
+// restoreChildren undoes deleteChildren, bringing back only the rows deleted together with the parent at deletedAt
+func restoreChildren(db *gorm.DB, model interface{}, column string, parentID uuid.UUID, deletedAt time.Time) error {
+	return db.Unscoped().Model(model).Where(column+" = ? AND deleted_at = ?", parentID, deletedAt).
+		Update("deleted_at", nil).Error
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Sort orders a list query by one of the fields its repository allows
+type Sort struct {
+	Field string // API name of the field, e.g. "score"
+	Desc  bool
+}
+
+// SortColumns whitelists the fields a list can be sorted by, mapping their API names to table columns.
+// Queries only ever order by a column from the whitelist, never by text from the request.
+type SortColumns map[string]string
+
+var (
+	EntrySortColumns = SortColumns{
+		"rank":         "rank",
+		"score":        "score",
+		"last_updated": "last_updated",
+		"created_at":   "created_at",
+	}
+	MetricValueSortColumns = SortColumns{
+		"timestamp":  "timestamp",
+		"value":      "value",
+		"created_at": "created_at",
+	}
+	ParticipantSortColumns = SortColumns{
+		"name":         "name",
+		"display_name": "display_name",
+		"type":         "type",
+		"created_at":   "created_at",
+		"updated_at":   "updated_at",
+	}
+	LeaderboardSortColumns = SortColumns{
+		"name":       "name",
+		"category":   "category",
+		"start_date": "start_date",
+		"end_date":   "end_date",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	}
+)
+
+// Fields returns the sortable field names in alphabetical order
+func (c SortColumns) Fields() []string {
+	fields := make([]string, 0, len(c))
+	for field := range c {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Valid reports whether the field can be sorted by
+func (c SortColumns) Valid(field string) bool {
+	_, ok := c[field]
+	return ok
+}
+
+// apply orders the query by the sort, falling back to the repository's default order when it is nil.
+// Ties are broken by ID so the order is stable between requests.
+func (c SortColumns) apply(query *gorm.DB, s *Sort, fallback string) (*gorm.DB, error) {
+	if s == nil {
+		return query.Order(fallback), nil
+	}
+
+	column, ok := c[s.Field]
+	if !ok {
+		return nil, errors.New("invalid sort field")
+	}
+	return query.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: s.Desc}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}}), nil
+}
+
+// Keyset is where a page of a sorted list ended: the sorted field's value and the ID of the page's last row
+type Keyset struct {
+	Value interface{}
+	ID    uuid.UUID
+}
+
+// after limits the query to the rows that follow the keyset in the order apply gives the sort, so the next page is
+// read from an index instead of by skipping every row before it. The redundant bound on the column alone is what
+// lets the index be used.
+func (c SortColumns) after(query *gorm.DB, s Sort, keyset Keyset) (*gorm.DB, error) {
+	column, ok := c[s.Field]
+	if !ok {
+		return nil, errors.New("invalid sort field")
+	}
+	bound, past := ">=", ">"
+	if s.Desc {
+		bound, past = "<=", "<"
+	}
+	return query.Where(fmt.Sprintf("%s %s ? AND (%s %s ? OR id > ?)", column, bound, column, past),
+		keyset.Value, keyset.Value, keyset.ID), nil
+}
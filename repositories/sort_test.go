@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"regexp"
+	"testing"
+
+	"leaderboard-service/models"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDB builds queries without a database so their SQL can be checked
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dryRun, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to open dry run database: %v", err)
+	}
+	return dryRun
+}
+
+// keysetClause matches the condition after adds, capturing the column and the two comparisons
+var keysetClause = regexp.MustCompile(`WHERE \((\w+) (>=|<=) \$1 AND \(\w+ (>|<) \$2 OR id > \$3\)`)
+
+type keysetRow struct {
+	value float64
+	id    uuid.UUID
+}
+
+// compare reports whether a OP b for the comparison operators after uses
+func compare(a float64, op string, b float64) bool {
+	switch op {
+	case ">=":
+		return a >= b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case "<":
+		return a < b
+	}
+	return false
+}
+
+func TestSortColumnsAfter(t *testing.T) {
+	lowID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	pageID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	highID := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+
+	testCases := []struct {
+		name           string
+		sort           Sort
+		keyset         Keyset
+		row            keysetRow
+		expectedColumn string
+		expectedFollow bool
+	}{
+		{
+			name:           "Ascending, greater value",
+			sort:           Sort{Field: "value"},
+			keyset:         Keyset{Value: 10.0, ID: pageID},
+			row:            keysetRow{value: 11, id: lowID},
+			expectedColumn: "value",
+			expectedFollow: true,
+		},
+		{
+			name:           "Ascending, smaller value",
+			sort:           Sort{Field: "value"},
+			keyset:         Keyset{Value: 10.0, ID: pageID},
+			row:            keysetRow{value: 9, id: highID},
+			expectedColumn: "value",
+			expectedFollow: false,
+		},
+		{
+			name:           "Ascending, tie with a greater ID",
+			sort:           Sort{Field: "value"},
+			keyset:         Keyset{Value: 10.0, ID: pageID},
+			row:            keysetRow{value: 10, id: highID},
+			expectedColumn: "value",
+			expectedFollow: true,
+		},
+		{
+			name:           "Ascending, tie with a smaller ID",
+			sort:           Sort{Field: "value"},
+			keyset:         Keyset{Value: 10.0, ID: pageID},
+			row:            keysetRow{value: 10, id: lowID},
+			expectedColumn: "value",
+			expectedFollow: false,
+		},
+		{
+			name:           "Ascending, the last row of the page",
+			sort:           Sort{Field: "value"},
+			keyset:         Keyset{Value: 10.0, ID: pageID},
+			row:            keysetRow{value: 10, id: pageID},
+			expectedColumn: "value",
+			expectedFollow: false,
+		},
+		{
+			name:           "Descending, smaller value",
+			sort:           Sort{Field: "value", Desc: true},
+			keyset:         Keyset{Value: 10.0, ID: pageID},
+			row:            keysetRow{value: 9, id: lowID},
+			expectedColumn: "value",
+			expectedFollow: true,
+		},
+		{
+			name:           "Descending, greater value",
+			sort:           Sort{Field: "value", Desc: true},
+			keyset:         Keyset{Value: 10.0, ID: pageID},
+			row:            keysetRow{value: 11, id: highID},
+			expectedColumn: "value",
+			expectedFollow: false,
+		},
+		{
+			// Ties are broken by ID ascending whatever the direction of the sort
+			name:           "Descending, tie with a greater ID",
+			sort:           Sort{Field: "value", Desc: true},
+			keyset:         Keyset{Value: 10.0, ID: pageID},
+			row:            keysetRow{value: 10, id: highID},
+			expectedColumn: "value",
+			expectedFollow: true,
+		},
+		{
+			name:           "Descending, tie with a smaller ID",
+			sort:           Sort{Field: "value", Desc: true},
+			keyset:         Keyset{Value: 10.0, ID: pageID},
+			row:            keysetRow{value: 10, id: lowID},
+			expectedColumn: "value",
+			expectedFollow: false,
+		},
+		{
+			name:           "Field mapped to its column",
+			sort:           Sort{Field: "timestamp", Desc: true},
+			keyset:         Keyset{Value: 10.0, ID: pageID},
+			row:            keysetRow{value: 10, id: highID},
+			expectedColumn: "timestamp",
+			expectedFollow: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := MetricValueSortColumns.after(dryRunDB(t), tc.sort, tc.keyset)
+			if err != nil {
+				t.Fatalf("Expected the keyset condition to be added but got error: %v", err)
+			}
+			statement := query.Find(&[]models.MetricValue{}).Statement
+
+			match := keysetClause.FindStringSubmatch(statement.SQL.String())
+			if match == nil {
+				t.Fatalf("Expected a keyset condition but got %q", statement.SQL.String())
+			}
+			if match[1] != tc.expectedColumn {
+				t.Errorf("Expected the condition to be on %q but got %q", tc.expectedColumn, match[1])
+			}
+			if len(statement.Vars) != 3 || statement.Vars[0] != tc.keyset.Value ||
+				statement.Vars[1] != tc.keyset.Value || statement.Vars[2] != tc.keyset.ID {
+				t.Fatalf("Expected the keyset's value twice and its ID but got %v", statement.Vars)
+			}
+
+			value := tc.keyset.Value.(float64)
+			follows := compare(tc.row.value, match[2], value) &&
+				(compare(tc.row.value, match[3], value) || tc.row.id.String() > tc.keyset.ID.String())
+			if follows != tc.expectedFollow {
+				t.Errorf("Expected the row to follow the keyset to be %v but got %v", tc.expectedFollow, follows)
+			}
+		})
+	}
+}
+
+func TestSortColumnsAfterInvalidField(t *testing.T) {
+	if _, err := MetricValueSortColumns.after(dryRunDB(t), Sort{Field: "id"}, Keyset{ID: uuid.New()}); err == nil {
+		t.Errorf("Expected an error for a field that can't be sorted by")
+	}
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StandingSummaryRepository interface {
+	// Rebuild recomputes the leaderboard's standing aggregates from its metric values and records the version of the
+	// board they reflect. The summary of a leaderboard that no longer exists is removed.
+	Rebuild(ctx context.Context, leaderboardID uuid.UUID) error
+	// FindAggregates returns the participants' standing aggregates for the leaderboard, reporting false when it has
+	// no summary or the summary was built from another version of the board
+	FindAggregates(ctx context.Context, leaderboardID uuid.UUID, version time.Time, participantIDs []uuid.UUID) ([]ParticipantMetricAggregate, bool, error)
+	// FindSummarizedByMetricIDs returns the IDs of the summarized leaderboards that use any of the metrics
+	FindSummarizedByMetricIDs(ctx context.Context, metricIDs []uuid.UUID) ([]uuid.UUID, error)
+}
+
+type standingSummaryRepository struct {
+	db *gorm.DB
+}
+
+func NewStandingSummaryRepository() StandingSummaryRepository {
+	return &standingSummaryRepository{
+		db: db.DB,
+	}
+}
+
+func (r *standingSummaryRepository) Rebuild(ctx context.Context, leaderboardID uuid.UUID) error {
+	// The version and the aggregates are read from the same snapshot, so a summary never claims a version whose
+	// values it has not seen
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		version, err := lastModified(tx, leaderboardID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := tx.Unscoped().Where("leaderboard_id = ?", leaderboardID).Delete(&models.StandingAggregate{}).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Where("leaderboard_id = ?", leaderboardID).Delete(&models.StandingSummary{}).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("leaderboard_id = ?", leaderboardID).Delete(&models.StandingAggregate{}).Error; err != nil {
+			return err
+		}
+		// Same aggregation as AggregateForParticipants, over every participant with an entry on the board
+		err = tx.Exec(`INSERT INTO standing_aggregates
+			(leaderboard_id, participant_id, metric_id, count, sum, min, max, average, last_value, last_bool_value,
+				last_string_value, last_recorded_at)
+		SELECT leaderboards.id, participant_id, metric_id, `+partAggregates+`
+		FROM `+metricValueParts+`
+		JOIN leaderboards ON leaderboards.id = @id
+		WHERE metric_id IN (SELECT metric_id FROM leaderboard_metrics
+				WHERE leaderboard_id = @id AND deleted_at IS NULL)
+			AND participant_id IN (SELECT participant_id FROM leaderboard_entries
+				WHERE leaderboard_id = @id AND deleted_at IS NULL)
+			AND (leaderboards.start_date IS NULL OR first_recorded_at >= leaderboards.start_date)
+			AND (leaderboards.end_date IS NULL OR last_recorded_at <= leaderboards.end_date)
+		GROUP BY leaderboards.id, participant_id, metric_id`, sql.Named("id", leaderboardID)).Error
+		if err != nil {
+			return err
+		}
+
+		summary := models.StandingSummary{LeaderboardID: leaderboardID, Version: version, RefreshedAt: time.Now()}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "leaderboard_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"version", "refreshed_at", "updated_at"}),
+		}).Create(&summary).Error
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+}
+
+func (r *standingSummaryRepository) FindAggregates(ctx context.Context, leaderboardID uuid.UUID, version time.Time,
+	participantIDs []uuid.UUID) ([]ParticipantMetricAggregate, bool, error) {
+
+	var summary models.StandingSummary
+	err := r.db.WithContext(ctx).Where("leaderboard_id = ?", leaderboardID).First(&summary).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if !summary.Version.Equal(version) {
+		return nil, false, nil
+	}
+
+	var aggregates []ParticipantMetricAggregate
+	if len(participantIDs) == 0 {
+		return aggregates, true, nil
+	}
+	err = r.db.WithContext(ctx).Model(&models.StandingAggregate{}).
+		Select("participant_id, metric_id, count, sum, min, max, average, last_value, last_bool_value, last_string_value, "+
+			"last_recorded_at").
+		Where("leaderboard_id = ? AND participant_id IN ?", leaderboardID, participantIDs).
+		Scan(&aggregates).Error
+	if err != nil {
+		return nil, false, err
+	}
+	return aggregates, true, nil
+}
+
+func (r *standingSummaryRepository) FindSummarizedByMetricIDs(ctx context.Context, metricIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var leaderboardIDs []uuid.UUID
+	if len(metricIDs) == 0 {
+		return leaderboardIDs, nil
+	}
+	err := r.db.WithContext(ctx).Model(&models.StandingSummary{}).
+		Where("leaderboard_id IN (?)", r.db.WithContext(ctx).Model(&models.LeaderboardMetric{}).Select("leaderboard_id").Where("metric_id IN ?", metricIDs)).
+		Pluck("leaderboard_id", &leaderboardIDs).Error
+	return leaderboardIDs, err
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TeamMemberRepository interface {
+	Create(ctx context.Context, member *models.TeamMember) error
+	// FindCurrent returns the membership of the member that has not ended yet
+	FindCurrent(ctx context.Context, teamID, memberID uuid.UUID) (*models.TeamMember, error)
+	// FindByTeamID returns the memberships active at the given time, or every membership when at is nil
+	FindByTeamID(ctx context.Context, teamID uuid.UUID, at *time.Time) ([]models.TeamMember, error)
+	// FindByMemberID returns every past and present membership of the member
+	FindByMemberID(ctx context.Context, memberID uuid.UUID) ([]models.TeamMember, error)
+	// FindTeamIDsWithValues returns the teams that have a member with a value for any of the metrics,
+	// recorded in the time range while they were on the team
+	FindTeamIDsWithValues(ctx context.Context, metricIDs []uuid.UUID, fromTime, toTime *time.Time) ([]uuid.UUID, error)
+	Update(ctx context.Context, member *models.TeamMember) error
+}
+
+type teamMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamMemberRepository() TeamMemberRepository {
+	return &teamMemberRepository{
+		db: db.DB,
+	}
+}
+
+func (r *teamMemberRepository) Create(ctx context.Context, member *models.TeamMember) error {
+	return r.db.WithContext(ctx).Create(member).Error
+}
+
+func (r *teamMemberRepository) FindCurrent(ctx context.Context, teamID, memberID uuid.UUID) (*models.TeamMember, error) {
+	var member models.TeamMember
+	err := r.db.WithContext(ctx).Where("team_id = ? AND member_id = ? AND left_at IS NULL", teamID, memberID).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *teamMemberRepository) FindByTeamID(ctx context.Context, teamID uuid.UUID, at *time.Time) ([]models.TeamMember, error) {
+	var members []models.TeamMember
+	query := r.db.WithContext(ctx).Preload("Member").Where("team_id = ?", teamID)
+	if at != nil {
+		query = query.Where("joined_at <= ? AND (left_at IS NULL OR left_at > ?)", *at, *at)
+	}
+	err := query.Order("joined_at asc").Find(&members).Error
+	return members, err
+}
+
+func (r *teamMemberRepository) FindByMemberID(ctx context.Context, memberID uuid.UUID) ([]models.TeamMember, error) {
+	var members []models.TeamMember
+	err := r.db.WithContext(ctx).Where("member_id = ?", memberID).Order("joined_at asc").Find(&members).Error
+	return members, err
+}
+
+func (r *teamMemberRepository) FindTeamIDsWithValues(ctx context.Context, metricIDs []uuid.UUID, fromTime, toTime *time.Time) ([]uuid.UUID, error) {
+	var teamIDs []uuid.UUID
+	if len(metricIDs) == 0 {
+		return teamIDs, nil
+	}
+
+	query := r.db.WithContext(ctx).Model(&models.TeamMember{}).
+		Joins("JOIN "+metricValueParts+" ON metric_value_parts.participant_id = team_members.member_id").
+		Where("metric_value_parts.metric_id IN ?", metricIDs).
+		Where("metric_value_parts.first_recorded_at >= team_members.joined_at").
+		Where("team_members.left_at IS NULL OR metric_value_parts.first_recorded_at < team_members.left_at")
+	if fromTime != nil {
+		query = query.Where("metric_value_parts.first_recorded_at >= ?", *fromTime)
+	}
+	if toTime != nil {
+		query = query.Where("metric_value_parts.last_recorded_at <= ?", *toTime)
+	}
+
+	err := query.Distinct().Pluck("team_members.team_id", &teamIDs).Error
+	return teamIDs, err
+}
+
+func (r *teamMemberRepository) Update(ctx context.Context, member *models.TeamMember) error {
+	return r.db.WithContext(ctx).Save(member).Error
+}
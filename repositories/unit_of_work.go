@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+
+	"leaderboard-service/db"
+
+	"gorm.io/gorm"
+)
+
+// Repositories are the repositories of one transaction, see UnitOfWork
+type Repositories struct {
+	AuditLogs                 AuditLogRepository
+	Events                    EventRepository
+	Leaderboards              LeaderboardRepository
+	LeaderboardEntries        LeaderboardEntryRepository
+	LeaderboardMetrics        LeaderboardMetricRepository
+	Metrics                   MetricRepository
+	MetricValues              MetricValueRepository
+	Participants              ParticipantRepository
+	ParticipantNameChanges    ParticipantNameChangeRepository
+	ParticipantAnonymizations ParticipantAnonymizationRepository
+}
+
+// UnitOfWork runs multi-step writes in a single transaction, so a step that fails leaves none of the others behind
+type UnitOfWork interface {
+	// Do runs fn with repositories that share a transaction, committing it when fn returns nil and rolling it back
+	// when fn returns an error or panics
+	Do(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork() UnitOfWork {
+	return &unitOfWork{
+		db: db.DB,
+	}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos Repositories) error) error {
+	// Cached rows the transaction changes are only removed once it has finished, see cacheRemovals
+	removals := &cacheRemovals{}
+	defer removals.run()
+
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(Repositories{
+			AuditLogs:                 &auditLogRepository{db: tx},
+			Events:                    &eventRepository{db: tx},
+			Leaderboards:              &leaderboardRepository{db: tx, removals: removals},
+			LeaderboardEntries:        &leaderboardEntryRepository{db: tx},
+			LeaderboardMetrics:        &leaderboardMetricRepository{db: tx},
+			Metrics:                   &metricRepository{db: tx, removals: removals},
+			MetricValues:              &metricValueRepository{db: tx},
+			Participants:              &participantRepository{db: tx, removals: removals},
+			ParticipantNameChanges:    &participantNameChangeRepository{db: tx},
+			ParticipantAnonymizations: &participantAnonymizationRepository{db: tx},
+		})
+	})
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrVersionConflict is returned by an update of a row that another update changed since it was read
+var ErrVersionConflict = errors.New("version conflict")
+
+// updateVersioned saves every column of a row read at *version, but only while that is still its stored version,
+// and increments the version. It returns ErrVersionConflict, leaving the version as it was, when another update got
+// there first.
+func updateVersioned(db *gorm.DB, model interface{}, version *int) error {
+	readVersion := *version
+	*version = readVersion + 1
+	result := db.Model(model).Where("version = ?", readVersion).
+		Select("*").Omit("id", "created_at", clause.Associations).Updates(model)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = readVersion
+	}
+	return result.Error
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"leaderboard-service/db"
+	"leaderboard-service/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *models.WebhookSubscription) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error)
+	FindAll(ctx context.Context) ([]models.WebhookSubscription, error)
+	FindActive(ctx context.Context) ([]models.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *models.WebhookSubscription) error
+	RecordDelivery(ctx context.Context, id uuid.UUID, status int, deliveryError string, deliveredAt time.Time) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type webhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookSubscriptionRepository() WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{
+		db: db.DB,
+	}
+}
+
+func (r *webhookSubscriptionRepository) Create(ctx context.Context, subscription *models.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+func (r *webhookSubscriptionRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := r.db.WithContext(ctx).First(&subscription, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *webhookSubscriptionRepository) FindAll(ctx context.Context) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.db.WithContext(ctx).Order("created_at asc").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *webhookSubscriptionRepository) FindActive(ctx context.Context) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.db.WithContext(ctx).Where("is_active = ?", true).Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *webhookSubscriptionRepository) Update(ctx context.Context, subscription *models.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Save(subscription).Error
+}
+
+func (r *webhookSubscriptionRepository) RecordDelivery(ctx context.Context, id uuid.UUID, status int, deliveryError string, deliveredAt time.Time) error {
+	// Only touch the delivery columns so concurrent admin edits are not overwritten
+	return r.db.WithContext(ctx).Model(&models.WebhookSubscription{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_delivery_at":     deliveredAt,
+		"last_delivery_status": status,
+		"last_delivery_error":  deliveryError,
+	}).Error
+}
+
+func (r *webhookSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.WebhookSubscription{}, "id = ?", id).Error
+}
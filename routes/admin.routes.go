@@ -0,0 +1,64 @@
+package router
+
+import (
+	"net/http"
+	"net/http/pprof"
+
+	"leaderboard-service/handlers"
+	"leaderboard-service/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Whether the net/http/pprof handlers are mounted under /admin/debug/pprof
+var profilingEnabled bool
+
+// EnableProfiling mounts the net/http/pprof handlers under /admin/debug/pprof for admins. Call it before Router.
+func EnableProfiling() {
+	profilingEnabled = true
+}
+
+func init() {
+	// Register protected routes
+	RegisterProtectedRoutes(setupAdminRoutes)
+}
+
+// setupAdminRoutes configures the system-wide admin routes
+func setupAdminRoutes(r chi.Router) {
+	adminHandler := handlers.NewAdminHandler()
+	auditLogHandler := handlers.NewAuditLogHandler()
+	featureFlagHandler := handlers.NewFeatureFlagHandler()
+	maintenanceHandler := handlers.NewMaintenanceHandler()
+
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(middleware.RequireRole(middleware.RoleAdmin))
+
+		r.Get("/overview", adminHandler.GetOverview)
+		r.Get("/audit-logs", auditLogHandler.ListAuditLogs)
+
+		r.Get("/feature-flags", featureFlagHandler.ListFeatureFlags)
+		r.Put("/feature-flags/{name}", featureFlagHandler.SetFeatureFlag)
+		r.Delete("/feature-flags/{name}", featureFlagHandler.ResetFeatureFlag)
+
+		r.Get("/maintenance", maintenanceHandler.GetMaintenance)
+		r.Put("/maintenance", maintenanceHandler.SetMaintenance)
+
+		if profilingEnabled {
+			r.Route("/debug/pprof", setupProfilingRoutes)
+		}
+	})
+}
+
+// setupProfilingRoutes mounts the pprof handlers. pprof.Index only serves profiles under /debug/pprof/, so the named
+// profiles are routed to pprof.Handler here instead; the index page links to them relatively.
+func setupProfilingRoutes(r chi.Router) {
+	r.Get("/", pprof.Index)
+	r.Get("/cmdline", pprof.Cmdline)
+	r.Get("/profile", pprof.Profile)
+	r.Get("/symbol", pprof.Symbol)
+	r.Post("/symbol", pprof.Symbol)
+	r.Get("/trace", pprof.Trace)
+	r.Get("/{profile}", func(w http.ResponseWriter, r *http.Request) {
+		pprof.Handler(chi.URLParam(r, "profile")).ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"leaderboard-service/handlers"
+	"leaderboard-service/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func init() {
+	// Register protected routes
+	RegisterProtectedRoutes(setupAPIKeyRoutes)
+}
+
+// setupAPIKeyRoutes configures all routes related to API keys
+func setupAPIKeyRoutes(r chi.Router) {
+	apiKeyHandler := handlers.NewAPIKeyHandler()
+
+	// API key routes - keys grant roles to other services so they are admin-only
+	r.Route("/api-keys", func(r chi.Router) {
+		r.Use(middleware.RequireScope(middleware.ScopeAPIKeysAdmin))
+		r.Get("/", apiKeyHandler.ListAPIKeys)
+		r.Post("/", apiKeyHandler.CreateAPIKey)
+		r.Delete("/{id}", apiKeyHandler.RevokeAPIKey)
+	})
+}
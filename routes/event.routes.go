@@ -0,0 +1,20 @@
+package router
+
+import (
+	"leaderboard-service/handlers"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func init() {
+	// Register protected routes
+	RegisterProtectedRoutes(setupEventRoutes)
+}
+
+// setupEventRoutes configures the event log polling routes
+func setupEventRoutes(r chi.Router) {
+	eventHandler := handlers.NewEventHandler()
+
+	// Event log routes - any authenticated user can poll the events of the leaderboards they can read
+	r.Get("/events", eventHandler.ListEvents)
+}
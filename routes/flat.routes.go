@@ -1,6 +1,7 @@
 package router
 
 import (
+	"leaderboard-service/enums"
 	"leaderboard-service/handlers"
 	"leaderboard-service/middleware"
 
@@ -16,6 +17,8 @@ func init() {
 func setupFlatRoutes(r chi.Router) {
 	metricValueHandler := handlers.NewMetricValueHandler()
 	leaderboardEntryHandler := handlers.NewLeaderboardEntryHandler()
+	importHandler := handlers.NewImportHandler()
+	leaderboardMetricHandler := handlers.NewLeaderboardMetricHandler()
 
 	// Metric Value routes (flat)
 	r.Route("/metric-values", func(r chi.Router) {
@@ -23,10 +26,11 @@ func setupFlatRoutes(r chi.Router) {
 		r.Get("/", metricValueHandler.ListMetricValues)
 		r.Get("/{id}", metricValueHandler.GetMetricValue)
 
-		// Admin-only endpoints
+		// Metric ingestion endpoints
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.RequireAnyRole(middleware.RoleAdmin, middleware.RoleModerator))
+			r.Use(middleware.RequireScope(middleware.ScopeMetricsWrite))
 			r.Post("/", metricValueHandler.CreateMetricValue)
+			r.Post("/import", importHandler.ImportMetricValues) // Bulk CSV ingestion
 			r.Put("/{id}", metricValueHandler.UpdateMetricValue)
 			r.Delete("/{id}", metricValueHandler.DeleteMetricValue)
 		})
@@ -34,31 +38,45 @@ func setupFlatRoutes(r chi.Router) {
 
 	// LeaderboardEntry routes (flat)
 	r.Route("/leaderboard-entries", func(r chi.Router) {
-		// Public endpoints
+		// Public endpoints - entries of private leaderboards require access
 		r.Get("/", leaderboardEntryHandler.ListLeaderboardEntries)
-		r.Get("/{id}", leaderboardEntryHandler.GetLeaderboardEntry)
+		r.With(middleware.RequireLeaderboardRead(middleware.LeaderboardFromEntry("id"))).Get("/{id}", leaderboardEntryHandler.GetLeaderboardEntry)
 
-		// Admin-only endpoints
+		// Leaderboard admin endpoints - the target leaderboard is only known from the body
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.RequireAnyRole(middleware.RoleAdmin, middleware.RoleModerator))
+			r.Use(middleware.RequireScope(middleware.ScopeLeaderboardsAdmin))
 			r.Post("/", leaderboardEntryHandler.CreateLeaderboardEntry)
+		})
+
+		// Leaderboard owner and editor endpoints
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireLeaderboardRole(middleware.LeaderboardFromEntry("id"), enums.LeaderboardOwner, enums.LeaderboardEditor))
 			r.Put("/{id}", leaderboardEntryHandler.UpdateLeaderboardEntry)
+			r.Post("/{id}/increment", leaderboardEntryHandler.IncrementScore)
 			r.Delete("/{id}", leaderboardEntryHandler.DeleteLeaderboardEntry)
 		})
+
+		// Admins can bring back soft-deleted entries, which the leaderboard role checks can no longer find
+		r.With(middleware.RequireRole(middleware.RoleAdmin)).Post("/{id}/restore", leaderboardEntryHandler.RestoreLeaderboardEntry)
 	})
 
 	// LeaderboardMetric routes (flat)
 	r.Route("/leaderboard-metrics", func(r chi.Router) {
-		// Public endpoints
-		r.Get("/", handlers.ListLeaderboardMetrics)
-		r.Get("/{id}", handlers.GetLeaderboardMetric)
+		// Public endpoints - metrics of private leaderboards require access
+		r.Get("/", leaderboardMetricHandler.ListLeaderboardMetrics)
+		r.With(middleware.RequireLeaderboardRead(middleware.LeaderboardFromLeaderboardMetric("id"))).Get("/{id}", leaderboardMetricHandler.GetLeaderboardMetric)
+
+		// Leaderboard admin endpoints - the target leaderboard is only known from the body
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireScope(middleware.ScopeLeaderboardsAdmin))
+			r.Post("/", leaderboardMetricHandler.CreateLeaderboardMetric)
+		})
 
-		// Admin-only endpoints
+		// Leaderboard owner and editor endpoints
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.RequireAnyRole(middleware.RoleAdmin, middleware.RoleModerator))
-			r.Post("/", handlers.CreateLeaderboardMetric)
-			r.Put("/{id}", handlers.UpdateLeaderboardMetric)
-			r.Delete("/{id}", handlers.DeleteLeaderboardMetric)
+			r.Use(middleware.RequireLeaderboardRole(middleware.LeaderboardFromLeaderboardMetric("id"), enums.LeaderboardOwner, enums.LeaderboardEditor))
+			r.Put("/{id}", leaderboardMetricHandler.UpdateLeaderboardMetric)
+			r.Delete("/{id}", leaderboardMetricHandler.DeleteLeaderboardMetric)
 		})
 	})
 }
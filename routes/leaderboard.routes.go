@@ -1,6 +1,7 @@
 package router
 
 import (
+	"leaderboard-service/enums"
 	"leaderboard-service/handlers"
 	"leaderboard-service/middleware"
 
@@ -8,37 +9,105 @@ import (
 )
 
 func init() {
-	// Register protected routes
+	// Register public and protected routes
+	RegisterPublicRoutes(setupPublicLeaderboardRoutes)
 	RegisterProtectedRoutes(setupLeaderboardRoutes)
 }
 
+// setupPublicLeaderboardRoutes configures the unauthenticated reads of public leaderboards, whose responses are the
+// same for every caller and can be kept by shared caches
+func setupPublicLeaderboardRoutes(r chi.Router) {
+	leaderboardHandler := handlers.NewLeaderboardHandler()
+	leaderboardEntryHandler := handlers.NewLeaderboardEntryHandler()
+
+	// Anonymous callers can only read public leaderboards; private ones respond with 404
+	fromURL := middleware.LeaderboardFromURLParam("id")
+	canRead := middleware.RequireLeaderboardRead(fromURL)
+	cacheable := middleware.PublicLeaderboardCacheHeaders(fromURL)
+
+	r.Route("/public/leaderboards", func(r chi.Router) {
+		r.Use(canRead, cacheable, middleware.ETag)
+		r.Get("/{id}", leaderboardHandler.GetPublicLeaderboard)
+		r.Get("/{id}/entries", leaderboardEntryHandler.ListPublicLeaderboardEntries)
+	})
+}
+
 // setupLeaderboardRoutes configures all routes related to leaderboards
 func setupLeaderboardRoutes(r chi.Router) {
 	leaderboardHandler := handlers.NewLeaderboardHandler()
 	leaderboardEntryHandler := handlers.NewLeaderboardEntryHandler()
+	leaderboardMetricHandler := handlers.NewLeaderboardMetricHandler()
+	changeFeedHandler := handlers.NewChangeFeedHandler()
+	memberHandler := handlers.NewLeaderboardMemberHandler()
+	standingsHandler := handlers.NewStandingsHandler()
+	snapshotHandler := handlers.NewLeaderboardSnapshotHandler()
+
+	// Leaderboard permissions are checked against ownership and membership; the leaderboards:admin scope bypasses them
+	fromURL := middleware.LeaderboardFromURLParam("id")
+	canEdit := middleware.RequireLeaderboardRole(fromURL, enums.LeaderboardOwner, enums.LeaderboardEditor)
+	isOwner := middleware.RequireLeaderboardRole(fromURL, enums.LeaderboardOwner)
+	canRead := middleware.RequireLeaderboardRead(fromURL)
+	cacheable := middleware.LeaderboardCacheHeaders(fromURL)
 
 	// Leaderboard routes
 	r.Route("/leaderboards", func(r chi.Router) {
-		// Public leaderboard endpoints - any authenticated user can access
+		// Any authenticated user can list leaderboards; private ones are filtered by access
 		r.Get("/", leaderboardHandler.ListLeaderboards)
-		r.Get("/{id}", leaderboardHandler.GetLeaderboard)
 
-		// Nested routes for leaderboard entries
-		r.Get("/{id}/entries", leaderboardEntryHandler.ListLeaderboardEntries) // Get all entries for a specific leaderboard
+		// Read endpoints - private leaderboards require access
+		r.Group(func(r chi.Router) {
+			r.Use(canRead)
+			// Polling clients and caches get 304 Not Modified while the leaderboard and its standings are unchanged
+			r.With(cacheable, middleware.ETag).Get("/{id}", leaderboardHandler.GetLeaderboard)
+
+			// Nested routes for leaderboard entries
+			r.With(cacheable, middleware.ETag).Get("/{id}/entries", leaderboardEntryHandler.ListLeaderboardEntries) // Get all entries for a specific leaderboard
+
+			// Resumable feed of entry mutations for incremental sync
+			r.Get("/{id}/changes", changeFeedHandler.ListLeaderboardChanges)
 
-		// Nested routes for leaderboard metrics
-		r.Get("/{id}/metrics", handlers.ListLeaderboardMetrics) // Get all metrics for a specific leaderboard
+			// Nested routes for leaderboard metrics
+			r.Get("/{id}/metrics", leaderboardMetricHandler.ListLeaderboardMetrics) // Get all metrics for a specific leaderboard
 
-		// Admin-only leaderboard endpoints
+			// Entries with their participants, tiers, trends and metric aggregates, or rolled up to a level of the
+			// participant group hierarchy with ?level=
+			r.With(cacheable).Get("/{id}/standings", standingsHandler.ListStandings)
+
+			// Standings as a CSV, JSON or XLSX download, live or from a snapshot
+			r.Get("/{id}/export", standingsHandler.ExportStandings)
+			r.Get("/{id}/snapshots", snapshotHandler.ListLeaderboardSnapshots)
+			r.Get("/{id}/snapshots/{snapshotId}", snapshotHandler.GetLeaderboardSnapshot)
+			r.Get("/{id}/snapshots/{a}/diff/{b}", snapshotHandler.DiffLeaderboardSnapshots) // Rank and score movement, e.g. week over week
+		})
+
+		// Any authenticated user can create a leaderboard and becomes its owner
+		r.With(middleware.RequireScope(middleware.ScopeLeaderboardsWrite)).Post("/", leaderboardHandler.CreateLeaderboard)
+
+		// Admins can bring back soft-deleted leaderboards, which the ownership checks can no longer find
+		r.With(middleware.RequireRole(middleware.RoleAdmin)).Post("/{id}/restore", leaderboardHandler.RestoreLeaderboard)
+
+		// Owner and editor endpoints
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.RequireAnyRole(middleware.RoleAdmin, middleware.RoleModerator))
-			r.Post("/", leaderboardHandler.CreateLeaderboard)
+			r.Use(canEdit)
 			r.Put("/{id}", leaderboardHandler.UpdateLeaderboard)
-			r.Delete("/{id}", leaderboardHandler.DeleteLeaderboard)
 
-			// Admin-only nested routes
-			r.Post("/{id}/entries", leaderboardEntryHandler.CreateLeaderboardEntry) // Create entry for a specific leaderboard
-			r.Post("/{id}/metrics", handlers.CreateLeaderboardMetric)               // Associate a metric with a leaderboard
+			// Nested routes
+			r.Post("/{id}/entries", leaderboardEntryHandler.CreateLeaderboardEntry)   // Create entry for a specific leaderboard
+			r.Post("/{id}/metrics", leaderboardMetricHandler.CreateLeaderboardMetric) // Associate a metric with a leaderboard
+			r.Get("/{id}/members", memberHandler.ListLeaderboardMembers)
+			r.Get("/{id}/access/participants", memberHandler.ListLeaderboardParticipantAccess)
+			r.Post("/{id}/recompute", leaderboardHandler.RecomputeTeamScores)    // Rebuild team scores from member metric values
+			r.Post("/{id}/snapshots", snapshotHandler.CreateLeaderboardSnapshot) // Save the current standings for later export
+		})
+
+		// Owner-only endpoints
+		r.Group(func(r chi.Router) {
+			r.Use(isOwner)
+			r.Delete("/{id}", leaderboardHandler.DeleteLeaderboard)
+			r.Put("/{id}/members/{userId}", memberHandler.SetLeaderboardMember)
+			r.Delete("/{id}/members/{userId}", memberHandler.RemoveLeaderboardMember)
+			r.Put("/{id}/access/participants/{participantId}", memberHandler.GrantLeaderboardParticipantAccess)
+			r.Delete("/{id}/access/participants/{participantId}", memberHandler.RevokeLeaderboardParticipantAccess)
 		})
 	})
 }
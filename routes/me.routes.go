@@ -0,0 +1,24 @@
+package router
+
+import (
+	"leaderboard-service/handlers"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func init() {
+	// Register protected routes
+	RegisterProtectedRoutes(setupMeRoutes)
+}
+
+// setupMeRoutes configures the routes scoped to the authenticated user's participant
+func setupMeRoutes(r chi.Router) {
+	meHandler := handlers.NewMeHandler()
+
+	r.Route("/me", func(r chi.Router) {
+		r.Get("/", meHandler.GetMyParticipant)
+		r.Get("/entries", meHandler.ListMyEntries)
+		r.Get("/rank/{leaderboard_id}", meHandler.GetMyRank)
+		r.Get("/metric-values", meHandler.ListMyMetricValues)
+	})
+}
@@ -16,6 +16,7 @@ func init() {
 func setupMetricRoutes(r chi.Router) {
 	metricHandler := handlers.NewMetricHandler()
 	metricValueHandler := handlers.NewMetricValueHandler()
+	metricAggregationHandler := handlers.NewMetricAggregationHandler()
 
 	// Metric routes
 	r.Route("/metrics", func(r chi.Router) {
@@ -24,16 +25,18 @@ func setupMetricRoutes(r chi.Router) {
 		r.Get("/{id}", metricHandler.GetMetric)
 
 		// Nested routes for metric values
-		r.Get("/{id}/values", metricValueHandler.ListMetricValues) // Get all values for a specific metric
+		r.Get("/{id}/values", metricValueHandler.ListMetricValues)         // Get all values for a specific metric
+		r.Get("/{id}/aggregate", metricAggregationHandler.AggregateMetric) // Aggregate a metric's values server-side
 
-		// Admin-only metric endpoints
+		// Metric management endpoints
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.RequireAnyRole(middleware.RoleAdmin, middleware.RoleModerator))
+			r.Use(middleware.RequireScope(middleware.ScopeMetricsWrite))
 			r.Post("/", metricHandler.CreateMetric)
 			r.Put("/{id}", metricHandler.UpdateMetric)
 			r.Delete("/{id}", metricHandler.DeleteMetric)
+			r.With(middleware.RequireRole(middleware.RoleAdmin)).Post("/{id}/restore", metricHandler.RestoreMetric) // Admins can bring back soft-deleted metrics
 
-			// Admin-only nested routes
+			// Nested routes
 			r.Post("/{id}/values", metricValueHandler.CreateMetricValue) // Create a new value for a specific metric
 		})
 	})
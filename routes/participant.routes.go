@@ -16,25 +16,61 @@ func init() {
 func setupParticipantRoutes(r chi.Router) {
 	participantHandler := handlers.NewParticipantHandler()
 	metricValueHandler := handlers.NewMetricValueHandler()
+	deviceTokenHandler := handlers.NewDeviceTokenHandler()
+	teamMemberHandler := handlers.NewTeamMemberHandler()
+	groupHandler := handlers.NewParticipantGroupHandler()
+	anonymizationHandler := handlers.NewParticipantAnonymizationHandler()
+	statsHandler := handlers.NewParticipantStatsHandler()
+	tagHandler := handlers.NewParticipantTagHandler()
+	aggregationHandler := handlers.NewMetricAggregationHandler()
+	importHandler := handlers.NewImportHandler()
 
 	// Participant routes
 	r.Route("/participants", func(r chi.Router) {
 		// Public participant endpoints - any authenticated user can access
 		r.Get("/", participantHandler.ListParticipants)
 		r.Get("/{id}", participantHandler.GetParticipant)
+		r.Get("/{id}/history", participantHandler.GetParticipantHistory)      // Names the participant has used over time
+		r.Get("/{id}/stats", statsHandler.GetParticipantStats)                // Metric aggregates, ranks and activity in one call
+		r.Get("/{id}/leaderboards", statsHandler.ListParticipantLeaderboards) // Rank, tier and trend on every leaderboard
+		r.Get("/by-external-id/{external_id}", participantHandler.GetParticipantByExternalID)
 
 		// Nested routes for participant's metric values
-		r.Get("/{id}/metric-values", metricValueHandler.ListMetricValues) // Get all metric values for a specific participant
+		r.Get("/{id}/metric-values", metricValueHandler.ListMetricValues)                          // Get all metric values for a specific participant
+		r.Get("/{id}/metrics/{metric_id}/timeseries", aggregationHandler.GetParticipantTimeseries) // Values per interval for charting
 
-		// Admin-only participant endpoints
+		// Team rosters
+		r.Get("/{id}/members", teamMemberHandler.ListTeamMembers)
+
+		// Group hierarchy
+		r.Get("/{id}/children", groupHandler.ListParticipantChildren)
+
+		// Push notification devices registered for a participant
+		r.Get("/{id}/device-tokens", deviceTokenHandler.ListDeviceTokens)
+		r.Post("/{id}/device-tokens", deviceTokenHandler.RegisterDeviceToken)
+		r.Delete("/{id}/device-tokens/{tokenId}", deviceTokenHandler.DeleteDeviceToken)
+
+		// Participant management endpoints
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.RequireAnyRole(middleware.RoleAdmin, middleware.RoleModerator))
+			r.Use(middleware.RequireScope(middleware.ScopeParticipantsWrite))
 			r.Post("/", participantHandler.CreateParticipant)
+			r.Post("/import", importHandler.ImportParticipants) // Bulk CSV creation
 			r.Put("/{id}", participantHandler.UpdateParticipant)
 			r.Delete("/{id}", participantHandler.DeleteParticipant)
-
-			// Admin-only nested routes
-			r.Post("/{id}/metric-values", metricValueHandler.CreateMetricValue) // Record a new metric value for a participant
+			r.Post("/{id}/members", teamMemberHandler.AddTeamMember)
+			r.Delete("/{id}/members/{memberId}", teamMemberHandler.RemoveTeamMember)
+			r.Put("/{id}/parent", groupHandler.SetParticipantParent)
+			r.Delete("/{id}/parent", groupHandler.ClearParticipantParent)
+			r.Post("/{id}/anonymize", anonymizationHandler.AnonymizeParticipant) // Irreversibly scrub personal data
+			r.Post("/tags/add", tagHandler.TagParticipants)
+			r.Post("/tags/remove", tagHandler.UntagParticipants)
 		})
+
+		// Admins can bring back soft-deleted participants
+		r.With(middleware.RequireRole(middleware.RoleAdmin)).Post("/{id}/restore", participantHandler.RestoreParticipant)
+
+		// Metric ingestion for a participant
+		r.With(middleware.RequireScope(middleware.ScopeMetricsWrite)).
+			Post("/{id}/metric-values", metricValueHandler.CreateMetricValue) // Record a new metric value for a participant
 	})
 }
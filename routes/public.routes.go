@@ -6,16 +6,22 @@ import (
 	"leaderboard-service/handlers"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 func init() {
-	// Register public routes
+	// Register unversioned and public routes
+	RegisterRootRoutes(setupRootRoutes)
 	RegisterPublicRoutes(setupPublicRoutes)
 }
 
-// setupPublicRoutes configures all routes that do not require authentication
-func setupPublicRoutes(r chi.Router) {
+// setupRootRoutes configures the unversioned routes that stay at the root whatever the API version
+func setupRootRoutes(r chi.Router) {
+	authHandler := handlers.NewAuthHandler()
+	healthHandler := handlers.NewHealthHandler()
+	openAPIHandler := handlers.NewOpenAPIHandler(r) // Walks the root router, so it sees the versions mounted later
+
 	r.Group(func(r chi.Router) {
 		// Base routes
 		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
@@ -25,13 +31,36 @@ func setupPublicRoutes(r chi.Router) {
 			w.WriteHeader(http.StatusOK)
 		})
 
+		// Probes for Kubernetes and load balancers
+		r.Get("/healthz", healthHandler.Liveness)
+		r.Get("/readyz", healthHandler.Readiness)
+
+		// Prometheus metrics
+		r.Handle("/metrics", promhttp.Handler())
+
 		// Swagger documentation
 		r.Get("/swagger/*", httpSwagger.Handler(
 			httpSwagger.URL("/swagger/doc.json"), // The URL pointing to API definition
 		))
 
+		// OpenAPI document generated from the registered routes and request structs
+		r.Get("/openapi.json", openAPIHandler.GetOpenAPI)
+
+		// Token verifiers look for the signing keys at the well-known location
+		r.Get("/.well-known/jwks.json", authHandler.JWKS)
+	})
+}
+
+// setupPublicRoutes configures all routes that do not require authentication
+func setupPublicRoutes(r chi.Router) {
+	authHandler := handlers.NewAuthHandler()
+
+	r.Group(func(r chi.Router) {
 		// Authentication routes
-		r.Post("/auth/login", handlers.Login)
-		r.Post("/auth/register", handlers.Register)
+		r.Post("/auth/login", authHandler.Login)
+		r.Post("/auth/register", authHandler.Register)
+		r.Post("/auth/password-reset/request", authHandler.RequestPasswordReset)
+		r.Post("/auth/password-reset/confirm", authHandler.ConfirmPasswordReset)
+		r.Get("/.well-known/jwks.json", authHandler.JWKS)
 	})
 }
@@ -1,7 +1,9 @@
 package router
 
 import (
+	"context"
 	"net/http"
+	"sort"
 
 	"leaderboard-service/middleware"
 
@@ -12,26 +14,63 @@ import (
 // Define types for route setup functions for better organization
 type RouteSetupFunc func(r chi.Router)
 
-// RouteGroups holds all the route setup functions
+// APIVersion names a version of the API; its routes are mounted under /api/<version>
+type APIVersion string
+
+const (
+	V1 APIVersion = "v1"
+
+	// LegacyVersion is served at the original unversioned paths, e.g. /leaderboards for /api/v1/leaderboards
+	LegacyVersion = V1
+)
+
+// RouteGroups holds all the route setup functions of an API version
 type RouteGroups struct {
 	Public    []RouteSetupFunc
 	Protected []RouteSetupFunc
 }
 
 // Global route groups that will be populated by init() functions in other files
-var routes = RouteGroups{
-	Public:    []RouteSetupFunc{},
-	Protected: []RouteSetupFunc{},
+var (
+	// Unversioned routes mounted at the root: health checks, docs and well-known endpoints
+	rootRoutes = []RouteSetupFunc{}
+	versions   = map[APIVersion]*RouteGroups{}
+)
+
+// RegisterRootRoutes adds route setup functions to the unversioned routes at the root
+func RegisterRootRoutes(setupFunc RouteSetupFunc) {
+	rootRoutes = append(rootRoutes, setupFunc)
 }
 
-// RegisterPublicRoutes adds route setup functions to the public routes list
+// RegisterPublicRoutes adds route setup functions to the v1 public routes list
 func RegisterPublicRoutes(setupFunc RouteSetupFunc) {
-	routes.Public = append(routes.Public, setupFunc)
+	RegisterVersionedPublicRoutes(V1, setupFunc)
 }
 
-// RegisterProtectedRoutes adds route setup functions to the protected routes list
+// RegisterProtectedRoutes adds route setup functions to the v1 protected routes list
 func RegisterProtectedRoutes(setupFunc RouteSetupFunc) {
-	routes.Protected = append(routes.Protected, setupFunc)
+	RegisterVersionedProtectedRoutes(V1, setupFunc)
+}
+
+// RegisterVersionedPublicRoutes adds route setup functions to the public routes list of an API version
+func RegisterVersionedPublicRoutes(version APIVersion, setupFunc RouteSetupFunc) {
+	groups := routeGroups(version)
+	groups.Public = append(groups.Public, setupFunc)
+}
+
+// RegisterVersionedProtectedRoutes adds route setup functions to the protected routes list of an API version
+func RegisterVersionedProtectedRoutes(version APIVersion, setupFunc RouteSetupFunc) {
+	groups := routeGroups(version)
+	groups.Protected = append(groups.Protected, setupFunc)
+}
+
+func routeGroups(version APIVersion) *RouteGroups {
+	groups, ok := versions[version]
+	if !ok {
+		groups = &RouteGroups{}
+		versions[version] = groups
+	}
+	return groups
 }
 
 // Router creates and configures the main router
@@ -40,25 +79,87 @@ func Router() http.Handler {
 
 	// Basic middleware for all routes
 	r.Use(chimiddleware.RequestID)
+	r.Use(middleware.PeerAddress) // Keep the connection's address for ClientIP before RealIP replaces it
 	r.Use(chimiddleware.RealIP)
 	r.Use(middleware.RequestLogger) // Our custom request logger
+	r.Use(middleware.Metrics)       // Request counts and latencies for /metrics
 	r.Use(chimiddleware.Recoverer)
+	r.Use(middleware.DebugLogger)      // Log a sample of requests and responses in full when DEBUG_LOG_SAMPLE_RATE is set
+	r.Use(middleware.MaintenanceMode)  // Reject writes with 503 while maintenance_mode is on
+	r.Use(middleware.StatementTimeout) // Cancel queries still running after DB_STATEMENT_TIMEOUT
+	r.Use(middleware.ResponseEnvelope) // Wrap lists in { data, meta } when X-Response-Envelope is sent
+	r.Use(middleware.SparseFields)     // Trim responses to ?fields= when given
 
-	// Mount public routes
-	for _, setupFunc := range routes.Public {
+	// Mount unversioned routes
+	for _, setupFunc := range rootRoutes {
 		setupFunc(r)
 	}
 
-	// Protected routes - require JWT authentication
+	// Mount each API version under its own prefix
+	names := make([]string, 0, len(versions))
+	for version := range versions {
+		names = append(names, string(version))
+	}
+	sort.Strings(names)
+
+	versionRouters := make(map[APIVersion]chi.Router, len(versions))
+	for _, name := range names {
+		version := APIVersion(name)
+		versionRouters[version] = versionRouter(versions[version])
+		r.Mount("/api/"+name, versionRouters[version])
+	}
+
+	// Compatibility shim: paths that are not versioned keep working against the legacy version
+	if legacy, ok := versionRouters[LegacyVersion]; ok {
+		r.NotFound(legacyRoutes(legacy))
+	}
+
+	return r
+}
+
+// versionRouter builds the routes of one API version
+func versionRouter(groups *RouteGroups) chi.Router {
+	r := chi.NewRouter()
+
+	// Keep chi's default 404s; otherwise the root router's legacy shim would be inherited and route back to itself
+	r.NotFound(http.NotFound)
+
+	// Mount public routes
 	r.Group(func(r chi.Router) {
-		// Apply JWT authentication middleware
-		// r.Use(middleware.JWTAuth)
+		r.Use(middleware.FieldRedaction) // Anonymous callers never see internal fields
+
+		for _, setupFunc := range groups.Public {
+			setupFunc(r)
+		}
+	})
+
+	// Protected routes - require a JWT or an API key
+	r.Group(func(r chi.Router) {
+		// Apply authentication middleware
+		r.Use(middleware.Authenticate)
+		r.Use(middleware.FieldRedaction) // Hide internal fields from callers that are not admins
 
 		// Mount all protected routes
-		for _, setupFunc := range routes.Protected {
+		for _, setupFunc := range groups.Protected {
 			setupFunc(r)
 		}
 	})
 
 	return r
 }
+
+// legacyRoutes serves unversioned requests from the legacy version's routes, marking the responses as deprecated
+// and pointing at the versioned path that replaces them
+func legacyRoutes(legacy chi.Router) http.HandlerFunc {
+	prefix := "/api/" + string(LegacyVersion)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if legacy.Match(chi.NewRouteContext(), r.Method, r.URL.Path) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", "<"+prefix+r.URL.Path+`>; rel="successor-version"`)
+		}
+
+		// Route from scratch so the legacy router does not pick up the root router's state
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, (*chi.Context)(nil)))
+		legacy.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,21 @@
+package router
+
+import (
+	"leaderboard-service/handlers"
+	"leaderboard-service/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func init() {
+	// Register protected routes
+	RegisterProtectedRoutes(setupSearchRoutes)
+}
+
+// setupSearchRoutes configures the admin console search route
+func setupSearchRoutes(r chi.Router) {
+	searchHandler := handlers.NewSearchHandler()
+
+	// Search spans private leaderboards, so it needs the scope that bypasses leaderboard permissions
+	r.With(middleware.RequireScope(middleware.ScopeLeaderboardsAdmin)).Get("/search", searchHandler.Search)
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"leaderboard-service/handlers"
+	"leaderboard-service/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func init() {
+	// Register protected routes
+	RegisterProtectedRoutes(setupServiceTokenRoutes)
+}
+
+// setupServiceTokenRoutes configures all routes related to service tokens
+func setupServiceTokenRoutes(r chi.Router) {
+	serviceTokenHandler := handlers.NewServiceTokenHandler()
+
+	// Service token routes - tokens carry arbitrary scopes so they are admin-only
+	r.Route("/service-tokens", func(r chi.Router) {
+		r.Use(middleware.RequireScope(middleware.ScopeServiceTokensAdmin))
+		r.Get("/", serviceTokenHandler.ListServiceTokens)
+		r.Post("/", serviceTokenHandler.CreateServiceToken)
+		r.Delete("/{id}", serviceTokenHandler.RevokeServiceToken)
+	})
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"leaderboard-service/handlers"
+	"leaderboard-service/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func init() {
+	// Register protected routes
+	RegisterProtectedRoutes(setupWebhookRoutes)
+}
+
+// setupWebhookRoutes configures all routes related to webhook subscriptions
+func setupWebhookRoutes(r chi.Router) {
+	webhookHandler := handlers.NewWebhookSubscriptionHandler()
+
+	// Webhook subscription routes - subscriptions hold shared secrets so they are admin-only
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Use(middleware.RequireScope(middleware.ScopeWebhooksAdmin))
+		r.Get("/", webhookHandler.ListWebhookSubscriptions)
+		r.Post("/", webhookHandler.CreateWebhookSubscription)
+		r.Get("/{id}", webhookHandler.GetWebhookSubscription)
+		r.Put("/{id}", webhookHandler.UpdateWebhookSubscription)
+		r.Delete("/{id}", webhookHandler.DeleteWebhookSubscription)
+		r.Post("/{id}/test", webhookHandler.TestWebhookSubscription)
+	})
+}
@@ -0,0 +1,140 @@
+// Package seed loads fixtures of demo data, leaderboards with their metrics, participants and metric values, into
+// the database for local development and demo environments.
+package seed
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// demoFixture is the fixture loaded when no other is given
+//
+//go:embed fixtures/demo.yaml
+var demoFixture []byte
+
+// Fixture is the demo data to load. Metrics and participants are referred to by name and external ID, so a fixture
+// can be written by hand without knowing any IDs.
+type Fixture struct {
+	Metrics      []Metric      `json:"metrics" yaml:"metrics"`
+	Participants []Participant `json:"participants" yaml:"participants"`
+	Leaderboards []Leaderboard `json:"leaderboards" yaml:"leaderboards"`
+	MetricValues []MetricValue `json:"metric_values" yaml:"metric_values"`
+	Generate     *Generate     `json:"generate" yaml:"generate"`
+}
+
+type Metric struct {
+	Name            string `json:"name" yaml:"name"`
+	Description     string `json:"description" yaml:"description"`
+	DataType        string `json:"data_type" yaml:"data_type"`
+	Unit            string `json:"unit" yaml:"unit"`
+	AggregationType string `json:"aggregation_type" yaml:"aggregation_type"`
+	ResetPeriod     string `json:"reset_period" yaml:"reset_period"` // Defaults to none
+	IsHigherBetter  *bool  `json:"is_higher_better" yaml:"is_higher_better"`
+}
+
+type Participant struct {
+	ExternalID  string `json:"external_id" yaml:"external_id"`
+	Name        string `json:"name" yaml:"name"`
+	Type        string `json:"type" yaml:"type"` // Defaults to individual
+	DisplayName string `json:"display_name" yaml:"display_name"`
+	AvatarURL   string `json:"avatar_url" yaml:"avatar_url"`
+	Country     string `json:"country" yaml:"country"`
+}
+
+type Leaderboard struct {
+	Name            string `json:"name" yaml:"name"`
+	Description     string `json:"description" yaml:"description"`
+	Category        string `json:"category" yaml:"category"`
+	Type            string `json:"type" yaml:"type"`                         // Defaults to individual
+	TimeFrame       string `json:"time_frame" yaml:"time_frame"`             // Defaults to all-time
+	SortOrder       string `json:"sort_order" yaml:"sort_order"`             // Defaults to descending
+	VisibilityScope string `json:"visibility_scope" yaml:"visibility_scope"` // Defaults to public
+	MaxEntries      int    `json:"max_entries" yaml:"max_entries"`
+
+	Metrics []LeaderboardMetric `json:"metrics" yaml:"metrics"`
+	// Participants lists the external IDs of the participants entered on the board, every participant of the
+	// board's type when empty
+	Participants []string `json:"participants" yaml:"participants"`
+}
+
+type LeaderboardMetric struct {
+	Metric          string  `json:"metric" yaml:"metric"`
+	Weight          float64 `json:"weight" yaml:"weight"` // Defaults to 1
+	DisplayPriority int     `json:"display_priority" yaml:"display_priority"`
+}
+
+// MetricValue is a value recorded for a participant, read as its metric's data type
+type MetricValue struct {
+	Metric      string    `json:"metric" yaml:"metric"`
+	Participant string    `json:"participant" yaml:"participant"` // External ID
+	Value       string    `json:"value" yaml:"value"`
+	Timestamp   time.Time `json:"timestamp" yaml:"timestamp"` // Defaults to the time of loading
+	Source      string    `json:"source" yaml:"source"`
+}
+
+// Generate describes metric values made up for every participant, so a fixture needn't list thousands
+// of them. The same seed always makes the same values, spread over the days before loading.
+type Generate struct {
+	Seed                 int64                    `json:"seed" yaml:"seed"`
+	Days                 int                      `json:"days" yaml:"days"`
+	ValuesPerParticipant int                      `json:"values_per_participant" yaml:"values_per_participant"`
+	Metrics              map[string]GenerateRange `json:"metrics" yaml:"metrics"`
+}
+
+// GenerateRange is the range a metric's generated values are drawn from. Boolean metrics come out true about Max
+// of the time, and string metrics pick one of Choices.
+type GenerateRange struct {
+	Min     float64  `json:"min" yaml:"min"`
+	Max     float64  `json:"max" yaml:"max"`
+	Choices []string `json:"choices" yaml:"choices"`
+}
+
+// Demo returns the fixture embedded in the binary
+func Demo() (*Fixture, error) {
+	return Parse(demoFixture, "yaml")
+}
+
+// LoadFile reads a fixture from a YAML or JSON file, told apart by its extension
+func LoadFile(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	fixture, err := Parse(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+// Parse reads a fixture in the format, yaml (or yml) or json. Unknown fields are an error, so a misspelt one
+// isn't silently left out of the demo data.
+func Parse(data []byte, format string) (*Fixture, error) {
+	var fixture Fixture
+	switch format {
+	case "yaml", "yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&fixture); err != nil {
+			return nil, fmt.Errorf("invalid fixture: %w", err)
+		}
+	case "json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&fixture); err != nil {
+			return nil, fmt.Errorf("invalid fixture: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown fixture format %q, expected yaml or json", format)
+	}
+	return &fixture, nil
+}
@@ -0,0 +1,366 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+	"leaderboard-service/services"
+)
+
+// generatedSource is the source of generated metric values, so demo data can be told apart from real data
+const generatedSource = "seed"
+
+// Seeder loads fixtures through the services, so demo data is checked the way data created through the API is
+type Seeder struct {
+	metrics      services.MetricService
+	participants services.ParticipantService
+	leaderboards services.LeaderboardService
+	values       services.MetricValueService
+	entries      services.LeaderboardEntryService
+	standings    services.StandingsService
+	entryRepo    repositories.LeaderboardEntryRepository
+}
+
+func NewSeeder(metrics services.MetricService,
+	participants services.ParticipantService,
+	leaderboards services.LeaderboardService,
+	values services.MetricValueService,
+	entries services.LeaderboardEntryService,
+	standings services.StandingsService,
+	entryRepo repositories.LeaderboardEntryRepository) *Seeder {
+	return &Seeder{
+		metrics:      metrics,
+		participants: participants,
+		leaderboards: leaderboards,
+		values:       values,
+		entries:      entries,
+		standings:    standings,
+		entryRepo:    entryRepo,
+	}
+}
+
+// Result counts what loading a fixture created
+type Result struct {
+	Metrics      int
+	Participants int
+	Leaderboards int
+	Entries      int
+	MetricValues int
+}
+
+// Load creates the fixture's metrics, participants, metric values and leaderboards. Each board gets an entry for
+// every one of its participants, scored from their metric values the way standings weigh them, and is then ranked.
+// The fixture is checked in full first, and it is refused when any of its participants exists already, as it does
+// after the fixture was loaded once. A failure while loading leaves what was created before it.
+func (s *Seeder) Load(ctx context.Context, fixture *Fixture, now time.Time) (*Result, error) {
+	if err := fixture.validate(); err != nil {
+		return nil, err
+	}
+	for _, participant := range fixture.Participants {
+		_, err := s.participants.GetParticipantByExternalID(ctx, participant.ExternalID)
+		if err == nil {
+			return nil, fmt.Errorf("participant %s exists already, so the fixture was loaded before", participant.ExternalID)
+		}
+		if err.Error() != "participant not found" {
+			return nil, err
+		}
+	}
+
+	result := &Result{}
+	metrics := make(map[string]*models.Metric, len(fixture.Metrics))
+	for _, m := range fixture.Metrics {
+		metric, err := s.metrics.CreateMetric(ctx, m.Name, m.Description, enums.MetricDataType(m.DataType), m.Unit,
+			enums.AggregationType(m.AggregationType), enums.ResetPeriod(orDefault(m.ResetPeriod, string(enums.NoReset))),
+			m.IsHigherBetter)
+		if err != nil {
+			return result, fmt.Errorf("error creating metric %s: %w", m.Name, err)
+		}
+		metrics[m.Name] = metric
+		result.Metrics++
+	}
+
+	participants := make(map[string]*models.Participant, len(fixture.Participants))
+	for _, p := range fixture.Participants {
+		participant, err := s.participants.CreateParticipant(ctx, p.ExternalID, p.Name, p.participantType(), nil, nil,
+			p.DisplayName, p.AvatarURL, p.Country)
+		if err != nil {
+			return result, fmt.Errorf("error creating participant %s: %w", p.ExternalID, err)
+		}
+		participants[p.ExternalID] = participant
+		result.Participants++
+	}
+
+	rows := fixture.metricValueRows(metrics, now)
+	imported, err := s.values.ImportMetricValues(ctx, rows)
+	if err != nil {
+		return result, fmt.Errorf("error loading metric values: %w", err)
+	}
+	result.MetricValues = imported
+
+	for _, lb := range fixture.Leaderboards {
+		entries, err := s.loadLeaderboard(ctx, lb, fixture, metrics, participants, now)
+		if err != nil {
+			return result, fmt.Errorf("error creating leaderboard %s: %w", lb.Name, err)
+		}
+		result.Leaderboards++
+		result.Entries += entries
+	}
+	return result, nil
+}
+
+// loadLeaderboard creates the board with its metrics and entries, and returns how many entries it has
+func (s *Seeder) loadLeaderboard(ctx context.Context, lb Leaderboard, fixture *Fixture, metrics map[string]*models.Metric,
+	participants map[string]*models.Participant, now time.Time) (int, error) {
+	boardMetrics := make([]models.LeaderboardMetric, 0, len(lb.Metrics))
+	for _, m := range lb.Metrics {
+		weight := m.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		boardMetrics = append(boardMetrics, models.LeaderboardMetric{
+			MetricID:        metrics[m.Metric].ID,
+			Weight:          weight,
+			DisplayPriority: m.DisplayPriority,
+		})
+	}
+
+	leaderboard, err := s.leaderboards.CreateLeaderboard(ctx, lb.Name, lb.Description, lb.Category,
+		enums.LeaderboardType(lb.leaderboardType()), enums.TimeFrame(orDefault(lb.TimeFrame, string(enums.AllTime))), nil, nil,
+		enums.SortOrder(orDefault(lb.SortOrder, string(enums.Descending))),
+		enums.VisibilityScope(orDefault(lb.VisibilityScope, string(enums.Public))), lb.MaxEntries, "", nil, nil, "", 0, nil,
+		boardMetrics)
+	if err != nil {
+		return 0, err
+	}
+
+	// Entries start at zero and are scored from the standings, which aggregate and weigh the metric values
+	externalIDs := fixture.boardParticipants(lb)
+	for _, externalID := range externalIDs {
+		if _, err := s.entries.CreateLeaderboardEntry(ctx, leaderboard.ID, participants[externalID].ID, 0, 0, now); err != nil {
+			return 0, fmt.Errorf("error entering participant %s: %w", externalID, err)
+		}
+	}
+
+	standings, err := s.standings.ListStandings(ctx, leaderboard.ID)
+	if err != nil {
+		return 0, err
+	}
+	for _, standing := range standings {
+		var score float64
+		for _, metric := range standing.Metrics {
+			score += metric.WeightedValue
+		}
+		if _, err := s.entries.UpdateLeaderboardEntry(ctx, standing.EntryID, &score, nil, &now, nil); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := s.entryRepo.BulkUpdateRanks(ctx, leaderboard.ID, leaderboard.SortOrder, now); err != nil {
+		return 0, fmt.Errorf("error ranking entries: %w", err)
+	}
+	return len(externalIDs), nil
+}
+
+// metricValueRows returns the fixture's metric values followed by the generated ones
+func (f *Fixture) metricValueRows(metrics map[string]*models.Metric, now time.Time) []services.MetricValueImport {
+	rows := make([]services.MetricValueImport, 0, len(f.MetricValues))
+	for _, value := range f.MetricValues {
+		rows = append(rows, services.MetricValueImport{
+			MetricID:              metrics[value.Metric].ID,
+			ParticipantExternalID: value.Participant,
+			Value:                 value.Value,
+			Timestamp:             value.Timestamp,
+			Source:                value.Source,
+		})
+	}
+	if f.Generate == nil {
+		return rows
+	}
+
+	// Metrics are generated in name order, so the same seed makes the same values however the map is iterated
+	names := make([]string, 0, len(f.Generate.Metrics))
+	for name := range f.Generate.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rng := rand.New(rand.NewSource(f.Generate.Seed))
+	period := time.Duration(f.Generate.Days) * 24 * time.Hour
+	for _, participant := range f.Participants {
+		// Each participant gets a skill, so the standings spread out rather than every score being about the mean
+		skill := 0.4 + rng.Float64()
+		for _, name := range names {
+			metric := metrics[name]
+			for range f.Generate.ValuesPerParticipant {
+				rows = append(rows, services.MetricValueImport{
+					MetricID:              metric.ID,
+					ParticipantExternalID: participant.ExternalID,
+					Value:                 f.Generate.Metrics[name].value(rng, metric, skill),
+					Timestamp:             now.Add(-time.Duration(rng.Int63n(int64(period)))),
+					Source:                generatedSource,
+				})
+			}
+		}
+	}
+	return rows
+}
+
+// value draws a value from the range in the metric's data type, a higher skill making for better values: numbers
+// nearer Max, or Min when lower is better, and true more often
+func (r GenerateRange) value(rng *rand.Rand, metric *models.Metric, skill float64) string {
+	fraction := min(rng.Float64()*skill, 1)
+	if !metric.IsHigherBetter {
+		fraction = 1 - fraction
+	}
+	switch metric.DataType {
+	case enums.Boolean:
+		return strconv.FormatBool(fraction > 1-r.Max)
+	case enums.String:
+		return r.Choices[rng.Intn(len(r.Choices))]
+	case enums.Integer:
+		return strconv.FormatInt(int64(r.Min+fraction*(r.Max-r.Min)+0.5), 10)
+	default:
+		return strconv.FormatFloat(r.Min+fraction*(r.Max-r.Min), 'f', 2, 64)
+	}
+}
+
+// boardParticipants returns the external IDs of the participants entered on the board
+func (f *Fixture) boardParticipants(lb Leaderboard) []string {
+	if len(lb.Participants) > 0 {
+		return lb.Participants
+	}
+	var externalIDs []string
+	for _, participant := range f.Participants {
+		if participant.participantType() == lb.leaderboardType() {
+			externalIDs = append(externalIDs, participant.ExternalID)
+		}
+	}
+	return externalIDs
+}
+
+// validate returns every problem with the fixture at once, so it can be fixed before anything is loaded
+func (f *Fixture) validate() error {
+	var errs []error
+	problem := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	metrics := make(map[string]Metric, len(f.Metrics))
+	for _, metric := range f.Metrics {
+		switch {
+		case metric.Name == "":
+			problem("a metric has no name")
+		case !enums.MetricDataType(metric.DataType).Valid():
+			problem("metric %s: unknown data_type %q", metric.Name, metric.DataType)
+		case !enums.AggregationType(metric.AggregationType).Valid():
+			problem("metric %s: unknown aggregation_type %q", metric.Name, metric.AggregationType)
+		case metric.ResetPeriod != "" && !enums.ResetPeriod(metric.ResetPeriod).Valid():
+			problem("metric %s: unknown reset_period %q", metric.Name, metric.ResetPeriod)
+		}
+		if _, ok := metrics[metric.Name]; ok {
+			problem("metric %s is listed twice", metric.Name)
+		}
+		metrics[metric.Name] = metric
+	}
+
+	participants := make(map[string]Participant, len(f.Participants))
+	for _, participant := range f.Participants {
+		switch {
+		case participant.ExternalID == "":
+			problem("participant %q has no external_id", participant.Name)
+		case participant.Name == "":
+			problem("participant %s has no name", participant.ExternalID)
+		case !slices.Contains(participantTypes, participant.participantType()):
+			problem("participant %s: unknown type %q", participant.ExternalID, participant.Type)
+		}
+		if _, ok := participants[participant.ExternalID]; ok {
+			problem("participant %s is listed twice", participant.ExternalID)
+		}
+		participants[participant.ExternalID] = participant
+	}
+
+	for _, lb := range f.Leaderboards {
+		switch {
+		case lb.Name == "":
+			problem("a leaderboard has no name")
+		case !enums.LeaderboardType(lb.leaderboardType()).Valid():
+			problem("leaderboard %s: unknown type %q", lb.Name, lb.Type)
+		case lb.TimeFrame != "" && !enums.TimeFrame(lb.TimeFrame).Valid():
+			problem("leaderboard %s: unknown time_frame %q", lb.Name, lb.TimeFrame)
+		case lb.SortOrder != "" && !enums.SortOrder(lb.SortOrder).Valid():
+			problem("leaderboard %s: unknown sort_order %q", lb.Name, lb.SortOrder)
+		case lb.VisibilityScope != "" && !enums.VisibilityScope(lb.VisibilityScope).Valid():
+			problem("leaderboard %s: unknown visibility_scope %q", lb.Name, lb.VisibilityScope)
+		}
+		for _, metric := range lb.Metrics {
+			if _, ok := metrics[metric.Metric]; !ok {
+				problem("leaderboard %s: unknown metric %s", lb.Name, metric.Metric)
+			}
+		}
+		for _, externalID := range lb.Participants {
+			participant, ok := participants[externalID]
+			if !ok {
+				problem("leaderboard %s: unknown participant %s", lb.Name, externalID)
+			} else if participant.participantType() != lb.leaderboardType() {
+				problem("leaderboard %s: participant %s is not of type %s", lb.Name, externalID, lb.leaderboardType())
+			}
+		}
+		if lb.MaxEntries > 0 && len(f.boardParticipants(lb)) > lb.MaxEntries {
+			problem("leaderboard %s: more participants than max_entries", lb.Name)
+		}
+	}
+
+	for i, value := range f.MetricValues {
+		if _, ok := metrics[value.Metric]; !ok {
+			problem("metric value %d: unknown metric %s", i+1, value.Metric)
+		}
+		if _, ok := participants[value.Participant]; !ok {
+			problem("metric value %d: unknown participant %s", i+1, value.Participant)
+		}
+	}
+
+	if f.Generate != nil {
+		if f.Generate.Days <= 0 {
+			problem("generate: days must be positive")
+		}
+		for name, r := range f.Generate.Metrics {
+			metric, ok := metrics[name]
+			if !ok {
+				problem("generate: unknown metric %s", name)
+				continue
+			}
+			if enums.MetricDataType(metric.DataType) == enums.String && len(r.Choices) == 0 {
+				problem("generate: metric %s is a string metric and needs choices", name)
+			} else if r.Max < r.Min {
+				problem("generate: metric %s has max below min", name)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// participantTypes are the types a participant can have
+var participantTypes = []string{string(enums.Individual), string(enums.Team), services.GroupParticipantType}
+
+func (p Participant) participantType() string {
+	return orDefault(p.Type, string(enums.Individual))
+}
+
+func (lb Leaderboard) leaderboardType() string {
+	return orDefault(lb.Type, string(enums.Individual))
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
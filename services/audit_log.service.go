@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultAuditLogPageSize = 100
+	MaxAuditLogPageSize     = 1000
+)
+
+// Kinds of actor that make changes
+const (
+	AuditActorUser    = "user"
+	AuditActorService = "service"
+	AuditActorAPIKey  = "api_key"
+	AuditActorSystem  = "system" // Changes made outside a request, by background jobs and commands
+)
+
+// AuditActor identifies who made a change
+type AuditActor struct {
+	Type string
+	ID   string // User ID, service token subject or API key ID, empty for the system
+}
+
+// auditOmittedFields are associations left out of audited entities, which are audited on their own or not at all
+var auditOmittedFields = []string{"Metrics", "Entries", "Participant", "Values", "Tags", "MetricValues"}
+
+// auditBookkeepingFields change with every update, so they are left out of the changes of one
+var auditBookkeepingFields = []string{"UpdatedAt", "Version"}
+
+// auditLog records changes once EnableAuditLog is called, resolving their actor from the request context
+var auditLog *auditRecorder
+
+type auditRecorder struct {
+	repo  repositories.AuditLogRepository
+	actor func(ctx context.Context) AuditActor
+}
+
+// EnableAuditLog records every change to leaderboards, entries, metrics and participants made through the services
+// from now on, with the actor the function finds in the context of the change. Call it once at startup.
+func EnableAuditLog(repo repositories.AuditLogRepository, actor func(ctx context.Context) AuditActor) {
+	auditLog = &auditRecorder{repo: repo, actor: actor}
+}
+
+// recordAudit records a change to an entity, before being nil for creates and after nil for deletes
+func recordAudit(ctx context.Context, entityType enums.AuditEntityType, entityID uuid.UUID, action enums.AuditAction,
+	before, after interface{}) {
+	recordAudits(ctx, []models.AuditLog{newAuditLog(entityType, entityID, action, before, after)})
+}
+
+// recordAudits records changes made together, such as by an import, in one write. The write happens after the
+// changes are made and isn't cancelled with the request, and a failure is logged rather than failing the change.
+func recordAudits(ctx context.Context, logs []models.AuditLog) {
+	if auditLog == nil || len(logs) == 0 {
+		return
+	}
+	actor := auditLog.actor(ctx)
+	for i := range logs {
+		logs[i].ActorType = actor.Type
+		logs[i].ActorID = actor.ID
+	}
+	if err := auditLog.repo.CreateBatch(context.WithoutCancel(ctx), logs); err != nil {
+		log.Printf("audit: failed to record %d change(s) to %s %s: %v", len(logs), logs[0].EntityType, logs[0].EntityID, err)
+	}
+}
+
+// newAuditLog describes a change without its actor, which recordAudits adds
+func newAuditLog(entityType enums.AuditEntityType, entityID uuid.UUID, action enums.AuditAction,
+	before, after interface{}) models.AuditLog {
+	entry := models.AuditLog{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		OccurredAt: time.Now().UTC(),
+	}
+	beforeFields, afterFields := auditSnapshot(before), auditSnapshot(after)
+	if beforeFields != nil {
+		entry.Before = beforeFields
+	}
+	if afterFields != nil {
+		entry.After = afterFields
+	}
+	if beforeFields != nil && afterFields != nil {
+		entry.Changes = auditChanges(beforeFields, afterFields)
+	}
+	return entry
+}
+
+// auditSnapshot returns the entity's fields as they are stored in the log, or nil for no entity
+func auditSnapshot(entity interface{}) map[string]interface{} {
+	if entity == nil {
+		return nil
+	}
+	if value := reflect.ValueOf(entity); value.Kind() == reflect.Pointer && value.IsNil() {
+		return nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		log.Printf("audit: failed to encode %T: %v", entity, err)
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		log.Printf("audit: failed to encode %T: %v", entity, err)
+		return nil
+	}
+	for _, field := range auditOmittedFields {
+		delete(fields, field)
+	}
+	return fields
+}
+
+// auditChange is a field's value before and after a change
+type auditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// auditChanges returns the fields whose value differs between before and after
+func auditChanges(before, after map[string]interface{}) map[string]auditChange {
+	changes := make(map[string]auditChange)
+	for field, value := range after {
+		if !reflect.DeepEqual(before[field], value) {
+			changes[field] = auditChange{Before: before[field], After: value}
+		}
+	}
+	for field, value := range before {
+		if _, ok := after[field]; !ok {
+			changes[field] = auditChange{Before: value}
+		}
+	}
+	for _, field := range auditBookkeepingFields {
+		delete(changes, field)
+	}
+	return changes
+}
+
+// AuditLogService serves the audit log to admins
+type AuditLogService interface {
+	// ListAuditLogs returns the logs matching the filter with an ID greater than afterID, oldest first
+	ListAuditLogs(ctx context.Context, filter repositories.AuditLogFilter, afterID uint64, limit int) ([]models.AuditLog, error)
+}
+
+type auditLogService struct {
+	repo repositories.AuditLogRepository
+}
+
+func NewAuditLogService(repo repositories.AuditLogRepository) AuditLogService {
+	return &auditLogService{
+		repo: repo,
+	}
+}
+
+func (s *auditLogService) ListAuditLogs(ctx context.Context, filter repositories.AuditLogFilter, afterID uint64,
+	limit int) ([]models.AuditLog, error) {
+	if limit <= 0 {
+		limit = DefaultAuditLogPageSize
+	}
+	if limit > MaxAuditLogPageSize {
+		limit = MaxAuditLogPageSize
+	}
+
+	// Like events, logs are held back briefly so one committing late can't land behind a client's cursor
+	return s.repo.FindAfter(ctx, filter, afterID, time.Now().Add(-eventVisibilityDelay), limit)
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultUserRole is assigned to self-registered accounts
+	DefaultUserRole = "user"
+	// AdminUserRole is assigned to the bootstrap administrator
+	AdminUserRole = "admin"
+)
+
+// dummyPasswordHash is compared against when the username is unknown so that
+// failed logins take the same time whether or not the account exists
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("leaderboard-service-dummy-password"), bcrypt.DefaultCost)
+
+type AuthService interface {
+	Register(ctx context.Context, username, email, password string) (*models.User, error)
+	Authenticate(ctx context.Context, username, password string) (*models.User, error)
+	EnsureAdmin(ctx context.Context, username, email, password string) (*models.User, error)
+	// CreateAdmin creates an administrator account, failing like Register when the username or email is taken
+	CreateAdmin(ctx context.Context, username, email, password string) (*models.User, error)
+}
+
+type authService struct {
+	repo repositories.UserRepository
+}
+
+func NewAuthService(repo repositories.UserRepository) AuthService {
+	return &authService{
+		repo: repo,
+	}
+}
+
+// Register creates a new account with the default role
+func (s *authService) Register(ctx context.Context, username, email, password string) (*models.User, error) {
+	return s.createUser(ctx, username, email, password, DefaultUserRole)
+}
+
+// Authenticate verifies the credentials and returns the matching user
+func (s *authService) Authenticate(ctx context.Context, username, password string) (*models.User, error) {
+	user, err := s.repo.FindByUsername(ctx, strings.TrimSpace(username))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+			return nil, errors.New("invalid credentials")
+		}
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, errors.New("invalid credentials")
+	}
+
+	return user, nil
+}
+
+// EnsureAdmin creates the administrator account on first start if it does not exist yet
+func (s *authService) EnsureAdmin(ctx context.Context, username, email, password string) (*models.User, error) {
+	user, err := s.repo.FindByUsername(ctx, strings.TrimSpace(username))
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	return s.createUser(ctx, username, email, password, AdminUserRole)
+}
+
+func (s *authService) CreateAdmin(ctx context.Context, username, email, password string) (*models.User, error) {
+	return s.createUser(ctx, username, email, password, AdminUserRole)
+}
+
+func (s *authService) createUser(ctx context.Context, username, email, password, role string) (*models.User, error) {
+	username = strings.TrimSpace(username)
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	if _, err := s.repo.FindByUsername(ctx, username); err == nil {
+		return nil, errors.New("username already taken")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if _, err := s.repo.FindByEmail(ctx, email); err == nil {
+		return nil, errors.New("email already registered")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := models.User{
+		Username:     username,
+		Email:        email,
+		PasswordHash: string(hash),
+		Role:         role,
+	}
+
+	err = s.repo.Create(ctx, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
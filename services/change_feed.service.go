@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultChangePageSize = 100
+	MaxChangePageSize     = 500
+
+	changeCursorPrefix = "ev:"
+)
+
+// ChangeFeed is a page of entry mutations for a leaderboard
+type ChangeFeed struct {
+	Changes    []models.Event `json:"changes"`
+	NextCursor string         `json:"next_cursor"`
+	HasMore    bool           `json:"has_more"`
+}
+
+// ChangeFeedService serves a resumable, ordered feed of entry mutations per leaderboard
+type ChangeFeedService interface {
+	GetChanges(ctx context.Context, leaderboardID uuid.UUID, cursor string, limit int) (*ChangeFeed, error)
+}
+
+type changeFeedService struct {
+	eventRepo       repositories.EventRepository
+	leaderboardRepo repositories.LeaderboardRepository
+}
+
+func NewChangeFeedService(eventRepo repositories.EventRepository,
+	leaderboardRepo repositories.LeaderboardRepository) ChangeFeedService {
+	return &changeFeedService{
+		eventRepo:       eventRepo,
+		leaderboardRepo: leaderboardRepo,
+	}
+}
+
+// GetChanges returns the entry mutations recorded after the cursor, oldest first.
+// An empty cursor starts from the beginning of the log.
+func (s *changeFeedService) GetChanges(ctx context.Context, leaderboardID uuid.UUID, cursor string, limit int) (*ChangeFeed, error) {
+	afterID, err := decodeChangeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.leaderboardRepo.FindByID(ctx, leaderboardID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("leaderboard not found")
+		}
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = DefaultChangePageSize
+	}
+	if limit > MaxChangePageSize {
+		limit = MaxChangePageSize
+	}
+
+	types := []string{
+		string(enums.EntryCreated),
+		string(enums.EntryUpdated),
+		string(enums.EntryDeleted),
+		string(enums.EntryRankChanged),
+	}
+
+	// Fetch one extra event to find out whether another page is waiting
+	events, err := s.eventRepo.FindAfter(ctx, afterID, types, &leaderboardID, time.Now().Add(-eventVisibilityDelay), limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &ChangeFeed{Changes: events}
+	if len(events) > limit {
+		feed.Changes = events[:limit]
+		feed.HasMore = true
+	}
+	if len(feed.Changes) > 0 {
+		afterID = feed.Changes[len(feed.Changes)-1].ID
+	}
+	feed.NextCursor = encodeChangeCursor(afterID)
+
+	return feed, nil
+}
+
+func encodeChangeCursor(afterID uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(changeCursorPrefix + strconv.FormatUint(afterID, 10)))
+}
+
+func decodeChangeCursor(cursor string) (uint64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), changeCursorPrefix) {
+		return 0, errors.New("invalid cursor")
+	}
+
+	afterID, err := strconv.ParseUint(strings.TrimPrefix(string(raw), changeCursorPrefix), 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid cursor")
+	}
+	return afterID, nil
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DeviceTokenService interface {
+	RegisterDeviceToken(ctx context.Context, participantID uuid.UUID, provider enums.PushProvider, token string) (*models.DeviceToken, error)
+	ListDeviceTokens(ctx context.Context, participantID uuid.UUID) ([]models.DeviceToken, error)
+	DeleteDeviceToken(ctx context.Context, participantID, id uuid.UUID) error
+}
+
+type deviceTokenService struct {
+	repo            repositories.DeviceTokenRepository
+	participantRepo repositories.ParticipantRepository
+}
+
+func NewDeviceTokenService(repo repositories.DeviceTokenRepository,
+	participantRepo repositories.ParticipantRepository) DeviceTokenService {
+	return &deviceTokenService{
+		repo:            repo,
+		participantRepo: participantRepo,
+	}
+}
+
+func (s *deviceTokenService) RegisterDeviceToken(ctx context.Context, participantID uuid.UUID, provider enums.PushProvider,
+	token string) (*models.DeviceToken, error) {
+
+	if err := s.verifyParticipantExists(ctx, participantID); err != nil {
+		return nil, err
+	}
+
+	deviceToken := models.DeviceToken{
+		ParticipantID: participantID,
+		Provider:      provider,
+		Token:         token,
+	}
+
+	err := s.repo.Upsert(ctx, &deviceToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deviceToken, nil
+}
+
+func (s *deviceTokenService) ListDeviceTokens(ctx context.Context, participantID uuid.UUID) ([]models.DeviceToken, error) {
+	if err := s.verifyParticipantExists(ctx, participantID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByParticipantID(ctx, participantID)
+}
+
+func (s *deviceTokenService) DeleteDeviceToken(ctx context.Context, participantID, id uuid.UUID) error {
+	deviceToken, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("device token not found")
+		}
+		return err
+	}
+	if deviceToken.ParticipantID != participantID {
+		return errors.New("device token not found")
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *deviceTokenService) verifyParticipantExists(ctx context.Context, participantID uuid.UUID) error {
+	_, err := s.participantRepo.FindByID(ctx, participantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("participant not found")
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// EmailMessage is a plain text email
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// EmailSender delivers transactional email such as password reset links
+type EmailSender interface {
+	Send(message EmailMessage) error
+}
+
+type logEmailSender struct{}
+
+// NewLogEmailSender creates a sender that logs the recipient and subject of messages instead of sending them. Bodies
+// are never logged, as they hold secrets such as reset tokens. For development only.
+func NewLogEmailSender() EmailSender {
+	return &logEmailSender{}
+}
+
+func (s *logEmailSender) Send(message EmailMessage) error {
+	log.Printf("email(log): to=%s subject=%q (body not logged)", message.To, message.Subject)
+	return nil
+}
+
+// SMTPConfig holds the settings of an SMTP relay
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string // Optional, authentication is skipped when empty
+	Password string
+	From     string
+}
+
+type smtpEmailSender struct {
+	config SMTPConfig
+}
+
+// NewSMTPEmailSender creates a sender that delivers through an SMTP relay
+func NewSMTPEmailSender(config SMTPConfig) EmailSender {
+	if config.Port == "" {
+		config.Port = "587"
+	}
+	return &smtpEmailSender{config: config}
+}
+
+func (s *smtpEmailSender) Send(message EmailMessage) error {
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	// Header values must not contain line breaks or they could inject extra headers
+	for _, value := range []string{message.To, message.Subject} {
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("email header contains a line break")
+		}
+	}
+
+	body := strings.Join([]string{
+		"From: " + s.config.From,
+		"To: " + message.To,
+		"Subject: " + message.Subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		message.Body,
+	}, "\r\n")
+
+	addr := net.JoinHostPort(s.config.Host, s.config.Port)
+	return smtp.SendMail(addr, auth, s.config.From, []string{message.To}, []byte(body))
+}
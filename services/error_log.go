@@ -0,0 +1,51 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// recentErrorLimit is how many server errors the admin overview keeps
+const recentErrorLimit = 50
+
+// RecentError is a request that failed with a server error
+type RecentError struct {
+	RequestID  string    `json:"request_id"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Status     int       `json:"status"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Server errors of this process, newest last, overwritten oldest first once full
+var (
+	recentErrorsMu sync.Mutex
+	recentErrors   []RecentError
+	nextError      int
+)
+
+// RecordError keeps a server error for the admin overview. Only the latest errors are kept, and only in memory,
+// so each instance reports its own.
+func RecordError(recentError RecentError) {
+	recentErrorsMu.Lock()
+	defer recentErrorsMu.Unlock()
+
+	if len(recentErrors) < recentErrorLimit {
+		recentErrors = append(recentErrors, recentError)
+		return
+	}
+	recentErrors[nextError] = recentError
+	nextError = (nextError + 1) % recentErrorLimit
+}
+
+// RecentErrors returns the kept server errors, newest first
+func RecentErrors() []RecentError {
+	recentErrorsMu.Lock()
+	defer recentErrorsMu.Unlock()
+
+	errors := make([]RecentError, 0, len(recentErrors))
+	for i := len(recentErrors) - 1; i >= 0; i-- {
+		errors = append(errors, recentErrors[(nextError+i)%len(recentErrors)])
+	}
+	return errors
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultEventPageSize = 100
+	MaxEventPageSize     = 1000
+
+	// Events become visible to pollers only after this delay, so a slow insert that
+	// received a lower ID cannot commit behind a cursor that has already moved past it
+	eventVisibilityDelay = time.Second
+)
+
+// EventLogService persists domain events and serves them to polling clients
+type EventLogService interface {
+	EventPublisher
+	// ListEvents returns events with an ID greater than afterID, oldest first. Unless leaderboardIDs is nil, only the
+	// events of those leaderboards are returned.
+	ListEvents(ctx context.Context, afterID uint64, types []string, leaderboardIDs []uuid.UUID, limit int) ([]models.Event, error)
+}
+
+type eventLogService struct {
+	repo repositories.EventRepository
+}
+
+func NewEventLogService(repo repositories.EventRepository) EventLogService {
+	return &eventLogService{
+		repo: repo,
+	}
+}
+
+// Publish synchronously appends the event to the durable log
+func (s *eventLogService) Publish(event Event) {
+	record := models.Event{
+		Type:          event.Type,
+		LeaderboardID: event.LeaderboardID,
+		Data:          event.Data,
+		OccurredAt:    event.OccurredAt,
+	}
+
+	// Events carry no request context, so the write isn't cancelled with the request that caused it
+	if err := s.repo.Create(context.Background(), &record); err != nil {
+		log.Printf("events: failed to persist %s event: %v", event.Type, err)
+	}
+}
+
+func (s *eventLogService) ListEvents(ctx context.Context, afterID uint64, types []string, leaderboardIDs []uuid.UUID,
+	limit int) ([]models.Event, error) {
+	if limit <= 0 {
+		limit = DefaultEventPageSize
+	}
+	if limit > MaxEventPageSize {
+		limit = MaxEventPageSize
+	}
+
+	visibleBefore := time.Now().Add(-eventVisibilityDelay)
+	if leaderboardIDs != nil {
+		return s.repo.FindAfterInLeaderboards(ctx, afterID, types, leaderboardIDs, visibleBefore, limit)
+	}
+	return s.repo.FindAfter(ctx, afterID, types, nil, visibleBefore, limit)
+}
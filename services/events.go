@@ -0,0 +1,43 @@
+package services
+
+import (
+	"leaderboard-service/enums"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event describes a domain change that is fanned out to every registered publisher
+type Event struct {
+	Type          enums.EventType `json:"type"`
+	LeaderboardID *uuid.UUID      `json:"leaderboard_id,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          interface{}     `json:"data"`
+}
+
+// EventPublisher receives domain events emitted by the services
+type EventPublisher interface {
+	Publish(event Event)
+}
+
+// Global list of publishers that will be populated at startup
+var eventPublishers []EventPublisher
+
+// RegisterEventPublisher adds a publisher that will receive every emitted event
+func RegisterEventPublisher(publisher EventPublisher) {
+	eventPublishers = append(eventPublishers, publisher)
+}
+
+// publishEvent sends an event to all registered publishers
+func publishEvent(eventType enums.EventType, leaderboardID *uuid.UUID, data interface{}) {
+	event := Event{
+		Type:          eventType,
+		LeaderboardID: leaderboardID,
+		OccurredAt:    time.Now().UTC(),
+		Data:          data,
+	}
+
+	for _, publisher := range eventPublishers {
+		publisher.Publish(event)
+	}
+}
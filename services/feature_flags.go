@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+)
+
+// featureFlagDefinition describes a flag and the value it has unless FEATURE_<NAME> or an admin sets it
+type featureFlagDefinition struct {
+	description string
+	enabled     bool
+}
+
+// featureFlagDefinitions switch behaviours that are set up at startup off and back on without a restart. A flag
+// has no effect while its behaviour isn't set up. maintenance_mode instead puts the whole service in read-only mode.
+var featureFlagDefinitions = map[enums.FeatureFlag]featureFlagDefinition{
+	enums.FeatureStandingsCache:   {"Serve the standings of public leaderboards from Redis, when REDIS_URL is set", true},
+	enums.FeatureStandingsSummary: {"Read standings from the standings summary, when STANDINGS_SUMMARY is set", true},
+	enums.FeatureScoreBuffer:      {"Buffer score increments and apply them on an interval, when SCORE_BUFFER is set", true},
+	enums.FeatureMaintenanceMode:  {"Reject requests that change data with 503 while reads stay available", false},
+}
+
+// FeatureFlags holds the value of every feature flag on this instance: the one set by an admin, else the one the
+// instance was started with
+type FeatureFlags struct {
+	repo     repositories.FeatureFlagRepository
+	defaults map[enums.FeatureFlag]bool
+
+	mu        sync.RWMutex
+	overrides map[enums.FeatureFlag]models.FeatureFlag
+}
+
+// Global flags checked by the services, nil while every flag has its built-in value
+var featureFlags *FeatureFlags
+
+// NewFeatureFlags creates the flags of an instance, starting with the built-in values replaced by those given,
+// usually read from FEATURE_<NAME> variables for the environment
+func NewFeatureFlags(repo repositories.FeatureFlagRepository, values map[enums.FeatureFlag]bool) *FeatureFlags {
+	defaults := make(map[enums.FeatureFlag]bool, len(featureFlagDefinitions))
+	for name, definition := range featureFlagDefinitions {
+		defaults[name] = definition.enabled
+	}
+	for name, enabled := range values {
+		defaults[name] = enabled
+	}
+	return &FeatureFlags{
+		repo:      repo,
+		defaults:  defaults,
+		overrides: map[enums.FeatureFlag]models.FeatureFlag{},
+	}
+}
+
+// EnableFeatureFlags makes the services check the flags, and the admin endpoints change them
+func EnableFeatureFlags(flags *FeatureFlags) {
+	featureFlags = flags
+}
+
+// FeatureEnabled reports whether the flag is on
+func FeatureEnabled(name enums.FeatureFlag) bool {
+	if featureFlags == nil {
+		return featureFlagDefinitions[name].enabled
+	}
+	return featureFlags.enabled(name)
+}
+
+func (f *FeatureFlags) enabled(name enums.FeatureFlag) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if override, ok := f.overrides[name]; ok {
+		return override.Enabled
+	}
+	return f.defaults[name]
+}
+
+// Load reads the flags set by admins. Flags stored under names this version doesn't know are ignored.
+func (f *FeatureFlags) Load(ctx context.Context) error {
+	stored, err := f.repo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	f.replace(stored)
+	return nil
+}
+
+// Run reloads the flags every interval, so those set through another instance take effect here too
+func (f *FeatureFlags) Run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := f.Load(context.Background()); err != nil {
+			log.Printf("feature flags: failed to reload: %v", err)
+		}
+	}
+}
+
+func (f *FeatureFlags) replace(stored []models.FeatureFlag) {
+	overrides := make(map[enums.FeatureFlag]models.FeatureFlag, len(stored))
+	for _, flag := range stored {
+		if flag.Name.Valid() {
+			overrides[flag.Name] = flag
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.overrides = overrides
+}
+
+func (f *FeatureFlags) set(flag models.FeatureFlag) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.overrides[flag.Name] = flag
+}
+
+func (f *FeatureFlags) reset(name enums.FeatureFlag) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.overrides, name)
+}
+
+// FeatureFlagState is a flag's value and where it comes from
+type FeatureFlagState struct {
+	Name        enums.FeatureFlag
+	Description string
+	Enabled     bool
+	Default     bool       // Value the instance was started with, used while no admin has set the flag
+	Overridden  bool       // Whether an admin has set the flag
+	UpdatedBy   string     // Token subject of the admin who set it
+	UpdatedAt   *time.Time // When it was set
+}
+
+// FeatureFlagService lets admins see and change the feature flags
+type FeatureFlagService interface {
+	ListFeatureFlags(ctx context.Context) ([]FeatureFlagState, error)
+	GetFeatureFlag(ctx context.Context, name enums.FeatureFlag) (*FeatureFlagState, error)
+	// SetFeatureFlag turns the flag on or off on every instance, until it is reset
+	SetFeatureFlag(ctx context.Context, name enums.FeatureFlag, enabled bool, updatedBy string) (*FeatureFlagState, error)
+	// ResetFeatureFlag gives the flag back the value each instance was started with
+	ResetFeatureFlag(ctx context.Context, name enums.FeatureFlag) (*FeatureFlagState, error)
+}
+
+type featureFlagService struct {
+	repo repositories.FeatureFlagRepository
+}
+
+func NewFeatureFlagService(repo repositories.FeatureFlagRepository) FeatureFlagService {
+	return &featureFlagService{
+		repo: repo,
+	}
+}
+
+func (s *featureFlagService) ListFeatureFlags(ctx context.Context) ([]FeatureFlagState, error) {
+	stored, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The stored flags are the latest, so this instance needn't wait for its next reload to use them
+	if featureFlags != nil {
+		featureFlags.replace(stored)
+	}
+
+	overrides := make(map[enums.FeatureFlag]models.FeatureFlag, len(stored))
+	for _, flag := range stored {
+		overrides[flag.Name] = flag
+	}
+	states := make([]FeatureFlagState, 0, len(featureFlagDefinitions))
+	for _, name := range enums.GetValidFeatureFlags() {
+		var override *models.FeatureFlag
+		if flag, ok := overrides[enums.FeatureFlag(name)]; ok {
+			override = &flag
+		}
+		states = append(states, featureFlagState(enums.FeatureFlag(name), override))
+	}
+	return states, nil
+}
+
+func (s *featureFlagService) GetFeatureFlag(ctx context.Context, name enums.FeatureFlag) (*FeatureFlagState, error) {
+	states, err := s.ListFeatureFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, state := range states {
+		if state.Name == name {
+			return &state, nil
+		}
+	}
+	return nil, errors.New("feature flag not found")
+}
+
+func (s *featureFlagService) SetFeatureFlag(ctx context.Context, name enums.FeatureFlag, enabled bool,
+	updatedBy string) (*FeatureFlagState, error) {
+	if !name.Valid() {
+		return nil, errors.New("feature flag not found")
+	}
+
+	flag := models.FeatureFlag{
+		Name:      name,
+		Enabled:   enabled,
+		UpdatedBy: updatedBy,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := s.repo.Save(ctx, &flag); err != nil {
+		return nil, err
+	}
+	if featureFlags != nil {
+		featureFlags.set(flag)
+	}
+
+	state := featureFlagState(name, &flag)
+	return &state, nil
+}
+
+func (s *featureFlagService) ResetFeatureFlag(ctx context.Context, name enums.FeatureFlag) (*FeatureFlagState, error) {
+	if !name.Valid() {
+		return nil, errors.New("feature flag not found")
+	}
+
+	if err := s.repo.Delete(ctx, name); err != nil {
+		return nil, err
+	}
+	if featureFlags != nil {
+		featureFlags.reset(name)
+	}
+
+	state := featureFlagState(name, nil)
+	return &state, nil
+}
+
+// featureFlagState describes the flag on this instance, set by the override when there is one
+func featureFlagState(name enums.FeatureFlag, override *models.FeatureFlag) FeatureFlagState {
+	state := FeatureFlagState{
+		Name:        name,
+		Description: featureFlagDefinitions[name].description,
+		Default:     featureFlagDefinitions[name].enabled,
+	}
+	if featureFlags != nil {
+		state.Default = featureFlags.defaults[name]
+	}
+
+	state.Enabled = state.Default
+	if override != nil {
+		state.Enabled = override.Enabled
+		state.Overridden = true
+		state.UpdatedBy = override.UpdatedBy
+		state.UpdatedAt = &override.UpdatedAt
+	}
+	return state
+}
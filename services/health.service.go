@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"leaderboard-service/repositories"
+)
+
+// readinessTimeout bounds each readiness check, so a probe gets an answer before it gives up itself
+const readinessTimeout = 2 * time.Second
+
+const (
+	CheckOK       = "ok"
+	CheckFailing  = "failing"
+	CheckDisabled = "disabled" // The dependency isn't configured, which doesn't make the instance unready
+)
+
+// ReadinessCheck is the result of checking one dependency
+type ReadinessCheck struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Readiness reports whether the instance can serve requests, with the result of each check
+type Readiness struct {
+	Ready  bool                      `json:"ready"`
+	Checks map[string]ReadinessCheck `json:"checks"` // Keyed by database, migrations and cache
+}
+
+type HealthService interface {
+	// Readiness checks that the database can be reached, that it has had every migration the build knows and that
+	// the standings cache, when enabled, can be reached
+	Readiness(ctx context.Context) *Readiness
+}
+
+type healthService struct {
+	repo repositories.HealthRepository
+}
+
+func NewHealthService(repo repositories.HealthRepository) HealthService {
+	return &healthService{
+		repo: repo,
+	}
+}
+
+func (s *healthService) Readiness(ctx context.Context) *Readiness {
+	readiness := &Readiness{Ready: true, Checks: make(map[string]ReadinessCheck, 3)}
+	record := func(name string, err error) {
+		if err != nil {
+			readiness.Ready = false
+			readiness.Checks[name] = ReadinessCheck{Status: CheckFailing, Error: err.Error()}
+			return
+		}
+		readiness.Checks[name] = ReadinessCheck{Status: CheckOK}
+	}
+
+	record("database", s.checkDatabase(ctx))
+	record("migrations", s.checkMigrations(ctx))
+	if standingsCache == nil {
+		readiness.Checks["cache"] = ReadinessCheck{Status: CheckDisabled}
+	} else {
+		record("cache", standingsCache.Ping(ctx))
+	}
+	return readiness
+}
+
+func (s *healthService) checkDatabase(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	return s.repo.Ping(ctx)
+}
+
+// checkMigrations fails while migrations are pending, such as when a replica of an older build has the database
+// or another replica is still migrating it
+func (s *healthService) checkMigrations(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	pending, err := s.repo.PendingMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d not yet applied", pending)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package services
+
+import "fmt"
+
+// ImportRowError is returned when one row stops a bulk import, which then stores nothing
+type ImportRowError struct {
+	Row int // Counted from 1, the first row after any header
+	Err error
+}
+
+func (e *ImportRowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *ImportRowError) Unwrap() error {
+	return e.Err
+}
@@ -1,43 +1,97 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"leaderboard-service/enums"
 	"leaderboard-service/models"
 	"leaderboard-service/repositories"
 	"leaderboard-service/utils"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type LeaderboardService interface {
-	CreateLeaderboard(name, description, category string, leaderboardType enums.LeaderboardType,
+	CreateLeaderboard(ctx context.Context, name, description, category string, leaderboardType enums.LeaderboardType,
 		timeFrame enums.TimeFrame, startDate, endDate *string, sortOrder enums.SortOrder,
-		visibilityScope enums.VisibilityScope, maxEntries int, isActive bool) (*models.Leaderboard, error)
-	GetLeaderboard(id uuid.UUID) (*models.Leaderboard, error)
-	ListLeaderboards() ([]models.Leaderboard, error)
-	UpdateLeaderboard(id uuid.UUID, name, description, category *string, leaderboardType *enums.LeaderboardType,
+		visibilityScope enums.VisibilityScope, maxEntries int, maxEntriesPolicy enums.MaxEntriesPolicy, isActive *bool, ownerID *uuid.UUID,
+		teamScoreAggregation enums.TeamScoreAggregation, teamScoreTopK int, groupID *uuid.UUID,
+		metrics []models.LeaderboardMetric) (*models.Leaderboard, error)
+	// GetLeaderboard returns the leaderboard with the included related resources (see repositories.LeaderboardIncludes) loaded
+	GetLeaderboard(ctx context.Context, id uuid.UUID, include []string) (*models.Leaderboard, error)
+	// ListLeaderboards returns the leaderboards with the given IDs, or all of them when there are none, matching every
+	// filter that is set, in the given order or oldest first when it is nil, with the included related resources
+	// loaded. Soft-deleted leaderboards are only listed when asked for.
+	ListLeaderboards(ctx context.Context, ids []uuid.UUID, category *string, leaderboardType *enums.LeaderboardType, timeFrame *enums.TimeFrame, isActive *bool,
+		visibilityScope *enums.VisibilityScope, sort *repositories.Sort, include []string, includeDeleted bool) ([]models.Leaderboard, error)
+	UpdateLeaderboard(ctx context.Context, id uuid.UUID, name, description, category *string, leaderboardType *enums.LeaderboardType,
 		timeFrame *enums.TimeFrame, startDate, endDate *string, sortOrder *enums.SortOrder,
-		visibilityScope *enums.VisibilityScope, maxEntries *int, isActive *bool) (*models.Leaderboard, error)
-	DeleteLeaderboard(id uuid.UUID) error
+		visibilityScope *enums.VisibilityScope, maxEntries *int, maxEntriesPolicy *enums.MaxEntriesPolicy, isActive *bool,
+		teamScoreAggregation *enums.TeamScoreAggregation, teamScoreTopK *int, groupID *uuid.UUID, clearGroup bool,
+		version *int) (*models.Leaderboard, error)
+	// DeleteLeaderboard soft-deletes the leaderboard together with its entries and metrics. Unless force is set, it
+	// returns a *LeaderboardNotEmptyError and deletes nothing when the leaderboard has entries.
+	DeleteLeaderboard(ctx context.Context, id uuid.UUID, force bool) error
+	// RestoreLeaderboard undoes the soft delete of a leaderboard and of the entries and metrics deleted with it
+	RestoreLeaderboard(ctx context.Context, id uuid.UUID) (*models.Leaderboard, error)
+}
+
+// LeaderboardNotEmptyError is returned when a leaderboard that has entries would be deleted without force
+type LeaderboardNotEmptyError struct {
+	Entries int64 // Live entries that would be deleted with the leaderboard
+	Metrics int64 // Leaderboard metrics that would be deleted with the leaderboard
+}
+
+func (e *LeaderboardNotEmptyError) Error() string {
+	return "leaderboard is not empty"
 }
 
 type leaderboardService struct {
-	repo repositories.LeaderboardRepository
+	repo            repositories.LeaderboardRepository
+	participantRepo repositories.ParticipantRepository
+	unitOfWork      repositories.UnitOfWork
 }
 
-func NewLeaderboardService(repo repositories.LeaderboardRepository) LeaderboardService {
+func NewLeaderboardService(repo repositories.LeaderboardRepository,
+	participantRepo repositories.ParticipantRepository, unitOfWork repositories.UnitOfWork) LeaderboardService {
 	return &leaderboardService{
-		repo: repo,
+		repo:            repo,
+		participantRepo: participantRepo,
+		unitOfWork:      unitOfWork,
 	}
 }
 
-func (s *leaderboardService) CreateLeaderboard(name, description, category string, leaderboardType enums.LeaderboardType,
+func (s *leaderboardService) CreateLeaderboard(ctx context.Context, name, description, category string, leaderboardType enums.LeaderboardType,
 	timeFrame enums.TimeFrame, startDate, endDate *string, sortOrder enums.SortOrder,
-	visibilityScope enums.VisibilityScope, maxEntries int, isActive bool) (*models.Leaderboard, error) {
+	visibilityScope enums.VisibilityScope, maxEntries int, maxEntriesPolicy enums.MaxEntriesPolicy, isActive *bool, ownerID *uuid.UUID,
+	teamScoreAggregation enums.TeamScoreAggregation, teamScoreTopK int, groupID *uuid.UUID,
+	metrics []models.LeaderboardMetric) (*models.Leaderboard, error) {
+
+	// Boards are active unless the request says otherwise
+	active := true
+	if isActive != nil {
+		active = *isActive
+	}
+	if maxEntriesPolicy == "" {
+		maxEntriesPolicy = enums.MaxEntriesReject
+	}
+	if teamScoreAggregation == "" {
+		teamScoreAggregation = enums.TeamScoreSum
+	}
+	if err := validateTeamScoring(teamScoreAggregation, teamScoreTopK); err != nil {
+		return nil, err
+	}
 
 	start, end := utils.ValidateDates(startDate, endDate)
+	if err := validateDateRange(start, end); err != nil {
+		return nil, err
+	}
+	// Updates may close a board early, but a new one has to be open for entries
+	if end != nil && !end.After(time.Now()) {
+		return nil, errors.New("end_date is in the past")
+	}
 
 	leaderboard := models.Leaderboard{
 		Name:            name,
@@ -50,19 +104,46 @@ func (s *leaderboardService) CreateLeaderboard(name, description, category strin
 		SortOrder:       sortOrder,
 		VisibilityScope: visibilityScope,
 		MaxEntries:      maxEntries,
-		IsActive:        isActive,
+		IsActive:        active,
+		OwnerID:         ownerID,
+		GroupID:         groupID,
+
+		MaxEntriesPolicy: maxEntriesPolicy,
+
+		TeamScoreAggregation: teamScoreAggregation,
+		TeamScoreTopK:        teamScoreTopK,
 	}
 
-	err := s.repo.Create(&leaderboard)
+	// The board and its metrics are created together, so a missing group or metric leaves no board behind
+	err := s.unitOfWork.Do(ctx, func(repos repositories.Repositories) error {
+		if groupID != nil {
+			if err := verifyGroup(ctx, repos.Participants, *groupID); err != nil {
+				return err
+			}
+		}
+		if err := verifyMetrics(ctx, repos.Metrics, metrics); err != nil {
+			return err
+		}
+
+		if err := repos.Leaderboards.Create(ctx, &leaderboard); err != nil {
+			return err
+		}
+		for i := range metrics {
+			metrics[i].LeaderboardID = leaderboard.ID
+		}
+		return repos.LeaderboardMetrics.CreateBatch(ctx, metrics)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	leaderboard.Metrics = metrics
+	recordAudit(ctx, enums.AuditLeaderboard, leaderboard.ID, enums.AuditCreate, nil, &leaderboard)
 	return &leaderboard, nil
 }
 
-func (s *leaderboardService) GetLeaderboard(id uuid.UUID) (*models.Leaderboard, error) {
-	leaderboard, err := s.repo.FindByID(id)
+func (s *leaderboardService) GetLeaderboard(ctx context.Context, id uuid.UUID, include []string) (*models.Leaderboard, error) {
+	leaderboard, err := s.repo.FindByIDWithIncludes(ctx, id, include)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("leaderboard not found")
@@ -72,22 +153,32 @@ func (s *leaderboardService) GetLeaderboard(id uuid.UUID) (*models.Leaderboard,
 	return leaderboard, nil
 }
 
-func (s *leaderboardService) ListLeaderboards() ([]models.Leaderboard, error) {
-	return s.repo.FindAll()
+func (s *leaderboardService) ListLeaderboards(ctx context.Context, ids []uuid.UUID, category *string, leaderboardType *enums.LeaderboardType,
+	timeFrame *enums.TimeFrame, isActive *bool, visibilityScope *enums.VisibilityScope,
+	sort *repositories.Sort, include []string, includeDeleted bool) ([]models.Leaderboard, error) {
+	return s.repo.FindFiltered(ctx, ids, category, leaderboardType, timeFrame, isActive, visibilityScope, sort, include, includeDeleted)
 }
 
-func (s *leaderboardService) UpdateLeaderboard(id uuid.UUID, name, description, category *string,
+func (s *leaderboardService) UpdateLeaderboard(ctx context.Context, id uuid.UUID, name, description, category *string,
 	leaderboardType *enums.LeaderboardType, timeFrame *enums.TimeFrame,
 	startDate, endDate *string, sortOrder *enums.SortOrder,
-	visibilityScope *enums.VisibilityScope, maxEntries *int, isActive *bool) (*models.Leaderboard, error) {
+	visibilityScope *enums.VisibilityScope, maxEntries *int, maxEntriesPolicy *enums.MaxEntriesPolicy, isActive *bool,
+	teamScoreAggregation *enums.TeamScoreAggregation, teamScoreTopK *int, groupID *uuid.UUID, clearGroup bool,
+	version *int) (*models.Leaderboard, error) {
 
-	leaderboard, err := s.repo.FindByID(id)
+	// Read past the lookup cache, whose copy may be of an older version
+	leaderboard, err := s.repo.FindByIDForShare(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("leaderboard not found")
 		}
 		return nil, err
 	}
+	// Clients that send the version they read only update the leaderboard they saw
+	if version != nil && *version != leaderboard.Version {
+		return nil, errors.New("leaderboard version conflict")
+	}
+	before := *leaderboard
 
 	// Apply the updates to the leaderboard
 	if name != nil {
@@ -113,6 +204,9 @@ func (s *leaderboardService) UpdateLeaderboard(id uuid.UUID, name, description,
 		if endDate != nil {
 			leaderboard.EndDate = end
 		}
+		if err := validateDateRange(leaderboard.StartDate, leaderboard.EndDate); err != nil {
+			return nil, err
+		}
 	}
 	if sortOrder != nil {
 		leaderboard.SortOrder = *sortOrder
@@ -123,20 +217,44 @@ func (s *leaderboardService) UpdateLeaderboard(id uuid.UUID, name, description,
 	if maxEntries != nil {
 		leaderboard.MaxEntries = *maxEntries
 	}
+	if maxEntriesPolicy != nil {
+		leaderboard.MaxEntriesPolicy = *maxEntriesPolicy
+	}
 	if isActive != nil {
 		leaderboard.IsActive = *isActive
 	}
+	if teamScoreAggregation != nil {
+		leaderboard.TeamScoreAggregation = *teamScoreAggregation
+	}
+	if teamScoreTopK != nil {
+		leaderboard.TeamScoreTopK = *teamScoreTopK
+	}
+	if err := validateTeamScoring(leaderboard.TeamScoreAggregation, leaderboard.TeamScoreTopK); err != nil {
+		return nil, err
+	}
+	if clearGroup {
+		leaderboard.GroupID = nil
+	} else if groupID != nil {
+		if err := verifyGroup(ctx, s.participantRepo, *groupID); err != nil {
+			return nil, err
+		}
+		leaderboard.GroupID = groupID
+	}
 
-	err = s.repo.Update(leaderboard)
+	err = s.repo.Update(ctx, leaderboard)
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return nil, errors.New("leaderboard version conflict")
+		}
 		return nil, err
 	}
 
+	recordAudit(ctx, enums.AuditLeaderboard, leaderboard.ID, enums.AuditUpdate, &before, leaderboard)
 	return leaderboard, nil
 }
 
-func (s *leaderboardService) DeleteLeaderboard(id uuid.UUID) error {
-	_, err := s.repo.FindByID(id)
+func (s *leaderboardService) DeleteLeaderboard(ctx context.Context, id uuid.UUID, force bool) error {
+	leaderboard, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("leaderboard not found")
@@ -144,5 +262,142 @@ func (s *leaderboardService) DeleteLeaderboard(id uuid.UUID) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	// Entries and metrics get the leaderboard's deleted_at, so restoring it brings back only those deleted with it
+	err = s.unitOfWork.Do(ctx, func(repos repositories.Repositories) error {
+		if !force {
+			if err := verifyEmpty(ctx, repos, id); err != nil {
+				return err
+			}
+		}
+		if err := repos.Leaderboards.Delete(ctx, id); err != nil {
+			return err
+		}
+		deleted, err := repos.Leaderboards.FindDeletedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := repos.LeaderboardEntries.DeleteByLeaderboardID(ctx, id, deleted.DeletedAt.Time); err != nil {
+			return err
+		}
+		return repos.LeaderboardMetrics.DeleteByLeaderboardID(ctx, id, deleted.DeletedAt.Time)
+	})
+	if err != nil {
+		return err
+	}
+
+	// The entries deleted with the board are recorded by its deletion alone
+	recordAudit(ctx, enums.AuditLeaderboard, id, enums.AuditDelete, leaderboard, nil)
+	return nil
+}
+
+func (s *leaderboardService) RestoreLeaderboard(ctx context.Context, id uuid.UUID) (*models.Leaderboard, error) {
+	deleted, err := s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_, err = s.repo.FindByID(ctx, id)
+			return nil, notDeletedError("leaderboard", err)
+		}
+		return nil, err
+	}
+
+	err = s.unitOfWork.Do(ctx, func(repos repositories.Repositories) error {
+		if err := repos.Leaderboards.Restore(ctx, id); err != nil {
+			return err
+		}
+		if err := repos.LeaderboardEntries.RestoreByLeaderboardID(ctx, id, deleted.DeletedAt.Time); err != nil {
+			return err
+		}
+		return repos.LeaderboardMetrics.RestoreByLeaderboardID(ctx, id, deleted.DeletedAt.Time)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, enums.AuditLeaderboard, id, enums.AuditRestore, deleted, restored)
+	return restored, nil
+}
+
+// verifyEmpty returns a *LeaderboardNotEmptyError saying what would be deleted with the leaderboard when it has
+// entries
+func verifyEmpty(ctx context.Context, repos repositories.Repositories, leaderboardID uuid.UUID) error {
+	entries, err := repos.LeaderboardEntries.CountByLeaderboardID(ctx, leaderboardID)
+	if err != nil || entries == 0 {
+		return err
+	}
+	metrics, err := repos.LeaderboardMetrics.CountByLeaderboardID(ctx, leaderboardID)
+	if err != nil {
+		return err
+	}
+	return &LeaderboardNotEmptyError{Entries: entries, Metrics: metrics}
+}
+
+// validateDateRange rejects a leaderboard window that ends before or at the moment it starts
+func validateDateRange(start, end *time.Time) error {
+	if start == nil || end == nil {
+		return nil
+	}
+	if end.Before(*start) {
+		return errors.New("end_date is before start_date")
+	}
+	if end.Equal(*start) {
+		return errors.New("start_date and end_date are the same")
+	}
+	return nil
+}
+
+// validateTeamScoring checks that a top_k team board says how many member scores to count
+func validateTeamScoring(aggregation enums.TeamScoreAggregation, topK int) error {
+	if aggregation == enums.TeamScoreTopK && topK < 1 {
+		return errors.New("team_score_top_k is required for top_k aggregation")
+	}
+	return nil
+}
+
+// verifyGroup checks that a leaderboard's target group is a group participant
+func verifyGroup(ctx context.Context, participantRepo repositories.ParticipantRepository, groupID uuid.UUID) error {
+	group, err := participantRepo.FindByIDForShare(ctx, groupID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("group not found")
+		}
+		return err
+	}
+	if group.Type != GroupParticipantType {
+		return errors.New("group_id must be a group participant")
+	}
+	return nil
+}
+
+// verifyMetrics checks that the metrics to attach to a leaderboard exist and are each attached once
+func verifyMetrics(ctx context.Context, metricRepo repositories.MetricRepository, leaderboardMetrics []models.LeaderboardMetric) error {
+	if len(leaderboardMetrics) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(leaderboardMetrics))
+	listed := make(map[uuid.UUID]bool, len(leaderboardMetrics))
+	for i, leaderboardMetric := range leaderboardMetrics {
+		if listed[leaderboardMetric.MetricID] {
+			return errors.New("metric is listed more than once")
+		}
+		listed[leaderboardMetric.MetricID] = true
+		ids[i] = leaderboardMetric.MetricID
+	}
+	metrics, err := metricRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	found := make(map[uuid.UUID]bool, len(metrics))
+	for _, metric := range metrics {
+		found[metric.ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return errors.New("metric not found")
+		}
+	}
+	return nil
 }
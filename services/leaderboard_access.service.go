@@ -0,0 +1,339 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Viewer identifies who is reading leaderboards
+type Viewer struct {
+	UserID     *uuid.UUID // nil for callers that are not users, such as API keys
+	CanReadAll bool       // Set for leaderboard admins
+}
+
+// LeaderboardAccessService resolves and manages per-leaderboard roles and read access
+type LeaderboardAccessService interface {
+	// GetRole returns the user's role on the leaderboard, or an empty role if they have none
+	GetRole(ctx context.Context, leaderboardID, userID uuid.UUID) (enums.LeaderboardRole, error)
+	ListMembers(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardMember, error)
+	SetMember(ctx context.Context, leaderboardID, userID uuid.UUID, role enums.LeaderboardRole) (*models.LeaderboardMember, error)
+	RemoveMember(ctx context.Context, leaderboardID, userID uuid.UUID) error
+
+	// Private leaderboards are readable by their owner, members and the users of participants on the access list
+	CanRead(ctx context.Context, leaderboardID uuid.UUID, viewer Viewer) (bool, error)
+	FilterReadableLeaderboards(ctx context.Context, leaderboards []models.Leaderboard, viewer Viewer) ([]models.Leaderboard, error)
+	FilterReadableEntries(ctx context.Context, entries []models.LeaderboardEntry, viewer Viewer) ([]models.LeaderboardEntry, error)
+	// ReadableLeaderboardIDs returns the IDs of every leaderboard the viewer can read
+	ReadableLeaderboardIDs(ctx context.Context, viewer Viewer) ([]uuid.UUID, error)
+	ListParticipantAccess(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardParticipantAccess, error)
+	GrantParticipantAccess(ctx context.Context, leaderboardID, participantID uuid.UUID) (*models.LeaderboardParticipantAccess, error)
+	RevokeParticipantAccess(ctx context.Context, leaderboardID, participantID uuid.UUID) error
+}
+
+type leaderboardAccessService struct {
+	leaderboardRepo       repositories.LeaderboardRepository
+	memberRepo            repositories.LeaderboardMemberRepository
+	userRepo              repositories.UserRepository
+	participantRepo       repositories.ParticipantRepository
+	participantAccessRepo repositories.LeaderboardParticipantAccessRepository
+}
+
+func NewLeaderboardAccessService(leaderboardRepo repositories.LeaderboardRepository,
+	memberRepo repositories.LeaderboardMemberRepository,
+	userRepo repositories.UserRepository,
+	participantRepo repositories.ParticipantRepository,
+	participantAccessRepo repositories.LeaderboardParticipantAccessRepository) LeaderboardAccessService {
+	return &leaderboardAccessService{
+		leaderboardRepo:       leaderboardRepo,
+		memberRepo:            memberRepo,
+		userRepo:              userRepo,
+		participantRepo:       participantRepo,
+		participantAccessRepo: participantAccessRepo,
+	}
+}
+
+func (s *leaderboardAccessService) GetRole(ctx context.Context, leaderboardID, userID uuid.UUID) (enums.LeaderboardRole, error) {
+	leaderboard, err := s.getLeaderboard(ctx, leaderboardID)
+	if err != nil {
+		return "", err
+	}
+
+	if leaderboard.OwnerID != nil && *leaderboard.OwnerID == userID {
+		return enums.LeaderboardOwner, nil
+	}
+
+	member, err := s.memberRepo.Find(ctx, leaderboardID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return member.Role, nil
+}
+
+func (s *leaderboardAccessService) ListMembers(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardMember, error) {
+	if _, err := s.getLeaderboard(ctx, leaderboardID); err != nil {
+		return nil, err
+	}
+
+	return s.memberRepo.FindByLeaderboardID(ctx, leaderboardID)
+}
+
+func (s *leaderboardAccessService) SetMember(ctx context.Context, leaderboardID, userID uuid.UUID,
+	role enums.LeaderboardRole) (*models.LeaderboardMember, error) {
+
+	leaderboard, err := s.getLeaderboard(ctx, leaderboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ownership comes from the leaderboard itself and cannot be granted through membership
+	if role == enums.LeaderboardOwner {
+		return nil, errors.New("owner role cannot be assigned")
+	}
+	if leaderboard.OwnerID != nil && *leaderboard.OwnerID == userID {
+		return nil, errors.New("owner role cannot be assigned")
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	member := models.LeaderboardMember{
+		LeaderboardID: leaderboardID,
+		UserID:        userID,
+		Role:          role,
+	}
+
+	err = s.memberRepo.Upsert(ctx, &member)
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (s *leaderboardAccessService) RemoveMember(ctx context.Context, leaderboardID, userID uuid.UUID) error {
+	if _, err := s.getLeaderboard(ctx, leaderboardID); err != nil {
+		return err
+	}
+
+	if _, err := s.memberRepo.Find(ctx, leaderboardID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("member not found")
+		}
+		return err
+	}
+
+	return s.memberRepo.Delete(ctx, leaderboardID, userID)
+}
+
+func (s *leaderboardAccessService) CanRead(ctx context.Context, leaderboardID uuid.UUID, viewer Viewer) (bool, error) {
+	leaderboard, err := s.getLeaderboard(ctx, leaderboardID)
+	if err != nil {
+		return false, err
+	}
+
+	if leaderboard.VisibilityScope != enums.Private || viewer.CanReadAll {
+		return true, nil
+	}
+
+	readable, err := s.readableLeaderboardIDs(ctx, viewer)
+	if err != nil {
+		return false, err
+	}
+	return isReadable(leaderboard, viewer, readable), nil
+}
+
+func (s *leaderboardAccessService) FilterReadableLeaderboards(ctx context.Context, leaderboards []models.Leaderboard,
+	viewer Viewer) ([]models.Leaderboard, error) {
+
+	if viewer.CanReadAll {
+		return leaderboards, nil
+	}
+
+	readable, err := s.readableLeaderboardIDs(ctx, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]models.Leaderboard, 0, len(leaderboards))
+	for i := range leaderboards {
+		if isReadable(&leaderboards[i], viewer, readable) {
+			filtered = append(filtered, leaderboards[i])
+		}
+	}
+	return filtered, nil
+}
+
+func (s *leaderboardAccessService) FilterReadableEntries(ctx context.Context, entries []models.LeaderboardEntry,
+	viewer Viewer) ([]models.LeaderboardEntry, error) {
+
+	if viewer.CanReadAll || len(entries) == 0 {
+		return entries, nil
+	}
+
+	// Load the distinct leaderboards the entries belong to
+	seen := map[uuid.UUID]bool{}
+	var leaderboardIDs []uuid.UUID
+	for _, entry := range entries {
+		if !seen[entry.LeaderboardID] {
+			seen[entry.LeaderboardID] = true
+			leaderboardIDs = append(leaderboardIDs, entry.LeaderboardID)
+		}
+	}
+	leaderboards, err := s.leaderboardRepo.FindByIDs(ctx, leaderboardIDs)
+	if err != nil {
+		return nil, err
+	}
+	leaderboards, err = s.FilterReadableLeaderboards(ctx, leaderboards, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[uuid.UUID]bool, len(leaderboards))
+	for _, leaderboard := range leaderboards {
+		allowed[leaderboard.ID] = true
+	}
+
+	filtered := make([]models.LeaderboardEntry, 0, len(entries))
+	for _, entry := range entries {
+		if allowed[entry.LeaderboardID] {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered, nil
+}
+
+func (s *leaderboardAccessService) ReadableLeaderboardIDs(ctx context.Context, viewer Viewer) ([]uuid.UUID, error) {
+	leaderboards, err := s.leaderboardRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	leaderboards, err = s.FilterReadableLeaderboards(ctx, leaderboards, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboardIDs := make([]uuid.UUID, 0, len(leaderboards))
+	for _, leaderboard := range leaderboards {
+		leaderboardIDs = append(leaderboardIDs, leaderboard.ID)
+	}
+	return leaderboardIDs, nil
+}
+
+func (s *leaderboardAccessService) ListParticipantAccess(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardParticipantAccess, error) {
+	if _, err := s.getLeaderboard(ctx, leaderboardID); err != nil {
+		return nil, err
+	}
+
+	return s.participantAccessRepo.FindByLeaderboardID(ctx, leaderboardID)
+}
+
+func (s *leaderboardAccessService) GrantParticipantAccess(ctx context.Context, leaderboardID,
+	participantID uuid.UUID) (*models.LeaderboardParticipantAccess, error) {
+
+	if _, err := s.getLeaderboard(ctx, leaderboardID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.participantRepo.FindByID(ctx, participantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("participant not found")
+		}
+		return nil, err
+	}
+
+	access := models.LeaderboardParticipantAccess{
+		LeaderboardID: leaderboardID,
+		ParticipantID: participantID,
+	}
+
+	err := s.participantAccessRepo.Create(ctx, &access)
+	if err != nil {
+		return nil, err
+	}
+
+	return &access, nil
+}
+
+func (s *leaderboardAccessService) RevokeParticipantAccess(ctx context.Context, leaderboardID, participantID uuid.UUID) error {
+	if _, err := s.getLeaderboard(ctx, leaderboardID); err != nil {
+		return err
+	}
+
+	exists, err := s.participantAccessRepo.Exists(ctx, leaderboardID, participantID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("participant access not found")
+	}
+
+	return s.participantAccessRepo.Delete(ctx, leaderboardID, participantID)
+}
+
+// readableLeaderboardIDs collects the private leaderboards the viewer was granted access to
+func (s *leaderboardAccessService) readableLeaderboardIDs(ctx context.Context, viewer Viewer) (map[uuid.UUID]bool, error) {
+	readable := map[uuid.UUID]bool{}
+	if viewer.UserID == nil {
+		return readable, nil
+	}
+
+	members, err := s.memberRepo.FindByUserID(ctx, *viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		readable[member.LeaderboardID] = true
+	}
+
+	participant, err := s.participantRepo.FindByUserID(ctx, *viewer.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return readable, nil
+		}
+		return nil, err
+	}
+
+	accesses, err := s.participantAccessRepo.FindByParticipantID(ctx, participant.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, access := range accesses {
+		readable[access.LeaderboardID] = true
+	}
+
+	return readable, nil
+}
+
+func isReadable(leaderboard *models.Leaderboard, viewer Viewer, readable map[uuid.UUID]bool) bool {
+	if leaderboard.VisibilityScope != enums.Private {
+		return true
+	}
+	if viewer.UserID != nil && leaderboard.OwnerID != nil && *leaderboard.OwnerID == *viewer.UserID {
+		return true
+	}
+	return readable[leaderboard.ID]
+}
+
+func (s *leaderboardAccessService) getLeaderboard(ctx context.Context, leaderboardID uuid.UUID) (*models.Leaderboard, error) {
+	leaderboard, err := s.leaderboardRepo.FindByID(ctx, leaderboardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("leaderboard not found")
+		}
+		return nil, err
+	}
+	return leaderboard, nil
+}
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"leaderboard-service/enums"
 	"leaderboard-service/models"
 	"leaderboard-service/repositories"
 	"time"
@@ -11,70 +13,193 @@ import (
 )
 
 type LeaderboardEntryService interface {
-	CreateLeaderboardEntry(leaderboardID, participantID uuid.UUID, score float64, rank int, lastUpdated time.Time) (*models.LeaderboardEntry, error)
-	GetLeaderboardEntry(id uuid.UUID) (*models.LeaderboardEntry, error)
-	ListLeaderboardEntries() ([]models.LeaderboardEntry, error)
-	ListFilteredLeaderboardEntries(leaderboardID, participantID *uuid.UUID) ([]models.LeaderboardEntry, error)
-	UpdateLeaderboardEntry(id uuid.UUID, score *float64, rank *int, lastUpdated *time.Time) (*models.LeaderboardEntry, error)
-	DeleteLeaderboardEntry(id uuid.UUID) error
+	// CreateLeaderboardEntry returns an *EntryExistsError if the participant already has an entry on the leaderboard
+	CreateLeaderboardEntry(ctx context.Context, leaderboardID, participantID uuid.UUID, score float64, rank int, lastUpdated time.Time) (*models.LeaderboardEntry, error)
+	GetLeaderboardEntry(ctx context.Context, id uuid.UUID) (*models.LeaderboardEntry, error)
+	ListLeaderboardEntries(ctx context.Context) ([]models.LeaderboardEntry, error)
+	// ListFilteredLeaderboardEntries returns the entries of the leaderboard and participant when set. Soft-deleted entries
+	// are only listed when asked for.
+	ListFilteredLeaderboardEntries(ctx context.Context, leaderboardID, participantID *uuid.UUID, sort *repositories.Sort, includeDeleted bool) ([]models.LeaderboardEntry, error)
+	// UpdateLeaderboardEntry updates the entry unless it changed since it was read, or since version when that is set
+	UpdateLeaderboardEntry(ctx context.Context, id uuid.UUID, score *float64, rank *int, lastUpdated *time.Time, version *int) (*models.LeaderboardEntry, error)
+	// IncrementScore adds delta to the entry's score and re-ranks its leaderboard. While the score buffer is enabled
+	// the increment is only recorded and applied with the next flush, reported by buffered, and the entry is returned
+	// as it was before.
+	IncrementScore(ctx context.Context, id uuid.UUID, delta float64) (entry *models.LeaderboardEntry, buffered bool, err error)
+	DeleteLeaderboardEntry(ctx context.Context, id uuid.UUID) error
+	// RestoreLeaderboardEntry undoes the soft delete of an entry whose leaderboard and participant are still live, unless
+	// the participant has a new entry on the leaderboard since, which is returned in an *EntryExistsError
+	RestoreLeaderboardEntry(ctx context.Context, id uuid.UUID) (*models.LeaderboardEntry, error)
 
 	// Verification methods
-	VerifyLeaderboardExists(leaderboardID uuid.UUID) error
-	VerifyParticipantExists(participantID uuid.UUID) error
+	VerifyLeaderboardExists(ctx context.Context, leaderboardID uuid.UUID) error
+	VerifyParticipantExists(ctx context.Context, participantID uuid.UUID) error
+}
+
+// EntryExistsError is returned when a participant would get a second live entry on a leaderboard
+type EntryExistsError struct {
+	Entry *models.LeaderboardEntry // The participant's existing entry
+}
+
+func (e *EntryExistsError) Error() string {
+	return "participant already has an entry on the leaderboard"
+}
+
+// RankChange is the payload of an entry.rank_changed event
+type RankChange struct {
+	Entry        *models.LeaderboardEntry `json:"entry"`
+	PreviousRank int                      `json:"previous_rank"`
+	CurrentRank  int                      `json:"current_rank"`
 }
 
 type leaderboardEntryService struct {
 	repo            repositories.LeaderboardEntryRepository
 	leaderboardRepo repositories.LeaderboardRepository
 	participantRepo repositories.ParticipantRepository
+	incrementRepo   repositories.ScoreIncrementRepository
+	unitOfWork      repositories.UnitOfWork
 }
 
 func NewLeaderboardEntryService(repo repositories.LeaderboardEntryRepository,
 	leaderboardRepo repositories.LeaderboardRepository,
-	participantRepo repositories.ParticipantRepository) LeaderboardEntryService {
+	participantRepo repositories.ParticipantRepository,
+	incrementRepo repositories.ScoreIncrementRepository,
+	unitOfWork repositories.UnitOfWork) LeaderboardEntryService {
 	return &leaderboardEntryService{
 		repo:            repo,
 		leaderboardRepo: leaderboardRepo,
 		participantRepo: participantRepo,
+		incrementRepo:   incrementRepo,
+		unitOfWork:      unitOfWork,
 	}
 }
 
-func (s *leaderboardEntryService) CreateLeaderboardEntry(leaderboardID, participantID uuid.UUID,
+func (s *leaderboardEntryService) CreateLeaderboardEntry(ctx context.Context, leaderboardID, participantID uuid.UUID,
 	score float64, rank int, lastUpdated time.Time) (*models.LeaderboardEntry, error) {
 
-	// Verify leaderboard exists
-	if err := s.VerifyLeaderboardExists(leaderboardID); err != nil {
-		return nil, err
+	// Set lastUpdated to current time if not provided
+	if lastUpdated.IsZero() {
+		lastUpdated = time.Now()
 	}
 
-	// Verify participant exists
-	if err := s.VerifyParticipantExists(participantID); err != nil {
+	// The leaderboard and participant are locked until the entry is created, so neither can be deleted in between.
+	// Entries for the same leaderboard are created one at a time, so each counts the ones before it against
+	// max_entries.
+	var entry models.LeaderboardEntry
+	var evicted *models.LeaderboardEntry
+	err := s.unitOfWork.Do(ctx, func(repos repositories.Repositories) error {
+		leaderboard, err := repos.Leaderboards.FindByIDForUpdate(ctx, leaderboardID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("leaderboard not found")
+			}
+			return err
+		}
+
+		participant, err := repos.Participants.FindByIDForShare(ctx, participantID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("participant not found")
+			}
+			return err
+		}
+		if leaderboard.HasEnded(time.Now()) {
+			return errors.New("leaderboard has ended")
+		}
+
+		// Individual boards rank individuals and team boards rank teams
+		if participant.Type != string(leaderboard.Type) {
+			return errors.New("participant type does not match the leaderboard")
+		}
+
+		// Group leaderboards only rank participants within the group
+		if leaderboard.GroupID != nil {
+			inGroup, err := isInGroup(ctx, repos.Participants, participantID, *leaderboard.GroupID)
+			if err != nil {
+				return err
+			}
+			if !inGroup {
+				return errors.New("participant is not in the leaderboard's group")
+			}
+		}
+
+		if err := verifyNoEntry(ctx, repos.LeaderboardEntries, leaderboardID, participantID); err != nil {
+			return err
+		}
+
+		evicted, err = makeRoomForEntry(ctx, repos.LeaderboardEntries, leaderboard, score)
+		if err != nil {
+			return err
+		}
+
+		entry = models.LeaderboardEntry{
+			LeaderboardID:   leaderboardID,
+			ParticipantID:   participantID,
+			Score:           score,
+			Rank:            rank,
+			LastUpdated:     lastUpdated,
+			ParticipantName: participant.ShownName(),
+			AvatarURL:       participant.AvatarURL,
+		}
+		return repos.LeaderboardEntries.Create(ctx, &entry)
+	})
+	if err != nil {
+		if repositories.IsUniqueViolation(err) {
+			// Another request created the participant's entry at the same time
+			if existsErr := verifyNoEntry(ctx, s.repo, leaderboardID, participantID); existsErr != nil {
+				return nil, existsErr
+			}
+		}
 		return nil, err
 	}
 
-	// Set lastUpdated to current time if not provided
-	if lastUpdated.IsZero() {
-		lastUpdated = time.Now()
+	if evicted != nil {
+		recordAudit(ctx, enums.AuditLeaderboardEntry, evicted.ID, enums.AuditDelete, evicted, nil)
+		publishEvent(enums.EntryDeleted, &evicted.LeaderboardID, evicted)
 	}
+	recordAudit(ctx, enums.AuditLeaderboardEntry, entry.ID, enums.AuditCreate, nil, &entry)
+	publishEvent(enums.EntryCreated, &entry.LeaderboardID, &entry)
 
-	entry := models.LeaderboardEntry{
-		LeaderboardID: leaderboardID,
-		ParticipantID: participantID,
-		Score:         score,
-		Rank:          rank,
-		LastUpdated:   lastUpdated,
-	}
+	return &entry, nil
+}
 
-	err := s.repo.Create(&entry)
+// makeRoomForEntry checks a new entry with the score against the leaderboard's max_entries. Under the evict_last
+// policy a full board deletes the entry in last place when the new score beats it, and returns that entry.
+func makeRoomForEntry(ctx context.Context, repo repositories.LeaderboardEntryRepository, leaderboard *models.Leaderboard,
+	score float64) (*models.LeaderboardEntry, error) {
+	if leaderboard.MaxEntries <= 0 {
+		return nil, nil
+	}
+	count, err := repo.CountByLeaderboardID(ctx, leaderboard.ID)
 	if err != nil {
 		return nil, err
 	}
+	if count < int64(leaderboard.MaxEntries) {
+		return nil, nil
+	}
+	if leaderboard.MaxEntriesPolicy != enums.MaxEntriesEvictLast {
+		return nil, errors.New("leaderboard has reached max_entries")
+	}
 
-	return &entry, nil
+	last, err := repo.FindLast(ctx, leaderboard.ID, leaderboard.SortOrder)
+	if err != nil {
+		return nil, err
+	}
+	beatsLast := score > last.Score
+	if leaderboard.SortOrder == enums.Ascending {
+		beatsLast = score < last.Score
+	}
+	if !beatsLast {
+		return nil, errors.New("leaderboard has reached max_entries")
+	}
+	if err := repo.Delete(ctx, last.ID); err != nil {
+		return nil, err
+	}
+	return last, nil
 }
 
-func (s *leaderboardEntryService) GetLeaderboardEntry(id uuid.UUID) (*models.LeaderboardEntry, error) {
-	entry, err := s.repo.FindByID(id)
+func (s *leaderboardEntryService) GetLeaderboardEntry(ctx context.Context, id uuid.UUID) (*models.LeaderboardEntry, error) {
+	entry, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("leaderboard entry not found")
@@ -84,24 +209,61 @@ func (s *leaderboardEntryService) GetLeaderboardEntry(id uuid.UUID) (*models.Lea
 	return entry, nil
 }
 
-func (s *leaderboardEntryService) ListLeaderboardEntries() ([]models.LeaderboardEntry, error) {
-	return s.repo.FindAll()
+func (s *leaderboardEntryService) ListLeaderboardEntries(ctx context.Context) ([]models.LeaderboardEntry, error) {
+	return s.repo.FindAll(ctx)
 }
 
-func (s *leaderboardEntryService) ListFilteredLeaderboardEntries(leaderboardID, participantID *uuid.UUID) ([]models.LeaderboardEntry, error) {
-	return s.repo.FindFiltered(leaderboardID, participantID)
+func (s *leaderboardEntryService) ListFilteredLeaderboardEntries(ctx context.Context, leaderboardID, participantID *uuid.UUID,
+	sort *repositories.Sort, includeDeleted bool) ([]models.LeaderboardEntry, error) {
+	entries, err := s.repo.FindFiltered(ctx, leaderboardID, participantID, sort, includeDeleted)
+	if err != nil || participantID != nil {
+		return entries, err
+	}
+
+	// Inactive participants are left out of standings; a participant's own history still includes them
+	return activeEntries(entries), nil
 }
 
-func (s *leaderboardEntryService) UpdateLeaderboardEntry(id uuid.UUID, score *float64,
-	rank *int, lastUpdated *time.Time) (*models.LeaderboardEntry, error) {
+// activeEntries drops the entries of inactive participants
+func activeEntries(entries []models.LeaderboardEntry) []models.LeaderboardEntry {
+	active := entries[:0]
+	for _, entry := range entries {
+		if entry.Participant != nil && !entry.Participant.IsActive {
+			continue
+		}
+		active = append(active, entry)
+	}
+	return active
+}
+
+func (s *leaderboardEntryService) UpdateLeaderboardEntry(ctx context.Context, id uuid.UUID, score *float64,
+	rank *int, lastUpdated *time.Time, version *int) (*models.LeaderboardEntry, error) {
 
-	entry, err := s.repo.FindByID(id)
+	entry, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("leaderboard entry not found")
 		}
 		return nil, err
 	}
+	if version != nil && *version != entry.Version {
+		return nil, errors.New("leaderboard entry version conflict")
+	}
+
+	leaderboard, err := s.leaderboardRepo.FindByID(ctx, entry.LeaderboardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("leaderboard not found")
+		}
+		return nil, err
+	}
+	// The standings of an ended board are final
+	if leaderboard.HasEnded(time.Now()) {
+		return nil, errors.New("leaderboard has ended")
+	}
+
+	before := *entry
+	previousRank := entry.Rank
 
 	// Apply the updates to the entry
 	if score != nil {
@@ -117,16 +279,87 @@ func (s *leaderboardEntryService) UpdateLeaderboardEntry(id uuid.UUID, score *fl
 		entry.LastUpdated = time.Now()
 	}
 
-	err = s.repo.Update(entry)
+	err = s.repo.Update(ctx, entry)
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			// Another update or a re-rank got there first
+			return nil, errors.New("leaderboard entry version conflict")
+		}
 		return nil, err
 	}
 
+	recordAudit(ctx, enums.AuditLeaderboardEntry, entry.ID, enums.AuditUpdate, &before, entry)
+	publishEvent(enums.EntryUpdated, &entry.LeaderboardID, entry)
+	if entry.Rank != previousRank {
+		publishEvent(enums.EntryRankChanged, &entry.LeaderboardID, RankChange{
+			Entry:        entry,
+			PreviousRank: previousRank,
+			CurrentRank:  entry.Rank,
+		})
+	}
+
 	return entry, nil
 }
 
-func (s *leaderboardEntryService) DeleteLeaderboardEntry(id uuid.UUID) error {
-	_, err := s.repo.FindByID(id)
+func (s *leaderboardEntryService) IncrementScore(ctx context.Context, id uuid.UUID, delta float64) (*models.LeaderboardEntry, bool, error) {
+	entry, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, errors.New("leaderboard entry not found")
+		}
+		return nil, false, err
+	}
+
+	leaderboard, err := s.leaderboardRepo.FindByID(ctx, entry.LeaderboardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, errors.New("leaderboard not found")
+		}
+		return nil, false, err
+	}
+	// Team scores are rolled up from their members', so an increment would be lost on the next recompute
+	if leaderboard.Type == enums.Team {
+		return nil, false, errors.New("team leaderboard scores can't be incremented")
+	}
+	if leaderboard.HasEnded(time.Now()) {
+		return nil, false, errors.New("leaderboard has ended")
+	}
+
+	eventID, err := s.incrementRepo.Record(ctx, entry.LeaderboardID, repositories.ScoreIncrement{EntryID: entry.ID, Delta: delta})
+	if err != nil {
+		return nil, false, err
+	}
+	scoreIncrementsRecorded.Inc()
+	if scoreBuffer != nil && FeatureEnabled(enums.FeatureScoreBuffer) {
+		// Buffered increments are applied in bulk later and, like re-ranks, aren't audited one by one
+		scoreBuffer.add(eventID)
+		return entry, true, nil
+	}
+
+	now := time.Now()
+	incremented, err := s.incrementRepo.Apply(ctx, []uint64{eventID}, now)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(incremented) == 0 {
+		// Deleted since it was read
+		return nil, false, errors.New("leaderboard entry not found")
+	}
+	if err := rankIncrementedEntries(ctx, s.repo, s.leaderboardRepo, entry.LeaderboardID, []uuid.UUID{entry.ID}, now); err != nil {
+		return nil, false, err
+	}
+
+	before := entry
+	entry, err = s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	recordAudit(ctx, enums.AuditLeaderboardEntry, entry.ID, enums.AuditUpdate, before, entry)
+	return entry, false, nil
+}
+
+func (s *leaderboardEntryService) DeleteLeaderboardEntry(ctx context.Context, id uuid.UUID) error {
+	entry, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("leaderboard entry not found")
@@ -134,12 +367,77 @@ func (s *leaderboardEntryService) DeleteLeaderboardEntry(id uuid.UUID) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, enums.AuditLeaderboardEntry, id, enums.AuditDelete, entry, nil)
+	publishEvent(enums.EntryDeleted, &entry.LeaderboardID, entry)
+	return nil
+}
+
+func (s *leaderboardEntryService) RestoreLeaderboardEntry(ctx context.Context, id uuid.UUID) (*models.LeaderboardEntry, error) {
+	deleted, err := s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_, err = s.repo.FindByID(ctx, id)
+			return nil, notDeletedError("leaderboard entry", err)
+		}
+		return nil, err
+	}
+
+	// An entry can only come back onto a leaderboard, and for a participant, that still exist
+	if err := s.VerifyLeaderboardExists(ctx, deleted.LeaderboardID); err != nil {
+		if err.Error() == "leaderboard not found" {
+			return nil, errors.New("leaderboard of the entry is deleted")
+		}
+		return nil, err
+	}
+	if err := s.VerifyParticipantExists(ctx, deleted.ParticipantID); err != nil {
+		if err.Error() == "participant not found" {
+			return nil, errors.New("participant of the entry is deleted")
+		}
+		return nil, err
+	}
+
+	if err := verifyNoEntry(ctx, s.repo, deleted.LeaderboardID, deleted.ParticipantID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Restore(ctx, id); err != nil {
+		if repositories.IsUniqueViolation(err) {
+			if existsErr := verifyNoEntry(ctx, s.repo, deleted.LeaderboardID, deleted.ParticipantID); existsErr != nil {
+				return nil, existsErr
+			}
+		}
+		return nil, err
+	}
+
+	entry, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, enums.AuditLeaderboardEntry, id, enums.AuditRestore, deleted, entry)
+	publishEvent(enums.EntryCreated, &entry.LeaderboardID, entry)
+	return entry, nil
+}
+
+// verifyNoEntry returns an *EntryExistsError when the participant has a live entry on the leaderboard
+func verifyNoEntry(ctx context.Context, entryRepo repositories.LeaderboardEntryRepository, leaderboardID, participantID uuid.UUID) error {
+	existing, err := entryRepo.FindByLeaderboardAndParticipant(ctx, leaderboardID, participantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	return &EntryExistsError{Entry: existing}
 }
 
 // Verify that a leaderboard exists
-func (s *leaderboardEntryService) VerifyLeaderboardExists(leaderboardID uuid.UUID) error {
-	_, err := s.leaderboardRepo.FindByID(leaderboardID)
+func (s *leaderboardEntryService) VerifyLeaderboardExists(ctx context.Context, leaderboardID uuid.UUID) error {
+	_, err := s.leaderboardRepo.FindByID(ctx, leaderboardID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("leaderboard not found")
@@ -150,8 +448,8 @@ func (s *leaderboardEntryService) VerifyLeaderboardExists(leaderboardID uuid.UUI
 }
 
 // Verify that a participant exists
-func (s *leaderboardEntryService) VerifyParticipantExists(participantID uuid.UUID) error {
-	_, err := s.participantRepo.FindByID(participantID)
+func (s *leaderboardEntryService) VerifyParticipantExists(ctx context.Context, participantID uuid.UUID) error {
+	_, err := s.participantRepo.FindByID(ctx, participantID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("participant not found")
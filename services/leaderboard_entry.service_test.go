@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+)
+
+// capacityEntryRepo holds a leaderboard's entries in memory for the max_entries checks, recording what is deleted
+type capacityEntryRepo struct {
+	repositories.LeaderboardEntryRepository
+	entries []models.LeaderboardEntry
+	deleted []uuid.UUID
+}
+
+func (r *capacityEntryRepo) CountByLeaderboardID(context.Context, uuid.UUID) (int64, error) {
+	return int64(len(r.entries)), nil
+}
+
+func (r *capacityEntryRepo) FindLast(_ context.Context, _ uuid.UUID, sortOrder enums.SortOrder) (*models.LeaderboardEntry, error) {
+	last := r.entries[0]
+	for _, entry := range r.entries[1:] {
+		if (sortOrder == enums.Ascending && entry.Score > last.Score) ||
+			(sortOrder != enums.Ascending && entry.Score < last.Score) {
+			last = entry
+		}
+	}
+	return &last, nil
+}
+
+func (r *capacityEntryRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func TestMakeRoomForEntry(t *testing.T) {
+	// Scores 10, 20 and 30; the last place is 10 when higher scores win and 30 when lower ones do
+	lowestID, middleID, highestID := uuid.New(), uuid.New(), uuid.New()
+	entries := []models.LeaderboardEntry{
+		{BaseModel: models.BaseModel{ID: middleID}, Score: 20},
+		{BaseModel: models.BaseModel{ID: lowestID}, Score: 10},
+		{BaseModel: models.BaseModel{ID: highestID}, Score: 30},
+	}
+
+	testCases := []struct {
+		name            string
+		maxEntries      int
+		policy          enums.MaxEntriesPolicy
+		sortOrder       enums.SortOrder
+		score           float64
+		expectedValid   bool
+		expectedEvicted *uuid.UUID
+	}{
+		{
+			name:          "No limit",
+			maxEntries:    0,
+			policy:        enums.MaxEntriesReject,
+			sortOrder:     enums.Descending,
+			score:         5,
+			expectedValid: true,
+		},
+		{
+			name:          "Room left",
+			maxEntries:    4,
+			policy:        enums.MaxEntriesReject,
+			sortOrder:     enums.Descending,
+			score:         5,
+			expectedValid: true,
+		},
+		{
+			name:          "Full board rejects even a winning score",
+			maxEntries:    3,
+			policy:        enums.MaxEntriesReject,
+			sortOrder:     enums.Descending,
+			score:         100,
+			expectedValid: false,
+		},
+		{
+			name:            "Descending, evicts the lowest score for a higher one",
+			maxEntries:      3,
+			policy:          enums.MaxEntriesEvictLast,
+			sortOrder:       enums.Descending,
+			score:           15,
+			expectedValid:   true,
+			expectedEvicted: &lowestID,
+		},
+		{
+			name:          "Descending, rejects a lower score",
+			maxEntries:    3,
+			policy:        enums.MaxEntriesEvictLast,
+			sortOrder:     enums.Descending,
+			score:         5,
+			expectedValid: false,
+		},
+		{
+			name:          "Descending, rejects a score tied with last place",
+			maxEntries:    3,
+			policy:        enums.MaxEntriesEvictLast,
+			sortOrder:     enums.Descending,
+			score:         10,
+			expectedValid: false,
+		},
+		{
+			name:            "Ascending, evicts the highest score for a lower one",
+			maxEntries:      3,
+			policy:          enums.MaxEntriesEvictLast,
+			sortOrder:       enums.Ascending,
+			score:           25,
+			expectedValid:   true,
+			expectedEvicted: &highestID,
+		},
+		{
+			name:          "Ascending, rejects a higher score",
+			maxEntries:    3,
+			policy:        enums.MaxEntriesEvictLast,
+			sortOrder:     enums.Ascending,
+			score:         35,
+			expectedValid: false,
+		},
+		{
+			name:          "Ascending, rejects a score tied with last place",
+			maxEntries:    3,
+			policy:        enums.MaxEntriesEvictLast,
+			sortOrder:     enums.Ascending,
+			score:         30,
+			expectedValid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &capacityEntryRepo{entries: entries}
+			leaderboard := &models.Leaderboard{
+				MaxEntries:       tc.maxEntries,
+				MaxEntriesPolicy: tc.policy,
+				SortOrder:        tc.sortOrder,
+			}
+
+			evicted, err := makeRoomForEntry(context.Background(), repo, leaderboard, tc.score)
+			isValid := err == nil
+
+			if isValid != tc.expectedValid {
+				if tc.expectedValid {
+					t.Fatalf("Expected the entry to fit but got error: %v", err)
+				}
+				t.Fatalf("Expected the entry to be rejected but it fit")
+			}
+			if !isValid && err.Error() != "leaderboard has reached max_entries" {
+				t.Errorf("Expected the max_entries error but got %q", err.Error())
+			}
+
+			if tc.expectedEvicted == nil {
+				if evicted != nil || len(repo.deleted) > 0 {
+					t.Errorf("Expected nothing to be evicted but got %v", repo.deleted)
+				}
+				return
+			}
+			if evicted == nil || evicted.ID != *tc.expectedEvicted {
+				t.Fatalf("Expected entry %s to be evicted but got %v", *tc.expectedEvicted, evicted)
+			}
+			if len(repo.deleted) != 1 || repo.deleted[0] != *tc.expectedEvicted {
+				t.Errorf("Expected only entry %s to be deleted but got %v", *tc.expectedEvicted, repo.deleted)
+			}
+		})
+	}
+}
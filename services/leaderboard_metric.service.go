@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LeaderboardMetricService interface {
+	// CreateLeaderboardMetric returns a *LeaderboardMetricExistsError if the metric is already attached to the
+	// leaderboard
+	CreateLeaderboardMetric(ctx context.Context, leaderboardID, metricID uuid.UUID, weight float64, displayPriority int) (*models.LeaderboardMetric, error)
+	GetLeaderboardMetric(ctx context.Context, id uuid.UUID) (*models.LeaderboardMetric, error)
+	// ListLeaderboardMetrics lists the metrics of a leaderboard, or of every leaderboard when leaderboardID is nil.
+	// Unless readableIDs is nil, the metrics of leaderboards not in it are left out.
+	ListLeaderboardMetrics(ctx context.Context, leaderboardID *uuid.UUID, readableIDs []uuid.UUID) ([]models.LeaderboardMetric, error)
+	UpdateLeaderboardMetric(ctx context.Context, id uuid.UUID, weight *float64, displayPriority *int) (*models.LeaderboardMetric, error)
+	DeleteLeaderboardMetric(ctx context.Context, id uuid.UUID) error
+}
+
+// LeaderboardMetricExistsError is returned when a metric would be attached to a leaderboard a second time
+type LeaderboardMetricExistsError struct {
+	LeaderboardMetric *models.LeaderboardMetric // The existing association
+}
+
+func (e *LeaderboardMetricExistsError) Error() string {
+	return "metric is already attached to the leaderboard"
+}
+
+type leaderboardMetricService struct {
+	repo       repositories.LeaderboardMetricRepository
+	unitOfWork repositories.UnitOfWork
+}
+
+func NewLeaderboardMetricService(repo repositories.LeaderboardMetricRepository,
+	unitOfWork repositories.UnitOfWork) LeaderboardMetricService {
+	return &leaderboardMetricService{
+		repo:       repo,
+		unitOfWork: unitOfWork,
+	}
+}
+
+func (s *leaderboardMetricService) CreateLeaderboardMetric(ctx context.Context, leaderboardID, metricID uuid.UUID, weight float64,
+	displayPriority int) (*models.LeaderboardMetric, error) {
+
+	leaderboardMetric := models.LeaderboardMetric{
+		LeaderboardID:   leaderboardID,
+		MetricID:        metricID,
+		Weight:          weight,
+		DisplayPriority: displayPriority,
+	}
+
+	// The leaderboard is locked until the metric is attached, so it can't be deleted in between
+	err := s.unitOfWork.Do(ctx, func(repos repositories.Repositories) error {
+		if _, err := repos.Leaderboards.FindByIDForShare(ctx, leaderboardID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("leaderboard not found")
+			}
+			return err
+		}
+		if err := verifyMetrics(ctx, repos.Metrics, []models.LeaderboardMetric{leaderboardMetric}); err != nil {
+			return err
+		}
+		if err := verifyNotAttached(ctx, repos.LeaderboardMetrics, leaderboardID, metricID); err != nil {
+			return err
+		}
+		return repos.LeaderboardMetrics.Create(ctx, &leaderboardMetric)
+	})
+	if err != nil {
+		if repositories.IsUniqueViolation(err) {
+			// Another request attached the metric at the same time
+			if existsErr := verifyNotAttached(ctx, s.repo, leaderboardID, metricID); existsErr != nil {
+				return nil, existsErr
+			}
+		}
+		return nil, err
+	}
+
+	return &leaderboardMetric, nil
+}
+
+func (s *leaderboardMetricService) GetLeaderboardMetric(ctx context.Context, id uuid.UUID) (*models.LeaderboardMetric, error) {
+	leaderboardMetric, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("leaderboard metric not found")
+		}
+		return nil, err
+	}
+	return leaderboardMetric, nil
+}
+
+func (s *leaderboardMetricService) ListLeaderboardMetrics(ctx context.Context, leaderboardID *uuid.UUID,
+	readableIDs []uuid.UUID) ([]models.LeaderboardMetric, error) {
+	return s.repo.FindFiltered(ctx, leaderboardID, readableIDs)
+}
+
+func (s *leaderboardMetricService) UpdateLeaderboardMetric(ctx context.Context, id uuid.UUID, weight *float64,
+	displayPriority *int) (*models.LeaderboardMetric, error) {
+
+	leaderboardMetric, err := s.GetLeaderboardMetric(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the updates to the metric
+	if weight != nil {
+		leaderboardMetric.Weight = *weight
+	}
+	if displayPriority != nil {
+		leaderboardMetric.DisplayPriority = *displayPriority
+	}
+
+	if err := s.repo.Update(ctx, leaderboardMetric); err != nil {
+		return nil, err
+	}
+
+	return leaderboardMetric, nil
+}
+
+func (s *leaderboardMetricService) DeleteLeaderboardMetric(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetLeaderboardMetric(ctx, id); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// verifyNotAttached returns a *LeaderboardMetricExistsError when the metric is attached to the leaderboard
+func verifyNotAttached(ctx context.Context, repo repositories.LeaderboardMetricRepository, leaderboardID, metricID uuid.UUID) error {
+	existing, err := repo.FindByLeaderboardAndMetric(ctx, leaderboardID, metricID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	return &LeaderboardMetricExistsError{LeaderboardMetric: existing}
+}
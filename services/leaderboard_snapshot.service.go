@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SnapshotDiff is how a leaderboard's standings moved from one snapshot to another
+type SnapshotDiff struct {
+	LeaderboardID  uuid.UUID             `json:"leaderboard_id"`
+	FromSnapshotID uuid.UUID             `json:"from_snapshot_id"`
+	FromTakenAt    time.Time             `json:"from_taken_at"`
+	ToSnapshotID   uuid.UUID             `json:"to_snapshot_id"`
+	ToTakenAt      time.Time             `json:"to_taken_at"`
+	Movements      []ParticipantMovement `json:"movements"` // Best rank in the later snapshot first, then those who dropped off
+}
+
+// ParticipantMovement is one participant's change between two snapshots. Ranks and scores are unset for the
+// snapshot the participant is not in.
+type ParticipantMovement struct {
+	ParticipantID uuid.UUID           `json:"participant_id"`
+	Participant   *models.Participant `json:"participant,omitempty"`
+	FromRank      *int                `json:"from_rank,omitempty"`
+	ToRank        *int                `json:"to_rank,omitempty"`
+	Trend         Trend               `json:"trend"`
+	RankChange    int                 `json:"rank_change"` // Places gained, negative when places were lost
+	FromScore     *float64            `json:"from_score,omitempty"`
+	ToScore       *float64            `json:"to_score,omitempty"`
+	ScoreChange   float64             `json:"score_change"`
+}
+
+type LeaderboardSnapshotService interface {
+	// CreateSnapshot saves the leaderboard's current standings with their metric breakdowns
+	CreateSnapshot(ctx context.Context, leaderboardID uuid.UUID) (*models.LeaderboardSnapshot, error)
+	// ListSnapshots returns the leaderboard's snapshots newest first, without their entries
+	ListSnapshots(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardSnapshot, error)
+	// GetSnapshot returns one of the leaderboard's snapshots with its entries best rank first
+	GetSnapshot(ctx context.Context, leaderboardID, snapshotID uuid.UUID) (*models.LeaderboardSnapshot, error)
+	// DiffSnapshots compares every participant's rank and score from one of the leaderboard's snapshots to another
+	DiffSnapshots(ctx context.Context, leaderboardID, fromSnapshotID, toSnapshotID uuid.UUID) (*SnapshotDiff, error)
+}
+
+type leaderboardSnapshotService struct {
+	repo             repositories.LeaderboardSnapshotRepository
+	leaderboardRepo  repositories.LeaderboardRepository
+	standingsService StandingsService
+}
+
+func NewLeaderboardSnapshotService(repo repositories.LeaderboardSnapshotRepository,
+	leaderboardRepo repositories.LeaderboardRepository,
+	standingsService StandingsService) LeaderboardSnapshotService {
+	return &leaderboardSnapshotService{
+		repo:             repo,
+		leaderboardRepo:  leaderboardRepo,
+		standingsService: standingsService,
+	}
+}
+
+func (s *leaderboardSnapshotService) CreateSnapshot(ctx context.Context, leaderboardID uuid.UUID) (*models.LeaderboardSnapshot, error) {
+	standings, err := s.standingsService.ListStandings(ctx, leaderboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &models.LeaderboardSnapshot{
+		LeaderboardID: leaderboardID,
+		TakenAt:       time.Now().UTC(),
+		EntryCount:    len(standings),
+		Entries:       make([]models.LeaderboardSnapshotEntry, 0, len(standings)),
+	}
+	for _, standing := range standings {
+		entry := models.LeaderboardSnapshotEntry{
+			ParticipantID: standing.ParticipantID,
+			Rank:          standing.Rank,
+			Score:         standing.Score,
+			Metrics:       make([]models.SnapshotMetric, 0, len(standing.Metrics)),
+		}
+		for _, metric := range standing.Metrics {
+			entry.Metrics = append(entry.Metrics, models.SnapshotMetric{
+				MetricID:      metric.MetricID,
+				MetricName:    metric.MetricName,
+				Value:         metric.Aggregate.Value,
+				Weight:        metric.Weight,
+				WeightedValue: metric.WeightedValue,
+			})
+		}
+		snapshot.Entries = append(snapshot.Entries, entry)
+	}
+
+	if err := s.repo.Create(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (s *leaderboardSnapshotService) ListSnapshots(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardSnapshot, error) {
+	if _, err := s.leaderboardRepo.FindByID(ctx, leaderboardID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("leaderboard not found")
+		}
+		return nil, err
+	}
+	return s.repo.FindByLeaderboardID(ctx, leaderboardID)
+}
+
+func (s *leaderboardSnapshotService) GetSnapshot(ctx context.Context, leaderboardID, snapshotID uuid.UUID) (*models.LeaderboardSnapshot, error) {
+	snapshot, err := s.repo.FindByID(ctx, snapshotID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("snapshot not found")
+		}
+		return nil, err
+	}
+	// Snapshots are only reachable through their own leaderboard, whose read access has been checked
+	if snapshot.LeaderboardID != leaderboardID {
+		return nil, errors.New("snapshot not found")
+	}
+	return snapshot, nil
+}
+
+func (s *leaderboardSnapshotService) DiffSnapshots(ctx context.Context, leaderboardID, fromSnapshotID, toSnapshotID uuid.UUID) (*SnapshotDiff, error) {
+	from, err := s.GetSnapshot(ctx, leaderboardID, fromSnapshotID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.GetSnapshot(ctx, leaderboardID, toSnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &SnapshotDiff{
+		LeaderboardID:  leaderboardID,
+		FromSnapshotID: from.ID,
+		FromTakenAt:    from.TakenAt,
+		ToSnapshotID:   to.ID,
+		ToTakenAt:      to.TakenAt,
+		Movements:      make([]ParticipantMovement, 0, len(to.Entries)),
+	}
+
+	before := make(map[uuid.UUID]*models.LeaderboardSnapshotEntry, len(from.Entries))
+	for i := range from.Entries {
+		before[from.Entries[i].ParticipantID] = &from.Entries[i]
+	}
+
+	// Entries come best rank first, so the movements keep the order of the later snapshot
+	for i := range to.Entries {
+		after := &to.Entries[i]
+		previous := before[after.ParticipantID]
+		delete(before, after.ParticipantID)
+		diff.Movements = append(diff.Movements, snapshotMovement(previous, after))
+	}
+	for i := range from.Entries {
+		if previous, ok := before[from.Entries[i].ParticipantID]; ok {
+			diff.Movements = append(diff.Movements, snapshotMovement(previous, nil))
+		}
+	}
+	return diff, nil
+}
+
+// snapshotMovement compares a participant's entries in two snapshots, either of which is nil when the participant
+// is not in that snapshot
+func snapshotMovement(from, to *models.LeaderboardSnapshotEntry) ParticipantMovement {
+	var movement ParticipantMovement
+	var move repositories.RankMove
+	var fromScore, toScore float64
+	if from != nil {
+		movement.ParticipantID = from.ParticipantID
+		movement.Participant = from.Participant
+		movement.FromRank = &from.Rank
+		movement.FromScore = &from.Score
+		move.PreviousRank = from.Rank
+		fromScore = from.Score
+	}
+	if to != nil {
+		movement.ParticipantID = to.ParticipantID
+		movement.Participant = to.Participant
+		movement.ToRank = &to.Rank
+		movement.ToScore = &to.Score
+		move.CurrentRank = to.Rank
+		toScore = to.Score
+	}
+
+	movement.Trend, movement.RankChange = trendForMove(move)
+	movement.ScoreChange = toScore - fromScore
+	return movement
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxLoginFailuresPerUsername locks an account after this many failures within LoginLockoutWindow
+	MaxLoginFailuresPerUsername = 5
+	// MaxLoginFailuresPerIP blocks an address after this many failures within LoginLockoutWindow, across all usernames
+	MaxLoginFailuresPerIP = 20
+	// LoginLockoutWindow is both how far back failures are counted and how long a lockout lasts after the last failure
+	LoginLockoutWindow = 15 * time.Minute
+)
+
+// LoginThrottledError is returned while a username or IP address is locked out
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return "too many login attempts"
+}
+
+// LoginThrottleService rate limits failed logins per username and per IP address and records every attempt
+type LoginThrottleService interface {
+	// Check returns a *LoginThrottledError if the attempt must be rejected without checking the password
+	Check(ctx context.Context, username, ipAddress string) error
+	RecordFailure(ctx context.Context, username, ipAddress, reason string)
+	RecordSuccess(ctx context.Context, username, ipAddress string)
+}
+
+type loginThrottleService struct {
+	repo repositories.LoginAttemptRepository
+}
+
+func NewLoginThrottleService(repo repositories.LoginAttemptRepository) LoginThrottleService {
+	return &loginThrottleService{
+		repo: repo,
+	}
+}
+
+func (s *loginThrottleService) Check(ctx context.Context, username, ipAddress string) error {
+	now := time.Now()
+	windowStart := now.Add(-LoginLockoutWindow)
+
+	ipStats, err := s.repo.IPFailuresSince(ctx, ipAddress, windowStart)
+	if err != nil {
+		return err
+	}
+	if throttled := lockout(ipStats, MaxLoginFailuresPerIP, now); throttled != nil {
+		return throttled
+	}
+
+	// A successful login resets the username's counter
+	username = normalizeLoginUsername(username)
+	since := windowStart
+	lastSuccess, err := s.repo.LastSuccessByUsername(ctx, username)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if lastSuccess != nil && lastSuccess.After(since) {
+		since = *lastSuccess
+	}
+
+	userStats, err := s.repo.UsernameFailuresSince(ctx, username, since)
+	if err != nil {
+		return err
+	}
+	if throttled := lockout(userStats, MaxLoginFailuresPerUsername, now); throttled != nil {
+		return throttled
+	}
+
+	return nil
+}
+
+func (s *loginThrottleService) RecordFailure(ctx context.Context, username, ipAddress, reason string) {
+	s.record(ctx, &models.LoginAttempt{
+		Username:      normalizeLoginUsername(username),
+		IPAddress:     ipAddress,
+		FailureReason: reason,
+	})
+}
+
+func (s *loginThrottleService) RecordSuccess(ctx context.Context, username, ipAddress string) {
+	s.record(ctx, &models.LoginAttempt{
+		Username:  normalizeLoginUsername(username),
+		IPAddress: ipAddress,
+		Succeeded: true,
+	})
+}
+
+// record stores the audit record; failures are logged rather than failing the login
+func (s *loginThrottleService) record(ctx context.Context, attempt *models.LoginAttempt) {
+	if err := s.repo.Create(ctx, attempt); err != nil {
+		log.Printf("auth: failed to record login attempt for %q from %s: %v", attempt.Username, attempt.IPAddress, err)
+	}
+}
+
+// lockout returns an error when the failures reached the limit, lasting until the window has passed since the latest one
+func lockout(stats repositories.FailureStats, limit int64, now time.Time) *LoginThrottledError {
+	if stats.Count < limit || stats.Latest == nil {
+		return nil
+	}
+
+	retryAfter := stats.Latest.Add(LoginLockoutWindow).Sub(now)
+	if retryAfter <= 0 {
+		return nil
+	}
+	return &LoginThrottledError{RetryAfter: retryAfter}
+}
+
+func normalizeLoginUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MeService serves data for the participant linked to the authenticated user
+type MeService interface {
+	GetParticipant(ctx context.Context, userID uuid.UUID) (*models.Participant, error)
+	ListEntries(ctx context.Context, userID uuid.UUID) ([]models.LeaderboardEntry, error)
+	GetRank(ctx context.Context, userID, leaderboardID uuid.UUID) (*models.LeaderboardEntry, error)
+	ListMetricValues(ctx context.Context, userID uuid.UUID, metricID *uuid.UUID, fromTime, toTime *time.Time) ([]models.MetricValue, error)
+}
+
+type meService struct {
+	participantRepo repositories.ParticipantRepository
+	entryRepo       repositories.LeaderboardEntryRepository
+	metricValueRepo repositories.MetricValueRepository
+	leaderboardRepo repositories.LeaderboardRepository
+}
+
+func NewMeService(participantRepo repositories.ParticipantRepository,
+	entryRepo repositories.LeaderboardEntryRepository,
+	metricValueRepo repositories.MetricValueRepository,
+	leaderboardRepo repositories.LeaderboardRepository) MeService {
+	return &meService{
+		participantRepo: participantRepo,
+		entryRepo:       entryRepo,
+		metricValueRepo: metricValueRepo,
+		leaderboardRepo: leaderboardRepo,
+	}
+}
+
+func (s *meService) GetParticipant(ctx context.Context, userID uuid.UUID) (*models.Participant, error) {
+	participant, err := s.participantRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("no participant linked to user")
+		}
+		return nil, err
+	}
+	return participant, nil
+}
+
+func (s *meService) ListEntries(ctx context.Context, userID uuid.UUID) ([]models.LeaderboardEntry, error) {
+	participant, err := s.GetParticipant(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.entryRepo.FindByParticipantID(ctx, participant.ID)
+}
+
+func (s *meService) GetRank(ctx context.Context, userID, leaderboardID uuid.UUID) (*models.LeaderboardEntry, error) {
+	participant, err := s.GetParticipant(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.leaderboardRepo.FindByID(ctx, leaderboardID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("leaderboard not found")
+		}
+		return nil, err
+	}
+
+	entries, err := s.entryRepo.FindFiltered(ctx, &leaderboardID, &participant.ID, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("leaderboard entry not found")
+	}
+	return &entries[0], nil
+}
+
+func (s *meService) ListMetricValues(ctx context.Context, userID uuid.UUID, metricID *uuid.UUID, fromTime, toTime *time.Time) ([]models.MetricValue, error) {
+	participant, err := s.GetParticipant(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.metricValueRepo.FindFiltered(ctx, metricID, &participant.ID, fromTime, toTime, nil)
+}
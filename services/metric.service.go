@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"leaderboard-service/enums"
 	"leaderboard-service/models"
@@ -11,14 +12,18 @@ import (
 )
 
 type MetricService interface {
-	CreateMetric(name, description string, dataType enums.MetricDataType, unit string,
-		aggregationType enums.AggregationType, resetPeriod enums.ResetPeriod, isHigherBetter bool) (*models.Metric, error)
-	GetMetric(id uuid.UUID) (*models.Metric, error)
-	ListMetrics() ([]models.Metric, error)
-	UpdateMetric(id uuid.UUID, name, description *string, dataType *enums.MetricDataType,
+	CreateMetric(ctx context.Context, name, description string, dataType enums.MetricDataType, unit string,
+		aggregationType enums.AggregationType, resetPeriod enums.ResetPeriod, isHigherBetter *bool) (*models.Metric, error)
+	GetMetric(ctx context.Context, id uuid.UUID) (*models.Metric, error)
+	// ListMetrics returns the metrics with the given IDs, or every metric when there are none; soft-deleted ones
+	// are only listed when asked for
+	ListMetrics(ctx context.Context, ids []uuid.UUID, includeDeleted bool) ([]models.Metric, error)
+	UpdateMetric(ctx context.Context, id uuid.UUID, name, description *string, dataType *enums.MetricDataType,
 		unit *string, aggregationType *enums.AggregationType, resetPeriod *enums.ResetPeriod,
 		isHigherBetter *bool) (*models.Metric, error)
-	DeleteMetric(id uuid.UUID) error
+	DeleteMetric(ctx context.Context, id uuid.UUID) error
+	// RestoreMetric undoes the soft delete of a metric
+	RestoreMetric(ctx context.Context, id uuid.UUID) (*models.Metric, error)
 }
 
 type metricService struct {
@@ -31,8 +36,18 @@ func NewMetricService(repo repositories.MetricRepository) MetricService {
 	}
 }
 
-func (s *metricService) CreateMetric(name, description string, dataType enums.MetricDataType, unit string,
-	aggregationType enums.AggregationType, resetPeriod enums.ResetPeriod, isHigherBetter bool) (*models.Metric, error) {
+func (s *metricService) CreateMetric(ctx context.Context, name, description string, dataType enums.MetricDataType, unit string,
+	aggregationType enums.AggregationType, resetPeriod enums.ResetPeriod, isHigherBetter *bool) (*models.Metric, error) {
+
+	if err := checkAggregation(dataType, aggregationType); err != nil {
+		return nil, err
+	}
+
+	// Higher values are better unless the request says otherwise
+	higherBetter := true
+	if isHigherBetter != nil {
+		higherBetter = *isHigherBetter
+	}
 
 	metric := models.Metric{
 		Name:            name,
@@ -41,19 +56,20 @@ func (s *metricService) CreateMetric(name, description string, dataType enums.Me
 		Unit:            unit,
 		AggregationType: aggregationType,
 		ResetPeriod:     resetPeriod,
-		IsHigherBetter:  isHigherBetter,
+		IsHigherBetter:  higherBetter,
 	}
 
-	err := s.repo.Create(&metric)
+	err := s.repo.Create(ctx, &metric)
 	if err != nil {
 		return nil, err
 	}
 
+	recordAudit(ctx, enums.AuditMetric, metric.ID, enums.AuditCreate, nil, &metric)
 	return &metric, nil
 }
 
-func (s *metricService) GetMetric(id uuid.UUID) (*models.Metric, error) {
-	metric, err := s.repo.FindByID(id)
+func (s *metricService) GetMetric(ctx context.Context, id uuid.UUID) (*models.Metric, error) {
+	metric, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("metric not found")
@@ -63,21 +79,22 @@ func (s *metricService) GetMetric(id uuid.UUID) (*models.Metric, error) {
 	return metric, nil
 }
 
-func (s *metricService) ListMetrics() ([]models.Metric, error) {
-	return s.repo.FindAll()
+func (s *metricService) ListMetrics(ctx context.Context, ids []uuid.UUID, includeDeleted bool) ([]models.Metric, error) {
+	return s.repo.FindFiltered(ctx, ids, includeDeleted)
 }
 
-func (s *metricService) UpdateMetric(id uuid.UUID, name, description *string, dataType *enums.MetricDataType,
+func (s *metricService) UpdateMetric(ctx context.Context, id uuid.UUID, name, description *string, dataType *enums.MetricDataType,
 	unit *string, aggregationType *enums.AggregationType, resetPeriod *enums.ResetPeriod,
 	isHigherBetter *bool) (*models.Metric, error) {
 
-	metric, err := s.repo.FindByID(id)
+	metric, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("metric not found")
 		}
 		return nil, err
 	}
+	before := *metric
 
 	// Apply the updates to the metric
 	if name != nil {
@@ -87,6 +104,10 @@ func (s *metricService) UpdateMetric(id uuid.UUID, name, description *string, da
 		metric.Description = *description
 	}
 	if dataType != nil {
+		// Recorded values are stored by the kind of data they are, so they would no longer be read as the new one
+		if dataType.IsNumeric() != metric.DataType.IsNumeric() {
+			return nil, errors.New("metric data type can't change between numeric and non-numeric")
+		}
 		metric.DataType = *dataType
 	}
 	if unit != nil {
@@ -101,17 +122,24 @@ func (s *metricService) UpdateMetric(id uuid.UUID, name, description *string, da
 	if isHigherBetter != nil {
 		metric.IsHigherBetter = *isHigherBetter
 	}
+	// Metrics made before the check are left alone until one of the two changes
+	if dataType != nil || aggregationType != nil {
+		if err := checkAggregation(metric.DataType, metric.AggregationType); err != nil {
+			return nil, err
+		}
+	}
 
-	err = s.repo.Update(metric)
+	err = s.repo.Update(ctx, metric)
 	if err != nil {
 		return nil, err
 	}
 
+	recordAudit(ctx, enums.AuditMetric, metric.ID, enums.AuditUpdate, &before, metric)
 	return metric, nil
 }
 
-func (s *metricService) DeleteMetric(id uuid.UUID) error {
-	_, err := s.repo.FindByID(id)
+func (s *metricService) DeleteMetric(ctx context.Context, id uuid.UUID) error {
+	metric, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("metric not found")
@@ -119,5 +147,38 @@ func (s *metricService) DeleteMetric(id uuid.UUID) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	recordAudit(ctx, enums.AuditMetric, id, enums.AuditDelete, metric, nil)
+	return nil
+}
+
+func (s *metricService) RestoreMetric(ctx context.Context, id uuid.UUID) (*models.Metric, error) {
+	deleted, err := s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_, err = s.repo.FindByID(ctx, id)
+			return nil, notDeletedError("metric", err)
+		}
+		return nil, err
+	}
+
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	restored, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, enums.AuditMetric, id, enums.AuditRestore, deleted, restored)
+	return restored, nil
+}
+
+// checkAggregation rejects aggregations that need numbers for boolean and string metrics
+func checkAggregation(dataType enums.MetricDataType, aggregationType enums.AggregationType) error {
+	if !dataType.IsNumeric() && aggregationType != enums.Count && aggregationType != enums.Last {
+		return errors.New("boolean and string metrics only support count and last aggregation")
+	}
+	return nil
 }
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxTimeseriesPoints caps how many intervals a time series can span
+const MaxTimeseriesPoints = 1000
+
+// MetricAggregationQuery narrows down the values aggregated; every field is optional
+type MetricAggregationQuery struct {
+	ParticipantID *uuid.UUID
+	From          *time.Time
+	To            *time.Time
+	GroupBy       string // One of repositories.AggregateIntervals, or empty for the total only
+}
+
+// MetricAggregation is a metric's values aggregated by the server with the metric's aggregation type
+type MetricAggregation struct {
+	MetricID        uuid.UUID             `json:"metric_id"`
+	MetricName      string                `json:"metric_name"`
+	AggregationType enums.AggregationType `json:"aggregation_type"`
+	ParticipantID   *uuid.UUID            `json:"participant_id,omitempty"`
+	From            *time.Time            `json:"from,omitempty"`
+	To              *time.Time            `json:"to,omitempty"`
+	GroupBy         string                `json:"group_by,omitempty"`
+	Total           MetricAggregate       `json:"total"`
+	Buckets         []MetricBucket        `json:"buckets,omitempty"` // One per interval with values, oldest first, when grouped
+}
+
+// MetricBucket aggregates the values recorded in the interval starting at PeriodStart
+type MetricBucket struct {
+	PeriodStart time.Time `json:"period_start"`
+	MetricAggregate
+}
+
+// MetricTimeseries is a participant's values for one metric aggregated per interval for charting
+type MetricTimeseries struct {
+	ParticipantID   uuid.UUID             `json:"participant_id"`
+	MetricID        uuid.UUID             `json:"metric_id"`
+	MetricName      string                `json:"metric_name"`
+	AggregationType enums.AggregationType `json:"aggregation_type"`
+	Interval        string                `json:"interval"`
+	From            *time.Time            `json:"from,omitempty"`
+	To              *time.Time            `json:"to,omitempty"`
+	Points          []MetricBucket        `json:"points"` // One per interval in the range, oldest first, with zero counts where nothing was recorded
+}
+
+type MetricAggregationService interface {
+	// AggregateMetric aggregates the metric's values, in total and per interval when the query groups them
+	AggregateMetric(ctx context.Context, metricID uuid.UUID, query MetricAggregationQuery) (*MetricAggregation, error)
+	// ParticipantTimeseries aggregates the participant's values for the metric per interval of query.GroupBy
+	ParticipantTimeseries(ctx context.Context, participantID, metricID uuid.UUID, query MetricAggregationQuery) (*MetricTimeseries, error)
+}
+
+type metricAggregationService struct {
+	metricRepo      repositories.MetricRepository
+	metricValueRepo repositories.MetricValueRepository
+	participantRepo repositories.ParticipantRepository
+}
+
+func NewMetricAggregationService(metricRepo repositories.MetricRepository,
+	metricValueRepo repositories.MetricValueRepository,
+	participantRepo repositories.ParticipantRepository) MetricAggregationService {
+	return &metricAggregationService{
+		metricRepo:      metricRepo,
+		metricValueRepo: metricValueRepo,
+		participantRepo: participantRepo,
+	}
+}
+
+func (s *metricAggregationService) AggregateMetric(ctx context.Context, metricID uuid.UUID, query MetricAggregationQuery) (*MetricAggregation, error) {
+	metric, err := s.metricRepo.FindByID(ctx, metricID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("metric not found")
+		}
+		return nil, err
+	}
+
+	buckets, err := s.metricValueRepo.AggregateByInterval(ctx, metricID, query.ParticipantID, query.From, query.To, query.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregation := &MetricAggregation{
+		MetricID:        metric.ID,
+		MetricName:      metric.Name,
+		AggregationType: metric.AggregationType,
+		ParticipantID:   query.ParticipantID,
+		From:            query.From,
+		To:              query.To,
+		GroupBy:         query.GroupBy,
+		Total:           toMetricAggregate(metric.AggregationType, combineBuckets(buckets)),
+	}
+	if query.GroupBy != "" {
+		aggregation.Buckets = make([]MetricBucket, 0, len(buckets))
+		for _, bucket := range buckets {
+			aggregation.Buckets = append(aggregation.Buckets, MetricBucket{
+				PeriodStart:     bucket.PeriodStart.UTC(),
+				MetricAggregate: toMetricAggregate(metric.AggregationType, bucket.MetricValueAggregate),
+			})
+		}
+	}
+	return aggregation, nil
+}
+
+func (s *metricAggregationService) ParticipantTimeseries(ctx context.Context, participantID, metricID uuid.UUID,
+	query MetricAggregationQuery) (*MetricTimeseries, error) {
+
+	if query.GroupBy == "" {
+		query.GroupBy = repositories.IntervalDay
+	}
+	// Gaps are filled in between the bounds, so a range must not hold more points than a chart can use
+	if query.From != nil && query.To != nil &&
+		intervalsBetween(query.GroupBy, *query.From, *query.To) > MaxTimeseriesPoints {
+		return nil, errors.New("too many points")
+	}
+
+	if _, err := s.participantRepo.FindByID(ctx, participantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("participant not found")
+		}
+		return nil, err
+	}
+	metric, err := s.metricRepo.FindByID(ctx, metricID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("metric not found")
+		}
+		return nil, err
+	}
+
+	buckets, err := s.metricValueRepo.AggregateByInterval(ctx, metricID, &participantID, query.From, query.To, query.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	timeseries := &MetricTimeseries{
+		ParticipantID:   participantID,
+		MetricID:        metric.ID,
+		MetricName:      metric.Name,
+		AggregationType: metric.AggregationType,
+		Interval:        query.GroupBy,
+		From:            query.From,
+		To:              query.To,
+		Points:          []MetricBucket{},
+	}
+
+	byPeriod := make(map[time.Time]repositories.MetricValueAggregate, len(buckets))
+	for _, bucket := range buckets {
+		byPeriod[bucket.PeriodStart.UTC()] = bucket.MetricValueAggregate
+	}
+
+	// The range runs between the bounds given, or else from the first interval with values up to now
+	var first, last time.Time
+	switch {
+	case query.From != nil:
+		first = intervalStart(query.GroupBy, *query.From)
+	case len(buckets) > 0:
+		first = buckets[0].PeriodStart.UTC()
+	default:
+		return timeseries, nil
+	}
+	if query.To != nil {
+		last = intervalStart(query.GroupBy, *query.To)
+	} else {
+		last = intervalStart(query.GroupBy, time.Now())
+		if len(buckets) > 0 && buckets[len(buckets)-1].PeriodStart.After(last) {
+			last = buckets[len(buckets)-1].PeriodStart.UTC()
+		}
+	}
+	if intervalsBetween(query.GroupBy, first, last) > MaxTimeseriesPoints {
+		return nil, errors.New("too many points")
+	}
+
+	for period := first; !period.After(last); period = nextInterval(query.GroupBy, period) {
+		point := MetricBucket{PeriodStart: period}
+		if aggregate, ok := byPeriod[period]; ok {
+			point.MetricAggregate = toMetricAggregate(metric.AggregationType, aggregate)
+		}
+		timeseries.Points = append(timeseries.Points, point)
+	}
+	return timeseries, nil
+}
+
+// intervalStart returns when the UTC interval containing t began, with weeks starting on Monday
+func intervalStart(interval string, t time.Time) time.Time {
+	switch interval {
+	case repositories.IntervalHour:
+		return t.UTC().Truncate(time.Hour)
+	case repositories.IntervalWeek:
+		start, _ := periodStart(enums.WeeklyReset, t)
+		return start
+	case repositories.IntervalMonth:
+		start, _ := periodStart(enums.MonthlyReset, t)
+		return start
+	default:
+		start, _ := periodStart(enums.DailyReset, t)
+		return start
+	}
+}
+
+// nextInterval returns the start of the interval after the one starting at start
+func nextInterval(interval string, start time.Time) time.Time {
+	switch interval {
+	case repositories.IntervalHour:
+		return start.Add(time.Hour)
+	case repositories.IntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case repositories.IntervalMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// intervalsBetween counts the intervals from the one containing from to the one containing to, both included
+func intervalsBetween(interval string, from, to time.Time) int {
+	first, last := intervalStart(interval, from), intervalStart(interval, to)
+	if last.Before(first) {
+		return 0
+	}
+	switch interval {
+	case repositories.IntervalHour:
+		return int(last.Sub(first)/time.Hour) + 1
+	case repositories.IntervalWeek:
+		return int(last.Sub(first)/(7*24*time.Hour)) + 1
+	case repositories.IntervalMonth:
+		return (last.Year()-first.Year())*12 + int(last.Month()-first.Month()) + 1
+	default:
+		return int(last.Sub(first)/(24*time.Hour)) + 1
+	}
+}
+
+// combineBuckets merges per-interval summaries into one over all of them, which saves querying the total again
+func combineBuckets(buckets []repositories.MetricValueBucket) repositories.MetricValueAggregate {
+	var total repositories.MetricValueAggregate
+	for i, bucket := range buckets {
+		if i == 0 {
+			total.Min = math.Inf(1)
+			total.Max = math.Inf(-1)
+		}
+		total.MetricID = bucket.MetricID
+		total.Count += bucket.Count
+		total.Sum += bucket.Sum
+		total.Min = math.Min(total.Min, bucket.Min)
+		total.Max = math.Max(total.Max, bucket.Max)
+		if bucket.LastRecordedAt != nil && (total.LastRecordedAt == nil || !bucket.LastRecordedAt.Before(*total.LastRecordedAt)) {
+			total.LastValue = bucket.LastValue
+			total.LastBoolValue, total.LastStringValue = bucket.LastBoolValue, bucket.LastStringValue
+			total.LastRecordedAt = bucket.LastRecordedAt
+		}
+	}
+	if total.Count > 0 {
+		total.Average = total.Sum / float64(total.Count)
+	}
+	return total
+}
@@ -1,28 +1,79 @@
 package services
 
 import (
+	"context"
+	"encoding/base64"
 	"errors"
+	"leaderboard-service/enums"
 	"leaderboard-service/models"
 	"leaderboard-service/repositories"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+const (
+	DefaultMetricValuePageSize = 100
+	MaxMetricValuePageSize     = 1000
+
+	metricValueCursorPrefix = "mv:"
+)
+
+// MetricValuePage is a page of metric values, with the cursor to pass for the next one
+type MetricValuePage struct {
+	Values     []models.MetricValue
+	NextCursor string // Empty on the last page
+}
+
+// MetricValueInput is a value to record, set in the field matching its metric's data type: Number for integer and
+// decimal metrics, Bool for boolean ones and String for string ones
+type MetricValueInput struct {
+	Number *float64
+	Bool   *bool
+	String *string
+}
+
+// IsZero reports whether no value is set, which leaves a value unchanged when updating it
+func (v MetricValueInput) IsZero() bool {
+	return v.Number == nil && v.Bool == nil && v.String == nil
+}
+
+// MetricValueImport is one row of a metric value import. The participant is identified by ParticipantID or, when
+// that is nil, by ParticipantExternalID.
+type MetricValueImport struct {
+	MetricID              uuid.UUID
+	ParticipantID         *uuid.UUID
+	ParticipantExternalID string
+	Value                 string    // Read as the metric's data type
+	Timestamp             time.Time // Defaults to the time of the import
+	Source                string
+}
+
 type MetricValueService interface {
-	CreateMetricValue(metricID, participantID uuid.UUID, value float64, timestamp time.Time,
+	CreateMetricValue(ctx context.Context, metricID, participantID uuid.UUID, value MetricValueInput, timestamp time.Time,
 		source string, context interface{}) (*models.MetricValue, error)
-	GetMetricValue(id uuid.UUID) (*models.MetricValue, error)
-	ListMetricValues() ([]models.MetricValue, error)
-	ListFilteredMetricValues(metricID, participantID *uuid.UUID, fromTime, toTime *time.Time) ([]models.MetricValue, error)
-	UpdateMetricValue(id uuid.UUID, value *float64, timestamp *time.Time, source *string,
+	// ImportMetricValues stores every row in bulk and returns how many were stored, or stores none and returns an
+	// *ImportRowError when a row names a metric or participant that does not exist or has a value that isn't of its
+	// metric's data type. Unlike values created one at a
+	// time, imported values publish no events.
+	ImportMetricValues(ctx context.Context, rows []MetricValueImport) (int, error)
+	GetMetricValue(ctx context.Context, id uuid.UUID) (*models.MetricValue, error)
+	ListMetricValues(ctx context.Context) ([]models.MetricValue, error)
+	// ListFilteredMetricValues returns a page of the values matching the filters, most recent first unless sorted
+	// otherwise. An empty cursor starts from the first page.
+	ListFilteredMetricValues(ctx context.Context, metricID, participantID *uuid.UUID, fromTime, toTime *time.Time,
+		sort *repositories.Sort, cursor string, limit int) (*MetricValuePage, error)
+	// UpdateMetricValue replaces the fields that are set, leaving the value as it is when value is zero
+	UpdateMetricValue(ctx context.Context, id uuid.UUID, value MetricValueInput, timestamp *time.Time, source *string,
 		context *interface{}) (*models.MetricValue, error)
-	DeleteMetricValue(id uuid.UUID) error
+	DeleteMetricValue(ctx context.Context, id uuid.UUID) error
 
 	// Extra methods that verify entity existence
-	VerifyMetricExists(metricID uuid.UUID) error
-	VerifyParticipantExists(participantID uuid.UUID) error
+	VerifyMetricExists(ctx context.Context, metricID uuid.UUID) error
+	VerifyParticipantExists(ctx context.Context, participantID uuid.UUID) error
 }
 
 type metricValueService struct {
@@ -41,16 +92,16 @@ func NewMetricValueService(repo repositories.MetricValueRepository,
 	}
 }
 
-func (s *metricValueService) CreateMetricValue(metricID, participantID uuid.UUID, value float64,
+func (s *metricValueService) CreateMetricValue(ctx context.Context, metricID, participantID uuid.UUID, value MetricValueInput,
 	timestamp time.Time, source string, context interface{}) (*models.MetricValue, error) {
 
-	// Verify metric exists
-	if err := s.VerifyMetricExists(metricID); err != nil {
+	metric, err := s.findMetric(ctx, metricID)
+	if err != nil {
 		return nil, err
 	}
 
 	// Verify participant exists
-	if err := s.VerifyParticipantExists(participantID); err != nil {
+	if err := s.VerifyParticipantExists(ctx, participantID); err != nil {
 		return nil, err
 	}
 
@@ -62,22 +113,125 @@ func (s *metricValueService) CreateMetricValue(metricID, participantID uuid.UUID
 	metricValue := models.MetricValue{
 		MetricID:      metricID,
 		ParticipantID: participantID,
-		Value:         value,
 		Timestamp:     timestamp,
 		Source:        source,
 		Context:       context,
 	}
+	if err := setValue(&metricValue, metric.DataType, value); err != nil {
+		return nil, err
+	}
 
-	err := s.repo.Create(&metricValue)
+	err = s.repo.Create(ctx, &metricValue)
 	if err != nil {
 		return nil, err
 	}
 
+	metricValuesIngested.WithLabelValues("single").Inc()
+	publishEvent(enums.MetricValueCreated, nil, &metricValue)
+
 	return &metricValue, nil
 }
 
-func (s *metricValueService) GetMetricValue(id uuid.UUID) (*models.MetricValue, error) {
-	metricValue, err := s.repo.FindByID(id)
+func (s *metricValueService) ImportMetricValues(ctx context.Context, rows []MetricValueImport) (int, error) {
+	// Resolve every metric and participant the rows name in a few queries rather than a lookup per row
+	var metricIDs, participantIDs []uuid.UUID
+	var externalIDs []string
+	seenMetrics := make(map[uuid.UUID]bool)
+	seenParticipants := make(map[uuid.UUID]bool)
+	seenExternalIDs := make(map[string]bool)
+	for _, row := range rows {
+		if !seenMetrics[row.MetricID] {
+			seenMetrics[row.MetricID] = true
+			metricIDs = append(metricIDs, row.MetricID)
+		}
+		if row.ParticipantID != nil {
+			if !seenParticipants[*row.ParticipantID] {
+				seenParticipants[*row.ParticipantID] = true
+				participantIDs = append(participantIDs, *row.ParticipantID)
+			}
+		} else if !seenExternalIDs[row.ParticipantExternalID] {
+			seenExternalIDs[row.ParticipantExternalID] = true
+			externalIDs = append(externalIDs, row.ParticipantExternalID)
+		}
+	}
+
+	metrics, err := s.metricRepo.FindByIDs(ctx, metricIDs)
+	if err != nil {
+		return 0, err
+	}
+	dataTypes := make(map[uuid.UUID]enums.MetricDataType, len(metrics))
+	for _, metric := range metrics {
+		dataTypes[metric.ID] = metric.DataType
+	}
+
+	existingIDs, err := s.participantRepo.FindExistingIDs(ctx, participantIDs)
+	if err != nil {
+		return 0, err
+	}
+	knownParticipants := make(map[uuid.UUID]bool, len(existingIDs))
+	for _, id := range existingIDs {
+		knownParticipants[id] = true
+	}
+
+	participants, err := s.participantRepo.FindByExternalIDs(ctx, externalIDs)
+	if err != nil {
+		return 0, err
+	}
+	byExternalID := make(map[string]uuid.UUID, len(participants))
+	for _, participant := range participants {
+		byExternalID[participant.ExternalID] = participant.ID
+	}
+
+	now := time.Now()
+	values := make([]models.MetricValue, len(rows))
+	for i, row := range rows {
+		dataType, ok := dataTypes[row.MetricID]
+		if !ok {
+			return 0, &ImportRowError{Row: i + 1, Err: errors.New("metric not found")}
+		}
+
+		var participantID uuid.UUID
+		if row.ParticipantID != nil {
+			if !knownParticipants[*row.ParticipantID] {
+				return 0, &ImportRowError{Row: i + 1, Err: errors.New("participant not found")}
+			}
+			participantID = *row.ParticipantID
+		} else {
+			id, ok := byExternalID[row.ParticipantExternalID]
+			if !ok {
+				return 0, &ImportRowError{Row: i + 1, Err: errors.New("participant not found")}
+			}
+			participantID = id
+		}
+
+		timestamp := row.Timestamp
+		if timestamp.IsZero() {
+			timestamp = now
+		}
+		values[i] = models.MetricValue{
+			MetricID:      row.MetricID,
+			ParticipantID: participantID,
+			Timestamp:     timestamp,
+			Source:        row.Source,
+		}
+		value, err := parseValue(dataType, row.Value)
+		if err == nil {
+			err = setValue(&values[i], dataType, value)
+		}
+		if err != nil {
+			return 0, &ImportRowError{Row: i + 1, Err: err}
+		}
+	}
+
+	if err := s.repo.CreateBatch(ctx, values); err != nil {
+		return 0, err
+	}
+	metricValuesIngested.WithLabelValues("import").Add(float64(len(values)))
+	return len(values), nil
+}
+
+func (s *metricValueService) GetMetricValue(ctx context.Context, id uuid.UUID) (*models.MetricValue, error) {
+	metricValue, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("metric value not found")
@@ -87,19 +241,94 @@ func (s *metricValueService) GetMetricValue(id uuid.UUID) (*models.MetricValue,
 	return metricValue, nil
 }
 
-func (s *metricValueService) ListMetricValues() ([]models.MetricValue, error) {
-	return s.repo.FindAll()
+func (s *metricValueService) ListMetricValues(ctx context.Context) ([]models.MetricValue, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *metricValueService) ListFilteredMetricValues(ctx context.Context, metricID, participantID *uuid.UUID,
+	fromTime, toTime *time.Time, sort *repositories.Sort, cursor string, limit int) (*MetricValuePage, error) {
+	order := repositories.Sort{Field: "timestamp", Desc: true}
+	if sort != nil {
+		order = *sort
+	}
+	after, err := decodeMetricValueCursor(cursor, order)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = DefaultMetricValuePageSize
+	}
+	if limit > MaxMetricValuePageSize {
+		limit = MaxMetricValuePageSize
+	}
+
+	// Read one extra value to know whether there is another page
+	values, err := s.repo.FindPage(ctx, metricID, participantID, fromTime, toTime, order, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &MetricValuePage{Values: values}
+	if len(values) > limit {
+		page.Values = values[:limit]
+		page.NextCursor = encodeMetricValueCursor(order, page.Values[limit-1])
+	}
+	return page, nil
+}
+
+// A metric value cursor holds the sort it was issued for and the sorted value and ID of the last value returned.
+// The ID comes before the value, which may itself contain colons.
+func encodeMetricValueCursor(sort repositories.Sort, last models.MetricValue) string {
+	var value string
+	switch sort.Field {
+	case "value":
+		value = strconv.FormatFloat(last.Value, 'g', -1, 64)
+	case "created_at":
+		value = last.CreatedAt.Format(time.RFC3339Nano)
+	default:
+		value = last.Timestamp.Format(time.RFC3339Nano)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(metricValueCursorPrefix +
+		strings.Join([]string{sort.Field, strconv.FormatBool(sort.Desc), last.ID.String(), value}, ":")))
 }
 
-func (s *metricValueService) ListFilteredMetricValues(metricID, participantID *uuid.UUID,
-	fromTime, toTime *time.Time) ([]models.MetricValue, error) {
-	return s.repo.FindFiltered(metricID, participantID, fromTime, toTime)
+// decodeMetricValueCursor returns where the cursor's page ended, or nil for an empty cursor. A cursor issued for a
+// different sort is rejected, as its position means nothing in another order.
+func decodeMetricValueCursor(cursor string, sort repositories.Sort) (*repositories.Keyset, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), metricValueCursorPrefix) {
+		return nil, errors.New("invalid cursor")
+	}
+	parts := strings.SplitN(strings.TrimPrefix(string(raw), metricValueCursorPrefix), ":", 4)
+	if len(parts) != 4 || parts[0] != sort.Field || parts[1] != strconv.FormatBool(sort.Desc) {
+		return nil, errors.New("invalid cursor")
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	keyset := &repositories.Keyset{ID: id}
+	if sort.Field == "value" {
+		keyset.Value, err = strconv.ParseFloat(parts[3], 64)
+	} else {
+		keyset.Value, err = time.Parse(time.RFC3339Nano, parts[3])
+	}
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return keyset, nil
 }
 
-func (s *metricValueService) UpdateMetricValue(id uuid.UUID, value *float64, timestamp *time.Time,
+func (s *metricValueService) UpdateMetricValue(ctx context.Context, id uuid.UUID, value MetricValueInput, timestamp *time.Time,
 	source *string, context *interface{}) (*models.MetricValue, error) {
 
-	metricValue, err := s.repo.FindByID(id)
+	metricValue, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("metric value not found")
@@ -108,8 +337,14 @@ func (s *metricValueService) UpdateMetricValue(id uuid.UUID, value *float64, tim
 	}
 
 	// Apply the updates to the metric value
-	if value != nil {
-		metricValue.Value = *value
+	if !value.IsZero() {
+		metric, err := s.findMetric(ctx, metricValue.MetricID)
+		if err != nil {
+			return nil, err
+		}
+		if err := setValue(metricValue, metric.DataType, value); err != nil {
+			return nil, err
+		}
 	}
 	if timestamp != nil {
 		metricValue.Timestamp = *timestamp
@@ -121,16 +356,18 @@ func (s *metricValueService) UpdateMetricValue(id uuid.UUID, value *float64, tim
 		metricValue.Context = *context
 	}
 
-	err = s.repo.Update(metricValue)
+	err = s.repo.Update(ctx, metricValue)
 	if err != nil {
 		return nil, err
 	}
 
+	publishEvent(enums.MetricValueUpdated, nil, metricValue)
+
 	return metricValue, nil
 }
 
-func (s *metricValueService) DeleteMetricValue(id uuid.UUID) error {
-	_, err := s.repo.FindByID(id)
+func (s *metricValueService) DeleteMetricValue(ctx context.Context, id uuid.UUID) error {
+	metricValue, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("metric value not found")
@@ -138,24 +375,34 @@ func (s *metricValueService) DeleteMetricValue(id uuid.UUID) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	publishEvent(enums.MetricValueDeleted, nil, metricValue)
+	return nil
 }
 
 // Verify that a metric exists
-func (s *metricValueService) VerifyMetricExists(metricID uuid.UUID) error {
-	_, err := s.metricRepo.FindByID(metricID)
+func (s *metricValueService) VerifyMetricExists(ctx context.Context, metricID uuid.UUID) error {
+	_, err := s.findMetric(ctx, metricID)
+	return err
+}
+
+func (s *metricValueService) findMetric(ctx context.Context, metricID uuid.UUID) (*models.Metric, error) {
+	metric, err := s.metricRepo.FindByID(ctx, metricID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("metric not found")
+			return nil, errors.New("metric not found")
 		}
-		return err
+		return nil, err
 	}
-	return nil
+	return metric, nil
 }
 
 // Verify that a participant exists
-func (s *metricValueService) VerifyParticipantExists(participantID uuid.UUID) error {
-	_, err := s.participantRepo.FindByID(participantID)
+func (s *metricValueService) VerifyParticipantExists(ctx context.Context, participantID uuid.UUID) error {
+	_, err := s.participantRepo.FindByID(ctx, participantID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("participant not found")
@@ -164,3 +411,42 @@ func (s *metricValueService) VerifyParticipantExists(participantID uuid.UUID) er
 	}
 	return nil
 }
+
+// setValue stores the value in the fields of the metric's data type. Boolean values are also kept as 1 or 0 in
+// Value, so that a boolean metric's last value adds to scores.
+func setValue(metricValue *models.MetricValue, dataType enums.MetricDataType, value MetricValueInput) error {
+	switch {
+	case dataType == enums.Boolean && value.Bool != nil && value.Number == nil && value.String == nil:
+		metricValue.Value, metricValue.BoolValue, metricValue.StringValue = 0, value.Bool, nil
+		if *value.Bool {
+			metricValue.Value = 1
+		}
+	case dataType == enums.String && value.String != nil && value.Number == nil && value.Bool == nil:
+		metricValue.Value, metricValue.BoolValue, metricValue.StringValue = 0, nil, value.String
+	case dataType.IsNumeric() && value.Number != nil && value.Bool == nil && value.String == nil:
+		metricValue.Value, metricValue.BoolValue, metricValue.StringValue = *value.Number, nil, nil
+	default:
+		return errors.New("value does not match the metric's data type")
+	}
+	return nil
+}
+
+// parseValue reads an imported value as the metric's data type
+func parseValue(dataType enums.MetricDataType, raw string) (MetricValueInput, error) {
+	switch dataType {
+	case enums.Boolean:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return MetricValueInput{}, errors.New("value does not match the metric's data type")
+		}
+		return MetricValueInput{Bool: &parsed}, nil
+	case enums.String:
+		return MetricValueInput{String: &raw}, nil
+	default:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return MetricValueInput{}, errors.New("value does not match the metric's data type")
+		}
+		return MetricValueInput{Number: &parsed}, nil
+	}
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"leaderboard-service/repositories"
+)
+
+// DefaultMetricValueRollupInterval is how often raw metric values old enough to compact are rolled up
+const DefaultMetricValueRollupInterval = time.Hour
+
+// MetricValueCompactor rolls raw metric values older than a retention age into daily rollups and deletes them,
+// keeping the metric values table small. Aggregates read rollups alongside the remaining raw values, so scores and
+// statistics are unchanged, but compacted values can no longer be listed, edited or deleted one by one.
+type MetricValueCompactor struct {
+	rollupRepo repositories.MetricValueRollupRepository
+	age        time.Duration
+}
+
+func NewMetricValueCompactor(rollupRepo repositories.MetricValueRollupRepository, age time.Duration) *MetricValueCompactor {
+	return &MetricValueCompactor{
+		rollupRepo: rollupRepo,
+		age:        age,
+	}
+}
+
+// Run compacts the values that have reached the retention age every interval. It never returns.
+func (c *MetricValueCompactor) Run(interval time.Duration) {
+	ctx := context.Background()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		c.compact(ctx, time.Now())
+	}
+}
+
+// compact rolls up each whole UTC day older than the retention age, one transaction per day
+func (c *MetricValueCompactor) compact(ctx context.Context, now time.Time) {
+	before := now.Add(-c.age).UTC().Truncate(24 * time.Hour)
+	days, err := c.rollupRepo.FindCompactableDays(ctx, before)
+	if err != nil {
+		log.Printf("metric value rollup: failed to find days to compact: %v", err)
+		return
+	}
+
+	var total int64
+	for _, day := range days {
+		compacted, err := c.rollupRepo.CompactDay(ctx, day)
+		if err != nil {
+			log.Printf("metric value rollup: failed to compact %s: %v", day.Format(time.DateOnly), err)
+			continue
+		}
+		total += compacted
+	}
+	if total > 0 {
+		log.Printf("metric value rollup: compacted %d values from before %s", total, before.Format(time.DateOnly))
+	}
+}
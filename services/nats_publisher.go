@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"leaderboard-service/enums"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const natsPublishTimeout = 5 * time.Second
+
+// NATSPublisherConfig configures the optional NATS JetStream event publisher
+type NATSPublisherConfig struct {
+	URL           string
+	SubjectPrefix string // Events are published to <prefix>.<event type>, e.g. leaderboard.events.entry.rank_changed
+	Stream        string // When set, the stream is created or updated to capture <prefix>.>
+}
+
+type natsPublisher struct {
+	conn          *nats.Conn
+	js            jetstream.JetStream
+	subjectPrefix string
+}
+
+// NewNATSPublisher connects to NATS and returns a publisher that emits events to JetStream
+func NewNATSPublisher(config NATSPublisherConfig) (EventPublisher, error) {
+	subjectPrefix := strings.TrimSuffix(config.SubjectPrefix, ".")
+	if subjectPrefix == "" {
+		subjectPrefix = "leaderboard.events"
+	}
+
+	conn, err := nats.Connect(config.URL,
+		nats.Name("leaderboard-service"),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS at %s: %w", config.URL, err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	if config.Stream != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), natsPublishTimeout)
+		defer cancel()
+
+		_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+			Name:     config.Stream,
+			Subjects: []string{subjectPrefix + ".>"},
+		})
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to configure JetStream stream %s: %w", config.Stream, err)
+		}
+	}
+
+	return &natsPublisher{
+		conn:          conn,
+		js:            js,
+		subjectPrefix: subjectPrefix,
+	}, nil
+}
+
+// Publish emits the event in the background so request latency is not tied to NATS
+func (p *natsPublisher) Publish(event Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("nats: failed to encode %s event: %v", event.Type, err)
+		return
+	}
+
+	subject := p.subjectFor(event.Type)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), natsPublishTimeout)
+		defer cancel()
+
+		// The message ID lets JetStream drop duplicates if the publish is retried
+		if _, err := p.js.Publish(ctx, subject, data, jetstream.WithMsgID(uuid.NewString())); err != nil {
+			log.Printf("nats: failed to publish %s: %v", subject, err)
+		}
+	}()
+}
+
+func (p *natsPublisher) subjectFor(eventType enums.EventType) string {
+	return p.subjectPrefix + "." + string(eventType)
+}
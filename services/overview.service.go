@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"leaderboard-service/repositories"
+)
+
+const (
+	DefaultOverviewDays = 30
+	MaxOverviewDays     = 365
+)
+
+// Overview is a system-wide summary for an ops dashboard
+type Overview struct {
+	Counts             repositories.OverviewCounts `json:"counts"`
+	MetricValuesPerDay []repositories.DailyCount   `json:"metric_values_per_day"` // One per day, oldest first, today included
+	RecentErrors       []RecentError               `json:"recent_errors"`         // This instance's latest server errors, newest first
+	DatabasePool       repositories.PoolStats      `json:"database_pool"`         // This instance's connection pool
+	GeneratedAt        time.Time                   `json:"generated_at"`
+}
+
+type OverviewService interface {
+	// GetOverview summarizes the system, with the metric values ingested over the last days days
+	GetOverview(ctx context.Context, days int) (*Overview, error)
+}
+
+type overviewService struct {
+	repo repositories.OverviewRepository
+}
+
+func NewOverviewService(repo repositories.OverviewRepository) OverviewService {
+	return &overviewService{
+		repo: repo,
+	}
+}
+
+func (s *overviewService) GetOverview(ctx context.Context, days int) (*Overview, error) {
+	if days <= 0 {
+		days = DefaultOverviewDays
+	}
+	if days > MaxOverviewDays {
+		days = MaxOverviewDays
+	}
+
+	counts, err := s.repo.Counts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	since := today.AddDate(0, 0, 1-days)
+	daily, err := s.repo.MetricValuesPerDay(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fill in the days nothing was ingested so a chart gets one point per day
+	byDay := make(map[string]int64, len(daily))
+	for _, count := range daily {
+		byDay[count.Day.Format(time.DateOnly)] = count.Count
+	}
+	perDay := make([]repositories.DailyCount, 0, days)
+	for day := since; !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		perDay = append(perDay, repositories.DailyCount{Day: day, Date: date, Count: byDay[date]})
+	}
+
+	pool, err := s.repo.PoolStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Overview{
+		Counts:             *counts,
+		MetricValuesPerDay: perDay,
+		RecentErrors:       RecentErrors(),
+		DatabasePool:       *pool,
+		GeneratedAt:        now,
+	}, nil
+}
@@ -1,41 +1,102 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"leaderboard-service/enums"
 	"leaderboard-service/models"
 	"leaderboard-service/repositories"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// ParticipantImport is one row of a participant import
+type ParticipantImport struct {
+	ExternalID  string
+	Name        string
+	Type        string
+	DisplayName string
+	AvatarURL   string
+	Country     string
+}
+
 type ParticipantService interface {
-	CreateParticipant(externalID, name, participantType string, metadata interface{}) (*models.Participant, error)
-	GetParticipant(id uuid.UUID) (*models.Participant, error)
-	ListParticipants() ([]models.Participant, error)
-	UpdateParticipant(id uuid.UUID, externalID, name, participantType *string, metadata *interface{}) (*models.Participant, error)
-	DeleteParticipant(id uuid.UUID) error
+	CreateParticipant(ctx context.Context, externalID, name, participantType string, metadata interface{}, userID *uuid.UUID,
+		displayName, avatarURL, country string) (*models.Participant, error)
+	// ImportParticipants creates an active participant for every row in bulk and returns how many were created, or
+	// creates none and returns an *ImportRowError when a row's external_id is already in use
+	ImportParticipants(ctx context.Context, rows []ParticipantImport) (int, error)
+	GetParticipant(ctx context.Context, id uuid.UUID) (*models.Participant, error)
+	GetParticipantByExternalID(ctx context.Context, externalID string) (*models.Participant, error)
+	// GetNameHistory returns the names the participant has used oldest first, or only the one in use at the given time
+	GetNameHistory(ctx context.Context, id uuid.UUID, at *time.Time) ([]models.ParticipantNameChange, error)
+	// ListParticipants returns the participants with the given IDs, or all of them when there are none, that have
+	// every one of the tags, in the given order or oldest first when it is nil. Soft-deleted participants are only
+	// listed when asked for.
+	ListParticipants(ctx context.Context, ids []uuid.UUID, tags []string, sort *repositories.Sort, includeDeleted bool) ([]models.Participant, error)
+	UpdateParticipant(ctx context.Context, id uuid.UUID, externalID, name, participantType *string, metadata *interface{},
+		userID *uuid.UUID, clearUser bool, displayName, avatarURL, country *string, isActive *bool) (*models.Participant, error)
+	DeleteParticipant(ctx context.Context, id uuid.UUID) error
+	// RestoreParticipant undoes the soft delete of a participant, unless its external_id or user has been taken since
+	RestoreParticipant(ctx context.Context, id uuid.UUID) (*models.Participant, error)
 }
 
 type participantService struct {
-	repo repositories.ParticipantRepository
+	repo           repositories.ParticipantRepository
+	nameChangeRepo repositories.ParticipantNameChangeRepository
+	entryRepo      repositories.LeaderboardEntryRepository
 }
 
-func NewParticipantService(repo repositories.ParticipantRepository) ParticipantService {
+func NewParticipantService(repo repositories.ParticipantRepository,
+	nameChangeRepo repositories.ParticipantNameChangeRepository,
+	entryRepo repositories.LeaderboardEntryRepository) ParticipantService {
 	return &participantService{
-		repo: repo,
+		repo:           repo,
+		nameChangeRepo: nameChangeRepo,
+		entryRepo:      entryRepo,
 	}
 }
 
-func (s *participantService) CreateParticipant(externalID, name, participantType string, metadata interface{}) (*models.Participant, error) {
+func (s *participantService) CreateParticipant(ctx context.Context, externalID, name, participantType string, metadata interface{},
+	userID *uuid.UUID, displayName, avatarURL, country string) (*models.Participant, error) {
+
+	if err := s.verifyExternalIDAvailable(ctx, externalID, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	if userID != nil {
+		if err := s.verifyUserNotLinked(ctx, *userID, uuid.Nil); err != nil {
+			return nil, err
+		}
+	}
+
 	participant := models.Participant{
 		ExternalID: externalID,
 		Name:       name,
 		Type:       participantType,
 		Metadata:   metadata,
+		UserID:     userID,
+		IsActive:   true,
+
+		DisplayName: displayName,
+		AvatarURL:   avatarURL,
+		Country:     country,
 	}
 
-	err := s.repo.Create(&participant)
+	err := s.repo.Create(ctx, &participant)
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, enums.AuditParticipant, participant.ID, enums.AuditCreate, nil, &participant)
+
+	err = s.nameChangeRepo.Create(ctx, &models.ParticipantNameChange{
+		ParticipantID: participant.ID,
+		Name:          participant.Name,
+		DisplayName:   participant.DisplayName,
+		EffectiveFrom: participant.CreatedAt,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -43,8 +104,73 @@ func (s *participantService) CreateParticipant(externalID, name, participantType
 	return &participant, nil
 }
 
-func (s *participantService) GetParticipant(id uuid.UUID) (*models.Participant, error) {
-	participant, err := s.repo.FindByID(id)
+func (s *participantService) ImportParticipants(ctx context.Context, rows []ParticipantImport) (int, error) {
+	var externalIDs []string
+	for _, row := range rows {
+		if row.ExternalID != "" {
+			externalIDs = append(externalIDs, row.ExternalID)
+		}
+	}
+	existing, err := s.repo.FindByExternalIDs(ctx, externalIDs)
+	if err != nil {
+		return 0, err
+	}
+	taken := make(map[string]bool, len(existing)+len(rows))
+	for _, participant := range existing {
+		taken[participant.ExternalID] = true
+	}
+
+	participants := make([]models.Participant, len(rows))
+	for i, row := range rows {
+		// External IDs must be unique within the import as well as among the existing participants
+		if row.ExternalID != "" {
+			if taken[row.ExternalID] {
+				return 0, &ImportRowError{Row: i + 1, Err: errors.New("external_id already in use")}
+			}
+			taken[row.ExternalID] = true
+		}
+
+		participants[i] = models.Participant{
+			ExternalID: row.ExternalID,
+			Name:       row.Name,
+			Type:       row.Type,
+			IsActive:   true,
+
+			DisplayName: row.DisplayName,
+			AvatarURL:   row.AvatarURL,
+			Country:     row.Country,
+		}
+	}
+
+	if err := s.repo.CreateBatch(ctx, participants); err != nil {
+		return 0, err
+	}
+
+	logs := make([]models.AuditLog, len(participants))
+	for i := range participants {
+		logs[i] = newAuditLog(enums.AuditParticipant, participants[i].ID, enums.AuditCreate, nil, &participants[i])
+	}
+	recordAudits(ctx, logs)
+	return len(participants), nil
+}
+
+func (s *participantService) GetParticipant(ctx context.Context, id uuid.UUID) (*models.Participant, error) {
+	participant, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("participant not found")
+		}
+		return nil, err
+	}
+	return participant, nil
+}
+
+func (s *participantService) GetParticipantByExternalID(ctx context.Context, externalID string) (*models.Participant, error) {
+	if externalID == "" {
+		return nil, errors.New("participant not found")
+	}
+
+	participant, err := s.repo.FindByExternalID(ctx, externalID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("participant not found")
@@ -54,21 +180,59 @@ func (s *participantService) GetParticipant(id uuid.UUID) (*models.Participant,
 	return participant, nil
 }
 
-func (s *participantService) ListParticipants() ([]models.Participant, error) {
-	return s.repo.FindAll()
+func (s *participantService) GetNameHistory(ctx context.Context, id uuid.UUID, at *time.Time) ([]models.ParticipantNameChange, error) {
+	participant, err := s.GetParticipant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.nameChangeRepo.FindByParticipantID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Participants that have not been renamed since history was tracked have used one name since creation
+	if len(history) == 0 {
+		history = append(history, models.ParticipantNameChange{
+			ParticipantID: participant.ID,
+			Name:          participant.Name,
+			DisplayName:   participant.DisplayName,
+			EffectiveFrom: participant.CreatedAt,
+		})
+	}
+
+	if at == nil {
+		return history, nil
+	}
+	inEffect := make([]models.ParticipantNameChange, 0, 1)
+	for _, change := range history {
+		if change.InEffectAt(*at) {
+			inEffect = append(inEffect, change)
+		}
+	}
+	return inEffect, nil
+}
+
+func (s *participantService) ListParticipants(ctx context.Context, ids []uuid.UUID, tags []string, sort *repositories.Sort, includeDeleted bool) ([]models.Participant, error) {
+	return s.repo.FindFiltered(ctx, ids, normalizeTags(tags), sort, includeDeleted)
 }
 
-func (s *participantService) UpdateParticipant(id uuid.UUID, externalID, name, participantType *string, metadata *interface{}) (*models.Participant, error) {
-	participant, err := s.repo.FindByID(id)
+func (s *participantService) UpdateParticipant(ctx context.Context, id uuid.UUID, externalID, name, participantType *string, metadata *interface{},
+	userID *uuid.UUID, clearUser bool, displayName, avatarURL, country *string, isActive *bool) (*models.Participant, error) {
+	participant, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("participant not found")
 		}
 		return nil, err
 	}
+	before := *participant
+	previousName, previousDisplayName, previousAvatarURL := participant.Name, participant.DisplayName, participant.AvatarURL
 
 	// Apply the updates to the participant
 	if externalID != nil {
+		if err := s.verifyExternalIDAvailable(ctx, *externalID, participant.ID); err != nil {
+			return nil, err
+		}
 		participant.ExternalID = *externalID
 	}
 	if name != nil {
@@ -80,17 +244,49 @@ func (s *participantService) UpdateParticipant(id uuid.UUID, externalID, name, p
 	if metadata != nil {
 		participant.Metadata = *metadata
 	}
+	if displayName != nil {
+		participant.DisplayName = *displayName
+	}
+	if avatarURL != nil {
+		participant.AvatarURL = *avatarURL
+	}
+	if country != nil {
+		participant.Country = *country
+	}
+	if isActive != nil {
+		participant.IsActive = *isActive
+	}
+	if clearUser {
+		participant.UserID = nil
+	} else if userID != nil {
+		if err := s.verifyUserNotLinked(ctx, *userID, participant.ID); err != nil {
+			return nil, err
+		}
+		participant.UserID = userID
+	}
 
-	err = s.repo.Update(participant)
+	err = s.repo.Update(ctx, participant)
 	if err != nil {
 		return nil, err
 	}
+	recordAudit(ctx, enums.AuditParticipant, participant.ID, enums.AuditUpdate, &before, participant)
+
+	if participant.Name != previousName || participant.DisplayName != previousDisplayName {
+		if err := s.recordNameChange(ctx, participant, previousName, previousDisplayName); err != nil {
+			return nil, err
+		}
+	}
+	if participant.Name != previousName || participant.DisplayName != previousDisplayName || participant.AvatarURL != previousAvatarURL {
+		if err := s.entryRepo.UpdateParticipantDisplay(ctx, participant); err != nil {
+			return nil, err
+		}
+	}
 
 	return participant, nil
 }
 
-func (s *participantService) DeleteParticipant(id uuid.UUID) error {
-	_, err := s.repo.FindByID(id)
+func (s *participantService) DeleteParticipant(ctx context.Context, id uuid.UUID) error {
+	participant, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("participant not found")
@@ -98,5 +294,105 @@ func (s *participantService) DeleteParticipant(id uuid.UUID) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	recordAudit(ctx, enums.AuditParticipant, id, enums.AuditDelete, participant, nil)
+	return nil
+}
+
+func (s *participantService) RestoreParticipant(ctx context.Context, id uuid.UUID) (*models.Participant, error) {
+	participant, err := s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_, err = s.repo.FindByID(ctx, id)
+			return nil, notDeletedError("participant", err)
+		}
+		return nil, err
+	}
+
+	// Unique external IDs and users only apply to live participants, so another one may have taken them since
+	if err := s.verifyExternalIDAvailable(ctx, participant.ExternalID, id); err != nil {
+		return nil, err
+	}
+	if participant.UserID != nil {
+		if err := s.verifyUserNotLinked(ctx, *participant.UserID, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	restored, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, enums.AuditParticipant, id, enums.AuditRestore, participant, restored)
+	return restored, nil
+}
+
+// recordNameChange ends the participant's current name record and starts one for its new name
+func (s *participantService) recordNameChange(ctx context.Context, participant *models.Participant, previousName, previousDisplayName string) error {
+	now := time.Now().UTC()
+
+	current, err := s.nameChangeRepo.FindCurrent(ctx, participant.ID)
+	switch {
+	case err == nil:
+		current.EffectiveTo = &now
+		err = s.nameChangeRepo.Update(ctx, current)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// Participants created before history was tracked get a record for the name they had until now
+		err = s.nameChangeRepo.Create(ctx, &models.ParticipantNameChange{
+			ParticipantID: participant.ID,
+			Name:          previousName,
+			DisplayName:   previousDisplayName,
+			EffectiveFrom: participant.CreatedAt,
+			EffectiveTo:   &now,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.nameChangeRepo.Create(ctx, &models.ParticipantNameChange{
+		ParticipantID: participant.ID,
+		Name:          participant.Name,
+		DisplayName:   participant.DisplayName,
+		EffectiveFrom: now,
+	})
+}
+
+// verifyExternalIDAvailable makes sure a non-empty external ID belongs to at most one participant
+func (s *participantService) verifyExternalIDAvailable(ctx context.Context, externalID string, participantID uuid.UUID) error {
+	if externalID == "" {
+		return nil
+	}
+
+	existing, err := s.repo.FindByExternalID(ctx, externalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != participantID {
+		return errors.New("external_id already in use")
+	}
+	return nil
+}
+
+// verifyUserNotLinked makes sure a user plays as at most one participant
+func (s *participantService) verifyUserNotLinked(ctx context.Context, userID, participantID uuid.UUID) error {
+	existing, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != participantID {
+		return errors.New("user already linked to another participant")
+	}
+	return nil
 }
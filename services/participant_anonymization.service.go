@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AnonymizedNamePrefix starts the pseudonym given to anonymized participants
+const AnonymizedNamePrefix = "Anonymous "
+
+type ParticipantAnonymizationService interface {
+	// Anonymize irreversibly scrubs the participant's personal data while keeping its leaderboard entries under
+	// a pseudonym, optionally deletes its raw metric values, and records who asked for it
+	Anonymize(ctx context.Context, id uuid.UUID, deleteMetricValues bool, requestedBy, reason string) (*models.ParticipantAnonymization, error)
+}
+
+type participantAnonymizationService struct {
+	participantRepo repositories.ParticipantRepository
+	unitOfWork      repositories.UnitOfWork
+}
+
+func NewParticipantAnonymizationService(participantRepo repositories.ParticipantRepository,
+	unitOfWork repositories.UnitOfWork) ParticipantAnonymizationService {
+	return &participantAnonymizationService{
+		participantRepo: participantRepo,
+		unitOfWork:      unitOfWork,
+	}
+}
+
+func (s *participantAnonymizationService) Anonymize(ctx context.Context, id uuid.UUID, deleteMetricValues bool,
+	requestedBy, reason string) (*models.ParticipantAnonymization, error) {
+
+	participant, err := s.participantRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("participant not found")
+		}
+		return nil, err
+	}
+	if participant.AnonymizedAt != nil {
+		return nil, errors.New("participant already anonymized")
+	}
+
+	// The pseudonym is random so it cannot be traced back to the participant's name or IDs
+	now := time.Now().UTC()
+	pseudonym := AnonymizedNamePrefix + strings.SplitN(uuid.NewString(), "-", 2)[0]
+
+	participant.Name = pseudonym
+	participant.ExternalID = ""
+	participant.Metadata = nil
+	participant.UserID = nil
+	participant.DisplayName = ""
+	participant.AvatarURL = ""
+	participant.Country = ""
+	participant.AnonymizedAt = &now
+
+	anonymization := models.ParticipantAnonymization{
+		ParticipantID: id,
+		Pseudonym:     pseudonym,
+		RequestedBy:   requestedBy,
+		Reason:        reason,
+	}
+
+	// Every step is in one transaction, so a failed one leaves the participant as it was, to be anonymized again,
+	// rather than marked anonymized with part of its data left
+	err = s.unitOfWork.Do(ctx, func(repos repositories.Repositories) error {
+		if err := repos.Participants.Update(ctx, participant); err != nil {
+			return err
+		}
+		// The audit log keeps who changed what and when, but not the personal data being erased
+		if err := repos.AuditLogs.ScrubParticipant(ctx, id); err != nil {
+			return err
+		}
+		// Neither does the event log served by GET /events
+		if err := repos.Events.ScrubParticipant(ctx, id); err != nil {
+			return err
+		}
+		// Entries show the pseudonym from now on
+		if err := repos.LeaderboardEntries.UpdateParticipantDisplay(ctx, participant); err != nil {
+			return err
+		}
+
+		// Earlier names are personal data too, so the history restarts with the pseudonym
+		if err := repos.ParticipantNameChanges.DeleteByParticipantID(ctx, id); err != nil {
+			return err
+		}
+		err := repos.ParticipantNameChanges.Create(ctx, &models.ParticipantNameChange{
+			ParticipantID: id,
+			Name:          pseudonym,
+			EffectiveFrom: now,
+		})
+		if err != nil {
+			return err
+		}
+
+		if deleteMetricValues {
+			anonymization.MetricValuesDeleted, err = repos.MetricValues.DeleteByParticipantID(ctx, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		return repos.ParticipantAnonymizations.Create(ctx, &anonymization)
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, enums.AuditParticipant, id, enums.AuditUpdate, nil, participant)
+
+	return &anonymization, nil
+}
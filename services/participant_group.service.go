@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// GroupParticipantType is the participant type that can contain other participants in the hierarchy
+	GroupParticipantType = "group"
+
+	// MaxGroupDepth bounds how many levels a hierarchy can have, counting the top-level group as 1
+	MaxGroupDepth = 16
+)
+
+// GroupStanding is one row of a leaderboard rolled up to a level of the group hierarchy
+type GroupStanding struct {
+	Participant *models.Participant `json:"participant"`
+	Rank        int                 `json:"rank"`
+	Score       float64             `json:"score"`
+	EntryCount  int                 `json:"entry_count"` // Number of leaderboard entries within this participant's part of the hierarchy
+}
+
+type ParticipantGroupService interface {
+	// SetParent moves the participant under a group participant
+	SetParent(ctx context.Context, id, parentID uuid.UUID) (*models.Participant, error)
+	// ClearParent moves the participant to the top level
+	ClearParent(ctx context.Context, id uuid.UUID) (*models.Participant, error)
+	// ListChildren returns the participant's direct children, or every descendant when recursive
+	ListChildren(ctx context.Context, id uuid.UUID, recursive bool) ([]models.Participant, error)
+	// RollUpStandings sums a leaderboard's entries into the participants at the given level below the
+	// leaderboard's group (or below the top of the hierarchy when it has none) and ranks them
+	RollUpStandings(ctx context.Context, leaderboardID uuid.UUID, level int) ([]GroupStanding, error)
+}
+
+type participantGroupService struct {
+	repo            repositories.ParticipantRepository
+	leaderboardRepo repositories.LeaderboardRepository
+	entryRepo       repositories.LeaderboardEntryRepository
+}
+
+func NewParticipantGroupService(repo repositories.ParticipantRepository,
+	leaderboardRepo repositories.LeaderboardRepository,
+	entryRepo repositories.LeaderboardEntryRepository) ParticipantGroupService {
+	return &participantGroupService{
+		repo:            repo,
+		leaderboardRepo: leaderboardRepo,
+		entryRepo:       entryRepo,
+	}
+}
+
+func (s *participantGroupService) SetParent(ctx context.Context, id, parentID uuid.UUID) (*models.Participant, error) {
+	participant, err := s.getParticipant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == parentID {
+		return nil, errors.New("participant cannot be its own parent")
+	}
+
+	parent, err := s.repo.FindByID(ctx, parentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("parent not found")
+		}
+		return nil, err
+	}
+	if parent.Type != GroupParticipantType {
+		return nil, errors.New("parent must be a group")
+	}
+
+	// The new parent cannot sit below the participant, and the moved subtree must still fit
+	chain, err := ancestorChain(ctx, s.repo, parent, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, ancestor := range chain {
+		if ancestor.ID == id {
+			return nil, errors.New("parent is a descendant of the participant")
+		}
+	}
+	height, err := s.subtreeHeight(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(chain)+height > MaxGroupDepth {
+		return nil, errors.New("group hierarchy too deep")
+	}
+
+	before := *participant
+	participant.ParentID = &parentID
+	if err := s.repo.Update(ctx, participant); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, enums.AuditParticipant, id, enums.AuditUpdate, &before, participant)
+	return participant, nil
+}
+
+func (s *participantGroupService) ClearParent(ctx context.Context, id uuid.UUID) (*models.Participant, error) {
+	participant, err := s.getParticipant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *participant
+	participant.ParentID = nil
+	if err := s.repo.Update(ctx, participant); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, enums.AuditParticipant, id, enums.AuditUpdate, &before, participant)
+	return participant, nil
+}
+
+func (s *participantGroupService) ListChildren(ctx context.Context, id uuid.UUID, recursive bool) ([]models.Participant, error) {
+	if _, err := s.getParticipant(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if !recursive {
+		return s.repo.FindByParentIDs(ctx, []uuid.UUID{id})
+	}
+
+	var descendants []models.Participant
+	level := []uuid.UUID{id}
+	for depth := 0; depth < MaxGroupDepth && len(level) > 0; depth++ {
+		children, err := s.repo.FindByParentIDs(ctx, level)
+		if err != nil {
+			return nil, err
+		}
+		level = level[:0]
+		for _, child := range children {
+			level = append(level, child.ID)
+		}
+		descendants = append(descendants, children...)
+	}
+
+	return descendants, nil
+}
+
+func (s *participantGroupService) RollUpStandings(ctx context.Context, leaderboardID uuid.UUID, level int) ([]GroupStanding, error) {
+	if level < 1 {
+		return nil, errors.New("level must be at least 1")
+	}
+
+	leaderboard, err := s.leaderboardRepo.FindByID(ctx, leaderboardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("leaderboard not found")
+		}
+		return nil, err
+	}
+
+	entries, err := s.entryRepo.FindPublishedByLeaderboardID(ctx, leaderboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every group above the entries is loaded up front, so the chains are built without a lookup per entry
+	participantIDs := make([]uuid.UUID, 0, len(entries))
+	for _, entry := range entries {
+		participantIDs = append(participantIDs, entry.ParticipantID)
+	}
+	ancestors, err := s.repo.FindAncestors(ctx, participantIDs)
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[uuid.UUID]*models.Participant, len(ancestors))
+	for i := range ancestors {
+		parents[ancestors[i].ID] = &ancestors[i]
+	}
+	standings := make(map[uuid.UUID]*GroupStanding)
+	var order []uuid.UUID
+	for _, entry := range entries {
+		if entry.Participant == nil {
+			continue
+		}
+
+		chain, err := ancestorChain(ctx, s.repo, entry.Participant, parents)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only the part of the hierarchy below the leaderboard's group counts
+		if leaderboard.GroupID != nil {
+			below := -1
+			for i, ancestor := range chain {
+				if ancestor.ID == *leaderboard.GroupID {
+					below = i + 1
+					break
+				}
+			}
+			if below < 0 {
+				continue
+			}
+			chain = chain[below:]
+		}
+
+		// Entries above the requested level have no participant to roll up into
+		if len(chain) < level {
+			continue
+		}
+		target := chain[level-1]
+
+		standing, ok := standings[target.ID]
+		if !ok {
+			standing = &GroupStanding{Participant: target}
+			standings[target.ID] = standing
+			order = append(order, target.ID)
+		}
+		standing.Score += entry.Score
+		standing.EntryCount++
+	}
+
+	result := make([]GroupStanding, 0, len(order))
+	for _, id := range order {
+		result = append(result, *standings[id])
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if leaderboard.SortOrder == enums.Ascending {
+			return result[i].Score < result[j].Score
+		}
+		return result[i].Score > result[j].Score
+	})
+	for i := range result {
+		if i > 0 && result[i].Score == result[i-1].Score {
+			result[i].Rank = result[i-1].Rank
+		} else {
+			result[i].Rank = i + 1
+		}
+	}
+
+	return result, nil
+}
+
+func (s *participantGroupService) getParticipant(ctx context.Context, id uuid.UUID) (*models.Participant, error) {
+	participant, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("participant not found")
+		}
+		return nil, err
+	}
+	return participant, nil
+}
+
+// subtreeHeight counts the levels from the participant down to its deepest descendant, including itself
+func (s *participantGroupService) subtreeHeight(ctx context.Context, id uuid.UUID) (int, error) {
+	height := 0
+	level := []uuid.UUID{id}
+	for len(level) > 0 && height <= MaxGroupDepth {
+		height++
+		children, err := s.repo.FindByParentIDs(ctx, level)
+		if err != nil {
+			return 0, err
+		}
+		level = level[:0]
+		for _, child := range children {
+			level = append(level, child.ID)
+		}
+	}
+	return height, nil
+}
+
+// ancestorChain returns the participant's ancestors from the top of the hierarchy down, ending with the participant.
+// Parents are looked up in cache first when one is given, and added to it.
+func ancestorChain(ctx context.Context, repo repositories.ParticipantRepository, participant *models.Participant,
+	cache map[uuid.UUID]*models.Participant) ([]*models.Participant, error) {
+	chain := []*models.Participant{participant}
+	current := participant
+	for current.ParentID != nil && len(chain) <= MaxGroupDepth {
+		parent, cached := cache[*current.ParentID]
+		if !cached {
+			var err error
+			parent, err = repo.FindByID(ctx, *current.ParentID)
+			if err != nil {
+				// A deleted parent leaves the participant at the top of what remains
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					break
+				}
+				return nil, err
+			}
+			if cache != nil {
+				cache[parent.ID] = parent
+			}
+		}
+		chain = append(chain, parent)
+		current = parent
+	}
+
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain, nil
+}
+
+// isInGroup reports whether the participant sits anywhere below the group in the hierarchy
+func isInGroup(ctx context.Context, repo repositories.ParticipantRepository, participantID, groupID uuid.UUID) (bool, error) {
+	participant, err := repo.FindByID(ctx, participantID)
+	if err != nil {
+		return false, err
+	}
+
+	chain, err := ancestorChain(ctx, repo, participant, nil)
+	if err != nil {
+		return false, err
+	}
+	for _, ancestor := range chain[:len(chain)-1] {
+		if ancestor.ID == groupID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,492 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StatsActivityWindow is how far back a participant's recent activity is counted
+const StatsActivityWindow = 30 * 24 * time.Hour
+
+// ParticipantStats summarises a participant's metric values and leaderboard standing
+type ParticipantStats struct {
+	ParticipantID uuid.UUID     `json:"participant_id"`
+	Metrics       []MetricStats `json:"metrics"`
+	BestRank      *int          `json:"best_rank,omitempty"` // Best rank ever recorded on any readable leaderboard
+	CurrentRanks  []BoardRank   `json:"current_ranks"`
+	Activity      ActivityStats `json:"activity"`
+}
+
+// MetricStats aggregates a participant's values for one metric over its lifetime and the metric's current reset period
+type MetricStats struct {
+	MetricID        uuid.UUID             `json:"metric_id"`
+	MetricName      string                `json:"metric_name"`
+	AggregationType enums.AggregationType `json:"aggregation_type"`
+	ResetPeriod     enums.ResetPeriod     `json:"reset_period"`
+	Lifetime        MetricAggregate       `json:"lifetime"`
+	PeriodStart     *time.Time            `json:"period_start,omitempty"`   // Start of the current reset period, unset for metrics that never reset
+	CurrentPeriod   *MetricAggregate      `json:"current_period,omitempty"` // Unset for metrics that never reset
+}
+
+// MetricAggregate summarises a set of metric values. Value applies the metric's aggregation type.
+type MetricAggregate struct {
+	Value   float64 `json:"value"`
+	Count   int64   `json:"count"`
+	Sum     float64 `json:"sum"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Average float64 `json:"average"`
+	// The last value of a boolean or string metric, whose Value is the last as a number (1 or 0) or the count
+	LastBoolValue   *bool      `json:"last_bool_value,omitempty"`
+	LastStringValue *string    `json:"last_string_value,omitempty"`
+	LastRecordedAt  *time.Time `json:"last_recorded_at,omitempty"`
+}
+
+// BoardRank is the participant's current entry on a leaderboard
+type BoardRank struct {
+	LeaderboardID   uuid.UUID `json:"leaderboard_id"`
+	LeaderboardName string    `json:"leaderboard_name"`
+	Rank            int       `json:"rank"`
+	Score           float64   `json:"score"`
+	BestRank        *int      `json:"best_rank,omitempty"` // Best rank recorded on this leaderboard
+	LastUpdated     time.Time `json:"last_updated"`
+}
+
+// ActivityStats counts the metric values a participant has recorded
+type ActivityStats struct {
+	MetricValueCount int64      `json:"metric_value_count"`
+	RecentValueCount int64      `json:"recent_value_count"` // Values recorded within StatsActivityWindow
+	RecentActiveDays int64      `json:"recent_active_days"` // Distinct UTC days with a value within StatsActivityWindow
+	LeaderboardCount int        `json:"leaderboard_count"`
+	FirstRecordedAt  *time.Time `json:"first_recorded_at,omitempty"`
+	LastRecordedAt   *time.Time `json:"last_recorded_at,omitempty"`
+}
+
+// Tier buckets a ranked entry by how far up its leaderboard it sits
+type Tier string
+
+const (
+	TierDiamond  Tier = "diamond"  // Top 1%
+	TierPlatinum Tier = "platinum" // Top 5%
+	TierGold     Tier = "gold"     // Top 10%
+	TierSilver   Tier = "silver"   // Top 25%
+	TierBronze   Tier = "bronze"   // Top 50%
+	TierNone     Tier = "none"     // Bottom half or unranked
+)
+
+// Trend is the direction of an entry's most recent rank change
+type Trend string
+
+const (
+	TrendUp     Trend = "up"
+	TrendDown   Trend = "down"
+	TrendSteady Trend = "steady" // No rank change has been recorded
+	TrendNew    Trend = "new"    // The latest change ranked a previously unranked entry
+)
+
+// ParticipantLeaderboard is the participant's standing on one leaderboard
+type ParticipantLeaderboard struct {
+	Leaderboard   *models.Leaderboard `json:"leaderboard"`
+	Rank          int                 `json:"rank"`
+	Score         float64             `json:"score"`
+	RankedEntries int64               `json:"ranked_entries"` // Ranked entries on the leaderboard
+	TopPercent    float64             `json:"top_percent"`    // Rank as a percentage of the ranked entries, 0 when unranked
+	Tier          Tier                `json:"tier"`
+	Trend         Trend               `json:"trend"`
+	RankChange    int                 `json:"rank_change"` // Places gained (positive) or lost in the most recent rank change
+	LastUpdated   time.Time           `json:"last_updated"`
+}
+
+type ParticipantStatsService interface {
+	// GetStats summarises the participant's activity. Ranks on leaderboards the viewer cannot read are left out.
+	GetStats(ctx context.Context, participantID uuid.UUID, viewer Viewer) (*ParticipantStats, error)
+	// ListLeaderboards returns the participant's standing on every leaderboard the viewer can read, best rank first
+	ListLeaderboards(ctx context.Context, participantID uuid.UUID, viewer Viewer) ([]ParticipantLeaderboard, error)
+}
+
+type participantStatsService struct {
+	participantRepo repositories.ParticipantRepository
+	metricRepo      repositories.MetricRepository
+	valueRepo       repositories.MetricValueRepository
+	entryRepo       repositories.LeaderboardEntryRepository
+	leaderboardRepo repositories.LeaderboardRepository
+	eventRepo       repositories.EventRepository
+	accessService   LeaderboardAccessService
+}
+
+func NewParticipantStatsService(participantRepo repositories.ParticipantRepository,
+	metricRepo repositories.MetricRepository,
+	valueRepo repositories.MetricValueRepository,
+	entryRepo repositories.LeaderboardEntryRepository,
+	leaderboardRepo repositories.LeaderboardRepository,
+	eventRepo repositories.EventRepository,
+	accessService LeaderboardAccessService) ParticipantStatsService {
+	return &participantStatsService{
+		participantRepo: participantRepo,
+		metricRepo:      metricRepo,
+		valueRepo:       valueRepo,
+		entryRepo:       entryRepo,
+		leaderboardRepo: leaderboardRepo,
+		eventRepo:       eventRepo,
+		accessService:   accessService,
+	}
+}
+
+func (s *participantStatsService) GetStats(ctx context.Context, participantID uuid.UUID, viewer Viewer) (*ParticipantStats, error) {
+	if _, err := s.participantRepo.FindByID(ctx, participantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("participant not found")
+		}
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	stats := &ParticipantStats{
+		ParticipantID: participantID,
+		Metrics:       []MetricStats{},
+		CurrentRanks:  []BoardRank{},
+	}
+
+	metrics, err := s.metricStats(ctx, participantID, now)
+	if err != nil {
+		return nil, err
+	}
+	stats.Metrics = metrics
+
+	activity, err := s.valueRepo.ActivityForParticipant(ctx, participantID, now.Add(-StatsActivityWindow))
+	if err != nil {
+		return nil, err
+	}
+	stats.Activity = ActivityStats{
+		MetricValueCount: activity.Total,
+		RecentValueCount: activity.Recent,
+		RecentActiveDays: activity.RecentActiveDays,
+		FirstRecordedAt:  activity.FirstRecordedAt,
+		LastRecordedAt:   activity.LastRecordedAt,
+	}
+
+	entries, err := s.entryRepo.FindByParticipantID(ctx, participantID)
+	if err != nil {
+		return nil, err
+	}
+	bestRanks, err := s.eventRepo.BestRanksForParticipant(ctx, participantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load every leaderboard the participant has ranked on and keep the ones the viewer can read
+	var leaderboardIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, entry := range entries {
+		if !seen[entry.LeaderboardID] {
+			seen[entry.LeaderboardID] = true
+			leaderboardIDs = append(leaderboardIDs, entry.LeaderboardID)
+		}
+	}
+	for leaderboardID := range bestRanks {
+		if !seen[leaderboardID] {
+			seen[leaderboardID] = true
+			leaderboardIDs = append(leaderboardIDs, leaderboardID)
+		}
+	}
+	readable, err := s.readableLeaderboards(ctx, leaderboardIDs, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		leaderboard, ok := readable[entry.LeaderboardID]
+		if !ok {
+			continue
+		}
+		boardRank := BoardRank{
+			LeaderboardID:   entry.LeaderboardID,
+			LeaderboardName: leaderboard.Name,
+			Rank:            entry.Rank,
+			Score:           entry.Score,
+			LastUpdated:     entry.LastUpdated,
+		}
+		// The current rank counts even when no event recorded it, e.g. for entries older than the event log
+		if best := bestRank(bestRanks, entry.LeaderboardID, entry.Rank); best > 0 {
+			bestRanks[entry.LeaderboardID] = best
+			boardRank.BestRank = &best
+		}
+		stats.CurrentRanks = append(stats.CurrentRanks, boardRank)
+	}
+	sort.SliceStable(stats.CurrentRanks, func(i, j int) bool {
+		return rankedBefore(stats.CurrentRanks[i].Rank, stats.CurrentRanks[j].Rank)
+	})
+	stats.Activity.LeaderboardCount = len(stats.CurrentRanks)
+
+	for leaderboardID := range readable {
+		best := bestRanks[leaderboardID]
+		if best < 1 {
+			continue
+		}
+		if stats.BestRank == nil || best < *stats.BestRank {
+			stats.BestRank = &best
+		}
+	}
+
+	return stats, nil
+}
+
+func (s *participantStatsService) ListLeaderboards(ctx context.Context, participantID uuid.UUID, viewer Viewer) ([]ParticipantLeaderboard, error) {
+	if _, err := s.participantRepo.FindByID(ctx, participantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("participant not found")
+		}
+		return nil, err
+	}
+
+	entries, err := s.entryRepo.FindByParticipantID(ctx, participantID)
+	if err != nil {
+		return nil, err
+	}
+	result := []ParticipantLeaderboard{}
+	if len(entries) == 0 {
+		return result, nil
+	}
+
+	leaderboardIDs := make([]uuid.UUID, 0, len(entries))
+	for _, entry := range entries {
+		leaderboardIDs = append(leaderboardIDs, entry.LeaderboardID)
+	}
+	readable, err := s.readableLeaderboards(ctx, leaderboardIDs, viewer)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.entryRepo.CountRankedByLeaderboardIDs(ctx, leaderboardIDs)
+	if err != nil {
+		return nil, err
+	}
+	moves, err := s.eventRepo.LatestRankChangesForParticipant(ctx, participantID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		leaderboard, ok := readable[entry.LeaderboardID]
+		if !ok {
+			continue
+		}
+
+		standing := ParticipantLeaderboard{
+			Leaderboard:   leaderboard,
+			Rank:          entry.Rank,
+			Score:         entry.Score,
+			RankedEntries: counts[entry.LeaderboardID],
+			Tier:          TierNone,
+			Trend:         TrendSteady,
+			LastUpdated:   entry.LastUpdated,
+		}
+		if entry.Rank >= 1 && standing.RankedEntries > 0 {
+			standing.TopPercent = float64(entry.Rank) / float64(standing.RankedEntries) * 100
+			standing.Tier = tierForTopPercent(standing.TopPercent)
+		}
+		if move, ok := moves[entry.LeaderboardID]; ok {
+			standing.Trend, standing.RankChange = trendForMove(move)
+		}
+		result = append(result, standing)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return rankedBefore(result[i].Rank, result[j].Rank)
+	})
+	return result, nil
+}
+
+// readableLeaderboards loads the leaderboards and keeps the ones the viewer can read, keyed by ID
+func (s *participantStatsService) readableLeaderboards(ctx context.Context, leaderboardIDs []uuid.UUID,
+	viewer Viewer) (map[uuid.UUID]*models.Leaderboard, error) {
+
+	readable := make(map[uuid.UUID]*models.Leaderboard, len(leaderboardIDs))
+	if len(leaderboardIDs) == 0 {
+		return readable, nil
+	}
+
+	leaderboards, err := s.leaderboardRepo.FindByIDs(ctx, leaderboardIDs)
+	if err != nil {
+		return nil, err
+	}
+	leaderboards, err = s.accessService.FilterReadableLeaderboards(ctx, leaderboards, viewer)
+	if err != nil {
+		return nil, err
+	}
+	for i := range leaderboards {
+		readable[leaderboards[i].ID] = &leaderboards[i]
+	}
+	return readable, nil
+}
+
+// metricStats aggregates the participant's values per metric, over their lifetime and each metric's current period
+func (s *participantStatsService) metricStats(ctx context.Context, participantID uuid.UUID, now time.Time) ([]MetricStats, error) {
+	lifetime, err := s.valueRepo.AggregateByMetric(ctx, participantID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(lifetime) == 0 {
+		return []MetricStats{}, nil
+	}
+
+	metricIDs := make([]uuid.UUID, 0, len(lifetime))
+	for _, aggregate := range lifetime {
+		metricIDs = append(metricIDs, aggregate.MetricID)
+	}
+	metrics, err := s.metricRepo.FindByIDs(ctx, metricIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*models.Metric, len(metrics))
+	periodStarts := make(map[uuid.UUID]time.Time)
+	for i := range metrics {
+		byID[metrics[i].ID] = &metrics[i]
+		if start, ok := periodStart(metrics[i].ResetPeriod, now); ok {
+			periodStarts[metrics[i].ID] = start
+		}
+	}
+
+	current, err := s.valueRepo.AggregateByMetric(ctx, participantID, periodStarts)
+	if err != nil {
+		return nil, err
+	}
+	currentByID := make(map[uuid.UUID]repositories.MetricValueAggregate, len(current))
+	for _, aggregate := range current {
+		currentByID[aggregate.MetricID] = aggregate
+	}
+
+	result := make([]MetricStats, 0, len(lifetime))
+	for _, aggregate := range lifetime {
+		metric, ok := byID[aggregate.MetricID]
+		if !ok {
+			continue
+		}
+		metricStats := MetricStats{
+			MetricID:        metric.ID,
+			MetricName:      metric.Name,
+			AggregationType: metric.AggregationType,
+			ResetPeriod:     metric.ResetPeriod,
+			Lifetime:        toMetricAggregate(metric.AggregationType, aggregate),
+		}
+		if start, ok := periodStarts[metric.ID]; ok {
+			// A period without values yet aggregates to zero
+			periodAggregate := toMetricAggregate(metric.AggregationType, currentByID[metric.ID])
+			metricStats.PeriodStart = &start
+			metricStats.CurrentPeriod = &periodAggregate
+		}
+		result = append(result, metricStats)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].MetricName < result[j].MetricName
+	})
+	return result, nil
+}
+
+func toMetricAggregate(aggregation enums.AggregationType, aggregate repositories.MetricValueAggregate) MetricAggregate {
+	result := MetricAggregate{
+		Count:           aggregate.Count,
+		Sum:             aggregate.Sum,
+		Min:             aggregate.Min,
+		Max:             aggregate.Max,
+		Average:         aggregate.Average,
+		LastBoolValue:   aggregate.LastBoolValue,
+		LastStringValue: aggregate.LastStringValue,
+		LastRecordedAt:  aggregate.LastRecordedAt,
+	}
+
+	switch aggregation {
+	case enums.Average:
+		result.Value = aggregate.Average
+	case enums.Count:
+		result.Value = float64(aggregate.Count)
+	case enums.Min:
+		result.Value = aggregate.Min
+	case enums.Max:
+		result.Value = aggregate.Max
+	case enums.Last:
+		result.Value = aggregate.LastValue
+	default:
+		result.Value = aggregate.Sum
+	}
+	return result
+}
+
+// rankedBefore orders ranks best first, with unranked entries last
+func rankedBefore(a, b int) bool {
+	if (a < 1) != (b < 1) {
+		return b < 1
+	}
+	return a < b
+}
+
+func tierForTopPercent(topPercent float64) Tier {
+	switch {
+	case topPercent <= 1:
+		return TierDiamond
+	case topPercent <= 5:
+		return TierPlatinum
+	case topPercent <= 10:
+		return TierGold
+	case topPercent <= 25:
+		return TierSilver
+	case topPercent <= 50:
+		return TierBronze
+	default:
+		return TierNone
+	}
+}
+
+// trendForMove returns the direction of a rank change and the places gained, where a lower rank is better
+func trendForMove(move repositories.RankMove) (Trend, int) {
+	switch {
+	case move.PreviousRank < 1 && move.CurrentRank >= 1:
+		return TrendNew, 0
+	case move.CurrentRank < 1:
+		return TrendDown, 0
+	case move.CurrentRank < move.PreviousRank:
+		return TrendUp, move.PreviousRank - move.CurrentRank
+	case move.CurrentRank > move.PreviousRank:
+		return TrendDown, move.PreviousRank - move.CurrentRank
+	default:
+		return TrendSteady, 0
+	}
+}
+
+// bestRank combines the best rank recorded on a leaderboard with the current one, returning 0 when neither is ranked
+func bestRank(bestRanks map[uuid.UUID]int, leaderboardID uuid.UUID, current int) int {
+	best := bestRanks[leaderboardID]
+	if current >= 1 && (best < 1 || current < best) {
+		best = current
+	}
+	return best
+}
+
+// periodStart returns when the reset period containing now began in UTC, with weeks starting on Monday.
+// It reports false for metrics that never reset.
+func periodStart(period enums.ResetPeriod, now time.Time) (time.Time, bool) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case enums.DailyReset:
+		return today, true
+	case enums.WeeklyReset:
+		return today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7)), true
+	case enums.MonthlyReset:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), true
+	case enums.YearlyReset:
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC), true
+	default:
+		return time.Time{}, false
+	}
+}
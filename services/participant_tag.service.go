@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"leaderboard-service/repositories"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ParticipantTagService interface {
+	// TagParticipants adds every tag to every participant and returns how many tags were added
+	TagParticipants(ctx context.Context, participantIDs []uuid.UUID, tags []string) (int64, error)
+	// UntagParticipants removes the tags from the participants and returns how many were removed
+	UntagParticipants(ctx context.Context, participantIDs []uuid.UUID, tags []string) (int64, error)
+}
+
+type participantTagService struct {
+	repo            repositories.ParticipantTagRepository
+	participantRepo repositories.ParticipantRepository
+}
+
+func NewParticipantTagService(repo repositories.ParticipantTagRepository,
+	participantRepo repositories.ParticipantRepository) ParticipantTagService {
+	return &participantTagService{
+		repo:            repo,
+		participantRepo: participantRepo,
+	}
+}
+
+func (s *participantTagService) TagParticipants(ctx context.Context, participantIDs []uuid.UUID, tags []string) (int64, error) {
+	if err := s.verifyParticipants(ctx, participantIDs); err != nil {
+		return 0, err
+	}
+
+	return s.repo.AddTags(ctx, participantIDs, normalizeTags(tags))
+}
+
+func (s *participantTagService) UntagParticipants(ctx context.Context, participantIDs []uuid.UUID, tags []string) (int64, error) {
+	if err := s.verifyParticipants(ctx, participantIDs); err != nil {
+		return 0, err
+	}
+
+	return s.repo.RemoveTags(ctx, participantIDs, normalizeTags(tags))
+}
+
+// verifyParticipants makes sure every ID belongs to a participant so a typo does not silently tag nobody
+func (s *participantTagService) verifyParticipants(ctx context.Context, participantIDs []uuid.UUID) error {
+	existing, err := s.participantRepo.FindExistingIDs(ctx, participantIDs)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		found[id] = true
+	}
+	for _, id := range participantIDs {
+		if !found[id] {
+			return errors.New("participant not found")
+		}
+	}
+	return nil
+}
+
+// normalizeTags trims and lowercases tags and drops empty and repeated ones
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
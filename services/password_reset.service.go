@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// PasswordResetTokenTTL is how long an emailed reset token stays valid
+const PasswordResetTokenTTL = time.Hour
+
+// PasswordResetConfig controls how reset tokens are delivered
+type PasswordResetConfig struct {
+	Sender EmailSender
+	// ResetURL is the page users open to choose a new password; the token is appended as the token query parameter.
+	// When empty the email contains the bare token.
+	ResetURL string
+}
+
+// Logs reset emails until an email provider is configured at startup
+var passwordResetConfig = PasswordResetConfig{Sender: NewLogEmailSender()}
+
+// ConfigurePasswordReset sets the email sender and reset link used for password reset emails
+func ConfigurePasswordReset(config PasswordResetConfig) {
+	if config.Sender == nil {
+		config.Sender = NewLogEmailSender()
+	}
+	passwordResetConfig = config
+}
+
+type PasswordResetService interface {
+	// RequestReset emails a reset token if an account uses the address. It never reveals whether one does.
+	RequestReset(ctx context.Context, email string) error
+	// ConfirmReset consumes the token and sets the new password
+	ConfirmReset(ctx context.Context, token, newPassword string) error
+}
+
+type passwordResetService struct {
+	userRepo  repositories.UserRepository
+	tokenRepo repositories.PasswordResetTokenRepository
+}
+
+func NewPasswordResetService(userRepo repositories.UserRepository,
+	tokenRepo repositories.PasswordResetTokenRepository) PasswordResetService {
+	return &passwordResetService{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+	}
+}
+
+func (s *passwordResetService) RequestReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.FindByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// Only the most recently requested token is valid
+	now := time.Now()
+	if err := s.tokenRepo.InvalidateForUser(ctx, user.ID, now); err != nil {
+		return err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	rawToken := base64.RawURLEncoding.EncodeToString(secret)
+
+	token := models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashResetToken(rawToken),
+		ExpiresAt: now.Add(PasswordResetTokenTTL),
+	}
+	if err := s.tokenRepo.Create(ctx, &token); err != nil {
+		return err
+	}
+
+	// Send in the background so the response time does not reveal whether the account exists
+	config := passwordResetConfig
+	message := passwordResetEmail(user, rawToken, config.ResetURL)
+	go func() {
+		if err := config.Sender.Send(message); err != nil {
+			log.Printf("auth: failed to send password reset email to user %s: %v", user.ID, err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *passwordResetService) ConfirmReset(ctx context.Context, rawToken, newPassword string) error {
+	token, err := s.tokenRepo.FindByHash(ctx, hashResetToken(rawToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid or expired reset token")
+		}
+		return err
+	}
+
+	now := time.Now()
+	if token.UsedAt != nil || !now.Before(token.ExpiresAt) {
+		return errors.New("invalid or expired reset token")
+	}
+
+	// Consume the token first so it cannot be used twice concurrently
+	consumed, err := s.tokenRepo.MarkUsed(ctx, token.ID, now)
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return errors.New("invalid or expired reset token")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid or expired reset token")
+		}
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = string(hash)
+
+	return s.userRepo.Update(ctx, user)
+}
+
+func passwordResetEmail(user *models.User, rawToken, resetURL string) EmailMessage {
+	instructions := "Use this token to choose a new password: " + rawToken
+	if resetURL != "" {
+		separator := "?"
+		if strings.Contains(resetURL, "?") {
+			separator = "&"
+		}
+		instructions = "Open this link to choose a new password: " + resetURL + separator + "token=" + url.QueryEscape(rawToken)
+	}
+
+	return EmailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nWe received a request to reset your password.\n\n%s\n\n"+
+			"This expires in %d minutes and can only be used once. If you did not ask to reset your password, you can ignore this email.\n",
+			user.Username, instructions, int(PasswordResetTokenTTL.Minutes())),
+	}
+}
+
+func hashResetToken(rawToken string) string {
+	sum := sha256.Sum256([]byte(rawToken))
+	return hex.EncodeToString(sum[:])
+}
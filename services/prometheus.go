@@ -0,0 +1,73 @@
+package services
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// metricsNamespace prefixes every metric the service exports on /metrics
+const metricsNamespace = "leaderboard"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled, by method, route pattern and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time taken to handle HTTP requests, by method and route pattern.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	metricValuesIngested = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "metric_values_ingested_total",
+		Help:      "Metric values stored, by how they arrived: one at a time or in a bulk import.",
+	}, []string{"mode"})
+
+	scoreIncrementsRecorded = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "score_increments_recorded_total",
+		Help:      "Score increments appended to the event log.",
+	})
+
+	rankingJobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "ranking_job_duration_seconds",
+		Help:      "Time taken by each run of a background job recomputing leaderboards, by job.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"job"})
+
+	rankingJobLeaderboards = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "ranking_job_leaderboards_total",
+		Help:      "Leaderboards recomputed by background jobs, by job and result (ok or failed).",
+	}, []string{"job", "result"})
+)
+
+// ObserveRequest counts an HTTP request and its duration. The route is the pattern it matched rather than its path,
+// so IDs don't create a series each.
+func ObserveRequest(method, route string, status int, duration time.Duration) {
+	httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	httpRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// RegisterDBStatsCollector exports the statistics of the database connection pool
+func RegisterDBStatsCollector(db *sql.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, "postgres"))
+}
+
+// observeRankingJob records a run of a background job that recomputed total leaderboards, of which failed failed
+func observeRankingJob(job string, duration time.Duration, total, failed int) {
+	rankingJobDuration.WithLabelValues(job).Observe(duration.Seconds())
+	rankingJobLeaderboards.WithLabelValues(job, "ok").Add(float64(total - failed))
+	rankingJobLeaderboards.WithLabelValues(job, "failed").Add(float64(failed))
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"log"
+)
+
+// PushNotification is a provider agnostic push message
+type PushNotification struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// PushNotifier sends a push notification to a single device token
+type PushNotifier interface {
+	Send(token string, notification PushNotification) error
+}
+
+// FCMConfig holds the credentials for Firebase Cloud Messaging
+type FCMConfig struct {
+	ProjectID       string
+	CredentialsFile string
+}
+
+type fcmNotifier struct {
+	config FCMConfig
+}
+
+// NewFCMNotifier creates a notifier for Android and web devices registered with Firebase
+func NewFCMNotifier(config FCMConfig) PushNotifier {
+	return &fcmNotifier{config: config}
+}
+
+// Send is a stub until the Firebase client is wired in; it only logs the message
+func (n *fcmNotifier) Send(token string, notification PushNotification) error {
+	log.Printf("push(fcm): project=%s token=%s title=%q body=%q",
+		n.config.ProjectID, token, notification.Title, notification.Body)
+	return nil
+}
+
+// APNsConfig holds the token based credentials for the Apple Push Notification service
+type APNsConfig struct {
+	KeyID      string
+	TeamID     string
+	Topic      string
+	KeyFile    string
+	Production bool
+}
+
+type apnsNotifier struct {
+	config APNsConfig
+}
+
+// NewAPNsNotifier creates a notifier for iOS devices
+func NewAPNsNotifier(config APNsConfig) PushNotifier {
+	return &apnsNotifier{config: config}
+}
+
+// Send is a stub until the APNs client is wired in; it only logs the message
+func (n *apnsNotifier) Send(token string, notification PushNotification) error {
+	log.Printf("push(apns): topic=%s production=%t token=%s title=%q body=%q",
+		n.config.Topic, n.config.Production, token, notification.Title, notification.Body)
+	return nil
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+)
+
+// TopRankThreshold is the rank a participant has to reach to get an "entered the top" notification
+const TopRankThreshold = 10
+
+// RankNotifier turns rank change events into push notifications for the affected participants
+type RankNotifier struct {
+	tokenRepo repositories.DeviceTokenRepository
+	entryRepo repositories.LeaderboardEntryRepository
+	notifiers map[enums.PushProvider]PushNotifier
+}
+
+func NewRankNotifier(tokenRepo repositories.DeviceTokenRepository,
+	entryRepo repositories.LeaderboardEntryRepository,
+	notifiers map[enums.PushProvider]PushNotifier) *RankNotifier {
+	return &RankNotifier{
+		tokenRepo: tokenRepo,
+		entryRepo: entryRepo,
+		notifiers: notifiers,
+	}
+}
+
+// Publish implements EventPublisher and only reacts to entry.rank_changed events
+func (n *RankNotifier) Publish(event Event) {
+	if event.Type != enums.EntryRankChanged {
+		return
+	}
+	change, ok := event.Data.(RankChange)
+	if !ok || change.Entry == nil {
+		return
+	}
+
+	go n.notify(context.Background(), change)
+}
+
+func (n *RankNotifier) notify(ctx context.Context, change RankChange) {
+	entry := change.Entry
+	previous, current := change.PreviousRank, change.CurrentRank
+
+	// Ranks start at 1, anything else means the entry was not ranked before
+	if current < 1 || (previous >= 1 && current >= previous) {
+		return
+	}
+
+	if current <= TopRankThreshold && (previous < 1 || previous > TopRankThreshold) {
+		n.sendToParticipants(ctx, []uuid.UUID{entry.ParticipantID}, PushNotification{
+			Title: fmt.Sprintf("You're in the top %d!", TopRankThreshold),
+			Body:  fmt.Sprintf("You moved up to rank %d.", current),
+			Data:  rankNotificationData("entered_top", entry),
+		})
+	}
+
+	// Everyone between the new and the old position has been overtaken
+	upper := previous
+	if previous < 1 {
+		upper = current
+	}
+	overtaken, err := n.entryRepo.FindByRankRange(ctx, entry.LeaderboardID, current, upper)
+	if err != nil {
+		log.Printf("push: failed to load overtaken entries for leaderboard %s: %v", entry.LeaderboardID, err)
+		return
+	}
+
+	var participantIDs []uuid.UUID
+	for _, other := range overtaken {
+		if other.ID == entry.ID || other.ParticipantID == entry.ParticipantID {
+			continue
+		}
+		participantIDs = append(participantIDs, other.ParticipantID)
+	}
+	n.sendToParticipants(ctx, participantIDs, PushNotification{
+		Title: "You've been overtaken",
+		Body:  fmt.Sprintf("Someone just passed you and took rank %d.", current),
+		Data:  rankNotificationData("overtaken", entry),
+	})
+}
+
+func (n *RankNotifier) sendToParticipants(ctx context.Context, participantIDs []uuid.UUID, notification PushNotification) {
+	if len(participantIDs) == 0 {
+		return
+	}
+
+	tokens, err := n.tokenRepo.FindByParticipantIDs(ctx, participantIDs)
+	if err != nil {
+		log.Printf("push: failed to load device tokens: %v", err)
+		return
+	}
+
+	for _, token := range tokens {
+		notifier, ok := n.notifiers[token.Provider]
+		if !ok {
+			continue
+		}
+		if err := notifier.Send(token.Token, notification); err != nil {
+			log.Printf("push: failed to notify %s device %s: %v", token.Provider, token.ID, err)
+		}
+	}
+}
+
+func rankNotificationData(kind string, entry *models.LeaderboardEntry) map[string]string {
+	return map[string]string{
+		"type":           kind,
+		"leaderboard_id": entry.LeaderboardID.String(),
+		"entry_id":       entry.ID.String(),
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultRecomputeWorkers is how many leaderboards a background job recomputes at once
+const DefaultRecomputeWorkers = 4
+
+// Workers each background job recomputes leaderboards on, set once at startup
+var recomputeWorkers = DefaultRecomputeWorkers
+
+// ConfigureRecomputeWorkers sets how many leaderboards a background job recomputes at once. Each worker holds a
+// database connection while it runs, so keep it well below DB_MAX_OPEN_CONNS.
+func ConfigureRecomputeWorkers(workers int) {
+	recomputeWorkers = workers
+}
+
+// recomputeLeaderboards runs recompute for each leaderboard on a bounded pool of workers and waits for all of them.
+// Boards are independent: one that fails or panics is logged under the job's name without stopping the others, and
+// the IDs of the boards that failed are returned. Each run is timed on /metrics under the job's name.
+func recomputeLeaderboards(job string, leaderboardIDs []uuid.UUID, recompute func(leaderboardID uuid.UUID) error) []uuid.UUID {
+	start := time.Now()
+	workers := min(recomputeWorkers, len(leaderboardIDs))
+	queue := make(chan uuid.UUID)
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed []uuid.UUID
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for leaderboardID := range queue {
+				if err := recoverRecompute(leaderboardID, recompute); err != nil {
+					log.Printf("%s: failed to recompute leaderboard %s: %v", job, leaderboardID, err)
+					mu.Lock()
+					failed = append(failed, leaderboardID)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, leaderboardID := range leaderboardIDs {
+		queue <- leaderboardID
+	}
+	close(queue)
+	wg.Wait()
+
+	observeRankingJob(job, time.Since(start), len(leaderboardIDs), len(failed))
+	return failed
+}
+
+// recoverRecompute runs recompute for one leaderboard, turning a panic into an error so it can't take down the
+// other workers
+func recoverRecompute(leaderboardID uuid.UUID, recompute func(uuid.UUID) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return recompute(leaderboardID)
+}
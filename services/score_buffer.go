@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultScoreBufferInterval is how often buffered score increments are applied when no interval is configured
+const DefaultScoreBufferInterval = 5 * time.Second
+
+const (
+	// Increments are only recovered from the event log once they are this many intervals old, well past any flush of
+	// the instance that recorded them
+	scoreRecoveryIntervals = 10
+	// Oldest increment a flush still recovers
+	scoreRecoveryWindow = 7 * 24 * time.Hour
+	// Most increments recovered in one flush
+	scoreRecoveryBatch = 1000
+)
+
+// ScoreBuffer holds recorded score increments in memory and applies them to Postgres together on an interval.
+// Increments are written to the event log before they are buffered, so those lost with the process are recovered
+// from the log by the next flush of any instance.
+type ScoreBuffer struct {
+	incrementRepo   repositories.ScoreIncrementRepository
+	entryRepo       repositories.LeaderboardEntryRepository
+	leaderboardRepo repositories.LeaderboardRepository
+	interval        time.Duration // Set by Run, the recovery age of increments depends on it
+
+	mu      sync.Mutex
+	pending []uint64
+}
+
+// Global buffer used by the entry service, nil while increments are applied as they are made
+var scoreBuffer *ScoreBuffer
+
+func NewScoreBuffer(incrementRepo repositories.ScoreIncrementRepository,
+	entryRepo repositories.LeaderboardEntryRepository,
+	leaderboardRepo repositories.LeaderboardRepository) *ScoreBuffer {
+	return &ScoreBuffer{
+		incrementRepo:   incrementRepo,
+		entryRepo:       entryRepo,
+		leaderboardRepo: leaderboardRepo,
+	}
+}
+
+// EnableScoreBuffer makes the entry service buffer score increments instead of applying them right away
+func EnableScoreBuffer(buffer *ScoreBuffer) {
+	scoreBuffer = buffer
+}
+
+// add buffers a recorded increment until the next flush
+func (b *ScoreBuffer) add(eventID uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending = append(b.pending, eventID)
+}
+
+// Run applies the buffered increments every interval
+func (b *ScoreBuffer) Run(interval time.Duration) {
+	b.interval = interval
+	ctx := context.Background()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		b.flush(ctx, time.Now())
+	}
+}
+
+func (b *ScoreBuffer) flush(ctx context.Context, now time.Time) {
+	b.mu.Lock()
+	eventIDs := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	// Increments of crashed instances are applied with this instance's own. Ones another instance is applying at the
+	// same time are only counted once, so recovering them too is harmless.
+	recoveryAge := max(scoreRecoveryIntervals*b.interval, time.Minute)
+	recovered, err := b.incrementRepo.FindUnapplied(ctx, now.Add(-scoreRecoveryWindow), now.Add(-recoveryAge), scoreRecoveryBatch)
+	if err != nil {
+		log.Printf("Score buffer: failed to find unapplied increments: %v", err)
+	}
+	eventIDs = append(eventIDs, recovered...)
+	if len(eventIDs) == 0 {
+		return
+	}
+
+	incremented, err := b.incrementRepo.Apply(ctx, eventIDs, now)
+	if err != nil {
+		log.Printf("Score buffer: failed to apply %d increments: %v", len(eventIDs), err)
+		b.mu.Lock()
+		b.pending = append(b.pending, eventIDs...)
+		b.mu.Unlock()
+		return
+	}
+
+	byBoard := make(map[uuid.UUID][]uuid.UUID)
+	var leaderboardIDs []uuid.UUID
+	for _, entry := range incremented {
+		if _, ok := byBoard[entry.LeaderboardID]; !ok {
+			leaderboardIDs = append(leaderboardIDs, entry.LeaderboardID)
+		}
+		byBoard[entry.LeaderboardID] = append(byBoard[entry.LeaderboardID], entry.EntryID)
+	}
+
+	// Scores are already applied, so a board that fails to re-rank keeps its old ranks until its next increment
+	recomputeLeaderboards("score buffer", leaderboardIDs, func(leaderboardID uuid.UUID) error {
+		return rankIncrementedEntries(ctx, b.entryRepo, b.leaderboardRepo, leaderboardID, byBoard[leaderboardID], now)
+	})
+}
+
+// rankIncrementedEntries re-ranks a leaderboard after increments were applied to some of its entries, publishing
+// entry.updated for them and for every other entry that moved, and entry.rank_changed for all that moved
+func rankIncrementedEntries(ctx context.Context, entryRepo repositories.LeaderboardEntryRepository,
+	leaderboardRepo repositories.LeaderboardRepository, leaderboardID uuid.UUID, entryIDs []uuid.UUID, now time.Time) error {
+	leaderboard, err := leaderboardRepo.FindByID(ctx, leaderboardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The leaderboard was deleted since, so there is nothing left to rank
+			return nil
+		}
+		return err
+	}
+
+	updates, err := entryRepo.BulkUpdateRanks(ctx, leaderboard.ID, leaderboard.SortOrder, now)
+	if err != nil {
+		return err
+	}
+	previousRanks := make(map[uuid.UUID]int, len(updates))
+	changedIDs := append([]uuid.UUID(nil), entryIDs...)
+	for _, update := range updates {
+		previousRanks[update.EntryID] = update.PreviousRank
+		changedIDs = append(changedIDs, update.EntryID)
+	}
+
+	entries, err := entryRepo.FindByIDs(ctx, changedIDs)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		entry := &entries[i]
+		publishEvent(enums.EntryUpdated, &entry.LeaderboardID, entry)
+		if previousRank, moved := previousRanks[entry.ID]; moved {
+			publishEvent(enums.EntryRankChanged, &entry.LeaderboardID, RankChange{
+				Entry:        entry,
+				PreviousRank: previousRank,
+				CurrentRank:  entry.Rank,
+			})
+		}
+	}
+	return nil
+}
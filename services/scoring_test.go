@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+)
+
+// In-memory repositories for benchmarking the scoring and ranking code without a database. Each embeds the real
+// interface and implements only the methods the benchmarked paths call.
+
+type benchLeaderboardRepo struct {
+	repositories.LeaderboardRepository
+	leaderboard *models.Leaderboard
+}
+
+func (r *benchLeaderboardRepo) FindByID(context.Context, uuid.UUID) (*models.Leaderboard, error) {
+	return r.leaderboard, nil
+}
+
+type benchEntryRepo struct {
+	repositories.LeaderboardEntryRepository
+	entries []models.LeaderboardEntry
+}
+
+func (r *benchEntryRepo) FindPublishedByLeaderboardID(context.Context, uuid.UUID) ([]models.LeaderboardEntry, error) {
+	// Standings reorder the entries, so every call gets its own copy
+	return append([]models.LeaderboardEntry(nil), r.entries...), nil
+}
+
+type benchMetricRepo struct {
+	repositories.MetricRepository
+	metrics []repositories.LeaderboardMetricWeight
+}
+
+func (r *benchMetricRepo) FindByLeaderboardID(context.Context, uuid.UUID) ([]repositories.LeaderboardMetricWeight, error) {
+	return r.metrics, nil
+}
+
+type benchValueRepo struct {
+	repositories.MetricValueRepository
+	aggregates []repositories.ParticipantMetricAggregate
+	parts      []repositories.MetricValuePart
+}
+
+func (r *benchValueRepo) AggregateForParticipants(context.Context, []uuid.UUID, []uuid.UUID, *time.Time,
+	*time.Time) ([]repositories.ParticipantMetricAggregate, error) {
+	return r.aggregates, nil
+}
+
+func (r *benchValueRepo) FindPartsForParticipants(context.Context, []uuid.UUID, []uuid.UUID, *time.Time, *time.Time) ([]repositories.MetricValuePart, error) {
+	return r.parts, nil
+}
+
+type benchEventRepo struct {
+	repositories.EventRepository
+	moves map[uuid.UUID]repositories.RankMove
+}
+
+func (r *benchEventRepo) LatestRankChangesForLeaderboard(context.Context, uuid.UUID) (map[uuid.UUID]repositories.RankMove, error) {
+	return r.moves, nil
+}
+
+type benchTeamMemberRepo struct {
+	repositories.TeamMemberRepository
+	memberships []models.TeamMember
+}
+
+func (r *benchTeamMemberRepo) FindByTeamID(context.Context, uuid.UUID, *time.Time) ([]models.TeamMember, error) {
+	return r.memberships, nil
+}
+
+var benchAggregations = []enums.AggregationType{enums.Sum, enums.Average, enums.Max, enums.Last}
+
+// buildStandings creates a leaderboard with the given number of ranked entries, each with an aggregate and a recent
+// rank change for every one of its metrics
+func buildStandings(entries, metrics int) (StandingsService, uuid.UUID) {
+	rng := rand.New(rand.NewSource(1))
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	leaderboard := &models.Leaderboard{Name: "Bench", Type: enums.Individual, SortOrder: enums.Descending}
+	leaderboard.ID = uuid.New()
+
+	metricRepo := &benchMetricRepo{}
+	for i := 0; i < metrics; i++ {
+		metric := repositories.LeaderboardMetricWeight{Weight: 1}
+		metric.ID = uuid.New()
+		metric.Name = fmt.Sprintf("metric-%d", i)
+		metric.AggregationType = benchAggregations[i%len(benchAggregations)]
+		metricRepo.metrics = append(metricRepo.metrics, metric)
+	}
+
+	entryRepo := &benchEntryRepo{}
+	valueRepo := &benchValueRepo{}
+	eventRepo := &benchEventRepo{moves: make(map[uuid.UUID]repositories.RankMove, entries)}
+	// Entries come back unsorted, as they would after concurrent updates
+	for _, rank := range rng.Perm(entries) {
+		entry := models.LeaderboardEntry{
+			LeaderboardID: leaderboard.ID,
+			ParticipantID: uuid.New(),
+			Rank:          rank + 1,
+			Score:         float64(entries - rank),
+			LastUpdated:   now,
+		}
+		entry.ID = uuid.New()
+		entryRepo.entries = append(entryRepo.entries, entry)
+		eventRepo.moves[entry.ParticipantID] = repositories.RankMove{PreviousRank: rank + 1 + rng.Intn(5) - 2, CurrentRank: rank + 1}
+
+		for _, metric := range metricRepo.metrics {
+			valueRepo.aggregates = append(valueRepo.aggregates, repositories.ParticipantMetricAggregate{
+				ParticipantID: entry.ParticipantID,
+				MetricValueAggregate: repositories.MetricValueAggregate{
+					MetricID:       metric.ID,
+					Count:          10,
+					Sum:            rng.Float64() * 1000,
+					Min:            1,
+					Max:            100,
+					Average:        50,
+					LastValue:      rng.Float64() * 100,
+					LastRecordedAt: &now,
+				},
+			})
+		}
+	}
+
+	service := NewStandingsService(&benchLeaderboardRepo{leaderboard: leaderboard}, entryRepo, metricRepo, valueRepo, eventRepo)
+	return service, leaderboard.ID
+}
+
+func BenchmarkListStandings(b *testing.B) {
+	for _, size := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("entries=%d", size), func(b *testing.B) {
+			service, leaderboardID := buildStandings(size, 4)
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := service.ListStandings(ctx, leaderboardID); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// buildTeam creates a team of members who were each on it for half of the leaderboard's range, with the given
+// number of parts per member and metric, oldest first
+func buildTeam(members, metrics, partsPerMetric int) (*teamScoreService, *models.Leaderboard, []uuid.UUID,
+	map[uuid.UUID]float64, map[uuid.UUID]enums.AggregationType) {
+	rng := rand.New(rand.NewSource(1))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	leaderboard := &models.Leaderboard{Type: enums.Team, SortOrder: enums.Descending,
+		TeamScoreAggregation: enums.TeamScoreTopK, TeamScoreTopK: members / 2}
+	leaderboard.ID = uuid.New()
+	teamID := uuid.New()
+
+	metricIDs := make([]uuid.UUID, metrics)
+	weights := make(map[uuid.UUID]float64, metrics)
+	aggregations := make(map[uuid.UUID]enums.AggregationType, metrics)
+	for i := range metricIDs {
+		metricIDs[i] = uuid.New()
+		weights[metricIDs[i]] = float64(i + 1)
+		aggregations[metricIDs[i]] = benchAggregations[i%len(benchAggregations)]
+	}
+
+	memberRepo := &benchTeamMemberRepo{}
+	valueRepo := &benchValueRepo{}
+	left := start.Add(time.Duration(partsPerMetric/2) * time.Hour)
+	for i := 0; i < members; i++ {
+		membership := models.TeamMember{TeamID: teamID, MemberID: uuid.New(), JoinedAt: start}
+		if i%2 == 1 {
+			membership.JoinedAt, membership.LeftAt = left, nil
+		} else {
+			membership.LeftAt = &left
+		}
+		memberRepo.memberships = append(memberRepo.memberships, membership)
+	}
+	for p := 0; p < partsPerMetric; p++ {
+		at := start.Add(time.Duration(p) * time.Hour)
+		for _, membership := range memberRepo.memberships {
+			for _, metricID := range metricIDs {
+				value := rng.Float64() * 100
+				valueRepo.parts = append(valueRepo.parts, repositories.MetricValuePart{
+					MetricID: metricID, ParticipantID: membership.MemberID,
+					Count: 1, Sum: value, Min: value, Max: value, LastValue: value,
+					FirstRecordedAt: at, LastRecordedAt: at,
+				})
+			}
+		}
+	}
+
+	s := &teamScoreService{teamMemberRepo: memberRepo, valueRepo: valueRepo}
+	return s, leaderboard, metricIDs, weights, aggregations
+}
+
+func BenchmarkTeamScore(b *testing.B) {
+	for _, members := range []int{10, 100} {
+		b.Run(fmt.Sprintf("members=%d", members), func(b *testing.B) {
+			s, leaderboard, metricIDs, weights, aggregations := buildTeam(members, 3, 100)
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := s.teamScore(ctx, leaderboard, uuid.Nil, metricIDs, weights, aggregations); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCombineMemberScores(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	scores := make([]float64, 1000)
+	for i := range scores {
+		scores[i] = rng.Float64() * 1000
+	}
+	for _, aggregation := range []enums.TeamScoreAggregation{enums.TeamScoreSum, enums.TeamScoreAverage, enums.TeamScoreTopK} {
+		leaderboard := &models.Leaderboard{SortOrder: enums.Descending, TeamScoreAggregation: aggregation, TeamScoreTopK: 10}
+		b.Run(string(aggregation), func(b *testing.B) {
+			work := make([]float64, len(scores))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				// Top-k sorts in place, so every run starts from the same unsorted scores
+				copy(work, scores)
+				combineMemberScores(work, leaderboard)
+			}
+		})
+	}
+}
+
+func BenchmarkCombineBuckets(b *testing.B) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	buckets := make([]repositories.MetricValueBucket, 24*90)
+	for i := range buckets {
+		at := start.Add(time.Duration(i) * time.Hour)
+		buckets[i] = repositories.MetricValueBucket{PeriodStart: &at, MetricValueAggregate: repositories.MetricValueAggregate{
+			Count: 4, Sum: float64(i), Min: 0, Max: float64(i), LastValue: float64(i), LastRecordedAt: &at,
+		}}
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		combineBuckets(buckets)
+	}
+}
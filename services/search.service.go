@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+
+	"leaderboard-service/repositories"
+)
+
+const (
+	DefaultSearchPageSize = 20
+	MaxSearchPageSize     = 100
+)
+
+// SearchService finds leaderboards, participants and metrics by name for quick navigation
+type SearchService interface {
+	Search(ctx context.Context, query string, types []string, page, perPage int) ([]repositories.SearchResult, int64, error)
+}
+
+type searchService struct {
+	repo repositories.SearchRepository
+}
+
+func NewSearchService(repo repositories.SearchRepository) SearchService {
+	return &searchService{
+		repo: repo,
+	}
+}
+
+// Search returns one page of the resources whose name contains the query, and how many there are in all.
+// No types searches every type.
+func (s *searchService) Search(ctx context.Context, query string, types []string, page, perPage int) ([]repositories.SearchResult, int64, error) {
+	if len(types) == 0 {
+		types = repositories.SearchTypes
+	}
+	if page < 1 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = DefaultSearchPageSize
+	}
+	if perPage > MaxSearchPageSize {
+		perPage = MaxSearchPageSize
+	}
+
+	return s.repo.Search(ctx, query, types, perPage, (page-1)*perPage)
+}
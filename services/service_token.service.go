@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxServiceTokenLifetime caps how far in the future a service token may expire
+	MaxServiceTokenLifetime = 2 * 365 * 24 * time.Hour
+
+	// Avoid a write on every request by recording usage at most this often per token
+	serviceTokenLastUsedInterval = time.Minute
+)
+
+// Errors for service tokens that can't be used, returned by Verify and RevokeServiceToken
+var (
+	ErrServiceTokenNotFound = errors.New("service token not found")
+	ErrServiceTokenRevoked  = errors.New("service token revoked")
+	ErrServiceTokenExpired  = errors.New("service token expired")
+)
+
+// ServiceTokenSigner mints the JWT for a stored service token record
+type ServiceTokenSigner func(token *models.ServiceToken) (string, error)
+
+type ServiceTokenService interface {
+	// CreateServiceToken stores the token record and returns it together with the signed JWT, which cannot be recovered later
+	CreateServiceToken(ctx context.Context, name string, audience, scopes []string, expiresAt time.Time, createdBy *uuid.UUID) (*models.ServiceToken, string, error)
+	ListServiceTokens(ctx context.Context) ([]models.ServiceToken, error)
+	RevokeServiceToken(ctx context.Context, id uuid.UUID) error
+	// Verify checks that the token named by a JWT's jti is still active
+	Verify(ctx context.Context, id uuid.UUID) (*models.ServiceToken, error)
+}
+
+type serviceTokenService struct {
+	repo   repositories.ServiceTokenRepository
+	signer ServiceTokenSigner
+}
+
+func NewServiceTokenService(repo repositories.ServiceTokenRepository, signer ServiceTokenSigner) ServiceTokenService {
+	return &serviceTokenService{
+		repo:   repo,
+		signer: signer,
+	}
+}
+
+func (s *serviceTokenService) CreateServiceToken(ctx context.Context, name string, audience, scopes []string, expiresAt time.Time,
+	createdBy *uuid.UUID) (*models.ServiceToken, string, error) {
+
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return nil, "", errors.New("expiry must be in the future")
+	}
+	if expiresAt.Sub(now) > MaxServiceTokenLifetime {
+		return nil, "", errors.New("expiry exceeds the maximum lifetime")
+	}
+
+	token := models.ServiceToken{
+		Name:      name,
+		Audience:  audience,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedBy: createdBy,
+	}
+
+	err := s.repo.Create(ctx, &token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	signed, err := s.signer(&token)
+	if err != nil {
+		// Do not leave an inventory entry behind for a token that was never issued
+		if revokeErr := s.repo.Revoke(ctx, token.ID, now); revokeErr != nil {
+			log.Printf("service tokens: failed to revoke unsigned token %s: %v", token.ID, revokeErr)
+		}
+		return nil, "", err
+	}
+
+	return &token, signed, nil
+}
+
+func (s *serviceTokenService) ListServiceTokens(ctx context.Context) ([]models.ServiceToken, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *serviceTokenService) RevokeServiceToken(ctx context.Context, id uuid.UUID) error {
+	token, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrServiceTokenNotFound
+		}
+		return err
+	}
+
+	if token.RevokedAt != nil {
+		return nil
+	}
+
+	return s.repo.Revoke(ctx, id, time.Now())
+}
+
+func (s *serviceTokenService) Verify(ctx context.Context, id uuid.UUID) (*models.ServiceToken, error) {
+	token, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrServiceTokenNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if token.RevokedAt != nil {
+		return nil, ErrServiceTokenRevoked
+	}
+	if !token.IsActive(now) {
+		return nil, ErrServiceTokenExpired
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > serviceTokenLastUsedInterval {
+		if err := s.repo.TouchLastUsed(ctx, token.ID, now); err != nil {
+			log.Printf("service tokens: failed to record usage of token %s: %v", token.ID, err)
+		}
+	}
+
+	return token, nil
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// notDeletedError explains why a resource could not be restored once it was not found among the soft-deleted ones,
+// from the error looking it up among the live ones: either it was never deleted or it does not exist
+func notDeletedError(resource string, liveErr error) error {
+	if liveErr == nil {
+		return errors.New(resource + " is not deleted")
+	}
+	if errors.Is(liveErr, gorm.ErrRecordNotFound) {
+		return errors.New(resource + " not found")
+	}
+	return liveErr
+}
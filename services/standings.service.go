@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Standing is one entry of a leaderboard with what clients display next to it
+type Standing struct {
+	EntryID       uuid.UUID           `json:"entry_id"`
+	ParticipantID uuid.UUID           `json:"participant_id"`
+	Participant   *models.Participant `json:"participant"`
+	Rank          int                 `json:"rank"`
+	Score         float64             `json:"score"`
+	TopPercent    float64             `json:"top_percent"` // Rank as a percentage of the ranked entries, 0 when unranked
+	Tier          Tier                `json:"tier"`
+	Trend         Trend               `json:"trend"`
+	RankChange    int                 `json:"rank_change"` // Places gained (positive) or lost in the most recent rank change
+	LastUpdated   time.Time           `json:"last_updated"`
+	Metrics       []StandingMetric    `json:"metrics"`
+}
+
+// CompactStanding is a standing with only what a leaderboard list shows, read from the entries table alone
+type CompactStanding struct {
+	EntryID         uuid.UUID `json:"entry_id"`
+	ParticipantID   uuid.UUID `json:"participant_id"`
+	ParticipantName string    `json:"participant_name"` // Display name, or the name when there is none
+	AvatarURL       string    `json:"avatar_url"`
+	Rank            int       `json:"rank"`
+	Score           float64   `json:"score"`
+	TopPercent      float64   `json:"top_percent"`
+	Tier            Tier      `json:"tier"`
+	LastUpdated     time.Time `json:"last_updated"`
+}
+
+// StandingMetric aggregates the participant's values for one of the leaderboard's metrics within its date range
+type StandingMetric struct {
+	MetricID        uuid.UUID             `json:"metric_id"`
+	MetricName      string                `json:"metric_name"`
+	AggregationType enums.AggregationType `json:"aggregation_type"`
+	Weight          float64               `json:"weight"`
+	Aggregate       MetricAggregate       `json:"aggregate"`
+	WeightedValue   float64               `json:"weighted_value"` // Aggregate value times the weight
+}
+
+type StandingsService interface {
+	// ListStandings returns the leaderboard's published entries best rank first, each with its participant, tier,
+	// latest rank change and the participant's values for the leaderboard's metrics
+	ListStandings(ctx context.Context, leaderboardID uuid.UUID) ([]Standing, error)
+	// ListCompactStandings returns the leaderboard's published entries best rank first with the participant's shown
+	// name, avatar and tier, without reading participants, metric values or rank changes
+	ListCompactStandings(ctx context.Context, leaderboardID uuid.UUID) ([]CompactStanding, error)
+}
+
+type standingsService struct {
+	leaderboardRepo repositories.LeaderboardRepository
+	entryRepo       repositories.LeaderboardEntryRepository
+	metricRepo      repositories.MetricRepository
+	valueRepo       repositories.MetricValueRepository
+	eventRepo       repositories.EventRepository
+}
+
+func NewStandingsService(leaderboardRepo repositories.LeaderboardRepository,
+	entryRepo repositories.LeaderboardEntryRepository,
+	metricRepo repositories.MetricRepository,
+	valueRepo repositories.MetricValueRepository,
+	eventRepo repositories.EventRepository) StandingsService {
+	return &standingsService{
+		leaderboardRepo: leaderboardRepo,
+		entryRepo:       entryRepo,
+		metricRepo:      metricRepo,
+		valueRepo:       valueRepo,
+		eventRepo:       eventRepo,
+	}
+}
+
+func (s *standingsService) ListStandings(ctx context.Context, leaderboardID uuid.UUID) ([]Standing, error) {
+	leaderboard, err := s.leaderboardRepo.FindByID(ctx, leaderboardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("leaderboard not found")
+		}
+		return nil, err
+	}
+
+	entries, err := s.entryRepo.FindPublishedByLeaderboardID(ctx, leaderboardID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Standing, 0, len(entries))
+	if len(entries) == 0 {
+		return result, nil
+	}
+
+	metrics, err := s.metricRepo.FindByLeaderboardID(ctx, leaderboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	participantIDs := make([]uuid.UUID, 0, len(entries))
+	var ranked int64
+	for _, entry := range entries {
+		participantIDs = append(participantIDs, entry.ParticipantID)
+		if entry.Rank >= 1 {
+			ranked++
+		}
+	}
+
+	metricIDs := make([]uuid.UUID, 0, len(metrics))
+	for _, metric := range metrics {
+		metricIDs = append(metricIDs, metric.ID)
+	}
+	aggregates, err := s.participantAggregates(ctx, leaderboard, metricIDs, participantIDs)
+	if err != nil {
+		return nil, err
+	}
+	byParticipant := make(map[uuid.UUID]map[uuid.UUID]repositories.MetricValueAggregate)
+	for _, aggregate := range aggregates {
+		if byParticipant[aggregate.ParticipantID] == nil {
+			byParticipant[aggregate.ParticipantID] = make(map[uuid.UUID]repositories.MetricValueAggregate)
+		}
+		byParticipant[aggregate.ParticipantID][aggregate.MetricID] = aggregate.MetricValueAggregate
+	}
+
+	moves, err := s.eventRepo.LatestRankChangesForLeaderboard(ctx, leaderboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		standing := Standing{
+			EntryID:       entry.ID,
+			ParticipantID: entry.ParticipantID,
+			Participant:   entry.Participant,
+			Rank:          entry.Rank,
+			Score:         entry.Score,
+			Tier:          TierNone,
+			Trend:         TrendSteady,
+			LastUpdated:   entry.LastUpdated,
+			Metrics:       make([]StandingMetric, 0, len(metrics)),
+		}
+		if entry.Rank >= 1 {
+			standing.TopPercent = float64(entry.Rank) / float64(ranked) * 100
+			standing.Tier = tierForTopPercent(standing.TopPercent)
+		}
+		if move, ok := moves[entry.ParticipantID]; ok {
+			standing.Trend, standing.RankChange = trendForMove(move)
+		}
+
+		// Metrics without values in the date range aggregate to zero
+		for _, metric := range metrics {
+			aggregate := toMetricAggregate(metric.AggregationType, byParticipant[entry.ParticipantID][metric.ID])
+			standing.Metrics = append(standing.Metrics, StandingMetric{
+				MetricID:        metric.ID,
+				MetricName:      metric.Name,
+				AggregationType: metric.AggregationType,
+				Weight:          metric.Weight,
+				Aggregate:       aggregate,
+				WeightedValue:   metric.Weight * aggregate.Value,
+			})
+		}
+		result = append(result, standing)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return rankedBefore(result[i].Rank, result[j].Rank)
+	})
+	return result, nil
+}
+
+func (s *standingsService) ListCompactStandings(ctx context.Context, leaderboardID uuid.UUID) ([]CompactStanding, error) {
+	if _, err := s.leaderboardRepo.FindByID(ctx, leaderboardID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("leaderboard not found")
+		}
+		return nil, err
+	}
+
+	entries, err := s.entryRepo.FindPublishedWithoutParticipants(ctx, leaderboardID)
+	if err != nil {
+		return nil, err
+	}
+	var ranked int64
+	for _, entry := range entries {
+		if entry.Rank >= 1 {
+			ranked++
+		}
+	}
+
+	result := make([]CompactStanding, 0, len(entries))
+	for _, entry := range entries {
+		standing := CompactStanding{
+			EntryID:         entry.ID,
+			ParticipantID:   entry.ParticipantID,
+			ParticipantName: entry.ParticipantName,
+			AvatarURL:       entry.AvatarURL,
+			Rank:            entry.Rank,
+			Score:           entry.Score,
+			Tier:            TierNone,
+			LastUpdated:     entry.LastUpdated,
+		}
+		if entry.Rank >= 1 {
+			standing.TopPercent = float64(entry.Rank) / float64(ranked) * 100
+			standing.Tier = tierForTopPercent(standing.TopPercent)
+		}
+		result = append(result, standing)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return rankedBefore(result[i].Rank, result[j].Rank)
+	})
+	return result, nil
+}
+
+// participantAggregates reads the participants' aggregates from the standings summary when it is enabled and up to
+// date, and from their metric values otherwise
+func (s *standingsService) participantAggregates(ctx context.Context, leaderboard *models.Leaderboard, metricIDs,
+	participantIDs []uuid.UUID) ([]repositories.ParticipantMetricAggregate, error) {
+	if standingsSummary != nil && FeatureEnabled(enums.FeatureStandingsSummary) {
+		if aggregates, ok := standingsSummary.aggregates(ctx, leaderboard.ID, participantIDs); ok {
+			return aggregates, nil
+		}
+	}
+	return s.valueRepo.AggregateForParticipants(ctx, metricIDs, participantIDs, leaderboard.StartDate, leaderboard.EndDate)
+}
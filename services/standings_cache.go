@@ -0,0 +1,305 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	redisTimeout             = 2 * time.Second
+	DefaultStandingsCacheTTL = 5 * time.Minute
+	// DefaultStandingsCacheWarm is how many of the most-read leaderboards have their standings cached on startup
+	DefaultStandingsCacheWarm = 50
+	// standingsReadDays is how many days of standings reads pick the leaderboards to warm
+	standingsReadDays = 7
+)
+
+// StandingsCacheConfig configures the optional Redis cache of public leaderboard standings
+type StandingsCacheConfig struct {
+	URL       string        // Redis URL, e.g. redis://localhost:6379/0
+	KeyPrefix string        // Standings are stored under <prefix>:<leaderboard id>, defaults to standings
+	TTL       time.Duration // How long an unread board is kept, defaults to DefaultStandingsCacheTTL
+}
+
+// StandingsCache keeps serialized standings in Redis. Each leaderboard has one hash holding the standings of its
+// current version, so a read either finds that version or misses, and writes to a board drop its hash.
+type StandingsCache struct {
+	client    *redis.Client
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// Global cache used by WithStandingsCache, nil while caching is disabled
+var standingsCache *StandingsCache
+
+// NewStandingsCache connects to Redis and returns a cache for standings
+func NewStandingsCache(config StandingsCacheConfig) (*StandingsCache, error) {
+	options, err := redis.ParseURL(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	client := redis.NewClient(options)
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis at %s: %w", options.Addr, err)
+	}
+
+	keyPrefix := config.KeyPrefix
+	if keyPrefix == "" {
+		keyPrefix = "standings"
+	}
+	ttl := config.TTL
+	if ttl <= 0 {
+		ttl = DefaultStandingsCacheTTL
+	}
+
+	return &StandingsCache{
+		client:    client,
+		keyPrefix: keyPrefix,
+		ttl:       ttl,
+	}, nil
+}
+
+// EnableStandingsCache makes the standings services returned by WithStandingsCache read through the cache
+func EnableStandingsCache(cache *StandingsCache) {
+	standingsCache = cache
+}
+
+// Ping checks that Redis can be reached
+func (c *StandingsCache) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, redisTimeout)
+	defer cancel()
+
+	return c.client.Ping(ctx).Err()
+}
+
+func (c *StandingsCache) key(leaderboardID uuid.UUID) string {
+	return c.keyPrefix + ":" + leaderboardID.String()
+}
+
+// Get returns the standings cached for the leaderboard at the version, if there are any
+func (c *StandingsCache) Get(leaderboardID uuid.UUID, version string) ([]byte, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	data, err := c.client.HGet(ctx, c.key(leaderboardID), version).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			log.Printf("redis: failed to read standings of leaderboard %s: %v", leaderboardID, err)
+		}
+		return nil, false
+	}
+	return data, true
+}
+
+// Set caches the standings of the leaderboard at the version, replacing those of any other version
+func (c *StandingsCache) Set(leaderboardID uuid.UUID, version string, data []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	key := c.key(leaderboardID)
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, version, data)
+		pipe.Expire(ctx, key, c.ttl)
+		return nil
+	})
+	if err != nil {
+		log.Printf("redis: failed to cache standings of leaderboard %s: %v", leaderboardID, err)
+	}
+}
+
+// readsKey is the sorted set counting each leaderboard's standings reads on the UTC day
+func (c *StandingsCache) readsKey(day time.Time) string {
+	return c.keyPrefix + ":reads:" + day.UTC().Format(time.DateOnly)
+}
+
+// RecordRead counts a read of the leaderboard's standings towards today's reads
+func (c *StandingsCache) RecordRead(leaderboardID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	key := c.readsKey(time.Now())
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZIncrBy(ctx, key, 1, leaderboardID.String())
+		pipe.Expire(ctx, key, (standingsReadDays+1)*24*time.Hour)
+		return nil
+	})
+	if err != nil {
+		log.Printf("redis: failed to count a read of leaderboard %s: %v", leaderboardID, err)
+	}
+}
+
+// MostRead returns up to limit leaderboards whose standings were read the most over the last standingsReadDays days,
+// most read first
+func (c *StandingsCache) MostRead(limit int) ([]uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	now := time.Now()
+	keys := make([]string, standingsReadDays)
+	for i := range keys {
+		keys[i] = c.readsKey(now.AddDate(0, 0, -i))
+	}
+	reads, err := c.client.ZUnionWithScores(ctx, redis.ZStore{Keys: keys, Aggregate: "SUM"}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// ZUNION returns the boards least read first
+	leaderboardIDs := make([]uuid.UUID, 0, min(limit, len(reads)))
+	for i := len(reads) - 1; i >= 0 && len(leaderboardIDs) < limit; i-- {
+		member, _ := reads[i].Member.(string)
+		if leaderboardID, err := uuid.Parse(member); err == nil {
+			leaderboardIDs = append(leaderboardIDs, leaderboardID)
+		}
+	}
+	return leaderboardIDs, nil
+}
+
+// Invalidate drops whatever is cached for the leaderboard
+func (c *StandingsCache) Invalidate(leaderboardID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	if err := c.client.Del(ctx, c.key(leaderboardID)).Err(); err != nil {
+		log.Printf("redis: failed to invalidate standings of leaderboard %s: %v", leaderboardID, err)
+	}
+}
+
+// Publish drops the cached standings of the leaderboard whenever the ranking engine writes one of its entries.
+// Other changes, such as metric values or renamed participants, move the board's version on instead.
+func (c *StandingsCache) Publish(event Event) {
+	if event.LeaderboardID == nil {
+		return
+	}
+	switch event.Type {
+	case enums.EntryCreated, enums.EntryUpdated, enums.EntryDeleted, enums.EntryRankChanged:
+		c.Invalidate(*event.LeaderboardID)
+	}
+}
+
+type cachedStandingsService struct {
+	StandingsService
+	leaderboardRepo repositories.LeaderboardRepository
+	cache           *StandingsCache
+}
+
+// WithStandingsCache returns a standings service that serves public leaderboards from the standings cache, or the
+// service itself when caching is disabled. Cached standings are keyed by the leaderboard and its version, the last
+// time anything shown in them changed, so they are never served once stale.
+func WithStandingsCache(service StandingsService, leaderboardRepo repositories.LeaderboardRepository) StandingsService {
+	if standingsCache == nil {
+		return service
+	}
+	return &cachedStandingsService{
+		StandingsService: service,
+		leaderboardRepo:  leaderboardRepo,
+		cache:            standingsCache,
+	}
+}
+
+func (s *cachedStandingsService) ListStandings(ctx context.Context, leaderboardID uuid.UUID) ([]Standing, error) {
+	// Turning the cache off at runtime only stops reads. Cached standings are keyed by the board's version, so none
+	// are served stale once it is turned back on
+	if !FeatureEnabled(enums.FeatureStandingsCache) {
+		return s.StandingsService.ListStandings(ctx, leaderboardID)
+	}
+	leaderboard, err := s.leaderboardRepo.FindByID(ctx, leaderboardID)
+	if err != nil || leaderboard.VisibilityScope != enums.Public {
+		return s.StandingsService.ListStandings(ctx, leaderboardID)
+	}
+	lastModified, err := s.leaderboardRepo.LastModified(ctx, leaderboardID)
+	if err != nil {
+		return s.StandingsService.ListStandings(ctx, leaderboardID)
+	}
+	version := strconv.FormatInt(lastModified.UnixMicro(), 10)
+	s.cache.RecordRead(leaderboardID)
+
+	if data, ok := s.cache.Get(leaderboardID, version); ok {
+		var standings []Standing
+		if err := json.Unmarshal(data, &standings); err == nil {
+			return standings, nil
+		}
+	}
+	return s.refresh(ctx, leaderboardID, version)
+}
+
+// refresh builds the leaderboard's standings and caches them at the version
+func (s *cachedStandingsService) refresh(ctx context.Context, leaderboardID uuid.UUID, version string) ([]Standing, error) {
+	standings, err := s.StandingsService.ListStandings(ctx, leaderboardID)
+	if err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(standings); err == nil {
+		s.cache.Set(leaderboardID, version, data)
+	}
+	return standings, nil
+}
+
+// warm caches the standings of a public leaderboard unless they are already cached at its current version, without
+// counting it as a read. Boards deleted or made private since they were read are skipped.
+func (s *cachedStandingsService) warm(ctx context.Context, leaderboardID uuid.UUID) error {
+	leaderboard, err := s.leaderboardRepo.FindByID(ctx, leaderboardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if leaderboard.VisibilityScope != enums.Public {
+		return nil
+	}
+	lastModified, err := s.leaderboardRepo.LastModified(ctx, leaderboardID)
+	if err != nil {
+		return err
+	}
+	version := strconv.FormatInt(lastModified.UnixMicro(), 10)
+
+	if _, ok := s.cache.Get(leaderboardID, version); ok {
+		return nil
+	}
+	_, err = s.refresh(ctx, leaderboardID, version)
+	return err
+}
+
+// WarmStandingsCache caches the current standings of up to limit of the most-read public leaderboards, so the first
+// requests after a deploy are answered from Redis instead of all aggregating in Postgres at once. It does nothing
+// while caching is disabled.
+func WarmStandingsCache(service StandingsService, leaderboardRepo repositories.LeaderboardRepository, limit int) {
+	if standingsCache == nil || limit <= 0 {
+		return
+	}
+	leaderboardIDs, err := standingsCache.MostRead(limit)
+	if err != nil {
+		log.Printf("standings cache warm-up: failed to find the most-read leaderboards: %v", err)
+		return
+	}
+
+	cached := &cachedStandingsService{
+		StandingsService: service,
+		leaderboardRepo:  leaderboardRepo,
+		cache:            standingsCache,
+	}
+	start := time.Now()
+	failed := recomputeLeaderboards("standings cache warm-up", leaderboardIDs, func(leaderboardID uuid.UUID) error {
+		return cached.warm(context.Background(), leaderboardID)
+	})
+	log.Printf("standings cache warm-up: warmed %d of the most-read leaderboards in %s", len(leaderboardIDs)-len(failed),
+		time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Formats a standings export can be written in
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+	ExportFormatXLSX = "xlsx"
+)
+
+// ExportFormats lists every export format, the first being the default
+var ExportFormats = []string{ExportFormatCSV, ExportFormatJSON, ExportFormatXLSX}
+
+// StandingsExport is a leaderboard's standings flattened for reporting, live or from a snapshot
+type StandingsExport struct {
+	LeaderboardID   uuid.UUID   `json:"leaderboard_id"`
+	LeaderboardName string      `json:"leaderboard_name"`
+	SnapshotID      *uuid.UUID  `json:"snapshot_id,omitempty"` // Unset for the live standings
+	TakenAt         time.Time   `json:"taken_at"`
+	Metrics         []string    `json:"metrics"` // Names of the metrics broken down in every row, in column order
+	Rows            []ExportRow `json:"rows"`
+}
+
+// ExportRow is one standing of an export. Metrics holds one value per name in StandingsExport.Metrics.
+type ExportRow struct {
+	Rank            int            `json:"rank"`
+	ParticipantID   uuid.UUID      `json:"participant_id"`
+	ParticipantName string         `json:"participant_name"`
+	DisplayName     string         `json:"display_name"`
+	Score           float64        `json:"score"`
+	Metrics         []ExportMetric `json:"metrics"`
+}
+
+// ExportMetric is what one metric contributed to an exported standing
+type ExportMetric struct {
+	MetricID      uuid.UUID `json:"metric_id"`
+	Value         float64   `json:"value"`
+	Weight        float64   `json:"weight"`
+	WeightedValue float64   `json:"weighted_value"`
+}
+
+type StandingsExportService interface {
+	// ExportStandings flattens the leaderboard's live standings, or those of one of its snapshots when snapshotID is set
+	ExportStandings(ctx context.Context, leaderboardID uuid.UUID, snapshotID *uuid.UUID) (*StandingsExport, error)
+}
+
+type standingsExportService struct {
+	leaderboardRepo  repositories.LeaderboardRepository
+	standingsService StandingsService
+	snapshotService  LeaderboardSnapshotService
+}
+
+func NewStandingsExportService(leaderboardRepo repositories.LeaderboardRepository,
+	standingsService StandingsService,
+	snapshotService LeaderboardSnapshotService) StandingsExportService {
+	return &standingsExportService{
+		leaderboardRepo:  leaderboardRepo,
+		standingsService: standingsService,
+		snapshotService:  snapshotService,
+	}
+}
+
+func (s *standingsExportService) ExportStandings(ctx context.Context, leaderboardID uuid.UUID, snapshotID *uuid.UUID) (*StandingsExport, error) {
+	leaderboard, err := s.leaderboardRepo.FindByID(ctx, leaderboardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("leaderboard not found")
+		}
+		return nil, err
+	}
+
+	export := &StandingsExport{
+		LeaderboardID:   leaderboard.ID,
+		LeaderboardName: leaderboard.Name,
+		Rows:            []ExportRow{},
+	}
+	var columns exportColumns
+
+	if snapshotID != nil {
+		snapshot, err := s.snapshotService.GetSnapshot(ctx, leaderboardID, *snapshotID)
+		if err != nil {
+			return nil, err
+		}
+		export.SnapshotID = &snapshot.ID
+		export.TakenAt = snapshot.TakenAt
+
+		for _, entry := range snapshot.Entries {
+			row := newExportRow(entry.Rank, entry.ParticipantID, entry.Participant, entry.Score)
+			for _, metric := range entry.Metrics {
+				columns.add(metric.MetricID, metric.MetricName)
+				row.Metrics = append(row.Metrics, ExportMetric{
+					MetricID:      metric.MetricID,
+					Value:         metric.Value,
+					Weight:        metric.Weight,
+					WeightedValue: metric.WeightedValue,
+				})
+			}
+			export.Rows = append(export.Rows, row)
+		}
+	} else {
+		standings, err := s.standingsService.ListStandings(ctx, leaderboardID)
+		if err != nil {
+			return nil, err
+		}
+		export.TakenAt = time.Now().UTC()
+
+		for _, standing := range standings {
+			row := newExportRow(standing.Rank, standing.ParticipantID, standing.Participant, standing.Score)
+			for _, metric := range standing.Metrics {
+				columns.add(metric.MetricID, metric.MetricName)
+				row.Metrics = append(row.Metrics, ExportMetric{
+					MetricID:      metric.MetricID,
+					Value:         metric.Aggregate.Value,
+					Weight:        metric.Weight,
+					WeightedValue: metric.WeightedValue,
+				})
+			}
+			export.Rows = append(export.Rows, row)
+		}
+	}
+
+	// Every row gets a value for every metric, in the same order, so the breakdown lines up in columns
+	export.Metrics = append([]string{}, columns.names...)
+	for i := range export.Rows {
+		byID := make(map[uuid.UUID]ExportMetric, len(export.Rows[i].Metrics))
+		for _, metric := range export.Rows[i].Metrics {
+			byID[metric.MetricID] = metric
+		}
+		export.Rows[i].Metrics = make([]ExportMetric, 0, len(columns.ids))
+		for _, metricID := range columns.ids {
+			metric, ok := byID[metricID]
+			if !ok {
+				metric = ExportMetric{MetricID: metricID}
+			}
+			export.Rows[i].Metrics = append(export.Rows[i].Metrics, metric)
+		}
+	}
+	return export, nil
+}
+
+// exportColumns collects the metrics seen across the rows in the order they first appear
+type exportColumns struct {
+	ids   []uuid.UUID
+	names []string
+}
+
+func (c *exportColumns) add(metricID uuid.UUID, name string) {
+	if slices.Contains(c.ids, metricID) {
+		return
+	}
+	c.ids = append(c.ids, metricID)
+	c.names = append(c.names, name)
+}
+
+// newExportRow starts a row for a participant, which is nil when it has been deleted since
+func newExportRow(rank int, participantID uuid.UUID, participant *models.Participant, score float64) ExportRow {
+	row := ExportRow{
+		Rank:          rank,
+		ParticipantID: participantID,
+		Score:         score,
+	}
+	if participant != nil {
+		row.ParticipantName = participant.Name
+		row.DisplayName = participant.DisplayName
+	}
+	return row
+}
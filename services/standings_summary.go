@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+)
+
+// DefaultStandingsSummaryInterval is how often changed leaderboards have their standings summary rebuilt
+const DefaultStandingsSummaryInterval = 10 * time.Second
+
+// StandingsSummary maintains the summary table of each participant's aggregated metric values per leaderboard, so
+// standings are read from it instead of aggregating raw metric values on every request.
+//
+// Writes mark the leaderboards they touch and a background job rebuilds them on every tick. A summary is only read
+// while it was built from the board's current version; until it is rebuilt, standings are aggregated as before.
+type StandingsSummary struct {
+	summaryRepo     repositories.StandingSummaryRepository
+	leaderboardRepo repositories.LeaderboardRepository
+
+	mu             sync.Mutex
+	staleBoards    map[uuid.UUID]struct{}
+	changedMetrics map[uuid.UUID]struct{} // Metrics with new values, resolved to their summarized boards on the next tick
+}
+
+// Global summary used by the standings service, nil while the summary is disabled
+var standingsSummary *StandingsSummary
+
+func NewStandingsSummary(summaryRepo repositories.StandingSummaryRepository,
+	leaderboardRepo repositories.LeaderboardRepository) *StandingsSummary {
+	return &StandingsSummary{
+		summaryRepo:     summaryRepo,
+		leaderboardRepo: leaderboardRepo,
+		staleBoards:     make(map[uuid.UUID]struct{}),
+		changedMetrics:  make(map[uuid.UUID]struct{}),
+	}
+}
+
+// EnableStandingsSummary makes standings read aggregates from the summary
+func EnableStandingsSummary(summary *StandingsSummary) {
+	standingsSummary = summary
+}
+
+// Publish implements EventPublisher and marks the leaderboards whose aggregates an entry or metric value change affects
+func (s *StandingsSummary) Publish(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch event.Type {
+	case enums.EntryCreated, enums.EntryDeleted:
+		if event.LeaderboardID != nil {
+			s.staleBoards[*event.LeaderboardID] = struct{}{}
+		}
+	case enums.MetricValueCreated, enums.MetricValueUpdated, enums.MetricValueDeleted:
+		if value, ok := event.Data.(*models.MetricValue); ok && value != nil {
+			s.changedMetrics[value.MetricID] = struct{}{}
+		}
+	}
+}
+
+// Run rebuilds the summaries of the marked leaderboards every interval. It never returns.
+func (s *StandingsSummary) Run(interval time.Duration) {
+	ctx := context.Background()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.refresh(ctx)
+	}
+}
+
+func (s *StandingsSummary) refresh(ctx context.Context) {
+	s.mu.Lock()
+	boards, metrics := s.staleBoards, s.changedMetrics
+	s.staleBoards, s.changedMetrics = make(map[uuid.UUID]struct{}), make(map[uuid.UUID]struct{})
+	s.mu.Unlock()
+
+	if len(metrics) > 0 {
+		metricIDs := make([]uuid.UUID, 0, len(metrics))
+		for metricID := range metrics {
+			metricIDs = append(metricIDs, metricID)
+		}
+		leaderboardIDs, err := s.summaryRepo.FindSummarizedByMetricIDs(ctx, metricIDs)
+		if err != nil {
+			log.Printf("standings summary: failed to find leaderboards using changed metrics: %v", err)
+		}
+		for _, leaderboardID := range leaderboardIDs {
+			boards[leaderboardID] = struct{}{}
+		}
+	}
+
+	leaderboardIDs := make([]uuid.UUID, 0, len(boards))
+	for leaderboardID := range boards {
+		leaderboardIDs = append(leaderboardIDs, leaderboardID)
+	}
+	// Boards that fail are rebuilt again on the next tick
+	for _, leaderboardID := range recomputeLeaderboards("standings summary", leaderboardIDs, func(leaderboardID uuid.UUID) error {
+		return s.summaryRepo.Rebuild(ctx, leaderboardID)
+	}) {
+		s.markStale(leaderboardID)
+	}
+}
+
+func (s *StandingsSummary) markStale(leaderboardID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.staleBoards[leaderboardID] = struct{}{}
+}
+
+// aggregates returns the participants' aggregates from the leaderboard's summary, reporting false when the summary
+// is missing or out of date, in which case the board is rebuilt on the next tick
+func (s *StandingsSummary) aggregates(ctx context.Context, leaderboardID uuid.UUID, participantIDs []uuid.UUID) ([]repositories.ParticipantMetricAggregate, bool) {
+	version, err := s.leaderboardRepo.LastModified(ctx, leaderboardID)
+	if err != nil {
+		return nil, false
+	}
+	aggregates, ok, err := s.summaryRepo.FindAggregates(ctx, leaderboardID, version, participantIDs)
+	if err != nil {
+		log.Printf("standings summary: failed to read leaderboard %s: %v", leaderboardID, err)
+		return nil, false
+	}
+	if !ok {
+		s.markStale(leaderboardID)
+	}
+	return aggregates, ok
+}
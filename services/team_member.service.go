@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TeamParticipantType is the participant type that can have members
+const TeamParticipantType = "team"
+
+type TeamMemberService interface {
+	// AddMember adds the participant to the team from joinedAt, or from now when nil
+	AddMember(ctx context.Context, teamID, memberID uuid.UUID, joinedAt *time.Time) (*models.TeamMember, error)
+	// RemoveMember ends the participant's current membership at leftAt, or now when nil
+	RemoveMember(ctx context.Context, teamID, memberID uuid.UUID, leftAt *time.Time) (*models.TeamMember, error)
+	// ListRoster returns the members active at the given time, or the full membership history when at is nil
+	ListRoster(ctx context.Context, teamID uuid.UUID, at *time.Time) ([]models.TeamMember, error)
+}
+
+type teamMemberService struct {
+	repo            repositories.TeamMemberRepository
+	participantRepo repositories.ParticipantRepository
+}
+
+func NewTeamMemberService(repo repositories.TeamMemberRepository,
+	participantRepo repositories.ParticipantRepository) TeamMemberService {
+	return &teamMemberService{
+		repo:            repo,
+		participantRepo: participantRepo,
+	}
+}
+
+func (s *teamMemberService) AddMember(ctx context.Context, teamID, memberID uuid.UUID, joinedAt *time.Time) (*models.TeamMember, error) {
+	if err := s.verifyTeam(ctx, teamID); err != nil {
+		return nil, err
+	}
+
+	if teamID == memberID {
+		return nil, errors.New("team cannot be a member of itself")
+	}
+
+	member, err := s.participantRepo.FindByID(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("member not found")
+		}
+		return nil, err
+	}
+	if member.Type == TeamParticipantType {
+		return nil, errors.New("teams cannot be members of a team")
+	}
+
+	if _, err := s.repo.FindCurrent(ctx, teamID, memberID); err == nil {
+		return nil, errors.New("member already on team")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	joined := time.Now().UTC()
+	if joinedAt != nil {
+		joined = *joinedAt
+	}
+
+	// A rejoining member's new stint cannot overlap their earlier ones
+	history, err := s.repo.FindByTeamID(ctx, teamID, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, past := range history {
+		if past.MemberID == memberID && past.LeftAt != nil && past.LeftAt.After(joined) {
+			return nil, errors.New("joined_at overlaps a previous membership")
+		}
+	}
+
+	teamMember := models.TeamMember{
+		TeamID:   teamID,
+		MemberID: memberID,
+		JoinedAt: joined,
+	}
+
+	err = s.repo.Create(ctx, &teamMember)
+	if err != nil {
+		return nil, err
+	}
+	teamMember.Member = member
+
+	return &teamMember, nil
+}
+
+func (s *teamMemberService) RemoveMember(ctx context.Context, teamID, memberID uuid.UUID, leftAt *time.Time) (*models.TeamMember, error) {
+	if err := s.verifyTeam(ctx, teamID); err != nil {
+		return nil, err
+	}
+
+	teamMember, err := s.repo.FindCurrent(ctx, teamID, memberID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("member not on team")
+		}
+		return nil, err
+	}
+
+	left := time.Now().UTC()
+	if leftAt != nil {
+		left = *leftAt
+	}
+	if !left.After(teamMember.JoinedAt) {
+		return nil, errors.New("left_at must be after joined_at")
+	}
+	teamMember.LeftAt = &left
+
+	err = s.repo.Update(ctx, teamMember)
+	if err != nil {
+		return nil, err
+	}
+
+	return teamMember, nil
+}
+
+func (s *teamMemberService) ListRoster(ctx context.Context, teamID uuid.UUID, at *time.Time) ([]models.TeamMember, error) {
+	if err := s.verifyTeam(ctx, teamID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByTeamID(ctx, teamID, at)
+}
+
+func (s *teamMemberService) verifyTeam(ctx context.Context, teamID uuid.UUID) error {
+	team, err := s.participantRepo.FindByID(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("team not found")
+		}
+		return err
+	}
+	if team.Type != TeamParticipantType {
+		return errors.New("participant is not a team")
+	}
+	return nil
+}
@@ -0,0 +1,389 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Serialises recomputes per leaderboard so concurrent metric values cannot interleave their entry writes
+var teamScoreLocks sync.Map
+
+// TeamScoreService keeps team leaderboards in sync with the metric values of the teams' members.
+//
+// A member's score is the weighted sum of their values for each of the board's metrics, aggregated with the
+// metric's aggregation type. Only values recorded inside the board's date range while the member was on the
+// team count. Member scores are combined per the board's TeamScoreAggregation: sum, average over the members
+// that scored, or the sum of the best TeamScoreTopK member scores.
+type TeamScoreService interface {
+	EventPublisher
+	// RecomputeLeaderboard rebuilds the team entries of a team leaderboard and re-ranks it
+	RecomputeLeaderboard(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardEntry, error)
+}
+
+type teamScoreService struct {
+	leaderboardRepo repositories.LeaderboardRepository
+	metricRepo      repositories.MetricRepository
+	valueRepo       repositories.MetricValueRepository
+	teamMemberRepo  repositories.TeamMemberRepository
+	entryRepo       repositories.LeaderboardEntryRepository
+	participantRepo repositories.ParticipantRepository
+}
+
+func NewTeamScoreService(leaderboardRepo repositories.LeaderboardRepository,
+	metricRepo repositories.MetricRepository,
+	valueRepo repositories.MetricValueRepository,
+	teamMemberRepo repositories.TeamMemberRepository,
+	entryRepo repositories.LeaderboardEntryRepository,
+	participantRepo repositories.ParticipantRepository) TeamScoreService {
+	return &teamScoreService{
+		leaderboardRepo: leaderboardRepo,
+		metricRepo:      metricRepo,
+		valueRepo:       valueRepo,
+		teamMemberRepo:  teamMemberRepo,
+		entryRepo:       entryRepo,
+		participantRepo: participantRepo,
+	}
+}
+
+// Publish implements EventPublisher and recomputes the affected team boards when a team member's metric value changes
+func (s *teamScoreService) Publish(event Event) {
+	switch event.Type {
+	case enums.MetricValueCreated, enums.MetricValueUpdated, enums.MetricValueDeleted:
+	default:
+		return
+	}
+	value, ok := event.Data.(*models.MetricValue)
+	if !ok || value == nil {
+		return
+	}
+
+	go s.recomputeForValue(context.Background(), *value)
+}
+
+func (s *teamScoreService) recomputeForValue(ctx context.Context, value models.MetricValue) {
+	memberships, err := s.teamMemberRepo.FindByMemberID(ctx, value.ParticipantID)
+	if err != nil {
+		log.Printf("team scores: failed to load teams of participant %s: %v", value.ParticipantID, err)
+		return
+	}
+	if len(memberships) == 0 {
+		return
+	}
+
+	leaderboards, err := s.leaderboardRepo.FindActiveByMetricID(ctx, value.MetricID, enums.Team)
+	if err != nil {
+		log.Printf("team scores: failed to load team leaderboards for metric %s: %v", value.MetricID, err)
+		return
+	}
+
+	byID := make(map[uuid.UUID]*models.Leaderboard, len(leaderboards))
+	leaderboardIDs := make([]uuid.UUID, len(leaderboards))
+	for i := range leaderboards {
+		byID[leaderboards[i].ID] = &leaderboards[i]
+		leaderboardIDs[i] = leaderboards[i].ID
+	}
+	recomputeLeaderboards("team scores", leaderboardIDs, func(leaderboardID uuid.UUID) error {
+		_, err := s.recompute(ctx, byID[leaderboardID])
+		return err
+	})
+}
+
+func (s *teamScoreService) RecomputeLeaderboard(ctx context.Context, leaderboardID uuid.UUID) ([]models.LeaderboardEntry, error) {
+	leaderboard, err := s.leaderboardRepo.FindWithMetrics(ctx, leaderboardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("leaderboard not found")
+		}
+		return nil, err
+	}
+	if leaderboard.Type != enums.Team {
+		return nil, errors.New("leaderboard is not a team leaderboard")
+	}
+	if leaderboard.HasEnded(time.Now()) {
+		return nil, errors.New("leaderboard has ended")
+	}
+
+	return s.recompute(ctx, leaderboard)
+}
+
+func (s *teamScoreService) recompute(ctx context.Context, leaderboard *models.Leaderboard) ([]models.LeaderboardEntry, error) {
+	lock, _ := teamScoreLocks.LoadOrStore(leaderboard.ID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	weights := make(map[uuid.UUID]float64, len(leaderboard.Metrics))
+	metricIDs := make([]uuid.UUID, 0, len(leaderboard.Metrics))
+	for _, lm := range leaderboard.Metrics {
+		if _, seen := weights[lm.MetricID]; !seen {
+			metricIDs = append(metricIDs, lm.MetricID)
+		}
+		weights[lm.MetricID] += lm.Weight
+	}
+
+	metrics, err := s.metricRepo.FindByIDs(ctx, metricIDs)
+	if err != nil {
+		return nil, err
+	}
+	aggregations := make(map[uuid.UUID]enums.AggregationType, len(metrics))
+	for _, metric := range metrics {
+		aggregations[metric.ID] = metric.AggregationType
+	}
+
+	entries, err := s.entryRepo.FindByLeaderboardID(ctx, leaderboard.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Score every team already on the board plus any team whose members have scored
+	teamIDs, err := s.teamMemberRepo.FindTeamIDsWithValues(ctx, metricIDs, leaderboard.StartDate, leaderboard.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	onBoard := make(map[uuid.UUID]bool, len(entries))
+	for _, entry := range entries {
+		teamIDs = append(teamIDs, entry.ParticipantID)
+		onBoard[entry.ParticipantID] = true
+	}
+
+	scores := make(map[uuid.UUID]float64)
+	newTeams := make(map[uuid.UUID]*models.Participant) // Teams not on the board yet, whose entries show their profile
+	for _, teamID := range teamIDs {
+		if _, done := scores[teamID]; done {
+			continue
+		}
+		// Teams outside a group leaderboard's group are not ranked on it
+		if leaderboard.GroupID != nil {
+			inGroup, err := isInGroup(ctx, s.participantRepo, teamID, *leaderboard.GroupID)
+			if err != nil {
+				return nil, err
+			}
+			if !inGroup {
+				continue
+			}
+		}
+		// Inactive teams that are not on the board yet are not added to it
+		if !onBoard[teamID] {
+			team, err := s.participantRepo.FindByID(ctx, teamID)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					continue
+				}
+				return nil, err
+			}
+			if !team.IsActive {
+				continue
+			}
+			newTeams[teamID] = team
+		}
+		score, isTeam, err := s.teamScore(ctx, leaderboard, teamID, metricIDs, weights, aggregations)
+		if err != nil {
+			return nil, err
+		}
+		if isTeam {
+			scores[teamID] = score
+		}
+	}
+
+	now := time.Now()
+	previous := make(map[uuid.UUID]models.LeaderboardEntry, len(entries))
+	for i := range entries {
+		previous[entries[i].ID] = entries[i]
+		if score, ok := scores[entries[i].ParticipantID]; ok {
+			entries[i].Score = score
+			delete(scores, entries[i].ParticipantID)
+		}
+	}
+	for teamID, score := range scores {
+		entries = append(entries, models.LeaderboardEntry{
+			LeaderboardID:   leaderboard.ID,
+			ParticipantID:   teamID,
+			Score:           score,
+			ParticipantName: newTeams[teamID].ShownName(),
+			AvatarURL:       newTeams[teamID].AvatarURL,
+		})
+	}
+
+	// Write the new scores, then re-rank the whole board in one statement rather than saving every entry whose rank moved
+	for i := range entries {
+		entry := &entries[i]
+		before, existed := previous[entry.ID]
+		if !existed {
+			entry.LastUpdated = now
+			if err := s.entryRepo.Create(ctx, entry); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if before.Score == entry.Score {
+			continue
+		}
+		entry.LastUpdated = now
+		if err := s.entryRepo.Update(ctx, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	// Inactive teams keep their entry and score but drop out of the ranking until they are reactivated
+	updates, err := s.entryRepo.BulkUpdateRanks(ctx, leaderboard.ID, leaderboard.SortOrder, now)
+	if err != nil {
+		return nil, err
+	}
+	ranks := make(map[uuid.UUID]int, len(updates))
+	for _, update := range updates {
+		ranks[update.EntryID] = update.Rank
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		if rank, ok := ranks[entry.ID]; ok {
+			entry.Rank = rank
+			entry.LastUpdated = now
+		}
+
+		before, existed := previous[entry.ID]
+		if !existed {
+			publishEvent(enums.EntryCreated, &entry.LeaderboardID, entry)
+			continue
+		}
+		if before.Score == entry.Score && before.Rank == entry.Rank {
+			continue
+		}
+		publishEvent(enums.EntryUpdated, &entry.LeaderboardID, entry)
+		if entry.Rank != before.Rank {
+			publishEvent(enums.EntryRankChanged, &entry.LeaderboardID, RankChange{
+				Entry:        entry,
+				PreviousRank: before.Rank,
+				CurrentRank:  entry.Rank,
+			})
+		}
+	}
+
+	// Ranked entries best first, then the unranked ones
+	sort.SliceStable(entries, func(i, j int) bool {
+		return rankedBefore(entries[i].Rank, entries[j].Rank)
+	})
+	return entries, nil
+}
+
+// teamScore rolls up the members' scores for one team. It reports false when the participant has never had members.
+func (s *teamScoreService) teamScore(ctx context.Context, leaderboard *models.Leaderboard, teamID uuid.UUID, metricIDs []uuid.UUID,
+	weights map[uuid.UUID]float64, aggregations map[uuid.UUID]enums.AggregationType) (float64, bool, error) {
+
+	memberships, err := s.teamMemberRepo.FindByTeamID(ctx, teamID, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(memberships) == 0 {
+		return 0, false, nil
+	}
+
+	stints := make(map[uuid.UUID][]models.TeamMember)
+	memberIDs := make([]uuid.UUID, 0, len(memberships))
+	for _, membership := range memberships {
+		if _, seen := stints[membership.MemberID]; !seen {
+			memberIDs = append(memberIDs, membership.MemberID)
+		}
+		stints[membership.MemberID] = append(stints[membership.MemberID], membership)
+	}
+
+	parts, err := s.valueRepo.FindPartsForParticipants(ctx, metricIDs, memberIDs, leaderboard.StartDate, leaderboard.EndDate)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// Aggregate each member's values per metric, keeping only those recorded while on the team. Rollups never
+	// straddle a membership, so one is on the team when its first value is.
+	memberAggregates := make(map[uuid.UUID]map[uuid.UUID]*repositories.MetricValueAggregate)
+	for _, part := range parts {
+		if !onTeamAt(stints[part.ParticipantID], part.FirstRecordedAt) {
+			continue
+		}
+		if memberAggregates[part.ParticipantID] == nil {
+			memberAggregates[part.ParticipantID] = make(map[uuid.UUID]*repositories.MetricValueAggregate)
+		}
+		aggregate, ok := memberAggregates[part.ParticipantID][part.MetricID]
+		if !ok {
+			aggregate = &repositories.MetricValueAggregate{MetricID: part.MetricID}
+			memberAggregates[part.ParticipantID][part.MetricID] = aggregate
+		}
+		addPart(aggregate, part)
+	}
+
+	memberScores := make([]float64, 0, len(memberAggregates))
+	for _, byMetric := range memberAggregates {
+		var score float64
+		for metricID, aggregate := range byMetric {
+			score += weights[metricID] * toMetricAggregate(aggregations[metricID], *aggregate).Value
+		}
+		memberScores = append(memberScores, score)
+	}
+
+	return combineMemberScores(memberScores, leaderboard), true, nil
+}
+
+func onTeamAt(stints []models.TeamMember, at time.Time) bool {
+	for i := range stints {
+		if stints[i].IsActiveAt(at) {
+			return true
+		}
+	}
+	return false
+}
+
+// addPart folds a part into an aggregate, given parts ordered oldest first
+func addPart(aggregate *repositories.MetricValueAggregate, part repositories.MetricValuePart) {
+	if aggregate.Count == 0 {
+		aggregate.Min, aggregate.Max = part.Min, part.Max
+	} else {
+		aggregate.Min, aggregate.Max = min(aggregate.Min, part.Min), max(aggregate.Max, part.Max)
+	}
+	aggregate.Count += part.Count
+	aggregate.Sum += part.Sum
+	aggregate.Average = aggregate.Sum / float64(aggregate.Count)
+	aggregate.LastValue = part.LastValue
+	aggregate.LastBoolValue, aggregate.LastStringValue = part.LastBoolValue, part.LastStringValue
+	aggregate.LastRecordedAt = &part.LastRecordedAt
+}
+
+// combineMemberScores applies the leaderboard's team aggregation, where the best scores follow its sort order
+func combineMemberScores(scores []float64, leaderboard *models.Leaderboard) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+
+	switch leaderboard.TeamScoreAggregation {
+	case enums.TeamScoreAverage:
+		var total float64
+		for _, score := range scores {
+			total += score
+		}
+		return total / float64(len(scores))
+	case enums.TeamScoreTopK:
+		sort.Float64s(scores)
+		if leaderboard.SortOrder == enums.Descending {
+			sort.Sort(sort.Reverse(sort.Float64Slice(scores)))
+		}
+		var total float64
+		for i := 0; i < len(scores) && i < leaderboard.TeamScoreTopK; i++ {
+			total += scores[i]
+		}
+		return total
+	default:
+		var total float64
+		for _, score := range scores {
+			total += score
+		}
+		return total
+	}
+}
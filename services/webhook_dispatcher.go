@@ -0,0 +1,134 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+
+	"github.com/google/uuid"
+)
+
+const (
+	webhookMaxAttempts = 3
+	webhookTimeout     = 10 * time.Second
+)
+
+// WebhookDelivery is the JSON body posted to subscribers
+type WebhookDelivery struct {
+	ID uuid.UUID `json:"id"`
+	Event
+}
+
+// WebhookDispatcher delivers domain events to webhook subscriptions
+type WebhookDispatcher interface {
+	EventPublisher
+	Deliver(ctx context.Context, subscription *models.WebhookSubscription, event Event) (int, error)
+}
+
+type webhookDispatcher struct {
+	repo   repositories.WebhookSubscriptionRepository
+	client *http.Client
+}
+
+func NewWebhookDispatcher(repo repositories.WebhookSubscriptionRepository) WebhookDispatcher {
+	return &webhookDispatcher{
+		repo:   repo,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Publish fans the event out to all matching subscriptions in the background
+func (d *webhookDispatcher) Publish(event Event) {
+	go func() {
+		ctx := context.Background()
+		subscriptions, err := d.repo.FindActive(ctx)
+		if err != nil {
+			log.Printf("webhooks: failed to load subscriptions for %s: %v", event.Type, err)
+			return
+		}
+
+		for i := range subscriptions {
+			subscription := subscriptions[i]
+			if !subscription.Matches(string(event.Type), event.LeaderboardID) {
+				continue
+			}
+			go d.deliverWithRetry(ctx, &subscription, event)
+		}
+	}()
+}
+
+// deliverWithRetry attempts a delivery a few times with a linear backoff
+func (d *webhookDispatcher) deliverWithRetry(ctx context.Context, subscription *models.WebhookSubscription, event Event) {
+	for attempt := 1; attempt <= webhookMaxAttempts; attempt++ {
+		status, err := d.Deliver(ctx, subscription, event)
+		if err == nil {
+			return
+		}
+
+		log.Printf("webhooks: delivery of %s to %s failed (attempt %d/%d, status %d): %v",
+			event.Type, subscription.TargetURL, attempt, webhookMaxAttempts, status, err)
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+}
+
+// Deliver posts a single signed event to the subscription and records the outcome
+func (d *webhookDispatcher) Deliver(ctx context.Context, subscription *models.WebhookSubscription, event Event) (int, error) {
+	body, err := json.Marshal(WebhookDelivery{ID: uuid.New(), Event: event})
+	if err != nil {
+		return 0, err
+	}
+
+	status, err := d.post(subscription, string(event.Type), body)
+
+	errMsg := ""
+	if err != nil {
+		errMsg = err.Error()
+	}
+	if recordErr := d.repo.RecordDelivery(ctx, subscription.ID, status, errMsg, time.Now()); recordErr != nil {
+		log.Printf("webhooks: failed to record delivery for subscription %s: %v", subscription.ID, recordErr)
+	}
+
+	return status, err
+}
+
+func (d *webhookDispatcher) post(subscription *models.WebhookSubscription, eventType string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, subscription.TargetURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "leaderboard-service-webhooks")
+	req.Header.Set("X-Webhook-Event", eventType)
+	req.Header.Set("X-Webhook-Signature", "sha256="+SignWebhookPayload(subscription.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload computes the hex encoded HMAC-SHA256 of the payload so receivers can verify it
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
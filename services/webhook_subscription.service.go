@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"leaderboard-service/enums"
+	"leaderboard-service/models"
+	"leaderboard-service/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WebhookSubscriptionService interface {
+	CreateSubscription(ctx context.Context, targetURL, secret string, eventTypes []string, leaderboardID *uuid.UUID, isActive bool) (*models.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, id uuid.UUID, targetURL, secret *string, eventTypes *[]string, leaderboardID *uuid.UUID,
+		clearLeaderboard bool, isActive *bool) (*models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	TestSubscription(ctx context.Context, id uuid.UUID) (int, error)
+}
+
+type webhookSubscriptionService struct {
+	repo            repositories.WebhookSubscriptionRepository
+	leaderboardRepo repositories.LeaderboardRepository
+	dispatcher      WebhookDispatcher
+}
+
+func NewWebhookSubscriptionService(repo repositories.WebhookSubscriptionRepository,
+	leaderboardRepo repositories.LeaderboardRepository,
+	dispatcher WebhookDispatcher) WebhookSubscriptionService {
+	return &webhookSubscriptionService{
+		repo:            repo,
+		leaderboardRepo: leaderboardRepo,
+		dispatcher:      dispatcher,
+	}
+}
+
+func (s *webhookSubscriptionService) CreateSubscription(ctx context.Context, targetURL, secret string, eventTypes []string,
+	leaderboardID *uuid.UUID, isActive bool) (*models.WebhookSubscription, error) {
+
+	if leaderboardID != nil {
+		if err := s.verifyLeaderboardExists(ctx, *leaderboardID); err != nil {
+			return nil, err
+		}
+	}
+
+	subscription := models.WebhookSubscription{
+		TargetURL:     targetURL,
+		Secret:        secret,
+		EventTypes:    eventTypes,
+		LeaderboardID: leaderboardID,
+		IsActive:      isActive,
+	}
+
+	err := s.repo.Create(ctx, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (s *webhookSubscriptionService) GetSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	subscription, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook subscription not found")
+		}
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *webhookSubscriptionService) ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *webhookSubscriptionService) UpdateSubscription(ctx context.Context, id uuid.UUID, targetURL, secret *string, eventTypes *[]string,
+	leaderboardID *uuid.UUID, clearLeaderboard bool, isActive *bool) (*models.WebhookSubscription, error) {
+
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the updates to the subscription
+	if targetURL != nil {
+		subscription.TargetURL = *targetURL
+	}
+	if secret != nil {
+		subscription.Secret = *secret
+	}
+	if eventTypes != nil {
+		subscription.EventTypes = *eventTypes
+	}
+	if clearLeaderboard {
+		subscription.LeaderboardID = nil
+	} else if leaderboardID != nil {
+		if err := s.verifyLeaderboardExists(ctx, *leaderboardID); err != nil {
+			return nil, err
+		}
+		subscription.LeaderboardID = leaderboardID
+	}
+	if isActive != nil {
+		subscription.IsActive = *isActive
+	}
+
+	err = s.repo.Update(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (s *webhookSubscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// TestSubscription synchronously delivers a test event so integrators can verify their endpoint
+func (s *webhookSubscriptionService) TestSubscription(ctx context.Context, id uuid.UUID) (int, error) {
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	event := Event{
+		Type:          enums.WebhookTest,
+		LeaderboardID: subscription.LeaderboardID,
+		OccurredAt:    time.Now().UTC(),
+		Data: map[string]interface{}{
+			"subscription_id": subscription.ID,
+			"message":         "This is a test delivery from the leaderboard service",
+		},
+	}
+
+	return s.dispatcher.Deliver(ctx, subscription, event)
+}
+
+func (s *webhookSubscriptionService) verifyLeaderboardExists(ctx context.Context, leaderboardID uuid.UUID) error {
+	_, err := s.leaderboardRepo.FindByID(ctx, leaderboardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("leaderboard not found")
+		}
+		return err
+	}
+	return nil
+}
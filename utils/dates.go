@@ -4,23 +4,19 @@ import (
 	"time"
 )
 
+// ValidateDates parses the RFC3339 start and end dates that are set into UTC, leaving unset or malformed ones nil
 func ValidateDates(startDate *string, endDate *string) (*time.Time, *time.Time) {
-	if startDate != nil {
-		parsedDate, err := time.Parse(time.RFC3339, *startDate)
-		if err != nil {
-			return nil, nil
-		}
-		return &parsedDate, nil
-	}
+	return parseDate(startDate), parseDate(endDate)
+}
 
-	if endDate != nil {
-		parsedDate, err := time.Parse(time.RFC3339, *endDate)
-		if err != nil {
-			return nil, nil
-		}
-		return &parsedDate, nil
+func parseDate(date *string) *time.Time {
+	if date == nil {
+		return nil
 	}
-
-	return nil, nil
+	parsedDate, err := time.Parse(time.RFC3339Nano, *date)
+	if err != nil {
+		return nil
+	}
+	parsedDate = parsedDate.UTC()
+	return &parsedDate
 }
-
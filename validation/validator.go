@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -16,6 +18,7 @@ func init() {
 
 	// Register custom validations
 	Validate.RegisterValidation("custom_timeframe", validateCustomTimeframe)
+	Validate.RegisterValidation("rfc3339", validateRFC3339)
 
 	// Use JSON tag names in error messages
 	Validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
@@ -27,34 +30,95 @@ func init() {
 	})
 }
 
-// FormatValidationErrors converts validation errors into a user-friendly error message
+// FieldError describes why a single request field failed validation
+type FieldError struct {
+	Field   string `json:"field" example:"name"`
+	Rule    string `json:"rule" example:"required"`
+	Message string `json:"message" example:"name is required"`
+}
+
+// Errors lists every field that failed validation. Its message joins the field messages so callers that only
+// print the error keep working.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldError := range e {
+		messages[i] = fieldError.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// FormatValidationErrors converts validation errors into user-friendly messages, one per field
 func FormatValidationErrors(validationErrors validator.ValidationErrors) error {
-	var errMsgs []string
+	fieldErrors := make(Errors, 0, len(validationErrors))
 	for _, err := range validationErrors {
-		switch err.Tag() {
+		// Optional pointer fields use len=0|<tag> so an empty string clears them
+		rule := strings.TrimPrefix(err.Tag(), "len=0|")
+
+		var message string
+		switch rule {
 		case "required":
-			errMsgs = append(errMsgs, fmt.Sprintf("%s is required", err.Field()))
+			message = fmt.Sprintf("%s is required", err.Field())
+		case "required_without":
+			message = fmt.Sprintf("%s is required when %s is not provided", err.Field(), toSnakeCase(err.Param()))
+		case "required_without_all":
+			params := strings.Fields(err.Param())
+			for i, param := range params {
+				params[i] = toSnakeCase(param)
+			}
+			message = fmt.Sprintf("%s is required when none of %s is provided", err.Field(), strings.Join(params, ", "))
 		case "min":
-			errMsgs = append(errMsgs, fmt.Sprintf("%s must be at least %s", err.Field(), err.Param()))
+			message = fmt.Sprintf("%s must be at least %s", err.Field(), err.Param())
 		case "max":
-			errMsgs = append(errMsgs, fmt.Sprintf("%s must be at most %s", err.Field(), err.Param()))
+			message = fmt.Sprintf("%s must be at most %s", err.Field(), err.Param())
 		case "oneof":
-			errMsgs = append(errMsgs, fmt.Sprintf("%s must be one of: %s", err.Field(), err.Param()))
+			message = fmt.Sprintf("%s must be one of: %s", err.Field(), err.Param())
 		case "datetime":
-			errMsgs = append(errMsgs, fmt.Sprintf("%s must be a valid date-time in format %s", err.Field(), err.Param()))
+			message = fmt.Sprintf("%s must be a valid date-time in format %s", err.Field(), err.Param())
+		case "rfc3339":
+			message = fmt.Sprintf("%s must be an RFC3339 date-time, e.g. 2023-01-01T00:00:00Z or 2023-01-01T02:00:00+02:00", err.Field())
 		case "custom_timeframe":
-			errMsgs = append(errMsgs, "When time_frame is 'custom', both start_date and end_date must be provided")
+			message = "When time_frame is 'custom', both start_date and end_date must be provided"
 		case "email":
-			errMsgs = append(errMsgs, fmt.Sprintf("%s must be a valid email address", err.Field()))
+			message = fmt.Sprintf("%s must be a valid email address", err.Field())
 		case "url":
-			errMsgs = append(errMsgs, fmt.Sprintf("%s must be a valid URL", err.Field()))
+			message = fmt.Sprintf("%s must be a valid URL", err.Field())
+		case "http_url":
+			message = fmt.Sprintf("%s must be a valid http or https URL", err.Field())
+		case "iso3166_1_alpha2":
+			message = fmt.Sprintf("%s must be an uppercase ISO 3166-1 alpha-2 country code", err.Field())
 		case "uuid":
-			errMsgs = append(errMsgs, fmt.Sprintf("%s must be a valid UUID", err.Field()))
+			message = fmt.Sprintf("%s must be a valid UUID", err.Field())
 		default:
-			errMsgs = append(errMsgs, fmt.Sprintf("%s failed validation: %v", err.Field(), err.Tag()))
+			message = fmt.Sprintf("%s failed validation: %v", err.Field(), err.Tag())
 		}
+
+		fieldErrors = append(fieldErrors, FieldError{Field: err.Field(), Rule: rule, Message: message})
+	}
+	return fieldErrors
+}
+
+// toSnakeCase turns a struct field name referenced by a tag parameter into its JSON-style name
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
 	}
-	return fmt.Errorf("%s", strings.Join(errMsgs, "; "))
+	return b.String()
+}
+
+// validateRFC3339 accepts any RFC3339 date-time, with or without fractional seconds and with a Z or numeric offset
+func validateRFC3339(fl validator.FieldLevel) bool {
+	_, err := time.Parse(time.RFC3339Nano, fl.Field().String())
+	return err == nil
 }
 
 // Custom validation function to check that when TimeFrame is 'custom', both StartDate and EndDate are provided
@@ -10,8 +10,8 @@ import (
 type TestStruct struct {
 	Name      string  `json:"name" validate:"required"`
 	TimeFrame string  `json:"time_frame" validate:"required,oneof=daily weekly monthly yearly all_time custom,custom_timeframe"`
-	StartDate *string `json:"start_date,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z"`
-	EndDate   *string `json:"end_date,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z"`
+	StartDate *string `json:"start_date,omitempty" validate:"omitempty,rfc3339"`
+	EndDate   *string `json:"end_date,omitempty" validate:"omitempty,rfc3339"`
 }
 
 func TestCustomTimeframeValidation(t *testing.T) {
@@ -69,6 +69,16 @@ func TestCustomTimeframeValidation(t *testing.T) {
 			},
 			expectedValid: false,
 		},
+		{
+			name: "Custom timeframe with offset and fractional seconds",
+			input: TestStruct{
+				Name:      "Test Custom Leaderboard",
+				TimeFrame: "custom",
+				StartDate: stringPtr("2023-01-01T00:00:00+02:00"),
+				EndDate:   stringPtr("2023-01-31T23:59:59.999-05:00"),
+			},
+			expectedValid: true,
+		},
 		{
 			name: "Invalid datetime format",
 			input: TestStruct{